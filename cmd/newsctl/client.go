@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/pkg/response"
+)
+
+// apiClient calls the News Feed System REST API
+type apiClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newAPIClient(baseURL string, timeout time.Duration) *apiClient {
+	return &apiClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// apiError is an error response returned by the API
+type apiError struct {
+	status int
+	info   *response.ErrorInfo
+}
+
+func (e *apiError) Error() string {
+	if e.info == nil {
+		return fmt.Sprintf("request failed with status %d", e.status)
+	}
+
+	msg := fmt.Sprintf("%s: %s", e.info.Code, e.info.Message)
+	if e.info.Details != "" {
+		msg += " (" + e.info.Details + ")"
+	}
+	return msg
+}
+
+// do sends a request and returns the "data" member of a successful response.
+// Responses without a body, such as 204, return nil.
+func (c *apiClient) do(ctx context.Context, method, path string, query url.Values, body any) (json.RawMessage, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return nil, &apiError{status: resp.StatusCode}
+		}
+		return nil, nil
+	}
+
+	var envelope struct {
+		Success bool                `json:"success"`
+		Data    json.RawMessage     `json:"data"`
+		Message string              `json:"message"`
+		Error   *response.ErrorInfo `json:"error"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("unexpected response (status %d): %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest || !envelope.Success {
+		return nil, &apiError{status: resp.StatusCode, info: envelope.Error}
+	}
+
+	return envelope.Data, nil
+}
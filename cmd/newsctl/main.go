@@ -0,0 +1,277 @@
+// Command newsctl is an operator CLI for the News Feed System. It triggers
+// aggregations, lists and deletes posts and inspects the scheduler through the
+// REST API, and runs database migrations directly against PostgreSQL.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const usage = `Usage: newsctl [flags] <command> [arguments]
+
+Commands:
+  aggregate [all|headlines|categories|sources]
+                         Trigger a news aggregation (default: all)
+  posts list [-page N] [-limit N] [-category C] [-source S] [-search Q] [-sort latest|popular]
+                         List posts
+  posts get <id>         Show a post
+  posts delete <id>      Delete a post
+  scheduler status       Show scheduler status
+  scheduler jobs         List scheduled jobs
+  scheduler trigger <job>
+                         Trigger a scheduled job
+  migrate up             Apply pending migrations
+  migrate down [N]       Roll back the last N migrations (default: 1)
+  migrate version        Show the current schema version
+
+Flags:
+`
+
+// cli holds the global options shared by every command
+type cli struct {
+	api           *apiClient
+	databaseURL   string
+	migrationsDir string
+	out           io.Writer
+}
+
+func main() {
+	flags := flag.NewFlagSet("newsctl", flag.ExitOnError)
+	apiURL := flags.String("api", envOr("NEWSCTL_API_URL", "http://localhost:8080/api/v1"), "base URL of the API (env NEWSCTL_API_URL)")
+	databaseURL := flags.String("database", os.Getenv("DATABASE_URL"), "PostgreSQL URL used by migrate (env DATABASE_URL)")
+	migrationsDir := flags.String("migrations", "migrations", "directory containing migration files")
+	timeout := flags.Duration("timeout", 5*time.Minute, "request timeout; aggregations can take minutes")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	c := &cli{
+		api:           newAPIClient(*apiURL, *timeout),
+		databaseURL:   *databaseURL,
+		migrationsDir: *migrationsDir,
+		out:           os.Stdout,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if err := c.run(ctx, flags.Arg(0), flags.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "newsctl: %v\n", err)
+		if errors.Is(err, errUsage) {
+			flags.Usage()
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+var errUsage = errors.New("invalid usage")
+
+// run dispatches a command
+func (c *cli) run(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "aggregate":
+		return c.aggregate(ctx, args)
+	case "posts":
+		return c.posts(ctx, args)
+	case "scheduler":
+		return c.scheduler(ctx, args)
+	case "migrate":
+		return c.migrate(ctx, args)
+	}
+
+	return fmt.Errorf("%w: unknown command %q", errUsage, command)
+}
+
+func (c *cli) aggregate(ctx context.Context, args []string) error {
+	paths := map[string]string{
+		"all":        "/aggregation/trigger",
+		"headlines":  "/aggregation/trigger/headlines",
+		"categories": "/aggregation/trigger/categories",
+		"sources":    "/aggregation/trigger/sources",
+	}
+
+	kind := "all"
+	if len(args) > 0 {
+		kind = args[0]
+	}
+
+	path, ok := paths[kind]
+	if !ok {
+		return fmt.Errorf("%w: unknown aggregation %q", errUsage, kind)
+	}
+
+	return c.call(ctx, http.MethodPost, path, nil)
+}
+
+func (c *cli) posts(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: posts needs a subcommand", errUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		flags := flag.NewFlagSet("posts list", flag.ContinueOnError)
+		page := flags.Int("page", 1, "page number")
+		limit := flags.Int("limit", 10, "posts per page")
+		category := flags.String("category", "", "filter by category")
+		source := flags.String("source", "", "filter by source")
+		search := flags.String("search", "", "filter by text")
+		sort := flags.String("sort", "", "sort order: latest or popular")
+		if err := flags.Parse(args[1:]); err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(*page))
+		query.Set("limit", strconv.Itoa(*limit))
+		setIfNotEmpty(query, "category", *category)
+		setIfNotEmpty(query, "source", *source)
+		setIfNotEmpty(query, "search", *search)
+		setIfNotEmpty(query, "sort", *sort)
+
+		return c.call(ctx, http.MethodGet, "/posts", query)
+	case "get", "delete":
+		if len(args) != 2 {
+			return fmt.Errorf("%w: posts %s needs a post ID", errUsage, args[0])
+		}
+		id, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: invalid post ID %q", errUsage, args[1])
+		}
+
+		if args[0] == "get" {
+			return c.call(ctx, http.MethodGet, fmt.Sprintf("/posts/%d", id), nil)
+		}
+
+		if err := c.call(ctx, http.MethodDelete, fmt.Sprintf("/posts/%d", id), nil); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "deleted post %d\n", id)
+		return nil
+	}
+
+	return fmt.Errorf("%w: unknown posts subcommand %q", errUsage, args[0])
+}
+
+func (c *cli) scheduler(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: scheduler needs a subcommand", errUsage)
+	}
+
+	switch args[0] {
+	case "status":
+		return c.call(ctx, http.MethodGet, "/scheduler/status", nil)
+	case "jobs":
+		return c.call(ctx, http.MethodGet, "/scheduler/jobs", nil)
+	case "trigger":
+		if len(args) != 2 {
+			return fmt.Errorf("%w: scheduler trigger needs a job name", errUsage)
+		}
+		return c.call(ctx, http.MethodPost, "/scheduler/jobs/"+url.PathEscape(args[1])+"/trigger", nil)
+	}
+
+	return fmt.Errorf("%w: unknown scheduler subcommand %q", errUsage, args[0])
+}
+
+func (c *cli) migrate(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: migrate needs a subcommand", errUsage)
+	}
+	if c.databaseURL == "" {
+		return errors.New("migrate needs -database or DATABASE_URL")
+	}
+
+	migrations, err := loadMigrations(c.migrationsDir)
+	if err != nil {
+		return err
+	}
+
+	conn, err := pgx.Connect(ctx, c.databaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	m := &migrator{conn: conn, migrations: migrations, out: c.out}
+
+	switch args[0] {
+	case "up":
+		return m.up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("%w: invalid step count %q", errUsage, args[1])
+			}
+		}
+		return m.down(ctx, steps)
+	case "version":
+		version, dirty, err := m.version(ctx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			fmt.Fprintf(c.out, "%d (dirty)\n", version)
+		} else {
+			fmt.Fprintln(c.out, version)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: unknown migrate subcommand %q", errUsage, args[0])
+}
+
+// call sends an API request and prints the response data as indented JSON
+func (c *cli) call(ctx context.Context, method, path string, query url.Values) error {
+	data, err := c.api.do(ctx, method, path, query, nil)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	var pretty any
+	if err := json.Unmarshal(data, &pretty); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	encoder := json.NewEncoder(c.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(pretty)
+}
+
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		query.Set(key, value)
+	}
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
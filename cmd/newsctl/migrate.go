@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// The migration runner keeps its state in the same schema_migrations table as
+// the golang-migrate CLI used by the Makefile, so the two can be mixed freely.
+const (
+	queryCreateSchemaMigrations = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT NOT NULL PRIMARY KEY,
+			dirty BOOLEAN NOT NULL
+		)`
+	querySchemaVersion    = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	queryClearVersion     = `TRUNCATE schema_migrations`
+	queryInsertVersion    = `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`
+	queryMarkVersionClean = `UPDATE schema_migrations SET dirty = false`
+)
+
+var errDirtyDatabase = errors.New("database is dirty: fix the failed migration by hand, then force its version with the migrate CLI")
+
+// migration is one numbered pair of up/down SQL files
+type migration struct {
+	version  int64
+	name     string
+	upFile   string
+	downFile string
+}
+
+// loadMigrations reads NNNNNN_name.{up,down}.sql files from dir, ordered by version
+func loadMigrations(dir string) ([]migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*migration)
+	for _, entry := range entries {
+		name := entry.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		prefix, rest, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration file %q has no version prefix", name)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration file %q has an invalid version: %w", name, err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &migration{version: version, name: strings.TrimSuffix(rest, "."+direction+".sql")}
+			byVersion[version] = m
+		}
+
+		path := filepath.Join(dir, name)
+		if direction == "up" {
+			m.upFile = path
+		} else {
+			m.downFile = path
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+
+	return migrations, nil
+}
+
+// migrator applies migrations to a database
+type migrator struct {
+	conn       *pgx.Conn
+	migrations []migration
+	out        io.Writer
+}
+
+// version returns the current schema version, 0 when no migration has run
+func (m *migrator) version(ctx context.Context) (int64, bool, error) {
+	if _, err := m.conn.Exec(ctx, queryCreateSchemaMigrations); err != nil {
+		return 0, false, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var version int64
+	var dirty bool
+	err := m.conn.QueryRow(ctx, querySchemaVersion).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+// up applies every migration newer than the current version
+func (m *migrator) up(ctx context.Context) error {
+	current, dirty, err := m.version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return errDirtyDatabase
+	}
+
+	applied := 0
+	for _, mig := range m.migrations {
+		if mig.version <= current {
+			continue
+		}
+		if mig.upFile == "" {
+			return fmt.Errorf("migration %d has no up file", mig.version)
+		}
+
+		if err := m.apply(ctx, mig.upFile, mig.version); err != nil {
+			return fmt.Errorf("migration %d_%s failed: %w", mig.version, mig.name, err)
+		}
+		fmt.Fprintf(m.out, "applied %d_%s\n", mig.version, mig.name)
+		applied++
+	}
+
+	if applied == 0 {
+		fmt.Fprintln(m.out, "no change")
+	}
+
+	return nil
+}
+
+// down rolls back the given number of migrations, newest first
+func (m *migrator) down(ctx context.Context, steps int) error {
+	current, dirty, err := m.version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return errDirtyDatabase
+	}
+
+	for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+		mig := m.migrations[i]
+		if mig.version > current {
+			continue
+		}
+		if mig.downFile == "" {
+			return fmt.Errorf("migration %d has no down file", mig.version)
+		}
+
+		// The version left behind is the previous migration, or none at all
+		var previous int64
+		if i > 0 {
+			previous = m.migrations[i-1].version
+		}
+
+		if err := m.apply(ctx, mig.downFile, previous); err != nil {
+			return fmt.Errorf("rollback of %d_%s failed: %w", mig.version, mig.name, err)
+		}
+		fmt.Fprintf(m.out, "rolled back %d_%s\n", mig.version, mig.name)
+		current = previous
+		steps--
+	}
+
+	return nil
+}
+
+// apply runs one migration file and records version as the new schema version.
+// The version is marked dirty while the file runs so a failure is not mistaken
+// for a clean state, matching golang-migrate.
+func (m *migrator) apply(ctx context.Context, file string, version int64) error {
+	statements, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	if err := m.setVersion(ctx, version, true); err != nil {
+		return err
+	}
+
+	if _, err := m.conn.Exec(ctx, string(statements)); err != nil {
+		return err
+	}
+
+	if version == 0 {
+		_, err = m.conn.Exec(ctx, queryClearVersion)
+	} else {
+		_, err = m.conn.Exec(ctx, queryMarkVersionClean)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return nil
+}
+
+// setVersion replaces the recorded schema version
+func (m *migrator) setVersion(ctx context.Context, version int64, dirty bool) error {
+	tx, err := m.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, queryClearVersion); err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+	if _, err := tx.Exec(ctx, queryInsertVersion, version, dirty); err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
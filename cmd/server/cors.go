@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// originList holds the CORS allowed origins so they can be swapped when the
+// configuration is reloaded
+type originList struct {
+	origins atomic.Pointer[[]string]
+}
+
+func newOriginList(origins []string) *originList {
+	list := &originList{}
+	list.set(origins)
+	return list
+}
+
+// set replaces the allowed origins
+func (l *originList) set(origins []string) {
+	l.origins.Store(&origins)
+}
+
+// allow reports whether origin is permitted. An entry of "*" allows any
+// origin and a single "*" inside an entry matches any run of characters,
+// as in "https://*.example.com".
+func (l *originList) allow(origin string) (bool, error) {
+	for _, allowed := range *l.origins.Load() {
+		if allowed == "*" || allowed == origin {
+			return true, nil
+		}
+
+		prefix, suffix, found := strings.Cut(allowed, "*")
+		if found && len(origin) >= len(prefix)+len(suffix) &&
+			strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
+	"strings"
 	"syscall"
 	"time"
 
@@ -13,10 +18,12 @@ import (
 	"github.com/amirzre/news-feed-system/internal/bootstrap"
 	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/handler"
+	"github.com/amirzre/news-feed-system/internal/model"
 	"github.com/amirzre/news-feed-system/internal/repository"
 	"github.com/amirzre/news-feed-system/internal/service"
 	"github.com/amirzre/news-feed-system/pkg/database"
 	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
 	"github.com/amirzre/news-feed-system/pkg/validator"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -28,18 +35,36 @@ const (
 )
 
 func main() {
+	validateConfig := flag.Bool("validate-config", false, "validate the configuration and exit")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	flag.Parse()
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Printf("Failed to load configuration: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
 		os.Exit(1)
 	}
 
+	if *validateConfig || *printConfig {
+		if *printConfig {
+			if err := writeConfig(os.Stdout, cfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to print configuration: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if *validateConfig {
+			fmt.Fprintln(os.Stderr, "Configuration is valid")
+		}
+		return
+	}
+
 	// Initialize logger
 	log := logger.New(cfg)
+	defer log.Close()
 
 	// Initialize database connection
-	db, err := database.NewDatabase(cfg)
+	db, err := database.NewDatabase(cfg, log)
 	if err != nil {
 		log.Error("Failed to initialize database connections", "error", err.Error())
 		os.Exit(1)
@@ -55,7 +80,52 @@ func main() {
 		os.Exit(1)
 	}
 
-	log.Info("Database connections established successfully")
+	if err := repository.ValidateStatements(ctx, db.PG); err != nil {
+		log.Error("Repository statement validation failed", "error", err.Error())
+		os.Exit(1)
+	}
+
+	// Row-level security is what keeps tenants apart, and roles that bypass
+	// it would see every tenant's rows
+	if cfg.Tenant.Enabled {
+		if err := repository.CheckTenantIsolation(ctx, db.PG); err != nil {
+			log.Error("Tenant isolation check failed", "error", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	// A build running against a schema it was not written for fails in ways
+	// that are hard to trace, so compare migration versions before serving
+	schemaStatus := func(ctx context.Context) (*model.SchemaStatus, error) {
+		return repository.GetSchemaStatus(ctx, db.PG, cfg.Schema.AllowNewer)
+	}
+
+	schema, err := schemaStatus(ctx)
+	if err != nil {
+		log.Error("Schema version check failed", "error", err.Error())
+		os.Exit(1)
+	}
+
+	readOnly := false
+	if !schema.Compatible {
+		attrs := []any{"expected_version", schema.ExpectedVersion, "current_version", schema.CurrentVersion, "dirty", schema.Dirty}
+
+		switch cfg.Schema.OnMismatch {
+		case config.SchemaMismatchRefuse:
+			log.Error("Database schema is incompatible with this build, refusing to start", attrs...)
+			os.Exit(1)
+		case config.SchemaMismatchReadOnly:
+			log.Warn("Database schema is incompatible with this build, starting read-only", attrs...)
+			readOnly = true
+		default:
+			log.Warn("Database schema is incompatible with this build", attrs...)
+		}
+	}
+
+	log.Info("Database connections established successfully",
+		"replicas", db.Replicas.Len(),
+		"healthy_replicas", db.Replicas.HealthyCount(),
+	)
 
 	// Initialize Echo server
 	e := echo.New()
@@ -64,13 +134,34 @@ func main() {
 	e.HideBanner = true
 	e.HidePort = true
 	e.Validator = validator.NewValidator()
+	e.HTTPErrorHandler = response.HTTPErrorHandler
+
+	// Believe X-Forwarded-For only from trusted proxies; without any, clients
+	// are found by the address of their connection
+	e.IPExtractor = handler.ClientIPExtractor(cfg.Access)
 
 	// Add middleware
-	e.Use(middleware.Recover())
+	e.Use(handler.Recover(log))
+
+	if cfg.Security.Enabled {
+		e.Use(handler.SecurityHeaders(cfg.Security))
+	}
+
+	if cfg.Server.BodyLimitEnabled {
+		e.Use(middleware.BodyLimit(cfg.Server.BodyLimit))
+	}
+
+	if cfg.Server.CompressionEnabled {
+		e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
+			Level:     cfg.Server.CompressionLevel,
+			MinLength: cfg.Server.CompressionMinLength,
+		}))
+	}
 
 	// Configure CORS with config values
+	corsOrigins := newOriginList(cfg.CORS.AllowOrigins)
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins:     cfg.CORS.AllowOrigins,
+		AllowOriginFunc:  corsOrigins.allow,
 		AllowMethods:     cfg.CORS.AllowMethods,
 		AllowHeaders:     cfg.CORS.AllowHeaders,
 		ExposeHeaders:    cfg.CORS.ExposeHeaders,
@@ -78,53 +169,108 @@ func main() {
 		MaxAge:           cfg.CORS.MaxAge,
 	}))
 
-	// Custom logging middleware
-	e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
-		LogURI:     true,
-		LogStatus:  true,
-		LogMethod:  true,
-		LogLatency: true,
-		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
-			log.LogHTTPRequest(
-				v.Method,
-				v.URI,
-				v.Status,
-				v.Latency.Milliseconds(),
-			)
-			return nil
-		},
-	}))
+	// Tag each request with an ID, taken from X-Request-ID when the client sends one
+	e.Use(middleware.RequestID())
+
+	// Access log
+	e.Use(handler.RequestLogger(cfg.RequestLog, log))
+
+	// Keep the admin, scheduler and aggregation routes to allowed networks
+	if len(cfg.Access.AdminAllowlist) > 0 {
+		e.Use(handler.AdminAllowlist(cfg.Access, log))
+	}
+
+	// Tag bot traffic, left out of analytics, and rate limit it
+	if cfg.Bot.Enabled {
+		e.Use(handler.BotDetection(cfg.Bot, log))
+	}
+
+	// Cancel handlers that overrun their timeout with a 504
+	e.Use(handler.Timeout(cfg.Server))
 
 	// Swagger metadata
 	docs.SwaggerInfo.Title = appName
 	docs.SwaggerInfo.Description = "API documentation for the News Feed System."
 	docs.SwaggerInfo.Version = appVersion
-	docs.SwaggerInfo.BasePath = "/api/v1"
+	docs.SwaggerInfo.BasePath = handler.APIBasePath
 	docs.SwaggerInfo.Host = fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 	docs.SwaggerInfo.Schemes = []string{"http", "https"}
 
 	// Initialize repository and service layer
-	repo := repository.New(db.PG, db.Redis, log, cfg.Cache.TTL)
+	repo := repository.New(db.PG, db.Replicas, db.Redis, log, cfg.Cache)
 	svc := service.New(repo, log, cfg)
 	h := handler.New(svc, log)
 
+	// Answer only reads on a schema this build was not written for
+	if readOnly {
+		svc.ReadOnly.Enable("database schema is incompatible with this build")
+	}
+
 	// register jobs
-	bootstrap.SetupAggregationJobs(svc.Scheduler, svc.Aggregator, log)
+	bootstrap.SetupAggregationJobs(svc.Scheduler, svc.Aggregator, svc.Tenant, cfg.Scheduler, log)
+	bootstrap.SetupEnrichmentJobs(svc.Scheduler, svc.Content, svc.Tenant, cfg.ContentFetch, cfg.Scheduler, log)
+	bootstrap.SetupTopicJobs(svc.Scheduler, svc.Topic, svc.Tenant, cfg.Topic, cfg.Scheduler, log)
+	bootstrap.SetupStatsJobs(svc.Scheduler, svc.Stats, svc.Tenant, cfg.Stats, cfg.Scheduler, log)
+	bootstrap.SetupPartitionJobs(svc.Scheduler, svc.Partition, cfg.Partition, cfg.Scheduler, log)
+	bootstrap.SetupChangeJobs(svc.Scheduler, svc.Change, svc.Tenant, cfg.Changes, cfg.Scheduler, log)
+	bootstrap.SetupURLFilterJobs(svc.Scheduler, svc.Post, svc.Tenant, cfg.Cache, cfg.Scheduler, log)
+	bootstrap.SetupURLBackfillJobs(svc.Scheduler, svc.Post, svc.Tenant, cfg.URLBackfill, cfg.Scheduler, log)
+	bootstrap.SetupSyndicationJobs(svc.Scheduler, svc.Syndication, svc.Tenant, cfg.Syndication, cfg.Scheduler, log)
+
+	// Apply reloaded settings to the jobs, the CORS middleware and slow query logging
+	bootstrap.SetupConfigReload(svc.Config, svc.Scheduler, log)
+	svc.Config.OnReload(func(next *config.Config) {
+		corsOrigins.set(next.CORS.AllowOrigins)
+		db.Queries.SetSlowThreshold(next.DatabasePool.SlowQueryThreshold)
+	})
+
+	// Turn away mutating requests in read-only mode
+	e.Use(handler.ReadOnly(svc.ReadOnly))
+
+	// Resolve the tenant of each request before it reaches a handler
+	if cfg.Tenant.Enabled {
+		e.Use(handler.TenantMiddleware(svc.Tenant, cfg.Tenant, log))
+	}
 
 	// Setup routes
 	handler.SetupRoutes(e, h)
 
+	e.GET(handler.ReadyPath, handler.Ready(schemaStatus, cfg.Schema, svc.ReadOnly.Enabled))
+
+	if cfg.Server.MetricsEnabled {
+		e.GET(handler.MetricsPath, handler.Metrics(db.PoolStats, db.Queries.Stats))
+	}
+
+	server := newHTTPServer(cfg, e)
+
 	// Start server in a goroutine
 	go func() {
-		addr := cfg.ServerAddr()
 		log.LogStartup(appName, appVersion, cfg.Server.Port)
 
-		if err := e.Start(addr); err != nil && err != http.ErrServerClosed {
+		var err error
+		if cfg.TLSEnabled() {
+			err = server.ListenAndServeTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+
+		if err != nil && err != http.ErrServerClosed {
 			log.Error("Server failed to start", "error", err.Error())
 			os.Exit(1)
 		}
 	}()
 
+	// Reload configuration on SIGHUP
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if _, err := svc.Config.Reload(context.Background()); err != nil {
+				log.Error("Configuration reload failed, keeping current settings", "error", err.Error())
+			}
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -136,10 +282,69 @@ func main() {
 	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := e.Shutdown(ctx); err != nil {
+	if err := server.Shutdown(ctx); err != nil {
 		log.Error("Server forced to shutdown", "error", err.Error())
 		os.Exit(1)
 	}
 
 	log.Info("Server shutdown completed")
 }
+
+// newHTTPServer builds the HTTP server with the configured timeouts and protocols
+func newHTTPServer(cfg *config.Config, h http.Handler) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	if cfg.Server.HTTP2Enabled {
+		if cfg.TLSEnabled() {
+			protocols.SetHTTP2(true)
+		} else {
+			protocols.SetUnencryptedHTTP2(true)
+		}
+	}
+
+	return &http.Server{
+		Addr:              cfg.ServerAddr(),
+		Handler:           h,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		Protocols:         protocols,
+		TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+}
+
+// writeConfig prints the effective configuration, one Section.Field=value
+// line per setting, with secrets redacted
+func writeConfig(w io.Writer, cfg *config.Config) error {
+	root := reflect.ValueOf(cfg.Redacted()).Elem()
+
+	for i := range root.NumField() {
+		section := root.Field(i)
+		for j := range section.NumField() {
+			name := root.Type().Field(i).Name + "." + section.Type().Field(j).Name
+			if _, err := fmt.Fprintf(w, "%s=%s\n", name, formatConfigValue(section.Field(j))); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// formatConfigValue renders durations in their readable form and slices comma-separated
+func formatConfigValue(v reflect.Value) string {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+
+	return fmt.Sprint(v.Interface())
+}
@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/aggregation/trigger": {
+        "/admin/articles/preview": {
             "post": {
-                "description": "Trigger a complete aggregation across all categories and sources",
+                "description": "Fetch an article page through the enrichment pipeline and return the post it would be stored as, with its language and tags, whether it is flagged sensitive, already stored or refused by the source rules. Nothing is saved. The page's robots.txt and crawl delay are honoured.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,12 +25,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "aggregation"
+                    "admin"
+                ],
+                "summary": "Preview an article before importing it",
+                "parameters": [
+                    {
+                        "description": "Article URL",
+                        "name": "article",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ArticlePreviewRequest"
+                        }
+                    }
                 ],
-                "summary": "Trigger full aggregation",
                 "responses": {
-                    "201": {
-                        "description": "Aggregation result",
+                    "200": {
+                        "description": "Article preview",
                         "schema": {
                             "allOf": [
                                 {
@@ -40,7 +51,43 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.AggregationResponse"
+                                            "$ref": "#/definitions/model.ArticlePreview"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Page is disallowed by robots.txt, not HTML or untitled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
                                         }
                                     }
                                 }
@@ -48,7 +95,25 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Aggregation failed",
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "502": {
+                        "description": "Page could not be fetched",
                         "schema": {
                             "allOf": [
                                 {
@@ -68,9 +133,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/aggregation/trigger/categories": {
+        "/admin/config/reload": {
             "post": {
-                "description": "Trigger aggregation for one or more categories. If no categories provided, defaults are used.",
+                "description": "Re-read the environment and .env file and apply the runtime settings (log level, cache TTL, CORS origins, job intervals, NewsAPI key). The new configuration is validated first and nothing is applied if it is invalid. Other settings require a restart.",
                 "consumes": [
                     "application/json"
                 ],
@@ -78,22 +143,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "aggregation"
-                ],
-                "summary": "Trigger category aggregation",
-                "parameters": [
-                    {
-                        "description": "Categories payload (optional)",
-                        "name": "body",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/model.CategoryAggregationRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Reload configuration",
                 "responses": {
-                    "201": {
-                        "description": "Category aggregation result",
+                    "200": {
+                        "description": "Configuration reloaded",
                         "schema": {
                             "allOf": [
                                 {
@@ -103,15 +158,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.CategoryAggregationResponse"
+                                            "$ref": "#/definitions/model.ConfigReloadResult"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "No valid categories provided",
+                    "422": {
+                        "description": "New configuration is invalid",
                         "schema": {
                             "allOf": [
                                 {
@@ -129,7 +184,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Aggregation failed",
+                        "description": "Internal server error",
                         "schema": {
                             "allOf": [
                                 {
@@ -149,9 +204,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/aggregation/trigger/headlines": {
-            "post": {
-                "description": "Trigger aggregation for top headlines (global/top-level headlines)",
+        "/admin/dead-jobs": {
+            "get": {
+                "description": "Retrieve the queued jobs that failed on every attempt, most recent failure first, with the error of their last attempt.",
                 "consumes": [
                     "application/json"
                 ],
@@ -159,12 +214,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "aggregation"
+                    "admin"
                 ],
-                "summary": "Trigger top headlines aggregation",
+                "summary": "List dead jobs",
                 "responses": {
-                    "201": {
-                        "description": "Top headlines aggregation result",
+                    "200": {
+                        "description": "Dead jobs",
                         "schema": {
                             "allOf": [
                                 {
@@ -174,7 +229,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.AggregationResponse"
+                                            "$ref": "#/definitions/model.DeadOnceJobsResponse"
                                         }
                                     }
                                 }
@@ -182,7 +237,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Aggregation failed",
+                        "description": "Internal server error",
                         "schema": {
                             "allOf": [
                                 {
@@ -202,9 +257,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/aggregation/trigger/sources": {
-            "post": {
-                "description": "Trigger aggregation for one or more sources. If no sources provided, defaults are used.",
+        "/admin/dead-jobs/{id}": {
+            "delete": {
+                "description": "Discard a dead job without running it again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -212,40 +267,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "aggregation"
+                    "admin"
                 ],
-                "summary": "Trigger source aggregation",
+                "summary": "Delete a dead job",
                 "parameters": [
                     {
-                        "description": "Sources payload (optional)",
-                        "name": "body",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/model.SourceAggregationRequest"
-                        }
+                        "type": "string",
+                        "description": "Dead job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Source aggregation result",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.SourceAggregationResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "No valid sources provided",
+                    "404": {
+                        "description": "Dead job not found",
                         "schema": {
                             "allOf": [
                                 {
@@ -263,7 +305,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Aggregation failed",
+                        "description": "Internal server error",
                         "schema": {
                             "allOf": [
                                 {
@@ -283,9 +325,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts": {
-            "get": {
-                "description": "List posts with pagination, optional filtering by category/source and search",
+        "/admin/dead-jobs/{id}/retry": {
+            "post": {
+                "description": "Queue a dead job to run again now, with its attempts counted afresh.",
                 "consumes": [
                     "application/json"
                 ],
@@ -293,44 +335,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "admin"
                 ],
-                "summary": "List posts",
+                "summary": "Retry a dead job",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Results per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by source",
-                        "name": "source",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Dead job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of posts",
+                        "description": "Job requeued",
                         "schema": {
                             "allOf": [
                                 {
@@ -340,33 +359,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/response.PaginatedResponse"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "items": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/model.Post"
-                                                            }
-                                                        },
-                                                        "pagination": {
-                                                            "$ref": "#/definitions/response.PaginationInfo"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/model.OnceJob"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Validation error",
+                    "404": {
+                        "description": "Dead job not found",
                         "schema": {
                             "allOf": [
                                 {
@@ -402,9 +403,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new post with the provided payload",
+            }
+        },
+        "/admin/dead-letters": {
+            "get": {
+                "description": "List aggregated articles that could not be stored, most recent failure first, with the error of their last attempt",
                 "consumes": [
                     "application/json"
                 ],
@@ -412,23 +415,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "admin"
                 ],
-                "summary": "Create a new post",
+                "summary": "List dead letters",
                 "parameters": [
                     {
-                        "description": "Create Post payload",
-                        "name": "post",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreatePostParams"
-                        }
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Post created",
+                    "200": {
+                        "description": "Dead letters",
                         "schema": {
                             "allOf": [
                                 {
@@ -438,7 +444,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Post"
+                                            "$ref": "#/definitions/model.DeadLetterListResponse"
                                         }
                                     }
                                 }
@@ -446,25 +452,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/response.ErrorInfo"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict - post exists",
+                        "description": "Validation error",
                         "schema": {
                             "allOf": [
                                 {
@@ -502,9 +490,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/category/{category}": {
-            "get": {
-                "description": "List posts filtered by category",
+        "/admin/dead-letters/{id}": {
+            "delete": {
+                "description": "Drop a dead letter without storing its article",
                 "consumes": [
                     "application/json"
                 ],
@@ -512,33 +500,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "admin"
                 ],
-                "summary": "List posts by category",
+                "summary": "Discard a dead letter",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Category",
-                        "name": "category",
+                        "type": "integer",
+                        "description": "Dead letter ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Results per page",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of posts",
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid dead letter ID",
                         "schema": {
                             "allOf": [
                                 {
@@ -547,34 +529,16 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/response.PaginatedResponse"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "items": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/model.Post"
-                                                            }
-                                                        },
-                                                        "pagination": {
-                                                            "$ref": "#/definitions/response.PaginationInfo"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Validation error",
+                    "404": {
+                        "description": "Dead letter not found",
                         "schema": {
                             "allOf": [
                                 {
@@ -612,9 +576,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/search": {
-            "get": {
-                "description": "Search posts by query string with optional filters",
+        "/admin/dead-letters/{id}/retry": {
+            "post": {
+                "description": "Store a dead letter's article again. It is removed once stored, or when it turns out to be stored already; an article that fails again keeps its entry with the new error.",
                 "consumes": [
                     "application/json"
                 ],
@@ -622,45 +586,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "admin"
                 ],
-                "summary": "Search posts",
+                "summary": "Retry a dead letter",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Results per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by source",
-                        "name": "source",
-                        "in": "query"
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Search results",
+                        "description": "Article stored",
                         "schema": {
                             "allOf": [
                                 {
@@ -670,25 +610,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/response.PaginatedResponse"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "items": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/model.Post"
-                                                            }
-                                                        },
-                                                        "pagination": {
-                                                            "$ref": "#/definitions/response.PaginationInfo"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/model.DeadLetterRetryResponse"
                                         }
                                     }
                                 }
@@ -696,7 +618,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Validation error",
+                        "description": "Invalid dead letter ID",
                         "schema": {
                             "allOf": [
                                 {
@@ -713,8 +635,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Dead letter not found",
                         "schema": {
                             "allOf": [
                                 {
@@ -730,83 +652,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/posts/source/{source}": {
-            "get": {
-                "description": "List posts filtered by source",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "posts"
-                ],
-                "summary": "List posts by source",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Source",
-                        "name": "source",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Results per page",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "List of posts",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/response.PaginatedResponse"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "items": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/model.Post"
-                                                            }
-                                                        },
-                                                        "pagination": {
-                                                            "$ref": "#/definitions/response.PaginationInfo"
-                                                        }
-                                                    }
-                                                }
-                                            ]
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
-                    "400": {
-                        "description": "Validation error",
+                    "422": {
+                        "description": "Article cannot be stored",
                         "schema": {
                             "allOf": [
                                 {
@@ -844,9 +692,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/{id}": {
+        "/admin/experiments": {
             "get": {
-                "description": "Retrieve a single post by its ID",
+                "description": "List all feed ranking experiments",
                 "consumes": [
                     "application/json"
                 ],
@@ -854,21 +702,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
-                ],
-                "summary": "Get a post by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Post ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "List experiments",
                 "responses": {
                     "200": {
-                        "description": "Post retrieved",
+                        "description": "Experiments",
                         "schema": {
                             "allOf": [
                                 {
@@ -878,43 +717,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Post"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid ID",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/response.ErrorInfo"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "404": {
-                        "description": "Post not found",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/response.ErrorInfo"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Experiment"
+                                            }
                                         }
                                     }
                                 }
@@ -940,9 +746,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/experiments/{name}": {
             "put": {
-                "description": "Update a post by ID with the provided payload",
+                "description": "Define a feed ranking experiment. Variant allocations are percentages and must sum to 100.",
                 "consumes": [
                     "application/json"
                 ],
@@ -950,30 +758,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "admin"
                 ],
-                "summary": "Update a post",
+                "summary": "Create or replace an experiment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Post ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Experiment name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Post payload",
-                        "name": "post",
+                        "description": "Experiment definition",
+                        "name": "experiment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdatePostParams"
+                            "$ref": "#/definitions/model.UpsertExperimentParams"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated post",
+                        "description": "Experiment saved",
                         "schema": {
                             "allOf": [
                                 {
@@ -983,7 +791,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.Post"
+                                            "$ref": "#/definitions/model.Experiment"
                                         }
                                     }
                                 }
@@ -991,7 +799,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid experiment",
                         "schema": {
                             "allOf": [
                                 {
@@ -1008,8 +816,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Post not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "allOf": [
                                 {
@@ -1025,11 +833,59 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "allOf": [
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an experiment definition. Recorded events are kept for analysis.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete an experiment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Experiment name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Experiment deleted",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Experiment not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
                                 {
                                     "$ref": "#/definitions/response.APIResponse"
                                 },
@@ -1045,9 +901,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a post by ID",
+            }
+        },
+        "/admin/experiments/{name}/results": {
+            "get": {
+                "description": "Exposure, click and click-through totals per variant",
                 "consumes": [
                     "application/json"
                 ],
@@ -1055,27 +913,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "admin"
                 ],
-                "summary": "Delete a post",
+                "summary": "Get experiment results",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Post ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Experiment name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid ID",
+                    "200": {
+                        "description": "Experiment results",
                         "schema": {
                             "allOf": [
                                 {
@@ -1084,8 +936,8 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        "data": {
+                                            "$ref": "#/definitions/model.ExperimentResults"
                                         }
                                     }
                                 }
@@ -1093,7 +945,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Post not found",
+                        "description": "Experiment not found",
                         "schema": {
                             "allOf": [
                                 {
@@ -1131,9 +983,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/scheduler/jobs": {
+        "/admin/feed/ranking": {
             "get": {
-                "description": "Retrieve list of scheduled jobs and their statuses",
+                "description": "Retrieve the ranking weights currently applied to the ranked feed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1141,12 +993,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "scheduler"
+                    "admin"
                 ],
-                "summary": "List scheduler jobs",
+                "summary": "Get feed ranking weights",
                 "responses": {
                     "200": {
-                        "description": "Jobs list",
+                        "description": "Active ranking weights",
                         "schema": {
                             "allOf": [
                                 {
@@ -1156,25 +1008,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.JobsResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/response.ErrorInfo"
+                                            "$ref": "#/definitions/model.RankingWeights"
                                         }
                                     }
                                 }
@@ -1182,11 +1016,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/scheduler/jobs/{name}/trigger": {
-            "post": {
-                "description": "Trigger a specific job by name (acknowledges trigger; job runs according to schedule)",
+            },
+            "put": {
+                "description": "Replace the ranking weights applied to the ranked feed without restarting the server",
                 "consumes": [
                     "application/json"
                 ],
@@ -1194,21 +1026,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "scheduler"
+                    "admin"
                 ],
-                "summary": "Trigger a scheduler job",
+                "summary": "Update feed ranking weights",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Job name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Ranking weights",
+                        "name": "weights",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RankingWeights"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Trigger acknowledged",
+                        "description": "Updated ranking weights",
                         "schema": {
                             "allOf": [
                                 {
@@ -1218,7 +1052,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.JobTriggerResponse"
+                                            "$ref": "#/definitions/model.RankingWeights"
                                         }
                                     }
                                 }
@@ -1226,7 +1060,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Job name required",
+                        "description": "Invalid weights",
                         "schema": {
                             "allOf": [
                                 {
@@ -1242,9 +1076,106 @@ const docTemplate = `{
                                 }
                             ]
                         }
+                    }
+                }
+            }
+        },
+        "/admin/posts": {
+            "get": {
+                "description": "List posts including drafts and hidden posts, with the same filters as the public list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List posts in any state",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post status: draft, published, hidden or any (default)",
+                        "name": "status",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Job not found",
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by two-letter country code",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude posts flagged as sensitive",
+                        "name": "safe_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: latest (default) or popular (most reactions first)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show only the first post of each topic",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Total count: true (default), false to skip it or estimate",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add meta.counts with post counts per category and source in the requested status",
+                        "name": "include_counts",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of posts",
                         "schema": {
                             "allOf": [
                                 {
@@ -1253,16 +1184,40 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.Post"
+                                                            }
+                                                        },
+                                                        "meta": {
+                                                            "type": "object",
+                                                            "additionalProperties": {
+                                                                "$ref": "#/definitions/model.PostCounts"
+                                                            }
+                                                        },
+                                                        "pagination": {
+                                                            "$ref": "#/definitions/response.PaginationInfo"
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "409": {
-                        "description": "Job already running",
+                    "400": {
+                        "description": "Validation error",
                         "schema": {
                             "allOf": [
                                 {
@@ -1300,9 +1255,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/scheduler/status": {
-            "get": {
-                "description": "Retrieve current scheduler status including running flag and job list",
+        "/admin/posts/bulk-delete": {
+            "post": {
+                "description": "Delete every post matching the filter, given as post IDs, source, category and publication date range. A filter is required. With dry_run set nothing is deleted and the number of matching posts is returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1310,12 +1265,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "scheduler"
+                    "admin"
+                ],
+                "summary": "Bulk delete posts",
+                "parameters": [
+                    {
+                        "description": "Filter",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkDeletePostsParams"
+                        }
+                    }
                 ],
-                "summary": "Get scheduler status",
                 "responses": {
                     "200": {
-                        "description": "Scheduler status",
+                        "description": "Posts deleted",
                         "schema": {
                             "allOf": [
                                 {
@@ -1325,7 +1291,5202 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.SchedulerStatusResponse"
+                                            "$ref": "#/definitions/model.BulkPostsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/posts/bulk-update": {
+            "post": {
+                "description": "Set the status, category or sensitive flag of every post matching the filter, given as post IDs, source, category and publication date range. A filter is required. With dry_run set nothing is changed and the number of posts that would change is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Bulk update posts",
+                "parameters": [
+                    {
+                        "description": "Filter and changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkUpdatePostsParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posts updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.BulkPostsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or changes",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/posts/reprocess": {
+            "post": {
+                "description": "Send existing posts matching the filter back through the enrichment pipeline (content extraction and sensitivity classification). The run continues in the background; poll its progress with the returned run ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reprocess existing posts",
+                "parameters": [
+                    {
+                        "description": "Posts to reprocess; an empty body selects every post",
+                        "name": "filter",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReprocessParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Reprocess run started",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ReprocessRun"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "A reprocess run is already in progress",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/posts/reprocess/{id}": {
+            "get": {
+                "description": "Retrieve the progress of a reprocess run. Only recent runs are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get reprocess run progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reprocess run",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ReprocessRun"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Run not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/quarantine": {
+            "get": {
+                "description": "List articles rejected by the spam and quality filter, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List quarantined articles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quarantined articles",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.QuarantineListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/read-only": {
+            "get": {
+                "description": "Report whether this instance is in read-only mode, and why and since when",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get read-only mode",
+                "responses": {
+                    "200": {
+                        "description": "Read-only mode",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ReadOnlyStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Put this instance in read-only mode: every mutating request but this route's gets 503 READ_ONLY and scheduled jobs are paused, while reads are served as usual. Other instances are not affected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Enter read-only mode",
+                "parameters": [
+                    {
+                        "description": "Reason for entering read-only mode",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.EnableReadOnlyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Read-only mode enabled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ReadOnlyStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or reason",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Accept mutating requests on this instance again and resume the scheduled jobs read-only mode paused",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Leave read-only mode",
+                "responses": {
+                    "200": {
+                        "description": "Read-only mode disabled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ReadOnlyStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/search-analytics": {
+            "get": {
+                "description": "Most frequent search terms and terms that returned no results, to find gaps in content coverage. Terms are lower-cased with whitespace collapsed; only the first page of a search is counted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get search analytics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "Look-back window in days",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Terms per list",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search analytics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SearchAnalyticsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/source-rules": {
+            "get": {
+                "description": "List the domains and source names on the blocklist and the allowlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List source rules",
+                "responses": {
+                    "200": {
+                        "description": "Source rules",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.SourceRule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Put a domain or source name on the blocklist or the allowlist, moving it if it is already on the other list. Domains also match their subdomains and source names ignore case. With hide_existing, blocking also hides the stored posts from the domain or source.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Block or allow a domain or source",
+                "parameters": [
+                    {
+                        "description": "Source rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SaveSourceRuleParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Source rule saved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SaveSourceRuleResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid source rule",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/source-rules/{id}": {
+            "delete": {
+                "description": "Take a domain or source name off its list. Posts hidden when it was blocked stay hidden.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a source rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Source rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Source rule deleted",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid source rule ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Source rule not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tenants": {
+            "get": {
+                "description": "List every tenant, including inactive ones. NewsAPI keys are never returned; has_news_api_key tells whether one is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List tenants",
+                "responses": {
+                    "200": {
+                        "description": "Tenants",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Tenant"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tenants/{id}": {
+            "put": {
+                "description": "Create a tenant or update an existing one. Omit news_api_key to keep the current key or send an empty string to fall back to the deployment's key. A news_api_daily_quota of 0 means unlimited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create or update a tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tenant settings",
+                        "name": "tenant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpsertTenantParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tenant saved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Tenant"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tenant",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/aggregation/trigger": {
+            "post": {
+                "description": "Trigger a complete aggregation across all categories and sources",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aggregation"
+                ],
+                "summary": "Trigger full aggregation",
+                "responses": {
+                    "201": {
+                        "description": "Aggregation result",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AggregationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Aggregation failed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/aggregation/trigger/categories": {
+            "post": {
+                "description": "Trigger aggregation for one or more categories and countries. If no categories or countries are provided, defaults are used. Optional page_size, language and from/to override the NewsAPI query for this run.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aggregation"
+                ],
+                "summary": "Trigger category aggregation",
+                "parameters": [
+                    {
+                        "description": "Categories payload (optional)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.CategoryAggregationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Category aggregation result",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CategoryAggregationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "No valid categories or invalid query parameters",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Aggregation failed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/aggregation/trigger/headlines": {
+            "post": {
+                "description": "Trigger aggregation for top headlines (global/top-level headlines)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aggregation"
+                ],
+                "summary": "Trigger top headlines aggregation",
+                "responses": {
+                    "201": {
+                        "description": "Top headlines aggregation result",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AggregationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Aggregation failed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/aggregation/trigger/sources": {
+            "post": {
+                "description": "Trigger aggregation for one or more sources. If no sources provided, defaults are used. Optional page_size, language and from/to override the NewsAPI query for this run.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aggregation"
+                ],
+                "summary": "Trigger source aggregation",
+                "parameters": [
+                    {
+                        "description": "Sources payload (optional)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.SourceAggregationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Source aggregation result",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SourceAggregationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "No valid sources or invalid query parameters",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Aggregation failed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/ctr": {
+            "get": {
+                "description": "Views, clicks and click-through rate per source or category for recently created posts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get click-through rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "source",
+                        "description": "Group by source or category",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "Look-back window in days",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Click-through rates",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.CTRResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/experiments/events": {
+            "post": {
+                "description": "Record an exposure or click for the variant a user was assigned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "experiments"
+                ],
+                "summary": "Record an experiment event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User identifier",
+                        "name": "X-User-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Experiment event",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ExperimentEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Event recorded",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Experiment or variant not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/ranked": {
+            "get": {
+                "description": "List recent posts ordered by ranking score (recency, views, source weight, category boost)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feed"
+                ],
+                "summary": "Get ranked feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude posts flagged as sensitive",
+                        "name": "safe_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User identifier used for experiment bucketing (or X-User-ID header)",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ranked posts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.RankedPost"
+                                                            }
+                                                        },
+                                                        "pagination": {
+                                                            "$ref": "#/definitions/response.PaginationInfo"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/news/sources": {
+            "get": {
+                "description": "List the sources NewsAPI offers, optionally filtered by category, language and country, to pick the source IDs to aggregate. Listings are cached for NEWS_API_SOURCES_CACHE_TTL; a listing fetched from NewsAPI counts as one request against the tenant's daily quota.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "List upstream sources",
+                "parameters": [
+                    {
+                        "enum": [
+                            "general",
+                            "business",
+                            "entertainment",
+                            "health",
+                            "science",
+                            "sports",
+                            "technology"
+                        ],
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Two-letter ISO 639-1 language code",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Two-letter ISO 3166-1 country code",
+                        "name": "country",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching sources",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.NewsSourcesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "NewsAPI rate limit or tenant quota reached",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "502": {
+                        "description": "NewsAPI request failed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts": {
+            "get": {
+                "description": "List posts with pagination, optional filtering by category/source/country/author and search. With include_counts=true, meta.counts counts every published post per category and source, whatever the filters, limited to the 20 busiest of each.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "List posts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by two-letter country code",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude posts flagged as sensitive",
+                        "name": "safe_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: latest (default) or popular (most reactions first)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show only the first post of each topic",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Total count: true (default), false to skip it or estimate",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add meta.counts with post counts per category and source",
+                        "name": "include_counts",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of posts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.Post"
+                                                            }
+                                                        },
+                                                        "meta": {
+                                                            "type": "object",
+                                                            "additionalProperties": {
+                                                                "$ref": "#/definitions/model.PostCounts"
+                                                            }
+                                                        },
+                                                        "pagination": {
+                                                            "$ref": "#/definitions/response.PaginationInfo"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new post with the provided payload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Create a new post",
+                "parameters": [
+                    {
+                        "description": "Create Post payload",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreatePostParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Post created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Post"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - post exists",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Source is blocked",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/category/{category}": {
+            "get": {
+                "description": "List posts filtered by category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "List posts by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude posts flagged as sensitive",
+                        "name": "safe_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: latest (default) or popular (most reactions first)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show only the first post of each topic",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Total count: true (default), false to skip it or estimate",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of posts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.Post"
+                                                            }
+                                                        },
+                                                        "pagination": {
+                                                            "$ref": "#/definitions/response.PaginationInfo"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/changes": {
+            "get": {
+                "description": "List the IDs of the posts created, updated and deleted since a marker, for incremental sync. Start from an RFC 3339 timestamp, then pass the returned cursor as since; keep polling while has_more is true. A post is listed once, as of its last change; posts that are no longer published count as deleted. The last few seconds of changes are held back until they settle. Markers older than the change feed retention get 410 and require a full sync.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "List post changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 timestamp or cursor from the previous response",
+                        "name": "since",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Most changes returned (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post changes",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.PostChangesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid marker",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "410": {
+                        "description": "Marker older than the retention",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/discover": {
+            "get": {
+                "description": "List a random selection of posts published in the last 7 days, alternating between categories, for explore views. Posts are picked from a sample that is cached for a while, so successive calls reshuffle the same posts until a new sample is drawn.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Discover posts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of posts (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude posts flagged as sensitive",
+                        "name": "safe_mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Random recent posts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Post"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/lookup": {
+            "get": {
+                "description": "Retrieve the post stored for an article URL. The URL is normalized first, so tracking parameters, fragments, letter case of the host and a trailing slash do not matter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Look up a post by URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Article URL",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Post"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Quoted post version for If-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid URL",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/search": {
+            "get": {
+                "description": "Search posts by query string with optional filters. meta.facets counts every matching post per category, source and publication day (YYYY-MM-DD, most recent 30 days with matches); categories and sources are limited to the 20 busiest. With highlight=true each post carries a highlight object whose title and description snippet wrap matches in the configured delimiters (\u003cem\u003e and \u003c/em\u003e by default).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Search posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by two-letter country code",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude posts flagged as sensitive",
+                        "name": "safe_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: latest (default) or popular (most reactions first)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show only the first post of each topic",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Total count: true (default), false to skip it or estimate",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Highlight matches in titles and descriptions",
+                        "name": "highlight",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.Post"
+                                                            }
+                                                        },
+                                                        "meta": {
+                                                            "type": "object",
+                                                            "additionalProperties": {
+                                                                "$ref": "#/definitions/model.SearchFacets"
+                                                            }
+                                                        },
+                                                        "pagination": {
+                                                            "$ref": "#/definitions/response.PaginationInfo"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/source/{source}": {
+            "get": {
+                "description": "List posts filtered by source",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "List posts by source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source",
+                        "name": "source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude posts flagged as sensitive",
+                        "name": "safe_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: latest (default) or popular (most reactions first)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show only the first post of each topic",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Total count: true (default), false to skip it or estimate",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of posts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.Post"
+                                                            }
+                                                        },
+                                                        "pagination": {
+                                                            "$ref": "#/definitions/response.PaginationInfo"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}": {
+            "get": {
+                "description": "Retrieve a single post by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Get a post by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Post"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Quoted post version for If-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a post by ID with the provided payload. The version last read must be sent in an If-Match header or the body's version field.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Update a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Post version (ETag) the update is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Update Post payload",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdatePostParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated post",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Post"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "Post was modified since it was read",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "428": {
+                        "description": "Post version missing",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a post by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Delete a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/click": {
+            "post": {
+                "description": "Record that a user clicked through to a post's article. Referrer and user agent are taken from the request headers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Record a click-through",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Click recorded",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/comments": {
+            "get": {
+                "description": "List top-level comments on a post, oldest first, each with its replies nested beneath it. Pagination applies to top-level comments. Deleted comments keep their place in the thread with author and body removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List a post's comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Top-level comments per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment threads",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.Comment"
+                                                            }
+                                                        },
+                                                        "pagination": {
+                                                            "$ref": "#/definitions/response.PaginationInfo"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a comment to a post. Set parent_id to reply to an existing comment on the same post.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment on a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateCommentParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Comment created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Comment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or parent comment",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/comments/{comment_id}": {
+            "delete": {
+                "description": "Soft-delete a comment. Its replies stay visible and it no longer counts towards the post's comment_count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/hide": {
+            "post": {
+                "description": "Take a post off public endpoints without deleting it. Hiding an already hidden post is a no-op.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Hide a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hidden post",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Post"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/pin": {
+            "post": {
+                "description": "Pin a published post to a position at the top of the first page of the post list and the ranked feed, whatever their sort order. Positions run from 1 to PINNED_POSTS_MAX; pinning to a taken position unpins the post there, and pinning a pinned post moves it. Without expires_at the pin lasts until the post is unpinned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Pin a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pin position and expiry",
+                        "name": "pin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PinPostParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post pinned",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.PostPin"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, position or expiry",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Post is not published",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a post's pin so it is listed in its regular place again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Unpin a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid post ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post is not pinned",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/publish": {
+            "post": {
+                "description": "Make a draft or hidden post visible on public endpoints. Publishing an already published post is a no-op.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Publish a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Published post",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Post"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/reactions": {
+            "post": {
+                "description": "Record the client's reaction to a post. Until the API authenticates users, a client is the address it connects from; each client has one reaction per post, and reacting again with another type replaces it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "React to a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reaction",
+                        "name": "reaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReactParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reaction recorded",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ReactionSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the client's own reaction from a post",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reactions"
+                ],
+                "summary": "Remove a reaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Reaction not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/r/{id}": {
+            "get": {
+                "description": "Record a click-through and redirect to the original article URL. The redirect does not depend on the click being recorded, and no click is recorded in read-only mode.",
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Redirect to a post's article",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the article"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/rss": {
+            "get": {
+                "description": "RSS 2.0 feed of the most recent posts, or Atom 1.0 with format=atom. Feeds are cached and regenerated on a schedule.",
+                "produces": [
+                    "application/rss+xml",
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "syndication"
+                ],
+                "summary": "Feed of recent posts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "rss",
+                            "atom"
+                        ],
+                        "type": "string",
+                        "description": "Feed format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feed document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/rss/{category}": {
+            "get": {
+                "description": "RSS 2.0 feed of the most recent posts in a category, or Atom 1.0 with format=atom. Feeds are cached and regenerated on a schedule.",
+                "produces": [
+                    "application/rss+xml",
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "syndication"
+                ],
+                "summary": "Feed of recent posts in a category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "rss",
+                            "atom"
+                        ],
+                        "type": "string",
+                        "description": "Feed format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feed document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format or category",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/jobs": {
+            "get": {
+                "description": "Retrieve list of scheduled jobs and their statuses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "List scheduler jobs",
+                "responses": {
+                    "200": {
+                        "description": "Jobs list",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.JobsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/jobs/{name}/disable": {
+            "post": {
+                "description": "Skip a job's scheduled runs until it is enabled again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Disable a scheduler job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job disabled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.JobToggleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/jobs/{name}/enable": {
+            "post": {
+                "description": "Allow a disabled job to run on its schedule again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Enable a scheduler job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job enabled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.JobToggleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/jobs/{name}/history": {
+            "get": {
+                "description": "Retrieve the most recent executions of a job, newest first, including errors and run statistics",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Get job execution history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job history",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.JobHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Job name required",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/jobs/{name}/trigger": {
+            "post": {
+                "description": "Trigger a specific job by name (acknowledges trigger; job runs according to schedule)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Trigger a scheduler job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trigger acknowledged",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.JobTriggerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Job name required",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Job already running",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/once-jobs": {
+            "get": {
+                "description": "Retrieve the one-time jobs waiting to run, soonest first. Jobs are kept in Redis until they have run, so they survive restarts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "List one-time jobs",
+                "responses": {
+                    "200": {
+                        "description": "One-time jobs",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.OnceJobsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/once-jobs/{id}": {
+            "delete": {
+                "description": "Remove a one-time job before it runs. A job already running is not interrupted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Cancel a one-time job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "One-time job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "One-time job not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/pause": {
+            "post": {
+                "description": "Stop running scheduled jobs until the scheduler is resumed, without restarting the server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Pause the scheduler",
+                "responses": {
+                    "200": {
+                        "description": "Scheduler paused",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SchedulerStateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/resume": {
+            "post": {
+                "description": "Resume running scheduled jobs after a pause",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Resume the scheduler",
+                "responses": {
+                    "200": {
+                        "description": "Scheduler resumed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SchedulerStateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/status": {
+            "get": {
+                "description": "Retrieve current scheduler status including running flag and job list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduler"
+                ],
+                "summary": "Get scheduler status",
+                "responses": {
+                    "200": {
+                        "description": "Scheduler status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SchedulerStatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemap.xml": {
+            "get": {
+                "description": "Sitemap listing every post. Without page, a single sitemap is returned when all posts fit on one page, otherwise a sitemap index linking the numbered pages.",
+                "produces": [
+                    "application/xml"
+                ],
+                "tags": [
+                    "syndication"
+                ],
+                "summary": "Sitemap of posts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sitemap page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sitemap or sitemap index",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid page",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Sitemap page not found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/posts": {
+            "get": {
+                "description": "Number of posts ingested per day, per category, source or country unless grouped by day alone, for dashboards of ingestion trends. Days are UTC calendar days of the ingestion time; grouped by day, days without posts are included with a zero count. Grouped by country, posts without one are counted under \"unknown\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get post ingestion statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "Group by category, source, country or day",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window start, RFC 3339 or YYYY-MM-DD; default 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end (exclusive), RFC 3339 or YYYY-MM-DD; default now",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Post statistics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.PostStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/top": {
+            "get": {
+                "description": "Sources and categories ranked by posts ingested, views or clicks over the last 24 hours or 7 days, highest first. Totals are read from hourly rollups refreshed by a scheduled job, so they lag by up to the rollup interval; rolled_up_at tells when they were last computed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get top sources and categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "posts",
+                        "description": "Rank by posts, views or clicks",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "24h",
+                        "description": "Period, 24h or 7d",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Entries per ranking (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Top sources and categories",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.TopStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/topics": {
+            "get": {
+                "description": "List clusters of posts covering the same story, most recently active first. Each topic carries its number of published posts and its representative, the earliest published post. Only topics with at least two posts are listed by default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "topics"
+                ],
+                "summary": "List topics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum number of posts in a topic (default 2)",
+                        "name": "min_members",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude posts flagged as sensitive",
+                        "name": "safe_mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of topics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/response.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/model.Topic"
+                                                            }
+                                                        },
+                                                        "pagination": {
+                                                            "$ref": "#/definitions/response.PaginationInfo"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/response.ErrorInfo"
                                         }
                                     }
                                 }
@@ -1355,282 +6516,1998 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "model.AggregationError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "news provider request failed: rate limited"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AggregationErrorType"
+                        }
+                    ],
+                    "example": "provider_error"
+                }
+            }
+        },
+        "model.AggregationErrorType": {
+            "type": "string",
+            "enum": [
+                "provider_error",
+                "rate_limited",
+                "parse_error",
+                "duplicate",
+                "db_error"
+            ],
+            "x-enum-varnames": [
+                "AggregationErrorProvider",
+                "AggregationErrorRateLimited",
+                "AggregationErrorParse",
+                "AggregationErrorDuplicate",
+                "AggregationErrorDB"
+            ]
+        },
         "model.AggregationResponse": {
             "type": "object",
             "properties": {
-                "categories": {
+                "categories": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.CategoryStats"
+                    }
+                },
+                "countries": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.CountryStats"
+                    }
+                },
+                "duration": {
+                    "type": "string",
+                    "example": "1s"
+                },
+                "error_counts": {
+                    "description": "ErrorCounts tallies failures per type, including duplicates",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AggregationError"
+                    }
+                },
+                "rejection_counts": {
+                    "description": "RejectionCounts tallies articles dropped by the quality filter per rule",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "skipped_categories": {
+                    "description": "SkippedCategories and SkippedSources list what was not fully processed\nbecause the run timed out or was cancelled",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"sports\"]"
+                    ]
+                },
+                "skipped_sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"wired\"]"
+                    ]
+                },
+                "sources": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.SourceStats"
+                    }
+                },
+                "timed_out": {
+                    "description": "TimedOut is set when the deadline passed before the run finished; the\ntotals then cover only the work done in time",
+                    "type": "boolean",
+                    "example": false
+                },
+                "total_created": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "total_duplicates": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "total_errors": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "total_fetched": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "total_rejected": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.ArticlePreview": {
+            "type": "object",
+            "properties": {
+                "blocked": {
+                    "description": "Blocked is why the source rules would refuse the post, if they would",
+                    "type": "string",
+                    "example": "domain example.com is blocked"
+                },
+                "duplicate": {
+                    "description": "Duplicate is set when a post with the same URL is already stored",
+                    "type": "boolean",
+                    "example": false
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "post": {
+                    "$ref": "#/definitions/model.CreatePostParams"
+                },
+                "sensitive": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "artificial intelligence",
+                        "chips"
+                    ]
+                }
+            }
+        },
+        "model.ArticlePreviewRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "https://example.com/article"
+                }
+            }
+        },
+        "model.BulkDeletePostsParams": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "filter": {
+                    "$ref": "#/definitions/model.BulkPostFilter"
+                }
+            }
+        },
+        "model.BulkPostChanges": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1,
+                    "example": "business"
+                },
+                "sensitive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "status": {
+                    "enum": [
+                        "draft",
+                        "published",
+                        "hidden"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PostStatus"
+                        }
+                    ],
+                    "example": "hidden"
+                }
+            }
+        },
+        "model.BulkPostFilter": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "technology"
+                },
+                "domain": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "techcrunch.com"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "source": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "TechCrunch"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-31T23:59:59Z"
+                }
+            }
+        },
+        "model.BulkPostsResult": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "model.BulkUpdatePostsParams": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "$ref": "#/definitions/model.BulkPostChanges"
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "filter": {
+                    "$ref": "#/definitions/model.BulkPostFilter"
+                }
+            }
+        },
+        "model.CTRResponse": {
+            "type": "object",
+            "properties": {
+                "group_by": {
+                    "type": "string",
+                    "example": "source"
+                },
+                "since": {
+                    "type": "string",
+                    "example": "2025-08-04T07:11:03Z"
+                },
+                "stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CTRStat"
+                    }
+                }
+            }
+        },
+        "model.CTRStat": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer",
+                    "example": 84
+                },
+                "ctr": {
+                    "type": "number",
+                    "example": 0.07
+                },
+                "key": {
+                    "type": "string",
+                    "example": "TechCrunch"
+                },
+                "views": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "model.CategoryAggregationRequest": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"technology\"",
+                        "\"business\"]"
+                    ]
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"us\"",
+                        "\"gb\"]"
+                    ]
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-20T00:00:00Z"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 50
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-21T00:00:00Z"
+                }
+            }
+        },
+        "model.CategoryAggregationResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"technology\"]"
+                    ]
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"us\"]"
+                    ]
+                },
+                "result": {
+                    "$ref": "#/definitions/model.AggregationResponse"
+                }
+            }
+        },
+        "model.CategoryStats": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "duplicates": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "errors": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "fetched": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.Comment": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "jane"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Great write-up, thanks for sharing."
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "deleted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "post_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Comment"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                }
+            }
+        },
+        "model.ConfigReloadResult": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "description": "Changed lists the environment variables whose new values were applied",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "LOG_LEVEL",
+                        "CACHE_TTL"
+                    ]
+                },
+                "reloaded_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                }
+            }
+        },
+        "model.CountryStats": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 80
+                },
+                "duplicates": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "errors": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "fetched": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.CreateCommentParams": {
+            "type": "object",
+            "required": [
+                "author",
+                "body"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "jane"
+                },
+                "body": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "minLength": 1,
+                    "example": "Great write-up, thanks for sharing."
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                }
+            }
+        },
+        "model.CreatePostParams": {
+            "type": "object",
+            "required": [
+                "source",
+                "title",
+                "url"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Jane Doe"
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "technology"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Full content..."
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "A brief description"
+                },
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "https://example.com/image.jpg"
+                },
+                "published_at": {
+                    "type": "string",
+                    "example": "2024-01-20T10:00:00Z"
+                },
+                "source": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "TechCrunch"
+                },
+                "status": {
+                    "description": "Status defaults to published; create a draft to publish it later",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "hidden"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PostStatus"
+                        }
+                    ],
+                    "example": "draft"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 1,
+                    "example": "Breaking: new Go release"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 10,
+                    "example": "https://example.com/article"
+                }
+            }
+        },
+        "model.DeadLetter": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "post could not be stored: connection refused"
+                },
+                "error_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AggregationErrorType"
+                        }
+                    ],
+                    "example": "db_error"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_failed_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "payload": {
+                    "description": "Payload is the article as NewsAPI returned it",
+                    "type": "object"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "TechCrunch"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/article"
+                }
+            }
+        },
+        "model.DeadLetterListResponse": {
+            "type": "object",
+            "properties": {
+                "dead_letters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DeadLetter"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/model.PaginationMeta"
+                }
+            }
+        },
+        "model.DeadLetterRetryResponse": {
+            "type": "object",
+            "properties": {
+                "duplicate": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "post": {
+                    "$ref": "#/definitions/model.Post"
+                }
+            }
+        },
+        "model.DeadOnceJob": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Attempts counts the runs started so far, kept apart from the job",
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "timeout error"
+                },
+                "failed_at": {
+                    "type": "string",
+                    "example": "2025-08-11T08:03:10Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6TQJ2N7W4RZK3B5X7D2F9M4H1C"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "run_at": {
+                    "type": "string",
+                    "example": "2025-08-11T08:00:00Z"
+                },
+                "task": {
+                    "type": "string",
+                    "example": "post-purge"
+                },
+                "tenant": {
+                    "type": "string",
+                    "example": "default"
+                }
+            }
+        },
+        "model.DeadOnceJobsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DeadOnceJob"
+                    }
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                }
+            }
+        },
+        "model.EnableReadOnlyRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "database failover"
+                }
+            }
+        },
+        "model.Experiment": {
+            "type": "object",
+            "required": [
+                "variants"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "recency-vs-views"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "variants": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.ExperimentVariant"
+                    }
+                }
+            }
+        },
+        "model.ExperimentEvent": {
+            "type": "object",
+            "required": [
+                "experiment",
+                "type",
+                "variant"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "experiment": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "recency-vs-views"
+                },
+                "post_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "exposure",
+                        "click"
+                    ],
+                    "example": "click"
+                },
+                "variant": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "control"
+                }
+            }
+        },
+        "model.ExperimentResults": {
+            "type": "object",
+            "properties": {
+                "experiment": {
+                    "type": "string",
+                    "example": "recency-vs-views"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.VariantResult"
+                    }
+                }
+            }
+        },
+        "model.ExperimentVariant": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "allocation": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 50
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "control"
+                },
+                "weights": {
+                    "$ref": "#/definitions/model.RankingWeights"
+                }
+            }
+        },
+        "model.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "value": {
+                    "type": "string",
+                    "example": "technology"
+                }
+            }
+        },
+        "model.FilterRule": {
+            "type": "string",
+            "enum": [
+                "blocked_domain",
+                "source_list",
+                "title_pattern",
+                "min_content_length",
+                "clickbait"
+            ],
+            "x-enum-varnames": [
+                "FilterRuleBlockedDomain",
+                "FilterRuleSourceList",
+                "FilterRuleTitlePattern",
+                "FilterRuleMinContentLength",
+                "FilterRuleClickbait"
+            ]
+        },
+        "model.JobExecution": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "duration": {
+                    "type": "string",
+                    "example": "12s"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "timeout error"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "stats": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "triggered_by": {
+                    "description": "TriggeredBy names the job whose completion started this run; empty\nfor scheduled runs",
+                    "type": "string",
+                    "example": "top-headlines"
+                }
+            }
+        },
+        "model.JobHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "executions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.JobExecution"
+                    }
+                },
+                "job_name": {
+                    "type": "string",
+                    "example": "top-headlines"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                }
+            }
+        },
+        "model.JobStatus": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "description": "After lists the jobs whose successful runs also trigger this one",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "top-headlines"
+                    ]
+                },
+                "average_run_time": {
+                    "type": "string",
+                    "example": "30s"
+                },
+                "dependents": {
+                    "description": "Dependents lists the jobs triggered by this one's successful runs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "stats-view-refresh"
+                    ]
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "error_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "1h"
+                },
+                "is_running": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "jitter": {
+                    "type": "string",
+                    "example": "1m"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "timeout error"
+                },
+                "last_run": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "max_retries": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "fixed_rate"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "aggregate_all"
+                },
+                "next_run": {
+                    "type": "string",
+                    "example": "2025-08-11T08:11:03Z"
+                },
+                "queued": {
+                    "description": "Queued reports whether runs go through the queue of one-time jobs",
+                    "type": "boolean",
+                    "example": true
+                },
+                "quiet_hours": {
+                    "description": "QuietHours lists the daily UTC windows in which the job does not run;\nruns falling in one are moved to its end",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "01:00-05:00"
+                    ]
+                },
+                "retry_backoff": {
+                    "type": "string",
+                    "example": "30s"
+                },
+                "run_count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "timeout": {
+                    "type": "string",
+                    "example": "5m"
+                }
+            }
+        },
+        "model.JobToggleResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "job_name": {
+                    "type": "string",
+                    "example": "aggregate_all"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                }
+            }
+        },
+        "model.JobTriggerResponse": {
+            "type": "object",
+            "properties": {
+                "job_name": {
+                    "type": "string",
+                    "example": "aggregate_all"
+                },
+                "next_run": {
+                    "type": "string",
+                    "example": "2025-08-11T08:11:03Z"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Job will run according to its schedule."
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                }
+            }
+        },
+        "model.JobsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "jobs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.JobStatus"
+                    }
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                }
+            }
+        },
+        "model.NewsAPISource": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "technology"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "TechCrunch is a leading technology media property."
+                },
+                "id": {
+                    "type": "string",
+                    "example": "techcrunch"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "TechCrunch"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://techcrunch.com"
+                }
+            }
+        },
+        "model.NewsSourcesParams": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "technology"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                }
+            }
+        },
+        "model.NewsSourcesResponse": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "fetched_at": {
+                    "type": "string",
+                    "example": "2024-01-20T10:00:00Z"
+                },
+                "filters": {
+                    "$ref": "#/definitions/model.NewsSourcesParams"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NewsAPISource"
+                    }
+                }
+            }
+        },
+        "model.OnceJob": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Attempts counts the runs started so far, kept apart from the job",
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6TQJ2N7W4RZK3B5X7D2F9M4H1C"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "run_at": {
+                    "type": "string",
+                    "example": "2025-08-11T08:00:00Z"
+                },
+                "task": {
+                    "type": "string",
+                    "example": "post-purge"
+                },
+                "tenant": {
+                    "type": "string",
+                    "example": "default"
+                }
+            }
+        },
+        "model.OnceJobsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OnceJob"
+                    }
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                }
+            }
+        },
+        "model.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "has_prev": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "total_mode": {
+                    "description": "TotalMode tells how Total was worked out when it was not counted exactly",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PostCountMode"
+                        }
+                    ],
+                    "example": "estimate"
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 13
+                }
+            }
+        },
+        "model.PinPostParams": {
+            "type": "object",
+            "required": [
+                "position"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-08-12T07:11:03Z"
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "model.Post": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "Jane Doe"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "technology"
+                },
+                "comment_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Full content of the article..."
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "A brief description of the news article"
+                },
+                "highlight": {
+                    "$ref": "#/definitions/model.PostHighlight"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "pinned": {
+                    "description": "Pinned marks a post an editor pinned to the top of the list",
+                    "type": "boolean",
+                    "example": true
+                },
+                "published_at": {
+                    "type": "string",
+                    "example": "2024-01-20T10:00:00Z"
+                },
+                "reaction_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reactions": {
                     "type": "object",
                     "additionalProperties": {
-                        "$ref": "#/definitions/model.CategoryStats"
+                        "type": "integer",
+                        "format": "int64"
                     }
                 },
-                "duration": {
+                "sensitive": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "source": {
                     "type": "string",
-                    "example": "1s"
+                    "example": "TechCrunch"
                 },
-                "errors": {
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PostStatus"
+                        }
+                    ],
+                    "example": "published"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Breaking: new Go release"
+                },
+                "topic_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:16:04Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/article"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "model.PostChangesResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "integer"
+                    },
+                    "example": [
+                        125,
+                        126
+                    ]
+                },
+                "cursor": {
+                    "type": "string",
+                    "example": "MTcwNTc0NTAwMDAwMDAwMDoxMjY"
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        77
+                    ]
+                },
+                "has_more": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
                     },
                     "example": [
-                        "[]"
+                        98
                     ]
+                }
+            }
+        },
+        "model.PostCountMode": {
+            "type": "string",
+            "enum": [
+                "",
+                "estimate",
+                "none"
+            ],
+            "x-enum-varnames": [
+                "PostCountExact",
+                "PostCountEstimate",
+                "PostCountNone"
+            ]
+        },
+        "model.PostCounts": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FacetCount"
+                    }
                 },
                 "sources": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/model.SourceStats"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FacetCount"
                     }
+                }
+            }
+        },
+        "model.PostHighlight": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "The \u003cem\u003eGo\u003c/em\u003e team announced..."
                 },
-                "total_created": {
-                    "type": "integer",
-                    "example": 120
+                "title": {
+                    "type": "string",
+                    "example": "Breaking: new \u003cem\u003eGo\u003c/em\u003e release"
+                }
+            }
+        },
+        "model.PostPin": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-08-12T07:11:03Z"
                 },
-                "total_duplicates": {
-                    "type": "integer",
-                    "example": 25
+                "pinned_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
                 },
-                "total_errors": {
+                "position": {
                     "type": "integer",
-                    "example": 5
+                    "example": 1
                 },
-                "total_fetched": {
+                "post_id": {
                     "type": "integer",
-                    "example": 150
+                    "example": 42
                 }
             }
         },
-        "model.CategoryAggregationRequest": {
+        "model.PostStatsBucket": {
             "type": "object",
             "properties": {
-                "categories": {
+                "count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "day": {
+                    "type": "string",
+                    "example": "2025-08-11"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "technology"
+                }
+            }
+        },
+        "model.PostStatsResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"technology\"",
-                        "\"business\"]"
-                    ]
+                        "$ref": "#/definitions/model.PostStatsBucket"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-07-12T00:00:00Z"
+                },
+                "group_by": {
+                    "type": "string",
+                    "example": "category"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-08-11T00:00:00Z"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1250
                 }
             }
         },
-        "model.CategoryAggregationResponse": {
+        "model.PostStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "published",
+                "hidden",
+                "any"
+            ],
+            "x-enum-varnames": [
+                "PostStatusDraft",
+                "PostStatusPublished",
+                "PostStatusHidden",
+                "PostStatusAny"
+            ]
+        },
+        "model.QuarantineListResponse": {
             "type": "object",
             "properties": {
-                "categories": {
+                "articles": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"technology\"]"
-                    ]
+                        "$ref": "#/definitions/model.QuarantinedArticle"
+                    }
                 },
-                "result": {
-                    "$ref": "#/definitions/model.AggregationResponse"
+                "pagination": {
+                    "$ref": "#/definitions/model.PaginationMeta"
                 }
             }
         },
-        "model.CategoryStats": {
+        "model.QuarantinedArticle": {
             "type": "object",
             "properties": {
-                "created": {
-                    "type": "integer",
-                    "example": 80
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
                 },
-                "duplicates": {
+                "id": {
                     "type": "integer",
-                    "example": 15
+                    "example": 1
                 },
-                "errors": {
-                    "type": "integer",
-                    "example": 2
+                "reason": {
+                    "type": "string",
+                    "example": "title matches clickbait phrase \"you won't believe\""
                 },
-                "fetched": {
-                    "type": "integer",
-                    "example": 100
+                "rule": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.FilterRule"
+                        }
+                    ],
+                    "example": "clickbait"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "Example News"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "You won't believe what happened next"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://spam.example.com/article"
                 }
             }
         },
-        "model.CreatePostParams": {
+        "model.RankedPost": {
             "type": "object",
-            "required": [
-                "source",
-                "title",
-                "url"
-            ],
             "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "Jane Doe"
+                },
                 "category": {
                     "type": "string",
-                    "maxLength": 50,
                     "example": "technology"
                 },
+                "comment_count": {
+                    "type": "integer",
+                    "example": 3
+                },
                 "content": {
                     "type": "string",
-                    "example": "Full content..."
+                    "example": "Full content of the article..."
+                },
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
                 },
                 "description": {
                     "type": "string",
-                    "example": "A brief description"
+                    "example": "A brief description of the news article"
+                },
+                "highlight": {
+                    "$ref": "#/definitions/model.PostHighlight"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "image_url": {
                     "type": "string",
-                    "maxLength": 1000,
                     "example": "https://example.com/image.jpg"
                 },
+                "pinned": {
+                    "description": "Pinned marks a post an editor pinned to the top of the list",
+                    "type": "boolean",
+                    "example": true
+                },
                 "published_at": {
                     "type": "string",
                     "example": "2024-01-20T10:00:00Z"
                 },
+                "reaction_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reactions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.87
+                },
+                "sensitive": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "source": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1,
                     "example": "TechCrunch"
                 },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PostStatus"
+                        }
+                    ],
+                    "example": "published"
+                },
                 "title": {
                     "type": "string",
-                    "maxLength": 500,
-                    "minLength": 1,
                     "example": "Breaking: new Go release"
                 },
-                "url": {
-                    "type": "string",
-                    "maxLength": 500,
-                    "minLength": 10,
-                    "example": "https://example.com/article"
-                }
-            }
-        },
-        "model.JobStatus": {
-            "type": "object",
-            "properties": {
-                "average_run_time": {
-                    "type": "string",
-                    "example": "30s"
-                },
-                "error_count": {
+                "topic_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "interval": {
-                    "type": "string",
-                    "example": "1h"
-                },
-                "is_running": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "last_error": {
-                    "type": "string",
-                    "example": "timeout error"
-                },
-                "last_run": {
+                "updated_at": {
                     "type": "string",
-                    "example": "2025-08-11T07:11:03Z"
+                    "example": "2025-08-11T07:16:04Z"
                 },
-                "name": {
+                "url": {
                     "type": "string",
-                    "example": "aggregate_all"
+                    "example": "https://example.com/article"
                 },
-                "next_run": {
-                    "type": "string",
-                    "example": "2025-08-11T08:11:03Z"
+                "version": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "run_count": {
+                "views": {
                     "type": "integer",
-                    "example": 42
+                    "example": 120
                 }
             }
         },
-        "model.JobTriggerResponse": {
+        "model.RankingWeights": {
             "type": "object",
             "properties": {
-                "job_name": {
-                    "type": "string",
-                    "example": "aggregate_all"
+                "category_boosts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    },
+                    "example": {
+                        "technology": 1.5
+                    }
                 },
-                "next_run": {
+                "recency_half_life": {
                     "type": "string",
-                    "example": "2025-08-11T08:11:03Z"
+                    "example": "6h"
                 },
-                "note": {
-                    "type": "string",
-                    "example": "Job will run according to its schedule."
+                "recency_weight": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1
                 },
-                "timestamp": {
-                    "type": "string",
-                    "example": "2025-08-11T07:11:03Z"
+                "source_weights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    },
+                    "example": {
+                        "TechCrunch": 1.2
+                    }
+                },
+                "view_weight": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0.2
                 }
             }
         },
-        "model.JobsResponse": {
+        "model.ReactParams": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "type": {
+                    "enum": [
+                        "like",
+                        "upvote",
+                        "love",
+                        "insightful",
+                        "funny"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ReactionType"
+                        }
+                    ],
+                    "example": "like"
+                }
+            }
+        },
+        "model.ReactionSummary": {
             "type": "object",
             "properties": {
-                "count": {
+                "post_id": {
                     "type": "integer",
-                    "example": 3
+                    "example": 42
                 },
-                "jobs": {
+                "reaction_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reactions": {
                     "type": "object",
                     "additionalProperties": {
-                        "$ref": "#/definitions/model.JobStatus"
+                        "type": "integer",
+                        "format": "int64"
                     }
                 },
-                "timestamp": {
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ReactionType"
+                        }
+                    ],
+                    "example": "like"
+                }
+            }
+        },
+        "model.ReactionType": {
+            "type": "string",
+            "enum": [
+                "like",
+                "upvote",
+                "love",
+                "insightful",
+                "funny"
+            ],
+            "x-enum-varnames": [
+                "ReactionLike",
+                "ReactionUpvote",
+                "ReactionLove",
+                "ReactionInsightful",
+                "ReactionFunny"
+            ]
+        },
+        "model.ReadOnlyStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "reason": {
+                    "description": "Reason is what the operator gave when enabling read-only mode",
+                    "type": "string",
+                    "example": "database failover"
+                },
+                "since": {
                     "type": "string",
                     "example": "2025-08-11T07:11:03Z"
                 }
             }
         },
-        "model.Post": {
+        "model.ReprocessParams": {
             "type": "object",
             "properties": {
                 "category": {
                     "type": "string",
+                    "maxLength": 50,
                     "example": "technology"
                 },
-                "content": {
+                "from": {
                     "type": "string",
-                    "example": "Full content of the article..."
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-08-11T07:11:03Z"
+                "missing_content": {
+                    "description": "MissingContent limits the run to posts whose full content was never extracted",
+                    "type": "boolean",
+                    "example": true
                 },
-                "description": {
+                "to": {
                     "type": "string",
-                    "example": "A brief description of the news article"
+                    "example": "2024-01-31T23:59:59Z"
+                }
+            }
+        },
+        "model.ReprocessRun": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "failed to list posts"
                 },
-                "id": {
+                "extracted": {
                     "type": "integer",
-                    "example": 1
+                    "example": 40
                 },
-                "image_url": {
-                    "type": "string",
-                    "example": "https://example.com/image.jpg"
+                "failed": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "published_at": {
+                "finished_at": {
                     "type": "string",
-                    "example": "2024-01-20T10:00:00Z"
+                    "example": "2025-08-11T07:16:04Z"
                 },
-                "source": {
+                "flagged": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
                     "type": "string",
-                    "example": "TechCrunch"
+                    "example": "5f2b8c1e9a7d4e3f"
                 },
-                "title": {
+                "params": {
+                    "$ref": "#/definitions/model.ReprocessParams"
+                },
+                "processed": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "started_at": {
                     "type": "string",
-                    "example": "Breaking: new Go release"
+                    "example": "2025-08-11T07:11:03Z"
                 },
-                "updated_at": {
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ReprocessStatus"
+                        }
+                    ],
+                    "example": "running"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "model.ReprocessStatus": {
+            "type": "string",
+            "enum": [
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ReprocessStatusRunning",
+                "ReprocessStatusCompleted",
+                "ReprocessStatusFailed"
+            ]
+        },
+        "model.SaveSourceRuleParams": {
+            "type": "object",
+            "required": [
+                "list",
+                "type",
+                "value"
+            ],
+            "properties": {
+                "hide_existing": {
+                    "description": "HideExisting hides the stored posts a blocked rule matches",
+                    "type": "boolean",
+                    "example": true
+                },
+                "list": {
+                    "enum": [
+                        "block",
+                        "allow"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.SourceRuleList"
+                        }
+                    ],
+                    "example": "block"
+                },
+                "type": {
+                    "enum": [
+                        "domain",
+                        "source"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.SourceRuleType"
+                        }
+                    ],
+                    "example": "domain"
+                },
+                "value": {
                     "type": "string",
-                    "example": "2025-08-11T07:16:04Z"
+                    "maxLength": 255,
+                    "example": "spam.example.com"
+                }
+            }
+        },
+        "model.SaveSourceRuleResult": {
+            "type": "object",
+            "properties": {
+                "hidden": {
+                    "type": "integer",
+                    "example": 12
                 },
-                "url": {
+                "rule": {
+                    "$ref": "#/definitions/model.SourceRule"
+                }
+            }
+        },
+        "model.SchedulerStateResponse": {
+            "type": "object",
+            "properties": {
+                "paused": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "timestamp": {
                     "type": "string",
-                    "example": "https://example.com/article"
+                    "example": "2025-08-11T07:11:03Z"
                 }
             }
         },
@@ -1647,6 +8524,10 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 3
                 },
+                "scheduler_paused": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "scheduler_running": {
                     "type": "boolean",
                     "example": true
@@ -1657,9 +8538,108 @@ const docTemplate = `{
                 }
             }
         },
+        "model.SearchAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "since": {
+                    "type": "string",
+                    "example": "2025-08-04T07:11:03Z"
+                },
+                "top_queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SearchTermStat"
+                    }
+                },
+                "total_searches": {
+                    "type": "integer",
+                    "example": 1500
+                },
+                "zero_result_queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SearchTermStat"
+                    }
+                },
+                "zero_result_rate": {
+                    "type": "number",
+                    "example": 0.08
+                },
+                "zero_result_searches": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "model.SearchFacets": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FacetCount"
+                    }
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FacetCount"
+                    }
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FacetCount"
+                    }
+                }
+            }
+        },
+        "model.SearchTermStat": {
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "type": "number",
+                    "example": 31.2
+                },
+                "avg_results": {
+                    "type": "number",
+                    "example": 11.5
+                },
+                "last_searched_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "searches": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "term": {
+                    "type": "string",
+                    "example": "climate summit"
+                }
+            }
+        },
         "model.SourceAggregationRequest": {
             "type": "object",
             "properties": {
+                "country": {
+                    "type": "string",
+                    "example": "us"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-20T00:00:00Z"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "page_size": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 50
+                },
                 "sources": {
                     "type": "array",
                     "items": {
@@ -1669,6 +8649,10 @@ const docTemplate = `{
                         "[\"techcrunch\"",
                         "\"wired\"]"
                     ]
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-21T00:00:00Z"
                 }
             }
         },
@@ -1689,6 +8673,65 @@ const docTemplate = `{
                 }
             }
         },
+        "model.SourceRule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "list": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.SourceRuleList"
+                        }
+                    ],
+                    "example": "block"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.SourceRuleType"
+                        }
+                    ],
+                    "example": "domain"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "spam.example.com"
+                }
+            }
+        },
+        "model.SourceRuleList": {
+            "type": "string",
+            "enum": [
+                "block",
+                "allow"
+            ],
+            "x-enum-varnames": [
+                "SourceRuleBlock",
+                "SourceRuleAllow"
+            ]
+        },
+        "model.SourceRuleType": {
+            "type": "string",
+            "enum": [
+                "domain",
+                "source"
+            ],
+            "x-enum-varnames": [
+                "SourceRuleDomain",
+                "SourceRuleSource"
+            ]
+        },
         "model.SourceStats": {
             "type": "object",
             "properties": {
@@ -1707,12 +8750,138 @@ const docTemplate = `{
                 "fetched": {
                     "type": "integer",
                     "example": 100
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.Tenant": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                },
+                "has_news_api_key": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "string",
+                    "example": "acme"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Acme News"
+                },
+                "news_api_daily_quota": {
+                    "description": "NewsAPIDailyQuota caps the tenant's NewsAPI requests per UTC day; 0 is unlimited",
+                    "type": "integer",
+                    "example": 500
+                },
+                "sources": {
+                    "description": "Sources replaces the default sources of the source aggregation job when set",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "bbc-news",
+                        "reuters"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-08-11T07:11:03Z"
+                }
+            }
+        },
+        "model.TopStat": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "technology"
+                },
+                "value": {
+                    "type": "integer",
+                    "example": 1280
+                }
+            }
+        },
+        "model.TopStatsResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TopStat"
+                    }
+                },
+                "metric": {
+                    "type": "string",
+                    "example": "views"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "24h"
+                },
+                "rolled_up_at": {
+                    "description": "RolledUpAt is when the stats were last computed; it is absent until the\nfirst rollup has run",
+                    "type": "string",
+                    "example": "2025-08-11T07:05:00Z"
+                },
+                "since": {
+                    "type": "string",
+                    "example": "2025-08-10T07:00:00Z"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TopStat"
+                    }
+                }
+            }
+        },
+        "model.Topic": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_published_at": {
+                    "type": "string",
+                    "example": "2024-01-20T10:00:00Z"
+                },
+                "member_count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "representative": {
+                    "description": "Representative is the earliest published member of the topic",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Post"
+                        }
+                    ]
                 }
             }
         },
         "model.UpdatePostParams": {
             "type": "object",
             "properties": {
+                "author": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Jane Doe"
+                },
                 "category": {
                     "type": "string",
                     "maxLength": 50,
@@ -1736,6 +8905,92 @@ const docTemplate = `{
                     "maxLength": 500,
                     "minLength": 1,
                     "example": "Updated title"
+                },
+                "version": {
+                    "description": "Version is the post version the client last read; an If-Match header takes precedence",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "model.UpsertExperimentParams": {
+            "type": "object",
+            "required": [
+                "variants"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "variants": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.ExperimentVariant"
+                    }
+                }
+            }
+        },
+        "model.UpsertTenantParams": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Active defaults to true for new tenants and is kept when omitted",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Acme News"
+                },
+                "news_api_daily_quota": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 500
+                },
+                "news_api_key": {
+                    "description": "NewsAPIKey is kept when omitted and cleared when empty",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "abc123"
+                },
+                "sources": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "bbc-news",
+                        "reuters"
+                    ]
+                }
+            }
+        },
+        "model.VariantResult": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "ctr": {
+                    "type": "number",
+                    "example": 0.042
+                },
+                "exposures": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "variant": {
+                    "type": "string",
+                    "example": "control"
                 }
             }
         },
@@ -1756,9 +9011,118 @@ const docTemplate = `{
                 }
             }
         },
+        "response.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "BAD_REQUEST",
+                "VALIDATION_FAILED",
+                "INVALID_REQUEST_BODY",
+                "INVALID_PARAMETER",
+                "MISSING_PARAMETER",
+                "FORBIDDEN",
+                "NOT_FOUND",
+                "METHOD_NOT_ALLOWED",
+                "PAYLOAD_TOO_LARGE",
+                "RATE_LIMITED",
+                "INTERNAL_ERROR",
+                "TIMEOUT",
+                "READ_ONLY",
+                "INVALID_POST_ID",
+                "POST_NOT_FOUND",
+                "POST_ALREADY_EXISTS",
+                "POST_VERSION_REQUIRED",
+                "POST_VERSION_CONFLICT",
+                "JOB_NOT_FOUND",
+                "JOB_ALREADY_RUNNING",
+                "ONCE_JOB_NOT_FOUND",
+                "DEAD_JOB_NOT_FOUND",
+                "EXPERIMENT_NOT_FOUND",
+                "EXPERIMENT_VARIANT_NOT_FOUND",
+                "INVALID_EXPERIMENT",
+                "INVALID_RANKING_WEIGHTS",
+                "INVALID_AGGREGATION_PARAMETERS",
+                "REPROCESS_RUN_NOT_FOUND",
+                "REPROCESS_ALREADY_RUNNING",
+                "CONFIG_INVALID",
+                "INVALID_COMMENT_ID",
+                "COMMENT_NOT_FOUND",
+                "INVALID_PARENT_COMMENT",
+                "REACTION_NOT_FOUND",
+                "TENANT_NOT_FOUND",
+                "SOURCE_BLOCKED",
+                "SOURCE_RULE_NOT_FOUND",
+                "INVALID_SOURCE_RULE",
+                "CHANGES_MARKER_EXPIRED",
+                "DEAD_LETTER_NOT_FOUND",
+                "DEAD_LETTER_UNSTORABLE",
+                "NEWS_PROVIDER_FAILED",
+                "ARTICLE_FETCH_FAILED",
+                "ARTICLE_NOT_PREVIEWABLE",
+                "INVALID_PIN",
+                "POST_NOT_PINNED",
+                "POST_NOT_PUBLISHED"
+            ],
+            "x-enum-varnames": [
+                "CodeBadRequest",
+                "CodeValidationFailed",
+                "CodeInvalidRequestBody",
+                "CodeInvalidParameter",
+                "CodeMissingParameter",
+                "CodeForbidden",
+                "CodeNotFound",
+                "CodeMethodNotAllowed",
+                "CodePayloadTooLarge",
+                "CodeRateLimited",
+                "CodeInternal",
+                "CodeTimeout",
+                "CodeReadOnly",
+                "CodeInvalidPostID",
+                "CodePostNotFound",
+                "CodePostExists",
+                "CodePostVersionRequired",
+                "CodePostVersionConflict",
+                "CodeJobNotFound",
+                "CodeJobRunning",
+                "CodeOnceJobNotFound",
+                "CodeDeadJobNotFound",
+                "CodeExperimentNotFound",
+                "CodeVariantNotFound",
+                "CodeInvalidExperiment",
+                "CodeInvalidRankingWeights",
+                "CodeInvalidAggregation",
+                "CodeReprocessRunNotFound",
+                "CodeReprocessRunning",
+                "CodeInvalidConfig",
+                "CodeInvalidCommentID",
+                "CodeCommentNotFound",
+                "CodeInvalidParentComment",
+                "CodeReactionNotFound",
+                "CodeTenantNotFound",
+                "CodeSourceBlocked",
+                "CodeSourceRuleNotFound",
+                "CodeInvalidSourceRule",
+                "CodeChangesMarkerExpired",
+                "CodeDeadLetterNotFound",
+                "CodeDeadLetterUnstorable",
+                "CodeNewsProviderFailed",
+                "CodeArticleFetchFailed",
+                "CodeArticleUnpreviewable",
+                "CodeInvalidPin",
+                "CodePostNotPinned",
+                "CodePostNotPublished"
+            ]
+        },
         "response.ErrorInfo": {
             "type": "object",
             "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ErrorCode"
+                        }
+                    ],
+                    "example": "VALIDATION_FAILED"
+                },
                 "details": {
                     "type": "string",
                     "example": "title is required"
@@ -1779,8 +9143,15 @@ const docTemplate = `{
                     }
                 },
                 "items": {},
+                "meta": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
                 "pagination": {
                     "$ref": "#/definitions/response.PaginationInfo"
+                },
+                "query": {
+                    "$ref": "#/definitions/response.QueryInfo"
                 }
             }
         },
@@ -1807,11 +9178,39 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 123
                 },
+                "total_mode": {
+                    "description": "TotalMode is set when total is not an exact count: \"none\" when it is a\nlower bound, \"estimate\" when it is the query planner's estimate",
+                    "type": "string",
+                    "example": "estimate"
+                },
                 "total_pages": {
                     "type": "integer",
                     "example": 13
                 }
             }
+        },
+        "response.QueryInfo": {
+            "type": "object",
+            "properties": {
+                "cache_hit": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "filters": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "latest"
+                },
+                "took_ms": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
         }
     }
 }`
@@ -3,69 +3,104 @@ package bootstrap
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
 	"github.com/amirzre/news-feed-system/internal/service"
 	"github.com/amirzre/news-feed-system/pkg/logger"
 )
 
-// SetupAggregationJobs registers all aggregation jobs.
-func SetupAggregationJobs(scheduler service.SchedulerService, aggregator service.AggregatorService, log *logger.Logger) {
-	// Top headlines every 30 minutes
-	scheduler.AddJob("top-headlines", 30*time.Minute, func(ctx context.Context) error {
-		log.Info("Running scheduled top headlines aggregation")
-		result, err := aggregator.AggregateTopHeadlines(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to aggregate top headline news job: %w", err)
-		}
+// SetupAggregationJobs registers all aggregation jobs. Each run aggregates
+// news for every active tenant. Runs go through the durable job queue, so a
+// run lost to a crash is picked up by another replica.
+func SetupAggregationJobs(scheduler service.SchedulerService, aggregator service.AggregatorService, tenants service.TenantService, cfg config.SchedulerConfig, log *logger.Logger) {
+	scheduling := []service.JobOption{service.WithJobJitter(cfg.StartupJitter)}
+	if cfg.Mode == model.ScheduleModeFixedDelay {
+		scheduling = append(scheduling, service.WithJobFixedDelay())
+	}
 
-		log.Info("Top headlines aggregation completed",
-			"fetched", result.TotalFetched,
-			"created", result.TotalCreated,
-			"duplicates", result.TotalDuplicates,
-			"errors", result.TotalErrors,
-		)
+	// Top headlines, every 30 minutes by default
+	scheduler.AddJob("top-headlines", cfg.HeadlinesInterval, func(ctx context.Context) error {
+		log.Info("Running scheduled top headlines aggregation")
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			result, err := aggregator.AggregateTopHeadlines(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to aggregate top headline news job: %w", err)
+			}
 
-		return nil
-	})
+			logAggregation(log, "Top headlines aggregation completed", t.ID, result)
+			return aggregationStats(result), nil
+		})
+	}, jobOptions(scheduling, service.WithJobTimeout(5*time.Minute), service.WithJobRetries(2), service.WithJobRetryBackoff(30*time.Second), service.WithJobQueued())...)
 
-	// Category-based aggregation every 2 hours
-	scheduler.AddJob("category-aggregation", 2*time.Hour, func(ctx context.Context) error {
+	// Category-based aggregation, every 2 hours by default
+	scheduler.AddJob("category-aggregation", cfg.CategoriesInterval, func(ctx context.Context) error {
 		log.Info("Running scheduled category aggregation")
 		categories := service.GetDefaultCategories()
-		result, err := aggregator.AggregateByCategories(ctx, categories)
-		if err != nil {
-			return fmt.Errorf("failed to aggregate category news job: %w", err)
-		}
-
-		log.Info("Category aggregation completed",
-			"fetched", result.TotalFetched,
-			"created", result.TotalCreated,
-			"duplicates", result.TotalDuplicates,
-			"errors", result.TotalErrors,
-		)
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			result, err := aggregator.AggregateByCategories(ctx, categories, nil, model.AggregationQuery{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to aggregate category news job: %w", err)
+			}
 
-		return nil
-	})
+			logAggregation(log, "Category aggregation completed", t.ID, result)
+			return aggregationStats(result), nil
+		})
+	}, jobOptions(scheduling, service.WithJobTimeout(10*time.Minute), service.WithJobRetries(1), service.WithJobRetryBackoff(time.Minute), service.WithJobQueued())...)
 
-	// Source-based aggregation every 4 hours
-	scheduler.AddJob("source-aggregation", 4*time.Hour, func(ctx context.Context) error {
+	// Source-based aggregation, every 4 hours by default
+	scheduler.AddJob("source-aggregation", cfg.SourcesInterval, func(ctx context.Context) error {
 		log.Info("Running scheduled source aggregation")
-		sources := service.GetDefaultSources()
-		result, err := aggregator.AggregateBySources(ctx, sources)
-		if err != nil {
-			return fmt.Errorf("failed to aggregate source news job: %w", err)
-		}
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			// Tenants without their own source list follow the defaults
+			sources := t.Sources
+			if len(sources) == 0 {
+				sources = service.GetDefaultSources()
+			}
 
-		log.Info("Source aggregation completed",
-			"fetched", result.TotalFetched,
-			"created", result.TotalCreated,
-			"duplicates", result.TotalDuplicates,
-			"errors", result.TotalErrors,
-		)
+			result, err := aggregator.AggregateBySources(ctx, sources, model.AggregationQuery{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to aggregate source news job: %w", err)
+			}
 
-		return nil
-	})
+			logAggregation(log, "Source aggregation completed", t.ID, result)
+			return aggregationStats(result), nil
+		})
+	}, jobOptions(scheduling, service.WithJobTimeout(10*time.Minute), service.WithJobRetries(1), service.WithJobRetryBackoff(time.Minute), service.WithJobQueued())...)
 
 	log.Info("Aggregation jobs configured successfully")
 }
+
+// logAggregation logs the outcome of a tenant's scheduled aggregation
+func logAggregation(log *logger.Logger, msg, tenantID string, result *model.AggregationResponse) {
+	log.Info(msg,
+		"tenant", tenantID,
+		"fetched", result.TotalFetched,
+		"created", result.TotalCreated,
+		"duplicates", result.TotalDuplicates,
+		"errors", result.TotalErrors,
+		"rejected", result.TotalRejected,
+		"timed_out", result.TimedOut,
+		"skipped_categories", result.SkippedCategories,
+		"skipped_sources", result.SkippedSources,
+	)
+}
+
+// aggregationStats converts an aggregation result into job history counters
+func aggregationStats(result *model.AggregationResponse) map[string]int64 {
+	return map[string]int64{
+		"fetched":    int64(result.TotalFetched),
+		"created":    int64(result.TotalCreated),
+		"duplicates": int64(result.TotalDuplicates),
+		"errors":     int64(result.TotalErrors),
+		"rejected":   int64(result.TotalRejected),
+		"skipped":    int64(len(result.SkippedCategories) + len(result.SkippedSources)),
+	}
+}
+
+// jobOptions combines the shared scheduling options with job-specific ones
+func jobOptions(scheduling []service.JobOption, opts ...service.JobOption) []service.JobOption {
+	return slices.Concat(scheduling, opts)
+}
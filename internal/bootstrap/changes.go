@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// SetupChangeJobs registers the job that drops the tombstones of deleted
+// posts once they are past the change feed retention, when it is enabled.
+func SetupChangeJobs(scheduler service.SchedulerService, changes service.ChangeService, tenants service.TenantService, cfg config.ChangesConfig, schedulerCfg config.SchedulerConfig, log *logger.Logger) {
+	if !cfg.PruneEnabled {
+		log.Info("Post changes prune job disabled")
+		return
+	}
+
+	scheduling := []service.JobOption{service.WithJobJitter(schedulerCfg.StartupJitter), service.WithJobFixedDelay()}
+
+	scheduler.AddJob("post-changes-prune", cfg.PruneInterval, func(ctx context.Context) error {
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			pruned, err := changes.PruneDeletions(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run post changes prune job: %w", err)
+			}
+
+			log.Info("Post changes prune completed", "tenant", t.ID, "pruned", pruned)
+			return map[string]int64{"pruned": pruned}, nil
+		})
+	}, jobOptions(scheduling)...)
+
+	log.Info("Post change jobs configured successfully")
+}
@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// SetupEnrichmentJobs registers the post-ingestion content extraction job
+// when it is enabled. Besides its own schedule, it runs after each
+// aggregation job so that new posts are enriched without waiting. Runs go
+// through the durable job queue, like those of the aggregation jobs.
+func SetupEnrichmentJobs(scheduler service.SchedulerService, content service.ContentFetcherService, tenants service.TenantService, cfg config.ContentFetchConfig, schedulerCfg config.SchedulerConfig, log *logger.Logger) {
+	if !cfg.Enabled {
+		log.Info("Content extraction job disabled")
+		return
+	}
+
+	scheduling := []service.JobOption{service.WithJobJitter(schedulerCfg.StartupJitter), service.WithJobFixedDelay()}
+
+	// A batch is fetched sequentially with politeness delays, so allow for
+	// every request plus its wait before timing out
+	timeout := time.Duration(cfg.BatchSize) * (cfg.Delay + cfg.Timeout)
+
+	scheduler.AddJob("content-extraction", cfg.Interval, func(ctx context.Context) error {
+		log.Info("Running scheduled content extraction")
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			result, err := content.EnrichPendingPosts(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run content extraction job: %w", err)
+			}
+
+			log.Info("Content extraction completed",
+				"tenant", t.ID,
+				"processed", result.Processed,
+				"extracted", result.Extracted,
+				"skipped", result.Skipped,
+				"failed", result.Failed,
+			)
+			return map[string]int64{
+				"processed": int64(result.Processed),
+				"extracted": int64(result.Extracted),
+				"skipped":   int64(result.Skipped),
+				"failed":    int64(result.Failed),
+			}, nil
+		})
+	}, jobOptions(scheduling,
+		service.WithJobTimeout(timeout),
+		service.WithJobAfter("top-headlines", "category-aggregation", "source-aggregation"),
+		service.WithJobQueued(),
+	)...)
+
+	log.Info("Enrichment jobs configured successfully")
+}
@@ -0,0 +1,35 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// SetupPartitionJobs registers the job that creates the monthly partitions
+// of the posts table ahead of time, when it is enabled.
+func SetupPartitionJobs(scheduler service.SchedulerService, partitions service.PartitionService, cfg config.PartitionConfig, schedulerCfg config.SchedulerConfig, log *logger.Logger) {
+	if !cfg.MaintenanceEnabled {
+		log.Info("Posts partition maintenance job disabled")
+		return
+	}
+
+	scheduling := []service.JobOption{service.WithJobJitter(schedulerCfg.StartupJitter), service.WithJobFixedDelay()}
+
+	// Partitions span every tenant, so they are maintained once rather than per tenant
+	scheduler.AddJob("posts-partition-maintenance", cfg.MaintenanceInterval, func(ctx context.Context) error {
+		created, err := partitions.MaintainPostPartitions(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to run posts partition maintenance job: %w", err)
+		}
+
+		log.Info("Posts partition maintenance completed", "created", created)
+		service.SetJobStats(ctx, map[string]int64{"created": int64(created)})
+		return nil
+	}, jobOptions(scheduling)...)
+
+	log.Info("Posts partition jobs configured successfully")
+}
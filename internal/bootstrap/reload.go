@@ -0,0 +1,33 @@
+package bootstrap
+
+import (
+	"errors"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// SetupConfigReload reschedules jobs whose interval changes when the
+// configuration is reloaded.
+func SetupConfigReload(configs service.ConfigService, scheduler service.SchedulerService, log *logger.Logger) {
+	configs.OnReload(func(cfg *config.Config) {
+		for name, interval := range jobIntervals(cfg) {
+			err := scheduler.SetJobInterval(name, interval)
+			if err != nil && !errors.Is(err, service.ErrJobNotFound) {
+				log.Warn("Failed to apply reloaded job interval", "name", name, "error", err.Error())
+			}
+		}
+	})
+}
+
+// jobIntervals maps each scheduled job to its configured interval
+func jobIntervals(cfg *config.Config) map[string]time.Duration {
+	return map[string]time.Duration{
+		"top-headlines":        cfg.Scheduler.HeadlinesInterval,
+		"category-aggregation": cfg.Scheduler.CategoriesInterval,
+		"source-aggregation":   cfg.Scheduler.SourcesInterval,
+		"content-extraction":   cfg.ContentFetch.Interval,
+	}
+}
@@ -0,0 +1,61 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// SetupStatsJobs registers the jobs that roll post activity up into the
+// hourly totals behind the top stats endpoint and that refresh the
+// materialized post count views, each when it is enabled.
+func SetupStatsJobs(scheduler service.SchedulerService, stats service.StatsService, tenants service.TenantService, cfg config.StatsConfig, schedulerCfg config.SchedulerConfig, log *logger.Logger) {
+	scheduling := []service.JobOption{service.WithJobJitter(schedulerCfg.StartupJitter), service.WithJobFixedDelay()}
+
+	if cfg.ViewRefreshEnabled {
+		// The views span every tenant, so they are refreshed once rather than
+		// per tenant; they are also refreshed after content extraction, which
+		// changes the posts they count
+		scheduler.AddJob("stats-view-refresh", cfg.ViewRefreshInterval, func(ctx context.Context) error {
+			log.Info("Running scheduled stats view refresh")
+			if err := stats.RefreshViews(ctx); err != nil {
+				return fmt.Errorf("failed to run stats view refresh job: %w", err)
+			}
+			return nil
+		}, jobOptions(scheduling, service.WithJobAfter("content-extraction"))...)
+	} else {
+		log.Info("Stats view refresh job disabled")
+	}
+
+	if !cfg.RollupEnabled {
+		log.Info("Stats rollup job disabled")
+		return
+	}
+
+	scheduler.AddJob("stats-rollup", cfg.RollupInterval, func(ctx context.Context) error {
+		log.Info("Running scheduled stats rollup")
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			result, err := stats.RollupActivity(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run stats rollup job: %w", err)
+			}
+
+			log.Info("Stats rollup completed",
+				"tenant", t.ID,
+				"since", result.Since,
+				"hours", result.Hours,
+				"pruned", result.Pruned,
+			)
+			return map[string]int64{
+				"hours":  int64(result.Hours),
+				"pruned": result.Pruned,
+			}, nil
+		})
+	}, jobOptions(scheduling)...)
+
+	log.Info("Stats jobs configured successfully")
+}
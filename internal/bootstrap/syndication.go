@@ -0,0 +1,39 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// SetupSyndicationJobs registers the job that regenerates the cached RSS/Atom
+// feeds and sitemap of every active tenant.
+func SetupSyndicationJobs(scheduler service.SchedulerService, syndication service.SyndicationService, tenants service.TenantService, cfg config.SyndicationConfig, schedulerCfg config.SchedulerConfig, log *logger.Logger) {
+	scheduling := []service.JobOption{service.WithJobJitter(schedulerCfg.StartupJitter), service.WithJobFixedDelay()}
+
+	scheduler.AddJob("syndication-refresh", cfg.RefreshInterval, func(ctx context.Context) error {
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			result, err := syndication.Refresh(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to refresh syndication feeds: %w", err)
+			}
+
+			log.Info("Syndication feeds refreshed",
+				"tenant", t.ID,
+				"feeds", result.Feeds,
+				"sitemap_pages", result.SitemapPages,
+			)
+			return map[string]int64{
+				"feeds":         int64(result.Feeds),
+				"sitemap_pages": int64(result.SitemapPages),
+			}, nil
+		})
+	}, jobOptions(scheduling, service.WithJobTimeout(5*time.Minute))...)
+
+	log.Info("Syndication jobs configured successfully")
+}
@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+)
+
+// tenantJobFunc runs one tenant's share of a scheduled job and returns its
+// job history counters
+type tenantJobFunc func(ctx context.Context, t model.Tenant) (map[string]int64, error)
+
+// forEachTenant runs fn once per active tenant with ctx scoped to that tenant.
+// A failing tenant does not stop the others; the counters of every tenant are
+// summed into the job's stats and the failures are returned together.
+func forEachTenant(ctx context.Context, tenants service.TenantService, fn tenantJobFunc) error {
+	active, err := tenants.ActiveTenants(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	totals := make(map[string]int64)
+	var errs []error
+
+	for _, t := range active {
+		stats, err := fn(tenant.WithTenant(ctx, t.ID), t)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", t.ID, err))
+			continue
+		}
+
+		for name, value := range stats {
+			totals[name] += value
+		}
+	}
+
+	service.SetJobStats(ctx, totals)
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// SetupTopicJobs registers the job that clusters new posts into topics when
+// it is enabled.
+func SetupTopicJobs(scheduler service.SchedulerService, topics service.TopicService, tenants service.TenantService, cfg config.TopicConfig, schedulerCfg config.SchedulerConfig, log *logger.Logger) {
+	if !cfg.Enabled {
+		log.Info("Topic clustering job disabled")
+		return
+	}
+
+	scheduling := []service.JobOption{service.WithJobJitter(schedulerCfg.StartupJitter), service.WithJobFixedDelay()}
+
+	scheduler.AddJob("topic-clustering", cfg.Interval, func(ctx context.Context) error {
+		log.Info("Running scheduled topic clustering")
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			result, err := topics.ClusterPendingPosts(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run topic clustering job: %w", err)
+			}
+
+			log.Info("Topic clustering completed",
+				"tenant", t.ID,
+				"processed", result.Processed,
+				"clustered", result.Clustered,
+			)
+			return map[string]int64{
+				"processed": int64(result.Processed),
+				"clustered": int64(result.Clustered),
+			}, nil
+		})
+	}, jobOptions(scheduling)...)
+
+	log.Info("Topic jobs configured successfully")
+}
@@ -0,0 +1,39 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// SetupURLFilterJobs registers the job that rebuilds each tenant's filter of
+// stored post URLs, when the filter is enabled. Until its first run the
+// filter only holds the URLs stored since startup, and the database answers
+// for the rest.
+func SetupURLFilterJobs(scheduler service.SchedulerService, posts service.PostService, tenants service.TenantService, cfg config.CacheConfig, schedulerCfg config.SchedulerConfig, log *logger.Logger) {
+	if !cfg.URLFilterEnabled {
+		log.Info("URL filter rebuild job disabled")
+		return
+	}
+
+	scheduling := []service.JobOption{service.WithJobJitter(schedulerCfg.StartupJitter), service.WithJobFixedDelay()}
+
+	scheduler.AddJob("url-filter-rebuild", cfg.URLFilterRebuildInterval, func(ctx context.Context) error {
+		log.Info("Running scheduled URL filter rebuild")
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			urls, err := posts.RebuildURLFilter(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run URL filter rebuild job: %w", err)
+			}
+
+			log.Info("URL filter rebuild completed", "tenant", t.ID, "urls", urls)
+			return map[string]int64{"urls": int64(urls)}, nil
+		})
+	}, jobOptions(scheduling)...)
+
+	log.Info("URL filter jobs configured successfully")
+}
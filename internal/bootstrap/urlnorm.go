@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// SetupURLBackfillJobs registers the job that normalizes the stored URLs of
+// every tenant's posts, when it is enabled.
+func SetupURLBackfillJobs(scheduler service.SchedulerService, posts service.PostService, tenants service.TenantService, cfg config.URLBackfillConfig, schedulerCfg config.SchedulerConfig, log *logger.Logger) {
+	if !cfg.Enabled {
+		log.Info("URL backfill job disabled")
+		return
+	}
+
+	scheduling := []service.JobOption{service.WithJobJitter(schedulerCfg.StartupJitter), service.WithJobFixedDelay()}
+
+	scheduler.AddJob("url-backfill", cfg.Interval, func(ctx context.Context) error {
+		log.Info("Running scheduled URL backfill")
+		return forEachTenant(ctx, tenants, func(ctx context.Context, t model.Tenant) (map[string]int64, error) {
+			result, err := posts.NormalizePostURLs(ctx, cfg.BatchSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run URL backfill job: %w", err)
+			}
+
+			log.Info("URL backfill completed",
+				"tenant", t.ID,
+				"scanned", result.Scanned,
+				"normalized", result.Normalized,
+				"merged", result.Merged,
+			)
+			return map[string]int64{
+				"scanned":    int64(result.Scanned),
+				"normalized": int64(result.Normalized),
+				"merged":     int64(result.Merged),
+			}, nil
+		})
+	}, jobOptions(scheduling, service.WithJobTimeout(30*time.Minute))...)
+
+	log.Info("URL backfill jobs configured successfully")
+}
@@ -1,24 +1,52 @@
 package config
 
 import (
+	"compress/gzip"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/labstack/gommon/bytes"
 )
 
 type Config struct {
-	Database     DatabaseConfig
-	DatabasePool DatabasePoolConfig
-	Redis        RedisConfig
-	Server       ServerConfig
-	NewsAPI      NewsAPIConfig
-	App          AppConfig
-	Cache        CacheConfig
-	CORS         CORSConfig
+	Database       DatabaseConfig
+	DatabasePool   DatabasePoolConfig
+	Redis          RedisConfig
+	Server         ServerConfig
+	NewsAPI        NewsAPIConfig
+	App            AppConfig
+	Cache          CacheConfig
+	CORS           CORSConfig
+	Scheduler      SchedulerConfig
+	ContentFetch   ContentFetchConfig
+	Filter         FilterConfig
+	Classifier     ClassifierConfig
+	Image          ImageConfig
+	Syndication    SyndicationConfig
+	Tenant         TenantConfig
+	Search         SearchConfig
+	Topic          TopicConfig
+	Stats          StatsConfig
+	Partition      PartitionConfig
+	Changes        ChangesConfig
+	Pins           PinConfig
+	URLBackfill    URLBackfillConfig
+	Ingest         IngestConfig
+	RequestLog     RequestLogConfig
+	ErrorReporting ErrorReportingConfig
+	Security       SecurityHeadersConfig
+	Bot            BotConfig
+	Access         AccessConfig
+	Schema         SchemaConfig
 }
 
 type DatabaseConfig struct {
@@ -31,37 +59,410 @@ type DatabaseConfig struct {
 }
 
 type DatabasePoolConfig struct {
-	MaxConns          int
-	MinConns          int
-	MaxConnLifetime   time.Duration
-	MaxConnIdleTime   time.Duration
-	HealthCheckPeriod time.Duration
+	MaxConns                 int
+	MinConns                 int
+	MaxConnLifetime          time.Duration
+	MaxConnIdleTime          time.Duration
+	HealthCheckPeriod        time.Duration
+	ReplicaURLs              []string
+	ReplicaHealthCheckPeriod time.Duration
+	// Queries taking at least SlowQueryThreshold are logged with their SQL;
+	// zero disables slow query logging
+	SlowQueryThreshold time.Duration
 }
 
 type RedisConfig struct {
-	Host     string
-	Port     int
-	Password string
-	DB       int
+	// Mode is standalone, sentinel or cluster. Standalone connects to Host
+	// and Port; sentinel asks the sentinels in Addrs for the master named
+	// MasterName; cluster discovers the cluster from the seed nodes in Addrs.
+	Mode       string
+	Host       string
+	Port       int
+	Addrs      []string
+	MasterName string
+	Username   string
+	Password   string
+	// Sentinels may require credentials of their own
+	SentinelUsername string
+	SentinelPassword string
+	// DB is ignored in cluster mode, which only has database 0
+	DB int
+	// TLSCAFile verifies the server against a private CA instead of the
+	// system roots; TLSServerName overrides the name checked in its
+	// certificate
+	TLSEnabled            bool
+	TLSCAFile             string
+	TLSServerName         string
+	TLSInsecureSkipVerify bool
 }
 
 type ServerConfig struct {
 	Host string
 	Port int
+	// Compression gzips responses of at least CompressionMinLength bytes
+	CompressionEnabled   bool
+	CompressionLevel     int
+	CompressionMinLength int
+	// BodyLimit caps request bodies, e.g. "1M"; larger requests get 413
+	BodyLimitEnabled bool
+	BodyLimit        string
+	// Timeouts bound slow clients; zero disables the corresponding timeout
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// Handlers still running after RequestTimeout, or AggregationTimeout for
+	// aggregation triggers, are cancelled with a 504; zero disables the timeout
+	RequestTimeout     time.Duration
+	AggregationTimeout time.Duration
+	// TLS is served in-process when both files are set
+	TLSCertFile  string
+	TLSKeyFile   string
+	HTTP2Enabled bool
+	// MetricsEnabled serves connection pool and query metrics at /metrics
+	MetricsEnabled bool
+	// ReadOnly starts the API in read-only mode, turning away mutating
+	// requests and pausing scheduled jobs until an admin leaves it
+	ReadOnly bool
 }
 
+// NewsAPIConfig configures the NewsAPI client. A query whose results span
+// several pages fetches at most MaxPages of them. The upstream source catalog
+// is cached for SourcesCacheTTL.
 type NewsAPIConfig struct {
-	APIKey  string
-	BaseURL string
+	APIKey          string
+	BaseURL         string
+	Countries       []string
+	UpsertArticles  bool
+	MaxPages        int
+	SourcesCacheTTL time.Duration
 }
 
+// AppConfig configures the application and its logs. Logs go to each of
+// LogSinks: stdout, a file rotated by size and age, and syslog. A sink logs at
+// its own level when one is set and at LogLevel otherwise. With LogAsync set,
+// stdout and file entries are written in the background from a buffer of
+// LogAsyncBufferSize entries.
 type AppConfig struct {
 	Environment string
 	LogLevel    string
+
+	LogSinks       []string
+	LogStdoutLevel string
+
+	LogFilePath       string
+	LogFileLevel      string
+	LogFileMaxSizeMB  int
+	LogFileMaxBackups int
+	LogFileMaxAgeDays int
+	LogFileCompress   bool
+
+	// An empty LogSyslogNetwork logs to the local syslog daemon
+	LogSyslogNetwork string
+	LogSyslogAddress string
+	LogSyslogTag     string
+	LogSyslogLevel   string
+
+	LogAsync           bool
+	LogAsyncBufferSize int
 }
 
 type CacheConfig struct {
-	TTL time.Duration
+	TTL        time.Duration
+	SWREnabled bool
+	SoftTTL    time.Duration
+	HardTTL    time.Duration
+	L1Enabled  bool
+	L1Size     int
+	L1TTL      time.Duration
+	// Replicas broadcast the L1 keys they invalidate on
+	// L1InvalidationChannel, so the others drop their copies before
+	// L1TTL runs out
+	L1InvalidationEnabled bool
+	L1InvalidationChannel string
+	// The hottest keys are refreshed at random shortly before going stale,
+	// more eagerly the larger EarlyRefreshBeta
+	EarlyRefreshEnabled bool
+	EarlyRefreshBeta    float64
+	// NotFoundTTL is how long a post ID found missing is remembered; zero
+	// disables negative caching
+	NotFoundTTL time.Duration
+	// The URL filter, a Bloom filter of stored post URLs in Redis, answers
+	// most duplicate checks of fetched articles without the database. It is
+	// sized for URLFilterExpectedURLs at URLFilterFalsePositiveRate, the
+	// share of new articles wrongly skipped as duplicates, and rebuilt every
+	// URLFilterRebuildInterval to drop deleted posts.
+	URLFilterEnabled           bool
+	URLFilterExpectedURLs      int
+	URLFilterFalsePositiveRate float64
+	URLFilterRebuildInterval   time.Duration
+}
+
+type SchedulerConfig struct {
+	StartupJitter      time.Duration
+	Mode               string
+	HeadlinesInterval  time.Duration
+	CategoriesInterval time.Duration
+	SourcesInterval    time.Duration
+	// One-time jobs due are looked for every OncePollInterval. A claimed
+	// job is hidden from other replicas for OnceLease and runs again after
+	// it when its replica died before finishing it.
+	OncePollInterval time.Duration
+	OnceLease        time.Duration
+	// QuietHours holds job=HH:MM-HH:MM[,HH:MM-HH:MM] entries, see
+	// ParseQuietHours
+	QuietHours []string
+}
+
+// QuietWindow is a daily span of UTC time in which a scheduled job does not
+// run, from Start up to End, both offsets from midnight. A window whose End
+// comes before its Start spans midnight.
+type QuietWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// String formats the window as HH:MM-HH:MM
+func (w QuietWindow) String() string {
+	format := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+
+	return format(w.Start) + "-" + format(w.End)
+}
+
+// ContentFetchConfig controls the job that downloads articles to replace
+// the truncated NewsAPI content with the full text
+type ContentFetchConfig struct {
+	Enabled    bool
+	Sources    []string
+	Interval   time.Duration
+	BatchSize  int
+	Delay      time.Duration
+	MaxPerHost int
+	RobotsTTL  time.Duration
+	Timeout    time.Duration
+	MaxBytes   int64
+	UserAgent  string
+}
+
+// FilterConfig controls the spam and quality rules applied to aggregated
+// articles before they are stored
+type FilterConfig struct {
+	BlockedDomains   []string
+	TitlePatterns    []string
+	MinContentLength int
+	Clickbait        bool
+	Quarantine       bool
+}
+
+// ClassifierConfig controls how posts are flagged as sensitive. Keywords are
+// always checked; URL optionally points at an ML endpoint consulted first.
+type ClassifierConfig struct {
+	Keywords []string
+	URL      string
+	Timeout  time.Duration
+}
+
+// ImageConfig controls how the image URLs of ingested articles are cleaned.
+// With HTTPSUpgrade set, http:// images are switched to https:// when a HEAD
+// request over HTTPS succeeds within ProbeTimeout; the outcome for each host
+// is remembered for ProbeCacheTTL.
+type ImageConfig struct {
+	HTTPSUpgrade  bool
+	ProbeTimeout  time.Duration
+	ProbeCacheTTL time.Duration
+}
+
+// SyndicationConfig controls the RSS/Atom feeds and sitemap. BaseURL is the
+// public address links in the generated documents point at.
+type SyndicationConfig struct {
+	BaseURL         string
+	Title           string
+	FeedSize        int
+	SitemapPageSize int
+	RefreshInterval time.Duration
+}
+
+// TenantConfig controls multi-tenancy. When enabled, each request is scoped to
+// the tenant named in Header or, failing that, the subdomain of BaseDomain it
+// was sent to; requests naming neither use the default tenant.
+type TenantConfig struct {
+	Enabled    bool
+	Header     string
+	BaseDomain string
+	CacheTTL   time.Duration
+}
+
+// SearchConfig controls search result highlighting. Matches in highlighted
+// titles and descriptions are wrapped in HighlightStart and HighlightStop.
+type SearchConfig struct {
+	HighlightStart string
+	HighlightStop  string
+}
+
+// TopicConfig controls the job that clusters posts covering the same story
+// into topics. Posts published within Window of each other join a topic when
+// the similarity of their titles reaches Threshold.
+type TopicConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	BatchSize int
+	Window    time.Duration
+	Threshold float64
+}
+
+// StatsConfig controls the job that rolls post activity up into hourly
+// totals per source and category for the top stats endpoint. Totals older
+// than Retention are pruned, so it must cover the longest ranking period.
+//
+// It also controls the job refreshing the materialized views behind post
+// counts and statistics. A view older than ViewStaleTolerance is bypassed for
+// live queries; zero never reads the views.
+//
+// Repeat reads of a post by the same viewer within ViewerDedupWindow count
+// as one post view; zero counts every read.
+type StatsConfig struct {
+	RollupEnabled       bool
+	RollupInterval      time.Duration
+	Retention           time.Duration
+	ViewRefreshEnabled  bool
+	ViewRefreshInterval time.Duration
+	ViewStaleTolerance  time.Duration
+	ViewerDedupWindow   time.Duration
+}
+
+// PartitionConfig controls the job that creates the monthly partitions of
+// the posts table ahead of time. Each run makes sure the current month and
+// the MonthsAhead following ones have a partition.
+type PartitionConfig struct {
+	MaintenanceEnabled  bool
+	MaintenanceInterval time.Duration
+	MonthsAhead         int
+}
+
+// ChangesConfig controls the post change feed. Deleted posts leave a
+// tombstone that is kept for Retention, so a client polling less often than
+// that must sync from scratch. The prune job drops older tombstones every
+// PruneInterval.
+type ChangesConfig struct {
+	Retention     time.Duration
+	PruneEnabled  bool
+	PruneInterval time.Duration
+}
+
+// PinConfig limits editorial pinning. Pinned posts are listed at the top of
+// the post list and the ranked feed in one of MaxPosts positions.
+type PinConfig struct {
+	MaxPosts int
+}
+
+// URLBackfillConfig schedules the job rewriting stored post URLs into their
+// normalized form, deleting the duplicates that turn up. Each run pages
+// through every post, BatchSize at a time.
+type URLBackfillConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	BatchSize int
+}
+
+// IngestConfig sizes the worker pool that stores fetched articles. Workers
+// bounds the database connections used by aggregation; articles wait in a
+// queue of QueueSize while every worker is busy.
+//
+// With Incremental set, runs without an explicit date range resume from where
+// the previous run left off, less Overlap to allow for late indexing, and stop
+// paging once they reach articles already seen.
+type IngestConfig struct {
+	Workers     int
+	QueueSize   int
+	Incremental bool
+	Overlap     time.Duration
+}
+
+// RequestLogConfig controls the access log. Failed requests and those taking
+// at least SlowThreshold are always logged; the rest are logged with
+// probability SampleRate. At the debug level, request and response bodies of
+// up to MaxBodySize bytes are logged too, with the values of JSON fields named
+// in RedactFields masked.
+type RequestLogConfig struct {
+	SampleRate    float64
+	SlowThreshold time.Duration
+	MaxBodySize   int
+	RedactFields  []string
+}
+
+// ErrorReportingConfig forwards error logs and recovered panics to Sentry
+// when DSN is set. SampleRate is the share of events sent, with zero
+// disabling reporting, and Environment tags them, defaulting to the
+// application environment. Pending events are
+// sent for up to FlushTimeout on shutdown.
+type ErrorReportingConfig struct {
+	DSN          string
+	SampleRate   float64
+	Environment  string
+	FlushTimeout time.Duration
+}
+
+// SecurityHeadersConfig sets the security headers of every response, with
+// an empty value omitting its header. ContentSecurityPolicy covers the API and
+// SwaggerContentSecurityPolicy the Swagger UI, which runs scripts and styles
+// of its own. Strict-Transport-Security is only sent on TLS requests, and
+// only when HSTSMaxAge is positive.
+type SecurityHeadersConfig struct {
+	Enabled                      bool
+	ContentTypeOptions           string
+	FrameOptions                 string
+	ReferrerPolicy               string
+	ContentSecurityPolicy        string
+	SwaggerContentSecurityPolicy string
+	HSTSMaxAge                   int
+	HSTSIncludeSubdomains        bool
+	HSTSPreload                  bool
+}
+
+// BotConfig controls the detection of bots and crawlers, whose views and
+// clicks are left out of analytics. A request comes from a bot when its user
+// agent is a known crawler or script or contains one of UserAgents, or when
+// its client made more than BehaviorThreshold requests within BehaviorWindow;
+// a zero threshold only looks at user agents. Bots are limited to RateLimit
+// requests per second per client with bursts of RateBurst, zero not limiting
+// them.
+type BotConfig struct {
+	Enabled           bool
+	UserAgents        []string
+	BehaviorThreshold int
+	BehaviorWindow    time.Duration
+	RateLimit         float64
+	RateBurst         int
+}
+
+// AccessConfig restricts the administrative routes, under /admin, /scheduler
+// and /aggregation, to clients in AdminAllowlist, a list of CIDR ranges or
+// single addresses; an empty list leaves them open. A client is the address
+// of the connection unless that is one of TrustedProxies, in which case the
+// X-Forwarded-For header names it.
+type AccessConfig struct {
+	AdminAllowlist []string
+	TrustedProxies []string
+}
+
+// Schema mismatch actions, what the server does on startup when the database
+// schema is not the one it was written against
+const (
+	SchemaMismatchRefuse   = "refuse"
+	SchemaMismatchReadOnly = "read_only"
+	SchemaMismatchIgnore   = "ignore"
+)
+
+// SchemaConfig controls the startup check of the database schema version.
+// OnMismatch is one of the schema mismatch actions: refuse to start, start
+// answering only reads, or carry on. AllowNewer accepts a database migrated
+// past the build, for deploys whose migrations stay compatible with the
+// previous release so it can keep running while the next one rolls out.
+type SchemaConfig struct {
+	OnMismatch string
+	AllowNewer bool
 }
 
 type CORSConfig struct {
@@ -77,6 +478,19 @@ type CORSConfig struct {
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
+	return load()
+}
+
+// Reload re-reads the configuration, letting values in .env override the
+// process environment so edits to the file take effect without a restart
+func Reload() (*Config, error) {
+	_ = godotenv.Overload()
+
+	return load()
+}
+
+// load builds and validates the configuration from the environment
+func load() (*Config, error) {
 	config := &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -87,32 +501,98 @@ func Load() (*Config, error) {
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		},
 		DatabasePool: DatabasePoolConfig{
-			MaxConns:          getEnvInt("DB_MAX_CONNS", 25),
-			MinConns:          getEnvInt("DB_MIN_CONNS", 5),
-			MaxConnLifetime:   getEnvDuration("DB_MAX_CONN_LIFETIME", time.Hour),
-			MaxConnIdleTime:   getEnvDuration("DB_MAX_CONN_IDLE_TIME", 30*time.Minute),
-			HealthCheckPeriod: getEnvDuration("DB_HEALTH_CHECK_PERIOD", time.Minute),
+			MaxConns:                 getEnvInt("DB_MAX_CONNS", 25),
+			MinConns:                 getEnvInt("DB_MIN_CONNS", 5),
+			MaxConnLifetime:          getEnvDuration("DB_MAX_CONN_LIFETIME", time.Hour),
+			MaxConnIdleTime:          getEnvDuration("DB_MAX_CONN_IDLE_TIME", 30*time.Minute),
+			HealthCheckPeriod:        getEnvDuration("DB_HEALTH_CHECK_PERIOD", time.Minute),
+			ReplicaURLs:              getEnvStringSlice("DB_REPLICA_URLS", []string{}),
+			ReplicaHealthCheckPeriod: getEnvDuration("DB_REPLICA_HEALTH_CHECK_PERIOD", 15*time.Second),
+			SlowQueryThreshold:       getEnvDuration("DB_SLOW_QUERY_THRESHOLD", 500*time.Millisecond),
 		},
 		Redis: RedisConfig{
-			Host:     getEnv("REDIS_HOST", "localhost"),
-			Port:     getEnvInt("REDIS_PORT", 6379),
-			Password: getEnv("REDIS_PASSWORD", ""),
-			DB:       getEnvInt("REDIS_DB", 0),
+			Mode:                  getEnv("REDIS_MODE", "standalone"),
+			Host:                  getEnv("REDIS_HOST", "localhost"),
+			Port:                  getEnvInt("REDIS_PORT", 6379),
+			Addrs:                 getEnvStringSlice("REDIS_ADDRS", []string{}),
+			MasterName:            getEnv("REDIS_SENTINEL_MASTER", ""),
+			Username:              getEnv("REDIS_USERNAME", ""),
+			Password:              getEnv("REDIS_PASSWORD", ""),
+			SentinelUsername:      getEnv("REDIS_SENTINEL_USERNAME", ""),
+			SentinelPassword:      getEnv("REDIS_SENTINEL_PASSWORD", ""),
+			DB:                    getEnvInt("REDIS_DB", 0),
+			TLSEnabled:            getEnvBool("REDIS_TLS_ENABLED", false),
+			TLSCAFile:             getEnv("REDIS_TLS_CA_FILE", ""),
+			TLSServerName:         getEnv("REDIS_TLS_SERVER_NAME", ""),
+			TLSInsecureSkipVerify: getEnvBool("REDIS_TLS_INSECURE_SKIP_VERIFY", false),
 		},
 		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "localhost"),
-			Port: getEnvInt("SERVER_PORT", 8080),
+			Host:                 getEnv("SERVER_HOST", "localhost"),
+			Port:                 getEnvInt("SERVER_PORT", 8080),
+			CompressionEnabled:   getEnvBool("SERVER_COMPRESSION_ENABLED", true),
+			CompressionLevel:     getEnvInt("SERVER_COMPRESSION_LEVEL", gzip.DefaultCompression),
+			CompressionMinLength: getEnvInt("SERVER_COMPRESSION_MIN_LENGTH", 1024),
+			BodyLimitEnabled:     getEnvBool("SERVER_BODY_LIMIT_ENABLED", true),
+			BodyLimit:            getEnv("SERVER_BODY_LIMIT", "1M"),
+			ReadTimeout:          getEnvDuration("SERVER_READ_TIMEOUT", 15*time.Second),
+			ReadHeaderTimeout:    getEnvDuration("SERVER_READ_HEADER_TIMEOUT", 5*time.Second),
+			WriteTimeout:         getEnvDuration("SERVER_WRITE_TIMEOUT", 60*time.Second),
+			IdleTimeout:          getEnvDuration("SERVER_IDLE_TIMEOUT", 120*time.Second),
+			RequestTimeout:       getEnvDuration("SERVER_REQUEST_TIMEOUT", 10*time.Second),
+			AggregationTimeout:   getEnvDuration("SERVER_AGGREGATION_TIMEOUT", 55*time.Second),
+			TLSCertFile:          getEnv("SERVER_TLS_CERT_FILE", ""),
+			TLSKeyFile:           getEnv("SERVER_TLS_KEY_FILE", ""),
+			HTTP2Enabled:         getEnvBool("SERVER_HTTP2_ENABLED", true),
+			MetricsEnabled:       getEnvBool("METRICS_ENABLED", true),
+			ReadOnly:             getEnvBool("READ_ONLY_MODE", false),
 		},
 		NewsAPI: NewsAPIConfig{
-			APIKey:  getEnv("NEWS_API_KEY", ""),
-			BaseURL: getEnv("NEWS_API_BASE_URL", "https://newsapi.org/v2"),
+			APIKey:          getEnv("NEWS_API_KEY", ""),
+			BaseURL:         getEnv("NEWS_API_BASE_URL", "https://newsapi.org/v2"),
+			Countries:       getEnvStringSlice("NEWS_API_COUNTRIES", []string{"us"}),
+			UpsertArticles:  getEnvBool("NEWS_API_UPSERT_ARTICLES", false),
+			MaxPages:        getEnvInt("NEWS_API_MAX_PAGES", 5),
+			SourcesCacheTTL: getEnvDuration("NEWS_API_SOURCES_CACHE_TTL", 6*time.Hour),
 		},
 		App: AppConfig{
 			Environment: getEnv("APP_ENV", "development"),
 			LogLevel:    getEnv("LOG_LEVEL", "info"),
+
+			LogSinks:       getEnvStringSlice("LOG_SINKS", []string{"stdout"}),
+			LogStdoutLevel: getEnv("LOG_STDOUT_LEVEL", ""),
+
+			LogFilePath:       getEnv("LOG_FILE_PATH", "logs/news-feed.log"),
+			LogFileLevel:      getEnv("LOG_FILE_LEVEL", ""),
+			LogFileMaxSizeMB:  getEnvInt("LOG_FILE_MAX_SIZE_MB", 100),
+			LogFileMaxBackups: getEnvInt("LOG_FILE_MAX_BACKUPS", 5),
+			LogFileMaxAgeDays: getEnvInt("LOG_FILE_MAX_AGE_DAYS", 28),
+			LogFileCompress:   getEnvBool("LOG_FILE_COMPRESS", true),
+
+			LogSyslogNetwork: getEnv("LOG_SYSLOG_NETWORK", ""),
+			LogSyslogAddress: getEnv("LOG_SYSLOG_ADDRESS", ""),
+			LogSyslogTag:     getEnv("LOG_SYSLOG_TAG", "news-feed-system"),
+			LogSyslogLevel:   getEnv("LOG_SYSLOG_LEVEL", ""),
+
+			LogAsync:           getEnvBool("LOG_ASYNC", false),
+			LogAsyncBufferSize: getEnvInt("LOG_ASYNC_BUFFER_SIZE", 1024),
 		},
 		Cache: CacheConfig{
-			TTL: getEnvDuration("CACHE_TTL", 3600*time.Second),
+			TTL:                        getEnvDuration("CACHE_TTL", 3600*time.Second),
+			SWREnabled:                 getEnvBool("CACHE_SWR_ENABLED", false),
+			SoftTTL:                    getEnvDuration("CACHE_SOFT_TTL", 5*time.Minute),
+			HardTTL:                    getEnvDuration("CACHE_HARD_TTL", 3600*time.Second),
+			L1Enabled:                  getEnvBool("CACHE_L1_ENABLED", false),
+			L1Size:                     getEnvInt("CACHE_L1_SIZE", 10000),
+			L1TTL:                      getEnvDuration("CACHE_L1_TTL", 5*time.Second),
+			L1InvalidationEnabled:      getEnvBool("CACHE_L1_INVALIDATION_ENABLED", true),
+			L1InvalidationChannel:      getEnv("CACHE_L1_INVALIDATION_CHANNEL", "cache:l1:invalidate"),
+			EarlyRefreshEnabled:        getEnvBool("CACHE_EARLY_REFRESH_ENABLED", true),
+			EarlyRefreshBeta:           getEnvFloat("CACHE_EARLY_REFRESH_BETA", 1),
+			NotFoundTTL:                getEnvDuration("CACHE_NOT_FOUND_TTL", 30*time.Second),
+			URLFilterEnabled:           getEnvBool("CACHE_URL_FILTER_ENABLED", true),
+			URLFilterExpectedURLs:      getEnvInt("CACHE_URL_FILTER_EXPECTED_URLS", 1000000),
+			URLFilterFalsePositiveRate: getEnvFloat("CACHE_URL_FILTER_FALSE_POSITIVE_RATE", 0.000001),
+			URLFilterRebuildInterval:   getEnvDuration("CACHE_URL_FILTER_REBUILD_INTERVAL", 6*time.Hour),
 		},
 		CORS: CORSConfig{
 			AllowOrigins: getEnvStringSlice("CORS_ALLOW_ORIGINS", []string{"*"}),
@@ -120,12 +600,153 @@ func Load() (*Config, error) {
 				"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS",
 			}),
 			AllowHeaders: getEnvStringSlice("CORS_ALLOW_HEADERS", []string{
-				"Origin", "Content-Type", "Accept",
+				"Origin", "Content-Type", "Accept", "If-Match",
 			}),
-			ExposeHeaders:    getEnvStringSlice("CORS_EXPOSE_HEADERS", []string{}),
+			ExposeHeaders:    getEnvStringSlice("CORS_EXPOSE_HEADERS", []string{"ETag", "X-Request-ID"}),
 			AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", false),
 			MaxAge:           getEnvInt("CORS_MAX_AGE", 86400),
 		},
+		Scheduler: SchedulerConfig{
+			StartupJitter:      getEnvDuration("SCHEDULER_STARTUP_JITTER", time.Minute),
+			Mode:               getEnv("SCHEDULER_MODE", "fixed_rate"),
+			HeadlinesInterval:  getEnvDuration("SCHEDULER_HEADLINES_INTERVAL", 30*time.Minute),
+			CategoriesInterval: getEnvDuration("SCHEDULER_CATEGORIES_INTERVAL", 2*time.Hour),
+			SourcesInterval:    getEnvDuration("SCHEDULER_SOURCES_INTERVAL", 4*time.Hour),
+			OncePollInterval:   getEnvDuration("SCHEDULER_ONCE_POLL_INTERVAL", time.Second),
+			OnceLease:          getEnvDuration("SCHEDULER_ONCE_LEASE", 15*time.Minute),
+			QuietHours:         getEnvSeparatedSlice("SCHEDULER_QUIET_HOURS", ";", nil),
+		},
+		Filter: FilterConfig{
+			BlockedDomains:   getEnvStringSlice("FILTER_BLOCKED_DOMAINS", []string{}),
+			TitlePatterns:    getEnvSeparatedSlice("FILTER_TITLE_PATTERNS", ";", []string{}),
+			MinContentLength: getEnvInt("FILTER_MIN_CONTENT_LENGTH", 0),
+			Clickbait:        getEnvBool("FILTER_CLICKBAIT", false),
+			Quarantine:       getEnvBool("FILTER_QUARANTINE", false),
+		},
+		Classifier: ClassifierConfig{
+			Keywords: getEnvStringSlice("SENSITIVE_KEYWORDS", []string{}),
+			URL:      getEnv("SENSITIVE_CLASSIFIER_URL", ""),
+			Timeout:  getEnvDuration("SENSITIVE_CLASSIFIER_TIMEOUT", 2*time.Second),
+		},
+		Image: ImageConfig{
+			HTTPSUpgrade:  getEnvBool("IMAGE_HTTPS_UPGRADE", true),
+			ProbeTimeout:  getEnvDuration("IMAGE_PROBE_TIMEOUT", 3*time.Second),
+			ProbeCacheTTL: getEnvDuration("IMAGE_PROBE_CACHE_TTL", 24*time.Hour),
+		},
+		ContentFetch: ContentFetchConfig{
+			Enabled:    getEnvBool("CONTENT_FETCH_ENABLED", false),
+			Sources:    getEnvStringSlice("CONTENT_FETCH_SOURCES", []string{}),
+			Interval:   getEnvDuration("CONTENT_FETCH_INTERVAL", 15*time.Minute),
+			BatchSize:  getEnvInt("CONTENT_FETCH_BATCH_SIZE", 50),
+			Delay:      getEnvDuration("CONTENT_FETCH_DELAY", 2*time.Second),
+			MaxPerHost: getEnvInt("CONTENT_FETCH_MAX_PER_HOST", 1),
+			RobotsTTL:  getEnvDuration("CONTENT_FETCH_ROBOTS_TTL", 24*time.Hour),
+			Timeout:    getEnvDuration("CONTENT_FETCH_TIMEOUT", 15*time.Second),
+			MaxBytes:   int64(getEnvInt("CONTENT_FETCH_MAX_BYTES", 2<<20)),
+			UserAgent:  getEnv("CONTENT_FETCH_USER_AGENT", "news-feed-system/1.0"),
+		},
+		Syndication: SyndicationConfig{
+			BaseURL:         strings.TrimRight(getEnv("SYNDICATION_BASE_URL", "http://localhost:8080"), "/"),
+			Title:           getEnv("SYNDICATION_TITLE", "News Feed"),
+			FeedSize:        getEnvInt("SYNDICATION_FEED_SIZE", 50),
+			SitemapPageSize: getEnvInt("SYNDICATION_SITEMAP_PAGE_SIZE", 1000),
+			RefreshInterval: getEnvDuration("SYNDICATION_REFRESH_INTERVAL", 15*time.Minute),
+		},
+		Tenant: TenantConfig{
+			Enabled:    getEnvBool("TENANT_ENABLED", false),
+			Header:     getEnv("TENANT_HEADER", "X-Tenant-ID"),
+			BaseDomain: strings.ToLower(strings.Trim(getEnv("TENANT_BASE_DOMAIN", ""), ".")),
+			CacheTTL:   getEnvDuration("TENANT_CACHE_TTL", time.Minute),
+		},
+		Search: SearchConfig{
+			HighlightStart: getEnv("SEARCH_HIGHLIGHT_START", "<em>"),
+			HighlightStop:  getEnv("SEARCH_HIGHLIGHT_STOP", "</em>"),
+		},
+		Topic: TopicConfig{
+			Enabled:   getEnvBool("TOPIC_CLUSTERING_ENABLED", true),
+			Interval:  getEnvDuration("TOPIC_CLUSTERING_INTERVAL", 5*time.Minute),
+			BatchSize: getEnvInt("TOPIC_CLUSTERING_BATCH_SIZE", 500),
+			Window:    getEnvDuration("TOPIC_CLUSTERING_WINDOW", 48*time.Hour),
+			Threshold: getEnvFloat("TOPIC_CLUSTERING_THRESHOLD", 0.6),
+		},
+		Stats: StatsConfig{
+			RollupEnabled:  getEnvBool("STATS_ROLLUP_ENABLED", true),
+			RollupInterval: getEnvDuration("STATS_ROLLUP_INTERVAL", 5*time.Minute),
+			Retention:      getEnvDuration("STATS_RETENTION", 8*24*time.Hour),
+
+			ViewRefreshEnabled:  getEnvBool("STATS_VIEW_REFRESH_ENABLED", true),
+			ViewRefreshInterval: getEnvDuration("STATS_VIEW_REFRESH_INTERVAL", 5*time.Minute),
+			ViewStaleTolerance:  getEnvDuration("STATS_VIEW_STALE_TOLERANCE", 15*time.Minute),
+
+			ViewerDedupWindow: getEnvDuration("STATS_VIEWER_DEDUP_WINDOW", 30*time.Minute),
+		},
+		Partition: PartitionConfig{
+			MaintenanceEnabled:  getEnvBool("POSTS_PARTITION_MAINTENANCE_ENABLED", true),
+			MaintenanceInterval: getEnvDuration("POSTS_PARTITION_MAINTENANCE_INTERVAL", 12*time.Hour),
+			MonthsAhead:         getEnvInt("POSTS_PARTITION_MONTHS_AHEAD", 3),
+		},
+		Changes: ChangesConfig{
+			Retention:     getEnvDuration("POST_CHANGES_RETENTION", 30*24*time.Hour),
+			PruneEnabled:  getEnvBool("POST_CHANGES_PRUNE_ENABLED", true),
+			PruneInterval: getEnvDuration("POST_CHANGES_PRUNE_INTERVAL", 6*time.Hour),
+		},
+		Pins: PinConfig{
+			MaxPosts: getEnvInt("PINNED_POSTS_MAX", 5),
+		},
+		URLBackfill: URLBackfillConfig{
+			Enabled:   getEnvBool("URL_BACKFILL_ENABLED", true),
+			Interval:  getEnvDuration("URL_BACKFILL_INTERVAL", 24*time.Hour),
+			BatchSize: getEnvInt("URL_BACKFILL_BATCH_SIZE", 500),
+		},
+		Ingest: IngestConfig{
+			Workers:     getEnvInt("INGEST_WORKERS", 4),
+			QueueSize:   getEnvInt("INGEST_QUEUE_SIZE", 100),
+			Incremental: getEnvBool("INGEST_INCREMENTAL", true),
+			Overlap:     getEnvDuration("INGEST_OVERLAP", 15*time.Minute),
+		},
+		RequestLog: RequestLogConfig{
+			SampleRate:    getEnvFloat("REQUEST_LOG_SAMPLE_RATE", 1),
+			SlowThreshold: getEnvDuration("REQUEST_LOG_SLOW_THRESHOLD", time.Second),
+			MaxBodySize:   getEnvInt("REQUEST_LOG_MAX_BODY_SIZE", 4096),
+			RedactFields: getEnvStringSlice("REQUEST_LOG_REDACT_FIELDS", []string{
+				"password", "token", "secret", "api_key", "news_api_key", "authorization",
+			}),
+		},
+		ErrorReporting: ErrorReportingConfig{
+			DSN:          getEnv("SENTRY_DSN", ""),
+			SampleRate:   getEnvFloat("SENTRY_SAMPLE_RATE", 1),
+			Environment:  getEnv("SENTRY_ENVIRONMENT", ""),
+			FlushTimeout: getEnvDuration("SENTRY_FLUSH_TIMEOUT", 2*time.Second),
+		},
+		Security: SecurityHeadersConfig{
+			Enabled:            getEnvBool("SECURITY_HEADERS_ENABLED", true),
+			ContentTypeOptions: getEnv("SECURITY_CONTENT_TYPE_OPTIONS", "nosniff"),
+			FrameOptions:       getEnv("SECURITY_FRAME_OPTIONS", "DENY"),
+			ReferrerPolicy:     getEnv("SECURITY_REFERRER_POLICY", "strict-origin-when-cross-origin"),
+			ContentSecurityPolicy: getEnv("SECURITY_CONTENT_SECURITY_POLICY",
+				"default-src 'none'; frame-ancestors 'none'"),
+			SwaggerContentSecurityPolicy: getEnv("SECURITY_SWAGGER_CONTENT_SECURITY_POLICY",
+				"default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'"),
+			HSTSMaxAge:            getEnvInt("SECURITY_HSTS_MAX_AGE", defaultHSTSMaxAge(getEnv("APP_ENV", "development"))),
+			HSTSIncludeSubdomains: getEnvBool("SECURITY_HSTS_INCLUDE_SUBDOMAINS", false),
+			HSTSPreload:           getEnvBool("SECURITY_HSTS_PRELOAD", false),
+		},
+		Bot: BotConfig{
+			Enabled:           getEnvBool("BOT_DETECTION_ENABLED", true),
+			UserAgents:        getEnvStringSlice("BOT_USER_AGENTS", nil),
+			BehaviorThreshold: getEnvInt("BOT_BEHAVIOR_THRESHOLD", 300),
+			BehaviorWindow:    getEnvDuration("BOT_BEHAVIOR_WINDOW", time.Minute),
+			RateLimit:         getEnvFloat("BOT_RATE_LIMIT", 2),
+			RateBurst:         getEnvInt("BOT_RATE_BURST", 10),
+		},
+		Access: AccessConfig{
+			AdminAllowlist: getEnvStringSlice("ADMIN_ALLOWED_CIDRS", nil),
+			TrustedProxies: getEnvStringSlice("TRUSTED_PROXIES", nil),
+		},
+		Schema: SchemaConfig{
+			OnMismatch: getEnv("SCHEMA_MISMATCH_ACTION", SchemaMismatchRefuse),
+			AllowNewer: getEnvBool("SCHEMA_ALLOW_NEWER", false),
+		},
 	}
 
 	if err := config.validate(); err != nil {
@@ -135,17 +756,605 @@ func Load() (*Config, error) {
 	return config, nil
 }
 
-// validate checks if required configuration values are present
+// validate checks that required configuration values are present and that
+// values are within bounds, reporting every problem found
 func (c *Config) validate() error {
+	var errs []error
+
 	if c.Database.Password == "" {
-		return fmt.Errorf("database password is required")
+		errs = append(errs, fmt.Errorf("database password is required"))
 	}
 
+	errs = append(errs, c.App.validateLogging()...)
+
 	if c.NewsAPI.APIKey == "" {
-		return fmt.Errorf("news API key is required")
+		errs = append(errs, fmt.Errorf("news API key is required"))
+	}
+
+	if len(c.NewsAPI.Countries) == 0 {
+		errs = append(errs, fmt.Errorf("at least one news API country is required"))
+	}
+
+	for _, country := range c.NewsAPI.Countries {
+		if len(country) != 2 {
+			errs = append(errs, fmt.Errorf("news API country %q must be a two-letter code", country))
+		}
+	}
+
+	if c.NewsAPI.MaxPages <= 0 {
+		errs = append(errs, fmt.Errorf("news API max pages must be positive"))
+	}
+
+	if c.NewsAPI.SourcesCacheTTL <= 0 {
+		errs = append(errs, fmt.Errorf("news API sources cache TTL must be positive"))
+	}
+
+	if c.Database.Port < 1 || c.Database.Port > 65535 {
+		errs = append(errs, fmt.Errorf("database port must be between 1 and 65535"))
+	}
+
+	if c.DatabasePool.MaxConns <= 0 {
+		errs = append(errs, fmt.Errorf("database pool max conns must be positive"))
+	}
+
+	if c.DatabasePool.MinConns < 0 {
+		errs = append(errs, fmt.Errorf("database pool min conns must not be negative"))
+	}
+
+	if c.DatabasePool.MaxConns < c.DatabasePool.MinConns {
+		errs = append(errs, fmt.Errorf("database pool max conns (%d) must be at least min conns (%d)", c.DatabasePool.MaxConns, c.DatabasePool.MinConns))
+	}
+
+	if c.DatabasePool.MaxConnLifetime < 0 || c.DatabasePool.MaxConnIdleTime < 0 || c.DatabasePool.HealthCheckPeriod < 0 ||
+		c.DatabasePool.SlowQueryThreshold < 0 {
+		errs = append(errs, fmt.Errorf("database pool durations must not be negative"))
+	}
+
+	errs = append(errs, c.Redis.validate()...)
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server port must be between 1 and 65535"))
+	}
+
+	if c.Server.CompressionEnabled {
+		if c.Server.CompressionLevel < gzip.HuffmanOnly || c.Server.CompressionLevel > gzip.BestCompression {
+			errs = append(errs, fmt.Errorf("server compression level must be between %d and %d", gzip.HuffmanOnly, gzip.BestCompression))
+		}
+		if c.Server.CompressionMinLength < 0 {
+			errs = append(errs, fmt.Errorf("server compression min length must not be negative"))
+		}
+	}
+
+	if c.Server.BodyLimitEnabled {
+		limit, err := bytes.Parse(c.Server.BodyLimit)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("server body limit %q is invalid: %w", c.Server.BodyLimit, err))
+		} else if limit <= 0 {
+			errs = append(errs, fmt.Errorf("server body limit must be positive"))
+		}
+	}
+
+	if c.Server.ReadTimeout < 0 || c.Server.ReadHeaderTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 {
+		errs = append(errs, fmt.Errorf("server timeouts must not be negative"))
+	}
+
+	if c.Server.RequestTimeout < 0 || c.Server.AggregationTimeout < 0 {
+		errs = append(errs, fmt.Errorf("server request timeouts must not be negative"))
+	}
+
+	// Past the write timeout the connection is dropped before the 504 is sent
+	if c.Server.WriteTimeout > 0 && c.Server.RequestTimeout >= c.Server.WriteTimeout {
+		errs = append(errs, fmt.Errorf("server request timeout must be shorter than the write timeout"))
+	}
+
+	if c.Server.WriteTimeout > 0 && c.Server.AggregationTimeout >= c.Server.WriteTimeout {
+		errs = append(errs, fmt.Errorf("server aggregation timeout must be shorter than the write timeout"))
+	}
+
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		errs = append(errs, fmt.Errorf("server TLS cert file and key file must be set together"))
+	}
+
+	errs = append(errs, c.CORS.validate()...)
+
+	if c.Cache.SWREnabled && c.Cache.SoftTTL >= c.Cache.HardTTL {
+		errs = append(errs, fmt.Errorf("cache soft TTL must be shorter than hard TTL"))
+	}
+
+	if c.Cache.EarlyRefreshEnabled && c.Cache.EarlyRefreshBeta <= 0 {
+		errs = append(errs, fmt.Errorf("cache early refresh beta must be positive"))
+	}
+
+	if c.Cache.L1Enabled && c.Cache.L1InvalidationEnabled && c.Cache.L1InvalidationChannel == "" {
+		errs = append(errs, fmt.Errorf("cache L1 invalidation channel must be set"))
+	}
+
+	if c.Cache.NotFoundTTL < 0 {
+		errs = append(errs, fmt.Errorf("cache not found TTL must not be negative"))
+	}
+
+	if c.Cache.URLFilterEnabled {
+		if c.Cache.URLFilterExpectedURLs < 1 {
+			errs = append(errs, fmt.Errorf("cache URL filter expected URLs must be at least 1"))
+		}
+		if c.Cache.URLFilterFalsePositiveRate <= 0 || c.Cache.URLFilterFalsePositiveRate >= 1 {
+			errs = append(errs, fmt.Errorf("cache URL filter false positive rate must be between 0 and 1"))
+		}
+		if c.Cache.URLFilterRebuildInterval <= 0 {
+			errs = append(errs, fmt.Errorf("cache URL filter rebuild interval must be positive"))
+		}
+	}
+
+	if c.Scheduler.Mode != "fixed_rate" && c.Scheduler.Mode != "fixed_delay" {
+		errs = append(errs, fmt.Errorf("scheduler mode must be fixed_rate or fixed_delay"))
+	}
+
+	if c.Scheduler.HeadlinesInterval <= 0 || c.Scheduler.CategoriesInterval <= 0 || c.Scheduler.SourcesInterval <= 0 {
+		errs = append(errs, fmt.Errorf("scheduler job intervals must be positive"))
+	}
+
+	if _, err := ParseQuietHours(c.Scheduler.QuietHours); err != nil {
+		errs = append(errs, fmt.Errorf("scheduler quiet hours: %w", err))
+	}
+
+	if c.Scheduler.OncePollInterval <= 0 || c.Scheduler.OnceLease <= 0 {
+		errs = append(errs, fmt.Errorf("scheduler one-time job poll interval and lease must be positive"))
+	}
+
+	for _, pattern := range c.Filter.TitlePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs = append(errs, fmt.Errorf("filter title pattern %q is invalid: %w", pattern, err))
+		}
+	}
+
+	if c.Filter.MinContentLength < 0 {
+		errs = append(errs, fmt.Errorf("filter min content length must not be negative"))
+	}
+
+	if c.Classifier.URL != "" && c.Classifier.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("sensitive classifier timeout must be positive"))
+	}
+
+	if c.Image.HTTPSUpgrade {
+		if c.Image.ProbeTimeout <= 0 {
+			errs = append(errs, fmt.Errorf("image probe timeout must be positive"))
+		}
+		if c.Image.ProbeCacheTTL <= 0 {
+			errs = append(errs, fmt.Errorf("image probe cache TTL must be positive"))
+		}
+	}
+
+	if c.ContentFetch.Enabled {
+		if c.ContentFetch.BatchSize <= 0 {
+			errs = append(errs, fmt.Errorf("content fetch batch size must be positive"))
+		}
+		if c.ContentFetch.Interval <= 0 {
+			errs = append(errs, fmt.Errorf("content fetch interval must be positive"))
+		}
+		if c.ContentFetch.MaxPerHost <= 0 {
+			errs = append(errs, fmt.Errorf("content fetch max per host must be positive"))
+		}
+		if c.ContentFetch.MaxBytes <= 0 {
+			errs = append(errs, fmt.Errorf("content fetch max bytes must be positive"))
+		}
+	}
+
+	if u, err := url.Parse(c.Syndication.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("syndication base URL %q must be an absolute URL", c.Syndication.BaseURL))
+	}
+
+	if c.Syndication.FeedSize < 1 || c.Syndication.FeedSize > 100 {
+		errs = append(errs, fmt.Errorf("syndication feed size must be between 1 and 100"))
+	}
+
+	// The sitemap protocol allows at most 50,000 URLs per file
+	if c.Syndication.SitemapPageSize < 1 || c.Syndication.SitemapPageSize > 50000 {
+		errs = append(errs, fmt.Errorf("syndication sitemap page size must be between 1 and 50000"))
+	}
+
+	if c.Syndication.RefreshInterval <= 0 {
+		errs = append(errs, fmt.Errorf("syndication refresh interval must be positive"))
+	}
+
+	if c.Tenant.Enabled {
+		if c.Tenant.Header == "" && c.Tenant.BaseDomain == "" {
+			errs = append(errs, fmt.Errorf("tenant header or base domain is required when multi-tenancy is enabled"))
+		}
+		if c.Tenant.CacheTTL <= 0 {
+			errs = append(errs, fmt.Errorf("tenant cache TTL must be positive"))
+		}
+	}
+
+	// The delimiters are passed to ts_headline as double-quoted option values
+	if strings.Contains(c.Search.HighlightStart, `"`) || strings.Contains(c.Search.HighlightStop, `"`) {
+		errs = append(errs, fmt.Errorf("search highlight delimiters must not contain double quotes"))
+	}
+
+	if c.Topic.Enabled {
+		if c.Topic.BatchSize <= 0 {
+			errs = append(errs, fmt.Errorf("topic clustering batch size must be positive"))
+		}
+		if c.Topic.Interval <= 0 {
+			errs = append(errs, fmt.Errorf("topic clustering interval must be positive"))
+		}
+		if c.Topic.Window <= 0 {
+			errs = append(errs, fmt.Errorf("topic clustering window must be positive"))
+		}
+		if c.Topic.Threshold <= 0 || c.Topic.Threshold > 1 {
+			errs = append(errs, fmt.Errorf("topic clustering threshold must be between 0 and 1"))
+		}
+	}
+
+	if c.Stats.RollupEnabled && c.Stats.RollupInterval <= 0 {
+		errs = append(errs, fmt.Errorf("stats rollup interval must be positive"))
+	}
+	if c.Stats.Retention < 7*24*time.Hour {
+		errs = append(errs, fmt.Errorf("stats retention must be at least 7 days to cover the longest ranking period"))
+	}
+	if c.Stats.ViewRefreshEnabled && c.Stats.ViewRefreshInterval <= 0 {
+		errs = append(errs, fmt.Errorf("stats view refresh interval must be positive"))
+	}
+	if c.Stats.ViewStaleTolerance < 0 {
+		errs = append(errs, fmt.Errorf("stats view stale tolerance must not be negative"))
+	} else if c.Stats.ViewRefreshEnabled && c.Stats.ViewStaleTolerance > 0 && c.Stats.ViewStaleTolerance <= c.Stats.ViewRefreshInterval {
+		errs = append(errs, fmt.Errorf("stats view stale tolerance (%s) must exceed the refresh interval (%s)", c.Stats.ViewStaleTolerance, c.Stats.ViewRefreshInterval))
+	}
+	if c.Stats.ViewerDedupWindow < 0 {
+		errs = append(errs, fmt.Errorf("stats viewer dedup window must not be negative"))
+	}
+
+	if c.Partition.MaintenanceEnabled && c.Partition.MaintenanceInterval <= 0 {
+		errs = append(errs, fmt.Errorf("posts partition maintenance interval must be positive"))
+	}
+	if c.Partition.MonthsAhead < 1 {
+		errs = append(errs, fmt.Errorf("posts partition months ahead must be at least 1"))
+	}
+
+	if c.Changes.Retention < 24*time.Hour {
+		errs = append(errs, fmt.Errorf("post changes retention must be at least 24h"))
+	}
+	if c.Changes.PruneEnabled && c.Changes.PruneInterval <= 0 {
+		errs = append(errs, fmt.Errorf("post changes prune interval must be positive"))
+	}
+
+	if c.Pins.MaxPosts < 1 || c.Pins.MaxPosts > 20 {
+		errs = append(errs, fmt.Errorf("pinned posts max must be between 1 and 20"))
+	}
+
+	if c.URLBackfill.Enabled {
+		if c.URLBackfill.Interval <= 0 {
+			errs = append(errs, fmt.Errorf("URL backfill interval must be positive"))
+		}
+		if c.URLBackfill.BatchSize < 1 {
+			errs = append(errs, fmt.Errorf("URL backfill batch size must be at least 1"))
+		}
+	}
+
+	if c.Ingest.Workers <= 0 {
+		errs = append(errs, fmt.Errorf("ingest workers must be positive"))
+	} else if c.Ingest.Workers > c.DatabasePool.MaxConns {
+		errs = append(errs, fmt.Errorf("ingest workers (%d) must not exceed database pool max conns (%d)", c.Ingest.Workers, c.DatabasePool.MaxConns))
+	}
+
+	if c.Ingest.QueueSize < 0 {
+		errs = append(errs, fmt.Errorf("ingest queue size must not be negative"))
+	}
+
+	if c.Ingest.Incremental && c.Ingest.Overlap < 0 {
+		errs = append(errs, fmt.Errorf("ingest overlap must not be negative"))
+	}
+
+	if c.RequestLog.SampleRate < 0 || c.RequestLog.SampleRate > 1 {
+		errs = append(errs, fmt.Errorf("request log sample rate must be between 0 and 1"))
+	}
+
+	if c.RequestLog.SlowThreshold < 0 {
+		errs = append(errs, fmt.Errorf("request log slow threshold must not be negative"))
+	}
+
+	if c.RequestLog.MaxBodySize < 0 {
+		errs = append(errs, fmt.Errorf("request log max body size must not be negative"))
+	}
+
+	errs = append(errs, c.Security.validate()...)
+
+	if c.Bot.Enabled {
+		errs = append(errs, c.Bot.validate()...)
+	}
+
+	if _, err := ParseNetworks(c.Access.AdminAllowlist); err != nil {
+		errs = append(errs, fmt.Errorf("admin allowed CIDRs: %w", err))
+	}
+	if _, err := ParseNetworks(c.Access.TrustedProxies); err != nil {
+		errs = append(errs, fmt.Errorf("trusted proxies: %w", err))
+	}
+
+	switch c.Schema.OnMismatch {
+	case SchemaMismatchRefuse, SchemaMismatchReadOnly, SchemaMismatchIgnore:
+	default:
+		errs = append(errs, fmt.Errorf("schema mismatch action must be refuse, read_only or ignore"))
+	}
+
+	if c.ErrorReporting.DSN != "" {
+		if u, err := url.Parse(c.ErrorReporting.DSN); err != nil || u.Scheme == "" || u.Host == "" || u.User == nil {
+			errs = append(errs, fmt.Errorf("sentry DSN must be a URL with a public key"))
+		}
+		if c.ErrorReporting.SampleRate < 0 || c.ErrorReporting.SampleRate > 1 {
+			errs = append(errs, fmt.Errorf("sentry sample rate must be between 0 and 1"))
+		}
+		if c.ErrorReporting.FlushTimeout < 0 {
+			errs = append(errs, fmt.Errorf("sentry flush timeout must not be negative"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate checks the allowed origins. Any origin is allowed by reflecting
+// it back, which would let every site make credentialed requests, so
+// credentials require the origins to be listed, with wildcards only standing
+// for subdomains.
+func (c *CORSConfig) validate() []error {
+	var errs []error
+
+	if len(c.AllowOrigins) == 0 {
+		errs = append(errs, fmt.Errorf("at least one CORS allowed origin is required"))
+	}
+
+	for _, origin := range c.AllowOrigins {
+		if origin == "*" {
+			if c.AllowCredentials {
+				errs = append(errs, fmt.Errorf("CORS allowed origin * cannot be combined with credentials; list the origins instead"))
+			}
+			continue
+		}
+
+		if strings.Count(origin, "*") > 1 {
+			errs = append(errs, fmt.Errorf("CORS allowed origin %q may contain at most one wildcard", origin))
+			continue
+		}
+
+		// ".example.com" limits the wildcard to subdomains, ".com" does not
+		_, suffix, wildcard := strings.Cut(origin, "*")
+		if wildcard && c.AllowCredentials && (!strings.HasPrefix(suffix, ".") || !strings.Contains(suffix[1:], ".")) {
+			errs = append(errs, fmt.Errorf("CORS allowed origin %q must only match subdomains of a domain when credentials are allowed", origin))
+		}
+	}
+
+	if c.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("CORS max age must not be negative"))
+	}
+
+	return errs
+}
+
+// validate checks the Redis deployment settings of the configured mode
+func (c *RedisConfig) validate() []error {
+	var errs []error
+
+	switch c.Mode {
+	case "standalone":
+		if c.Port < 1 || c.Port > 65535 {
+			errs = append(errs, fmt.Errorf("redis port must be between 1 and 65535"))
+		}
+	case "sentinel":
+		if len(c.Addrs) == 0 {
+			errs = append(errs, fmt.Errorf("redis sentinel mode requires at least one sentinel address"))
+		}
+		if c.MasterName == "" {
+			errs = append(errs, fmt.Errorf("redis sentinel mode requires the sentinel master name"))
+		}
+	case "cluster":
+		if len(c.Addrs) == 0 {
+			errs = append(errs, fmt.Errorf("redis cluster mode requires at least one node address"))
+		}
+		if c.DB != 0 {
+			errs = append(errs, fmt.Errorf("redis cluster mode only supports DB 0"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("redis mode must be standalone, sentinel or cluster"))
+	}
+
+	for _, addr := range c.Addrs {
+		if _, port, err := net.SplitHostPort(addr); err != nil || port == "" {
+			errs = append(errs, fmt.Errorf("redis address %q must be host:port", addr))
+		}
+	}
+
+	if c.DB < 0 {
+		errs = append(errs, fmt.Errorf("redis DB must not be negative"))
+	}
+
+	if !c.TLSEnabled && (c.TLSCAFile != "" || c.TLSServerName != "" || c.TLSInsecureSkipVerify) {
+		errs = append(errs, fmt.Errorf("redis TLS settings require REDIS_TLS_ENABLED"))
+	}
+
+	return errs
+}
+
+// defaultHSTSMaxAge pins browsers to HTTPS for a year, except in
+// development, where a pinned localhost would outlive the TLS setup
+func defaultHSTSMaxAge(environment string) int {
+	if environment == "development" {
+		return 0
+	}
+
+	return 365 * 24 * 60 * 60
+}
+
+func (c *SecurityHeadersConfig) validate() []error {
+	var errs []error
+
+	switch c.FrameOptions {
+	case "", "DENY", "SAMEORIGIN":
+	default:
+		errs = append(errs, fmt.Errorf("security frame options must be DENY, SAMEORIGIN or empty"))
+	}
+
+	if c.HSTSMaxAge < 0 {
+		errs = append(errs, fmt.Errorf("security HSTS max age must not be negative"))
+	}
+
+	// The browser preload lists only accept a year or more covering subdomains
+	if c.HSTSPreload && (c.HSTSMaxAge < 365*24*60*60 || !c.HSTSIncludeSubdomains) {
+		errs = append(errs, fmt.Errorf("security HSTS preload requires a max age of at least a year and subdomains included"))
+	}
+
+	return errs
+}
+
+// validate checks the behavior window and the bot rate limit
+func (c *BotConfig) validate() []error {
+	var errs []error
+
+	if c.BehaviorThreshold < 0 {
+		errs = append(errs, fmt.Errorf("bot behavior threshold must not be negative"))
+	} else if c.BehaviorThreshold > 0 && c.BehaviorWindow <= 0 {
+		errs = append(errs, fmt.Errorf("bot behavior window must be positive"))
 	}
 
-	return nil
+	if c.RateLimit < 0 {
+		errs = append(errs, fmt.Errorf("bot rate limit must not be negative"))
+	} else if c.RateLimit > 0 && c.RateBurst < 1 {
+		errs = append(errs, fmt.Errorf("bot rate burst must be at least 1"))
+	}
+
+	return errs
+}
+
+// logSinks are the supported log destinations
+var logSinks = []string{"stdout", "file", "syslog"}
+
+// validateLogging checks the log sinks and their settings
+func (a *AppConfig) validateLogging() []error {
+	var errs []error
+
+	if len(a.LogSinks) == 0 {
+		errs = append(errs, fmt.Errorf("at least one log sink is required"))
+	}
+
+	for _, sink := range a.LogSinks {
+		if !slices.Contains(logSinks, sink) {
+			errs = append(errs, fmt.Errorf("log sink %q must be one of %s", sink, strings.Join(logSinks, ", ")))
+		}
+	}
+
+	for _, level := range []string{a.LogStdoutLevel, a.LogFileLevel, a.LogSyslogLevel} {
+		if level != "" && !validLogLevel(level) {
+			errs = append(errs, fmt.Errorf("log sink level %q must be debug, info, warn or error", level))
+		}
+	}
+
+	if slices.Contains(a.LogSinks, "file") {
+		if a.LogFilePath == "" {
+			errs = append(errs, fmt.Errorf("log file path is required for the file sink"))
+		}
+		if a.LogFileMaxSizeMB <= 0 {
+			errs = append(errs, fmt.Errorf("log file max size must be positive"))
+		}
+		if a.LogFileMaxBackups < 0 || a.LogFileMaxAgeDays < 0 {
+			errs = append(errs, fmt.Errorf("log file max backups and max age must not be negative"))
+		}
+	}
+
+	if slices.Contains(a.LogSinks, "syslog") && a.LogSyslogNetwork != "" && a.LogSyslogAddress == "" {
+		errs = append(errs, fmt.Errorf("log syslog address is required with a syslog network"))
+	}
+
+	if a.LogAsync && a.LogAsyncBufferSize <= 0 {
+		errs = append(errs, fmt.Errorf("log async buffer size must be positive"))
+	}
+
+	return errs
+}
+
+// validLogLevel reports whether level names a log level
+func validLogLevel(level string) bool {
+	switch strings.ToLower(level) {
+	case "debug", "info", "warn", "warning", "error":
+		return true
+	default:
+		return false
+	}
+}
+
+// ReloadableChanges lists, by environment variable, the settings that can be
+// applied at runtime and differ between c and next
+func (c *Config) ReloadableChanges(next *Config) []string {
+	var changed []string
+
+	if c.App.LogLevel != next.App.LogLevel {
+		changed = append(changed, "LOG_LEVEL")
+	}
+	if c.Cache.TTL != next.Cache.TTL {
+		changed = append(changed, "CACHE_TTL")
+	}
+	if !slices.Equal(c.CORS.AllowOrigins, next.CORS.AllowOrigins) {
+		changed = append(changed, "CORS_ALLOW_ORIGINS")
+	}
+	if c.Scheduler.HeadlinesInterval != next.Scheduler.HeadlinesInterval {
+		changed = append(changed, "SCHEDULER_HEADLINES_INTERVAL")
+	}
+	if c.Scheduler.CategoriesInterval != next.Scheduler.CategoriesInterval {
+		changed = append(changed, "SCHEDULER_CATEGORIES_INTERVAL")
+	}
+	if c.Scheduler.SourcesInterval != next.Scheduler.SourcesInterval {
+		changed = append(changed, "SCHEDULER_SOURCES_INTERVAL")
+	}
+	if c.ContentFetch.Interval != next.ContentFetch.Interval {
+		changed = append(changed, "CONTENT_FETCH_INTERVAL")
+	}
+	if c.NewsAPI.APIKey != next.NewsAPI.APIKey {
+		changed = append(changed, "NEWS_API_KEY")
+	}
+
+	return changed
+}
+
+// redactedValue replaces secrets in Redacted output
+const redactedValue = "[REDACTED]"
+
+// Redacted returns a copy of the configuration that is safe to print: passwords
+// and API keys are masked and credentials are stripped from URLs
+func (c *Config) Redacted() *Config {
+	redacted := *c
+
+	redacted.Database.Password = redactSecret(c.Database.Password)
+	redacted.Redis.Password = redactSecret(c.Redis.Password)
+	redacted.Redis.SentinelPassword = redactSecret(c.Redis.SentinelPassword)
+	redacted.NewsAPI.APIKey = redactSecret(c.NewsAPI.APIKey)
+	redacted.Classifier.URL = redactURL(c.Classifier.URL)
+	redacted.ErrorReporting.DSN = redactSecret(c.ErrorReporting.DSN)
+
+	redacted.DatabasePool.ReplicaURLs = make([]string, len(c.DatabasePool.ReplicaURLs))
+	for i, dsn := range c.DatabasePool.ReplicaURLs {
+		redacted.DatabasePool.ReplicaURLs[i] = redactURL(dsn)
+	}
+
+	return &redacted
+}
+
+// redactSecret masks a non-empty secret so its presence is still visible
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return redactedValue
+}
+
+// redactURL masks the password in a URL, or the whole value if it cannot be parsed
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		return redactedValue
+	}
+
+	return u.Redacted()
 }
 
 func (c *Config) DatabaseURL() string {
@@ -168,6 +1377,11 @@ func (c *Config) ServerAddr() string {
 	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
 }
 
+// TLSEnabled reports whether the server terminates TLS itself
+func (c *Config) TLSEnabled() bool {
+	return c.Server.TLSCertFile != "" && c.Server.TLSKeyFile != ""
+}
+
 func (c *Config) IsDevelopment() bool {
 	return c.App.Environment == "development"
 }
@@ -186,7 +1400,7 @@ func getEnv(key, fallback string) string {
 
 func getEnvInt(key string, fallback int) int {
 	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err != nil {
+		if intValue, err := strconv.Atoi(value); err == nil {
 			return intValue
 		}
 	}
@@ -196,7 +1410,7 @@ func getEnvInt(key string, fallback int) int {
 
 func getEnvBool(key string, fallback bool) bool {
 	if value := os.Getenv(key); value != "" {
-		if boolValue, err := strconv.ParseBool(value); err != nil {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
 			return boolValue
 		}
 	}
@@ -204,6 +1418,16 @@ func getEnvBool(key string, fallback bool) bool {
 	return fallback
 }
 
+func getEnvFloat(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+
+	return fallback
+}
+
 func getEnvDuration(key string, fallback time.Duration) time.Duration {
 	value := os.Getenv(key)
 	if value == "" {
@@ -218,13 +1442,103 @@ func getEnvDuration(key string, fallback time.Duration) time.Duration {
 	return duration
 }
 
+// ParseNetworks parses a list of CIDR ranges, taking a single address as the
+// range holding only that address
+func ParseNetworks(values []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(values))
+
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", value)
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR range %q", value)
+		}
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// ParseQuietHours parses job=HH:MM-HH:MM[,HH:MM-HH:MM] entries into the
+// quiet windows of each job, in UTC. The job * stands for every job without
+// an entry of its own.
+func ParseQuietHours(entries []string) (map[string][]QuietWindow, error) {
+	quietHours := make(map[string][]QuietWindow, len(entries))
+
+	for _, entry := range entries {
+		job, spans, ok := strings.Cut(entry, "=")
+		job = strings.TrimSpace(job)
+		if !ok || job == "" {
+			return nil, fmt.Errorf("entry %q is not job=HH:MM-HH:MM", entry)
+		}
+		if _, exists := quietHours[job]; exists {
+			return nil, fmt.Errorf("job %q is listed twice", job)
+		}
+
+		var windows []QuietWindow
+		for _, span := range strings.Split(spans, ",") {
+			from, to, ok := strings.Cut(strings.TrimSpace(span), "-")
+			if !ok {
+				return nil, fmt.Errorf("window %q of job %q is not HH:MM-HH:MM", span, job)
+			}
+
+			start, err := parseTimeOfDay(from)
+			if err != nil {
+				return nil, fmt.Errorf("window %q of job %q: %w", span, job, err)
+			}
+			end, err := parseTimeOfDay(to)
+			if err != nil {
+				return nil, fmt.Errorf("window %q of job %q: %w", span, job, err)
+			}
+			if start == end || start == 24*time.Hour || end-start == 24*time.Hour {
+				return nil, fmt.Errorf("window %q of job %q must cover part of a day", span, job)
+			}
+
+			windows = append(windows, QuietWindow{Start: start, End: end})
+		}
+
+		quietHours[job] = windows
+	}
+
+	return quietHours, nil
+}
+
+// parseTimeOfDay parses HH:MM, 00:00 to 24:00, into an offset from midnight
+func parseTimeOfDay(value string) (time.Duration, error) {
+	hours, minutes, ok := strings.Cut(strings.TrimSpace(value), ":")
+	h, herr := strconv.Atoi(hours)
+	m, merr := strconv.Atoi(minutes)
+	if !ok || herr != nil || merr != nil || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("invalid time of day %q", value)
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
 func getEnvStringSlice(key string, fallback []string) []string {
+	return getEnvSeparatedSlice(key, ",", fallback)
+}
+
+// getEnvSeparatedSlice splits on a custom separator, for values such as
+// regular expressions that may themselves contain commas
+func getEnvSeparatedSlice(key, sep string, fallback []string) []string {
 	value := os.Getenv(key)
 	if value == "" {
 		return fallback
 	}
 
-	parts := strings.Split(value, ",")
+	parts := strings.Split(value, sep)
 	sliceValue := make([]string, 0, len(parts))
 
 	for _, part := range parts {
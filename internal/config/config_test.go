@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEnvInt(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{"unset", "", 7},
+		{"set", "42", 42},
+		{"negative", "-3", -3},
+		{"zero", "0", 0},
+		{"not a number", "forty", 7},
+		{"fraction", "1.5", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_ENV_INT", tt.value)
+
+			assert.Equal(t, tt.want, getEnvInt("TEST_ENV_INT", 7))
+		})
+	}
+}
+
+func TestGetEnvBool(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		fallback bool
+		want     bool
+	}{
+		{"unset", "", true, true},
+		{"true", "true", false, true},
+		{"one", "1", false, true},
+		{"false overrides a true fallback", "false", true, false},
+		{"zero overrides a true fallback", "0", true, false},
+		{"not a boolean", "yes", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_ENV_BOOL", tt.value)
+
+			assert.Equal(t, tt.want, getEnvBool("TEST_ENV_BOOL", tt.fallback))
+		})
+	}
+}
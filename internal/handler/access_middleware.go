@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// adminPathPrefixes are the route groups restricted to the admin allowlist
+var adminPathPrefixes = []string{
+	APIBasePath + "/admin",
+	APIBasePath + "/scheduler",
+	APIBasePath + "/aggregation",
+}
+
+// ClientIPExtractor returns how the client of a request is found: the address
+// of the connection, or, when it comes from one of cfg.TrustedProxies, the
+// last address in X-Forwarded-For not added by a trusted proxy. Only the
+// configured proxies are trusted, private and loopback addresses included.
+func ClientIPExtractor(cfg config.AccessConfig) echo.IPExtractor {
+	proxies, _ := config.ParseNetworks(cfg.TrustedProxies)
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range proxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// AdminAllowlist turns away requests to the admin, scheduler and aggregation
+// routes from clients outside cfg.AdminAllowlist with a 403. Clients are found
+// with ClientIPExtractor, so X-Forwarded-For is only believed from trusted
+// proxies.
+func AdminAllowlist(cfg config.AccessConfig, log *logger.Logger) echo.MiddlewareFunc {
+	allowed, _ := config.ParseNetworks(cfg.AdminAllowlist)
+	clientIP := ClientIPExtractor(cfg)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !adminPath(c.Request().URL.Path) {
+				return next(c)
+			}
+
+			client := clientIP(c.Request())
+			ip := net.ParseIP(client)
+			if ip != nil && slices.ContainsFunc(allowed, func(network *net.IPNet) bool { return network.Contains(ip) }) {
+				return next(c)
+			}
+
+			log.Warn("Rejected admin request from a client outside the allowlist", "ip", client, "path", c.Request().URL.Path)
+
+			return response.Error(c, http.StatusForbidden, response.CodeForbidden, "Forbidden")
+		}
+	}
+}
+
+// adminPath reports whether path is in one of the route groups restricted to
+// the admin allowlist
+func adminPath(path string) bool {
+	for _, prefix := range adminPathPrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
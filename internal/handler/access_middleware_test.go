@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// accessServer returns a server behind AdminAllowlist that answers every
+// request with 200
+func accessServer(cfg config.AccessConfig) *echo.Echo {
+	e := echo.New()
+	e.Use(AdminAllowlist(cfg, logger.New(&config.Config{App: config.AppConfig{LogLevel: "error"}})))
+	e.Any("/*", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	return e
+}
+
+func serveAccess(e *echo.Echo, path, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestAdminAllowlistRestrictsAdminRoutes(t *testing.T) {
+	e := accessServer(config.AccessConfig{AdminAllowlist: []string{"10.1.0.0/16", "2001:db8::/32", "203.0.113.7"}})
+
+	assert.Equal(t, http.StatusOK, serveAccess(e, "/api/v1/admin/tenants", "10.1.2.3:5000", "").Code)
+	assert.Equal(t, http.StatusOK, serveAccess(e, "/api/v1/scheduler/status", "203.0.113.7:5000", "").Code)
+	assert.Equal(t, http.StatusOK, serveAccess(e, "/api/v1/aggregation/trigger", "[2001:db8::1]:5000", "").Code)
+
+	rec := serveAccess(e, "/api/v1/admin/tenants", "10.2.0.1:5000", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), string(response.CodeForbidden))
+	assert.Equal(t, http.StatusForbidden, serveAccess(e, "/api/v1/scheduler/jobs", "203.0.113.8:5000", "").Code)
+	assert.Equal(t, http.StatusForbidden, serveAccess(e, "/api/v1/aggregation", "198.51.100.1:5000", "").Code)
+
+	// Other routes stay open, including ones that only share a prefix
+	assert.Equal(t, http.StatusOK, serveAccess(e, "/api/v1/posts", "198.51.100.1:5000", "").Code)
+	assert.Equal(t, http.StatusOK, serveAccess(e, "/api/v1/administrators", "198.51.100.1:5000", "").Code)
+}
+
+func TestAdminAllowlistIgnoresForwardedForFromUntrustedClients(t *testing.T) {
+	e := accessServer(config.AccessConfig{AdminAllowlist: []string{"10.1.0.0/16"}})
+
+	assert.Equal(t, http.StatusForbidden, serveAccess(e, "/api/v1/admin/tenants", "198.51.100.1:5000", "10.1.2.3").Code)
+}
+
+func TestAdminAllowlistBehindTrustedProxy(t *testing.T) {
+	e := accessServer(config.AccessConfig{
+		AdminAllowlist: []string{"10.1.0.0/16"},
+		TrustedProxies: []string{"192.168.0.10"},
+	})
+
+	assert.Equal(t, http.StatusOK, serveAccess(e, "/api/v1/admin/tenants", "192.168.0.10:5000", "10.1.2.3").Code)
+	assert.Equal(t, http.StatusForbidden, serveAccess(e, "/api/v1/admin/tenants", "192.168.0.10:5000", "198.51.100.1").Code)
+
+	// A client cannot smuggle an allowed address in front of its own
+	assert.Equal(t, http.StatusForbidden, serveAccess(e, "/api/v1/admin/tenants", "192.168.0.10:5000", "10.1.2.3, 198.51.100.1").Code)
+
+	// Private addresses are not trusted unless they are listed
+	assert.Equal(t, http.StatusForbidden, serveAccess(e, "/api/v1/admin/tenants", "192.168.0.11:5000", "10.1.2.3").Code)
+}
@@ -51,7 +51,7 @@ func (h *aggregatorHandler) TriggerAggregation(c echo.Context) error {
 	}
 
 	h.logger.LogServiceOperation("aggregator_handler", "trigger_aggregation", true, time.Since(start).Milliseconds())
-	return response.Success(c, http.StatusCreated, result, "Aggregation completed successfully")
+	return response.Success(c, http.StatusCreated, result, aggregationMessage(result, "Aggregation completed successfully"))
 }
 
 // TriggerTopHeadlines handles POST /api/v1/aggregation/trigger/headlines
@@ -80,18 +80,18 @@ func (h *aggregatorHandler) TriggerTopHeadlines(c echo.Context) error {
 
 	h.logger.LogServiceOperation("aggregator_handler", "trigger_top_headlines", true, time.Since(start).Milliseconds())
 
-	return response.Success(c, http.StatusCreated, result, "Top headlines aggregation completed successfully")
+	return response.Success(c, http.StatusCreated, result, aggregationMessage(result, "Top headlines aggregation completed successfully"))
 }
 
 // TriggerCategoryAggregation handles POST /api/v1/aggregation/trigger/categories
 // @Summary      Trigger category aggregation
-// @Description  Trigger aggregation for one or more categories. If no categories provided, defaults are used.
+// @Description  Trigger aggregation for one or more categories and countries. If no categories or countries are provided, defaults are used. Optional page_size, language and from/to override the NewsAPI query for this run.
 // @Tags         aggregation
 // @Accept       json
 // @Produce      json
 // @Param        body  body      model.CategoryAggregationRequest     false  "Categories payload (optional)"
 // @Success      201   {object}  response.APIResponse{data=model.CategoryAggregationResponse}    "Category aggregation result"
-// @Failure      400   {object}  response.APIResponse{error=response.ErrorInfo}                   "No valid categories provided"
+// @Failure      400   {object}  response.APIResponse{error=response.ErrorInfo}                   "No valid categories or invalid query parameters"
 // @Failure      500   {object}  response.APIResponse{error=response.ErrorInfo}                   "Aggregation failed"
 // @Router       /aggregation/trigger/categories [post]
 func (h *aggregatorHandler) TriggerCategoryAggregation(c echo.Context) error {
@@ -121,15 +121,35 @@ func (h *aggregatorHandler) TriggerCategoryAggregation(c echo.Context) error {
 
 	if len(filteredCategories) == 0 {
 		h.logger.LogServiceOperation("aggregator_handler", "trigger_category_aggregation", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "No valid categories provided", "Available categories: "+strings.Join(validCategories, ", "))
+		return response.BadRequest(c, response.CodeInvalidParameter, "No valid categories provided", "Available categories: "+strings.Join(validCategories, ", "))
+	}
+
+	if msg := h.validateQuery(c, req.AggregationQuery); msg != "" {
+		h.logger.LogServiceOperation("aggregator_handler", "trigger_category_aggregation", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidAggregation, "Invalid aggregation parameters", msg)
+	}
+
+	requested := req.Countries
+	if req.Country != "" {
+		requested = append(requested, req.Country)
+	}
+
+	var countries []string
+	for _, country := range requested {
+		country = strings.ToLower(strings.TrimSpace(country))
+		if len(country) != 2 {
+			h.logger.LogServiceOperation("aggregator_handler", "trigger_category_aggregation", false, time.Since(start).Milliseconds())
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid country code", "Countries must be two-letter ISO 3166-1 codes")
+		}
+		countries = append(countries, country)
 	}
 
 	ctx, cancel := context.WithTimeout(c.Request().Context(), 4*time.Minute)
 	defer cancel()
 
-	h.logger.Info("Manual category aggregation triggered via API", "categories", filteredCategories)
+	h.logger.Info("Manual category aggregation triggered via API", "categories", filteredCategories, "countries", countries)
 
-	result, err := h.aggregatorService.AggregateByCategories(ctx, filteredCategories)
+	result, err := h.aggregatorService.AggregateByCategories(ctx, filteredCategories, countries, req.AggregationQuery)
 	if err != nil {
 		h.logger.LogServiceOperation("aggregator_handler", "trigger_category_aggregation", false, time.Since(start).Milliseconds())
 		return response.InternalServerError(c, "Category aggregation failed", err.Error())
@@ -139,30 +159,22 @@ func (h *aggregatorHandler) TriggerCategoryAggregation(c echo.Context) error {
 
 	responseData := model.CategoryAggregationResponse{
 		Categories: filteredCategories,
-		Result: model.AggregationResponse{
-			TotalFetched:    result.TotalFetched,
-			TotalCreated:    result.TotalCreated,
-			TotalDuplicates: result.TotalDuplicates,
-			TotalErrors:     result.TotalErrors,
-			Duration:        result.Duration,
-			Categories:      result.Categories,
-			Sources:         result.Sources,
-			Errors:          result.Errors,
-		},
-	}
-
-	return response.Success(c, http.StatusCreated, responseData, "Category aggregation completed successfully")
+		Countries:  countries,
+		Result:     *result,
+	}
+
+	return response.Success(c, http.StatusCreated, responseData, aggregationMessage(result, "Category aggregation completed successfully"))
 }
 
 // TriggerSourceAggregation handles POST /api/v1/aggregation/trigger/sources
 // @Summary      Trigger source aggregation
-// @Description  Trigger aggregation for one or more sources. If no sources provided, defaults are used.
+// @Description  Trigger aggregation for one or more sources. If no sources provided, defaults are used. Optional page_size, language and from/to override the NewsAPI query for this run.
 // @Tags         aggregation
 // @Accept       json
 // @Produce      json
 // @Param        body  body      model.SourceAggregationRequest       false  "Sources payload (optional)"
 // @Success      201   {object}  response.APIResponse{data=model.SourceAggregationResponse}      "Source aggregation result"
-// @Failure      400   {object}  response.APIResponse{error=response.ErrorInfo}                   "No valid sources provided"
+// @Failure      400   {object}  response.APIResponse{error=response.ErrorInfo}                   "No valid sources or invalid query parameters"
 // @Failure      500   {object}  response.APIResponse{error=response.ErrorInfo}                   "Aggregation failed"
 // @Router       /aggregation/trigger/sources [post]
 func (h *aggregatorHandler) TriggerSourceAggregation(c echo.Context) error {
@@ -189,7 +201,16 @@ func (h *aggregatorHandler) TriggerSourceAggregation(c echo.Context) error {
 		h.logger.LogServiceOperation("aggregator_handler", "trigger_source_aggregation", false, time.Since(start).Milliseconds())
 
 		defaultSources := service.GetDefaultSources()
-		return response.BadRequest(c, "No valid sources provided", "Available sources: "+strings.Join(defaultSources, ", "))
+		return response.BadRequest(c, response.CodeInvalidParameter, "No valid sources provided", "Available sources: "+strings.Join(defaultSources, ", "))
+	}
+
+	msg := h.validateQuery(c, req.AggregationQuery)
+	if req.Country != "" {
+		msg = "NewsAPI cannot filter source aggregation by country"
+	}
+	if msg != "" {
+		h.logger.LogServiceOperation("aggregator_handler", "trigger_source_aggregation", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidAggregation, "Invalid aggregation parameters", msg)
 	}
 
 	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Minute)
@@ -197,7 +218,7 @@ func (h *aggregatorHandler) TriggerSourceAggregation(c echo.Context) error {
 
 	h.logger.Info("Manual source aggregation triggered via API", "sources", filteredSources)
 
-	result, err := h.aggregatorService.AggregateBySources(ctx, filteredSources)
+	result, err := h.aggregatorService.AggregateBySources(ctx, filteredSources, req.AggregationQuery)
 	if err != nil {
 		h.logger.LogServiceOperation("aggregator_handler", "trigger_source_aggregation", false, time.Since(start).Milliseconds())
 		return response.InternalServerError(c, "Source aggregation failed", err.Error())
@@ -207,17 +228,32 @@ func (h *aggregatorHandler) TriggerSourceAggregation(c echo.Context) error {
 
 	responseData := model.SourceAggregationResponse{
 		Sources: filteredSources,
-		Result: model.AggregationResponse{
-			TotalFetched:    result.TotalFetched,
-			TotalCreated:    result.TotalCreated,
-			TotalDuplicates: result.TotalDuplicates,
-			TotalErrors:     result.TotalErrors,
-			Duration:        result.Duration,
-			Categories:      result.Categories,
-			Sources:         result.Sources,
-			Errors:          result.Errors,
-		},
-	}
-
-	return response.Success(c, http.StatusCreated, responseData, "Source aggregation completed successfully")
+		Result:  *result,
+	}
+
+	return response.Success(c, http.StatusCreated, responseData, aggregationMessage(result, "Source aggregation completed successfully"))
+}
+
+// aggregationMessage returns msg, or a partial results notice when the run
+// timed out before finishing
+func aggregationMessage(result *model.AggregationResponse, msg string) string {
+	if result.TimedOut {
+		return "Aggregation timed out; partial results returned"
+	}
+
+	return msg
+}
+
+// validateQuery checks the optional NewsAPI overrides of an aggregation request
+// and returns a description of the first problem, or an empty string
+func (h *aggregatorHandler) validateQuery(c echo.Context, query model.AggregationQuery) string {
+	if err := c.Validate(&query); err != nil {
+		return err.Error()
+	}
+
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return "from must not be after to"
+	}
+
+	return ""
 }
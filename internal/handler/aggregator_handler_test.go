@@ -33,16 +33,16 @@ func (m *MockAggregatorService) AggregateTopHeadlines(ctx context.Context) (*mod
 	return args.Get(0).(*model.AggregationResponse), args.Error(1)
 }
 
-func (m *MockAggregatorService) AggregateByCategories(ctx context.Context, categories []string) (*model.AggregationResponse, error) {
-	args := m.Called(ctx, categories)
+func (m *MockAggregatorService) AggregateByCategories(ctx context.Context, categories, countries []string, query model.AggregationQuery) (*model.AggregationResponse, error) {
+	args := m.Called(ctx, categories, countries, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.AggregationResponse), args.Error(1)
 }
 
-func (m *MockAggregatorService) AggregateBySources(ctx context.Context, sources []string) (*model.AggregationResponse, error) {
-	args := m.Called(ctx, sources)
+func (m *MockAggregatorService) AggregateBySources(ctx context.Context, sources []string, query model.AggregationQuery) (*model.AggregationResponse, error) {
+	args := m.Called(ctx, sources, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -99,7 +99,7 @@ func (suite *AggregatorHandlerTestSuite) createMockAggregationResponse() *model.
 		Duration:        2 * time.Minute,
 		Categories:      catStats,
 		Sources:         srcStats,
-		Errors:          []string{},
+		Errors:          []model.AggregationError{},
 	}
 }
 
@@ -137,6 +137,31 @@ func (suite *AggregatorHandlerTestSuite) TestTriggerAggregationSuccess() {
 	assert.Equal(suite.T(), "Aggregation completed successfully", response.Message)
 }
 
+func (suite *AggregatorHandlerTestSuite) TestTriggerAggregationTimedOut() {
+	expectedResult := suite.createMockAggregationResponse()
+	expectedResult.TimedOut = true
+	expectedResult.SkippedCategories = []string{"sports"}
+
+	suite.mockService.On("AggregateAll", mock.AnythingOfType("*context.timerCtx")).Return(expectedResult, nil)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger", nil)
+
+	err := suite.handler.TriggerAggregation(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+
+	var response response.APIResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), response.Success)
+	assert.Equal(suite.T(), "Aggregation timed out; partial results returned", response.Message)
+
+	data := response.Data.(map[string]interface{})
+	assert.Equal(suite.T(), true, data["timed_out"])
+	assert.Equal(suite.T(), []interface{}{"sports"}, data["skipped_categories"])
+}
+
 func (suite *AggregatorHandlerTestSuite) TestTriggerAggregationError() {
 	suite.mockService.On("AggregateAll", mock.AnythingOfType("*context.timerCtx")).Return(nil, errors.New("aggregation service error"))
 
@@ -194,7 +219,7 @@ func (suite *AggregatorHandlerTestSuite) TestTriggerCategoryAggregationWithValid
 		Categories: []string{"technology", "business"},
 	}
 
-	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), []string{"technology", "business"}).Return(expectedResult, nil)
+	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), []string{"technology", "business"}, []string(nil), model.AggregationQuery{}).Return(expectedResult, nil)
 
 	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/categories", requestBody)
 
@@ -210,11 +235,90 @@ func (suite *AggregatorHandlerTestSuite) TestTriggerCategoryAggregationWithValid
 	assert.Equal(suite.T(), "Category aggregation completed successfully", response.Message)
 }
 
+func (suite *AggregatorHandlerTestSuite) TestTriggerCategoryAggregationWithCountries() {
+	expectedResult := suite.createMockAggregationResponse()
+	requestBody := model.CategoryAggregationRequest{
+		Categories: []string{"technology"},
+		Countries:  []string{"US", " gb "},
+	}
+
+	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), []string{"technology"}, []string{"us", "gb"}, model.AggregationQuery{}).Return(expectedResult, nil)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/categories", requestBody)
+
+	err := suite.handler.TriggerCategoryAggregation(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+}
+
+func (suite *AggregatorHandlerTestSuite) TestTriggerCategoryAggregationWithInvalidCountry() {
+	requestBody := model.CategoryAggregationRequest{
+		Categories: []string{"technology"},
+		Countries:  []string{"usa"},
+	}
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/categories", requestBody)
+
+	err := suite.handler.TriggerCategoryAggregation(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *AggregatorHandlerTestSuite) TestTriggerCategoryAggregationWithQueryOverrides() {
+	expectedResult := suite.createMockAggregationResponse()
+	requestBody := map[string]any{
+		"categories": []string{"technology"},
+		"country":    "DE",
+		"page_size":  20,
+		"language":   "de",
+	}
+
+	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), []string{"technology"}, []string{"de"}, model.AggregationQuery{PageSize: 20, Language: "de"}).Return(expectedResult, nil)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/categories", requestBody)
+
+	err := suite.handler.TriggerCategoryAggregation(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+}
+
+func (suite *AggregatorHandlerTestSuite) TestTriggerCategoryAggregationWithInvertedDateRange() {
+	requestBody := map[string]any{
+		"categories": []string{"technology"},
+		"from":       "2024-01-21T00:00:00Z",
+		"to":         "2024-01-20T00:00:00Z",
+	}
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/categories", requestBody)
+
+	err := suite.handler.TriggerCategoryAggregation(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *AggregatorHandlerTestSuite) TestTriggerSourceAggregationRejectsCountry() {
+	requestBody := model.SourceAggregationRequest{
+		Sources: []string{"techcrunch"},
+		Country: "us",
+	}
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/sources", requestBody)
+
+	err := suite.handler.TriggerSourceAggregation(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
 func (suite *AggregatorHandlerTestSuite) TestTriggerCategoryAggregationWithInvalidJSON() {
 	expectedResult := suite.createMockAggregationResponse()
 
 	// When binding fails, should use default categories
-	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), mock.AnythingOfType("[]string")).Return(expectedResult, nil)
+	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), mock.AnythingOfType("[]string"), []string(nil), model.AggregationQuery{}).Return(expectedResult, nil)
 
 	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/categories", "invalid-json")
 
@@ -231,7 +335,7 @@ func (suite *AggregatorHandlerTestSuite) TestTriggerCategoryAggregationWithEmpty
 	}
 
 	// Should use default categories when empty
-	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), mock.AnythingOfType("[]string")).Return(expectedResult, nil)
+	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), mock.AnythingOfType("[]string"), []string(nil), model.AggregationQuery{}).Return(expectedResult, nil)
 
 	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/categories", requestBody)
 
@@ -264,7 +368,7 @@ func (suite *AggregatorHandlerTestSuite) TestTriggerCategoryAggregationError() {
 		Categories: []string{"technology"},
 	}
 
-	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), []string{"technology"}).Return(nil, errors.New("category service error"))
+	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), []string{"technology"}, []string(nil), model.AggregationQuery{}).Return(nil, errors.New("category service error"))
 
 	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/categories", requestBody)
 
@@ -285,7 +389,7 @@ func (suite *AggregatorHandlerTestSuite) TestTriggerSourceAggregationWithValidSo
 		Sources: []string{"bbc-news", "techcrunch"},
 	}
 
-	suite.mockService.On("AggregateBySources", mock.AnythingOfType("*context.timerCtx"), []string{"bbc-news", "techcrunch"}).Return(expectedResult, nil)
+	suite.mockService.On("AggregateBySources", mock.AnythingOfType("*context.timerCtx"), []string{"bbc-news", "techcrunch"}, model.AggregationQuery{}).Return(expectedResult, nil)
 
 	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/sources", requestBody)
 
@@ -305,7 +409,7 @@ func (suite *AggregatorHandlerTestSuite) TestTriggerSourceAggregationWithInvalid
 	expectedResult := suite.createMockAggregationResponse()
 
 	// When binding fails, should use default sources
-	suite.mockService.On("AggregateBySources", mock.AnythingOfType("*context.timerCtx"), mock.AnythingOfType("[]string")).Return(expectedResult, nil)
+	suite.mockService.On("AggregateBySources", mock.AnythingOfType("*context.timerCtx"), mock.AnythingOfType("[]string"), model.AggregationQuery{}).Return(expectedResult, nil)
 
 	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/sources", "invalid-json")
 
@@ -338,7 +442,7 @@ func (suite *AggregatorHandlerTestSuite) TestTriggerSourceAggregationError() {
 		Sources: []string{"bbc-news"},
 	}
 
-	suite.mockService.On("AggregateBySources", mock.AnythingOfType("*context.timerCtx"), []string{"bbc-news"}).Return(nil, errors.New("source service error"))
+	suite.mockService.On("AggregateBySources", mock.AnythingOfType("*context.timerCtx"), []string{"bbc-news"}, model.AggregationQuery{}).Return(nil, errors.New("source service error"))
 
 	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/sources", requestBody)
 
@@ -359,7 +463,7 @@ func (suite *AggregatorHandlerTestSuite) TestCategoryAggregationResponseFormat()
 		Categories: []string{"technology"},
 	}
 
-	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), []string{"technology"}).Return(expectedResult, nil)
+	suite.mockService.On("AggregateByCategories", mock.AnythingOfType("*context.timerCtx"), []string{"technology"}, []string(nil), model.AggregationQuery{}).Return(expectedResult, nil)
 
 	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/categories", requestBody)
 
@@ -388,7 +492,7 @@ func (suite *AggregatorHandlerTestSuite) TestSourceAggregationResponseFormat() {
 		Sources: []string{"bbc-news"},
 	}
 
-	suite.mockService.On("AggregateBySources", mock.AnythingOfType("*context.timerCtx"), []string{"bbc-news"}).Return(expectedResult, nil)
+	suite.mockService.On("AggregateBySources", mock.AnythingOfType("*context.timerCtx"), []string{"bbc-news"}, model.AggregationQuery{}).Return(expectedResult, nil)
 
 	c, rec := suite.createEchoContext(http.MethodPost, "/aggregation/trigger/sources", requestBody)
 
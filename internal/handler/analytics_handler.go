@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// analyticsHandler implements AnalyticsHandler interface
+type analyticsHandler struct {
+	analyticsService service.AnalyticsService
+	logger           *logger.Logger
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(analyticsService service.AnalyticsService, logger *logger.Logger) AnalyticsHandler {
+	return &analyticsHandler{
+		analyticsService: analyticsService,
+		logger:           logger,
+	}
+}
+
+// RecordClick handles POST /api/v1/posts/:id/click
+// @Summary      Record a click-through
+// @Description  Record that a user clicked through to a post's article. Referrer and user agent are taken from the request headers.
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Post ID"
+// @Success      201  {object}  response.APIResponse                            "Click recorded"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid ID"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Post not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/{id}/click [post]
+func (h *analyticsHandler) RecordClick(c echo.Context) error {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.logger.LogServiceOperation("analytics_handler", "record_click", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	if _, err := h.analyticsService.RecordClick(c.Request().Context(), id, c.Request().Referer(), c.Request().UserAgent()); err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "record_click", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrPostNotFound) {
+			return response.NotFound(c, response.CodePostNotFound, "Post not found")
+		}
+
+		return response.InternalServerError(c, "Failed to record click")
+	}
+
+	h.logger.LogServiceOperation("analytics_handler", "record_click", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusCreated, nil, "Click recorded successfully")
+}
+
+// RedirectToPost handles GET /r/:id
+// @Summary      Redirect to a post's article
+// @Description  Record a click-through and redirect to the original article URL. The redirect does not depend on the click being recorded, and no click is recorded in read-only mode.
+// @Tags         analytics
+// @Param        id   path  int  true  "Post ID"
+// @Success      302  "Redirect to the article"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid ID"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Post not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /r/{id} [get]
+func (h *analyticsHandler) RedirectToPost(c echo.Context) error {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.logger.LogServiceOperation("analytics_handler", "redirect_to_post", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	post, err := h.analyticsService.RecordClick(c.Request().Context(), id, c.Request().Referer(), c.Request().UserAgent())
+	if err != nil && post != nil {
+		// A lost click is not worth a broken link
+		h.logger.Warn("Failed to record click, redirecting anyway", "id", id, "error", err.Error())
+	} else if err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "redirect_to_post", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrPostNotFound) {
+			return response.NotFound(c, response.CodePostNotFound, "Post not found")
+		}
+
+		return response.InternalServerError(c, "Failed to record click")
+	}
+
+	h.logger.LogServiceOperation("analytics_handler", "redirect_to_post", true, time.Since(start).Milliseconds())
+
+	return c.Redirect(http.StatusFound, post.URL)
+}
+
+// GetCTRStats handles GET /api/v1/analytics/ctr
+// @Summary      Get click-through rates
+// @Description  Views, clicks and click-through rate per source or category for recently created posts
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Param        group_by  query     string  false  "Group by source or category"  default(source)
+// @Param        days      query     int     false  "Look-back window in days"     default(7)
+// @Success      200       {object}  response.APIResponse{data=model.CTRResponse}    "Click-through rates"
+// @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
+// @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /analytics/ctr [get]
+func (h *analyticsHandler) GetCTRStats(c echo.Context) error {
+	start := time.Now()
+
+	req := model.CTRParams{GroupBy: model.CTRGroupBySource, Days: 7}
+
+	if groupBy := c.QueryParam("group_by"); groupBy != "" {
+		req.GroupBy = groupBy
+	}
+
+	if daysParam := c.QueryParam("days"); daysParam != "" {
+		days, err := strconv.Atoi(daysParam)
+		if err != nil {
+			h.logger.LogServiceOperation("analytics_handler", "get_ctr_stats", false, time.Since(start).Milliseconds())
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid days parameter")
+		}
+		req.Days = days
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "get_ctr_stats", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	stats, err := h.analyticsService.GetCTRStats(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "get_ctr_stats", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to retrieve click-through rates")
+	}
+
+	h.logger.LogServiceOperation("analytics_handler", "get_ctr_stats", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, stats)
+}
+
+// GetSearchAnalytics handles GET /api/v1/admin/search-analytics
+// @Summary      Get search analytics
+// @Description  Most frequent search terms and terms that returned no results, to find gaps in content coverage. Terms are lower-cased with whitespace collapsed; only the first page of a search is counted.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        days   query     int  false  "Look-back window in days"     default(7)
+// @Param        limit  query     int  false  "Terms per list"               default(20)
+// @Success      200    {object}  response.APIResponse{data=model.SearchAnalyticsResponse}  "Search analytics"
+// @Failure      400    {object}  response.APIResponse{error=response.ErrorInfo}            "Validation error"
+// @Failure      500    {object}  response.APIResponse{error=response.ErrorInfo}            "Internal server error"
+// @Router       /admin/search-analytics [get]
+func (h *analyticsHandler) GetSearchAnalytics(c echo.Context) error {
+	start := time.Now()
+
+	req := model.SearchAnalyticsParams{Days: 7, Limit: 20}
+
+	if daysParam := c.QueryParam("days"); daysParam != "" {
+		days, err := strconv.Atoi(daysParam)
+		if err != nil {
+			h.logger.LogServiceOperation("analytics_handler", "get_search_analytics", false, time.Since(start).Milliseconds())
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid days parameter")
+		}
+		req.Days = days
+	}
+
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			h.logger.LogServiceOperation("analytics_handler", "get_search_analytics", false, time.Since(start).Milliseconds())
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid limit parameter")
+		}
+		req.Limit = limit
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "get_search_analytics", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	stats, err := h.analyticsService.GetSearchAnalytics(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "get_search_analytics", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to retrieve search analytics")
+	}
+
+	h.logger.LogServiceOperation("analytics_handler", "get_search_analytics", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, stats)
+}
+
+// GetPostStats handles GET /api/v1/stats/posts
+// @Summary      Get post ingestion statistics
+// @Description  Number of posts ingested per day, per category, source or country unless grouped by day alone, for dashboards of ingestion trends. Days are UTC calendar days of the ingestion time; grouped by day, days without posts are included with a zero count. Grouped by country, posts without one are counted under "unknown".
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Param        group_by  query     string  false  "Group by category, source, country or day"          default(day)
+// @Param        from      query     string  false  "Window start, RFC 3339 or YYYY-MM-DD; default 30 days before to"
+// @Param        to        query     string  false  "Window end (exclusive), RFC 3339 or YYYY-MM-DD; default now"
+// @Success      200       {object}  response.APIResponse{data=model.PostStatsResponse}  "Post statistics"
+// @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}      "Invalid parameters"
+// @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}      "Internal server error"
+// @Router       /stats/posts [get]
+func (h *analyticsHandler) GetPostStats(c echo.Context) error {
+	start := time.Now()
+
+	req := model.PostStatsParams{GroupBy: model.PostStatsGroupByDay}
+
+	if groupBy := c.QueryParam("group_by"); groupBy != "" {
+		req.GroupBy = groupBy
+	}
+
+	var err error
+	if req.From, err = parseTimeParam(c, "from"); err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "get_post_stats", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid from parameter", err.Error())
+	}
+	if req.To, err = parseTimeParam(c, "to"); err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "get_post_stats", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid to parameter", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "get_post_stats", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	stats, err := h.analyticsService.GetPostStats(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("analytics_handler", "get_post_stats", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrPostStatsRangeInvalid) {
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid time range", err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to retrieve post statistics")
+	}
+
+	h.logger.LogServiceOperation("analytics_handler", "get_post_stats", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, stats)
+}
+
+// parseTimeParam reads an optional RFC 3339 timestamp or YYYY-MM-DD date
+// query parameter, returning the zero time when it is absent
+func parseTimeParam(c echo.Context, name string) (time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC 3339 timestamp or a YYYY-MM-DD date", name)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockAnalyticsService is a mock implementation of AnalyticsService
+type MockAnalyticsService struct {
+	mock.Mock
+}
+
+func (m *MockAnalyticsService) RecordClick(ctx context.Context, postID int64, referrer, userAgent string) (*model.Post, error) {
+	args := m.Called(ctx, postID, referrer, userAgent)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Post), args.Error(1)
+}
+
+func (m *MockAnalyticsService) GetCTRStats(ctx context.Context, req *model.CTRParams) (*model.CTRResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CTRResponse), args.Error(1)
+}
+
+func (m *MockAnalyticsService) RecordSearch(ctx context.Context, term string, resultCount int64, latency time.Duration) error {
+	args := m.Called(ctx, term, resultCount, latency)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsService) GetSearchAnalytics(ctx context.Context, req *model.SearchAnalyticsParams) (*model.SearchAnalyticsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SearchAnalyticsResponse), args.Error(1)
+}
+
+func (m *MockAnalyticsService) GetPostStats(ctx context.Context, req *model.PostStatsParams) (*model.PostStatsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PostStatsResponse), args.Error(1)
+}
+
+// AnalyticsHandlerTestSuite defines the test suite for AnalyticsHandler
+type AnalyticsHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockAnalyticsService
+	handler     AnalyticsHandler
+	echo        *echo.Echo
+}
+
+func (suite *AnalyticsHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockAnalyticsService)
+	suite.handler = NewAnalyticsHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *AnalyticsHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *AnalyticsHandlerTestSuite) createEchoContext(method, target string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, nil)
+	rec := httptest.NewRecorder()
+	return suite.echo.NewContext(req, rec), rec
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestRedirectToPostSuccess() {
+	post := &model.Post{ID: 1, URL: "https://example.com/article"}
+
+	suite.mockService.On("RecordClick", mock.Anything, int64(1), "https://example.com/feed", "test-agent").Return(post, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/r/1")
+	c.Request().Header.Set("Referer", "https://example.com/feed")
+	c.Request().Header.Set("User-Agent", "test-agent")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := suite.handler.RedirectToPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusFound, rec.Code)
+	assert.Equal(suite.T(), post.URL, rec.Header().Get(echo.HeaderLocation))
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestRedirectToPostWhenClickNotRecorded() {
+	post := &model.Post{ID: 1, URL: "https://example.com/article"}
+
+	suite.mockService.On("RecordClick", mock.Anything, int64(1), "", mock.Anything).Return(post, errors.New("database down"))
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/r/1")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := suite.handler.RedirectToPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusFound, rec.Code)
+	assert.Equal(suite.T(), post.URL, rec.Header().Get(echo.HeaderLocation))
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestRecordClickNotFound() {
+	suite.mockService.On("RecordClick", mock.Anything, int64(99), "", mock.Anything).Return(nil, service.ErrPostNotFound)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/posts/99/click")
+	c.SetParamNames("id")
+	c.SetParamValues("99")
+
+	err := suite.handler.RecordClick(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestRecordClickInvalidID() {
+	c, rec := suite.createEchoContext(http.MethodPost, "/posts/abc/click")
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+
+	err := suite.handler.RecordClick(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestGetSearchAnalyticsSuccess() {
+	result := &model.SearchAnalyticsResponse{
+		TotalSearches:      3,
+		ZeroResultSearches: 1,
+		TopQueries:         []model.SearchTermStat{{Term: "golang", Searches: 2}},
+		ZeroResultQueries:  []model.SearchTermStat{{Term: "quantum kittens", Searches: 1}},
+	}
+
+	suite.mockService.On("GetSearchAnalytics", mock.Anything, mock.MatchedBy(func(req *model.SearchAnalyticsParams) bool {
+		return req.Days == 30 && req.Limit == 20
+	})).Return(result, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/admin/search-analytics?days=30")
+
+	err := suite.handler.GetSearchAnalytics(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"zero_result_queries"`)
+	assert.Contains(suite.T(), rec.Body.String(), `"quantum kittens"`)
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestGetSearchAnalyticsInvalidLimit() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/admin/search-analytics?limit=many")
+
+	err := suite.handler.GetSearchAnalytics(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestGetPostStatsSuccess() {
+	result := &model.PostStatsResponse{
+		GroupBy: model.PostStatsGroupByCategory,
+		Total:   3,
+		Buckets: []model.PostStatsBucket{{Day: "2025-08-10", Key: "technology", Count: 3}},
+	}
+
+	suite.mockService.On("GetPostStats", mock.Anything, mock.MatchedBy(func(req *model.PostStatsParams) bool {
+		return req.GroupBy == model.PostStatsGroupByCategory &&
+			req.From.Equal(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)) &&
+			req.To.Equal(time.Date(2025, 8, 11, 12, 0, 0, 0, time.UTC))
+	})).Return(result, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/stats/posts?group_by=category&from=2025-08-01&to=2025-08-11T12:00:00Z")
+
+	err := suite.handler.GetPostStats(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"key":"technology"`)
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestGetPostStatsByCountry() {
+	result := &model.PostStatsResponse{
+		GroupBy: model.PostStatsGroupByCountry,
+		Total:   2,
+		Buckets: []model.PostStatsBucket{{Day: "2025-08-10", Key: "de", Count: 2}},
+	}
+
+	suite.mockService.On("GetPostStats", mock.Anything, mock.MatchedBy(func(req *model.PostStatsParams) bool {
+		return req.GroupBy == model.PostStatsGroupByCountry
+	})).Return(result, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/stats/posts?group_by=country")
+
+	err := suite.handler.GetPostStats(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"key":"de"`)
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestGetPostStatsInvalidFrom() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/stats/posts?from=yesterday")
+
+	err := suite.handler.GetPostStats(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *AnalyticsHandlerTestSuite) TestGetPostStatsInvalidRange() {
+	suite.mockService.On("GetPostStats", mock.Anything, mock.Anything).Return(nil, service.ErrPostStatsRangeInvalid)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/stats/posts?from=2025-08-11&to=2025-08-01")
+
+	err := suite.handler.GetPostStats(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), string(response.CodeInvalidParameter))
+}
+
+func TestAnalyticsHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsHandlerTestSuite))
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/botdetect"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+// botTrackedClients bounds the clients whose request rate is tracked; the
+// least recently seen are forgotten first
+const botTrackedClients = 100_000
+
+// BotDetection tags requests from bots in their context, so that views and
+// clicks they make are left out of analytics. A request comes from a bot
+// when its user agent is a known crawler or script, or when its client made
+// more than cfg.BehaviorThreshold requests within cfg.BehaviorWindow. Bots
+// are limited to cfg.RateLimit requests per second per client, except on the
+// syndication feeds and sitemap they are meant to crawl.
+func BotDetection(cfg config.BotConfig, log *logger.Logger) echo.MiddlewareFunc {
+	matcher := botdetect.NewMatcher(cfg.UserAgents)
+
+	var requests *lru.Cache[string, *atomic.Int64]
+	if cfg.BehaviorThreshold > 0 {
+		requests = lru.New[string, *atomic.Int64](botTrackedClients, cfg.BehaviorWindow)
+	}
+
+	var limiter *middleware.RateLimiterMemoryStore
+	if cfg.RateLimit > 0 {
+		limiter = middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:  rate.Limit(cfg.RateLimit),
+			Burst: cfg.RateBurst,
+		})
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			client := c.RealIP()
+			if !matcher.Match(c.Request().UserAgent()) && !exceedsBehaviorThreshold(requests, client, cfg.BehaviorThreshold) {
+				return next(c)
+			}
+
+			c.SetRequest(c.Request().WithContext(botdetect.WithBot(c.Request().Context())))
+
+			if limiter != nil && !crawlablePath(c.Request().URL.Path) {
+				if allowed, _ := limiter.Allow(client); !allowed {
+					log.Debug("Bot request rate limited", "client", client, "user_agent", c.Request().UserAgent())
+					return response.Error(c, http.StatusTooManyRequests, response.CodeRateLimited, "Too Many Requests")
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// exceedsBehaviorThreshold counts a request of client and reports whether
+// it made more than threshold within the current window. Counts are kept in
+// a fixed window starting at the client's first request.
+func exceedsBehaviorThreshold(requests *lru.Cache[string, *atomic.Int64], client string, threshold int) bool {
+	if requests == nil {
+		return false
+	}
+
+	count, _ := requests.GetOrSet(client, new(atomic.Int64))
+
+	return count.Add(1) > int64(threshold)
+}
+
+// crawlablePath reports whether path serves content meant for crawlers
+func crawlablePath(path string) bool {
+	return path == "/rss" || strings.HasPrefix(path, "/rss/") || path == "/sitemap.xml"
+}
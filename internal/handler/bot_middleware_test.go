@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/botdetect"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const browserUserAgent = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Safari/537.36"
+
+// botServer returns a server behind BotDetection whose responses report
+// whether the request was tagged as a bot
+func botServer(cfg config.BotConfig) *echo.Echo {
+	e := echo.New()
+	e.Use(BotDetection(cfg, logger.New(&config.Config{App: config.AppConfig{LogLevel: "error"}})))
+	e.GET("/*", func(c echo.Context) error {
+		if botdetect.FromContext(c.Request().Context()) {
+			return c.String(http.StatusOK, "bot")
+		}
+		return c.String(http.StatusOK, "human")
+	})
+
+	return e
+}
+
+func serveBot(e *echo.Echo, path, userAgent string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("User-Agent", userAgent)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestBotDetectionTagsUserAgents(t *testing.T) {
+	e := botServer(config.BotConfig{UserAgents: []string{"NewsHarvester"}})
+
+	assert.Equal(t, "human", serveBot(e, "/api/v1/posts/1", browserUserAgent).Body.String())
+	assert.Equal(t, "bot", serveBot(e, "/api/v1/posts/1", "Mozilla/5.0 (compatible; Googlebot/2.1)").Body.String())
+	assert.Equal(t, "bot", serveBot(e, "/api/v1/posts/1", "curl/8.5.0").Body.String())
+	assert.Equal(t, "bot", serveBot(e, "/api/v1/posts/1", "newsharvester/1.0").Body.String())
+	assert.Equal(t, "bot", serveBot(e, "/api/v1/posts/1", "").Body.String())
+}
+
+func TestBotDetectionTagsBusyClients(t *testing.T) {
+	e := botServer(config.BotConfig{BehaviorThreshold: 2, BehaviorWindow: time.Minute})
+
+	assert.Equal(t, "human", serveBot(e, "/api/v1/posts", browserUserAgent).Body.String())
+	assert.Equal(t, "human", serveBot(e, "/api/v1/posts", browserUserAgent).Body.String())
+	assert.Equal(t, "bot", serveBot(e, "/api/v1/posts", browserUserAgent).Body.String())
+}
+
+func TestExceedsBehaviorThresholdCountsConcurrentRequests(t *testing.T) {
+	for range 100 {
+		requests := lru.New[string, *atomic.Int64](botTrackedClients, time.Minute)
+
+		var under atomic.Int64
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				if !exceedsBehaviorThreshold(requests, "203.0.113.7", 5) {
+					under.Add(1)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		// The first requests of a client share one count however they
+		// interleave, so no more than the threshold get through
+		require.Equal(t, int64(5), under.Load())
+	}
+}
+
+func TestBotDetectionCountsForgedForwardedForByRemoteAddress(t *testing.T) {
+	e := botServer(config.BotConfig{BehaviorThreshold: 2, BehaviorWindow: time.Minute})
+	e.IPExtractor = ClientIPExtractor(config.AccessConfig{})
+
+	serve := func(forwardedFor string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/posts", nil)
+		req.RemoteAddr = "203.0.113.7:41234"
+		req.Header.Set("User-Agent", browserUserAgent)
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		req.Header.Set(echo.HeaderXRealIP, forwardedFor)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		return rec.Body.String()
+	}
+
+	// Without trusted proxies every request counts against the connection's
+	// address, whatever the client claims to forward
+	assert.Equal(t, "human", serve("198.51.100.1"))
+	assert.Equal(t, "human", serve("198.51.100.2"))
+	assert.Equal(t, "bot", serve("198.51.100.3"))
+}
+
+func TestBotDetectionRateLimitsBots(t *testing.T) {
+	e := botServer(config.BotConfig{RateLimit: 0.001, RateBurst: 1})
+
+	assert.Equal(t, http.StatusOK, serveBot(e, "/api/v1/posts", "curl/8.5.0").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveBot(e, "/api/v1/posts", "curl/8.5.0").Code)
+
+	// Feeds and the sitemap stay open to crawlers, and people are not limited
+	assert.Equal(t, http.StatusOK, serveBot(e, "/sitemap.xml", "curl/8.5.0").Code)
+	assert.Equal(t, http.StatusOK, serveBot(e, "/rss/technology", "curl/8.5.0").Code)
+	assert.Equal(t, http.StatusOK, serveBot(e, "/api/v1/posts", browserUserAgent).Code)
+	assert.Equal(t, http.StatusOK, serveBot(e, "/api/v1/posts", browserUserAgent).Code)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// changeHandler implements ChangeHandler interface
+type changeHandler struct {
+	changeService service.ChangeService
+	logger        *logger.Logger
+}
+
+// NewChangeHandler creates a new post change feed handler
+func NewChangeHandler(changeService service.ChangeService, logger *logger.Logger) ChangeHandler {
+	return &changeHandler{
+		changeService: changeService,
+		logger:        logger,
+	}
+}
+
+// ListPostChanges handles GET /api/v1/posts/changes
+// @Summary      List post changes
+// @Description  List the IDs of the posts created, updated and deleted since a marker, for incremental sync. Start from an RFC 3339 timestamp, then pass the returned cursor as since; keep polling while has_more is true. A post is listed once, as of its last change; posts that are no longer published count as deleted. The last few seconds of changes are held back until they settle. Markers older than the change feed retention get 410 and require a full sync.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        since  query     string  true   "RFC 3339 timestamp or cursor from the previous response"
+// @Param        limit  query     int     false  "Most changes returned (default 100, max 1000)"
+// @Success      200    {object}  response.APIResponse{data=model.PostChangesResponse}  "Post changes"
+// @Failure      400    {object}  response.APIResponse{error=response.ErrorInfo}        "Missing or invalid marker"
+// @Failure      410    {object}  response.APIResponse{error=response.ErrorInfo}        "Marker older than the retention"
+// @Failure      500    {object}  response.APIResponse{error=response.ErrorInfo}        "Internal server error"
+// @Router       /posts/changes [get]
+func (h *changeHandler) ListPostChanges(c echo.Context) error {
+	start := time.Now()
+
+	req := model.PostChangesParams{Since: c.QueryParam("since"), Limit: 100}
+	if req.Since == "" {
+		h.logger.LogServiceOperation("change_handler", "list_post_changes", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeMissingParameter, "Query parameter 'since' is required")
+	}
+
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil && limit > 0 {
+			req.Limit = limit
+		}
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("change_handler", "list_post_changes", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	changes, err := h.changeService.ListPostChanges(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("change_handler", "list_post_changes", false, time.Since(start).Milliseconds())
+
+		switch {
+		case errors.Is(err, service.ErrChangesMarkerInvalid):
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid since parameter", err.Error())
+		case errors.Is(err, service.ErrChangesMarkerExpired):
+			return response.Error(c, http.StatusGone, response.CodeChangesMarkerExpired, "Change feed marker expired, sync from scratch", err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to list post changes")
+	}
+
+	h.logger.LogServiceOperation("change_handler", "list_post_changes", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, changes)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockChangeService is a mock implementation of ChangeService
+type MockChangeService struct {
+	mock.Mock
+}
+
+func (m *MockChangeService) ListPostChanges(ctx context.Context, req *model.PostChangesParams) (*model.PostChangesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PostChangesResponse), args.Error(1)
+}
+
+func (m *MockChangeService) PruneDeletions(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// ChangeHandlerTestSuite defines the test suite for ChangeHandler
+type ChangeHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockChangeService
+	handler     ChangeHandler
+	echo        *echo.Echo
+}
+
+func (suite *ChangeHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockChangeService)
+	suite.handler = NewChangeHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *ChangeHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *ChangeHandlerTestSuite) TestListPostChangesSuccess() {
+	result := &model.PostChangesResponse{
+		Created: []int64{1},
+		Updated: []int64{2},
+		Deleted: []int64{3},
+		Cursor:  "MTcwMDAwMDAwMDAwMDAwMDozCg",
+		HasMore: true,
+	}
+
+	suite.mockService.On("ListPostChanges", mock.Anything, &model.PostChangesParams{Since: "2025-01-01T00:00:00Z", Limit: 50}).Return(result, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/changes?since=2025-01-01T00:00:00Z&limit=50", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListPostChanges(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"deleted":[3]`)
+	assert.Contains(suite.T(), rec.Body.String(), `"has_more":true`)
+}
+
+func (suite *ChangeHandlerTestSuite) TestListPostChangesMissingSince() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/changes", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListPostChanges(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "ListPostChanges", mock.Anything, mock.Anything)
+}
+
+func (suite *ChangeHandlerTestSuite) TestListPostChangesInvalidMarker() {
+	suite.mockService.On("ListPostChanges", mock.Anything, mock.Anything).Return(nil, service.ErrChangesMarkerInvalid)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/changes?since=yesterday", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListPostChanges(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "INVALID_PARAMETER")
+}
+
+func (suite *ChangeHandlerTestSuite) TestListPostChangesExpiredMarker() {
+	suite.mockService.On("ListPostChanges", mock.Anything, mock.Anything).Return(nil, service.ErrChangesMarkerExpired)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/changes?since=2020-01-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListPostChanges(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusGone, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "CHANGES_MARKER_EXPIRED")
+}
+
+func (suite *ChangeHandlerTestSuite) TestListPostChangesServiceError() {
+	suite.mockService.On("ListPostChanges", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/changes?since=2025-01-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListPostChanges(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+}
+
+func TestChangeHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangeHandlerTestSuite))
+}
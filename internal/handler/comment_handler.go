@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// commentHandler implements CommentHandler interface
+type commentHandler struct {
+	commentService service.CommentService
+	logger         *logger.Logger
+}
+
+// NewCommentHandler creates a new comment handler
+func NewCommentHandler(commentService service.CommentService, logger *logger.Logger) CommentHandler {
+	return &commentHandler{
+		commentService: commentService,
+		logger:         logger,
+	}
+}
+
+// CreateComment handles POST /api/v1/posts/:id/comments
+// @Summary      Comment on a post
+// @Description  Add a comment to a post. Set parent_id to reply to an existing comment on the same post.
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                        true  "Post ID"
+// @Param        comment  body      model.CreateCommentParams  true  "Comment"
+// @Success      201      {object}  response.APIResponse{data=model.Comment}        "Comment created"
+// @Failure      400      {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid request or parent comment"
+// @Failure      404      {object}  response.APIResponse{error=response.ErrorInfo}  "Post not found"
+// @Failure      500      {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/{id}/comments [post]
+func (h *commentHandler) CreateComment(c echo.Context) error {
+	start := time.Now()
+
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || postID <= 0 {
+		h.logger.LogServiceOperation("comment_handler", "create_comment", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	var req model.CreateCommentParams
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("comment_handler", "create_comment", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("comment_handler", "create_comment", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	comment, err := h.commentService.CreateComment(c.Request().Context(), postID, &req)
+	if err != nil {
+		h.logger.LogServiceOperation("comment_handler", "create_comment", false, time.Since(start).Milliseconds())
+
+		switch {
+		case errors.Is(err, service.ErrPostNotFound):
+			return response.NotFound(c, response.CodePostNotFound, "Post not found")
+		case errors.Is(err, service.ErrParentCommentInvalid):
+			return response.BadRequest(c, response.CodeInvalidParentComment, "Invalid parent comment", err.Error())
+		case errors.Is(err, service.ErrCommentInvalid):
+			return response.BadRequest(c, response.CodeValidationFailed, "Invalid comment", err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to create comment")
+	}
+
+	h.logger.LogServiceOperation("comment_handler", "create_comment", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusCreated, comment, "Comment created successfully")
+}
+
+// ListComments handles GET /api/v1/posts/:id/comments
+// @Summary      List a post's comments
+// @Description  List top-level comments on a post, oldest first, each with its replies nested beneath it. Pagination applies to top-level comments. Deleted comments keep their place in the thread with author and body removed.
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int  true   "Post ID"
+// @Param        page   query     int  false  "Page number"
+// @Param        limit  query     int  false  "Top-level comments per page"
+// @Success      200    {object}  response.APIResponse{data=response.PaginatedResponse{items=[]model.Comment,pagination=response.PaginationInfo}}  "Comment threads"
+// @Failure      400    {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
+// @Failure      404    {object}  response.APIResponse{error=response.ErrorInfo}  "Post not found"
+// @Failure      500    {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/{id}/comments [get]
+func (h *commentHandler) ListComments(c echo.Context) error {
+	start := time.Now()
+
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || postID <= 0 {
+		h.logger.LogServiceOperation("comment_handler", "list_comments", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	req := model.CommentListParams{PostID: postID, Page: 1, Limit: 20}
+
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if page, err := strconv.Atoi(pageParam); err == nil && page > 0 {
+			req.Page = page
+		}
+	}
+
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil && limit > 0 {
+			req.Limit = limit
+		}
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("comment_handler", "list_comments", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	result, err := h.commentService.ListComments(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("comment_handler", "list_comments", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrPostNotFound) {
+			return response.NotFound(c, response.CodePostNotFound, "Post not found")
+		}
+
+		return response.InternalServerError(c, "Failed to list comments")
+	}
+
+	h.logger.LogServiceOperation("comment_handler", "list_comments", true, time.Since(start).Milliseconds())
+
+	paginationInfo := response.CreatePaginationInfo(req.Page, req.Limit, int(result.Pagination.Total))
+
+	return response.SuccessWithPagination(c, result.Comments, paginationInfo, nil)
+}
+
+// DeleteComment handles DELETE /api/v1/posts/:id/comments/:comment_id
+// @Summary      Delete a comment
+// @Description  Soft-delete a comment. Its replies stay visible and it no longer counts towards the post's comment_count.
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int  true  "Post ID"
+// @Param        comment_id  path      int  true  "Comment ID"
+// @Success      204         {string}  string                                          "No Content"
+// @Failure      400         {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid ID"
+// @Failure      404         {object}  response.APIResponse{error=response.ErrorInfo}  "Comment not found"
+// @Failure      500         {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/{id}/comments/{comment_id} [delete]
+func (h *commentHandler) DeleteComment(c echo.Context) error {
+	start := time.Now()
+
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || postID <= 0 {
+		h.logger.LogServiceOperation("comment_handler", "delete_comment", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	commentID, err := strconv.ParseInt(c.Param("comment_id"), 10, 64)
+	if err != nil || commentID <= 0 {
+		h.logger.LogServiceOperation("comment_handler", "delete_comment", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidCommentID, "Invalid comment ID")
+	}
+
+	if err := h.commentService.DeleteComment(c.Request().Context(), postID, commentID); err != nil {
+		h.logger.LogServiceOperation("comment_handler", "delete_comment", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrCommentNotFound) {
+			return response.NotFound(c, response.CodeCommentNotFound, "Comment not found")
+		}
+
+		return response.InternalServerError(c, "Failed to delete comment")
+	}
+
+	h.logger.LogServiceOperation("comment_handler", "delete_comment", true, time.Since(start).Milliseconds())
+
+	return c.NoContent(http.StatusNoContent)
+}
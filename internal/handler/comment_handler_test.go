@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockCommentService is a mock implementation of CommentService
+type MockCommentService struct {
+	mock.Mock
+}
+
+func (m *MockCommentService) CreateComment(ctx context.Context, postID int64, req *model.CreateCommentParams) (*model.Comment, error) {
+	args := m.Called(ctx, postID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) ListComments(ctx context.Context, req *model.CommentListParams) (*model.CommentListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CommentListResponse), args.Error(1)
+}
+
+func (m *MockCommentService) DeleteComment(ctx context.Context, postID, id int64) error {
+	args := m.Called(ctx, postID, id)
+	return args.Error(0)
+}
+
+// CommentHandlerTestSuite defines the test suite for CommentHandler
+type CommentHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockCommentService
+	handler     CommentHandler
+	echo        *echo.Echo
+}
+
+func (suite *CommentHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockCommentService)
+	suite.handler = NewCommentHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *CommentHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *CommentHandlerTestSuite) postComment(postID, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/posts/"+postID+"/comments", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(postID)
+	return c, rec
+}
+
+func (suite *CommentHandlerTestSuite) TestCreateCommentSuccess() {
+	comment := &model.Comment{ID: 7, PostID: 1, Author: "jane", Body: "Nice"}
+
+	suite.mockService.On("CreateComment", mock.Anything, int64(1), mock.MatchedBy(func(req *model.CreateCommentParams) bool {
+		return req.Author == "jane" && req.Body == "Nice"
+	})).Return(comment, nil)
+
+	c, rec := suite.postComment("1", `{"author":"jane","body":"Nice"}`)
+
+	err := suite.handler.CreateComment(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"id":7`)
+}
+
+func (suite *CommentHandlerTestSuite) TestCreateCommentInvalidParent() {
+	suite.mockService.On("CreateComment", mock.Anything, int64(1), mock.Anything).Return(nil, service.ErrParentCommentInvalid)
+
+	c, rec := suite.postComment("1", `{"author":"jane","body":"Reply","parent_id":99}`)
+
+	err := suite.handler.CreateComment(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"INVALID_PARENT_COMMENT"`)
+}
+
+func (suite *CommentHandlerTestSuite) TestCreateCommentPostNotFound() {
+	suite.mockService.On("CreateComment", mock.Anything, int64(99), mock.Anything).Return(nil, service.ErrPostNotFound)
+
+	c, rec := suite.postComment("99", `{"author":"jane","body":"Nice"}`)
+
+	err := suite.handler.CreateComment(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"POST_NOT_FOUND"`)
+}
+
+func (suite *CommentHandlerTestSuite) TestCreateCommentLocalizedMessages() {
+	suite.mockService.On("CreateComment", mock.Anything, int64(99), mock.Anything).Return(nil, service.ErrPostNotFound)
+
+	c, rec := suite.postComment("99", `{"author":"jane","body":"Nice"}`)
+	c.Request().Header.Set("Accept-Language", "fr-CA, es-MX;q=0.9, en;q=0.8")
+
+	err := suite.handler.CreateComment(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"message":"Publicación no encontrada"`)
+	assert.Equal(suite.T(), "es", rec.Header().Get("Content-Language"))
+}
+
+func (suite *CommentHandlerTestSuite) TestCreateCommentLocalizedValidationErrors() {
+	suite.echo.Validator = validator.NewValidator()
+
+	c, rec := suite.postComment("1", `{"author":"jane"}`)
+	c.Request().Header.Set("Accept-Language", "de")
+
+	err := suite.handler.CreateComment(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"message":"Validierung der Anfrage fehlgeschlagen"`)
+	assert.Contains(suite.T(), rec.Body.String(), `body ist erforderlich`)
+}
+
+func (suite *CommentHandlerTestSuite) TestListCommentsPaginates() {
+	result := &model.CommentListResponse{
+		Comments:   []model.Comment{{ID: 1, PostID: 1, Replies: []model.Comment{{ID: 2, PostID: 1}}}},
+		Pagination: model.PaginationMeta{Page: 2, Limit: 5, Total: 6},
+	}
+
+	suite.mockService.On("ListComments", mock.Anything, mock.MatchedBy(func(req *model.CommentListParams) bool {
+		return req.PostID == 1 && req.Page == 2 && req.Limit == 5
+	})).Return(result, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/1/comments?page=2&limit=5", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := suite.handler.ListComments(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"replies":[{"id":2`)
+	assert.Contains(suite.T(), rec.Body.String(), `"total":6`)
+}
+
+func (suite *CommentHandlerTestSuite) TestDeleteCommentNotFound() {
+	suite.mockService.On("DeleteComment", mock.Anything, int64(1), int64(7)).Return(service.ErrCommentNotFound)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/posts/1/comments/7", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	c.SetParamNames("id", "comment_id")
+	c.SetParamValues("1", "7")
+
+	err := suite.handler.DeleteComment(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"COMMENT_NOT_FOUND"`)
+}
+
+func (suite *CommentHandlerTestSuite) TestDeleteCommentSuccess() {
+	suite.mockService.On("DeleteComment", mock.Anything, int64(1), int64(7)).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/posts/1/comments/7", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	c.SetParamNames("id", "comment_id")
+	c.SetParamValues("1", "7")
+
+	err := suite.handler.DeleteComment(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNoContent, rec.Code)
+}
+
+func TestCommentHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentHandlerTestSuite))
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// configHandler implements ConfigHandler interface
+type configHandler struct {
+	configService service.ConfigService
+	logger        *logger.Logger
+}
+
+// NewConfigHandler creates a new configuration handler
+func NewConfigHandler(configService service.ConfigService, logger *logger.Logger) ConfigHandler {
+	return &configHandler{
+		configService: configService,
+		logger:        logger,
+	}
+}
+
+// ReloadConfig handles POST /api/v1/admin/config/reload
+// @Summary      Reload configuration
+// @Description  Re-read the environment and .env file and apply the runtime settings (log level, cache TTL, CORS origins, job intervals, NewsAPI key). The new configuration is validated first and nothing is applied if it is invalid. Other settings require a restart.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=model.ConfigReloadResult}  "Configuration reloaded"
+// @Failure      422  {object}  response.APIResponse{error=response.ErrorInfo}       "New configuration is invalid"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}       "Internal server error"
+// @Router       /admin/config/reload [post]
+func (h *configHandler) ReloadConfig(c echo.Context) error {
+	start := time.Now()
+
+	result, err := h.configService.Reload(c.Request().Context())
+	if err != nil {
+		h.logger.LogServiceOperation("config_handler", "reload_config", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrInvalidConfig) {
+			return response.Error(c, http.StatusUnprocessableEntity, response.CodeInvalidConfig, "Configuration is invalid", err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to reload configuration")
+	}
+
+	h.logger.LogServiceOperation("config_handler", "reload_config", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, result, "Configuration reloaded")
+}
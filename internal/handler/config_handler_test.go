@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockConfigService is a mock implementation of ConfigService
+type MockConfigService struct {
+	mock.Mock
+}
+
+func (m *MockConfigService) Reload(ctx context.Context) (*model.ConfigReloadResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ConfigReloadResult), args.Error(1)
+}
+
+func (m *MockConfigService) OnReload(apply service.ConfigApplier) {
+	m.Called(apply)
+}
+
+// ConfigHandlerTestSuite defines the test suite for ConfigHandler
+type ConfigHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockConfigService
+	handler     ConfigHandler
+	echo        *echo.Echo
+}
+
+func (suite *ConfigHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockConfigService)
+	suite.handler = NewConfigHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+}
+
+func (suite *ConfigHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *ConfigHandlerTestSuite) postReload() (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/config/reload", nil)
+	rec := httptest.NewRecorder()
+	return suite.echo.NewContext(req, rec), rec
+}
+
+func (suite *ConfigHandlerTestSuite) TestReloadConfigSuccess() {
+	result := &model.ConfigReloadResult{ReloadedAt: time.Now(), Changed: []string{"LOG_LEVEL"}}
+	suite.mockService.On("Reload", mock.Anything).Return(result, nil)
+
+	c, rec := suite.postReload()
+
+	err := suite.handler.ReloadConfig(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"LOG_LEVEL"`)
+}
+
+func (suite *ConfigHandlerTestSuite) TestReloadConfigInvalid() {
+	reloadErr := fmt.Errorf("%w: scheduler job intervals must be positive", service.ErrInvalidConfig)
+	suite.mockService.On("Reload", mock.Anything).Return(nil, reloadErr)
+
+	c, rec := suite.postReload()
+
+	err := suite.handler.ReloadConfig(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"CONFIG_INVALID"`)
+}
+
+func (suite *ConfigHandlerTestSuite) TestReloadConfigInternalError() {
+	suite.mockService.On("Reload", mock.Anything).Return(nil, errors.New("boom"))
+
+	c, rec := suite.postReload()
+
+	err := suite.handler.ReloadConfig(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+}
+
+func TestConfigHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigHandlerTestSuite))
+}
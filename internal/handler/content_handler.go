@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// contentHandler implements ContentHandler interface
+type contentHandler struct {
+	contentService service.ContentFetcherService
+	logger         *logger.Logger
+}
+
+// NewContentHandler creates a new content enrichment handler
+func NewContentHandler(contentService service.ContentFetcherService, logger *logger.Logger) ContentHandler {
+	return &contentHandler{
+		contentService: contentService,
+		logger:         logger,
+	}
+}
+
+// ReprocessPosts handles POST /api/v1/admin/posts/reprocess
+// @Summary      Reprocess existing posts
+// @Description  Send existing posts matching the filter back through the enrichment pipeline (content extraction and sensitivity classification). The run continues in the background; poll its progress with the returned run ID.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        filter  body      model.ReprocessParams  false  "Posts to reprocess; an empty body selects every post"
+// @Success      202     {object}  response.APIResponse{data=model.ReprocessRun}     "Reprocess run started"
+// @Failure      400     {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid filter"
+// @Failure      409     {object}  response.APIResponse{error=response.ErrorInfo}  "A reprocess run is already in progress"
+// @Failure      500     {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/posts/reprocess [post]
+func (h *contentHandler) ReprocessPosts(c echo.Context) error {
+	start := time.Now()
+
+	var req model.ReprocessParams
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("content_handler", "reprocess_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("content_handler", "reprocess_posts", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	run, err := h.contentService.StartReprocess(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("content_handler", "reprocess_posts", false, time.Since(start).Milliseconds())
+
+		switch {
+		case errors.Is(err, service.ErrReprocessInvalidRange):
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid date range", err.Error())
+		case errors.Is(err, service.ErrReprocessRunning):
+			return response.Conflict(c, response.CodeReprocessRunning, "A reprocess run is already in progress")
+		}
+
+		return response.InternalServerError(c, "Failed to start reprocess run")
+	}
+
+	h.logger.LogServiceOperation("content_handler", "reprocess_posts", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusAccepted, run, "Reprocess run started")
+}
+
+// GetReprocessRun handles GET /api/v1/admin/posts/reprocess/:id
+// @Summary      Get reprocess run progress
+// @Description  Retrieve the progress of a reprocess run. Only recent runs are kept.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Run ID"
+// @Success      200  {object}  response.APIResponse{data=model.ReprocessRun}     "Reprocess run"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Run not found"
+// @Router       /admin/posts/reprocess/{id} [get]
+func (h *contentHandler) GetReprocessRun(c echo.Context) error {
+	start := time.Now()
+
+	run, err := h.contentService.GetReprocessRun(c.Param("id"))
+	if err != nil {
+		h.logger.LogServiceOperation("content_handler", "get_reprocess_run", false, time.Since(start).Milliseconds())
+		return response.NotFound(c, response.CodeReprocessRunNotFound, "Reprocess run not found")
+	}
+
+	h.logger.LogServiceOperation("content_handler", "get_reprocess_run", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, run)
+}
+
+// PreviewArticle handles POST /api/v1/admin/articles/preview
+// @Summary      Preview an article before importing it
+// @Description  Fetch an article page through the enrichment pipeline and return the post it would be stored as, with its language and tags, whether it is flagged sensitive, already stored or refused by the source rules. Nothing is saved. The page's robots.txt and crawl delay are honoured.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        article  body      model.ArticlePreviewRequest  true  "Article URL"
+// @Success      200      {object}  response.APIResponse{data=model.ArticlePreview}  "Article preview"
+// @Failure      400      {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid URL"
+// @Failure      422      {object}  response.APIResponse{error=response.ErrorInfo}  "Page is disallowed by robots.txt, not HTML or untitled"
+// @Failure      502      {object}  response.APIResponse{error=response.ErrorInfo}  "Page could not be fetched"
+// @Failure      500      {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/articles/preview [post]
+func (h *contentHandler) PreviewArticle(c echo.Context) error {
+	start := time.Now()
+
+	var req model.ArticlePreviewRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("content_handler", "preview_article", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("content_handler", "preview_article", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	preview, err := h.contentService.PreviewArticle(c.Request().Context(), req.URL)
+	if err != nil {
+		h.logger.LogServiceOperation("content_handler", "preview_article", false, time.Since(start).Milliseconds())
+
+		switch {
+		case errors.Is(err, service.ErrContentDisallowed), errors.Is(err, service.ErrContentUnsupported), errors.Is(err, service.ErrContentUntitled):
+			return response.Error(c, http.StatusUnprocessableEntity, response.CodeArticleUnpreviewable, "Article cannot be previewed", err.Error())
+		case errors.Is(err, service.ErrContentFetch):
+			return response.Error(c, http.StatusBadGateway, response.CodeArticleFetchFailed, "Failed to fetch article", err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to preview article")
+	}
+
+	h.logger.LogServiceOperation("content_handler", "preview_article", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, preview)
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockContentFetcherService is a mock implementation of ContentFetcherService
+type MockContentFetcherService struct {
+	mock.Mock
+}
+
+func (m *MockContentFetcherService) FetchContent(ctx context.Context, url string) (string, error) {
+	args := m.Called(ctx, url)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockContentFetcherService) PreviewArticle(ctx context.Context, url string) (*model.ArticlePreview, error) {
+	args := m.Called(ctx, url)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ArticlePreview), args.Error(1)
+}
+
+func (m *MockContentFetcherService) EnrichPendingPosts(ctx context.Context) (*model.ContentEnrichmentResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ContentEnrichmentResult), args.Error(1)
+}
+
+func (m *MockContentFetcherService) StartReprocess(ctx context.Context, params *model.ReprocessParams) (*model.ReprocessRun, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReprocessRun), args.Error(1)
+}
+
+func (m *MockContentFetcherService) GetReprocessRun(id string) (*model.ReprocessRun, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReprocessRun), args.Error(1)
+}
+
+// ContentHandlerTestSuite defines the test suite for ContentHandler
+type ContentHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockContentFetcherService
+	handler     ContentHandler
+	echo        *echo.Echo
+}
+
+func (suite *ContentHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockContentFetcherService)
+	suite.handler = NewContentHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *ContentHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *ContentHandlerTestSuite) postReprocess(body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/posts/reprocess", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return suite.echo.NewContext(req, rec), rec
+}
+
+func (suite *ContentHandlerTestSuite) TestReprocessPostsAccepted() {
+	run := &model.ReprocessRun{ID: "abc123", Status: model.ReprocessStatusRunning, Total: 12}
+
+	suite.mockService.On("StartReprocess", mock.Anything, mock.MatchedBy(func(params *model.ReprocessParams) bool {
+		return params.Category != nil && *params.Category == "technology" && params.MissingContent
+	})).Return(run, nil)
+
+	c, rec := suite.postReprocess(`{"category":"technology","missing_content":true}`)
+
+	err := suite.handler.ReprocessPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusAccepted, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "abc123")
+}
+
+func (suite *ContentHandlerTestSuite) TestReprocessPostsAlreadyRunning() {
+	suite.mockService.On("StartReprocess", mock.Anything, mock.Anything).Return(nil, service.ErrReprocessRunning)
+
+	c, rec := suite.postReprocess(`{}`)
+
+	err := suite.handler.ReprocessPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusConflict, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"REPROCESS_ALREADY_RUNNING"`)
+}
+
+func (suite *ContentHandlerTestSuite) TestReprocessPostsInvalidRange() {
+	suite.mockService.On("StartReprocess", mock.Anything, mock.Anything).Return(nil, service.ErrReprocessInvalidRange)
+
+	c, rec := suite.postReprocess(`{"from":"2024-02-01T00:00:00Z","to":"2024-01-01T00:00:00Z"}`)
+
+	err := suite.handler.ReprocessPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *ContentHandlerTestSuite) TestGetReprocessRun() {
+	run := &model.ReprocessRun{ID: "abc123", Status: model.ReprocessStatusCompleted, Total: 12, Processed: 12}
+
+	suite.mockService.On("GetReprocessRun", "abc123").Return(run, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/posts/reprocess/abc123", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("abc123")
+
+	err := suite.handler.GetReprocessRun(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"completed"`)
+}
+
+func (suite *ContentHandlerTestSuite) TestGetReprocessRunNotFound() {
+	suite.mockService.On("GetReprocessRun", "missing").Return(nil, service.ErrReprocessRunNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/posts/reprocess/missing", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("missing")
+
+	err := suite.handler.GetReprocessRun(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"REPROCESS_RUN_NOT_FOUND"`)
+}
+
+func (suite *ContentHandlerTestSuite) postPreview(body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/articles/preview", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return suite.echo.NewContext(req, rec), rec
+}
+
+func (suite *ContentHandlerTestSuite) TestPreviewArticle() {
+	preview := &model.ArticlePreview{
+		Post:      model.CreatePostParams{Title: "Chip makers race to ship AI accelerators", URL: "https://example.com/chips", Source: "Example News"},
+		Language:  "en",
+		Tags:      []string{"chips"},
+		Duplicate: true,
+	}
+
+	suite.mockService.On("PreviewArticle", mock.Anything, "https://example.com/chips").Return(preview, nil)
+
+	c, rec := suite.postPreview(`{"url":"https://example.com/chips"}`)
+
+	err := suite.handler.PreviewArticle(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"title":"Chip makers race to ship AI accelerators"`)
+	assert.Contains(suite.T(), rec.Body.String(), `"language":"en"`)
+	assert.Contains(suite.T(), rec.Body.String(), `"duplicate":true`)
+}
+
+func (suite *ContentHandlerTestSuite) TestPreviewArticleInvalidBody() {
+	c, rec := suite.postPreview(`{"url":`)
+
+	err := suite.handler.PreviewArticle(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"INVALID_REQUEST_BODY"`)
+}
+
+func (suite *ContentHandlerTestSuite) TestPreviewArticleNotPreviewable() {
+	for _, cause := range []error{service.ErrContentDisallowed, service.ErrContentUnsupported, service.ErrContentUntitled} {
+		suite.mockService.On("PreviewArticle", mock.Anything, "https://example.com/page").
+			Return(nil, fmt.Errorf("%w: https://example.com/page", cause)).Once()
+
+		c, rec := suite.postPreview(`{"url":"https://example.com/page"}`)
+
+		err := suite.handler.PreviewArticle(c)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), `"code":"ARTICLE_NOT_PREVIEWABLE"`)
+	}
+}
+
+func (suite *ContentHandlerTestSuite) TestPreviewArticleFetchFailed() {
+	suite.mockService.On("PreviewArticle", mock.Anything, "https://example.com/missing").
+		Return(nil, fmt.Errorf("%w: unexpected status 404", service.ErrContentFetch))
+
+	c, rec := suite.postPreview(`{"url":"https://example.com/missing"}`)
+
+	err := suite.handler.PreviewArticle(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadGateway, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"ARTICLE_FETCH_FAILED"`)
+}
+
+func TestContentHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentHandlerTestSuite))
+}
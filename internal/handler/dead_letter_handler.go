@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// deadLetterHandler implements DeadLetterHandler interface
+type deadLetterHandler struct {
+	deadLetterService service.DeadLetterService
+	logger            *logger.Logger
+}
+
+// NewDeadLetterHandler creates a new dead letter handler
+func NewDeadLetterHandler(deadLetterService service.DeadLetterService, logger *logger.Logger) DeadLetterHandler {
+	return &deadLetterHandler{
+		deadLetterService: deadLetterService,
+		logger:            logger,
+	}
+}
+
+// ListDeadLetters handles GET /api/v1/admin/dead-letters
+// @Summary      List dead letters
+// @Description  List aggregated articles that could not be stored, most recent failure first, with the error of their last attempt
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int  false  "Page number"
+// @Param        limit  query     int  false  "Results per page"
+// @Success      200    {object}  response.APIResponse{data=model.DeadLetterListResponse}  "Dead letters"
+// @Failure      400    {object}  response.APIResponse{error=response.ErrorInfo}         "Validation error"
+// @Failure      500    {object}  response.APIResponse{error=response.ErrorInfo}         "Internal server error"
+// @Router       /admin/dead-letters [get]
+func (h *deadLetterHandler) ListDeadLetters(c echo.Context) error {
+	start := time.Now()
+
+	req := model.DeadLetterListParams{Page: 1, Limit: 20}
+
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if page, err := strconv.Atoi(pageParam); err == nil && page > 0 {
+			req.Page = page
+		}
+	}
+
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil && limit > 0 {
+			req.Limit = limit
+		}
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("dead_letter_handler", "list_dead_letters", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	result, err := h.deadLetterService.ListDeadLetters(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("dead_letter_handler", "list_dead_letters", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to list dead letters")
+	}
+
+	h.logger.LogServiceOperation("dead_letter_handler", "list_dead_letters", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, result)
+}
+
+// RetryDeadLetter handles POST /api/v1/admin/dead-letters/:id/retry
+// @Summary      Retry a dead letter
+// @Description  Store a dead letter's article again. It is removed once stored, or when it turns out to be stored already; an article that fails again keeps its entry with the new error.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Dead letter ID"
+// @Success      200  {object}  response.APIResponse{data=model.DeadLetterRetryResponse}  "Article stored"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}            "Invalid dead letter ID"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}            "Dead letter not found"
+// @Failure      422  {object}  response.APIResponse{error=response.ErrorInfo}            "Article cannot be stored"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}            "Internal server error"
+// @Router       /admin/dead-letters/{id}/retry [post]
+func (h *deadLetterHandler) RetryDeadLetter(c echo.Context) error {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.logger.LogServiceOperation("dead_letter_handler", "retry_dead_letter", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid dead letter ID")
+	}
+
+	result, err := h.deadLetterService.RetryDeadLetter(c.Request().Context(), id)
+	if err != nil {
+		h.logger.LogServiceOperation("dead_letter_handler", "retry_dead_letter", false, time.Since(start).Milliseconds())
+
+		switch {
+		case errors.Is(err, service.ErrDeadLetterNotFound):
+			return response.NotFound(c, response.CodeDeadLetterNotFound, "Dead letter not found")
+		case errors.Is(err, service.ErrArticleParse), errors.Is(err, service.ErrSourceBlocked):
+			return response.Error(c, http.StatusUnprocessableEntity, response.CodeDeadLetterUnstorable, "Article cannot be stored", err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to retry dead letter")
+	}
+
+	h.logger.LogServiceOperation("dead_letter_handler", "retry_dead_letter", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, result, "Dead letter stored")
+}
+
+// DiscardDeadLetter handles DELETE /api/v1/admin/dead-letters/:id
+// @Summary      Discard a dead letter
+// @Description  Drop a dead letter without storing its article
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int     true  "Dead letter ID"
+// @Success      204  {string}  string                                          "No Content"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid dead letter ID"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Dead letter not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/dead-letters/{id} [delete]
+func (h *deadLetterHandler) DiscardDeadLetter(c echo.Context) error {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.logger.LogServiceOperation("dead_letter_handler", "discard_dead_letter", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid dead letter ID")
+	}
+
+	if err := h.deadLetterService.DiscardDeadLetter(c.Request().Context(), id); err != nil {
+		h.logger.LogServiceOperation("dead_letter_handler", "discard_dead_letter", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrDeadLetterNotFound) {
+			return response.NotFound(c, response.CodeDeadLetterNotFound, "Dead letter not found")
+		}
+
+		return response.InternalServerError(c, "Failed to discard dead letter")
+	}
+
+	h.logger.LogServiceOperation("dead_letter_handler", "discard_dead_letter", true, time.Since(start).Milliseconds())
+
+	return c.NoContent(http.StatusNoContent)
+}
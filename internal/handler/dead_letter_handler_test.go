@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockDeadLetterService is a mock implementation of DeadLetterService
+type MockDeadLetterService struct {
+	mock.Mock
+}
+
+func (m *MockDeadLetterService) Record(ctx context.Context, article *model.NewsAPIArticleParams, cause error) {
+	m.Called(ctx, article, cause)
+}
+
+func (m *MockDeadLetterService) ListDeadLetters(ctx context.Context, req *model.DeadLetterListParams) (*model.DeadLetterListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DeadLetterListResponse), args.Error(1)
+}
+
+func (m *MockDeadLetterService) RetryDeadLetter(ctx context.Context, id int64) (*model.DeadLetterRetryResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DeadLetterRetryResponse), args.Error(1)
+}
+
+func (m *MockDeadLetterService) DiscardDeadLetter(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// DeadLetterHandlerTestSuite defines the test suite for DeadLetterHandler
+type DeadLetterHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockDeadLetterService
+	handler     DeadLetterHandler
+	echo        *echo.Echo
+}
+
+func (suite *DeadLetterHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockDeadLetterService)
+	suite.handler = NewDeadLetterHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *DeadLetterHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *DeadLetterHandlerTestSuite) createEchoContext(method, target, id string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	if id != "" {
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+	}
+	return c, rec
+}
+
+func (suite *DeadLetterHandlerTestSuite) TestListDeadLettersSuccess() {
+	result := &model.DeadLetterListResponse{
+		DeadLetters: []model.DeadLetter{
+			{ID: 1, URL: "https://example.com/ai", ErrorType: model.AggregationErrorDB, Error: "connection refused", Attempts: 2},
+		},
+		Pagination: model.CalculatePagination(2, 10, 11),
+	}
+
+	suite.mockService.On("ListDeadLetters", mock.Anything, &model.DeadLetterListParams{Page: 2, Limit: 10}).Return(result, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/api/v1/admin/dead-letters?page=2&limit=10", "")
+
+	err := suite.handler.ListDeadLetters(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"error_type":"db_error"`)
+	assert.Contains(suite.T(), rec.Body.String(), `"attempts":2`)
+}
+
+func (suite *DeadLetterHandlerTestSuite) TestListDeadLettersServiceError() {
+	suite.mockService.On("ListDeadLetters", mock.Anything, &model.DeadLetterListParams{Page: 1, Limit: 20}).
+		Return(nil, errors.New("database error"))
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/api/v1/admin/dead-letters", "")
+
+	err := suite.handler.ListDeadLetters(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+}
+
+func (suite *DeadLetterHandlerTestSuite) TestRetryDeadLetterSuccess() {
+	result := &model.DeadLetterRetryResponse{Post: &model.Post{ID: 42, URL: "https://example.com/ai"}}
+	suite.mockService.On("RetryDeadLetter", mock.Anything, int64(1)).Return(result, nil)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/api/v1/admin/dead-letters/1/retry", "1")
+
+	err := suite.handler.RetryDeadLetter(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"duplicate":false`)
+}
+
+func (suite *DeadLetterHandlerTestSuite) TestRetryDeadLetterUnstorable() {
+	suite.mockService.On("RetryDeadLetter", mock.Anything, int64(1)).Return(nil, service.ErrArticleParse)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/api/v1/admin/dead-letters/1/retry", "1")
+
+	err := suite.handler.RetryDeadLetter(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "DEAD_LETTER_UNSTORABLE")
+}
+
+func (suite *DeadLetterHandlerTestSuite) TestRetryDeadLetterInvalidID() {
+	c, rec := suite.createEchoContext(http.MethodPost, "/api/v1/admin/dead-letters/abc/retry", "abc")
+
+	err := suite.handler.RetryDeadLetter(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *DeadLetterHandlerTestSuite) TestDiscardDeadLetter() {
+	suite.mockService.On("DiscardDeadLetter", mock.Anything, int64(1)).Return(nil)
+
+	c, rec := suite.createEchoContext(http.MethodDelete, "/api/v1/admin/dead-letters/1", "1")
+
+	err := suite.handler.DiscardDeadLetter(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNoContent, rec.Code)
+}
+
+func (suite *DeadLetterHandlerTestSuite) TestDiscardDeadLetterNotFound() {
+	suite.mockService.On("DiscardDeadLetter", mock.Anything, int64(9)).Return(service.ErrDeadLetterNotFound)
+
+	c, rec := suite.createEchoContext(http.MethodDelete, "/api/v1/admin/dead-letters/9", "9")
+
+	err := suite.handler.DiscardDeadLetter(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "DEAD_LETTER_NOT_FOUND")
+}
+
+func TestDeadLetterHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterHandlerTestSuite))
+}
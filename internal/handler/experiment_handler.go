@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// experimentHandler implements ExperimentHandler interface
+type experimentHandler struct {
+	experimentService service.ExperimentService
+	logger            *logger.Logger
+}
+
+// NewExperimentHandler creates a new experiment handler
+func NewExperimentHandler(experimentService service.ExperimentService, logger *logger.Logger) ExperimentHandler {
+	return &experimentHandler{
+		experimentService: experimentService,
+		logger:            logger,
+	}
+}
+
+// ListExperiments handles GET /api/v1/admin/experiments
+// @Summary      List experiments
+// @Description  List all feed ranking experiments
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=[]model.Experiment}     "Experiments"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/experiments [get]
+func (h *experimentHandler) ListExperiments(c echo.Context) error {
+	start := time.Now()
+
+	experiments, err := h.experimentService.ListExperiments(c.Request().Context())
+	if err != nil {
+		h.logger.LogServiceOperation("experiment_handler", "list_experiments", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to list experiments")
+	}
+
+	h.logger.LogServiceOperation("experiment_handler", "list_experiments", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, experiments)
+}
+
+// UpsertExperiment handles PUT /api/v1/admin/experiments/:name
+// @Summary      Create or replace an experiment
+// @Description  Define a feed ranking experiment. Variant allocations are percentages and must sum to 100.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        name        path      string                        true  "Experiment name"
+// @Param        experiment  body      model.UpsertExperimentParams  true  "Experiment definition"
+// @Success      200         {object}  response.APIResponse{data=model.Experiment}       "Experiment saved"
+// @Failure      400         {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid experiment"
+// @Failure      500         {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/experiments/{name} [put]
+func (h *experimentHandler) UpsertExperiment(c echo.Context) error {
+	start := time.Now()
+
+	var req model.UpsertExperimentParams
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("experiment_handler", "upsert_experiment", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("experiment_handler", "upsert_experiment", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	experiment, err := h.experimentService.UpsertExperiment(c.Request().Context(), c.Param("name"), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("experiment_handler", "upsert_experiment", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrInvalidExperiment) {
+			return response.BadRequest(c, response.CodeInvalidExperiment, "Invalid experiment", err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to save experiment")
+	}
+
+	h.logger.LogServiceOperation("experiment_handler", "upsert_experiment", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, experiment, "Experiment saved successfully")
+}
+
+// DeleteExperiment handles DELETE /api/v1/admin/experiments/:name
+// @Summary      Delete an experiment
+// @Description  Remove an experiment definition. Recorded events are kept for analysis.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        name  path      string  true  "Experiment name"
+// @Success      200   {object}  response.APIResponse                            "Experiment deleted"
+// @Failure      404   {object}  response.APIResponse{error=response.ErrorInfo}  "Experiment not found"
+// @Failure      500   {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/experiments/{name} [delete]
+func (h *experimentHandler) DeleteExperiment(c echo.Context) error {
+	start := time.Now()
+
+	if err := h.experimentService.DeleteExperiment(c.Request().Context(), c.Param("name")); err != nil {
+		h.logger.LogServiceOperation("experiment_handler", "delete_experiment", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrExperimentNotFound) {
+			return response.NotFound(c, response.CodeExperimentNotFound, "Experiment not found")
+		}
+
+		return response.InternalServerError(c, "Failed to delete experiment")
+	}
+
+	h.logger.LogServiceOperation("experiment_handler", "delete_experiment", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, nil, "Experiment deleted successfully")
+}
+
+// GetExperimentResults handles GET /api/v1/admin/experiments/:name/results
+// @Summary      Get experiment results
+// @Description  Exposure, click and click-through totals per variant
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        name  path      string  true  "Experiment name"
+// @Success      200   {object}  response.APIResponse{data=model.ExperimentResults}  "Experiment results"
+// @Failure      404   {object}  response.APIResponse{error=response.ErrorInfo}      "Experiment not found"
+// @Failure      500   {object}  response.APIResponse{error=response.ErrorInfo}      "Internal server error"
+// @Router       /admin/experiments/{name}/results [get]
+func (h *experimentHandler) GetExperimentResults(c echo.Context) error {
+	start := time.Now()
+
+	results, err := h.experimentService.GetResults(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		h.logger.LogServiceOperation("experiment_handler", "get_results", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrExperimentNotFound) {
+			return response.NotFound(c, response.CodeExperimentNotFound, "Experiment not found")
+		}
+
+		return response.InternalServerError(c, "Failed to retrieve experiment results")
+	}
+
+	h.logger.LogServiceOperation("experiment_handler", "get_results", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, results)
+}
+
+// RecordEvent handles POST /api/v1/experiments/events
+// @Summary      Record an experiment event
+// @Description  Record an exposure or click for the variant a user was assigned
+// @Tags         experiments
+// @Accept       json
+// @Produce      json
+// @Param        X-User-ID  header    string                 false  "User identifier"
+// @Param        event      body      model.ExperimentEvent  true   "Experiment event"
+// @Success      201        {object}  response.APIResponse                            "Event recorded"
+// @Failure      400        {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid event"
+// @Failure      404        {object}  response.APIResponse{error=response.ErrorInfo}  "Experiment or variant not found"
+// @Failure      500        {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /experiments/events [post]
+func (h *experimentHandler) RecordEvent(c echo.Context) error {
+	start := time.Now()
+
+	var req model.ExperimentEvent
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("experiment_handler", "record_event", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("experiment_handler", "record_event", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	req.UserID = c.Request().Header.Get("X-User-ID")
+
+	if err := h.experimentService.RecordEvent(c.Request().Context(), &req); err != nil {
+		h.logger.LogServiceOperation("experiment_handler", "record_event", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrExperimentNotFound) || errors.Is(err, service.ErrExperimentVariantUnknown) {
+			return response.NotFound(c, response.CodeVariantNotFound, "Experiment variant not found")
+		}
+
+		return response.InternalServerError(c, "Failed to record experiment event")
+	}
+
+	h.logger.LogServiceOperation("experiment_handler", "record_event", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusCreated, nil, "Event recorded successfully")
+}
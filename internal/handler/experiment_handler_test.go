@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockExperimentService is a mock implementation of ExperimentService
+type MockExperimentService struct {
+	mock.Mock
+}
+
+func (m *MockExperimentService) UpsertExperiment(ctx context.Context, name string, req *model.UpsertExperimentParams) (*model.Experiment, error) {
+	args := m.Called(ctx, name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Experiment), args.Error(1)
+}
+
+func (m *MockExperimentService) ListExperiments(ctx context.Context) ([]model.Experiment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Experiment), args.Error(1)
+}
+
+func (m *MockExperimentService) DeleteExperiment(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockExperimentService) AssignFeedVariant(ctx context.Context, userID string) (*model.ExperimentAssignment, *model.RankingWeights, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.ExperimentAssignment), args.Get(1).(*model.RankingWeights), args.Error(2)
+}
+
+func (m *MockExperimentService) RecordEvent(ctx context.Context, event *model.ExperimentEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockExperimentService) GetResults(ctx context.Context, name string) (*model.ExperimentResults, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ExperimentResults), args.Error(1)
+}
+
+// ExperimentHandlerTestSuite defines the test suite for ExperimentHandler
+type ExperimentHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockExperimentService
+	handler     ExperimentHandler
+	echo        *echo.Echo
+}
+
+func (suite *ExperimentHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockExperimentService)
+	suite.handler = NewExperimentHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *ExperimentHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *ExperimentHandlerTestSuite) createEchoContext(method, target string, body any) (echo.Context, *httptest.ResponseRecorder) {
+	var req *http.Request
+	if body != nil {
+		jsonBody, _ := json.Marshal(body)
+		req = httptest.NewRequest(method, target, bytes.NewBuffer(jsonBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	} else {
+		req = httptest.NewRequest(method, target, nil)
+	}
+
+	rec := httptest.NewRecorder()
+	return suite.echo.NewContext(req, rec), rec
+}
+
+func (suite *ExperimentHandlerTestSuite) TestUpsertExperimentInvalid() {
+	req := model.UpsertExperimentParams{
+		Enabled:  true,
+		Variants: []model.ExperimentVariant{{Name: "control", Allocation: 40, Weights: model.DefaultRankingWeights()}},
+	}
+
+	suite.mockService.On("UpsertExperiment", mock.Anything, "recency", mock.AnythingOfType("*model.UpsertExperimentParams")).Return(nil, service.ErrInvalidExperiment)
+
+	c, rec := suite.createEchoContext(http.MethodPut, "/admin/experiments/recency", req)
+	c.SetParamNames("name")
+	c.SetParamValues("recency")
+
+	err := suite.handler.UpsertExperiment(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *ExperimentHandlerTestSuite) TestRecordEventSuccess() {
+	event := model.ExperimentEvent{Experiment: "recency", Variant: "control", Type: model.ExperimentEventClick}
+
+	suite.mockService.On("RecordEvent", mock.Anything, mock.MatchedBy(func(e *model.ExperimentEvent) bool {
+		return e.UserID == "user-1" && e.Variant == "control"
+	})).Return(nil)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/experiments/events", event)
+	c.Request().Header.Set("X-User-ID", "user-1")
+
+	err := suite.handler.RecordEvent(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusCreated, rec.Code)
+}
+
+func (suite *ExperimentHandlerTestSuite) TestGetExperimentResultsNotFound() {
+	suite.mockService.On("GetResults", mock.Anything, "missing").Return(nil, service.ErrExperimentNotFound)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/admin/experiments/missing/results", nil)
+	c.SetParamNames("name")
+	c.SetParamValues("missing")
+
+	err := suite.handler.GetExperimentResults(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+}
+
+func TestExperimentHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ExperimentHandlerTestSuite))
+}
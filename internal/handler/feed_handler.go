@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/cachetrace"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// feedHandler implements FeedHandler interface
+type feedHandler struct {
+	feedRankingService service.FeedRankingService
+	logger             *logger.Logger
+}
+
+// NewFeedHandler creates a new feed handler
+func NewFeedHandler(feedRankingService service.FeedRankingService, logger *logger.Logger) FeedHandler {
+	return &feedHandler{
+		feedRankingService: feedRankingService,
+		logger:             logger,
+	}
+}
+
+// GetRankedFeed handles GET /api/v1/feed/ranked
+// @Summary      Get ranked feed
+// @Description  List recent posts ordered by ranking score (recency, views, source weight, category boost)
+// @Tags         feed
+// @Accept       json
+// @Produce      json
+// @Param        page      query     int     false  "Page number"
+// @Param        limit     query     int     false  "Results per page"
+// @Param        category  query     string  false  "Filter by category"
+// @Param        safe_mode query     bool    false  "Exclude posts flagged as sensitive"
+// @Param        user_id   query     string  false  "User identifier used for experiment bucketing (or X-User-ID header)"
+// @Success      200       {object}  response.APIResponse{data=response.PaginatedResponse{items=[]model.RankedPost,pagination=response.PaginationInfo}}	"Ranked posts"
+// @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
+// @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /feed/ranked [get]
+func (h *feedHandler) GetRankedFeed(c echo.Context) error {
+	start := time.Now()
+
+	req := model.RankedFeedParams{Page: 1, Limit: 20}
+
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if page, err := strconv.Atoi(pageParam); err == nil && page > 0 {
+			req.Page = page
+		}
+	}
+
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil && limit > 0 {
+			req.Limit = limit
+		}
+	}
+
+	filters := make(map[string]string)
+	if category := c.QueryParam("category"); category != "" {
+		req.Category = &category
+		filters["category"] = category
+	}
+
+	safeMode, err := parseSafeMode(c)
+	if err != nil {
+		h.logger.LogServiceOperation("feed_handler", "get_ranked_feed", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid safe_mode parameter")
+	}
+	if safeMode {
+		req.SafeMode = true
+		filters["safe_mode"] = "true"
+	}
+
+	req.UserID = c.Request().Header.Get("X-User-ID")
+	if req.UserID == "" {
+		req.UserID = c.QueryParam("user_id")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("feed_handler", "get_ranked_feed", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	ctx, trace := cachetrace.WithTrace(c.Request().Context())
+	feed, err := h.feedRankingService.GetRankedFeed(ctx, &req)
+	if err != nil {
+		h.logger.LogServiceOperation("feed_handler", "get_ranked_feed", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to retrieve ranked feed")
+	}
+
+	h.logger.LogServiceOperation("feed_handler", "get_ranked_feed", true, time.Since(start).Milliseconds())
+
+	paginationInfo := response.CreatePaginationInfo(req.Page, req.Limit, int(feed.Pagination.Total))
+	response.SetQueryInfo(c, &response.QueryInfo{
+		Filters:  filters,
+		Sort:     "ranked",
+		TookMs:   time.Since(start).Milliseconds(),
+		CacheHit: trace.Hit(),
+	})
+
+	if feed.Experiment != nil {
+		meta := map[string]any{"experiment": feed.Experiment}
+		return response.SuccessWithPaginationAndMeta(c, feed.Posts, paginationInfo, filters, meta)
+	}
+
+	return response.SuccessWithPagination(c, feed.Posts, paginationInfo, filters)
+}
+
+// GetRankingWeights handles GET /api/v1/admin/feed/ranking
+// @Summary      Get feed ranking weights
+// @Description  Retrieve the ranking weights currently applied to the ranked feed
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=model.RankingWeights}  "Active ranking weights"
+// @Router       /admin/feed/ranking [get]
+func (h *feedHandler) GetRankingWeights(c echo.Context) error {
+	return response.Success(c, http.StatusOK, h.feedRankingService.GetWeights())
+}
+
+// UpdateRankingWeights handles PUT /api/v1/admin/feed/ranking
+// @Summary      Update feed ranking weights
+// @Description  Replace the ranking weights applied to the ranked feed without restarting the server
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        weights  body      model.RankingWeights  true  "Ranking weights"
+// @Success      200      {object}  response.APIResponse{data=model.RankingWeights}  "Updated ranking weights"
+// @Failure      400      {object}  response.APIResponse{error=response.ErrorInfo}     "Invalid weights"
+// @Router       /admin/feed/ranking [put]
+func (h *feedHandler) UpdateRankingWeights(c echo.Context) error {
+	start := time.Now()
+
+	var req model.RankingWeights
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("feed_handler", "update_ranking_weights", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("feed_handler", "update_ranking_weights", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	if err := h.feedRankingService.UpdateWeights(req); err != nil {
+		h.logger.LogServiceOperation("feed_handler", "update_ranking_weights", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrInvalidRankingWeights) {
+			return response.BadRequest(c, response.CodeInvalidRankingWeights, "Invalid ranking weights", "weights must be non-negative and recency_half_life must be positive")
+		}
+
+		return response.InternalServerError(c, "Failed to update ranking weights")
+	}
+
+	h.logger.LogServiceOperation("feed_handler", "update_ranking_weights", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, h.feedRankingService.GetWeights(), "Ranking weights updated successfully")
+}
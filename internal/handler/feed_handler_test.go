@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockFeedRankingService is a mock implementation of FeedRankingService
+type MockFeedRankingService struct {
+	mock.Mock
+}
+
+func (m *MockFeedRankingService) GetRankedFeed(ctx context.Context, req *model.RankedFeedParams) (*model.RankedFeedResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RankedFeedResponse), args.Error(1)
+}
+
+func (m *MockFeedRankingService) GetWeights() model.RankingWeights {
+	args := m.Called()
+	return args.Get(0).(model.RankingWeights)
+}
+
+func (m *MockFeedRankingService) UpdateWeights(weights model.RankingWeights) error {
+	args := m.Called(weights)
+	return args.Error(0)
+}
+
+// FeedHandlerTestSuite defines the test suite for FeedHandler
+type FeedHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockFeedRankingService
+	handler     FeedHandler
+	echo        *echo.Echo
+}
+
+func (suite *FeedHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockFeedRankingService)
+	suite.handler = NewFeedHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *FeedHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *FeedHandlerTestSuite) createEchoContext(method, target string, body any) (echo.Context, *httptest.ResponseRecorder) {
+	var req *http.Request
+	if body != nil {
+		jsonBody, _ := json.Marshal(body)
+		req = httptest.NewRequest(method, target, bytes.NewBuffer(jsonBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	} else {
+		req = httptest.NewRequest(method, target, nil)
+	}
+
+	rec := httptest.NewRecorder()
+	return suite.echo.NewContext(req, rec), rec
+}
+
+func (suite *FeedHandlerTestSuite) TestGetRankedFeedSuccess() {
+	feed := &model.RankedFeedResponse{
+		Posts:      []model.RankedPost{{Post: model.Post{ID: 1}, Score: 0.9}},
+		Pagination: model.CalculatePagination(2, 5, 1),
+	}
+
+	suite.mockService.On("GetRankedFeed", mock.Anything, &model.RankedFeedParams{Page: 2, Limit: 5}).Return(feed, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/feed/ranked?page=2&limit=5", nil)
+
+	err := suite.handler.GetRankedFeed(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var resp response.APIResponse
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.True(suite.T(), resp.Success)
+}
+
+func (suite *FeedHandlerTestSuite) TestUpdateRankingWeightsInvalid() {
+	weights := model.DefaultRankingWeights()
+	weights.RecencyHalfLife = 0
+
+	suite.mockService.On("UpdateWeights", mock.Anything).Return(service.ErrInvalidRankingWeights)
+
+	c, rec := suite.createEchoContext(http.MethodPut, "/admin/feed/ranking", weights)
+
+	err := suite.handler.UpdateRankingWeights(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *FeedHandlerTestSuite) TestUpdateRankingWeightsSuccess() {
+	weights := model.DefaultRankingWeights()
+
+	suite.mockService.On("UpdateWeights", mock.Anything).Return(nil)
+	suite.mockService.On("GetWeights").Return(weights)
+
+	c, rec := suite.createEchoContext(http.MethodPut, "/admin/feed/ranking", weights)
+
+	err := suite.handler.UpdateRankingWeights(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func TestFeedHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(FeedHandlerTestSuite))
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// filterHandler implements FilterHandler interface
+type filterHandler struct {
+	filterService service.ArticleFilterService
+	logger        *logger.Logger
+}
+
+// NewFilterHandler creates a new article filter handler
+func NewFilterHandler(filterService service.ArticleFilterService, logger *logger.Logger) FilterHandler {
+	return &filterHandler{
+		filterService: filterService,
+		logger:        logger,
+	}
+}
+
+// ListQuarantined handles GET /api/v1/admin/quarantine
+// @Summary      List quarantined articles
+// @Description  List articles rejected by the spam and quality filter, newest first
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int  false  "Page number"
+// @Param        limit  query     int  false  "Results per page"
+// @Success      200    {object}  response.APIResponse{data=model.QuarantineListResponse}  "Quarantined articles"
+// @Failure      400    {object}  response.APIResponse{error=response.ErrorInfo}         "Validation error"
+// @Failure      500    {object}  response.APIResponse{error=response.ErrorInfo}         "Internal server error"
+// @Router       /admin/quarantine [get]
+func (h *filterHandler) ListQuarantined(c echo.Context) error {
+	start := time.Now()
+
+	req := model.QuarantineListParams{Page: 1, Limit: 20}
+
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if page, err := strconv.Atoi(pageParam); err == nil && page > 0 {
+			req.Page = page
+		}
+	}
+
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil && limit > 0 {
+			req.Limit = limit
+		}
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("filter_handler", "list_quarantined", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	result, err := h.filterService.ListQuarantined(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("filter_handler", "list_quarantined", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to list quarantined articles")
+	}
+
+	h.logger.LogServiceOperation("filter_handler", "list_quarantined", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, result)
+}
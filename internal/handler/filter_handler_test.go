@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockArticleFilterService is a mock implementation of ArticleFilterService
+type MockArticleFilterService struct {
+	mock.Mock
+}
+
+func (m *MockArticleFilterService) Check(ctx context.Context, article *model.NewsAPIArticleParams) *model.FilterRejection {
+	args := m.Called(ctx, article)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*model.FilterRejection)
+}
+
+func (m *MockArticleFilterService) ListQuarantined(ctx context.Context, req *model.QuarantineListParams) (*model.QuarantineListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.QuarantineListResponse), args.Error(1)
+}
+
+// FilterHandlerTestSuite defines the test suite for FilterHandler
+type FilterHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockArticleFilterService
+	handler     FilterHandler
+	echo        *echo.Echo
+}
+
+func (suite *FilterHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockArticleFilterService)
+	suite.handler = NewFilterHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *FilterHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *FilterHandlerTestSuite) TestListQuarantinedSuccess() {
+	result := &model.QuarantineListResponse{
+		Articles: []model.QuarantinedArticle{
+			{ID: 1, URL: "https://spam.example.com/article", Rule: model.FilterRuleBlockedDomain},
+		},
+		Pagination: model.CalculatePagination(2, 10, 11),
+	}
+
+	suite.mockService.On("ListQuarantined", mock.Anything, &model.QuarantineListParams{Page: 2, Limit: 10}).Return(result, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/quarantine?page=2&limit=10", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListQuarantined(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "blocked_domain")
+}
+
+func (suite *FilterHandlerTestSuite) TestListQuarantinedServiceError() {
+	suite.mockService.On("ListQuarantined", mock.Anything, &model.QuarantineListParams{Page: 1, Limit: 20}).
+		Return(nil, errors.New("database error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/quarantine", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListQuarantined(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+}
+
+func TestFilterHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(FilterHandlerTestSuite))
+}
@@ -10,14 +10,27 @@ import (
 type PostHandler interface {
 	CreatePost(c echo.Context) error
 	GetPostByID(c echo.Context) error
+	LookupPost(c echo.Context) error
 	ListPosts(c echo.Context) error
+	DiscoverPosts(c echo.Context) error
 	UpdatePost(c echo.Context) error
 	DeletePost(c echo.Context) error
+	PublishPost(c echo.Context) error
+	HidePost(c echo.Context) error
+	AdminListPosts(c echo.Context) error
+	BulkUpdatePosts(c echo.Context) error
+	BulkDeletePosts(c echo.Context) error
 	GetPostsByCategory(c echo.Context) error
 	GetPostsBySource(c echo.Context) error
 	SearchPosts(c echo.Context) error
 }
 
+// PinHandler defines the contract for editorial post pinning HTTP handlers
+type PinHandler interface {
+	PinPost(c echo.Context) error
+	UnpinPost(c echo.Context) error
+}
+
 // AggregatorHandler defines the contract for aggregator HTTP handlers
 type AggregatorHandler interface {
 	TriggerTopHeadlines(c echo.Context) error
@@ -26,25 +39,180 @@ type AggregatorHandler interface {
 	TriggerAggregation(c echo.Context) error
 }
 
+// NewsHandler defines the contract for upstream news provider HTTP handlers
+type NewsHandler interface {
+	ListSources(c echo.Context) error
+}
+
 // SchedulerHandler defines the contract for scheduler HTTP handlers
 type SchedulerHandler interface {
 	GetStatus(c echo.Context) error
 	GetJobs(c echo.Context) error
 	TriggerJob(c echo.Context) error
+	GetJobHistory(c echo.Context) error
+	PauseScheduler(c echo.Context) error
+	ResumeScheduler(c echo.Context) error
+	EnableJob(c echo.Context) error
+	DisableJob(c echo.Context) error
+	GetOnceJobs(c echo.Context) error
+	CancelOnceJob(c echo.Context) error
+	GetDeadJobs(c echo.Context) error
+	RetryDeadJob(c echo.Context) error
+	DeleteDeadJob(c echo.Context) error
+}
+
+// FeedHandler defines the contract for feed HTTP handlers
+type FeedHandler interface {
+	GetRankedFeed(c echo.Context) error
+	GetRankingWeights(c echo.Context) error
+	UpdateRankingWeights(c echo.Context) error
+}
+
+// ExperimentHandler defines the contract for experiment HTTP handlers
+type ExperimentHandler interface {
+	ListExperiments(c echo.Context) error
+	UpsertExperiment(c echo.Context) error
+	DeleteExperiment(c echo.Context) error
+	GetExperimentResults(c echo.Context) error
+	RecordEvent(c echo.Context) error
+}
+
+// AnalyticsHandler defines the contract for analytics HTTP handlers
+type AnalyticsHandler interface {
+	RecordClick(c echo.Context) error
+	RedirectToPost(c echo.Context) error
+	GetCTRStats(c echo.Context) error
+	GetSearchAnalytics(c echo.Context) error
+	GetPostStats(c echo.Context) error
+}
+
+// FilterHandler defines the contract for article filter HTTP handlers
+type FilterHandler interface {
+	ListQuarantined(c echo.Context) error
+}
+
+// DeadLetterHandler defines the contract for dead letter HTTP handlers
+type DeadLetterHandler interface {
+	ListDeadLetters(c echo.Context) error
+	RetryDeadLetter(c echo.Context) error
+	DiscardDeadLetter(c echo.Context) error
+}
+
+// ContentHandler defines the contract for content enrichment HTTP handlers
+type ContentHandler interface {
+	ReprocessPosts(c echo.Context) error
+	GetReprocessRun(c echo.Context) error
+	PreviewArticle(c echo.Context) error
+}
+
+// CommentHandler defines the contract for post comment HTTP handlers
+type CommentHandler interface {
+	CreateComment(c echo.Context) error
+	ListComments(c echo.Context) error
+	DeleteComment(c echo.Context) error
+}
+
+// ReactionHandler defines the contract for post reaction HTTP handlers
+type ReactionHandler interface {
+	React(c echo.Context) error
+	RemoveReaction(c echo.Context) error
+}
+
+// SyndicationHandler defines the contract for RSS/Atom feed and sitemap HTTP handlers
+type SyndicationHandler interface {
+	GetFeed(c echo.Context) error
+	GetCategoryFeed(c echo.Context) error
+	GetSitemap(c echo.Context) error
+}
+
+// TopicHandler defines the contract for story topic HTTP handlers
+type TopicHandler interface {
+	ListTopics(c echo.Context) error
+}
+
+// StatsHandler defines the contract for materialized statistics HTTP handlers
+type StatsHandler interface {
+	GetTopStats(c echo.Context) error
+}
+
+// TenantHandler defines the contract for tenant administration HTTP handlers
+type TenantHandler interface {
+	ListTenants(c echo.Context) error
+	UpsertTenant(c echo.Context) error
+}
+
+// SourceRuleHandler defines the contract for source blocklist and allowlist HTTP handlers
+type SourceRuleHandler interface {
+	ListSourceRules(c echo.Context) error
+	SaveSourceRule(c echo.Context) error
+	DeleteSourceRule(c echo.Context) error
+}
+
+// ChangeHandler defines the contract for post change feed HTTP handlers
+type ChangeHandler interface {
+	ListPostChanges(c echo.Context) error
+}
+
+// ConfigHandler defines the contract for runtime configuration HTTP handlers
+type ConfigHandler interface {
+	ReloadConfig(c echo.Context) error
+}
+
+// ReadOnlyHandler defines the contract for read-only mode HTTP handlers
+type ReadOnlyHandler interface {
+	GetReadOnly(c echo.Context) error
+	EnableReadOnly(c echo.Context) error
+	DisableReadOnly(c echo.Context) error
 }
 
 // Handler holds all handler implementations
 type Handler struct {
-	Post       PostHandler
-	Aggregator AggregatorHandler
-	Scheduler  SchedulerHandler
+	Post        PostHandler
+	Pin         PinHandler
+	Aggregator  AggregatorHandler
+	News        NewsHandler
+	Scheduler   SchedulerHandler
+	Feed        FeedHandler
+	Experiment  ExperimentHandler
+	Analytics   AnalyticsHandler
+	Filter      FilterHandler
+	DeadLetter  DeadLetterHandler
+	Content     ContentHandler
+	Comment     CommentHandler
+	Reaction    ReactionHandler
+	Syndication SyndicationHandler
+	Topic       TopicHandler
+	Stats       StatsHandler
+	Tenant      TenantHandler
+	SourceRule  SourceRuleHandler
+	Change      ChangeHandler
+	Config      ConfigHandler
+	ReadOnly    ReadOnlyHandler
 }
 
 // New creates a new handler instance with all entity handlers
 func New(svc *service.Service, logger *logger.Logger) *Handler {
 	return &Handler{
-		Post:       NewPostHandler(svc.Post, logger),
-		Aggregator: NewAggregatorHandler(svc.Aggregator, logger),
-		Scheduler:  NewSchedulerHandler(svc.Scheduler, logger),
+		Post:        NewPostHandler(svc.Post, svc.Analytics, logger),
+		Pin:         NewPinHandler(svc.Pin, logger),
+		Aggregator:  NewAggregatorHandler(svc.Aggregator, logger),
+		News:        NewNewsHandler(svc.News, logger),
+		Scheduler:   NewSchedulerHandler(svc.Scheduler, logger),
+		Feed:        NewFeedHandler(svc.FeedRanking, logger),
+		Experiment:  NewExperimentHandler(svc.Experiment, logger),
+		Analytics:   NewAnalyticsHandler(svc.Analytics, logger),
+		Filter:      NewFilterHandler(svc.Filter, logger),
+		DeadLetter:  NewDeadLetterHandler(svc.DeadLetter, logger),
+		Content:     NewContentHandler(svc.Content, logger),
+		Comment:     NewCommentHandler(svc.Comment, logger),
+		Reaction:    NewReactionHandler(svc.Reaction, logger),
+		Syndication: NewSyndicationHandler(svc.Syndication, logger),
+		Topic:       NewTopicHandler(svc.Topic, logger),
+		Stats:       NewStatsHandler(svc.Stats, logger),
+		Tenant:      NewTenantHandler(svc.Tenant, logger),
+		SourceRule:  NewSourceRuleHandler(svc.SourceRule, logger),
+		Change:      NewChangeHandler(svc.Change, logger),
+		Config:      NewConfigHandler(svc.Config, logger),
+		ReadOnly:    NewReadOnlyHandler(svc.ReadOnly, logger),
 	}
 }
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/labstack/echo/v4"
+)
+
+// MetricsPath is where connection pool and query metrics are served
+const MetricsPath = "/metrics"
+
+// metricsContentType is the Prometheus text exposition format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// metricLabelEscaper escapes label values for the text exposition format
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metric is one sample of a metric family
+type metric struct {
+	label string
+	value float64
+}
+
+// Metrics serves the connection pool statistics of every pool and the
+// query totals of every query label in the Prometheus text format. Queries
+// are listed most time consuming first.
+func Metrics(pools func() []database.PoolStats, queries func() []database.QueryStats) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var buf bytes.Buffer
+		writePoolMetrics(&buf, pools())
+		writeQueryMetrics(&buf, queries())
+
+		return c.Blob(http.StatusOK, metricsContentType, buf.Bytes())
+	}
+}
+
+// writePoolMetrics writes one sample per pool of each pool metric
+func writePoolMetrics(buf *bytes.Buffer, pools []database.PoolStats) {
+	sample := func(value func(p database.PoolStats) float64) []metric {
+		samples := make([]metric, len(pools))
+		for i, p := range pools {
+			samples[i] = metric{label: p.Name, value: value(p)}
+		}
+		return samples
+	}
+
+	writeMetric(buf, "db_pool_acquired_connections", "gauge", "Connections currently checked out of the pool.", "pool",
+		sample(func(p database.PoolStats) float64 { return float64(p.Acquired) }))
+	writeMetric(buf, "db_pool_idle_connections", "gauge", "Idle connections in the pool.", "pool",
+		sample(func(p database.PoolStats) float64 { return float64(p.Idle) }))
+	writeMetric(buf, "db_pool_total_connections", "gauge", "Open connections in the pool.", "pool",
+		sample(func(p database.PoolStats) float64 { return float64(p.Total) }))
+	writeMetric(buf, "db_pool_max_connections", "gauge", "Maximum size of the pool.", "pool",
+		sample(func(p database.PoolStats) float64 { return float64(p.Max) }))
+	writeMetric(buf, "db_pool_acquires_total", "counter", "Connections acquired from the pool.", "pool",
+		sample(func(p database.PoolStats) float64 { return float64(p.AcquireCount) }))
+	writeMetric(buf, "db_pool_empty_acquires_total", "counter", "Acquires that waited because the pool was exhausted.", "pool",
+		sample(func(p database.PoolStats) float64 { return float64(p.EmptyAcquireCount) }))
+	writeMetric(buf, "db_pool_canceled_acquires_total", "counter", "Acquires canceled while waiting for a connection.", "pool",
+		sample(func(p database.PoolStats) float64 { return float64(p.CanceledAcquireCount) }))
+	writeMetric(buf, "db_pool_acquire_seconds_total", "counter", "Time spent acquiring connections.", "pool",
+		sample(func(p database.PoolStats) float64 { return p.AcquireDuration.Seconds() }))
+	writeMetric(buf, "db_pool_empty_acquire_wait_seconds_total", "counter", "Time spent waiting on an exhausted pool.", "pool",
+		sample(func(p database.PoolStats) float64 { return p.EmptyAcquireWait.Seconds() }))
+}
+
+// writeQueryMetrics writes one sample per query label of each query metric
+func writeQueryMetrics(buf *bytes.Buffer, queries []database.QueryStats) {
+	sample := func(value func(q database.QueryStats) float64) []metric {
+		samples := make([]metric, len(queries))
+		for i, q := range queries {
+			samples[i] = metric{label: q.Label, value: value(q)}
+		}
+		return samples
+	}
+
+	writeMetric(buf, "db_query_duration_seconds_total", "counter", "Time spent running queries.", "query",
+		sample(func(q database.QueryStats) float64 { return q.Duration.Seconds() }))
+	writeMetric(buf, "db_query_duration_seconds_max", "gauge", "Longest run of a query.", "query",
+		sample(func(q database.QueryStats) float64 { return q.Max.Seconds() }))
+	writeMetric(buf, "db_queries_total", "counter", "Queries run.", "query",
+		sample(func(q database.QueryStats) float64 { return float64(q.Calls) }))
+	writeMetric(buf, "db_query_errors_total", "counter", "Queries that failed.", "query",
+		sample(func(q database.QueryStats) float64 { return float64(q.Errors) }))
+	writeMetric(buf, "db_slow_queries_total", "counter", "Queries at or above the slow query threshold.", "query",
+		sample(func(q database.QueryStats) float64 { return float64(q.Slow) }))
+}
+
+// writeMetric writes a metric family with its help and type lines
+func writeMetric(buf *bytes.Buffer, name, kind, help, labelName string, samples []metric) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	for _, s := range samples {
+		fmt.Fprintf(buf, "%s{%s=\"%s\"} %g\n", name, labelName, metricLabelEscaper.Replace(s.label), s.value)
+	}
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsServesPoolAndQueryStats(t *testing.T) {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+	tracer := database.NewQueryTracer(time.Nanosecond, logger.New(cfg))
+
+	database.NameQueries(map[string]string{"list_things": "SELECT * FROM things"})
+	for _, err := range []error{nil, errors.New("boom")} {
+		ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT * FROM things"})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: err})
+	}
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "UPDATE widgets SET name = $1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	pools := func() []database.PoolStats {
+		return []database.PoolStats{
+			{Name: "primary", Acquired: 3, Idle: 2, Total: 5, Max: 25, AcquireCount: 40, EmptyAcquireWait: 1500 * time.Millisecond},
+			{Name: "replica_0", Total: 1, Max: 25},
+		}
+	}
+
+	e := echo.New()
+	e.GET(MetricsPath, Metrics(pools, tracer.Stats))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, MetricsPath, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, metricsContentType, rec.Header().Get(echo.HeaderContentType))
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	text := string(body)
+
+	assert.Contains(t, text, "# TYPE db_pool_acquired_connections gauge\n")
+	assert.Contains(t, text, `db_pool_acquired_connections{pool="primary"} 3`)
+	assert.Contains(t, text, `db_pool_total_connections{pool="replica_0"} 1`)
+	assert.Contains(t, text, `db_pool_acquires_total{pool="primary"} 40`)
+	assert.Contains(t, text, `db_pool_empty_acquire_wait_seconds_total{pool="primary"} 1.5`)
+	assert.Contains(t, text, `db_queries_total{query="list_things"} 2`)
+	assert.Contains(t, text, `db_query_errors_total{query="list_things"} 1`)
+	assert.Contains(t, text, `db_slow_queries_total{query="list_things"} 2`)
+	assert.Contains(t, text, `db_queries_total{query="update widgets"} 1`)
+}
+
+func TestMetricsEscapesLabelValues(t *testing.T) {
+	var buf bytes.Buffer
+	writeMetric(&buf, "m", "gauge", "Help.", "query", []metric{{label: "a\"b\\c\nd", value: 1}})
+
+	assert.Contains(t, buf.String(), `m{query="a\"b\\c\nd"} 1`)
+}
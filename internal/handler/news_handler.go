@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/newsapi"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// newsHandler implements NewsHandler interface
+type newsHandler struct {
+	newsService service.NewsService
+	logger      *logger.Logger
+}
+
+// NewNewsHandler creates a new news handler
+func NewNewsHandler(newsService service.NewsService, logger *logger.Logger) NewsHandler {
+	return &newsHandler{
+		newsService: newsService,
+		logger:      logger,
+	}
+}
+
+// ListSources handles GET /api/v1/news/sources
+// @Summary      List upstream sources
+// @Description  List the sources NewsAPI offers, optionally filtered by category, language and country, to pick the source IDs to aggregate. Listings are cached for NEWS_API_SOURCES_CACHE_TTL; a listing fetched from NewsAPI counts as one request against the tenant's daily quota.
+// @Tags         news
+// @Accept       json
+// @Produce      json
+// @Param        category  query     string  false  "Category"  Enums(general, business, entertainment, health, science, sports, technology)
+// @Param        language  query     string  false  "Two-letter ISO 639-1 language code"
+// @Param        country   query     string  false  "Two-letter ISO 3166-1 country code"
+// @Success      200       {object}  response.APIResponse{data=model.NewsSourcesResponse}  "Matching sources"
+// @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}       "Invalid filter"
+// @Failure      429       {object}  response.APIResponse{error=response.ErrorInfo}       "NewsAPI rate limit or tenant quota reached"
+// @Failure      502       {object}  response.APIResponse{error=response.ErrorInfo}       "NewsAPI request failed"
+// @Router       /news/sources [get]
+func (h *newsHandler) ListSources(c echo.Context) error {
+	start := time.Now()
+
+	req := model.NewsSourcesParams{
+		Category: strings.ToLower(strings.TrimSpace(c.QueryParam("category"))),
+		Language: strings.ToLower(strings.TrimSpace(c.QueryParam("language"))),
+		Country:  strings.ToLower(strings.TrimSpace(c.QueryParam("country"))),
+	}
+
+	if req.Category != "" && !slices.Contains(service.GetDefaultCategories(), req.Category) {
+		h.logger.LogServiceOperation("news_handler", "list_sources", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid category", "Available categories: "+strings.Join(service.GetDefaultCategories(), ", "))
+	}
+
+	if req.Language != "" && len(req.Language) != 2 {
+		h.logger.LogServiceOperation("news_handler", "list_sources", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid language code", "Language must be a two-letter ISO 639-1 code")
+	}
+
+	if req.Country != "" && len(req.Country) != 2 {
+		h.logger.LogServiceOperation("news_handler", "list_sources", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid country code", "Country must be a two-letter ISO 3166-1 code")
+	}
+
+	result, err := h.newsService.GetSources(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("news_handler", "list_sources", false, time.Since(start).Milliseconds())
+
+		var rateLimited *newsapi.ErrRateLimited
+		if errors.As(err, &rateLimited) || errors.Is(err, service.ErrTenantQuotaExceeded) {
+			return response.Error(c, http.StatusTooManyRequests, response.CodeRateLimited, "NewsAPI request limit reached", err.Error())
+		}
+		return response.Error(c, http.StatusBadGateway, response.CodeNewsProviderFailed, "Failed to list NewsAPI sources", err.Error())
+	}
+
+	h.logger.LogServiceOperation("news_handler", "list_sources", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, result)
+}
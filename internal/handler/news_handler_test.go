@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/newsapi"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockNewsService is a mock implementation of NewsService
+type MockNewsService struct {
+	mock.Mock
+}
+
+func (m *MockNewsService) GetTopHeadlines(ctx context.Context, req *model.NewsParams) (*model.NewsAPIResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NewsAPIResponse), args.Error(1)
+}
+
+func (m *MockNewsService) GetEverything(ctx context.Context, req *model.NewsParams) (*model.NewsAPIResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NewsAPIResponse), args.Error(1)
+}
+
+func (m *MockNewsService) GetNewsByCategory(ctx context.Context, category, country string, pageSize int) (*model.NewsAPIResponse, error) {
+	args := m.Called(ctx, category, country, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NewsAPIResponse), args.Error(1)
+}
+
+func (m *MockNewsService) GetNewsBySources(ctx context.Context, sources []string, pageSize int) (*model.NewsAPIResponse, error) {
+	args := m.Called(ctx, sources, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NewsAPIResponse), args.Error(1)
+}
+
+func (m *MockNewsService) GetSources(ctx context.Context, req *model.NewsSourcesParams) (*model.NewsSourcesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NewsSourcesResponse), args.Error(1)
+}
+
+func (m *MockNewsService) SetAPIKey(apiKey string) {
+	m.Called(apiKey)
+}
+
+// NewsHandlerTestSuite defines the test suite for NewsHandler
+type NewsHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockNewsService
+	handler     NewsHandler
+	echo        *echo.Echo
+}
+
+func (suite *NewsHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockNewsService)
+	suite.handler = NewNewsHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *NewsHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *NewsHandlerTestSuite) createEchoContext(target string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	return suite.echo.NewContext(req, rec), rec
+}
+
+func (suite *NewsHandlerTestSuite) TestListSourcesSuccess() {
+	params := &model.NewsSourcesParams{Category: "technology", Language: "en", Country: "us"}
+	result := &model.NewsSourcesResponse{
+		Sources: []model.NewsAPISource{{ID: "techcrunch", Name: "TechCrunch", Category: "technology", Language: "en", Country: "us"}},
+		Count:   1,
+		Filters: *params,
+		Cached:  true,
+	}
+
+	suite.mockService.On("GetSources", mock.Anything, params).Return(result, nil)
+
+	c, rec := suite.createEchoContext("/api/v1/news/sources?category=Technology&language=EN&country=us")
+
+	err := suite.handler.ListSources(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"id":"techcrunch"`)
+	assert.Contains(suite.T(), rec.Body.String(), `"cached":true`)
+}
+
+func (suite *NewsHandlerTestSuite) TestListSourcesWithoutFilters() {
+	suite.mockService.On("GetSources", mock.Anything, &model.NewsSourcesParams{}).
+		Return(&model.NewsSourcesResponse{Sources: []model.NewsAPISource{}}, nil)
+
+	c, rec := suite.createEchoContext("/api/v1/news/sources")
+
+	err := suite.handler.ListSources(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *NewsHandlerTestSuite) TestListSourcesInvalidFilters() {
+	for _, query := range []string{"category=weather", "language=english", "country=usa"} {
+		c, rec := suite.createEchoContext("/api/v1/news/sources?" + query)
+
+		err := suite.handler.ListSources(c)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), http.StatusBadRequest, rec.Code, query)
+		assert.Contains(suite.T(), rec.Body.String(), `"code":"INVALID_PARAMETER"`, query)
+	}
+}
+
+func (suite *NewsHandlerTestSuite) TestListSourcesRateLimited() {
+	for _, cause := range []error{&newsapi.ErrRateLimited{}, service.ErrTenantQuotaExceeded} {
+		suite.mockService.On("GetSources", mock.Anything, &model.NewsSourcesParams{}).
+			Return(nil, fmt.Errorf("failed to get sources: %w", cause)).Once()
+
+		c, rec := suite.createEchoContext("/api/v1/news/sources")
+
+		err := suite.handler.ListSources(c)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), http.StatusTooManyRequests, rec.Code)
+		assert.Contains(suite.T(), rec.Body.String(), `"code":"RATE_LIMITED"`)
+	}
+}
+
+func (suite *NewsHandlerTestSuite) TestListSourcesProviderError() {
+	suite.mockService.On("GetSources", mock.Anything, &model.NewsSourcesParams{}).
+		Return(nil, errors.New("failed to get sources: NewsAPI server error"))
+
+	c, rec := suite.createEchoContext("/api/v1/news/sources")
+
+	err := suite.handler.ListSources(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadGateway, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"NEWS_PROVIDER_FAILED"`)
+}
+
+func TestNewsHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(NewsHandlerTestSuite))
+}
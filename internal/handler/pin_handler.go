@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// pinHandler implements PinHandler interface
+type pinHandler struct {
+	pinService service.PinService
+	logger     *logger.Logger
+}
+
+// NewPinHandler creates a new pin handler
+func NewPinHandler(pinService service.PinService, logger *logger.Logger) PinHandler {
+	return &pinHandler{
+		pinService: pinService,
+		logger:     logger,
+	}
+}
+
+// PinPost handles POST /api/v1/posts/:id/pin
+// @Summary      Pin a post
+// @Description  Pin a published post to a position at the top of the first page of the post list and the ranked feed, whatever their sort order. Positions run from 1 to PINNED_POSTS_MAX; pinning to a taken position unpins the post there, and pinning a pinned post moves it. Without expires_at the pin lasts until the post is unpinned.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                  true  "Post ID"
+// @Param        pin  body      model.PinPostParams  true  "Pin position and expiry"
+// @Success      200  {object}  response.APIResponse{data=model.PostPin}          "Post pinned"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid ID, position or expiry"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Post not found"
+// @Failure      409  {object}  response.APIResponse{error=response.ErrorInfo}  "Post is not published"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/{id}/pin [post]
+func (h *pinHandler) PinPost(c echo.Context) error {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.logger.LogServiceOperation("pin_handler", "pin", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	var req model.PinPostParams
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("pin_handler", "pin", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("pin_handler", "pin", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	pin, err := h.pinService.PinPost(c.Request().Context(), id, &req)
+	if err != nil {
+		h.logger.LogServiceOperation("pin_handler", "pin", false, time.Since(start).Milliseconds())
+		return h.pinError(c, err, "Failed to pin post")
+	}
+
+	h.logger.LogServiceOperation("pin_handler", "pin", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, pin, "Post pinned successfully")
+}
+
+// UnpinPost handles DELETE /api/v1/posts/:id/pin
+// @Summary      Unpin a post
+// @Description  Remove a post's pin so it is listed in its regular place again
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int     true  "Post ID"
+// @Success      204  {string}  string                                          "No Content"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid post ID"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Post is not pinned"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/{id}/pin [delete]
+func (h *pinHandler) UnpinPost(c echo.Context) error {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.logger.LogServiceOperation("pin_handler", "unpin", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	if err := h.pinService.UnpinPost(c.Request().Context(), id); err != nil {
+		h.logger.LogServiceOperation("pin_handler", "unpin", false, time.Since(start).Milliseconds())
+		return h.pinError(c, err, "Failed to unpin post")
+	}
+
+	h.logger.LogServiceOperation("pin_handler", "unpin", true, time.Since(start).Milliseconds())
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// pinError maps pin service errors to responses
+func (h *pinHandler) pinError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrPinPositionInvalid), errors.Is(err, service.ErrPinExpiryInvalid):
+		return response.BadRequest(c, response.CodeInvalidPin, "Invalid pin", err.Error())
+	case errors.Is(err, service.ErrPostNotFound):
+		return response.NotFound(c, response.CodePostNotFound, "Post not found")
+	case errors.Is(err, service.ErrPinPostUnpublished):
+		return response.Conflict(c, response.CodePostNotPublished, "Only published posts can be pinned")
+	case errors.Is(err, service.ErrPinNotFound):
+		return response.NotFound(c, response.CodePostNotPinned, "Post is not pinned")
+	}
+
+	return response.InternalServerError(c, message)
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockPinService is a mock implementation of PinService
+type MockPinService struct {
+	mock.Mock
+}
+
+func (m *MockPinService) PinPost(ctx context.Context, id int64, req *model.PinPostParams) (*model.PostPin, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PostPin), args.Error(1)
+}
+
+func (m *MockPinService) UnpinPost(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPinService) PinnedPosts(ctx context.Context, safeMode bool) ([]model.Post, error) {
+	args := m.Called(ctx, safeMode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Post), args.Error(1)
+}
+
+// PinHandlerTestSuite defines the test suite for PinHandler
+type PinHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockPinService
+	handler     PinHandler
+	echo        *echo.Echo
+}
+
+func (suite *PinHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockPinService)
+	suite.handler = NewPinHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *PinHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *PinHandlerTestSuite) newContext(method, id, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/api/v1/posts/"+id+"/pin", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
+
+func (suite *PinHandlerTestSuite) TestPinPostSuccess() {
+	suite.mockService.On("PinPost", mock.Anything, int64(1), mock.MatchedBy(func(req *model.PinPostParams) bool {
+		return req.Position == 2 && req.ExpiresAt != nil
+	})).Return(&model.PostPin{PostID: 1, Position: 2}, nil)
+
+	c, rec := suite.newContext(http.MethodPost, "1", `{"position":2,"expires_at":"2030-01-01T00:00:00Z"}`)
+
+	err := suite.handler.PinPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"position":2`)
+}
+
+func (suite *PinHandlerTestSuite) TestPinPostInvalidID() {
+	c, rec := suite.newContext(http.MethodPost, "abc", `{"position":1}`)
+
+	err := suite.handler.PinPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"INVALID_POST_ID"`)
+}
+
+func (suite *PinHandlerTestSuite) TestPinPostPositionOutOfRange() {
+	suite.mockService.On("PinPost", mock.Anything, int64(1), mock.Anything).
+		Return(nil, fmt.Errorf("%w: positions run from 1 to 5", service.ErrPinPositionInvalid))
+
+	c, rec := suite.newContext(http.MethodPost, "1", `{"position":6}`)
+
+	err := suite.handler.PinPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"INVALID_PIN"`)
+	assert.Contains(suite.T(), rec.Body.String(), "1 to 5")
+}
+
+func (suite *PinHandlerTestSuite) TestPinPostUnpublished() {
+	suite.mockService.On("PinPost", mock.Anything, int64(1), mock.Anything).Return(nil, service.ErrPinPostUnpublished)
+
+	c, rec := suite.newContext(http.MethodPost, "1", `{"position":1}`)
+
+	err := suite.handler.PinPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusConflict, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"POST_NOT_PUBLISHED"`)
+}
+
+func (suite *PinHandlerTestSuite) TestUnpinPostSuccess() {
+	suite.mockService.On("UnpinPost", mock.Anything, int64(1)).Return(nil)
+
+	c, rec := suite.newContext(http.MethodDelete, "1", "")
+
+	err := suite.handler.UnpinPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNoContent, rec.Code)
+}
+
+func (suite *PinHandlerTestSuite) TestUnpinPostNotPinned() {
+	suite.mockService.On("UnpinPost", mock.Anything, int64(1)).Return(service.ErrPinNotFound)
+
+	c, rec := suite.newContext(http.MethodDelete, "1", "")
+
+	err := suite.handler.UnpinPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"POST_NOT_PINNED"`)
+}
+
+func TestPinHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(PinHandlerTestSuite))
+}
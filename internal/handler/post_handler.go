@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/amirzre/news-feed-system/internal/model"
 	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/cachetrace"
 	"github.com/amirzre/news-feed-system/pkg/logger"
 	"github.com/amirzre/news-feed-system/pkg/response"
 	"github.com/labstack/echo/v4"
@@ -15,15 +19,18 @@ import (
 
 // postHandler implements PostHandler interface
 type postHandler struct {
-	postService service.PostService
-	logger      *logger.Logger
+	postService      service.PostService
+	analyticsService service.AnalyticsService
+	logger           *logger.Logger
 }
 
-// NewPostHandler creates a new post handler
-func NewPostHandler(postService service.PostService, logger *logger.Logger) PostHandler {
+// NewPostHandler creates a new post handler. Public searches are recorded
+// through analyticsService.
+func NewPostHandler(postService service.PostService, analyticsService service.AnalyticsService, logger *logger.Logger) PostHandler {
 	return &postHandler{
-		postService: postService,
-		logger:      logger,
+		postService:      postService,
+		analyticsService: analyticsService,
+		logger:           logger,
 	}
 }
 
@@ -37,6 +44,7 @@ func NewPostHandler(postService service.PostService, logger *logger.Logger) Post
 // @Success      201   {object}  response.APIResponse{data=model.Post}              "Post created"
 // @Failure      400   {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid request"
 // @Failure      409   {object}  response.APIResponse{error=response.ErrorInfo}  "Conflict - post exists"
+// @Failure      422   {object}  response.APIResponse{error=response.ErrorInfo}  "Source is blocked"
 // @Failure      500   {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
 // @Router       /posts [post]
 func (h *postHandler) CreatePost(c echo.Context) error {
@@ -45,7 +53,7 @@ func (h *postHandler) CreatePost(c echo.Context) error {
 	var req model.CreatePostParams
 	if err := c.Bind(&req); err != nil {
 		h.logger.LogServiceOperation("post_handler", "create_post", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "Invalid request body", err.Error())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
 	}
 
 	if err := c.Validate(&req); err != nil {
@@ -57,8 +65,11 @@ func (h *postHandler) CreatePost(c echo.Context) error {
 	if err != nil {
 		h.logger.LogServiceOperation("post_handler", "create_post", false, time.Since(start).Milliseconds())
 
-		if errors.Is(err, service.ErrPostExists) {
-			return response.Conflict(c, "Post with this URL already exists")
+		switch {
+		case errors.Is(err, service.ErrPostExists):
+			return response.Conflict(c, response.CodePostExists, "Post with this URL already exists")
+		case errors.Is(err, service.ErrSourceBlocked):
+			return response.Error(c, http.StatusUnprocessableEntity, response.CodeSourceBlocked, "Source is blocked", err.Error())
 		}
 
 		return response.InternalServerError(c, "Failed to create post")
@@ -77,6 +88,7 @@ func (h *postHandler) CreatePost(c echo.Context) error {
 // @Produce      json
 // @Param        id   path      int  true  "Post ID"
 // @Success      200  {object}  response.APIResponse{data=model.Post}              "Post retrieved"
+// @Header       200  {string}  ETag  "Quoted post version for If-Match"
 // @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}     "Invalid ID"
 // @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}     "Post not found"
 // @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}     "Internal server error"
@@ -88,15 +100,15 @@ func (h *postHandler) GetPostByID(c echo.Context) error {
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil || id <= 0 {
 		h.logger.LogServiceOperation("post_handler", "get_post", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "Invalid post ID")
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
 	}
 
-	post, err := h.postService.GetPostByID(c.Request().Context(), id)
+	post, err := h.postService.GetPostByID(c.Request().Context(), id, viewerID(c))
 	if err != nil {
 		h.logger.LogServiceOperation("post_handler", "get_post", false, time.Since(start).Milliseconds())
 
 		if errors.Is(err, service.ErrPostNotFound) {
-			return response.NotFound(c, "Post not found")
+			return response.NotFound(c, response.CodePostNotFound, "Post not found")
 		}
 
 		return response.InternalServerError(c, "Failed to retrieve post")
@@ -104,12 +116,57 @@ func (h *postHandler) GetPostByID(c echo.Context) error {
 
 	h.logger.LogServiceOperation("post_handler", "get_post", true, time.Since(start).Milliseconds())
 
+	setETag(c, post)
+
+	return response.Success(c, http.StatusOK, post)
+}
+
+// LookupPost handles GET /api/v1/posts/lookup
+// @Summary      Look up a post by URL
+// @Description  Retrieve the post stored for an article URL. The URL is normalized first, so tracking parameters, fragments, letter case of the host and a trailing slash do not matter.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        url  query     string  true  "Article URL"
+// @Success      200  {object}  response.APIResponse{data=model.Post}              "Post retrieved"
+// @Header       200  {string}  ETag  "Quoted post version for If-Match"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}     "Missing or invalid URL"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}     "Post not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}     "Internal server error"
+// @Router       /posts/lookup [get]
+func (h *postHandler) LookupPost(c echo.Context) error {
+	start := time.Now()
+
+	rawURL := strings.TrimSpace(c.QueryParam("url"))
+	if rawURL == "" {
+		h.logger.LogServiceOperation("post_handler", "lookup_post", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeMissingParameter, "URL parameter 'url' is required")
+	}
+
+	post, err := h.postService.GetPostByURL(c.Request().Context(), rawURL)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", "lookup_post", false, time.Since(start).Milliseconds())
+
+		switch {
+		case errors.Is(err, service.ErrPostURLInvalid):
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid url parameter", err.Error())
+		case errors.Is(err, service.ErrPostNotFound):
+			return response.NotFound(c, response.CodePostNotFound, "Post not found")
+		}
+
+		return response.InternalServerError(c, "Failed to retrieve post")
+	}
+
+	h.logger.LogServiceOperation("post_handler", "lookup_post", true, time.Since(start).Milliseconds())
+
+	setETag(c, post)
+
 	return response.Success(c, http.StatusOK, post)
 }
 
 // ListPosts handles GET /api/v1/posts with pagination, filtering, and search
 // @Summary      List posts
-// @Description  List posts with pagination, optional filtering by category/source and search
+// @Description  List posts with pagination, optional filtering by category/source/country/author and search. With include_counts=true, meta.counts counts every published post per category and source, whatever the filters, limited to the 20 busiest of each.
 // @Tags         posts
 // @Accept       json
 // @Produce      json
@@ -117,15 +174,63 @@ func (h *postHandler) GetPostByID(c echo.Context) error {
 // @Param        limit     query     int     false  "Results per page"
 // @Param        category  query     string  false  "Filter by category"
 // @Param        source    query     string  false  "Filter by source"
+// @Param        country   query     string  false  "Filter by two-letter country code"
+// @Param        author    query     string  false  "Filter by author"
 // @Param        search    query     string  false  "Search term"
-// @Success      200       {object}  response.APIResponse{data=response.PaginatedResponse{items=[]model.Post,pagination=response.PaginationInfo}}	"List of posts"
+// @Param        safe_mode query     bool    false  "Exclude posts flagged as sensitive"
+// @Param        sort      query     string  false  "Sort order: latest (default) or popular (most reactions first)"
+// @Param        collapse  query     bool    false  "Show only the first post of each topic"
+// @Param        count     query     string  false  "Total count: true (default), false to skip it or estimate"
+// @Param        include_counts query bool false  "Add meta.counts with post counts per category and source"
+// @Success      200       {object}  response.APIResponse{data=response.PaginatedResponse{items=[]model.Post,pagination=response.PaginationInfo,meta=map[string]model.PostCounts}}	"List of posts"
 // @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
 // @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
 // @Router       /posts [get]
 func (h *postHandler) ListPosts(c echo.Context) error {
+	return h.listPosts(c, "list_posts", model.PostStatusPublished)
+}
+
+// AdminListPosts handles GET /api/v1/admin/posts
+// @Summary      List posts in any state
+// @Description  List posts including drafts and hidden posts, with the same filters as the public list
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        status    query     string  false  "Post status: draft, published, hidden or any (default)"
+// @Param        page      query     int     false  "Page number"
+// @Param        limit     query     int     false  "Results per page"
+// @Param        category  query     string  false  "Filter by category"
+// @Param        source    query     string  false  "Filter by source"
+// @Param        country   query     string  false  "Filter by two-letter country code"
+// @Param        author    query     string  false  "Filter by author"
+// @Param        search    query     string  false  "Search term"
+// @Param        safe_mode query     bool    false  "Exclude posts flagged as sensitive"
+// @Param        sort      query     string  false  "Sort order: latest (default) or popular (most reactions first)"
+// @Param        collapse  query     bool    false  "Show only the first post of each topic"
+// @Param        count     query     string  false  "Total count: true (default), false to skip it or estimate"
+// @Param        include_counts query bool false  "Add meta.counts with post counts per category and source in the requested status"
+// @Success      200       {object}  response.APIResponse{data=response.PaginatedResponse{items=[]model.Post,pagination=response.PaginationInfo,meta=map[string]model.PostCounts}}	"List of posts"
+// @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
+// @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/posts [get]
+func (h *postHandler) AdminListPosts(c echo.Context) error {
+	start := time.Now()
+
+	status, err := parseStatus(c)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", "admin_list_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid status parameter", err.Error())
+	}
+
+	return h.listPosts(c, "admin_list_posts", status)
+}
+
+// listPosts serves a filtered, paginated post list restricted to status
+func (h *postHandler) listPosts(c echo.Context, operation string, status model.PostStatus) error {
 	start := time.Now()
 
 	req := model.DefaultPostListParams()
+	req.Status = status
 
 	if pageParam := c.QueryParam("page"); pageParam != "" {
 		if page, err := strconv.Atoi(pageParam); err == nil && page > 0 {
@@ -150,23 +255,78 @@ func (h *postHandler) ListPosts(c echo.Context) error {
 		filters["source"] = source
 	}
 
+	if country := strings.ToLower(c.QueryParam("country")); country != "" {
+		req.Country = &country
+		filters["country"] = country
+	}
+
+	if author := strings.TrimSpace(c.QueryParam("author")); author != "" {
+		req.Author = &author
+		filters["author"] = author
+	}
+
 	if search := c.QueryParam("search"); search != "" {
 		req.Search = &search
 		filters["search"] = search
 	}
 
+	safeMode, err := parseSafeMode(c)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", operation, false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid safe_mode parameter")
+	}
+	if safeMode {
+		req.SafeMode = true
+		filters["safe_mode"] = "true"
+	}
+
+	if req.Sort, err = parseSort(c); err != nil {
+		h.logger.LogServiceOperation("post_handler", operation, false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid sort parameter", err.Error())
+	}
+	if req.Sort != "" {
+		filters["sort"] = req.Sort
+	}
+
+	if req.Collapse, err = parseBoolParam(c, "collapse"); err != nil {
+		h.logger.LogServiceOperation("post_handler", operation, false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid collapse parameter")
+	}
+	if req.Collapse {
+		filters["collapse"] = "true"
+	}
+
+	if req.Count, err = parseCountMode(c); err != nil {
+		h.logger.LogServiceOperation("post_handler", operation, false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid count parameter", err.Error())
+	}
+
+	if req.IncludeCounts, err = parseBoolParam(c, "include_counts"); err != nil {
+		h.logger.LogServiceOperation("post_handler", operation, false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid include_counts parameter")
+	}
+
+	if status != model.PostStatusPublished {
+		filters["status"] = string(status)
+	}
+
 	if err := c.Validate(&req); err != nil {
-		h.logger.LogServiceOperation("post_handler", "list_posts", false, time.Since(start).Milliseconds())
+		h.logger.LogServiceOperation("post_handler", operation, false, time.Since(start).Milliseconds())
 		return response.ValidationError(c, err)
 	}
 
-	posts, err := h.postService.ListPosts(c.Request().Context(), &req)
+	ctx, trace := cachetrace.WithTrace(c.Request().Context())
+	posts, err := h.postService.ListPosts(ctx, &req)
 	if err != nil {
-		h.logger.LogServiceOperation("post_handler", "list_posts", false, time.Since(start).Milliseconds())
+		h.logger.LogServiceOperation("post_handler", operation, false, time.Since(start).Milliseconds())
 		return response.InternalServerError(c, "Failed to retrieve posts")
 	}
 
-	h.logger.LogServiceOperation("post_handler", "list_posts", true, time.Since(start).Milliseconds())
+	if req.Search != nil && status == model.PostStatusPublished {
+		h.recordSearch(c, *req.Search, req.Page, posts.Pagination.Total, time.Since(start))
+	}
+
+	h.logger.LogServiceOperation("post_handler", operation, true, time.Since(start).Milliseconds())
 	h.logger.Debug("Listed posts",
 		"page", req.Page,
 		"limit", req.Limit,
@@ -174,22 +334,77 @@ func (h *postHandler) ListPosts(c echo.Context) error {
 		"returned", len(posts.Posts),
 	)
 
-	paginationInfo := response.CreatePaginationInfo(req.Page, req.Limit, int(posts.Pagination.Total))
+	paginationInfo := listPagination(&req, posts.Pagination)
+	response.SetQueryInfo(c, listQueryInfo(&req, filters, start, trace))
+
+	if posts.Counts != nil {
+		meta := map[string]any{"counts": posts.Counts}
+		return response.SuccessWithPaginationAndMeta(c, posts.Posts, paginationInfo, filters, meta)
+	}
 
 	return response.SuccessWithPagination(c, posts.Posts, paginationInfo, filters)
 }
 
+// DiscoverPosts handles GET /api/v1/posts/discover
+// @Summary      Discover posts
+// @Description  List a random selection of posts published in the last 7 days, alternating between categories, for explore views. Posts are picked from a sample that is cached for a while, so successive calls reshuffle the same posts until a new sample is drawn.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        limit     query     int     false  "Number of posts (default 10, max 50)"
+// @Param        safe_mode query     bool    false  "Exclude posts flagged as sensitive"
+// @Success      200       {object}  response.APIResponse{data=[]model.Post}         "Random recent posts"
+// @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
+// @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/discover [get]
+func (h *postHandler) DiscoverPosts(c echo.Context) error {
+	start := time.Now()
+
+	req := model.DiscoverPostsParams{Limit: 10}
+
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil && limit > 0 {
+			req.Limit = limit
+		}
+	}
+
+	safeMode, err := parseSafeMode(c)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", "discover_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid safe_mode parameter")
+	}
+	req.SafeMode = safeMode
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("post_handler", "discover_posts", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	posts, err := h.postService.DiscoverPosts(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", "discover_posts", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to discover posts")
+	}
+
+	h.logger.LogServiceOperation("post_handler", "discover_posts", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, posts)
+}
+
 // UpdatePost handles PUT /api/v1/posts/:id
 // @Summary      Update a post
-// @Description  Update a post by ID with the provided payload
+// @Description  Update a post by ID with the provided payload. The version last read must be sent in an If-Match header or the body's version field.
 // @Tags         posts
 // @Accept       json
 // @Produce      json
-// @Param        id    path      int                     true  "Post ID"
-// @Param        post  body      model.UpdatePostParams  true  "Update Post payload"
+// @Param        id        path      int                     true   "Post ID"
+// @Param        If-Match  header    string                  false  "Post version (ETag) the update is based on"
+// @Param        post      body      model.UpdatePostParams  true   "Update Post payload"
 // @Success      200   {object}  response.APIResponse{data=model.Post}           "Updated post"
 // @Failure      400   {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid request"
 // @Failure      404   {object}  response.APIResponse{error=response.ErrorInfo}  "Post not found"
+// @Failure      412   {object}  response.APIResponse{error=response.ErrorInfo}  "Post was modified since it was read"
+// @Failure      428   {object}  response.APIResponse{error=response.ErrorInfo}  "Post version missing"
 // @Failure      500   {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
 // @Router       /posts/{id} [put]
 func (h *postHandler) UpdatePost(c echo.Context) error {
@@ -199,13 +414,22 @@ func (h *postHandler) UpdatePost(c echo.Context) error {
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
 		h.logger.LogServiceOperation("post_handler", "update_post", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "Invalid post ID")
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
 	}
 
 	var req model.UpdatePostParams
 	if err := c.Bind(&req); err != nil {
 		h.logger.LogServiceOperation("post_handler", "update_post", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "Invalid request body", err.Error())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if ifMatch := c.Request().Header.Get("If-Match"); ifMatch != "" {
+		version, err := parseETag(ifMatch)
+		if err != nil {
+			h.logger.LogServiceOperation("post_handler", "update_post", false, time.Since(start).Milliseconds())
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid If-Match header", "expected a quoted post version such as \"3\"")
+		}
+		req.Version = version
 	}
 
 	if err := c.Validate(&req); err != nil {
@@ -217,8 +441,13 @@ func (h *postHandler) UpdatePost(c echo.Context) error {
 	if err != nil {
 		h.logger.LogServiceOperation("post_handler", "update_post", false, time.Since(start).Milliseconds())
 
-		if errors.Is(err, service.ErrPostNotFound) {
-			return response.NotFound(c, "Post not found")
+		switch {
+		case errors.Is(err, service.ErrPostNotFound):
+			return response.NotFound(c, response.CodePostNotFound, "Post not found")
+		case errors.Is(err, service.ErrPostVersionRequired):
+			return response.PreconditionRequired(c, response.CodePostVersionRequired, "Post version is required", "send the version from the post's ETag in an If-Match header")
+		case errors.Is(err, service.ErrPostVersionConflict):
+			return response.PreconditionFailed(c, response.CodePostVersionConflict, "Post was modified by another request", "fetch the post again and retry with its current version")
 		}
 
 		return response.InternalServerError(c, "Failed to update post")
@@ -226,6 +455,8 @@ func (h *postHandler) UpdatePost(c echo.Context) error {
 
 	h.logger.LogServiceOperation("post_handler", "update_post", true, time.Since(start).Milliseconds())
 
+	setETag(c, post)
+
 	return response.Success(c, http.StatusOK, post, "Post updated successfully")
 }
 
@@ -248,7 +479,7 @@ func (h *postHandler) DeletePost(c echo.Context) error {
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil || id <= 0 {
 		h.logger.LogServiceOperation("post_handler", "delete_post", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "Invalid post ID")
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
 	}
 
 	err = h.postService.DeletePost(c.Request().Context(), id)
@@ -256,7 +487,7 @@ func (h *postHandler) DeletePost(c echo.Context) error {
 		h.logger.LogServiceOperation("post_handler", "delete_post", false, time.Since(start).Milliseconds())
 
 		if errors.Is(err, service.ErrPostNotFound) {
-			return response.NotFound(c, "Post not found")
+			return response.NotFound(c, response.CodePostNotFound, "Post not found")
 		}
 
 		return response.InternalServerError(c, "Failed to delete post")
@@ -267,6 +498,152 @@ func (h *postHandler) DeletePost(c echo.Context) error {
 	return response.Success(c, http.StatusNoContent, nil, "Post deleted successfully")
 }
 
+// PublishPost handles POST /api/v1/posts/:id/publish
+// @Summary      Publish a post
+// @Description  Make a draft or hidden post visible on public endpoints. Publishing an already published post is a no-op.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Post ID"
+// @Success      200  {object}  response.APIResponse{data=model.Post}           "Published post"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid ID"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Post not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/{id}/publish [post]
+func (h *postHandler) PublishPost(c echo.Context) error {
+	return h.transition(c, "publish_post", "Post published successfully", h.postService.PublishPost)
+}
+
+// HidePost handles POST /api/v1/posts/:id/hide
+// @Summary      Hide a post
+// @Description  Take a post off public endpoints without deleting it. Hiding an already hidden post is a no-op.
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Post ID"
+// @Success      200  {object}  response.APIResponse{data=model.Post}           "Hidden post"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid ID"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Post not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/{id}/hide [post]
+func (h *postHandler) HidePost(c echo.Context) error {
+	return h.transition(c, "hide_post", "Post hidden successfully", h.postService.HidePost)
+}
+
+// BulkUpdatePosts handles POST /api/v1/admin/posts/bulk-update
+// @Summary      Bulk update posts
+// @Description  Set the status, category or sensitive flag of every post matching the filter, given as post IDs, source, category and publication date range. A filter is required. With dry_run set nothing is changed and the number of posts that would change is returned.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.BulkUpdatePostsParams  true  "Filter and changes"
+// @Success      200      {object}  response.APIResponse{data=model.BulkPostsResult}  "Posts updated"
+// @Failure      400      {object}  response.APIResponse{error=response.ErrorInfo}    "Invalid filter or changes"
+// @Failure      500      {object}  response.APIResponse{error=response.ErrorInfo}    "Internal server error"
+// @Router       /admin/posts/bulk-update [post]
+func (h *postHandler) BulkUpdatePosts(c echo.Context) error {
+	start := time.Now()
+
+	var req model.BulkUpdatePostsParams
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("post_handler", "bulk_update_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("post_handler", "bulk_update_posts", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	result, err := h.postService.BulkUpdatePosts(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", "bulk_update_posts", false, time.Since(start).Milliseconds())
+		return bulkError(c, err, "Failed to update posts")
+	}
+
+	h.logger.LogServiceOperation("post_handler", "bulk_update_posts", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, result, "Posts updated successfully")
+}
+
+// BulkDeletePosts handles POST /api/v1/admin/posts/bulk-delete
+// @Summary      Bulk delete posts
+// @Description  Delete every post matching the filter, given as post IDs, source, category and publication date range. A filter is required. With dry_run set nothing is deleted and the number of matching posts is returned.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.BulkDeletePostsParams  true  "Filter"
+// @Success      200      {object}  response.APIResponse{data=model.BulkPostsResult}  "Posts deleted"
+// @Failure      400      {object}  response.APIResponse{error=response.ErrorInfo}    "Invalid filter"
+// @Failure      500      {object}  response.APIResponse{error=response.ErrorInfo}    "Internal server error"
+// @Router       /admin/posts/bulk-delete [post]
+func (h *postHandler) BulkDeletePosts(c echo.Context) error {
+	start := time.Now()
+
+	var req model.BulkDeletePostsParams
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("post_handler", "bulk_delete_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("post_handler", "bulk_delete_posts", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	result, err := h.postService.BulkDeletePosts(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", "bulk_delete_posts", false, time.Since(start).Milliseconds())
+		return bulkError(c, err, "Failed to delete posts")
+	}
+
+	h.logger.LogServiceOperation("post_handler", "bulk_delete_posts", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, result, "Posts deleted successfully")
+}
+
+// bulkError writes the response for an error of a bulk operation, with
+// message for errors that are not caused by the request
+func bulkError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrBulkFilterEmpty):
+		return response.BadRequest(c, response.CodeMissingParameter, "A filter is required", err.Error())
+	case errors.Is(err, service.ErrBulkNoChanges):
+		return response.BadRequest(c, response.CodeMissingParameter, "No changes given", err.Error())
+	case errors.Is(err, service.ErrBulkInvalidRange):
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid date range", err.Error())
+	}
+
+	return response.InternalServerError(c, message)
+}
+
+// transition applies a status change to the post named in the path
+func (h *postHandler) transition(c echo.Context, operation, message string, apply func(context.Context, int64) (*model.Post, error)) error {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.logger.LogServiceOperation("post_handler", operation, false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	post, err := apply(c.Request().Context(), id)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", operation, false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrPostNotFound) {
+			return response.NotFound(c, response.CodePostNotFound, "Post not found")
+		}
+
+		return response.InternalServerError(c, "Failed to update post status")
+	}
+
+	h.logger.LogServiceOperation("post_handler", operation, true, time.Since(start).Milliseconds())
+
+	setETag(c, post)
+	return response.Success(c, http.StatusOK, post, message)
+}
+
 // GetPostsByCategory handles GET /api/v1/posts/category/:category
 // @Summary      List posts by category
 // @Description  List posts filtered by category
@@ -276,6 +653,10 @@ func (h *postHandler) DeletePost(c echo.Context) error {
 // @Param        category  path      string  true   "Category"
 // @Param        page      query     int     false  "Page number"
 // @Param        limit     query     int     false  "Results per page"
+// @Param        safe_mode query     bool    false  "Exclude posts flagged as sensitive"
+// @Param        sort      query     string  false  "Sort order: latest (default) or popular (most reactions first)"
+// @Param        collapse  query     bool    false  "Show only the first post of each topic"
+// @Param        count     query     string  false  "Total count: true (default), false to skip it or estimate"
 // @Success      200       {object}   response.APIResponse{data=response.PaginatedResponse{items=[]model.Post,pagination=response.PaginationInfo}}	"List of posts"
 // @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
 // @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
@@ -286,7 +667,7 @@ func (h *postHandler) GetPostsByCategory(c echo.Context) error {
 	category := c.Param("category")
 	if category == "" {
 		h.logger.LogServiceOperation("post_handler", "get_posts_by_category", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "Category is required")
+		return response.BadRequest(c, response.CodeMissingParameter, "Category is required")
 	}
 
 	req := model.DefaultPostListParams()
@@ -304,7 +685,30 @@ func (h *postHandler) GetPostsByCategory(c echo.Context) error {
 		}
 	}
 
-	posts, err := h.postService.ListPosts(c.Request().Context(), &req)
+	safeMode, err := parseSafeMode(c)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", "get_posts_by_category", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid safe_mode parameter")
+	}
+	req.SafeMode = safeMode
+
+	if req.Sort, err = parseSort(c); err != nil {
+		h.logger.LogServiceOperation("post_handler", "get_posts_by_category", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid sort parameter", err.Error())
+	}
+
+	if req.Collapse, err = parseBoolParam(c, "collapse"); err != nil {
+		h.logger.LogServiceOperation("post_handler", "get_posts_by_category", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid collapse parameter")
+	}
+
+	if req.Count, err = parseCountMode(c); err != nil {
+		h.logger.LogServiceOperation("post_handler", "get_posts_by_category", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid count parameter", err.Error())
+	}
+
+	ctx, trace := cachetrace.WithTrace(c.Request().Context())
+	posts, err := h.postService.ListPosts(ctx, &req)
 	if err != nil {
 		h.logger.LogServiceOperation("post_handler", "get_posts_by_category", false, time.Since(start).Milliseconds())
 		return response.InternalServerError(c, "Failed to retrieve posts by category")
@@ -312,8 +716,18 @@ func (h *postHandler) GetPostsByCategory(c echo.Context) error {
 
 	h.logger.LogServiceOperation("post_handler", "get_posts_by_category", true, time.Since(start).Milliseconds())
 
-	paginationInfo := response.CreatePaginationInfo(req.Page, req.Limit, int(posts.Pagination.Total))
+	paginationInfo := listPagination(&req, posts.Pagination)
 	filters := map[string]string{"category": category}
+	if safeMode {
+		filters["safe_mode"] = "true"
+	}
+	if req.Sort != "" {
+		filters["sort"] = req.Sort
+	}
+	if req.Collapse {
+		filters["collapse"] = "true"
+	}
+	response.SetQueryInfo(c, listQueryInfo(&req, filters, start, trace))
 
 	return response.SuccessWithPagination(c, posts.Posts, paginationInfo, filters)
 }
@@ -327,6 +741,10 @@ func (h *postHandler) GetPostsByCategory(c echo.Context) error {
 // @Param        source    path      string  true   "Source"
 // @Param        page      query     int     false  "Page number"
 // @Param        limit     query     int     false  "Results per page"
+// @Param        safe_mode query     bool    false  "Exclude posts flagged as sensitive"
+// @Param        sort      query     string  false  "Sort order: latest (default) or popular (most reactions first)"
+// @Param        collapse  query     bool    false  "Show only the first post of each topic"
+// @Param        count     query     string  false  "Total count: true (default), false to skip it or estimate"
 // @Success      200       {object}  response.APIResponse{data=response.PaginatedResponse{items=[]model.Post,pagination=response.PaginationInfo}}	"List of posts"
 // @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
 // @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
@@ -337,7 +755,7 @@ func (h *postHandler) GetPostsBySource(c echo.Context) error {
 	source := c.Param("source")
 	if source == "" {
 		h.logger.LogServiceOperation("post_handler", "get_posts_by_source", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "Source is required")
+		return response.BadRequest(c, response.CodeMissingParameter, "Source is required")
 	}
 
 	req := model.DefaultPostListParams()
@@ -355,7 +773,30 @@ func (h *postHandler) GetPostsBySource(c echo.Context) error {
 		}
 	}
 
-	posts, err := h.postService.ListPosts(c.Request().Context(), &req)
+	safeMode, err := parseSafeMode(c)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", "get_posts_by_source", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid safe_mode parameter")
+	}
+	req.SafeMode = safeMode
+
+	if req.Sort, err = parseSort(c); err != nil {
+		h.logger.LogServiceOperation("post_handler", "get_posts_by_source", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid sort parameter", err.Error())
+	}
+
+	if req.Collapse, err = parseBoolParam(c, "collapse"); err != nil {
+		h.logger.LogServiceOperation("post_handler", "get_posts_by_source", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid collapse parameter")
+	}
+
+	if req.Count, err = parseCountMode(c); err != nil {
+		h.logger.LogServiceOperation("post_handler", "get_posts_by_source", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid count parameter", err.Error())
+	}
+
+	ctx, trace := cachetrace.WithTrace(c.Request().Context())
+	posts, err := h.postService.ListPosts(ctx, &req)
 	if err != nil {
 		h.logger.LogServiceOperation("post_handler", "get_posts_by_source", false, time.Since(start).Milliseconds())
 		return response.InternalServerError(c, "Failed to retrieve posts by source")
@@ -363,15 +804,25 @@ func (h *postHandler) GetPostsBySource(c echo.Context) error {
 
 	h.logger.LogServiceOperation("post_handler", "get_posts_by_source", true, time.Since(start).Milliseconds())
 
-	paginationInfo := response.CreatePaginationInfo(req.Page, req.Limit, int(posts.Pagination.Total))
+	paginationInfo := listPagination(&req, posts.Pagination)
 	filters := map[string]string{"source": source}
+	if safeMode {
+		filters["safe_mode"] = "true"
+	}
+	if req.Sort != "" {
+		filters["sort"] = req.Sort
+	}
+	if req.Collapse {
+		filters["collapse"] = "true"
+	}
+	response.SetQueryInfo(c, listQueryInfo(&req, filters, start, trace))
 
 	return response.SuccessWithPagination(c, posts.Posts, paginationInfo, filters)
 }
 
 // SearchPosts handles GET /api/v1/posts/search
 // @Summary      Search posts
-// @Description  Search posts by query string with optional filters
+// @Description  Search posts by query string with optional filters. meta.facets counts every matching post per category, source and publication day (YYYY-MM-DD, most recent 30 days with matches); categories and sources are limited to the 20 busiest. With highlight=true each post carries a highlight object whose title and description snippet wrap matches in the configured delimiters (<em> and </em> by default).
 // @Tags         posts
 // @Accept       json
 // @Produce      json
@@ -380,7 +831,13 @@ func (h *postHandler) GetPostsBySource(c echo.Context) error {
 // @Param        limit     query     int     false  "Results per page"
 // @Param        category  query     string  false  "Filter by category"
 // @Param        source    query     string  false  "Filter by source"
-// @Success      200       {object}   response.APIResponse{data=response.PaginatedResponse{items=[]model.Post,pagination=response.PaginationInfo}}              "Search results"
+// @Param        country   query     string  false  "Filter by two-letter country code"
+// @Param        safe_mode query     bool    false  "Exclude posts flagged as sensitive"
+// @Param        sort      query     string  false  "Sort order: latest (default) or popular (most reactions first)"
+// @Param        collapse  query     bool    false  "Show only the first post of each topic"
+// @Param        count     query     string  false  "Total count: true (default), false to skip it or estimate"
+// @Param        highlight query     bool    false  "Highlight matches in titles and descriptions"
+// @Success      200       {object}  response.APIResponse{data=response.PaginatedResponse{items=[]model.Post,pagination=response.PaginationInfo,meta=map[string]model.SearchFacets}}  "Search results"
 // @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
 // @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
 // @Router       /posts/search [get]
@@ -390,11 +847,12 @@ func (h *postHandler) SearchPosts(c echo.Context) error {
 	query := c.QueryParam("q")
 	if query == "" {
 		h.logger.LogServiceOperation("post_handler", "search_posts", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "Search query parameter 'q' is required")
+		return response.BadRequest(c, response.CodeMissingParameter, "Search query parameter 'q' is required")
 	}
 
 	req := model.DefaultPostListParams()
 	req.Search = &query
+	req.Facets = true
 
 	if pageParam := c.QueryParam("page"); pageParam != "" {
 		if page, err := strconv.Atoi(pageParam); err == nil && page > 0 {
@@ -419,15 +877,213 @@ func (h *postHandler) SearchPosts(c echo.Context) error {
 		filters["source"] = source
 	}
 
-	posts, err := h.postService.ListPosts(c.Request().Context(), &req)
+	if country := strings.ToLower(c.QueryParam("country")); country != "" {
+		req.Country = &country
+		filters["country"] = country
+	}
+
+	safeMode, err := parseSafeMode(c)
+	if err != nil {
+		h.logger.LogServiceOperation("post_handler", "search_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid safe_mode parameter")
+	}
+	if safeMode {
+		req.SafeMode = true
+		filters["safe_mode"] = "true"
+	}
+
+	if req.Sort, err = parseSort(c); err != nil {
+		h.logger.LogServiceOperation("post_handler", "search_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid sort parameter", err.Error())
+	}
+	if req.Sort != "" {
+		filters["sort"] = req.Sort
+	}
+
+	if req.Highlight, err = parseBoolParam(c, "highlight"); err != nil {
+		h.logger.LogServiceOperation("post_handler", "search_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid highlight parameter")
+	}
+	if req.Highlight {
+		filters["highlight"] = "true"
+	}
+
+	if req.Collapse, err = parseBoolParam(c, "collapse"); err != nil {
+		h.logger.LogServiceOperation("post_handler", "search_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid collapse parameter")
+	}
+	if req.Collapse {
+		filters["collapse"] = "true"
+	}
+
+	if req.Count, err = parseCountMode(c); err != nil {
+		h.logger.LogServiceOperation("post_handler", "search_posts", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid count parameter", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("post_handler", "search_posts", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	ctx, trace := cachetrace.WithTrace(c.Request().Context())
+	posts, err := h.postService.ListPosts(ctx, &req)
 	if err != nil {
 		h.logger.LogServiceOperation("post_handler", "search_posts", false, time.Since(start).Milliseconds())
 		return response.InternalServerError(c, "Failed to search posts")
 	}
 
+	h.recordSearch(c, query, req.Page, posts.Pagination.Total, time.Since(start))
+
 	h.logger.LogServiceOperation("post_handler", "search_posts", true, time.Since(start).Milliseconds())
 
-	paginationInfo := response.CreatePaginationInfo(req.Page, req.Limit, int(posts.Pagination.Total))
+	paginationInfo := listPagination(&req, posts.Pagination)
+	response.SetQueryInfo(c, listQueryInfo(&req, filters, start, trace))
+
+	if posts.Facets != nil {
+		meta := map[string]any{"facets": posts.Facets}
+		return response.SuccessWithPaginationAndMeta(c, posts.Posts, paginationInfo, filters, meta)
+	}
 
 	return response.SuccessWithPagination(c, posts.Posts, paginationInfo, filters)
 }
+
+// recordSearch stores a search for analytics. Only the first page counts as a
+// search, so paging through results is not counted again. Analytics are
+// best-effort: a failure is logged and the search response is still served.
+func (h *postHandler) recordSearch(c echo.Context, term string, page int, total int64, latency time.Duration) {
+	if page > 1 {
+		return
+	}
+
+	if err := h.analyticsService.RecordSearch(c.Request().Context(), term, total, latency); err != nil {
+		h.logger.Warn("Failed to record search", "term", term, "error", err.Error())
+	}
+}
+
+// parseSafeMode reads the optional safe_mode query parameter
+func parseSafeMode(c echo.Context) (bool, error) {
+	value := c.QueryParam("safe_mode")
+	if value == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(value)
+}
+
+// parseBoolParam reads an optional boolean query parameter
+func parseBoolParam(c echo.Context, name string) (bool, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(value)
+}
+
+// listQueryInfo describes how a post list request was understood and served
+func listQueryInfo(req *model.PostListParams, filters map[string]string, start time.Time, trace *cachetrace.Trace) *response.QueryInfo {
+	sort := req.Sort
+	if sort == "" {
+		sort = model.PostSortLatest
+	}
+
+	return &response.QueryInfo{
+		Filters:  filters,
+		Sort:     sort,
+		TookMs:   time.Since(start).Milliseconds(),
+		CacheHit: trace.Hit(),
+	}
+}
+
+// parseCountMode reads the optional count query parameter: true or absent
+// counts every post, false skips the total and estimate reads it from the
+// query planner
+func parseCountMode(c echo.Context) (model.PostCountMode, error) {
+	value := c.QueryParam("count")
+	switch value {
+	case "":
+		return model.PostCountExact, nil
+	case string(model.PostCountEstimate):
+		return model.PostCountEstimate, nil
+	}
+
+	count, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", errors.New("count must be true, false or estimate")
+	}
+	if !count {
+		return model.PostCountNone, nil
+	}
+
+	return model.PostCountExact, nil
+}
+
+// listPagination creates the pagination info of a post list. Without an
+// exact count, whether there is a next page is known from the list itself
+// rather than from the total.
+func listPagination(req *model.PostListParams, pagination model.PaginationMeta) *response.PaginationInfo {
+	info := response.CreatePaginationInfo(req.Page, req.Limit, int(pagination.Total))
+	if pagination.TotalMode != model.PostCountExact {
+		info.HasNext = pagination.HasNext
+		info.TotalMode = string(pagination.TotalMode)
+	}
+
+	return info
+}
+
+// parseSort reads the optional sort query parameter. The default order,
+// latest first, is returned as an empty string.
+func parseSort(c echo.Context) (string, error) {
+	switch sort := c.QueryParam("sort"); sort {
+	case "", model.PostSortLatest:
+		return "", nil
+	case model.PostSortPopular:
+		return sort, nil
+	default:
+		return "", fmt.Errorf("sort must be %q or %q", model.PostSortLatest, model.PostSortPopular)
+	}
+}
+
+// parseStatus reads the optional status query parameter of the admin list,
+// which defaults to posts in any state
+func parseStatus(c echo.Context) (model.PostStatus, error) {
+	switch status := model.PostStatus(c.QueryParam("status")); status {
+	case "", model.PostStatusAny:
+		return model.PostStatusAny, nil
+	case model.PostStatusDraft, model.PostStatusPublished, model.PostStatusHidden:
+		return status, nil
+	default:
+		return "", errors.New("status must be draft, published, hidden or any")
+	}
+}
+
+// viewerID identifies the reader of a post for view deduplication: the user
+// when known, otherwise the client address and user agent
+func viewerID(c echo.Context) string {
+	if userID := c.Request().Header.Get("X-User-ID"); userID != "" {
+		return "user:" + userID
+	}
+
+	return "client:" + c.RealIP() + "|" + c.Request().UserAgent()
+}
+
+// setETag exposes the post version as a strong ETag for later If-Match updates
+func setETag(c echo.Context, post *model.Post) {
+	c.Response().Header().Set("ETag", strconv.Quote(strconv.Itoa(post.Version)))
+}
+
+// parseETag extracts the post version from an If-Match value such as "3" or W/"3"
+func parseETag(value string) (int, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid post version %q", value)
+	}
+
+	return version, nil
+}
@@ -41,8 +41,16 @@ func (m *MockPostService) PostExists(ctx context.Context, url string) (bool, err
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockPostService) GetPostByID(ctx context.Context, id int64) (*model.Post, error) {
-	args := m.Called(ctx, id)
+func (m *MockPostService) GetPostByID(ctx context.Context, id int64, viewer string) (*model.Post, error) {
+	args := m.Called(ctx, id, viewer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Post), args.Error(1)
+}
+
+func (m *MockPostService) GetPostByURL(ctx context.Context, url string) (*model.Post, error) {
+	args := m.Called(ctx, url)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -57,6 +65,14 @@ func (m *MockPostService) ListPosts(ctx context.Context, req *model.PostListPara
 	return args.Get(0).(*model.PostListResponse), args.Error(1)
 }
 
+func (m *MockPostService) DiscoverPosts(ctx context.Context, req *model.DiscoverPostsParams) ([]model.Post, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Post), args.Error(1)
+}
+
 func (m *MockPostService) UpdatePost(ctx context.Context, id int64, req *model.UpdatePostParams) (*model.Post, error) {
 	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
@@ -70,6 +86,22 @@ func (m *MockPostService) DeletePost(ctx context.Context, id int64) error {
 	return args.Error(0)
 }
 
+func (m *MockPostService) PublishPost(ctx context.Context, id int64) (*model.Post, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Post), args.Error(1)
+}
+
+func (m *MockPostService) HidePost(ctx context.Context, id int64) (*model.Post, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Post), args.Error(1)
+}
+
 func (m *MockPostService) CreatePostFromNewsAPI(ctx context.Context, article *model.NewsAPIArticleParams) (*model.Post, error) {
 	args := m.Called(ctx, article)
 	if args.Get(0) == nil {
@@ -78,6 +110,43 @@ func (m *MockPostService) CreatePostFromNewsAPI(ctx context.Context, article *mo
 	return args.Get(0).(*model.Post), args.Error(1)
 }
 
+func (m *MockPostService) PreviewPostFromNewsAPI(ctx context.Context, article *model.NewsAPIArticleParams) (*model.ArticlePreview, error) {
+	args := m.Called(ctx, article)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ArticlePreview), args.Error(1)
+}
+
+func (m *MockPostService) RebuildURLFilter(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPostService) NormalizePostURLs(ctx context.Context, batchSize int) (*model.URLNormalizationResult, error) {
+	args := m.Called(ctx, batchSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.URLNormalizationResult), args.Error(1)
+}
+
+func (m *MockPostService) BulkUpdatePosts(ctx context.Context, req *model.BulkUpdatePostsParams) (*model.BulkPostsResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BulkPostsResult), args.Error(1)
+}
+
+func (m *MockPostService) BulkDeletePosts(ctx context.Context, req *model.BulkDeletePostsParams) (*model.BulkPostsResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BulkPostsResult), args.Error(1)
+}
+
 // MockValidator is a mock implementation for Echo's validator
 type MockValidator struct{}
 
@@ -88,10 +157,11 @@ func (cv *MockValidator) Validate(i any) error {
 // PostHandlerTestSuite defines the test suite for PostHandler
 type PostHandlerTestSuite struct {
 	suite.Suite
-	mockService *MockPostService
-	logger      *logger.Logger
-	handler     PostHandler
-	echo        *echo.Echo
+	mockService   *MockPostService
+	mockAnalytics *MockAnalyticsService
+	logger        *logger.Logger
+	handler       PostHandler
+	echo          *echo.Echo
 }
 
 // SetupTest prepares each test
@@ -99,8 +169,9 @@ func (suite *PostHandlerTestSuite) SetupTest() {
 	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
 
 	suite.mockService = new(MockPostService)
+	suite.mockAnalytics = new(MockAnalyticsService)
 	suite.logger = logger.New(cfg)
-	suite.handler = NewPostHandler(suite.mockService, suite.logger)
+	suite.handler = NewPostHandler(suite.mockService, suite.mockAnalytics, suite.logger)
 	suite.echo = echo.New()
 	suite.echo.Validator = &MockValidator{}
 }
@@ -160,6 +231,7 @@ func (suite *PostHandlerTestSuite) createMockUpdateParams() *model.UpdatePostPar
 		Content:     &content,
 		Category:    &category,
 		ImageURL:    &imageURL,
+		Version:     1,
 	}
 }
 
@@ -224,6 +296,24 @@ func (suite *PostHandlerTestSuite) TestCreatePostInvalidJSON() {
 	assert.False(suite.T(), response.Success)
 }
 
+func (suite *PostHandlerTestSuite) TestCreatePostBlockedSource() {
+	req := suite.createMockCreateParams()
+
+	suite.mockService.On("CreatePost", mock.Anything, req).Return(nil, fmt.Errorf("%w: source %q is blocked", service.ErrSourceBlocked, req.Source))
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/posts", req)
+
+	err := suite.handler.CreatePost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+
+	var response response.APIResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.EqualValues(suite.T(), "SOURCE_BLOCKED", response.Error.Code)
+}
+
 func (suite *PostHandlerTestSuite) TestCreatePostConflict() {
 	req := suite.createMockCreateParams()
 
@@ -240,6 +330,7 @@ func (suite *PostHandlerTestSuite) TestCreatePostConflict() {
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(suite.T(), err)
 	assert.False(suite.T(), response.Success)
+	assert.EqualValues(suite.T(), "POST_ALREADY_EXISTS", response.Error.Code)
 }
 
 func (suite *PostHandlerTestSuite) TestCreatePostInternalError() {
@@ -263,7 +354,7 @@ func (suite *PostHandlerTestSuite) TestCreatePostInternalError() {
 func (suite *PostHandlerTestSuite) TestGetPostByIDSuccess() {
 	expectedPost := suite.createMockPost()
 
-	suite.mockService.On("GetPostByID", mock.Anything, int64(1)).Return(expectedPost, nil)
+	suite.mockService.On("GetPostByID", mock.Anything, int64(1), mock.Anything).Return(expectedPost, nil)
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/posts/1", nil)
 	c.SetParamNames("id")
@@ -280,6 +371,21 @@ func (suite *PostHandlerTestSuite) TestGetPostByIDSuccess() {
 	assert.True(suite.T(), response.Success)
 }
 
+func (suite *PostHandlerTestSuite) TestGetPostByIDPassesViewer() {
+	suite.mockService.On("GetPostByID", mock.Anything, int64(1), "user:42").Return(suite.createMockPost(), nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/1", nil)
+	c.Request().Header.Set("X-User-ID", "42")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := suite.handler.GetPostByID(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	suite.mockService.AssertExpectations(suite.T())
+}
+
 func (suite *PostHandlerTestSuite) TestGetPostByIDInvalidID() {
 	c, rec := suite.createEchoContext(http.MethodGet, "/posts/invalid", nil)
 	c.SetParamNames("id")
@@ -308,7 +414,7 @@ func (suite *PostHandlerTestSuite) TestGetPostByIDZeroID() {
 }
 
 func (suite *PostHandlerTestSuite) TestGetPostByIDNotFound() {
-	suite.mockService.On("GetPostByID", mock.Anything, int64(999)).Return(nil, service.ErrPostNotFound)
+	suite.mockService.On("GetPostByID", mock.Anything, int64(999), mock.Anything).Return(nil, service.ErrPostNotFound)
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/posts/999", nil)
 	c.SetParamNames("id")
@@ -323,10 +429,83 @@ func (suite *PostHandlerTestSuite) TestGetPostByIDNotFound() {
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(suite.T(), err)
 	assert.False(suite.T(), response.Success)
+	assert.EqualValues(suite.T(), "POST_NOT_FOUND", response.Error.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestGetPostByIDNotFoundProblemJSON() {
+	suite.mockService.On("GetPostByID", mock.Anything, int64(999), mock.Anything).Return(nil, service.ErrPostNotFound)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/999", nil)
+	c.Request().Header.Set(echo.HeaderAccept, "application/problem+json, application/json;q=0.5")
+	c.SetParamNames("id")
+	c.SetParamValues("999")
+
+	err := suite.handler.GetPostByID(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Equal(suite.T(), response.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var problem response.ProblemDetails
+	err = json.Unmarshal(rec.Body.Bytes(), &problem)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, problem.Status)
+	assert.Equal(suite.T(), "Post not found", problem.Title)
+	assert.Equal(suite.T(), "/posts/999", problem.Instance)
+	assert.Equal(suite.T(), response.CodePostNotFound, problem.Code)
+	assert.Equal(suite.T(), response.ProblemTypeBase+"POST_NOT_FOUND", problem.Type)
+}
+
+func (suite *PostHandlerTestSuite) TestLookupPostSuccess() {
+	expectedPost := suite.createMockPost()
+
+	suite.mockService.On("GetPostByURL", mock.Anything, "https://example.com/article?utm_source=x").Return(expectedPost, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/lookup?url="+url.QueryEscape("https://example.com/article?utm_source=x"), nil)
+
+	err := suite.handler.LookupPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.NotEmpty(suite.T(), rec.Header().Get("ETag"))
+}
+
+func (suite *PostHandlerTestSuite) TestLookupPostMissingURL() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/lookup", nil)
+
+	err := suite.handler.LookupPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), string(response.CodeMissingParameter))
+}
+
+func (suite *PostHandlerTestSuite) TestLookupPostInvalidURL() {
+	suite.mockService.On("GetPostByURL", mock.Anything, "not a url").Return(nil, service.ErrPostURLInvalid)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/lookup?url=not%20a%20url", nil)
+
+	err := suite.handler.LookupPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), string(response.CodeInvalidParameter))
+}
+
+func (suite *PostHandlerTestSuite) TestLookupPostNotFound() {
+	suite.mockService.On("GetPostByURL", mock.Anything, "https://example.com/missing").Return(nil, service.ErrPostNotFound)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/lookup?url=https://example.com/missing", nil)
+
+	err := suite.handler.LookupPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), string(response.CodePostNotFound))
 }
 
 func (suite *PostHandlerTestSuite) TestGetPostByIDInternalError() {
-	suite.mockService.On("GetPostByID", mock.Anything, int64(1)).Return(nil, errors.New("database error"))
+	suite.mockService.On("GetPostByID", mock.Anything, int64(1), mock.Anything).Return(nil, errors.New("database error"))
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/posts/1", nil)
 	c.SetParamNames("id")
@@ -387,6 +566,7 @@ func (suite *PostHandlerTestSuite) TestListPostsWithFilters() {
 			req.Source != nil && *req.Source == "test-source" &&
 			req.Search != nil && *req.Search == "test-query"
 	})).Return(mockResponse, nil)
+	suite.mockAnalytics.On("RecordSearch", mock.Anything, "test-query", int64(1), mock.Anything).Return(nil)
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/posts?category=technology&source=test-source&search=test-query", nil)
 
@@ -396,6 +576,270 @@ func (suite *PostHandlerTestSuite) TestListPostsWithFilters() {
 	assert.Equal(suite.T(), http.StatusOK, rec.Code)
 }
 
+func (suite *PostHandlerTestSuite) TestListPostsByAuthor() {
+	posts := []model.Post{*suite.createMockPost()}
+	mockResponse := suite.createMockPostListResponse(posts, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Author != nil && *req.Author == "Jane Doe"
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?author=Jane%20Doe", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsSafeMode() {
+	posts := []model.Post{*suite.createMockPost()}
+	mockResponse := suite.createMockPostListResponse(posts, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.SafeMode
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?safe_mode=true", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsInvalidSafeMode() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?safe_mode=maybe", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "ListPosts", mock.Anything, mock.Anything)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsSortPopular() {
+	posts := []model.Post{*suite.createMockPost()}
+	mockResponse := suite.createMockPostListResponse(posts, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Sort == model.PostSortPopular
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?sort=popular", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"sort":"popular"`)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsCollapse() {
+	posts := []model.Post{*suite.createMockPost()}
+	mockResponse := suite.createMockPostListResponse(posts, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Collapse
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?collapse=true", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"collapse":"true"`)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsInvalidCollapse() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?collapse=maybe", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "ListPosts", mock.Anything, mock.Anything)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsIncludeCounts() {
+	mockResponse := suite.createMockPostListResponse([]model.Post{*suite.createMockPost()}, 1)
+	mockResponse.Counts = &model.PostCounts{
+		Categories: []model.FacetCount{{Value: "technology", Count: 1}},
+		Sources:    []model.FacetCount{{Value: "Test Source", Count: 1}},
+	}
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.IncludeCounts
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?include_counts=true", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var body struct {
+		Data struct {
+			Meta struct {
+				Counts model.PostCounts `json:"counts"`
+			} `json:"meta"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(suite.T(), *mockResponse.Counts, body.Data.Meta.Counts)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsInvalidIncludeCounts() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?include_counts=maybe", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "ListPosts", mock.Anything, mock.Anything)
+}
+
+func (suite *PostHandlerTestSuite) TestDiscoverPostsSuccess() {
+	posts := []model.Post{*suite.createMockPost()}
+
+	suite.mockService.On("DiscoverPosts", mock.Anything, mock.MatchedBy(func(req *model.DiscoverPostsParams) bool {
+		return req.Limit == 5 && req.SafeMode
+	})).Return(posts, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/discover?limit=5&safe_mode=true", nil)
+
+	err := suite.handler.DiscoverPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var body struct {
+		Data []model.Post `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(suite.T(), body.Data, 1)
+}
+
+func (suite *PostHandlerTestSuite) TestDiscoverPostsDefaultLimit() {
+	suite.mockService.On("DiscoverPosts", mock.Anything, mock.MatchedBy(func(req *model.DiscoverPostsParams) bool {
+		return req.Limit == 10 && !req.SafeMode
+	})).Return([]model.Post{}, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/discover?limit=abc", nil)
+
+	err := suite.handler.DiscoverPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestDiscoverPostsInvalidSafeMode() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/discover?safe_mode=maybe", nil)
+
+	err := suite.handler.DiscoverPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "DiscoverPosts", mock.Anything, mock.Anything)
+}
+
+func (suite *PostHandlerTestSuite) TestDiscoverPostsInternalError() {
+	suite.mockService.On("DiscoverPosts", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/discover", nil)
+
+	err := suite.handler.DiscoverPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsWithoutCount() {
+	mockResponse := suite.createMockPostListResponse([]model.Post{*suite.createMockPost()}, 0)
+	mockResponse.Pagination = model.PeekPagination(1, 20, 1, true, 0, model.PostCountNone)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Count == model.PostCountNone
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?count=false", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var body struct {
+		Data struct {
+			Pagination response.PaginationInfo `json:"pagination"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.True(suite.T(), body.Data.Pagination.HasNext)
+	assert.Equal(suite.T(), 2, body.Data.Pagination.Total)
+	assert.Equal(suite.T(), "none", body.Data.Pagination.TotalMode)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsEstimatedCount() {
+	mockResponse := suite.createMockPostListResponse([]model.Post{*suite.createMockPost()}, 0)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Count == model.PostCountEstimate
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?count=estimate", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsEchoesQuery() {
+	mockResponse := suite.createMockPostListResponse([]model.Post{*suite.createMockPost()}, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.AnythingOfType("*model.PostListParams")).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?country=GB&author=%20Jane%20Doe%20", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var body struct {
+		Data struct {
+			Query response.QueryInfo `json:"query"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(suite.T(), map[string]string{"country": "gb", "author": "Jane Doe"}, body.Data.Query.Filters)
+	assert.Equal(suite.T(), model.PostSortLatest, body.Data.Query.Sort)
+	assert.False(suite.T(), body.Data.Query.CacheHit)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsInvalidCount() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?count=roughly", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "ListPosts", mock.Anything, mock.Anything)
+}
+
+func (suite *PostHandlerTestSuite) TestListPostsInvalidSort() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?sort=oldest", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "ListPosts", mock.Anything, mock.Anything)
+}
+
 func (suite *PostHandlerTestSuite) TestListPostsInternalError() {
 	suite.mockService.On("ListPosts", mock.Anything, mock.AnythingOfType("*model.PostListParams")).Return(nil, errors.New("database error"))
 
@@ -412,6 +856,61 @@ func (suite *PostHandlerTestSuite) TestListPostsInternalError() {
 	assert.False(suite.T(), response.Success)
 }
 
+func (suite *PostHandlerTestSuite) TestListPostsOnlyPublished() {
+	mockResponse := suite.createMockPostListResponse([]model.Post{*suite.createMockPost()}, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Status == model.PostStatusPublished
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts?status=draft", nil)
+
+	err := suite.handler.ListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestAdminListPostsDefaultsToAnyStatus() {
+	mockResponse := suite.createMockPostListResponse([]model.Post{*suite.createMockPost()}, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Status == model.PostStatusAny
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/admin/posts", nil)
+
+	err := suite.handler.AdminListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestAdminListPostsByStatus() {
+	mockResponse := suite.createMockPostListResponse([]model.Post{*suite.createMockPost()}, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Status == model.PostStatusDraft
+	})).Return(mockResponse, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/admin/posts?status=draft", nil)
+
+	err := suite.handler.AdminListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"status":"draft"`)
+}
+
+func (suite *PostHandlerTestSuite) TestAdminListPostsInvalidStatus() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/admin/posts?status=archived", nil)
+
+	err := suite.handler.AdminListPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
 func (suite *PostHandlerTestSuite) TestUpdatePostSuccess() {
 	req := suite.createMockUpdateParams()
 	expectedPost := suite.createMockPost()
@@ -435,6 +934,72 @@ func (suite *PostHandlerTestSuite) TestUpdatePostSuccess() {
 	assert.Equal(suite.T(), "Post updated successfully", response.Message)
 }
 
+func (suite *PostHandlerTestSuite) TestUpdatePostIfMatchOverridesBodyVersion() {
+	req := suite.createMockUpdateParams()
+	expectedPost := suite.createMockPost()
+	expectedPost.Version = 4
+
+	suite.mockService.On("UpdatePost", mock.Anything, int64(1), mock.MatchedBy(func(p *model.UpdatePostParams) bool {
+		return p.Version == 3
+	})).Return(expectedPost, nil)
+
+	c, rec := suite.createEchoContext(http.MethodPut, "/posts/1", req)
+	c.Request().Header.Set("If-Match", `W/"3"`)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := suite.handler.UpdatePost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), `"4"`, rec.Header().Get("ETag"))
+}
+
+func (suite *PostHandlerTestSuite) TestUpdatePostInvalidIfMatch() {
+	req := suite.createMockUpdateParams()
+
+	c, rec := suite.createEchoContext(http.MethodPut, "/posts/1", req)
+	c.Request().Header.Set("If-Match", "*")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := suite.handler.UpdatePost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestUpdatePostVersionMismatch() {
+	req := suite.createMockUpdateParams()
+
+	suite.mockService.On("UpdatePost", mock.Anything, int64(1), req).Return(nil, service.ErrPostVersionConflict)
+
+	c, rec := suite.createEchoContext(http.MethodPut, "/posts/1", req)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := suite.handler.UpdatePost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusPreconditionFailed, rec.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestUpdatePostVersionMissing() {
+	req := suite.createMockUpdateParams()
+	req.Version = 0
+
+	suite.mockService.On("UpdatePost", mock.Anything, int64(1), req).Return(nil, service.ErrPostVersionRequired)
+
+	c, rec := suite.createEchoContext(http.MethodPut, "/posts/1", req)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := suite.handler.UpdatePost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusPreconditionRequired, rec.Code)
+}
+
 func (suite *PostHandlerTestSuite) TestUpdatePostInvalidID() {
 	req := suite.createMockUpdateParams()
 
@@ -591,6 +1156,48 @@ func (suite *PostHandlerTestSuite) TestDeletePostInternalError() {
 	assert.False(suite.T(), response.Success)
 }
 
+func (suite *PostHandlerTestSuite) TestPublishPostSuccess() {
+	post := suite.createMockPost()
+	post.Status = model.PostStatusPublished
+
+	suite.mockService.On("PublishPost", mock.Anything, int64(1)).Return(post, nil)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/posts/1/publish", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := suite.handler.PublishPost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"status":"published"`)
+}
+
+func (suite *PostHandlerTestSuite) TestHidePostNotFound() {
+	suite.mockService.On("HidePost", mock.Anything, int64(999)).Return(nil, service.ErrPostNotFound)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/posts/999/hide", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("999")
+
+	err := suite.handler.HidePost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"POST_NOT_FOUND"`)
+}
+
+func (suite *PostHandlerTestSuite) TestHidePostInvalidID() {
+	c, rec := suite.createEchoContext(http.MethodPost, "/posts/abc/hide", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+
+	err := suite.handler.HidePost(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
 func (suite *PostHandlerTestSuite) TestGetPostsByCategorySuccess() {
 	posts := []model.Post{*suite.createMockPost()}
 	mockResponse := suite.createMockPostListResponse(posts, 1)
@@ -750,6 +1357,7 @@ func (suite *PostHandlerTestSuite) TestSearchPostsSuccess() {
 	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
 		return req.Search != nil && *req.Search == "test query"
 	})).Return(mockResponse, nil)
+	suite.mockAnalytics.On("RecordSearch", mock.Anything, "test query", int64(1), mock.Anything).Return(nil)
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/posts/search?q=test%20query", nil)
 
@@ -764,6 +1372,67 @@ func (suite *PostHandlerTestSuite) TestSearchPostsSuccess() {
 	assert.True(suite.T(), response.Success)
 }
 
+func (suite *PostHandlerTestSuite) TestSearchPostsReturnsFacets() {
+	mockResponse := suite.createMockPostListResponse([]model.Post{*suite.createMockPost()}, 1)
+	mockResponse.Facets = &model.SearchFacets{
+		Categories: []model.FacetCount{{Value: "technology", Count: 1}},
+		Sources:    []model.FacetCount{{Value: "Test Source", Count: 1}},
+		Days:       []model.FacetCount{{Value: "2025-08-11", Count: 1}},
+	}
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Facets && req.Search != nil && *req.Search == "golang"
+	})).Return(mockResponse, nil)
+	suite.mockAnalytics.On("RecordSearch", mock.Anything, "golang", int64(1), mock.Anything).Return(nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/search?q=golang", nil)
+
+	err := suite.handler.SearchPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var body struct {
+		Data struct {
+			Meta struct {
+				Facets model.SearchFacets `json:"facets"`
+			} `json:"meta"`
+		} `json:"data"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(suite.T(), *mockResponse.Facets, body.Data.Meta.Facets)
+}
+
+func (suite *PostHandlerTestSuite) TestSearchPostsHighlight() {
+	post := suite.createMockPost()
+	post.Highlight = &model.PostHighlight{Title: "<em>Golang</em> news"}
+	mockResponse := suite.createMockPostListResponse([]model.Post{*post}, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Highlight && req.Search != nil && *req.Search == "golang"
+	})).Return(mockResponse, nil)
+	suite.mockAnalytics.On("RecordSearch", mock.Anything, "golang", int64(1), mock.Anything).Return(nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/search?q=golang&highlight=true", nil)
+
+	err := suite.handler.SearchPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"highlight":"true"`)
+	assert.Contains(suite.T(), rec.Body.String(), `\u003cem\u003eGolang\u003c/em\u003e news`)
+}
+
+func (suite *PostHandlerTestSuite) TestSearchPostsInvalidHighlight() {
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/search?q=golang&highlight=maybe", nil)
+
+	err := suite.handler.SearchPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "ListPosts", mock.Anything, mock.Anything)
+}
+
 func (suite *PostHandlerTestSuite) TestSearchPostsEmptyQuery() {
 	c, rec := suite.createEchoContext(http.MethodGet, "/posts/search", nil)
 
@@ -804,6 +1473,24 @@ func (suite *PostHandlerTestSuite) TestSearchPostsWithFiltersAndPagination() {
 	assert.Equal(suite.T(), http.StatusOK, rec.Code)
 }
 
+func (suite *PostHandlerTestSuite) TestSearchPostsByCountry() {
+	posts := []model.Post{*suite.createMockPost()}
+	mockResponse := suite.createMockPostListResponse(posts, 1)
+
+	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
+		return req.Search != nil && *req.Search == "elections" &&
+			req.Country != nil && *req.Country == "gb"
+	})).Return(mockResponse, nil)
+	suite.mockAnalytics.On("RecordSearch", mock.Anything, "elections", int64(1), mock.Anything).Return(nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/posts/search?q=elections&country=GB", nil)
+
+	err := suite.handler.SearchPosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
 func (suite *PostHandlerTestSuite) TestSearchPostsInternalError() {
 	suite.mockService.On("ListPosts", mock.Anything, mock.AnythingOfType("*model.PostListParams")).Return(nil, errors.New("database error"))
 
@@ -862,6 +1549,7 @@ func (suite *PostHandlerTestSuite) TestSearchPostsSpecialCharacters() {
 	suite.mockService.On("ListPosts", mock.Anything, mock.MatchedBy(func(req *model.PostListParams) bool {
 		return req.Search != nil && *req.Search == searchQuery
 	})).Return(mockResponse, nil)
+	suite.mockAnalytics.On("RecordSearch", mock.Anything, mock.Anything, int64(1), mock.Anything).Return(nil)
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/posts/search?q="+url.QueryEscape(searchQuery), nil)
 
@@ -874,7 +1562,7 @@ func (suite *PostHandlerTestSuite) TestSearchPostsSpecialCharacters() {
 func (suite *PostHandlerTestSuite) TestMultipleConcurrentRequests() {
 	expectedPost := suite.createMockPost()
 
-	suite.mockService.On("GetPostByID", mock.Anything, int64(1)).Return(expectedPost, nil).Times(3)
+	suite.mockService.On("GetPostByID", mock.Anything, int64(1), mock.Anything).Return(expectedPost, nil).Times(3)
 
 	for i := 0; i < 3; i++ {
 		c, rec := suite.createEchoContext(http.MethodGet, "/posts/1", nil)
@@ -927,6 +1615,84 @@ func (suite *PostHandlerTestSuite) TestGetPostsBySourceWithSpecialCharacters() {
 	assert.Equal(suite.T(), http.StatusOK, rec.Code)
 }
 
+func (suite *PostHandlerTestSuite) TestBulkUpdatePostsSuccess() {
+	category := "technology"
+	status := model.PostStatusHidden
+	req := &model.BulkUpdatePostsParams{
+		Filter:  model.BulkPostFilter{Category: &category},
+		Changes: model.BulkPostChanges{Status: &status},
+	}
+
+	suite.mockService.On("BulkUpdatePosts", mock.Anything, req).Return(&model.BulkPostsResult{Affected: 12}, nil)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/admin/posts/bulk-update", req)
+
+	err := suite.handler.BulkUpdatePosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var response response.APIResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), response.Success)
+	assert.Equal(suite.T(), "Posts updated successfully", response.Message)
+	assert.EqualValues(suite.T(), 12, response.Data.(map[string]any)["affected"])
+}
+
+func (suite *PostHandlerTestSuite) TestBulkUpdatePostsEmptyFilter() {
+	category := "business"
+	req := &model.BulkUpdatePostsParams{Changes: model.BulkPostChanges{Category: &category}}
+
+	suite.mockService.On("BulkUpdatePosts", mock.Anything, req).Return(nil, service.ErrBulkFilterEmpty)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/admin/posts/bulk-update", req)
+
+	err := suite.handler.BulkUpdatePosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+
+	var response response.APIResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), response.Success)
+	assert.EqualValues(suite.T(), "MISSING_PARAMETER", response.Error.Code)
+}
+
+func (suite *PostHandlerTestSuite) TestBulkDeletePostsDryRun() {
+	req := &model.BulkDeletePostsParams{Filter: model.BulkPostFilter{IDs: []int64{4, 5}}, DryRun: true}
+
+	suite.mockService.On("BulkDeletePosts", mock.Anything, req).Return(&model.BulkPostsResult{Affected: 2, DryRun: true}, nil)
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/admin/posts/bulk-delete", req)
+
+	err := suite.handler.BulkDeletePosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var response response.APIResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	data := response.Data.(map[string]any)
+	assert.EqualValues(suite.T(), 2, data["affected"])
+	assert.Equal(suite.T(), true, data["dry_run"])
+}
+
+func (suite *PostHandlerTestSuite) TestBulkDeletePostsInternalError() {
+	req := &model.BulkDeletePostsParams{Filter: model.BulkPostFilter{IDs: []int64{4}}}
+
+	suite.mockService.On("BulkDeletePosts", mock.Anything, req).Return(nil, errors.New("database error"))
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/admin/posts/bulk-delete", req)
+
+	err := suite.handler.BulkDeletePosts(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+}
+
 // Run the test suite
 func TestPostHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(PostHandlerTestSuite))
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// reactionHandler implements ReactionHandler interface
+type reactionHandler struct {
+	reactionService service.ReactionService
+	logger          *logger.Logger
+}
+
+// NewReactionHandler creates a new reaction handler
+func NewReactionHandler(reactionService service.ReactionService, logger *logger.Logger) ReactionHandler {
+	return &reactionHandler{
+		reactionService: reactionService,
+		logger:          logger,
+	}
+}
+
+// React handles POST /api/v1/posts/:id/reactions
+// @Summary      React to a post
+// @Description  Record the client's reaction to a post. Until the API authenticates users, a client is the address it connects from; each client has one reaction per post, and reacting again with another type replaces it.
+// @Tags         reactions
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int                true  "Post ID"
+// @Param        reaction   body      model.ReactParams  true  "Reaction"
+// @Success      200        {object}  response.APIResponse{data=model.ReactionSummary}  "Reaction recorded"
+// @Failure      400        {object}  response.APIResponse{error=response.ErrorInfo}    "Invalid request"
+// @Failure      404        {object}  response.APIResponse{error=response.ErrorInfo}    "Post not found"
+// @Failure      500        {object}  response.APIResponse{error=response.ErrorInfo}    "Internal server error"
+// @Router       /posts/{id}/reactions [post]
+func (h *reactionHandler) React(c echo.Context) error {
+	start := time.Now()
+
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || postID <= 0 {
+		h.logger.LogServiceOperation("reaction_handler", "react", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	var req model.ReactParams
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("reaction_handler", "react", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("reaction_handler", "react", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	summary, err := h.reactionService.React(c.Request().Context(), postID, reactorID(c), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("reaction_handler", "react", false, time.Since(start).Milliseconds())
+		return h.reactionError(c, err, "Failed to record reaction")
+	}
+
+	h.logger.LogServiceOperation("reaction_handler", "react", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, summary, "Reaction recorded successfully")
+}
+
+// RemoveReaction handles DELETE /api/v1/posts/:id/reactions
+// @Summary      Remove a reaction
+// @Description  Remove the client's own reaction from a post
+// @Tags         reactions
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int     true  "Post ID"
+// @Success      204        {string}  string                                          "No Content"
+// @Failure      400        {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid ID"
+// @Failure      404        {object}  response.APIResponse{error=response.ErrorInfo}  "Reaction not found"
+// @Failure      500        {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /posts/{id}/reactions [delete]
+func (h *reactionHandler) RemoveReaction(c echo.Context) error {
+	start := time.Now()
+
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || postID <= 0 {
+		h.logger.LogServiceOperation("reaction_handler", "remove_reaction", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidPostID, "Invalid post ID")
+	}
+
+	if err := h.reactionService.RemoveReaction(c.Request().Context(), postID, reactorID(c)); err != nil {
+		h.logger.LogServiceOperation("reaction_handler", "remove_reaction", false, time.Since(start).Milliseconds())
+		return h.reactionError(c, err, "Failed to remove reaction")
+	}
+
+	h.logger.LogServiceOperation("reaction_handler", "remove_reaction", true, time.Since(start).Milliseconds())
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// reactorID identifies who reacts to a post. The API does not authenticate
+// users yet, so a caller-supplied user ID would let anyone remove another
+// user's reaction or inflate counts; the client address, found by the
+// server's IP extractor, is used instead.
+func reactorID(c echo.Context) string {
+	return "client:" + c.RealIP()
+}
+
+// reactionError maps reaction service errors to responses
+func (h *reactionHandler) reactionError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrReactionTypeInvalid):
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid reaction type", err.Error())
+	case errors.Is(err, service.ErrPostNotFound):
+		return response.NotFound(c, response.CodePostNotFound, "Post not found")
+	case errors.Is(err, service.ErrReactionNotFound):
+		return response.NotFound(c, response.CodeReactionNotFound, "Reaction not found")
+	}
+
+	return response.InternalServerError(c, message)
+}
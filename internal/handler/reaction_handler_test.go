@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockReactionService is a mock implementation of ReactionService
+type MockReactionService struct {
+	mock.Mock
+}
+
+func (m *MockReactionService) React(ctx context.Context, postID int64, userID string, req *model.ReactParams) (*model.ReactionSummary, error) {
+	args := m.Called(ctx, postID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReactionSummary), args.Error(1)
+}
+
+func (m *MockReactionService) RemoveReaction(ctx context.Context, postID int64, userID string) error {
+	args := m.Called(ctx, postID, userID)
+	return args.Error(0)
+}
+
+// ReactionHandlerTestSuite defines the test suite for ReactionHandler
+type ReactionHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockReactionService
+	handler     ReactionHandler
+	echo        *echo.Echo
+}
+
+func (suite *ReactionHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockReactionService)
+	suite.handler = NewReactionHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *ReactionHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+// newContext builds a reaction request from the address ip, claiming to be
+// user-1 in X-User-ID
+func (suite *ReactionHandlerTestSuite) newContext(method, ip, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/api/v1/posts/1/reactions", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-User-ID", "user-1")
+	req.RemoteAddr = ip + ":41234"
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	return c, rec
+}
+
+func (suite *ReactionHandlerTestSuite) TestReactSuccess() {
+	like := model.ReactionLike
+	summary := &model.ReactionSummary{PostID: 1, Type: &like, ReactionCount: 4, Reactions: map[string]int64{"like": 4}}
+
+	suite.mockService.On("React", mock.Anything, int64(1), "client:203.0.113.7", mock.MatchedBy(func(req *model.ReactParams) bool {
+		return req.Type == model.ReactionLike
+	})).Return(summary, nil)
+
+	c, rec := suite.newContext(http.MethodPost, "203.0.113.7", `{"type":"like"}`)
+
+	err := suite.handler.React(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"reaction_count":4`)
+}
+
+func (suite *ReactionHandlerTestSuite) TestReactIgnoresUserHeader() {
+	summary := &model.ReactionSummary{PostID: 1, ReactionCount: 1}
+	suite.mockService.On("React", mock.Anything, int64(1), "client:203.0.113.7", mock.Anything).Return(summary, nil).Twice()
+
+	for _, user := range []string{"user-1", "user-2"} {
+		c, rec := suite.newContext(http.MethodPost, "203.0.113.7", `{"type":"like"}`)
+		c.Request().Header.Set("X-User-ID", user)
+
+		assert.NoError(suite.T(), suite.handler.React(c))
+		assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	}
+}
+
+func (suite *ReactionHandlerTestSuite) TestRemoveReactionSuccess() {
+	suite.mockService.On("RemoveReaction", mock.Anything, int64(1), "client:203.0.113.7").Return(nil)
+
+	c, rec := suite.newContext(http.MethodDelete, "203.0.113.7", "")
+
+	err := suite.handler.RemoveReaction(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNoContent, rec.Code)
+}
+
+func (suite *ReactionHandlerTestSuite) TestRemoveReactionNotFound() {
+	suite.mockService.On("RemoveReaction", mock.Anything, int64(1), "client:198.51.100.2").Return(service.ErrReactionNotFound)
+
+	c, rec := suite.newContext(http.MethodDelete, "198.51.100.2", "")
+
+	err := suite.handler.RemoveReaction(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"REACTION_NOT_FOUND"`)
+}
+
+func TestReactionHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReactionHandlerTestSuite))
+}
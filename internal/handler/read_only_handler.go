@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// readOnlyPath is the admin route toggling read-only mode, which stays open
+// in read-only mode so it can be left
+const readOnlyPath = APIBasePath + "/admin/read-only"
+
+// readOnlyHandler implements ReadOnlyHandler interface
+type readOnlyHandler struct {
+	readOnlyService service.ReadOnlyService
+	logger          *logger.Logger
+}
+
+// NewReadOnlyHandler creates a new read-only mode handler
+func NewReadOnlyHandler(readOnlyService service.ReadOnlyService, logger *logger.Logger) ReadOnlyHandler {
+	return &readOnlyHandler{
+		readOnlyService: readOnlyService,
+		logger:          logger,
+	}
+}
+
+// GetReadOnly handles GET /api/v1/admin/read-only
+// @Summary      Get read-only mode
+// @Description  Report whether this instance is in read-only mode, and why and since when
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=model.ReadOnlyStatus}  "Read-only mode"
+// @Router       /admin/read-only [get]
+func (h *readOnlyHandler) GetReadOnly(c echo.Context) error {
+	return response.Success(c, http.StatusOK, h.readOnlyService.Status())
+}
+
+// EnableReadOnly handles PUT /api/v1/admin/read-only
+// @Summary      Enter read-only mode
+// @Description  Put this instance in read-only mode: every mutating request but this route's gets 503 READ_ONLY and scheduled jobs are paused, while reads are served as usual. Other instances are not affected.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.EnableReadOnlyRequest  false  "Reason for entering read-only mode"
+// @Success      200      {object}  response.APIResponse{data=model.ReadOnlyStatus}  "Read-only mode enabled"
+// @Failure      400      {object}  response.APIResponse{error=response.ErrorInfo}   "Invalid request body or reason"
+// @Router       /admin/read-only [put]
+func (h *readOnlyHandler) EnableReadOnly(c echo.Context) error {
+	start := time.Now()
+
+	var req model.EnableReadOnlyRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("read_only_handler", "enable_read_only", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("read_only_handler", "enable_read_only", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	status := h.readOnlyService.Enable(req.Reason)
+
+	h.logger.LogServiceOperation("read_only_handler", "enable_read_only", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, status, "Read-only mode enabled")
+}
+
+// DisableReadOnly handles DELETE /api/v1/admin/read-only
+// @Summary      Leave read-only mode
+// @Description  Accept mutating requests on this instance again and resume the scheduled jobs read-only mode paused
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=model.ReadOnlyStatus}  "Read-only mode disabled"
+// @Router       /admin/read-only [delete]
+func (h *readOnlyHandler) DisableReadOnly(c echo.Context) error {
+	status := h.readOnlyService.Disable()
+	h.logger.Info("Read-only mode disable requested via API")
+
+	return response.Success(c, http.StatusOK, status, "Read-only mode disabled")
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/amirzre/news-feed-system/pkg/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockReadOnlyService is a mock implementation of ReadOnlyService
+type MockReadOnlyService struct {
+	mock.Mock
+}
+
+func (m *MockReadOnlyService) Enabled() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+func (m *MockReadOnlyService) Status() *model.ReadOnlyStatus {
+	args := m.Called()
+	return args.Get(0).(*model.ReadOnlyStatus)
+}
+
+func (m *MockReadOnlyService) Enable(reason string) *model.ReadOnlyStatus {
+	args := m.Called(reason)
+	return args.Get(0).(*model.ReadOnlyStatus)
+}
+
+func (m *MockReadOnlyService) Disable() *model.ReadOnlyStatus {
+	args := m.Called()
+	return args.Get(0).(*model.ReadOnlyStatus)
+}
+
+// ReadOnlyHandlerTestSuite defines the test suite for ReadOnlyHandler
+type ReadOnlyHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockReadOnlyService
+	handler     ReadOnlyHandler
+	echo        *echo.Echo
+}
+
+func (suite *ReadOnlyHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockReadOnlyService)
+	suite.handler = NewReadOnlyHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = validator.NewValidator()
+}
+
+func (suite *ReadOnlyHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *ReadOnlyHandlerTestSuite) createEchoContext(method, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, readOnlyPath, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+
+	rec := httptest.NewRecorder()
+	return suite.echo.NewContext(req, rec), rec
+}
+
+func (suite *ReadOnlyHandlerTestSuite) TestGetReadOnly() {
+	since := time.Now().UTC()
+	suite.mockService.On("Status").Return(&model.ReadOnlyStatus{Enabled: true, Reason: "failover", Since: &since})
+
+	c, rec := suite.createEchoContext(http.MethodGet, "")
+
+	err := suite.handler.GetReadOnly(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var body response.APIResponse
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(suite.T(), "failover", body.Data.(map[string]any)["reason"])
+}
+
+func (suite *ReadOnlyHandlerTestSuite) TestEnableReadOnly() {
+	suite.mockService.On("Enable", "failover").Return(&model.ReadOnlyStatus{Enabled: true, Reason: "failover"})
+
+	c, rec := suite.createEchoContext(http.MethodPut, `{"reason":"failover"}`)
+
+	err := suite.handler.EnableReadOnly(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var body response.APIResponse
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(suite.T(), true, body.Data.(map[string]any)["enabled"])
+}
+
+func (suite *ReadOnlyHandlerTestSuite) TestEnableReadOnlyWithoutBody() {
+	suite.mockService.On("Enable", "").Return(&model.ReadOnlyStatus{Enabled: true})
+
+	c, rec := suite.createEchoContext(http.MethodPut, "")
+
+	err := suite.handler.EnableReadOnly(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *ReadOnlyHandlerTestSuite) TestEnableReadOnlyReasonTooLong() {
+	c, rec := suite.createEchoContext(http.MethodPut, `{"reason":"`+strings.Repeat("x", 201)+`"}`)
+
+	err := suite.handler.EnableReadOnly(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "Enable", mock.Anything)
+}
+
+func (suite *ReadOnlyHandlerTestSuite) TestDisableReadOnly() {
+	suite.mockService.On("Disable").Return(&model.ReadOnlyStatus{})
+
+	c, rec := suite.createEchoContext(http.MethodDelete, "")
+
+	err := suite.handler.DisableReadOnly(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var body response.APIResponse
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(suite.T(), false, body.Data.(map[string]any)["enabled"])
+}
+
+func TestReadOnlyHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReadOnlyHandlerTestSuite))
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// ReadOnly turns away every request but GET, HEAD and OPTIONS with a 503
+// while the API is in read-only mode, and tags the context of those it lets
+// through so that the views, clicks and searches they would record are
+// skipped. The read-only admin route is let through so the mode can be left.
+func ReadOnly(mode service.ReadOnlyService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !mode.Enabled() {
+				return next(c)
+			}
+
+			c.SetRequest(c.Request().WithContext(service.WithReadOnly(c.Request().Context())))
+
+			if c.Request().URL.Path == readOnlyPath {
+				return next(c)
+			}
+
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(c)
+			}
+
+			return response.Error(c, http.StatusServiceUnavailable, response.CodeReadOnly, "Service is read-only")
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReadOnlyEcho serves the posts route and the read-only admin route behind
+// ReadOnly, in read-only mode when enabled is set
+func newReadOnlyEcho(enabled bool) *echo.Echo {
+	mode := new(MockReadOnlyService)
+	mode.On("Enabled").Return(enabled)
+
+	e := echo.New()
+	e.Use(ReadOnly(mode))
+	e.Any(APIBasePath+"/posts", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.Any(readOnlyPath, func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	return e
+}
+
+func TestReadOnlyAllowsReads(t *testing.T) {
+	e := newReadOnlyEcho(true)
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, APIBasePath+"/posts", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code, method)
+	}
+}
+
+func TestReadOnlyTagsReads(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		mode := new(MockReadOnlyService)
+		mode.On("Enabled").Return(enabled)
+
+		var tagged bool
+		e := echo.New()
+		e.Use(ReadOnly(mode))
+		e.GET(APIBasePath+"/posts", func(c echo.Context) error {
+			tagged = service.ReadOnlyFromContext(c.Request().Context())
+			return c.NoContent(http.StatusOK)
+		})
+
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, APIBasePath+"/posts", nil))
+
+		assert.Equal(t, enabled, tagged)
+	}
+}
+
+func TestReadOnlyRejectsWrites(t *testing.T) {
+	e := newReadOnlyEcho(true)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, APIBasePath+"/posts", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code, method)
+
+		var body response.APIResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, response.CodeReadOnly, body.Error.Code)
+	}
+}
+
+func TestReadOnlyLetsTheToggleThrough(t *testing.T) {
+	e := newReadOnlyEcho(true)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, readOnlyPath, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestReadOnlyWhenDisabled(t *testing.T) {
+	e := newReadOnlyEcho(false)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, APIBasePath+"/posts", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
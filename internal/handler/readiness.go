@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/labstack/echo/v4"
+)
+
+// ReadyPath is where load balancers and orchestrators ask whether the server
+// should receive traffic
+const ReadyPath = "/readyz"
+
+// Ready reports whether the server can take traffic, checking the schema
+// version of the database on every call: a migration run after startup can
+// make it incompatible. The server is not ready when the database is
+// unreachable, or when the schema is incompatible, cfg.OnMismatch is refuse
+// and the server is not in read-only mode.
+func Ready(schema func(ctx context.Context) (*model.SchemaStatus, error), cfg config.SchemaConfig, readOnly func() bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		readiness := model.Readiness{ReadOnly: readOnly()}
+
+		status, err := schema(c.Request().Context())
+		if err != nil {
+			readiness.Error = "database unavailable"
+			return c.JSON(http.StatusServiceUnavailable, readiness)
+		}
+
+		readiness.Schema = status
+		readiness.Ready = status.Compatible || readiness.ReadOnly || cfg.OnMismatch == config.SchemaMismatchIgnore
+		if !readiness.Ready {
+			return c.JSON(http.StatusServiceUnavailable, readiness)
+		}
+
+		return c.JSON(http.StatusOK, readiness)
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveReady asks a Ready handler built from status and err whether the server is ready
+func serveReady(t *testing.T, status *model.SchemaStatus, err error, cfg config.SchemaConfig, readOnly bool) (int, model.Readiness) {
+	enabled := func() bool { return readOnly }
+	schema := func(context.Context) (*model.SchemaStatus, error) { return status, err }
+
+	e := echo.New()
+	e.GET(ReadyPath, Ready(schema, cfg, enabled))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+
+	var readiness model.Readiness
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &readiness))
+
+	return rec.Code, readiness
+}
+
+func TestReadyWithCompatibleSchema(t *testing.T) {
+	status := &model.SchemaStatus{ExpectedVersion: 29, CurrentVersion: 29, Compatible: true}
+
+	code, readiness := serveReady(t, status, nil, config.SchemaConfig{OnMismatch: config.SchemaMismatchRefuse}, false)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, readiness.Ready)
+	assert.Equal(t, status, readiness.Schema)
+}
+
+func TestReadyWithIncompatibleSchema(t *testing.T) {
+	status := &model.SchemaStatus{ExpectedVersion: 29, CurrentVersion: 30}
+
+	code, readiness := serveReady(t, status, nil, config.SchemaConfig{OnMismatch: config.SchemaMismatchRefuse}, false)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, int64(30), readiness.Schema.CurrentVersion)
+
+	code, readiness = serveReady(t, status, nil, config.SchemaConfig{OnMismatch: config.SchemaMismatchReadOnly}, true)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, readiness.ReadOnly)
+
+	code, _ = serveReady(t, status, nil, config.SchemaConfig{OnMismatch: config.SchemaMismatchIgnore}, false)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestReadyWithoutDatabase(t *testing.T) {
+	code, readiness := serveReady(t, nil, errors.New("connection refused"), config.SchemaConfig{OnMismatch: config.SchemaMismatchIgnore}, false)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, "database unavailable", readiness.Error)
+	assert.Nil(t, readiness.Schema)
+}
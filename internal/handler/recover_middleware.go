@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// Recover turns panics in later handlers into 500 responses. The panic is
+// logged with its stack trace and, when error reporting is enabled, reported
+// together with the request; errors logged with the request context are
+// reported with the request too.
+func Recover(log *logger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			req := c.Request()
+			c.SetRequest(req.WithContext(log.RequestContext(req.Context(), req)))
+
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				// The server aborts the response on this sentinel by design
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				log.LogPanic(c.Request().Context(), recovered)
+				c.Error(fmt.Errorf("panic: %v", recovered))
+				err = nil
+			}()
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPanickingEcho(log *logger.Logger) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = response.HTTPErrorHandler
+	e.Use(Recover(log))
+	e.GET("/panic", func(c echo.Context) error {
+		panic("boom")
+	})
+
+	return e
+}
+
+func TestRecoverRespondsWithInternalServerError(t *testing.T) {
+	var buf bytes.Buffer
+	log := &logger.Logger{Logger: slog.New(slog.NewJSONHandler(&buf, nil))}
+
+	rec := httptest.NewRecorder()
+	newPanickingEcho(log).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	entries := logEntries(t, &buf)
+	require.NotEmpty(t, entries)
+	assert.Equal(t, "Recovered from panic", entries[0]["msg"])
+	assert.Equal(t, "boom", entries[0]["panic"])
+	assert.Contains(t, entries[0]["stack"], "recover_middleware_test.go")
+}
+
+func TestRecoverReportsPanicWithRequest(t *testing.T) {
+	var mu sync.Mutex
+	var envelopes []string
+	sentry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		envelopes = append(envelopes, string(body))
+		mu.Unlock()
+	}))
+	defer sentry.Close()
+
+	dsn := strings.Replace(sentry.URL, "http://", "http://public@", 1) + "/1"
+	log := logger.New(&config.Config{
+		App: config.AppConfig{Environment: "staging", LogLevel: "error"},
+		ErrorReporting: config.ErrorReportingConfig{
+			DSN:          dsn,
+			SampleRate:   1,
+			FlushTimeout: 5 * time.Second,
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	newPanickingEcho(log).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic?page=1", nil))
+	require.NoError(t, log.Close())
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, envelopes, 1)
+	assert.Contains(t, envelopes[0], `"environment":"staging"`)
+	assert.Contains(t, envelopes[0], `"value":"boom"`)
+	assert.Contains(t, envelopes[0], "/panic")
+	assert.Contains(t, envelopes[0], "recover_middleware_test.go")
+}
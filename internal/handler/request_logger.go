@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// redactedValue replaces the values of redacted fields in logged bodies
+const redactedValue = "[REDACTED]"
+
+// RequestLogger logs every request that cfg samples with its method, path,
+// status, latency, request ID, user agent and response size. Failed and slow
+// requests are always logged. When the logger is at the debug level, JSON
+// request and response bodies are logged as well, with the values of
+// cfg.RedactFields masked at any depth.
+//
+// The request ID is read from the X-Request-ID response header, so the
+// middleware that assigns it must run first.
+func RequestLogger(cfg config.RequestLogConfig, log *logger.Logger) echo.MiddlewareFunc {
+	redact := make(map[string]struct{}, len(cfg.RedactFields))
+	for _, field := range cfg.RedactFields {
+		redact[strings.ToLower(field)] = struct{}{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+			res := c.Response()
+
+			// Bodies are captured only while the level is debug, which can
+			// change on a configuration reload
+			logBodies := cfg.MaxBodySize > 0 && log.Enabled(req.Context(), slog.LevelDebug)
+
+			var requestBody []byte
+			var capture *bodyCapture
+			if logBodies {
+				if req.Body != nil && req.Body != http.NoBody {
+					body, err := io.ReadAll(req.Body)
+					if err != nil {
+						return err
+					}
+					requestBody = body
+					req.Body = io.NopCloser(bytes.NewReader(body))
+				}
+
+				capture = &bodyCapture{ResponseWriter: res.Writer, limit: cfg.MaxBodySize}
+				res.Writer = capture
+			}
+
+			// Let the error handler write the response so its status is logged
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			duration := time.Since(start)
+			if res.Status < http.StatusBadRequest && duration < cfg.SlowThreshold && !sampled(cfg.SampleRate) {
+				return nil
+			}
+
+			entry := logger.HTTPRequest{
+				Method:       req.Method,
+				Path:         req.URL.Path,
+				Status:       res.Status,
+				Duration:     duration,
+				RequestID:    res.Header().Get(echo.HeaderXRequestID),
+				UserAgent:    req.UserAgent(),
+				ResponseSize: res.Size,
+			}
+			if logBodies {
+				entry.RequestBody = loggableBody(requestBody, int64(len(requestBody)), cfg.MaxBodySize, redact)
+				entry.ResponseBody = loggableBody(capture.body.Bytes(), res.Size, cfg.MaxBodySize, redact)
+			}
+
+			log.LogHTTPRequest(entry)
+
+			return nil
+		}
+	}
+}
+
+// sampled reports whether a request falls within the sample rate
+func sampled(rate float64) bool {
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
+// loggableBody renders a body of size bytes, of which body holds the first
+// limit, with redacted fields masked. Bodies that are not JSON or exceed limit
+// are described instead of logged, since they cannot be redacted.
+func loggableBody(body []byte, size int64, limit int, redact map[string]struct{}) string {
+	if size == 0 {
+		return ""
+	}
+	if size > int64(limit) {
+		return fmt.Sprintf("[%d bytes omitted]", size)
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("[%d bytes of non-JSON body omitted]", size)
+	}
+
+	redacted, err := json.Marshal(redactValue(value, redact))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes omitted]", size)
+	}
+
+	return string(redacted)
+}
+
+// redactValue masks the values of object fields named in redact
+func redactValue(value any, redact map[string]struct{}) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if _, ok := redact[strings.ToLower(key)]; ok {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(field, redact)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, redact)
+		}
+	}
+
+	return value
+}
+
+// bodyCapture keeps the first limit bytes written to a response
+type bodyCapture struct {
+	http.ResponseWriter
+	body  bytes.Buffer
+	limit int
+}
+
+func (w *bodyCapture) Write(b []byte) (int, error) {
+	if room := w.limit - w.body.Len(); room > 0 {
+		w.body.Write(b[:min(room, len(b))])
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController, so
+// flushing keeps working through the capture
+func (w *bodyCapture) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRequestLoggerEcho serves /echo, which returns the JSON body it receives,
+// and /fail, which fails, behind RequestLogger writing JSON lines at level
+func newRequestLoggerEcho(cfg config.RequestLogConfig, level slog.Level) (*echo.Echo, *bytes.Buffer) {
+	var buf bytes.Buffer
+	log := &logger.Logger{Logger: slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level}))}
+
+	e := echo.New()
+	e.HTTPErrorHandler = response.HTTPErrorHandler
+	e.Use(middleware.RequestID())
+	e.Use(RequestLogger(cfg, log))
+
+	e.POST("/echo", func(c echo.Context) error {
+		var body map[string]any
+		if err := c.Bind(&body); err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, body)
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "down")
+	})
+	e.GET("/ok", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	return e, &buf
+}
+
+// logEntries decodes the JSON log lines written to buf
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestRequestLoggerLogsRequestFields(t *testing.T) {
+	e, buf := newRequestLoggerEcho(config.RequestLogConfig{SampleRate: 1, SlowThreshold: time.Second}, slog.LevelInfo)
+
+	req := httptest.NewRequest(http.MethodGet, "/ok?page=2", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-123")
+	req.Header.Set("User-Agent", "integration/1.0")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	entries := logEntries(t, buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "INFO", entries[0]["level"])
+	assert.Equal(t, "GET", entries[0]["method"])
+	assert.Equal(t, "/ok", entries[0]["path"])
+	assert.EqualValues(t, http.StatusOK, entries[0]["status"])
+	assert.Equal(t, "req-123", entries[0]["requestID"])
+	assert.Equal(t, "integration/1.0", entries[0]["userAgent"])
+	assert.EqualValues(t, 2, entries[0]["responseSize"])
+	assert.NotContains(t, entries[0], "requestBody")
+}
+
+func TestRequestLoggerSampling(t *testing.T) {
+	e, buf := newRequestLoggerEcho(config.RequestLogConfig{SampleRate: 0, SlowThreshold: time.Second}, slog.LevelInfo)
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Empty(t, logEntries(t, buf), "successful requests outside the sample are not logged")
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	entries := logEntries(t, buf)
+	require.Len(t, entries, 1, "failed requests are always logged")
+	assert.Equal(t, "ERROR", entries[0]["level"])
+	assert.EqualValues(t, http.StatusServiceUnavailable, entries[0]["status"])
+	assert.NotEmpty(t, entries[0]["requestID"])
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestRequestLoggerLogsRedactedBodiesAtDebug(t *testing.T) {
+	cfg := config.RequestLogConfig{SampleRate: 1, SlowThreshold: time.Second, MaxBodySize: 1024, RedactFields: []string{"password"}}
+	e, buf := newRequestLoggerEcho(cfg, slog.LevelDebug)
+
+	body := `{"user":"amir","Password":"hunter2","nested":{"password":"x"}}`
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// The handler still sees the original body
+	assert.Contains(t, rec.Body.String(), "hunter2")
+
+	entries := logEntries(t, buf)
+	require.Len(t, entries, 1)
+	for _, key := range []string{"requestBody", "responseBody"} {
+		logged, ok := entries[0][key].(string)
+		require.True(t, ok, "%s is logged", key)
+		assert.NotContains(t, logged, "hunter2")
+		assert.Contains(t, logged, `"user":"amir"`)
+		assert.Contains(t, logged, `"nested":{"password":"[REDACTED]"}`)
+	}
+}
+
+func TestRequestLoggerOmitsLargeAndNonJSONBodies(t *testing.T) {
+	cfg := config.RequestLogConfig{SampleRate: 1, SlowThreshold: time.Second, MaxBodySize: 16}
+	e, buf := newRequestLoggerEcho(cfg, slog.LevelDebug)
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"title":"a long enough title"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+
+	entries := logEntries(t, buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "[31 bytes omitted]", entries[0]["requestBody"])
+	assert.Equal(t, "[2 bytes of non-JSON body omitted]", entries[1]["responseBody"])
+}
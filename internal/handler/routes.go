@@ -5,25 +5,55 @@ import (
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
+// APIBasePath is the prefix of every versioned API route
+const APIBasePath = "/api/v1"
+
+// swaggerPathPrefix is the prefix of the Swagger UI and spec routes
+const swaggerPathPrefix = "/swagger/"
+
 // SetupRoutes configures all API routes
 func SetupRoutes(e *echo.Echo, h *Handler) {
-	// Swagger UI
-	e.GET("/swagger/*", echoSwagger.WrapHandler)
+	// Swagger UI and the generated spec at /swagger/doc.json
+	e.GET(swaggerPathPrefix+"*", echoSwagger.WrapHandler)
+
+	// Click-through redirect
+	e.GET("/r/:id", h.Analytics.RedirectToPost)
+
+	// Syndication feeds and sitemap
+	e.GET("/rss", h.Syndication.GetFeed)
+	e.GET("/rss/:category", h.Syndication.GetCategoryFeed)
+	e.GET("/sitemap.xml", h.Syndication.GetSitemap)
 
 	// API v1 routes
-	api := e.Group("/api/v1")
+	api := e.Group(APIBasePath)
 
 	// Post routes
 	posts := api.Group("/posts")
 	posts.GET("", h.Post.ListPosts)
 	posts.POST("", h.Post.CreatePost)
+	posts.GET("/lookup", h.Post.LookupPost)
+	posts.GET("/discover", h.Post.DiscoverPosts)
+	posts.GET("/changes", h.Change.ListPostChanges)
 	posts.GET("/:id", h.Post.GetPostByID)
 	posts.PUT("/:id", h.Post.UpdatePost)
 	posts.DELETE("/:id", h.Post.DeletePost)
+	posts.POST("/:id/publish", h.Post.PublishPost)
+	posts.POST("/:id/hide", h.Post.HidePost)
+	posts.POST("/:id/pin", h.Pin.PinPost)
+	posts.DELETE("/:id/pin", h.Pin.UnpinPost)
 
 	posts.GET("/category/:category", h.Post.GetPostsByCategory)
 	posts.GET("/source/:source", h.Post.GetPostsBySource)
 	posts.GET("/search", h.Post.SearchPosts)
+	posts.POST("/:id/click", h.Analytics.RecordClick)
+	posts.GET("/:id/comments", h.Comment.ListComments)
+	posts.POST("/:id/comments", h.Comment.CreateComment)
+	posts.DELETE("/:id/comments/:comment_id", h.Comment.DeleteComment)
+	posts.POST("/:id/reactions", h.Reaction.React)
+	posts.DELETE("/:id/reactions", h.Reaction.RemoveReaction)
+
+	// Topic routes
+	api.GET("/topics", h.Topic.ListTopics)
 
 	// Aggregation routes
 	aggregation := api.Group("/aggregation")
@@ -32,9 +62,68 @@ func SetupRoutes(e *echo.Echo, h *Handler) {
 	aggregation.POST("/trigger/categories", h.Aggregator.TriggerCategoryAggregation)
 	aggregation.POST("/trigger/sources", h.Aggregator.TriggerSourceAggregation)
 
+	// Upstream news provider routes
+	api.GET("/news/sources", h.News.ListSources)
+
 	// Scheduler routes
 	scheduler := api.Group("/scheduler")
 	scheduler.GET("/status", h.Scheduler.GetStatus)
 	scheduler.GET("/jobs", h.Scheduler.GetJobs)
 	scheduler.POST("/jobs/:name/trigger", h.Scheduler.TriggerJob)
+	scheduler.GET("/jobs/:name/history", h.Scheduler.GetJobHistory)
+	scheduler.POST("/pause", h.Scheduler.PauseScheduler)
+	scheduler.POST("/resume", h.Scheduler.ResumeScheduler)
+	scheduler.POST("/jobs/:name/enable", h.Scheduler.EnableJob)
+	scheduler.POST("/jobs/:name/disable", h.Scheduler.DisableJob)
+	scheduler.GET("/once-jobs", h.Scheduler.GetOnceJobs)
+	scheduler.DELETE("/once-jobs/:id", h.Scheduler.CancelOnceJob)
+
+	// Feed routes
+	feed := api.Group("/feed")
+	feed.GET("/ranked", h.Feed.GetRankedFeed)
+
+	// Analytics routes
+	analytics := api.Group("/analytics")
+	analytics.GET("/ctr", h.Analytics.GetCTRStats)
+
+	// Statistics routes
+	stats := api.Group("/stats")
+	stats.GET("/posts", h.Analytics.GetPostStats)
+	stats.GET("/top", h.Stats.GetTopStats)
+
+	// Experiment routes
+	experiments := api.Group("/experiments")
+	experiments.POST("/events", h.Experiment.RecordEvent)
+
+	// Admin routes
+	admin := api.Group("/admin")
+	admin.GET("/feed/ranking", h.Feed.GetRankingWeights)
+	admin.PUT("/feed/ranking", h.Feed.UpdateRankingWeights)
+	admin.GET("/experiments", h.Experiment.ListExperiments)
+	admin.PUT("/experiments/:name", h.Experiment.UpsertExperiment)
+	admin.DELETE("/experiments/:name", h.Experiment.DeleteExperiment)
+	admin.GET("/experiments/:name/results", h.Experiment.GetExperimentResults)
+	admin.GET("/quarantine", h.Filter.ListQuarantined)
+	admin.GET("/dead-letters", h.DeadLetter.ListDeadLetters)
+	admin.POST("/dead-letters/:id/retry", h.DeadLetter.RetryDeadLetter)
+	admin.DELETE("/dead-letters/:id", h.DeadLetter.DiscardDeadLetter)
+	admin.GET("/search-analytics", h.Analytics.GetSearchAnalytics)
+	admin.GET("/dead-jobs", h.Scheduler.GetDeadJobs)
+	admin.POST("/dead-jobs/:id/retry", h.Scheduler.RetryDeadJob)
+	admin.DELETE("/dead-jobs/:id", h.Scheduler.DeleteDeadJob)
+	admin.GET("/posts", h.Post.AdminListPosts)
+	admin.POST("/posts/bulk-update", h.Post.BulkUpdatePosts)
+	admin.POST("/posts/bulk-delete", h.Post.BulkDeletePosts)
+	admin.POST("/posts/reprocess", h.Content.ReprocessPosts)
+	admin.GET("/posts/reprocess/:id", h.Content.GetReprocessRun)
+	admin.POST("/articles/preview", h.Content.PreviewArticle)
+	admin.GET("/tenants", h.Tenant.ListTenants)
+	admin.PUT("/tenants/:id", h.Tenant.UpsertTenant)
+	admin.GET("/source-rules", h.SourceRule.ListSourceRules)
+	admin.PUT("/source-rules", h.SourceRule.SaveSourceRule)
+	admin.DELETE("/source-rules/:id", h.SourceRule.DeleteSourceRule)
+	admin.POST("/config/reload", h.Config.ReloadConfig)
+	admin.GET("/read-only", h.ReadOnly.GetReadOnly)
+	admin.PUT("/read-only", h.ReadOnly.EnableReadOnly)
+	admin.DELETE("/read-only", h.ReadOnly.DisableReadOnly)
 }
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggo/swag"
+)
+
+// moduleRoot is the repository root relative to this package
+const moduleRoot = "../.."
+
+var echoParam = regexp.MustCompile(`:(\w+)`)
+
+// loadSpecPaths reads the committed swagger.json and returns its operations as "METHOD /path"
+func loadSpecPaths(t *testing.T) map[string]bool {
+	data, err := os.ReadFile(filepath.Join(moduleRoot, "docs", "swagger.json"))
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(data, &spec))
+
+	operations := make(map[string]bool)
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			operations[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	return operations
+}
+
+func TestRoutesMatchSwaggerSpec(t *testing.T) {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	e := echo.New()
+	SetupRoutes(e, New(&service.Service{}, logger.New(cfg)))
+
+	documented := loadSpecPaths(t)
+	routed := make(map[string]bool)
+
+	for _, route := range e.Routes() {
+		if strings.HasPrefix(route.Path, "/swagger") {
+			continue
+		}
+
+		path := echoParam.ReplaceAllString(strings.TrimPrefix(route.Path, APIBasePath), "{$1}")
+		operation := route.Method + " " + path
+		routed[operation] = true
+
+		assert.True(t, documented[operation], "route %s has no swagger annotation", operation)
+	}
+
+	for operation := range documented {
+		assert.True(t, routed[operation], "documented operation %s is not routed", operation)
+	}
+}
+
+func TestUnknownRouteReturnsErrorCode(t *testing.T) {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	e := echo.New()
+	e.HTTPErrorHandler = response.HTTPErrorHandler
+	SetupRoutes(e, New(&service.Service{}, logger.New(cfg)))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIBasePath+"/does-not-exist", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var body response.APIResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.NotNil(t, body.Error)
+	assert.Equal(t, response.CodeNotFound, body.Error.Code)
+}
+
+func TestSwaggerSpecUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("parsing the module for swagger annotations is slow")
+	}
+
+	parser := swag.New(swag.ParseUsingGoList(true))
+	parser.PropNamingStrategy = swag.CamelCase
+	require.NoError(t, parser.ParseAPI(moduleRoot, "cmd/server/main.go", 100))
+
+	generated, err := json.Marshal(parser.GetSwagger())
+	require.NoError(t, err)
+
+	committed, err := os.ReadFile(filepath.Join(moduleRoot, "docs", "swagger.json"))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(committed), string(generated), "docs are stale, run make docs")
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -40,6 +41,7 @@ func (h *schedulerHandler) GetStatus(c echo.Context) error {
 
 	statusData := model.SchedulerStatusResponse{
 		SchedulerRunning: isRunning,
+		SchedulerPaused:  h.schedulerService.IsPaused(),
 		JobsCount:        len(jobStatus),
 		Timestamp:        time.Now(),
 		Jobs:             jobStatus,
@@ -88,7 +90,7 @@ func (h *schedulerHandler) TriggerJob(c echo.Context) error {
 	jobName := c.Param("name")
 	if jobName == "" {
 		h.logger.LogServiceOperation("scheduler_handler", "trigger_job", false, time.Since(start).Milliseconds())
-		return response.BadRequest(c, "Job name is required")
+		return response.BadRequest(c, response.CodeMissingParameter, "Job name is required")
 	}
 
 	jobStatus := h.schedulerService.GetJobStatus()
@@ -96,12 +98,12 @@ func (h *schedulerHandler) TriggerJob(c echo.Context) error {
 	if !exists {
 		h.logger.LogServiceOperation("scheduler_handler", "trigger_job", false, time.Since(start).Milliseconds())
 		availableJobs := getJobNames(jobStatus)
-		return response.NotFound(c, "Job not found", "Available jobs: "+joinStrings(availableJobs, ", "))
+		return response.NotFound(c, response.CodeJobNotFound, "Job not found", "Available jobs: "+joinStrings(availableJobs, ", "))
 	}
 
 	if job.IsRunning {
 		h.logger.LogServiceOperation("scheduler_handler", "trigger_job", false, time.Since(start).Milliseconds())
-		return response.Conflict(c, "Job is already running")
+		return response.Conflict(c, response.CodeJobRunning, "Job is already running")
 	}
 
 	h.logger.Info("Manual job trigger requested via API", "job_name", jobName)
@@ -118,6 +120,305 @@ func (h *schedulerHandler) TriggerJob(c echo.Context) error {
 	return response.Success(c, http.StatusOK, triggerData, "Job trigger acknowledged")
 }
 
+// GetJobHistory handles GET /api/v1/scheduler/jobs/:name/history
+// @Summary      Get job execution history
+// @Description  Retrieve the most recent executions of a job, newest first, including errors and run statistics
+// @Tags         scheduler
+// @Accept       json
+// @Produce      json
+// @Param        name  path      string  true  "Job name"
+// @Success      200   {object}  response.APIResponse{data=model.JobHistoryResponse}  "Job history"
+// @Failure      400   {object}  response.APIResponse{error=response.ErrorInfo}        "Job name required"
+// @Failure      404   {object}  response.APIResponse{error=response.ErrorInfo}        "Job not found"
+// @Router       /scheduler/jobs/{name}/history [get]
+func (h *schedulerHandler) GetJobHistory(c echo.Context) error {
+	start := time.Now()
+
+	jobName := c.Param("name")
+	if jobName == "" {
+		h.logger.LogServiceOperation("scheduler_handler", "get_job_history", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeMissingParameter, "Job name is required")
+	}
+
+	executions, err := h.schedulerService.GetJobHistory(jobName)
+	if err != nil {
+		h.logger.LogServiceOperation("scheduler_handler", "get_job_history", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrJobNotFound) {
+			availableJobs := getJobNames(h.schedulerService.GetJobStatus())
+			return response.NotFound(c, response.CodeJobNotFound, "Job not found", "Available jobs: "+joinStrings(availableJobs, ", "))
+		}
+
+		return response.InternalServerError(c, "Failed to retrieve job history")
+	}
+
+	h.logger.LogServiceOperation("scheduler_handler", "get_job_history", true, time.Since(start).Milliseconds())
+
+	historyData := model.JobHistoryResponse{
+		JobName:    jobName,
+		Executions: executions,
+		Count:      len(executions),
+		Timestamp:  time.Now(),
+	}
+
+	return response.Success(c, http.StatusOK, historyData, "Job history retrieved successfully")
+}
+
+// PauseScheduler handles POST /api/v1/scheduler/pause
+// @Summary      Pause the scheduler
+// @Description  Stop running scheduled jobs until the scheduler is resumed, without restarting the server
+// @Tags         scheduler
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=model.SchedulerStateResponse}  "Scheduler paused"
+// @Router       /scheduler/pause [post]
+func (h *schedulerHandler) PauseScheduler(c echo.Context) error {
+	h.schedulerService.Pause()
+	h.logger.Info("Scheduler pause requested via API")
+
+	stateData := model.SchedulerStateResponse{
+		Paused:    true,
+		Timestamp: time.Now(),
+	}
+
+	return response.Success(c, http.StatusOK, stateData, "Scheduler paused")
+}
+
+// ResumeScheduler handles POST /api/v1/scheduler/resume
+// @Summary      Resume the scheduler
+// @Description  Resume running scheduled jobs after a pause
+// @Tags         scheduler
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=model.SchedulerStateResponse}  "Scheduler resumed"
+// @Router       /scheduler/resume [post]
+func (h *schedulerHandler) ResumeScheduler(c echo.Context) error {
+	h.schedulerService.Resume()
+	h.logger.Info("Scheduler resume requested via API")
+
+	stateData := model.SchedulerStateResponse{
+		Paused:    false,
+		Timestamp: time.Now(),
+	}
+
+	return response.Success(c, http.StatusOK, stateData, "Scheduler resumed")
+}
+
+// EnableJob handles POST /api/v1/scheduler/jobs/:name/enable
+// @Summary      Enable a scheduler job
+// @Description  Allow a disabled job to run on its schedule again
+// @Tags         scheduler
+// @Accept       json
+// @Produce      json
+// @Param        name  path      string  true  "Job name"
+// @Success      200   {object}  response.APIResponse{data=model.JobToggleResponse}  "Job enabled"
+// @Failure      404   {object}  response.APIResponse{error=response.ErrorInfo}       "Job not found"
+// @Router       /scheduler/jobs/{name}/enable [post]
+func (h *schedulerHandler) EnableJob(c echo.Context) error {
+	return h.toggleJob(c, true)
+}
+
+// DisableJob handles POST /api/v1/scheduler/jobs/:name/disable
+// @Summary      Disable a scheduler job
+// @Description  Skip a job's scheduled runs until it is enabled again
+// @Tags         scheduler
+// @Accept       json
+// @Produce      json
+// @Param        name  path      string  true  "Job name"
+// @Success      200   {object}  response.APIResponse{data=model.JobToggleResponse}  "Job disabled"
+// @Failure      404   {object}  response.APIResponse{error=response.ErrorInfo}       "Job not found"
+// @Router       /scheduler/jobs/{name}/disable [post]
+func (h *schedulerHandler) DisableJob(c echo.Context) error {
+	return h.toggleJob(c, false)
+}
+
+// GetOnceJobs handles GET /api/v1/scheduler/once-jobs
+// @Summary      List one-time jobs
+// @Description  Retrieve the one-time jobs waiting to run, soonest first. Jobs are kept in Redis until they have run, so they survive restarts.
+// @Tags         scheduler
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=model.OnceJobsResponse}  "One-time jobs"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}     "Internal server error"
+// @Router       /scheduler/once-jobs [get]
+func (h *schedulerHandler) GetOnceJobs(c echo.Context) error {
+	start := time.Now()
+
+	jobs, err := h.schedulerService.ListOnceJobs(c.Request().Context())
+	if err != nil {
+		h.logger.LogServiceOperation("scheduler_handler", "get_once_jobs", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to retrieve one-time jobs")
+	}
+
+	h.logger.LogServiceOperation("scheduler_handler", "get_once_jobs", true, time.Since(start).Milliseconds())
+
+	jobsData := model.OnceJobsResponse{
+		Jobs:      jobs,
+		Count:     len(jobs),
+		Timestamp: time.Now(),
+	}
+
+	return response.Success(c, http.StatusOK, jobsData, "One-time jobs retrieved successfully")
+}
+
+// CancelOnceJob handles DELETE /api/v1/scheduler/once-jobs/:id
+// @Summary      Cancel a one-time job
+// @Description  Remove a one-time job before it runs. A job already running is not interrupted.
+// @Tags         scheduler
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "One-time job ID"
+// @Success      204  {string}  string                                          "No Content"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "One-time job not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /scheduler/once-jobs/{id} [delete]
+func (h *schedulerHandler) CancelOnceJob(c echo.Context) error {
+	start := time.Now()
+
+	if err := h.schedulerService.CancelOnce(c.Request().Context(), c.Param("id")); err != nil {
+		h.logger.LogServiceOperation("scheduler_handler", "cancel_once_job", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrOnceJobNotFound) {
+			return response.NotFound(c, response.CodeOnceJobNotFound, "One-time job not found")
+		}
+
+		return response.InternalServerError(c, "Failed to cancel one-time job")
+	}
+
+	h.logger.LogServiceOperation("scheduler_handler", "cancel_once_job", true, time.Since(start).Milliseconds())
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDeadJobs handles GET /api/v1/admin/dead-jobs
+// @Summary      List dead jobs
+// @Description  Retrieve the queued jobs that failed on every attempt, most recent failure first, with the error of their last attempt.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=model.DeadOnceJobsResponse}  "Dead jobs"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}         "Internal server error"
+// @Router       /admin/dead-jobs [get]
+func (h *schedulerHandler) GetDeadJobs(c echo.Context) error {
+	start := time.Now()
+
+	jobs, err := h.schedulerService.ListDeadOnceJobs(c.Request().Context())
+	if err != nil {
+		h.logger.LogServiceOperation("scheduler_handler", "get_dead_jobs", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to retrieve dead jobs")
+	}
+
+	h.logger.LogServiceOperation("scheduler_handler", "get_dead_jobs", true, time.Since(start).Milliseconds())
+
+	jobsData := model.DeadOnceJobsResponse{
+		Jobs:      jobs,
+		Count:     len(jobs),
+		Timestamp: time.Now(),
+	}
+
+	return response.Success(c, http.StatusOK, jobsData, "Dead jobs retrieved successfully")
+}
+
+// RetryDeadJob handles POST /api/v1/admin/dead-jobs/:id/retry
+// @Summary      Retry a dead job
+// @Description  Queue a dead job to run again now, with its attempts counted afresh.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Dead job ID"
+// @Success      200  {object}  response.APIResponse{data=model.OnceJob}       "Job requeued"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Dead job not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/dead-jobs/{id}/retry [post]
+func (h *schedulerHandler) RetryDeadJob(c echo.Context) error {
+	start := time.Now()
+
+	job, err := h.schedulerService.RetryDeadOnceJob(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		h.logger.LogServiceOperation("scheduler_handler", "retry_dead_job", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrDeadOnceJobNotFound) {
+			return response.NotFound(c, response.CodeDeadJobNotFound, "Dead job not found")
+		}
+
+		return response.InternalServerError(c, "Failed to retry dead job")
+	}
+
+	h.logger.LogServiceOperation("scheduler_handler", "retry_dead_job", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, job, "Dead job requeued")
+}
+
+// DeleteDeadJob handles DELETE /api/v1/admin/dead-jobs/:id
+// @Summary      Delete a dead job
+// @Description  Discard a dead job without running it again.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Dead job ID"
+// @Success      204  {string}  string                                          "No Content"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Dead job not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/dead-jobs/{id} [delete]
+func (h *schedulerHandler) DeleteDeadJob(c echo.Context) error {
+	start := time.Now()
+
+	if err := h.schedulerService.DeleteDeadOnceJob(c.Request().Context(), c.Param("id")); err != nil {
+		h.logger.LogServiceOperation("scheduler_handler", "delete_dead_job", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrDeadOnceJobNotFound) {
+			return response.NotFound(c, response.CodeDeadJobNotFound, "Dead job not found")
+		}
+
+		return response.InternalServerError(c, "Failed to delete dead job")
+	}
+
+	h.logger.LogServiceOperation("scheduler_handler", "delete_dead_job", true, time.Since(start).Milliseconds())
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// toggleJob enables or disables the job named in the request path
+func (h *schedulerHandler) toggleJob(c echo.Context, enabled bool) error {
+	start := time.Now()
+
+	operation := "disable_job"
+	toggle := h.schedulerService.DisableJob
+	message := "Job disabled"
+	if enabled {
+		operation = "enable_job"
+		toggle = h.schedulerService.EnableJob
+		message = "Job enabled"
+	}
+
+	jobName := c.Param("name")
+	if jobName == "" {
+		h.logger.LogServiceOperation("scheduler_handler", operation, false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeMissingParameter, "Job name is required")
+	}
+
+	if err := toggle(jobName); err != nil {
+		h.logger.LogServiceOperation("scheduler_handler", operation, false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrJobNotFound) {
+			availableJobs := getJobNames(h.schedulerService.GetJobStatus())
+			return response.NotFound(c, response.CodeJobNotFound, "Job not found", "Available jobs: "+joinStrings(availableJobs, ", "))
+		}
+
+		return response.InternalServerError(c, "Failed to update job")
+	}
+
+	h.logger.LogServiceOperation("scheduler_handler", operation, true, time.Since(start).Milliseconds())
+
+	toggleData := model.JobToggleResponse{
+		JobName:   jobName,
+		Enabled:   enabled,
+		Timestamp: time.Now(),
+	}
+
+	return response.Success(c, http.StatusOK, toggleData, message)
+}
+
 // getJobNames extracts job names from job status map
 func getJobNames(jobStatus map[string]model.JobStatus) []string {
 	names := make([]string, 0, len(jobStatus))
@@ -11,6 +11,7 @@ import (
 
 	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
 	"github.com/amirzre/news-feed-system/pkg/logger"
 	"github.com/amirzre/news-feed-system/pkg/response"
 	"github.com/labstack/echo/v4"
@@ -39,8 +40,8 @@ func (m *MockSchedulerService) IsRunning() bool {
 	return args.Bool(0)
 }
 
-func (m *MockSchedulerService) AddJob(name string, interval time.Duration, job func(context.Context) error) {
-	m.Called(name, interval, job)
+func (m *MockSchedulerService) AddJob(name string, interval time.Duration, job func(context.Context) error, opts ...service.JobOption) {
+	m.Called(name, interval, job, opts)
 }
 
 func (m *MockSchedulerService) RemoveJob(name string) {
@@ -55,6 +56,88 @@ func (m *MockSchedulerService) GetJobStatus() map[string]model.JobStatus {
 	return args.Get(0).(map[string]model.JobStatus)
 }
 
+func (m *MockSchedulerService) GetJobHistory(name string) ([]model.JobExecution, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.JobExecution), args.Error(1)
+}
+
+func (m *MockSchedulerService) Pause() {
+	m.Called()
+}
+
+func (m *MockSchedulerService) Resume() {
+	m.Called()
+}
+
+func (m *MockSchedulerService) IsPaused() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+func (m *MockSchedulerService) EnableJob(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockSchedulerService) DisableJob(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockSchedulerService) SetJobInterval(name string, interval time.Duration) error {
+	args := m.Called(name, interval)
+	return args.Error(0)
+}
+
+func (m *MockSchedulerService) RegisterTask(name string, task service.OnceTaskFunc, opts ...service.JobOption) {
+	m.Called(name, task, opts)
+}
+
+func (m *MockSchedulerService) ScheduleOnce(ctx context.Context, name string, at time.Time, payload any) (*model.OnceJob, error) {
+	args := m.Called(ctx, name, at, payload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.OnceJob), args.Error(1)
+}
+
+func (m *MockSchedulerService) CancelOnce(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSchedulerService) ListOnceJobs(ctx context.Context) ([]model.OnceJob, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OnceJob), args.Error(1)
+}
+
+func (m *MockSchedulerService) ListDeadOnceJobs(ctx context.Context) ([]model.DeadOnceJob, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.DeadOnceJob), args.Error(1)
+}
+
+func (m *MockSchedulerService) RetryDeadOnceJob(ctx context.Context, id string) (*model.OnceJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.OnceJob), args.Error(1)
+}
+
+func (m *MockSchedulerService) DeleteDeadOnceJob(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 // SchedulerHandlerTestSuite defines the test suite for SchedulerHandler
 type SchedulerHandlerTestSuite struct {
 	suite.Suite
@@ -153,6 +236,7 @@ func (suite *SchedulerHandlerTestSuite) TestGetStatusWhenSchedulerRunning() {
 	mockJobStatus := suite.createMockJobStatus()
 
 	suite.mockService.On("IsRunning").Return(true)
+	suite.mockService.On("IsPaused").Return(false)
 	suite.mockService.On("GetJobStatus").Return(mockJobStatus)
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/scheduler/status", nil)
@@ -181,6 +265,7 @@ func (suite *SchedulerHandlerTestSuite) TestGetStatusWhenSchedulerNotRunning() {
 	mockJobStatus := suite.createMockJobStatus()
 
 	suite.mockService.On("IsRunning").Return(false)
+	suite.mockService.On("IsPaused").Return(false)
 	suite.mockService.On("GetJobStatus").Return(mockJobStatus)
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/scheduler/status", nil)
@@ -207,6 +292,7 @@ func (suite *SchedulerHandlerTestSuite) TestGetStatusWithEmptyJobStatus() {
 	emptyJobStatus := make(map[string]model.JobStatus)
 
 	suite.mockService.On("IsRunning").Return(true)
+	suite.mockService.On("IsPaused").Return(false)
 	suite.mockService.On("GetJobStatus").Return(emptyJobStatus)
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/scheduler/status", nil)
@@ -476,6 +562,7 @@ func (suite *SchedulerHandlerTestSuite) TestTriggerJobWithNilJobStatus() {
 
 func (suite *SchedulerHandlerTestSuite) TestGetStatusWithNilJobStatus() {
 	suite.mockService.On("IsRunning").Return(true)
+	suite.mockService.On("IsPaused").Return(false)
 	suite.mockService.On("GetJobStatus").Return(nil)
 
 	c, rec := suite.createEchoContext(http.MethodGet, "/scheduler/status", nil)
@@ -522,6 +609,198 @@ func (suite *SchedulerHandlerTestSuite) TestGetJobsWithNilJobStatus() {
 	assert.Nil(suite.T(), jobsData.Jobs)
 }
 
+func (suite *SchedulerHandlerTestSuite) TestPauseScheduler() {
+	suite.mockService.On("Pause").Return()
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/scheduler/pause", nil)
+
+	err := suite.handler.PauseScheduler(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var response response.APIResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Scheduler paused", response.Message)
+}
+
+func (suite *SchedulerHandlerTestSuite) TestResumeScheduler() {
+	suite.mockService.On("Resume").Return()
+
+	c, rec := suite.createEchoContext(http.MethodPost, "/scheduler/resume", nil)
+
+	err := suite.handler.ResumeScheduler(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *SchedulerHandlerTestSuite) TestDisableJobSuccess() {
+	jobName := "aggregate_all"
+
+	suite.mockService.On("DisableJob", jobName).Return(nil)
+
+	c, rec := suite.createEchoContextWithParam(http.MethodPost, "/scheduler/jobs/"+jobName+"/disable", "name", jobName)
+
+	err := suite.handler.DisableJob(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var response response.APIResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+
+	dataBytes, _ := json.Marshal(response.Data)
+	var toggleData model.JobToggleResponse
+	err = json.Unmarshal(dataBytes, &toggleData)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), jobName, toggleData.JobName)
+	assert.False(suite.T(), toggleData.Enabled)
+}
+
+func (suite *SchedulerHandlerTestSuite) TestEnableJobNotFound() {
+	jobName := "missing"
+
+	suite.mockService.On("EnableJob", jobName).Return(service.ErrJobNotFound)
+	suite.mockService.On("GetJobStatus").Return(suite.createMockJobStatus())
+
+	c, rec := suite.createEchoContextWithParam(http.MethodPost, "/scheduler/jobs/"+jobName+"/enable", "name", jobName)
+
+	err := suite.handler.EnableJob(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+}
+
+func (suite *SchedulerHandlerTestSuite) TestGetJobHistorySuccess() {
+	jobName := "top-headlines"
+	executions := []model.JobExecution{
+		{StartedAt: time.Now(), Duration: time.Second, Success: false, Error: "timeout error"},
+		{StartedAt: time.Now().Add(-time.Hour), Duration: time.Second, Success: true, Stats: map[string]int64{"created": 5}},
+	}
+
+	suite.mockService.On("GetJobHistory", jobName).Return(executions, nil)
+
+	c, rec := suite.createEchoContextWithParam(http.MethodGet, "/scheduler/jobs/"+jobName+"/history", "name", jobName)
+
+	err := suite.handler.GetJobHistory(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var response response.APIResponse
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+
+	dataBytes, _ := json.Marshal(response.Data)
+	var historyData model.JobHistoryResponse
+	err = json.Unmarshal(dataBytes, &historyData)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, historyData.Count)
+	assert.Equal(suite.T(), "timeout error", historyData.Executions[0].Error)
+	assert.Equal(suite.T(), int64(5), historyData.Executions[1].Stats["created"])
+}
+
+func (suite *SchedulerHandlerTestSuite) TestGetJobHistoryNotFound() {
+	jobName := "missing"
+
+	suite.mockService.On("GetJobHistory", jobName).Return(nil, service.ErrJobNotFound)
+	suite.mockService.On("GetJobStatus").Return(suite.createMockJobStatus())
+
+	c, rec := suite.createEchoContextWithParam(http.MethodGet, "/scheduler/jobs/"+jobName+"/history", "name", jobName)
+
+	err := suite.handler.GetJobHistory(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+}
+
+func (suite *SchedulerHandlerTestSuite) TestGetOnceJobsSuccess() {
+	jobs := []model.OnceJob{
+		{ID: "A1", Task: "post-purge", RunAt: time.Now().Add(time.Hour), Tenant: "default", Payload: json.RawMessage(`{"post_id":7}`)},
+	}
+
+	suite.mockService.On("ListOnceJobs", mock.Anything).Return(jobs, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/scheduler/once-jobs", nil)
+
+	err := suite.handler.GetOnceJobs(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"task":"post-purge"`)
+	assert.Contains(suite.T(), rec.Body.String(), `"payload":{"post_id":7}`)
+	assert.Contains(suite.T(), rec.Body.String(), `"count":1`)
+}
+
+func (suite *SchedulerHandlerTestSuite) TestCancelOnceJob() {
+	suite.mockService.On("CancelOnce", mock.Anything, "A1").Return(nil)
+
+	c, rec := suite.createEchoContextWithParam(http.MethodDelete, "/scheduler/once-jobs/A1", "id", "A1")
+
+	err := suite.handler.CancelOnceJob(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNoContent, rec.Code)
+}
+
+func (suite *SchedulerHandlerTestSuite) TestCancelOnceJobNotFound() {
+	suite.mockService.On("CancelOnce", mock.Anything, "missing").Return(service.ErrOnceJobNotFound)
+
+	c, rec := suite.createEchoContextWithParam(http.MethodDelete, "/scheduler/once-jobs/missing", "id", "missing")
+
+	err := suite.handler.CancelOnceJob(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "ONCE_JOB_NOT_FOUND")
+}
+
+func (suite *SchedulerHandlerTestSuite) TestGetDeadJobsSuccess() {
+	jobs := []model.DeadOnceJob{
+		{OnceJob: model.OnceJob{ID: "job:top-headlines", Task: "top-headlines", Tenant: "default", Attempts: 3}, Error: "provider unavailable", FailedAt: time.Now()},
+	}
+
+	suite.mockService.On("ListDeadOnceJobs", mock.Anything).Return(jobs, nil)
+
+	c, rec := suite.createEchoContext(http.MethodGet, "/admin/dead-jobs", nil)
+
+	err := suite.handler.GetDeadJobs(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"id":"job:top-headlines"`)
+	assert.Contains(suite.T(), rec.Body.String(), `"attempts":3`)
+	assert.Contains(suite.T(), rec.Body.String(), `"error":"provider unavailable"`)
+}
+
+func (suite *SchedulerHandlerTestSuite) TestRetryDeadJob() {
+	job := &model.OnceJob{ID: "A1", Task: "post-purge", RunAt: time.Now(), Tenant: "default"}
+	suite.mockService.On("RetryDeadOnceJob", mock.Anything, "A1").Return(job, nil)
+
+	c, rec := suite.createEchoContextWithParam(http.MethodPost, "/admin/dead-jobs/A1/retry", "id", "A1")
+
+	err := suite.handler.RetryDeadJob(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"task":"post-purge"`)
+}
+
+func (suite *SchedulerHandlerTestSuite) TestDeleteDeadJobNotFound() {
+	suite.mockService.On("DeleteDeadOnceJob", mock.Anything, "missing").Return(service.ErrDeadOnceJobNotFound)
+
+	c, rec := suite.createEchoContextWithParam(http.MethodDelete, "/admin/dead-jobs/missing", "id", "missing")
+
+	err := suite.handler.DeleteDeadJob(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "DEAD_JOB_NOT_FOUND")
+}
+
 // Run the test suite
 func TestSchedulerHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(SchedulerHandlerTestSuite))
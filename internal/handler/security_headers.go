@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/labstack/echo/v4"
+)
+
+// SecurityHeaders sets the configured security headers on every response.
+// Swagger UI pages get their own content security policy, and
+// Strict-Transport-Security is only sent on requests made over TLS, directly
+// or through a proxy reporting X-Forwarded-Proto.
+func SecurityHeaders(cfg config.SecurityHeadersConfig) echo.MiddlewareFunc {
+	hsts := ""
+	if cfg.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(cfg.HSTSMaxAge)
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if cfg.HSTSPreload {
+			hsts += "; preload"
+		}
+	}
+
+	headers := map[string]string{
+		echo.HeaderXContentTypeOptions: cfg.ContentTypeOptions,
+		echo.HeaderXFrameOptions:       cfg.FrameOptions,
+		echo.HeaderReferrerPolicy:      cfg.ReferrerPolicy,
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			for name, value := range headers {
+				if value != "" {
+					header.Set(name, value)
+				}
+			}
+
+			csp := cfg.ContentSecurityPolicy
+			if strings.HasPrefix(c.Request().URL.Path, swaggerPathPrefix) {
+				csp = cfg.SwaggerContentSecurityPolicy
+			}
+			if csp != "" {
+				header.Set(echo.HeaderContentSecurityPolicy, csp)
+			}
+
+			if hsts != "" && c.Scheme() == "https" {
+				header.Set(echo.HeaderStrictTransportSecurity, hsts)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSecurityHeaders = config.SecurityHeadersConfig{
+	ContentTypeOptions:           "nosniff",
+	FrameOptions:                 "DENY",
+	ReferrerPolicy:               "no-referrer",
+	ContentSecurityPolicy:        "default-src 'none'",
+	SwaggerContentSecurityPolicy: "default-src 'self'",
+	HSTSMaxAge:                   3600,
+	HSTSIncludeSubdomains:        true,
+}
+
+// serveSecurityHeaders serves req behind SecurityHeaders and returns the
+// response headers
+func serveSecurityHeaders(cfg config.SecurityHeadersConfig, req *http.Request) http.Header {
+	e := echo.New()
+	e.Use(SecurityHeaders(cfg))
+	e.GET("/*", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec.Header()
+}
+
+func TestSecurityHeadersSetsAPIHeaders(t *testing.T) {
+	header := serveSecurityHeaders(testSecurityHeaders, httptest.NewRequest(http.MethodGet, APIBasePath+"/posts", nil))
+
+	assert.Equal(t, "nosniff", header.Get(echo.HeaderXContentTypeOptions))
+	assert.Equal(t, "DENY", header.Get(echo.HeaderXFrameOptions))
+	assert.Equal(t, "no-referrer", header.Get(echo.HeaderReferrerPolicy))
+	assert.Equal(t, "default-src 'none'", header.Get(echo.HeaderContentSecurityPolicy))
+	assert.Empty(t, header.Get(echo.HeaderStrictTransportSecurity), "HSTS is not sent over plain HTTP")
+}
+
+func TestSecurityHeadersUsesSwaggerPolicy(t *testing.T) {
+	header := serveSecurityHeaders(testSecurityHeaders, httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil))
+
+	assert.Equal(t, "default-src 'self'", header.Get(echo.HeaderContentSecurityPolicy))
+}
+
+func TestSecurityHeadersSendsHSTSOverTLS(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, APIBasePath+"/posts", nil)
+	req.Header.Set(echo.HeaderXForwardedProto, "https")
+
+	header := serveSecurityHeaders(testSecurityHeaders, req)
+
+	assert.Equal(t, "max-age=3600; includeSubDomains", header.Get(echo.HeaderStrictTransportSecurity))
+}
+
+func TestSecurityHeadersOmitsEmptyValues(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, APIBasePath+"/posts", nil)
+	req.Header.Set(echo.HeaderXForwardedProto, "https")
+
+	header := serveSecurityHeaders(config.SecurityHeadersConfig{ContentTypeOptions: "nosniff"}, req)
+
+	assert.Equal(t, "nosniff", header.Get(echo.HeaderXContentTypeOptions))
+	for _, name := range []string{
+		echo.HeaderXFrameOptions,
+		echo.HeaderReferrerPolicy,
+		echo.HeaderContentSecurityPolicy,
+		echo.HeaderStrictTransportSecurity,
+	} {
+		assert.NotContains(t, header, name)
+	}
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// sourceRuleHandler implements SourceRuleHandler interface
+type sourceRuleHandler struct {
+	sourceRuleService service.SourceRuleService
+	logger            *logger.Logger
+}
+
+// NewSourceRuleHandler creates a new source rule handler
+func NewSourceRuleHandler(sourceRuleService service.SourceRuleService, logger *logger.Logger) SourceRuleHandler {
+	return &sourceRuleHandler{
+		sourceRuleService: sourceRuleService,
+		logger:            logger,
+	}
+}
+
+// ListSourceRules handles GET /api/v1/admin/source-rules
+// @Summary      List source rules
+// @Description  List the domains and source names on the blocklist and the allowlist
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=[]model.SourceRule}    "Source rules"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/source-rules [get]
+func (h *sourceRuleHandler) ListSourceRules(c echo.Context) error {
+	start := time.Now()
+
+	rules, err := h.sourceRuleService.ListRules(c.Request().Context())
+	if err != nil {
+		h.logger.LogServiceOperation("source_rule_handler", "list_source_rules", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to list source rules")
+	}
+
+	h.logger.LogServiceOperation("source_rule_handler", "list_source_rules", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, rules)
+}
+
+// SaveSourceRule handles PUT /api/v1/admin/source-rules
+// @Summary      Block or allow a domain or source
+// @Description  Put a domain or source name on the blocklist or the allowlist, moving it if it is already on the other list. Domains also match their subdomains and source names ignore case. With hide_existing, blocking also hides the stored posts from the domain or source.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        rule  body      model.SaveSourceRuleParams  true  "Source rule"
+// @Success      200   {object}  response.APIResponse{data=model.SaveSourceRuleResult}  "Source rule saved"
+// @Failure      400   {object}  response.APIResponse{error=response.ErrorInfo}        "Invalid source rule"
+// @Failure      500   {object}  response.APIResponse{error=response.ErrorInfo}        "Internal server error"
+// @Router       /admin/source-rules [put]
+func (h *sourceRuleHandler) SaveSourceRule(c echo.Context) error {
+	start := time.Now()
+
+	var req model.SaveSourceRuleParams
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("source_rule_handler", "save_source_rule", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("source_rule_handler", "save_source_rule", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	result, err := h.sourceRuleService.SaveRule(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("source_rule_handler", "save_source_rule", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrSourceRuleInvalid) {
+			return response.BadRequest(c, response.CodeInvalidSourceRule, "Invalid source rule", "domains must be bare hosts such as example.com")
+		}
+
+		return response.InternalServerError(c, "Failed to save source rule")
+	}
+
+	h.logger.LogServiceOperation("source_rule_handler", "save_source_rule", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, result, "Source rule saved successfully")
+}
+
+// DeleteSourceRule handles DELETE /api/v1/admin/source-rules/:id
+// @Summary      Delete a source rule
+// @Description  Take a domain or source name off its list. Posts hidden when it was blocked stay hidden.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Source rule ID"
+// @Success      200  {object}  response.APIResponse                            "Source rule deleted"
+// @Failure      400  {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid source rule ID"
+// @Failure      404  {object}  response.APIResponse{error=response.ErrorInfo}  "Source rule not found"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/source-rules/{id} [delete]
+func (h *sourceRuleHandler) DeleteSourceRule(c echo.Context) error {
+	start := time.Now()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.logger.LogServiceOperation("source_rule_handler", "delete_source_rule", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid source rule ID")
+	}
+
+	if err := h.sourceRuleService.DeleteRule(c.Request().Context(), id); err != nil {
+		h.logger.LogServiceOperation("source_rule_handler", "delete_source_rule", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrSourceRuleNotFound) {
+			return response.NotFound(c, response.CodeSourceRuleNotFound, "Source rule not found")
+		}
+
+		return response.InternalServerError(c, "Failed to delete source rule")
+	}
+
+	h.logger.LogServiceOperation("source_rule_handler", "delete_source_rule", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, nil, "Source rule deleted successfully")
+}
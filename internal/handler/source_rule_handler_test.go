@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockSourceRuleService is a mock implementation of SourceRuleService
+type MockSourceRuleService struct {
+	mock.Mock
+}
+
+func (m *MockSourceRuleService) ListRules(ctx context.Context) ([]model.SourceRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SourceRule), args.Error(1)
+}
+
+func (m *MockSourceRuleService) SaveRule(ctx context.Context, req *model.SaveSourceRuleParams) (*model.SaveSourceRuleResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SaveSourceRuleResult), args.Error(1)
+}
+
+func (m *MockSourceRuleService) DeleteRule(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSourceRuleService) Check(ctx context.Context, articleURL, source string) (*model.FilterRejection, error) {
+	args := m.Called(ctx, articleURL, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.FilterRejection), args.Error(1)
+}
+
+// SourceRuleHandlerTestSuite defines the test suite for SourceRuleHandler
+type SourceRuleHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockSourceRuleService
+	handler     SourceRuleHandler
+	echo        *echo.Echo
+}
+
+func (suite *SourceRuleHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockSourceRuleService)
+	suite.handler = NewSourceRuleHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *SourceRuleHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *SourceRuleHandlerTestSuite) createEchoContext(method, target string, body any) (echo.Context, *httptest.ResponseRecorder) {
+	var req *http.Request
+	if body != nil {
+		jsonBody, _ := json.Marshal(body)
+		req = httptest.NewRequest(method, target, bytes.NewBuffer(jsonBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	} else {
+		req = httptest.NewRequest(method, target, nil)
+	}
+
+	rec := httptest.NewRecorder()
+	return suite.echo.NewContext(req, rec), rec
+}
+
+func (suite *SourceRuleHandlerTestSuite) TestSaveSourceRuleSuccess() {
+	req := &model.SaveSourceRuleParams{
+		List:         model.SourceRuleBlock,
+		Type:         model.SourceRuleDomain,
+		Value:        "spam.example.com",
+		HideExisting: true,
+	}
+	result := &model.SaveSourceRuleResult{
+		Rule:   model.SourceRule{ID: 1, List: req.List, Type: req.Type, Value: req.Value},
+		Hidden: 4,
+	}
+
+	suite.mockService.On("SaveRule", mock.Anything, req).Return(result, nil)
+
+	c, rec := suite.createEchoContext(http.MethodPut, "/admin/source-rules", req)
+
+	err := suite.handler.SaveSourceRule(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+
+	var body response.APIResponse
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.EqualValues(suite.T(), 4, body.Data.(map[string]any)["hidden"])
+}
+
+func (suite *SourceRuleHandlerTestSuite) TestSaveSourceRuleInvalid() {
+	req := &model.SaveSourceRuleParams{List: model.SourceRuleBlock, Type: model.SourceRuleDomain, Value: "https://example.com"}
+
+	suite.mockService.On("SaveRule", mock.Anything, req).Return(nil, service.ErrSourceRuleInvalid)
+
+	c, rec := suite.createEchoContext(http.MethodPut, "/admin/source-rules", req)
+
+	err := suite.handler.SaveSourceRule(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+
+	var body response.APIResponse
+	assert.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(suite.T(), response.CodeInvalidSourceRule, body.Error.Code)
+}
+
+func (suite *SourceRuleHandlerTestSuite) TestDeleteSourceRuleNotFound() {
+	suite.mockService.On("DeleteRule", mock.Anything, int64(9)).Return(service.ErrSourceRuleNotFound)
+
+	c, rec := suite.createEchoContext(http.MethodDelete, "/admin/source-rules/9", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("9")
+
+	err := suite.handler.DeleteSourceRule(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+}
+
+func (suite *SourceRuleHandlerTestSuite) TestDeleteSourceRuleInvalidID() {
+	c, rec := suite.createEchoContext(http.MethodDelete, "/admin/source-rules/abc", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+
+	err := suite.handler.DeleteSourceRule(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func TestSourceRuleHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(SourceRuleHandlerTestSuite))
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// statsHandler implements StatsHandler interface
+type statsHandler struct {
+	statsService service.StatsService
+	logger       *logger.Logger
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(statsService service.StatsService, logger *logger.Logger) StatsHandler {
+	return &statsHandler{
+		statsService: statsService,
+		logger:       logger,
+	}
+}
+
+// GetTopStats handles GET /api/v1/stats/top
+// @Summary      Get top sources and categories
+// @Description  Sources and categories ranked by posts ingested, views or clicks over the last 24 hours or 7 days, highest first. Totals are read from hourly rollups refreshed by a scheduled job, so they lag by up to the rollup interval; rolled_up_at tells when they were last computed.
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Param        metric  query     string  false  "Rank by posts, views or clicks"  default(posts)
+// @Param        period  query     string  false  "Period, 24h or 7d"               default(24h)
+// @Param        limit   query     int     false  "Entries per ranking (1-100)"     default(10)
+// @Success      200     {object}  response.APIResponse{data=model.TopStatsResponse}  "Top sources and categories"
+// @Failure      400     {object}  response.APIResponse{error=response.ErrorInfo}     "Invalid parameters"
+// @Failure      500     {object}  response.APIResponse{error=response.ErrorInfo}     "Internal server error"
+// @Router       /stats/top [get]
+func (h *statsHandler) GetTopStats(c echo.Context) error {
+	start := time.Now()
+
+	req := model.DefaultTopStatsParams()
+
+	if metric := c.QueryParam("metric"); metric != "" {
+		req.Metric = metric
+	}
+	if period := c.QueryParam("period"); period != "" {
+		req.Period = period
+	}
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			h.logger.LogServiceOperation("stats_handler", "get_top_stats", false, time.Since(start).Milliseconds())
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid limit parameter")
+		}
+		req.Limit = limit
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("stats_handler", "get_top_stats", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	stats, err := h.statsService.GetTopStats(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("stats_handler", "get_top_stats", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrStatsMetricInvalid) || errors.Is(err, service.ErrStatsPeriodInvalid) {
+			return response.BadRequest(c, response.CodeInvalidParameter, err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to retrieve top stats")
+	}
+
+	h.logger.LogServiceOperation("stats_handler", "get_top_stats", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, stats)
+}
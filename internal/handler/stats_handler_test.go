@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockStatsService is a mock implementation of StatsService
+type MockStatsService struct {
+	mock.Mock
+}
+
+func (m *MockStatsService) RollupActivity(ctx context.Context) (*model.ActivityRollupResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ActivityRollupResult), args.Error(1)
+}
+
+func (m *MockStatsService) GetTopStats(ctx context.Context, req *model.TopStatsParams) (*model.TopStatsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TopStatsResponse), args.Error(1)
+}
+
+func (m *MockStatsService) RefreshViews(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// StatsHandlerTestSuite defines the test suite for StatsHandler
+type StatsHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockStatsService
+	handler     StatsHandler
+	echo        *echo.Echo
+}
+
+func (suite *StatsHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockStatsService)
+	suite.handler = NewStatsHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *StatsHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *StatsHandlerTestSuite) TestGetTopStatsDefaults() {
+	rolledUpAt := time.Date(2025, 8, 11, 7, 5, 0, 0, time.UTC)
+	result := &model.TopStatsResponse{
+		Metric:     model.StatsMetricPosts,
+		Period:     model.StatsPeriodDay,
+		Since:      time.Date(2025, 8, 10, 8, 0, 0, 0, time.UTC),
+		RolledUpAt: &rolledUpAt,
+		Sources:    []model.TopStat{{Key: "BBC News", Value: 40}},
+		Categories: []model.TopStat{{Key: "technology", Value: 25}},
+	}
+
+	suite.mockService.On("GetTopStats", mock.Anything, &model.TopStatsParams{Metric: "posts", Period: "24h", Limit: 10}).Return(result, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/top", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetTopStats(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"sources":[{"key":"BBC News","value":40}]`)
+	assert.Contains(suite.T(), rec.Body.String(), `"rolled_up_at":"2025-08-11T07:05:00Z"`)
+}
+
+func (suite *StatsHandlerTestSuite) TestGetTopStatsWithParams() {
+	result := &model.TopStatsResponse{Metric: "views", Period: "7d", Sources: []model.TopStat{}, Categories: []model.TopStat{}}
+
+	suite.mockService.On("GetTopStats", mock.Anything, &model.TopStatsParams{Metric: "views", Period: "7d", Limit: 5}).Return(result, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/top?metric=views&period=7d&limit=5", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetTopStats(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.NotContains(suite.T(), rec.Body.String(), "rolled_up_at")
+}
+
+func (suite *StatsHandlerTestSuite) TestGetTopStatsInvalidLimit() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/top?limit=ten", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetTopStats(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "GetTopStats", mock.Anything, mock.Anything)
+}
+
+func (suite *StatsHandlerTestSuite) TestGetTopStatsInvalidPeriod() {
+	suite.mockService.On("GetTopStats", mock.Anything, mock.Anything).Return(nil, service.ErrStatsPeriodInvalid)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/top?period=30d", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetTopStats(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), "INVALID_PARAMETER")
+}
+
+func (suite *StatsHandlerTestSuite) TestGetTopStatsServiceError() {
+	suite.mockService.On("GetTopStats", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stats/top", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetTopStats(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+}
+
+func TestStatsHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(StatsHandlerTestSuite))
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// syndicationCacheControl lets clients and proxies reuse a feed or sitemap
+// for a few minutes; the server regenerates them on a schedule anyway
+const syndicationCacheControl = "public, max-age=300"
+
+// syndicationHandler implements SyndicationHandler interface
+type syndicationHandler struct {
+	syndicationService service.SyndicationService
+	logger             *logger.Logger
+}
+
+// NewSyndicationHandler creates a new syndication handler
+func NewSyndicationHandler(syndicationService service.SyndicationService, logger *logger.Logger) SyndicationHandler {
+	return &syndicationHandler{
+		syndicationService: syndicationService,
+		logger:             logger,
+	}
+}
+
+// GetFeed handles GET /rss
+// @Summary      Feed of recent posts
+// @Description  RSS 2.0 feed of the most recent posts, or Atom 1.0 with format=atom. Feeds are cached and regenerated on a schedule.
+// @Tags         syndication
+// @Produce      application/rss+xml,application/atom+xml
+// @Param        format  query     string  false  "Feed format"  Enums(rss, atom)
+// @Success      200     {string}  string  "Feed document"
+// @Failure      400     {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid format"
+// @Failure      500     {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /rss [get]
+func (h *syndicationHandler) GetFeed(c echo.Context) error {
+	return h.feed(c, "")
+}
+
+// GetCategoryFeed handles GET /rss/:category
+// @Summary      Feed of recent posts in a category
+// @Description  RSS 2.0 feed of the most recent posts in a category, or Atom 1.0 with format=atom. Feeds are cached and regenerated on a schedule.
+// @Tags         syndication
+// @Produce      application/rss+xml,application/atom+xml
+// @Param        category  path      string  true   "Category"
+// @Param        format    query     string  false  "Feed format"  Enums(rss, atom)
+// @Success      200       {string}  string  "Feed document"
+// @Failure      400       {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid format or category"
+// @Failure      500       {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /rss/{category} [get]
+func (h *syndicationHandler) GetCategoryFeed(c echo.Context) error {
+	return h.feed(c, c.Param("category"))
+}
+
+// GetSitemap handles GET /sitemap.xml
+// @Summary      Sitemap of posts
+// @Description  Sitemap listing every post. Without page, a single sitemap is returned when all posts fit on one page, otherwise a sitemap index linking the numbered pages.
+// @Tags         syndication
+// @Produce      application/xml
+// @Param        page  query     int     false  "Sitemap page"
+// @Success      200   {string}  string  "Sitemap or sitemap index"
+// @Failure      400   {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid page"
+// @Failure      404   {object}  response.APIResponse{error=response.ErrorInfo}  "Sitemap page not found"
+// @Failure      500   {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /sitemap.xml [get]
+func (h *syndicationHandler) GetSitemap(c echo.Context) error {
+	start := time.Now()
+
+	page := 0
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		parsed, err := strconv.Atoi(pageParam)
+		if err != nil || parsed < 1 {
+			h.logger.LogServiceOperation("syndication_handler", "get_sitemap", false, time.Since(start).Milliseconds())
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid sitemap page")
+		}
+		page = parsed
+	}
+
+	doc, err := h.syndicationService.Sitemap(c.Request().Context(), page)
+	if err != nil {
+		h.logger.LogServiceOperation("syndication_handler", "get_sitemap", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrSitemapPageNotFound) {
+			return response.NotFound(c, response.CodeNotFound, "Sitemap page not found")
+		}
+
+		return response.InternalServerError(c, "Failed to generate sitemap")
+	}
+
+	h.logger.LogServiceOperation("syndication_handler", "get_sitemap", true, time.Since(start).Milliseconds())
+
+	return writeSyndicationDocument(c, doc)
+}
+
+// feed serves the main or a category feed in the requested format
+func (h *syndicationHandler) feed(c echo.Context, category string) error {
+	start := time.Now()
+
+	format := model.FeedFormatRSS
+	if formatParam := c.QueryParam("format"); formatParam != "" {
+		format = model.FeedFormat(formatParam)
+	}
+
+	doc, err := h.syndicationService.Feed(c.Request().Context(), category, format)
+	if err != nil {
+		h.logger.LogServiceOperation("syndication_handler", "get_feed", false, time.Since(start).Milliseconds())
+
+		switch {
+		case errors.Is(err, service.ErrFeedFormatInvalid):
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid feed format", err.Error())
+		case errors.Is(err, service.ErrFeedCategoryInvalid):
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid feed category", err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to generate feed")
+	}
+
+	h.logger.LogServiceOperation("syndication_handler", "get_feed", true, time.Since(start).Milliseconds())
+
+	return writeSyndicationDocument(c, doc)
+}
+
+// writeSyndicationDocument sends a rendered document with caching headers
+func writeSyndicationDocument(c echo.Context, doc *model.SyndicationDocument) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, syndicationCacheControl)
+	c.Response().Header().Set(echo.HeaderLastModified, doc.GeneratedAt.UTC().Format(http.TimeFormat))
+
+	return c.Blob(http.StatusOK, doc.ContentType, doc.Body)
+}
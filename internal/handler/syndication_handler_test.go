@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockSyndicationService is a mock implementation of SyndicationService
+type MockSyndicationService struct {
+	mock.Mock
+}
+
+func (m *MockSyndicationService) Feed(ctx context.Context, category string, format model.FeedFormat) (*model.SyndicationDocument, error) {
+	args := m.Called(ctx, category, format)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SyndicationDocument), args.Error(1)
+}
+
+func (m *MockSyndicationService) Sitemap(ctx context.Context, page int) (*model.SyndicationDocument, error) {
+	args := m.Called(ctx, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SyndicationDocument), args.Error(1)
+}
+
+func (m *MockSyndicationService) Refresh(ctx context.Context) (*model.SyndicationRefreshResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SyndicationRefreshResult), args.Error(1)
+}
+
+// SyndicationHandlerTestSuite defines the test suite for SyndicationHandler
+type SyndicationHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockSyndicationService
+	handler     SyndicationHandler
+	echo        *echo.Echo
+}
+
+func (suite *SyndicationHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockSyndicationService)
+	suite.handler = NewSyndicationHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+}
+
+func (suite *SyndicationHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *SyndicationHandlerTestSuite) TestGetFeedServesRSS() {
+	generated := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+	doc := &model.SyndicationDocument{Body: []byte(`<rss version="2.0"></rss>`), ContentType: "application/rss+xml; charset=utf-8", GeneratedAt: generated}
+
+	suite.mockService.On("Feed", mock.Anything, "", model.FeedFormatRSS).Return(doc, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/rss", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetFeed(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), "application/rss+xml; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(suite.T(), "Mon, 11 Aug 2025 07:00:00 GMT", rec.Header().Get(echo.HeaderLastModified))
+	assert.NotEmpty(suite.T(), rec.Header().Get(echo.HeaderCacheControl))
+	assert.Equal(suite.T(), `<rss version="2.0"></rss>`, rec.Body.String())
+}
+
+func (suite *SyndicationHandlerTestSuite) TestGetCategoryFeedAtom() {
+	doc := &model.SyndicationDocument{Body: []byte(`<feed></feed>`), ContentType: "application/atom+xml; charset=utf-8"}
+
+	suite.mockService.On("Feed", mock.Anything, "technology", model.FeedFormatAtom).Return(doc, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/rss/technology?format=atom", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+	c.SetParamNames("category")
+	c.SetParamValues("technology")
+
+	err := suite.handler.GetCategoryFeed(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), "application/atom+xml; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+}
+
+func (suite *SyndicationHandlerTestSuite) TestGetFeedInvalidFormat() {
+	suite.mockService.On("Feed", mock.Anything, "", model.FeedFormat("json")).Return(nil, service.ErrFeedFormatInvalid)
+
+	req := httptest.NewRequest(http.MethodGet, "/rss?format=json", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetFeed(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"INVALID_PARAMETER"`)
+}
+
+func (suite *SyndicationHandlerTestSuite) TestGetSitemapPage() {
+	doc := &model.SyndicationDocument{Body: []byte(`<urlset></urlset>`), ContentType: "application/xml; charset=utf-8"}
+
+	suite.mockService.On("Sitemap", mock.Anything, 2).Return(doc, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/sitemap.xml?page=2", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetSitemap(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), `<urlset></urlset>`, rec.Body.String())
+}
+
+func (suite *SyndicationHandlerTestSuite) TestGetSitemapInvalidPage() {
+	req := httptest.NewRequest(http.MethodGet, "/sitemap.xml?page=0", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetSitemap(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+}
+
+func (suite *SyndicationHandlerTestSuite) TestGetSitemapPageNotFound() {
+	suite.mockService.On("Sitemap", mock.Anything, 9).Return(nil, service.ErrSitemapPageNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/sitemap.xml?page=9", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.GetSitemap(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"NOT_FOUND"`)
+}
+
+func TestSyndicationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(SyndicationHandlerTestSuite))
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// tenantHandler implements TenantHandler interface
+type tenantHandler struct {
+	tenantService service.TenantService
+	logger        *logger.Logger
+}
+
+// NewTenantHandler creates a new tenant handler
+func NewTenantHandler(tenantService service.TenantService, logger *logger.Logger) TenantHandler {
+	return &tenantHandler{
+		tenantService: tenantService,
+		logger:        logger,
+	}
+}
+
+// ListTenants handles GET /api/v1/admin/tenants
+// @Summary      List tenants
+// @Description  List every tenant, including inactive ones. NewsAPI keys are never returned; has_news_api_key tells whether one is set.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=[]model.Tenant}        "Tenants"
+// @Failure      500  {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/tenants [get]
+func (h *tenantHandler) ListTenants(c echo.Context) error {
+	start := time.Now()
+
+	tenants, err := h.tenantService.ListTenants(c.Request().Context())
+	if err != nil {
+		h.logger.LogServiceOperation("tenant_handler", "list_tenants", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to list tenants")
+	}
+
+	h.logger.LogServiceOperation("tenant_handler", "list_tenants", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, tenants)
+}
+
+// UpsertTenant handles PUT /api/v1/admin/tenants/:id
+// @Summary      Create or update a tenant
+// @Description  Create a tenant or update an existing one. Omit news_api_key to keep the current key or send an empty string to fall back to the deployment's key. A news_api_daily_quota of 0 means unlimited.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                    true  "Tenant ID"
+// @Param        tenant  body      model.UpsertTenantParams  true  "Tenant settings"
+// @Success      200     {object}  response.APIResponse{data=model.Tenant}          "Tenant saved"
+// @Failure      400     {object}  response.APIResponse{error=response.ErrorInfo}  "Invalid tenant"
+// @Failure      500     {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /admin/tenants/{id} [put]
+func (h *tenantHandler) UpsertTenant(c echo.Context) error {
+	start := time.Now()
+
+	var req model.UpsertTenantParams
+	if err := c.Bind(&req); err != nil {
+		h.logger.LogServiceOperation("tenant_handler", "upsert_tenant", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidRequestBody, "Invalid request body", err.Error())
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("tenant_handler", "upsert_tenant", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	tenant, err := h.tenantService.UpsertTenant(c.Request().Context(), c.Param("id"), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("tenant_handler", "upsert_tenant", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, service.ErrTenantIDInvalid) {
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid tenant ID", err.Error())
+		}
+
+		return response.InternalServerError(c, "Failed to save tenant")
+	}
+
+	h.logger.LogServiceOperation("tenant_handler", "upsert_tenant", true, time.Since(start).Milliseconds())
+
+	return response.Success(c, http.StatusOK, tenant, "Tenant saved successfully")
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/labstack/echo/v4"
+)
+
+// TenantMiddleware resolves the tenant a request belongs to and scopes the
+// request context to it, so every query and cache key that follows only sees
+// that tenant's data. The tenant header takes precedence over the subdomain
+// of the base domain; requests carrying neither belong to the default tenant.
+func TenantMiddleware(tenants service.TenantService, cfg config.TenantConfig, logger *logger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := tenantID(c.Request().Header.Get(cfg.Header), c.Request().Host, cfg)
+			if !tenant.ValidID(id) {
+				return response.BadRequest(c, response.CodeInvalidParameter, "Invalid tenant ID", service.ErrTenantIDInvalid.Error())
+			}
+
+			ctx := c.Request().Context()
+			if _, err := tenants.Resolve(ctx, id); err != nil {
+				if errors.Is(err, service.ErrTenantNotFound) {
+					return response.NotFound(c, response.CodeTenantNotFound, "Tenant not found")
+				}
+
+				logger.Error("Failed to resolve tenant", "tenant", id, "error", err.Error())
+				return response.InternalServerError(c, "Failed to resolve tenant")
+			}
+
+			c.SetRequest(c.Request().WithContext(tenant.WithTenant(ctx, id)))
+
+			return next(c)
+		}
+	}
+}
+
+// tenantID picks the tenant ID from the header value or the request host
+func tenantID(header, host string, cfg config.TenantConfig) string {
+	if cfg.Header != "" {
+		if id := strings.TrimSpace(header); id != "" {
+			return strings.ToLower(id)
+		}
+	}
+
+	if cfg.BaseDomain == "" {
+		return tenant.Default
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	sub, ok := strings.CutSuffix(host, "."+cfg.BaseDomain)
+	if !ok || sub == "www" {
+		return tenant.Default
+	}
+
+	return sub
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockTenantService is a mock implementation of TenantService
+type MockTenantService struct {
+	mock.Mock
+}
+
+func (m *MockTenantService) Resolve(ctx context.Context, id string) (*model.Tenant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) ActiveTenants(ctx context.Context) ([]model.Tenant, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) ListTenants(ctx context.Context) ([]model.Tenant, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) UpsertTenant(ctx context.Context, id string, req *model.UpsertTenantParams) (*model.Tenant, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) ReserveNewsAPIRequest(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+// TenantMiddlewareTestSuite defines the test suite for TenantMiddleware
+type TenantMiddlewareTestSuite struct {
+	suite.Suite
+	mockService *MockTenantService
+	echo        *echo.Echo
+	resolved    string
+}
+
+func (suite *TenantMiddlewareTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+	tenantCfg := config.TenantConfig{Enabled: true, Header: "X-Tenant-ID", BaseDomain: "news.example.com"}
+
+	suite.mockService = new(MockTenantService)
+	suite.resolved = ""
+	suite.echo = echo.New()
+	suite.echo.Use(TenantMiddleware(suite.mockService, tenantCfg, logger.New(cfg)))
+	suite.echo.GET("/", func(c echo.Context) error {
+		suite.resolved = tenant.FromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	})
+}
+
+func (suite *TenantMiddlewareTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *TenantMiddlewareTestSuite) serve(host, header string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = host
+	if header != "" {
+		req.Header.Set("X-Tenant-ID", header)
+	}
+	rec := httptest.NewRecorder()
+	suite.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (suite *TenantMiddlewareTestSuite) TestResolvesFromSubdomain() {
+	suite.mockService.On("Resolve", mock.Anything, "acme").Return(&model.Tenant{ID: "acme", Active: true}, nil)
+
+	rec := suite.serve("acme.news.example.com:8080", "")
+
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), "acme", suite.resolved)
+}
+
+func (suite *TenantMiddlewareTestSuite) TestHeaderTakesPrecedence() {
+	suite.mockService.On("Resolve", mock.Anything, "globex").Return(&model.Tenant{ID: "globex", Active: true}, nil)
+
+	rec := suite.serve("acme.news.example.com", "Globex")
+
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), "globex", suite.resolved)
+}
+
+func (suite *TenantMiddlewareTestSuite) TestBareDomainIsDefaultTenant() {
+	suite.mockService.On("Resolve", mock.Anything, tenant.Default).Return(&model.Tenant{ID: tenant.Default, Active: true}, nil)
+
+	for _, host := range []string{"news.example.com", "www.news.example.com", "localhost:8080"} {
+		rec := suite.serve(host, "")
+
+		assert.Equal(suite.T(), http.StatusOK, rec.Code, host)
+		assert.Equal(suite.T(), tenant.Default, suite.resolved, host)
+	}
+}
+
+func (suite *TenantMiddlewareTestSuite) TestUnknownTenant() {
+	suite.mockService.On("Resolve", mock.Anything, "ghost").Return(nil, service.ErrTenantNotFound)
+
+	rec := suite.serve("ghost.news.example.com", "")
+
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"TENANT_NOT_FOUND"`)
+}
+
+func (suite *TenantMiddlewareTestSuite) TestMalformedTenant() {
+	rec := suite.serve("news.example.com", "not a tenant")
+
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"code":"INVALID_PARAMETER"`)
+}
+
+func TestTenantMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(TenantMiddlewareTestSuite))
+}
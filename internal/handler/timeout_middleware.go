@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// aggregationPathPrefix is the prefix of the aggregation trigger routes
+const aggregationPathPrefix = APIBasePath + "/aggregation/"
+
+// Timeout cancels the request context once the request has run for the
+// configured timeout: RequestTimeout, or AggregationTimeout for aggregation
+// triggers. A handler that has not started its response by then gets it
+// replaced by a 504 in the standard error format, so a cancelled query is
+// reported as a timeout rather than as the error the handler made of it.
+func Timeout(cfg config.ServerConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			timeout := cfg.RequestTimeout
+			if strings.HasPrefix(c.Path(), aggregationPathPrefix) {
+				timeout = cfg.AggregationTimeout
+			}
+			if timeout <= 0 {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			res := c.Response()
+			writer := &timeoutWriter{ResponseWriter: res.Writer, ctx: ctx}
+			res.Writer = writer
+
+			err := next(c)
+			res.Writer = writer.ResponseWriter
+
+			if writer.started || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return err
+			}
+
+			// The discarded response may have been marked committed, so the
+			// timeout is written through a fresh one
+			header := res.Header()
+			header.Del(echo.HeaderContentLength)
+			header.Del("ETag")
+			header.Del(echo.HeaderLastModified)
+			c.SetResponse(echo.NewResponse(writer.ResponseWriter, c.Echo()))
+
+			return response.GatewayTimeout(c, "Request timed out", "the request took longer than "+timeout.String())
+		}
+	}
+}
+
+// timeoutWriter passes writes through until the request context's deadline.
+// A response started before the deadline is completed; one that would start
+// after it is discarded.
+type timeoutWriter struct {
+	http.ResponseWriter
+	ctx        context.Context
+	started    bool
+	discarding bool
+}
+
+// discard reports whether a write must be dropped
+func (w *timeoutWriter) discard() bool {
+	if !w.started && !w.discarding {
+		if errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+			w.discarding = true
+		} else {
+			w.started = true
+		}
+	}
+
+	return w.discarding
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if !w.discard() {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	if w.discard() {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *timeoutWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTimeouts = config.ServerConfig{
+	RequestTimeout:     20 * time.Millisecond,
+	AggregationTimeout: time.Hour,
+}
+
+// newTimeoutEcho serves /slow, which fails once its context is done, /fast
+// and /deadline, which reports the remaining time, under the API base path
+// and aggregationPathPrefix
+func newTimeoutEcho(cfg config.ServerConfig) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = response.HTTPErrorHandler
+	e.Use(Timeout(cfg))
+
+	for _, prefix := range []string{APIBasePath + "/", aggregationPathPrefix} {
+		e.GET(prefix+"slow", func(c echo.Context) error {
+			<-c.Request().Context().Done()
+			return response.InternalServerError(c, "Query failed", c.Request().Context().Err().Error())
+		})
+		e.GET(prefix+"fast", func(c echo.Context) error {
+			return response.Success(c, http.StatusOK, "done")
+		})
+		e.GET(prefix+"deadline", func(c echo.Context) error {
+			deadline, ok := c.Request().Context().Deadline()
+			if !ok {
+				return c.String(http.StatusOK, "none")
+			}
+			return c.String(http.StatusOK, time.Until(deadline).Round(time.Minute).String())
+		})
+	}
+
+	return e
+}
+
+func TestTimeoutRespondsWithGatewayTimeout(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTimeoutEcho(testTimeouts).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIBasePath+"/slow", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+
+	var body response.APIResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.False(t, body.Success)
+	require.NotNil(t, body.Error)
+	assert.Equal(t, response.CodeTimeout, body.Error.Code)
+}
+
+func TestTimeoutPassesFastResponses(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTimeoutEcho(testTimeouts).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIBasePath+"/fast", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"data":"done"`)
+}
+
+func TestTimeoutKeepsResponsesStartedBeforeTheDeadline(t *testing.T) {
+	e := echo.New()
+	e.Use(Timeout(testTimeouts))
+	e.GET("/stream", func(c echo.Context) error {
+		c.Response().WriteHeader(http.StatusOK)
+		<-c.Request().Context().Done()
+		_, err := c.Response().Write([]byte("late"))
+		return err
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "late", rec.Body.String())
+}
+
+func TestTimeoutUsesAggregationTimeoutForTriggers(t *testing.T) {
+	e := newTimeoutEcho(testTimeouts)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, aggregationPathPrefix+"deadline", nil))
+	assert.Equal(t, "1h0m0s", rec.Body.String())
+}
+
+func TestTimeoutDisabled(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTimeoutEcho(config.ServerConfig{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, APIBasePath+"/deadline", nil))
+
+	assert.Equal(t, "none", rec.Body.String())
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// topicHandler implements TopicHandler interface
+type topicHandler struct {
+	topicService service.TopicService
+	logger       *logger.Logger
+}
+
+// NewTopicHandler creates a new topic handler
+func NewTopicHandler(topicService service.TopicService, logger *logger.Logger) TopicHandler {
+	return &topicHandler{
+		topicService: topicService,
+		logger:       logger,
+	}
+}
+
+// ListTopics handles GET /api/v1/topics
+// @Summary      List topics
+// @Description  List clusters of posts covering the same story, most recently active first. Each topic carries its number of published posts and its representative, the earliest published post. Only topics with at least two posts are listed by default.
+// @Tags         topics
+// @Accept       json
+// @Produce      json
+// @Param        page        query     int     false  "Page number"
+// @Param        limit       query     int     false  "Results per page"
+// @Param        min_members query     int     false  "Minimum number of posts in a topic (default 2)"
+// @Param        safe_mode   query     bool    false  "Exclude posts flagged as sensitive"
+// @Success      200         {object}  response.APIResponse{data=response.PaginatedResponse{items=[]model.Topic,pagination=response.PaginationInfo}}  "List of topics"
+// @Failure      400         {object}  response.APIResponse{error=response.ErrorInfo}  "Validation error"
+// @Failure      500         {object}  response.APIResponse{error=response.ErrorInfo}  "Internal server error"
+// @Router       /topics [get]
+func (h *topicHandler) ListTopics(c echo.Context) error {
+	start := time.Now()
+
+	req := model.DefaultTopicListParams()
+
+	if pageParam := c.QueryParam("page"); pageParam != "" {
+		if page, err := strconv.Atoi(pageParam); err == nil && page > 0 {
+			req.Page = page
+		}
+	}
+
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		if limit, err := strconv.Atoi(limitParam); err == nil && limit > 0 && limit <= 100 {
+			req.Limit = limit
+		}
+	}
+
+	filters := make(map[string]string)
+	if minParam := c.QueryParam("min_members"); minParam != "" {
+		minMembers, err := strconv.Atoi(minParam)
+		if err != nil {
+			h.logger.LogServiceOperation("topic_handler", "list_topics", false, time.Since(start).Milliseconds())
+			return response.BadRequest(c, response.CodeInvalidParameter, "Invalid min_members parameter")
+		}
+		req.MinMembers = minMembers
+		filters["min_members"] = minParam
+	}
+
+	safeMode, err := parseSafeMode(c)
+	if err != nil {
+		h.logger.LogServiceOperation("topic_handler", "list_topics", false, time.Since(start).Milliseconds())
+		return response.BadRequest(c, response.CodeInvalidParameter, "Invalid safe_mode parameter")
+	}
+	if safeMode {
+		req.SafeMode = true
+		filters["safe_mode"] = "true"
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.logger.LogServiceOperation("topic_handler", "list_topics", false, time.Since(start).Milliseconds())
+		return response.ValidationError(c, err)
+	}
+
+	topics, err := h.topicService.ListTopics(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.LogServiceOperation("topic_handler", "list_topics", false, time.Since(start).Milliseconds())
+		return response.InternalServerError(c, "Failed to retrieve topics")
+	}
+
+	h.logger.LogServiceOperation("topic_handler", "list_topics", true, time.Since(start).Milliseconds())
+
+	paginationInfo := response.CreatePaginationInfo(req.Page, req.Limit, int(topics.Pagination.Total))
+
+	return response.SuccessWithPagination(c, topics.Topics, paginationInfo, filters)
+}
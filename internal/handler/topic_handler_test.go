@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockTopicService is a mock implementation of TopicService
+type MockTopicService struct {
+	mock.Mock
+}
+
+func (m *MockTopicService) ClusterPendingPosts(ctx context.Context) (*model.TopicClusteringResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TopicClusteringResult), args.Error(1)
+}
+
+func (m *MockTopicService) ListTopics(ctx context.Context, req *model.TopicListParams) (*model.TopicListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TopicListResponse), args.Error(1)
+}
+
+// TopicHandlerTestSuite defines the test suite for TopicHandler
+type TopicHandlerTestSuite struct {
+	suite.Suite
+	mockService *MockTopicService
+	handler     TopicHandler
+	echo        *echo.Echo
+}
+
+func (suite *TopicHandlerTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockService = new(MockTopicService)
+	suite.handler = NewTopicHandler(suite.mockService, logger.New(cfg))
+	suite.echo = echo.New()
+	suite.echo.Validator = &MockValidator{}
+}
+
+func (suite *TopicHandlerTestSuite) TearDownTest() {
+	suite.mockService.AssertExpectations(suite.T())
+}
+
+func (suite *TopicHandlerTestSuite) TestListTopicsSuccess() {
+	result := &model.TopicListResponse{
+		Topics: []model.Topic{
+			{ID: 7, MemberCount: 3, Representative: &model.Post{ID: 7, Title: "Apple unveils iPhone 17"}},
+		},
+		Pagination: model.CalculatePagination(1, 20, 1),
+	}
+
+	suite.mockService.On("ListTopics", mock.Anything, &model.TopicListParams{Page: 1, Limit: 20, MinMembers: 2}).Return(result, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/topics", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListTopics(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"member_count":3`)
+	assert.Contains(suite.T(), rec.Body.String(), "Apple unveils iPhone 17")
+}
+
+func (suite *TopicHandlerTestSuite) TestListTopicsWithFilters() {
+	result := &model.TopicListResponse{Topics: []model.Topic{}, Pagination: model.CalculatePagination(2, 5, 0)}
+
+	suite.mockService.On("ListTopics", mock.Anything, &model.TopicListParams{Page: 2, Limit: 5, MinMembers: 1, SafeMode: true}).Return(result, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/topics?page=2&limit=5&min_members=1&safe_mode=true", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListTopics(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Contains(suite.T(), rec.Body.String(), `"min_members":"1"`)
+}
+
+func (suite *TopicHandlerTestSuite) TestListTopicsInvalidMinMembers() {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/topics?min_members=many", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListTopics(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	suite.mockService.AssertNotCalled(suite.T(), "ListTopics", mock.Anything, mock.Anything)
+}
+
+func (suite *TopicHandlerTestSuite) TestListTopicsServiceError() {
+	suite.mockService.On("ListTopics", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/topics", nil)
+	rec := httptest.NewRecorder()
+	c := suite.echo.NewContext(req, rec)
+
+	err := suite.handler.ListTopics(c)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), http.StatusInternalServerError, rec.Code)
+}
+
+func TestTopicHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(TopicHandlerTestSuite))
+}
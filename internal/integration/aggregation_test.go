@@ -0,0 +1,68 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerCategoryAggregation(t *testing.T) {
+	server := testutil.StartServer(t)
+
+	body := model.CategoryAggregationRequest{Categories: []string{"technology"}, Countries: []string{"us"}}
+
+	resp := server.Do(t, http.MethodPost, "/aggregation/trigger/categories", body)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result model.CategoryAggregationResponse
+	testutil.DecodeData(t, resp, &result)
+	assert.Equal(t, server.NewsAPI.ArticlesPerQuery, result.Result.TotalCreated)
+	assert.Zero(t, result.Result.TotalErrors)
+
+	requests := server.NewsAPI.Requests()
+	require.NotEmpty(t, requests)
+	assert.True(t, strings.Contains(requests[0], "technology"), "unexpected NewsAPI request %s", requests[0])
+
+	resp = server.Do(t, http.MethodGet, "/posts/category/technology", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var page postPage
+	testutil.DecodeData(t, resp, &page)
+	assert.Len(t, page.Items, server.NewsAPI.ArticlesPerQuery)
+	for _, post := range page.Items {
+		assert.Equal(t, "Mock News", post.Source)
+	}
+
+	// A second run finds only articles it already stored
+	resp = server.Do(t, http.MethodPost, "/aggregation/trigger/categories", body)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	testutil.DecodeData(t, resp, &result)
+	assert.Zero(t, result.Result.TotalCreated)
+}
+
+func TestTriggerTopHeadlines(t *testing.T) {
+	server := testutil.StartServer(t)
+
+	resp := server.Do(t, http.MethodPost, "/aggregation/trigger/headlines", nil)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result model.AggregationResponse
+	testutil.DecodeData(t, resp, &result)
+	assert.Positive(t, result.TotalCreated)
+	assert.Zero(t, result.TotalErrors)
+
+	resp = server.Do(t, http.MethodGet, "/posts?limit=100", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var page postPage
+	testutil.DecodeData(t, resp, &page)
+	assert.Equal(t, result.TotalCreated, page.Pagination.Total)
+}
@@ -0,0 +1,4 @@
+// Package integration holds black-box tests that drive the complete server
+// over HTTP. They are built with the integration tag and need the services of
+// the docker compose test profile; run them with make test-integration.
+package integration
@@ -0,0 +1,99 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/testutil"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postPage is the data of a paginated post listing
+type postPage struct {
+	Items      []model.Post            `json:"items"`
+	Pagination response.PaginationInfo `json:"pagination"`
+}
+
+func createPost(t *testing.T, server *testutil.Server, params model.CreatePostParams) model.Post {
+	t.Helper()
+
+	resp := server.Do(t, http.MethodPost, "/posts", params)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var post model.Post
+	testutil.DecodeData(t, resp, &post)
+
+	return post
+}
+
+func TestPostCRUD(t *testing.T) {
+	server := testutil.StartServer(t)
+
+	category := "technology"
+	created := createPost(t, server, model.CreatePostParams{
+		Title:    "Go 1.25 released",
+		URL:      "https://go.dev/blog/go1.25",
+		Source:   "The Go Blog",
+		Category: &category,
+	})
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, 1, created.Version)
+
+	path := fmt.Sprintf("/posts/%d", created.ID)
+
+	resp := server.Do(t, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"1"`, resp.Header.Get("ETag"))
+
+	var fetched model.Post
+	testutil.DecodeData(t, resp, &fetched)
+	assert.Equal(t, created.Title, fetched.Title)
+
+	resp = server.Do(t, http.MethodPut, path, model.UpdatePostParams{Title: "Go 1.25 is out", Version: created.Version})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var updated model.Post
+	testutil.DecodeData(t, resp, &updated)
+	assert.Equal(t, "Go 1.25 is out", updated.Title)
+	assert.Equal(t, 2, updated.Version)
+
+	// The first version is stale now
+	resp = server.Do(t, http.MethodPut, path, model.UpdatePostParams{Title: "Stale edit", Version: created.Version})
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	resp = server.Do(t, http.MethodGet, "/posts?category=technology", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var page postPage
+	testutil.DecodeData(t, resp, &page)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, created.ID, page.Items[0].ID)
+
+	resp = server.Do(t, http.MethodDelete, path, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = server.Do(t, http.MethodGet, path, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, response.CodePostNotFound, testutil.DecodeData(t, resp, nil).Error.Code)
+}
+
+func TestCreatePostRejectsDuplicateURL(t *testing.T) {
+	server := testutil.StartServer(t)
+
+	params := model.CreatePostParams{
+		Title:  "Original",
+		URL:    "https://example.com/original",
+		Source: "Example",
+	}
+	createPost(t, server, params)
+
+	resp := server.Do(t, http.MethodPost, "/posts", params)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.False(t, testutil.DecodeData(t, resp, nil).Success)
+}
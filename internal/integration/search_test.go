@@ -0,0 +1,61 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/testutil"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPosts(t *testing.T) {
+	server := testutil.StartServer(t)
+
+	technology, business := "technology", "business"
+	createPost(t, server, model.CreatePostParams{
+		Title:    "Kubernetes adds sidecar containers",
+		URL:      "https://example.com/kubernetes-sidecars",
+		Source:   "Example",
+		Category: &technology,
+	})
+	createPost(t, server, model.CreatePostParams{
+		Title:    "Markets rally on Kubernetes vendor earnings",
+		URL:      "https://example.com/kubernetes-earnings",
+		Source:   "Example",
+		Category: &business,
+	})
+	createPost(t, server, model.CreatePostParams{
+		Title:    "Unrelated weather report",
+		URL:      "https://example.com/weather",
+		Source:   "Example",
+		Category: &business,
+	})
+
+	resp := server.Do(t, http.MethodGet, "/posts/search?q=kubernetes", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var page postPage
+	testutil.DecodeData(t, resp, &page)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, 2, page.Pagination.Total)
+
+	resp = server.Do(t, http.MethodGet, "/posts/search?q=kubernetes&category=technology", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	testutil.DecodeData(t, resp, &page)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "Kubernetes adds sidecar containers", page.Items[0].Title)
+}
+
+func TestSearchPostsRequiresQuery(t *testing.T) {
+	server := testutil.StartServer(t)
+
+	resp := server.Do(t, http.MethodGet, "/posts/search", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, response.CodeMissingParameter, testutil.DecodeData(t, resp, nil).Error.Code)
+}
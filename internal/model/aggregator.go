@@ -7,10 +7,40 @@ type AggregationResponse struct {
 	TotalCreated    int                      `json:"total_created" example:"120"`
 	TotalDuplicates int                      `json:"total_duplicates" example:"25"`
 	TotalErrors     int                      `json:"total_errors" example:"5"`
+	TotalRejected   int                      `json:"total_rejected" example:"3"`
 	Duration        time.Duration            `json:"duration" swaggertype:"string" example:"1s"`
 	Categories      map[string]CategoryStats `json:"categories,omitempty"`
+	Countries       map[string]CountryStats  `json:"countries,omitempty"`
 	Sources         map[string]SourceStats   `json:"sources,omitempty"`
-	Errors          []string                 `json:"errors,omitempty" example:"[]"`
+	Errors          []AggregationError       `json:"errors,omitempty"`
+	// ErrorCounts tallies failures per type, including duplicates
+	ErrorCounts map[AggregationErrorType]int `json:"error_counts,omitempty"`
+	// RejectionCounts tallies articles dropped by the quality filter per rule
+	RejectionCounts map[FilterRule]int `json:"rejection_counts,omitempty"`
+	// TimedOut is set when the deadline passed before the run finished; the
+	// totals then cover only the work done in time
+	TimedOut bool `json:"timed_out,omitempty" example:"false"`
+	// SkippedCategories and SkippedSources list what was not fully processed
+	// because the run timed out or was cancelled
+	SkippedCategories []string `json:"skipped_categories,omitempty" example:"[\"sports\"]"`
+	SkippedSources    []string `json:"skipped_sources,omitempty" example:"[\"wired\"]"`
+}
+
+// AggregationErrorType classifies a failure recorded during aggregation
+type AggregationErrorType string
+
+const (
+	AggregationErrorProvider    AggregationErrorType = "provider_error"
+	AggregationErrorRateLimited AggregationErrorType = "rate_limited"
+	AggregationErrorParse       AggregationErrorType = "parse_error"
+	AggregationErrorDuplicate   AggregationErrorType = "duplicate"
+	AggregationErrorDB          AggregationErrorType = "db_error"
+)
+
+// AggregationError describes a single failure recorded during aggregation
+type AggregationError struct {
+	Type    AggregationErrorType `json:"type" example:"provider_error"`
+	Message string               `json:"message" example:"news provider request failed: rate limited"`
 }
 
 type BaseStats struct {
@@ -18,6 +48,7 @@ type BaseStats struct {
 	Created    int `json:"created" example:"80"`
 	Duplicates int `json:"duplicates" example:"15"`
 	Errors     int `json:"errors" example:"2"`
+	Rejected   int `json:"rejected" example:"3"`
 }
 
 type CategoryStats struct {
@@ -28,20 +59,62 @@ type SourceStats struct {
 	BaseStats
 }
 
+type CountryStats struct {
+	BaseStats
+}
+
+// AggregationQuery holds optional NewsAPI overrides for a manual aggregation run.
+// Zero values keep the aggregator defaults.
+type AggregationQuery struct {
+	PageSize int        `json:"page_size,omitempty" validate:"omitempty,min=1,max=100" example:"50"`
+	Language string     `json:"language,omitempty" validate:"omitempty,len=2" example:"en"`
+	From     *time.Time `json:"from,omitempty" swaggertype:"string" example:"2024-01-20T00:00:00Z"`
+	To       *time.Time `json:"to,omitempty" swaggertype:"string" example:"2024-01-21T00:00:00Z"`
+}
+
+// Covers reports whether an article published at the given RFC 3339 timestamp
+// falls inside the query's date range. Unparseable timestamps are kept.
+func (q AggregationQuery) Covers(publishedAt string) bool {
+	if q.From == nil && q.To == nil {
+		return true
+	}
+
+	t, err := time.Parse(time.RFC3339, publishedAt)
+	if err != nil {
+		return true
+	}
+
+	if q.From != nil && t.Before(*q.From) {
+		return false
+	}
+	if q.To != nil && t.After(*q.To) {
+		return false
+	}
+
+	return true
+}
+
 // CategoryAggregationRequest represents the request body for category aggregation
 type CategoryAggregationRequest struct {
 	Categories []string `json:"categories,omitempty" example:"[\"technology\",\"business\"]"`
+	Country    string   `json:"country,omitempty" validate:"omitempty,len=2" example:"us"`
+	Countries  []string `json:"countries,omitempty" validate:"omitempty,dive,len=2" example:"[\"us\",\"gb\"]"`
+	AggregationQuery
 }
 
 // CategoryAggregationResponse represents the response for category aggregation
 type CategoryAggregationResponse struct {
 	Categories []string            `json:"categories" example:"[\"technology\"]"`
+	Countries  []string            `json:"countries,omitempty" example:"[\"us\"]"`
 	Result     AggregationResponse `json:"result"`
 }
 
-// SourceAggregationRequest represents the request body for source aggregation
+// SourceAggregationRequest represents the request body for source aggregation.
+// NewsAPI cannot combine sources with a country filter, so Country is rejected.
 type SourceAggregationRequest struct {
 	Sources []string `json:"sources,omitempty" example:"[\"techcrunch\",\"wired\"]"`
+	Country string   `json:"country,omitempty" example:"us"`
+	AggregationQuery
 }
 
 // SourceAggregationResponse represents the response for source aggregation
@@ -49,3 +122,16 @@ type SourceAggregationResponse struct {
 	Sources []string            `json:"sources" example:"[\"techcrunch\"]"`
 	Result  AggregationResponse `json:"result"`
 }
+
+// FetchScope identifies the kind of aggregation query a fetch watermark
+// belongs to
+type FetchScope string
+
+const (
+	// FetchScopeTopHeadlines watermarks are keyed by "category:country"
+	FetchScopeTopHeadlines FetchScope = "top_headlines"
+	// FetchScopeEverything watermarks are keyed by category
+	FetchScopeEverything FetchScope = "everything"
+	// FetchScopeSource watermarks are keyed by source ID
+	FetchScopeSource FetchScope = "source"
+)
@@ -0,0 +1,123 @@
+package model
+
+import "time"
+
+// CTR grouping dimensions
+const (
+	CTRGroupBySource   = "source"
+	CTRGroupByCategory = "category"
+)
+
+// PostClick records a single click-through to a post's article
+type PostClick struct {
+	ID        int64     `json:"id" example:"1"`
+	PostID    int64     `json:"post_id" example:"42"`
+	Referrer  *string   `json:"referrer,omitempty" example:"https://example.com/feed"`
+	UserAgent *string   `json:"user_agent,omitempty" example:"Mozilla/5.0"`
+	CreatedAt time.Time `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// PostClickCount is the number of clicks a post received, with the fields CTR is grouped by
+type PostClickCount struct {
+	PostID   int64
+	Source   string
+	Category *string
+	Clicks   int64
+}
+
+// CTRParams represents the request parameters for click-through analytics
+type CTRParams struct {
+	GroupBy string `json:"group_by" validate:"oneof=source category" example:"source"`
+	Days    int    `json:"days" validate:"min=1,max=90" example:"7"`
+}
+
+// CTRStat holds clicks, views and click-through rate for one source or category
+type CTRStat struct {
+	Key    string  `json:"key" example:"TechCrunch"`
+	Views  int64   `json:"views" example:"1200"`
+	Clicks int64   `json:"clicks" example:"84"`
+	CTR    float64 `json:"ctr" example:"0.07"`
+}
+
+// CTRResponse represents click-through analytics for posts created in the window
+type CTRResponse struct {
+	GroupBy string    `json:"group_by" example:"source"`
+	Since   time.Time `json:"since" swaggertype:"string" example:"2025-08-04T07:11:03Z"`
+	Stats   []CTRStat `json:"stats"`
+}
+
+// SearchQuery records one search and how it performed
+type SearchQuery struct {
+	ID          int64     `json:"id" example:"1"`
+	Term        string    `json:"term" example:"climate summit"`
+	ResultCount int64     `json:"result_count" example:"12"`
+	LatencyMs   int64     `json:"latency_ms" example:"35"`
+	ZeroResults bool      `json:"zero_results" example:"false"`
+	CreatedAt   time.Time `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// SearchAnalyticsParams represents the request parameters for search analytics
+type SearchAnalyticsParams struct {
+	Days  int `json:"days" validate:"min=1,max=90" example:"7"`
+	Limit int `json:"limit" validate:"min=1,max=100" example:"20"`
+}
+
+// SearchTermStat summarises the searches for one term
+type SearchTermStat struct {
+	Term           string    `json:"term" example:"climate summit"`
+	Searches       int64     `json:"searches" example:"42"`
+	AvgResults     float64   `json:"avg_results" example:"11.5"`
+	AvgLatencyMs   float64   `json:"avg_latency_ms" example:"31.2"`
+	LastSearchedAt time.Time `json:"last_searched_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// SearchTotals counts the searches in a window and how many found nothing
+type SearchTotals struct {
+	Searches           int64
+	ZeroResultSearches int64
+}
+
+// SearchAnalyticsResponse represents the most frequent and the zero-result
+// search terms in the window
+type SearchAnalyticsResponse struct {
+	Since              time.Time        `json:"since" swaggertype:"string" example:"2025-08-04T07:11:03Z"`
+	TotalSearches      int64            `json:"total_searches" example:"1500"`
+	ZeroResultSearches int64            `json:"zero_result_searches" example:"120"`
+	ZeroResultRate     float64          `json:"zero_result_rate" example:"0.08"`
+	TopQueries         []SearchTermStat `json:"top_queries"`
+	ZeroResultQueries  []SearchTermStat `json:"zero_result_queries"`
+}
+
+// Post statistics grouping dimensions
+const (
+	PostStatsGroupByCategory = "category"
+	PostStatsGroupBySource   = "source"
+	PostStatsGroupByCountry  = "country"
+	PostStatsGroupByDay      = "day"
+)
+
+// PostStatsParams represents the request parameters for post ingestion
+// statistics over [From, To)
+type PostStatsParams struct {
+	GroupBy string    `json:"group_by" validate:"oneof=category source country day" example:"category"`
+	From    time.Time `json:"from" swaggertype:"string" example:"2025-07-12T00:00:00Z"`
+	To      time.Time `json:"to" swaggertype:"string" example:"2025-08-11T00:00:00Z"`
+}
+
+// PostStatsBucket counts the posts ingested on one day, per category, source
+// or country unless grouped by day alone
+type PostStatsBucket struct {
+	Day   string `json:"day" example:"2025-08-11"`
+	Key   string `json:"key,omitempty" example:"technology"`
+	Count int64  `json:"count" example:"42"`
+}
+
+// PostStatsResponse represents the number of posts ingested per day in the
+// window, in day order and, within a day, busiest first
+type PostStatsResponse struct {
+	GroupBy string            `json:"group_by" example:"category"`
+	From    time.Time         `json:"from" swaggertype:"string" example:"2025-07-12T00:00:00Z"`
+	To      time.Time         `json:"to" swaggertype:"string" example:"2025-08-11T00:00:00Z"`
+	Total   int64             `json:"total" example:"1250"`
+	Buckets []PostStatsBucket `json:"buckets"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+// BulkPostFilter selects the posts of a bulk operation. Every set field must
+// match; IDs lists the posts explicitly, Domain matches the host of the post
+// URL and its subdomains and the date range applies to the publication date.
+type BulkPostFilter struct {
+	IDs      []int64    `json:"ids,omitempty" validate:"omitempty,max=1000,dive,min=1" example:"1,2,3"`
+	Source   *string    `json:"source,omitempty" validate:"omitempty,max=100" example:"TechCrunch"`
+	Category *string    `json:"category,omitempty" validate:"omitempty,max=50" example:"technology"`
+	Domain   *string    `json:"domain,omitempty" validate:"omitempty,max=255" example:"techcrunch.com"`
+	From     *time.Time `json:"from,omitempty" swaggertype:"string" example:"2024-01-01T00:00:00Z"`
+	To       *time.Time `json:"to,omitempty" swaggertype:"string" example:"2024-01-31T23:59:59Z"`
+}
+
+// IsEmpty reports whether the filter would select every post
+func (f *BulkPostFilter) IsEmpty() bool {
+	return len(f.IDs) == 0 && f.Source == nil && f.Category == nil && f.Domain == nil && f.From == nil && f.To == nil
+}
+
+// BulkPostChanges lists the fields a bulk update sets; nil fields are kept
+type BulkPostChanges struct {
+	Status    *PostStatus `json:"status,omitempty" validate:"omitempty,oneof=draft published hidden" example:"hidden"`
+	Category  *string     `json:"category,omitempty" validate:"omitempty,min=1,max=50" example:"business"`
+	Sensitive *bool       `json:"sensitive,omitempty" example:"true"`
+}
+
+// IsEmpty reports whether the changes would leave posts as they are
+func (c *BulkPostChanges) IsEmpty() bool {
+	return c.Status == nil && c.Category == nil && c.Sensitive == nil
+}
+
+// BulkUpdatePostsParams applies the same changes to every post matching Filter.
+// With DryRun set nothing is written and only the affected count is returned.
+type BulkUpdatePostsParams struct {
+	Filter  BulkPostFilter  `json:"filter"`
+	Changes BulkPostChanges `json:"changes"`
+	DryRun  bool            `json:"dry_run,omitempty" example:"true"`
+}
+
+// BulkDeletePostsParams deletes every post matching Filter. With DryRun set
+// nothing is deleted and only the affected count is returned.
+type BulkDeletePostsParams struct {
+	Filter BulkPostFilter `json:"filter"`
+	DryRun bool           `json:"dry_run,omitempty" example:"true"`
+}
+
+// BulkPostsBatchParams pages through the posts matching a bulk filter in ID order
+type BulkPostsBatchParams struct {
+	BulkPostFilter
+	AfterID int64
+	Limit   int
+}
+
+// BulkPostsResult reports the posts a bulk operation changed, or would
+// change in a dry run
+type BulkPostsResult struct {
+	Affected int64 `json:"affected" example:"42"`
+	DryRun   bool  `json:"dry_run" example:"false"`
+}
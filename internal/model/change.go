@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Post change types reported by the change feed
+const (
+	PostChangeCreated = "created"
+	PostChangeUpdated = "updated"
+	PostChangeDeleted = "deleted"
+)
+
+// PostChange is one entry of the post change feed: the post, what happened
+// to it and when
+type PostChange struct {
+	ID        int64
+	Type      string
+	ChangedAt time.Time
+}
+
+// PostChangesParams asks for the post changes after a marker, either an RFC
+// 3339 timestamp or the cursor returned by the previous call
+type PostChangesParams struct {
+	Since string `json:"since" validate:"required" example:"2024-01-20T10:00:00Z"`
+	Limit int    `json:"limit" validate:"min=1,max=1000" example:"100"`
+}
+
+// ListPostChangesParams selects up to Limit changes made after the change of
+// post AfterID at After and no later than Until, oldest first
+type ListPostChangesParams struct {
+	After   time.Time
+	AfterID int64
+	Until   time.Time
+	Limit   int
+}
+
+// PostChangesResponse lists the IDs of the posts created, updated and deleted
+// since the marker. Cursor resumes the feed after the last change listed;
+// HasMore tells whether more changes are waiting past it.
+type PostChangesResponse struct {
+	Created []int64 `json:"created" example:"125,126"`
+	Updated []int64 `json:"updated" example:"98"`
+	Deleted []int64 `json:"deleted" example:"77"`
+	Cursor  string  `json:"cursor" example:"MTcwNTc0NTAwMDAwMDAwMDoxMjY"`
+	HasMore bool    `json:"has_more" example:"false"`
+}
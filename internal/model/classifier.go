@@ -0,0 +1,14 @@
+package model
+
+// ClassificationInput is the text a sensitivity classifier inspects. It is
+// also the request body sent to an external classifier endpoint.
+type ClassificationInput struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	Content     *string `json:"content,omitempty"`
+}
+
+// ClassificationResult is the response expected from an external classifier endpoint
+type ClassificationResult struct {
+	Sensitive bool `json:"sensitive"`
+}
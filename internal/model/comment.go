@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Comment is a reader comment on a post. Replies point at their parent; a
+// deleted comment keeps its place in the thread with its author and body removed.
+type Comment struct {
+	ID        int64     `json:"id" example:"7"`
+	PostID    int64     `json:"post_id" example:"42"`
+	ParentID  *int64    `json:"parent_id,omitempty" example:"3"`
+	Author    string    `json:"author" example:"jane"`
+	Body      string    `json:"body" example:"Great write-up, thanks for sharing."`
+	Deleted   bool      `json:"deleted" example:"false"`
+	CreatedAt time.Time `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	UpdatedAt time.Time `json:"updated_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	Replies   []Comment `json:"replies,omitempty"`
+}
+
+// CreateCommentParams represents the request to comment on a post
+type CreateCommentParams struct {
+	Author   string `json:"author" validate:"required,min=1,max=100" example:"jane"`
+	Body     string `json:"body" validate:"required,min=1,max=5000" example:"Great write-up, thanks for sharing."`
+	ParentID *int64 `json:"parent_id,omitempty" validate:"omitempty,min=1" example:"3"`
+}
+
+// CommentListParams represents the request parameters for listing a post's
+// comments. Pagination applies to top-level comments; each carries its full reply tree.
+type CommentListParams struct {
+	PostID int64 `json:"-"`
+	Page   int   `json:"page" validate:"min=1" example:"1"`
+	Limit  int   `json:"limit" validate:"min=1,max=100" example:"20"`
+}
+
+// CommentListResponse represents the response for listing a post's comments
+type CommentListResponse struct {
+	Comments   []Comment      `json:"comments"`
+	Pagination PaginationMeta `json:"pagination"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// ConfigReloadResult reports which runtime settings a configuration reload changed
+type ConfigReloadResult struct {
+	ReloadedAt time.Time `json:"reloaded_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	// Changed lists the environment variables whose new values were applied
+	Changed []string `json:"changed" example:"LOG_LEVEL,CACHE_TTL"`
+}
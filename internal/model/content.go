@@ -0,0 +1,73 @@
+package model
+
+import "time"
+
+// ContentEnrichmentResult summarises one run of the content extraction job
+type ContentEnrichmentResult struct {
+	Processed int `json:"processed" example:"50"`
+	Extracted int `json:"extracted" example:"42"`
+	Skipped   int `json:"skipped" example:"5"`
+	Failed    int `json:"failed" example:"3"`
+}
+
+// ReprocessParams selects existing posts to send back through the enrichment
+// pipeline. Zero values leave the corresponding filter off.
+type ReprocessParams struct {
+	From     *time.Time `json:"from,omitempty" swaggertype:"string" example:"2024-01-01T00:00:00Z"`
+	To       *time.Time `json:"to,omitempty" swaggertype:"string" example:"2024-01-31T23:59:59Z"`
+	Category *string    `json:"category,omitempty" validate:"omitempty,max=50" example:"technology"`
+	// MissingContent limits the run to posts whose full content was never extracted
+	MissingContent bool `json:"missing_content,omitempty" example:"true"`
+}
+
+// ListPostsForReprocessParams pages through the posts matching a reprocess
+// filter in ID order
+type ListPostsForReprocessParams struct {
+	ReprocessParams
+	AfterID int64
+	Limit   int
+}
+
+// ReprocessStatus is the state of a reprocess run
+type ReprocessStatus string
+
+const (
+	ReprocessStatusRunning   ReprocessStatus = "running"
+	ReprocessStatusCompleted ReprocessStatus = "completed"
+	ReprocessStatusFailed    ReprocessStatus = "failed"
+)
+
+// ReprocessRun reports the progress of an admin-triggered enrichment run
+type ReprocessRun struct {
+	ID         string          `json:"id" example:"5f2b8c1e9a7d4e3f"`
+	Status     ReprocessStatus `json:"status" example:"running"`
+	Params     ReprocessParams `json:"params"`
+	Total      int             `json:"total" example:"120"`
+	Processed  int             `json:"processed" example:"48"`
+	Extracted  int             `json:"extracted" example:"40"`
+	Skipped    int             `json:"skipped" example:"5"`
+	Failed     int             `json:"failed" example:"3"`
+	Flagged    int             `json:"flagged" example:"2"`
+	Error      string          `json:"error,omitempty" example:"failed to list posts"`
+	StartedAt  time.Time       `json:"started_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty" swaggertype:"string" example:"2025-08-11T07:16:04Z"`
+}
+
+// ArticlePreviewRequest names an article page to preview before importing it
+type ArticlePreviewRequest struct {
+	URL string `json:"url" validate:"required,http_url,max=500" example:"https://example.com/article"`
+}
+
+// ArticlePreview is the post an article page would be stored as, with what
+// the enrichment pipeline found out about it. Nothing is saved; posts do not
+// store the language and tags, they are reported for vetting.
+type ArticlePreview struct {
+	Post      CreatePostParams `json:"post"`
+	Language  string           `json:"language,omitempty" example:"en"`
+	Tags      []string         `json:"tags" example:"artificial intelligence,chips"`
+	Sensitive bool             `json:"sensitive" example:"false"`
+	// Duplicate is set when a post with the same URL is already stored
+	Duplicate bool `json:"duplicate" example:"false"`
+	// Blocked is why the source rules would refuse the post, if they would
+	Blocked string `json:"blocked,omitempty" example:"domain example.com is blocked"`
+}
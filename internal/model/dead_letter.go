@@ -0,0 +1,41 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DeadLetter is an aggregated article that could not be stored, kept with
+// the error of its last attempt until it is retried or discarded
+type DeadLetter struct {
+	ID      int64   `json:"id" example:"1"`
+	URL     string  `json:"url" example:"https://example.com/article"`
+	Source  *string `json:"source,omitempty" example:"TechCrunch"`
+	Country *string `json:"country,omitempty" example:"us"`
+	// Payload is the article as NewsAPI returned it
+	Payload      json.RawMessage      `json:"payload" swaggertype:"object"`
+	ErrorType    AggregationErrorType `json:"error_type" example:"db_error"`
+	Error        string               `json:"error" example:"post could not be stored: connection refused"`
+	Attempts     int                  `json:"attempts" example:"1"`
+	CreatedAt    time.Time            `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	LastFailedAt time.Time            `json:"last_failed_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// DeadLetterListParams represents the request parameters for listing dead letters
+type DeadLetterListParams struct {
+	Page  int `json:"page" validate:"min=1" example:"1"`
+	Limit int `json:"limit" validate:"min=1,max=100" example:"20"`
+}
+
+// DeadLetterListResponse represents the response for listing dead letters
+type DeadLetterListResponse struct {
+	DeadLetters []DeadLetter   `json:"dead_letters"`
+	Pagination  PaginationMeta `json:"pagination"`
+}
+
+// DeadLetterRetryResponse represents the outcome of storing a dead letter
+// again. Duplicate is set when the article had been stored meanwhile.
+type DeadLetterRetryResponse struct {
+	Post      *Post `json:"post,omitempty"`
+	Duplicate bool  `json:"duplicate" example:"false"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+// Experiment event types
+const (
+	ExperimentEventExposure = "exposure"
+	ExperimentEventClick    = "click"
+)
+
+// Experiment defines an A/B test over feed ranking configurations
+type Experiment struct {
+	Name      string              `json:"name" example:"recency-vs-views"`
+	Enabled   bool                `json:"enabled" example:"true"`
+	Variants  []ExperimentVariant `json:"variants" validate:"required,min=1,dive"`
+	CreatedAt time.Time           `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	UpdatedAt time.Time           `json:"updated_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// ExperimentVariant is one arm of an experiment with its share of traffic
+type ExperimentVariant struct {
+	Name       string         `json:"name" validate:"required,max=100" example:"control"`
+	Allocation int            `json:"allocation" validate:"min=0,max=100" example:"50"`
+	Weights    RankingWeights `json:"weights"`
+}
+
+// UpsertExperimentParams represents the request to create or replace an experiment
+type UpsertExperimentParams struct {
+	Enabled  bool                `json:"enabled" example:"true"`
+	Variants []ExperimentVariant `json:"variants" validate:"required,min=1,dive"`
+}
+
+// ExperimentAssignment identifies the variant a user was bucketed into
+type ExperimentAssignment struct {
+	Experiment string `json:"experiment" example:"recency-vs-views"`
+	Variant    string `json:"variant" example:"control"`
+}
+
+// ExperimentEvent records an exposure to or click within an experiment variant
+type ExperimentEvent struct {
+	Experiment string    `json:"experiment" validate:"required,max=100" example:"recency-vs-views"`
+	Variant    string    `json:"variant" validate:"required,max=100" example:"control"`
+	Type       string    `json:"type" validate:"required,oneof=exposure click" example:"click"`
+	UserID     string    `json:"-"`
+	PostID     *int64    `json:"post_id,omitempty" example:"42"`
+	CreatedAt  time.Time `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// VariantResult aggregates events for a single experiment variant
+type VariantResult struct {
+	Variant   string  `json:"variant" example:"control"`
+	Exposures int64   `json:"exposures" example:"1000"`
+	Clicks    int64   `json:"clicks" example:"42"`
+	CTR       float64 `json:"ctr" example:"0.042"`
+}
+
+// ExperimentResults summarizes an experiment's events per variant
+type ExperimentResults struct {
+	Experiment string          `json:"experiment" example:"recency-vs-views"`
+	Variants   []VariantResult `json:"variants"`
+}
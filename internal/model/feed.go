@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// RankingWeights configures how the ranked feed scores posts
+type RankingWeights struct {
+	RecencyWeight   float64            `json:"recency_weight" validate:"gte=0" example:"1"`
+	RecencyHalfLife time.Duration      `json:"recency_half_life" swaggertype:"string" example:"6h"`
+	ViewWeight      float64            `json:"view_weight" validate:"gte=0" example:"0.2"`
+	SourceWeights   map[string]float64 `json:"source_weights,omitempty" example:"TechCrunch:1.2"`
+	CategoryBoosts  map[string]float64 `json:"category_boosts,omitempty" example:"technology:1.5"`
+}
+
+// RankedPost is a post together with its computed feed score
+type RankedPost struct {
+	Post
+	Score float64 `json:"score" example:"0.87"`
+	Views int64   `json:"views" example:"120"`
+}
+
+// RankedFeedParams represents the request parameters for the ranked feed
+type RankedFeedParams struct {
+	Page     int     `json:"page" validate:"min=1" example:"1"`
+	Limit    int     `json:"limit" validate:"min=1,max=100" example:"20"`
+	Category *string `json:"category,omitempty" example:"technology"`
+	SafeMode bool    `json:"safe_mode,omitempty" example:"true"`
+	UserID   string  `json:"-"`
+}
+
+// RankedFeedResponse represents a page of the ranked feed
+type RankedFeedResponse struct {
+	Posts      []RankedPost          `json:"posts"`
+	Pagination PaginationMeta        `json:"pagination"`
+	Experiment *ExperimentAssignment `json:"experiment,omitempty"`
+}
+
+// DefaultRankingWeights returns the ranking weights used until an operator overrides them
+func DefaultRankingWeights() RankingWeights {
+	return RankingWeights{
+		RecencyWeight:   1,
+		RecencyHalfLife: 6 * time.Hour,
+		ViewWeight:      0.2,
+		SourceWeights:   map[string]float64{},
+		CategoryBoosts:  map[string]float64{},
+	}
+}
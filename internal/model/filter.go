@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// FilterRule names the quality rule that rejected an article
+type FilterRule string
+
+const (
+	FilterRuleBlockedDomain    FilterRule = "blocked_domain"
+	FilterRuleSourceList       FilterRule = "source_list"
+	FilterRuleTitlePattern     FilterRule = "title_pattern"
+	FilterRuleMinContentLength FilterRule = "min_content_length"
+	FilterRuleClickbait        FilterRule = "clickbait"
+)
+
+// FilterRejection explains why an article was rejected before post creation
+type FilterRejection struct {
+	Rule   FilterRule `json:"rule" example:"clickbait"`
+	Reason string     `json:"reason" example:"title matches clickbait phrase \"you won't believe\""`
+}
+
+// QuarantinedArticle is a rejected article kept for manual review
+type QuarantinedArticle struct {
+	ID        int64      `json:"id" example:"1"`
+	URL       string     `json:"url" example:"https://spam.example.com/article"`
+	Title     string     `json:"title" example:"You won't believe what happened next"`
+	Source    *string    `json:"source,omitempty" example:"Example News"`
+	Rule      FilterRule `json:"rule" example:"clickbait"`
+	Reason    string     `json:"reason" example:"title matches clickbait phrase \"you won't believe\""`
+	CreatedAt time.Time  `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// QuarantineListParams represents the request parameters for listing quarantined articles
+type QuarantineListParams struct {
+	Page  int `json:"page" validate:"min=1" example:"1"`
+	Limit int `json:"limit" validate:"min=1,max=100" example:"20"`
+}
+
+// QuarantineListResponse represents the response for listing quarantined articles
+type QuarantineListResponse struct {
+	Articles   []QuarantinedArticle `json:"articles"`
+	Pagination PaginationMeta       `json:"pagination"`
+}
@@ -3,13 +3,18 @@ package model
 import "time"
 
 type NewsParams struct {
-	Query    string   `json:"q,omitempty" example:"openai"`
-	Sources  []string `json:"sources,omitempty" example:"[\"techcrunch\"]"`
-	Category string   `json:"category,omitempty" example:"technology"`
-	Country  string   `json:"country,omitempty" example:"us"`
-	Language string   `json:"language,omitempty" example:"en"`
-	PageSize int      `json:"pageSize,omitempty" example:"20"`
-	Page     int      `json:"page,omitempty" example:"1"`
+	Query    string     `json:"q,omitempty" example:"openai"`
+	Sources  []string   `json:"sources,omitempty" example:"[\"techcrunch\"]"`
+	Category string     `json:"category,omitempty" example:"technology"`
+	Country  string     `json:"country,omitempty" example:"us"`
+	Language string     `json:"language,omitempty" example:"en"`
+	PageSize int        `json:"pageSize,omitempty" example:"20"`
+	Page     int        `json:"page,omitempty" example:"1"`
+	From     *time.Time `json:"from,omitempty" swaggertype:"string" example:"2024-01-20T00:00:00Z"`
+	To       *time.Time `json:"to,omitempty" swaggertype:"string" example:"2024-01-21T00:00:00Z"`
+	// SeenUntil stops paging at the first page holding an article published
+	// at or before it, as every older article has already been fetched
+	SeenUntil *time.Time `json:"-"`
 }
 
 // NewsAPIArticleParams represents an article from News API
@@ -25,6 +30,8 @@ type NewsAPIArticleParams struct {
 	URLToImage  *string `json:"urlToImage,omitempty" example:"https://example.com/image.jpg"`
 	PublishedAt string  `json:"publishedAt" swaggertype:"string" example:"2024-01-20T10:00:00Z"`
 	Content     *string `json:"content,omitempty" example:"Full article content..."`
+	// Country is the edition the article was fetched for; NewsAPI does not return it
+	Country string `json:"-"`
 }
 
 // NewsAPIResponse represents the response from News API
@@ -47,6 +54,7 @@ func (article *NewsAPIArticleParams) ToPost() (*CreatePostParams, error) {
 		Content:     article.Content,
 		URL:         article.URL,
 		Source:      article.Source.Name,
+		Author:      article.Author,
 		ImageURL:    article.URLToImage,
 	}
 
@@ -54,5 +62,45 @@ func (article *NewsAPIArticleParams) ToPost() (*CreatePostParams, error) {
 		post.PublishedAt = &publishedAt
 	}
 
+	if article.Country != "" {
+		country := article.Country
+		post.Country = &country
+	}
+
 	return post, nil
 }
+
+// NewsSourcesParams filters the sources NewsAPI offers. Empty fields match
+// every source.
+type NewsSourcesParams struct {
+	Category string `json:"category,omitempty" example:"technology"`
+	Language string `json:"language,omitempty" example:"en"`
+	Country  string `json:"country,omitempty" example:"us"`
+}
+
+// NewsAPISource represents a source NewsAPI publishes articles from. ID is
+// the value the aggregation configuration refers to the source by.
+type NewsAPISource struct {
+	ID          string `json:"id" example:"techcrunch"`
+	Name        string `json:"name" example:"TechCrunch"`
+	Description string `json:"description" example:"TechCrunch is a leading technology media property."`
+	URL         string `json:"url" example:"https://techcrunch.com"`
+	Category    string `json:"category" example:"technology"`
+	Language    string `json:"language" example:"en"`
+	Country     string `json:"country" example:"us"`
+}
+
+// NewsAPISourcesResponse represents the response from the NewsAPI sources endpoint
+type NewsAPISourcesResponse struct {
+	Status  string          `json:"status" example:"ok"`
+	Sources []NewsAPISource `json:"sources"`
+}
+
+// NewsSourcesResponse lists the sources matching a NewsSourcesParams
+type NewsSourcesResponse struct {
+	Sources   []NewsAPISource   `json:"sources"`
+	Count     int               `json:"count" example:"1"`
+	Filters   NewsSourcesParams `json:"filters"`
+	Cached    bool              `json:"cached" example:"false"`
+	FetchedAt time.Time         `json:"fetched_at" example:"2024-01-20T10:00:00Z"`
+}
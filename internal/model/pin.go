@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// PostPin is an editor's pin of a post to a position at the top of the post
+// list and the ranked feed. Position 1 comes first. A pin without ExpiresAt
+// lasts until the post is unpinned.
+type PostPin struct {
+	PostID    int64      `json:"post_id" example:"42"`
+	Position  int        `json:"position" example:"1"`
+	PinnedAt  time.Time  `json:"pinned_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" swaggertype:"string" example:"2025-08-12T07:11:03Z"`
+}
+
+// IsActive reports whether the pin has not expired by now
+func (p *PostPin) IsActive(now time.Time) bool {
+	return p.ExpiresAt == nil || p.ExpiresAt.After(now)
+}
+
+// PinPostParams represents the request to pin a post
+type PinPostParams struct {
+	Position  int        `json:"position" validate:"required,min=1" example:"1"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" swaggertype:"string" example:"2025-08-12T07:11:03Z"`
+}
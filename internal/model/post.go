@@ -2,18 +2,43 @@ package model
 
 import "time"
 
+// PostStatus is the editorial state of a post. Only published posts are
+// visible on public endpoints.
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "draft"
+	PostStatusPublished PostStatus = "published"
+	PostStatusHidden    PostStatus = "hidden"
+
+	// PostStatusAny is a list filter matching posts in every state
+	PostStatusAny PostStatus = "any"
+)
+
 type Post struct {
-	ID          int64      `json:"id" example:"1"`
-	Title       string     `json:"title" example:"Breaking: new Go release"`
-	Description *string    `json:"description,omitempty" example:"A brief description of the news article"`
-	Content     *string    `json:"content,omitempty" example:"Full content of the article..."`
-	URL         string     `json:"url" example:"https://example.com/article"`
-	Source      string     `json:"source" example:"TechCrunch"`
-	Category    *string    `json:"category,omitempty" example:"technology"`
-	ImageURL    *string    `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
-	PublishedAt *time.Time `json:"published_at,omitempty" swaggertype:"string" example:"2024-01-20T10:00:00Z"`
-	CreatedAt   time.Time  `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
-	UpdatedAt   time.Time  `json:"updated_at" swaggertype:"string" example:"2025-08-11T07:16:04Z"`
+	ID            int64            `json:"id" example:"1"`
+	Title         string           `json:"title" example:"Breaking: new Go release"`
+	Description   *string          `json:"description,omitempty" example:"A brief description of the news article"`
+	Content       *string          `json:"content,omitempty" example:"Full content of the article..."`
+	URL           string           `json:"url" example:"https://example.com/article"`
+	Source        string           `json:"source" example:"TechCrunch"`
+	Author        *string          `json:"author,omitempty" example:"Jane Doe"`
+	Category      *string          `json:"category,omitempty" example:"technology"`
+	Country       *string          `json:"country,omitempty" example:"us"`
+	ImageURL      *string          `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
+	PublishedAt   *time.Time       `json:"published_at,omitempty" swaggertype:"string" example:"2024-01-20T10:00:00Z"`
+	CreatedAt     time.Time        `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	UpdatedAt     time.Time        `json:"updated_at" swaggertype:"string" example:"2025-08-11T07:16:04Z"`
+	Version       int              `json:"version" example:"1"`
+	Status        PostStatus       `json:"status" example:"published"`
+	Sensitive     bool             `json:"sensitive" example:"false"`
+	CommentCount  int              `json:"comment_count" example:"3"`
+	ReactionCount int              `json:"reaction_count" example:"12"`
+	TopicID       *int64           `json:"topic_id,omitempty" example:"1"`
+	Reactions     map[string]int64 `json:"reactions,omitempty"`
+	Highlight     *PostHighlight   `json:"highlight,omitempty"`
+	// Pinned marks a post an editor pinned to the top of the list
+	Pinned bool `json:"pinned,omitempty" example:"true"`
 }
 
 // CreatePostRequest represents the request to create a new post
@@ -23,9 +48,15 @@ type CreatePostParams struct {
 	Content     *string    `json:"content,omitempty" example:"Full content..."`
 	URL         string     `json:"url" validate:"required,min=10,max=500" example:"https://example.com/article"`
 	Source      string     `json:"source" validate:"required,min=1,max=100" example:"TechCrunch"`
+	Author      *string    `json:"author,omitempty" validate:"omitempty,max=255" example:"Jane Doe"`
 	Category    *string    `json:"category,omitempty" validate:"omitempty,max=50" example:"technology"`
-	ImageURL    *string    `json:"image_url,omitempty" validate:"omitempty,url,max=1000" example:"https://example.com/image.jpg"`
+	Country     *string    `json:"country,omitempty" validate:"omitempty,len=2,lowercase" example:"us"`
+	ImageURL    *string    `json:"image_url,omitempty" validate:"omitempty,http_url,max=1000" example:"https://example.com/image.jpg"`
 	PublishedAt *time.Time `json:"published_at,omitempty" swaggertype:"string" example:"2024-01-20T10:00:00Z"`
+	// Status defaults to published; create a draft to publish it later
+	Status PostStatus `json:"status,omitempty" validate:"omitempty,oneof=draft published hidden" example:"draft"`
+	// Sensitive is set by the content classifier, never by clients
+	Sensitive bool `json:"-"`
 }
 
 // UpdatePostRequest represents the request to update a post
@@ -33,14 +64,27 @@ type UpdatePostParams struct {
 	Title       string  `json:"title" validate:"min=1,max=500" example:"Updated title"`
 	Description *string `json:"description,omitempty" example:"Updated description"`
 	Content     *string `json:"content,omitempty" example:"Updated content"`
+	Author      *string `json:"author,omitempty" validate:"omitempty,max=255" example:"Jane Doe"`
 	Category    *string `json:"category,omitempty" validate:"omitempty,max=50" example:"business"`
-	ImageURL    *string `json:"image_url,omitempty" validate:"omitempty,url,max=1000" example:"https://example.com/updated.jpg"`
+	ImageURL    *string `json:"image_url,omitempty" validate:"omitempty,http_url,max=1000" example:"https://example.com/updated.jpg"`
+	// Version is the post version the client last read; an If-Match header takes precedence
+	Version int `json:"version,omitempty" validate:"omitempty,min=1" example:"1"`
+	// Sensitive is set by the content classifier, never by clients
+	Sensitive bool `json:"-"`
 }
 
 // BasePostListParams holds common pagination parameters used by post-listing operations.
 type BasePostListParams struct {
 	Limit  int `json:"limit" example:"10"`
 	Offset int `json:"offset" example:"0"`
+	// SafeMode excludes posts flagged as sensitive
+	SafeMode bool `json:"-"`
+	// Popular orders by reaction count before publication date
+	Popular bool `json:"-"`
+	// Status restricts the list to one state; empty means published
+	Status PostStatus `json:"-"`
+	// Collapse keeps only the first post of each topic
+	Collapse bool `json:"-"`
 }
 
 // PostListRequest represents the request parameters for listing posts
@@ -49,13 +93,71 @@ type PostListParams struct {
 	Limit    int     `json:"limit" validate:"min=1,max=100" example:"10"`
 	Category *string `json:"category,omitempty" example:"technology"`
 	Source   *string `json:"source,omitempty" example:"TechCrunch"`
+	Country  *string `json:"country,omitempty" validate:"omitempty,len=2,lowercase" example:"us"`
+	Author   *string `json:"author,omitempty" validate:"omitempty,max=255" example:"Jane Doe"`
 	Search   *string `json:"search,omitempty" example:"openai"`
+	SafeMode bool    `json:"safe_mode,omitempty" example:"true"`
+	Sort     string  `json:"sort,omitempty" validate:"omitempty,oneof=latest popular" example:"popular"`
+	// Status restricts the list to one state; empty means published
+	Status PostStatus `json:"status,omitempty" validate:"omitempty,oneof=draft published hidden any" example:"draft"`
+	// Facets asks for facet counts of a search alongside the result page
+	Facets bool `json:"-"`
+	// IncludeCounts asks for post counts per category and source alongside
+	// the result page
+	IncludeCounts bool `json:"-"`
+	// Collapse keeps only the first matching post of each topic
+	Collapse bool `json:"collapse,omitempty" example:"true"`
+	// Highlight asks for search matches to be marked in each post's title and description
+	Highlight bool `json:"-"`
+	// Count selects how the total is worked out; empty counts every match
+	Count PostCountMode `json:"count,omitempty" validate:"omitempty,oneof=estimate none" example:"estimate"`
+	// Peek fetches one post past the page, so that whether there is a next
+	// page is known without counting
+	Peek bool `json:"-"`
+}
+
+// PostCountMode selects how a post list works out its total. Counting every
+// matching post is exact but grows with the table; an estimate is read from
+// the query planner's statistics, and lists may skip the total altogether.
+type PostCountMode string
+
+const (
+	PostCountExact    PostCountMode = ""
+	PostCountEstimate PostCountMode = "estimate"
+	PostCountNone     PostCountMode = "none"
+)
+
+// Post list sort orders
+const (
+	PostSortLatest  = "latest"
+	PostSortPopular = "popular"
+)
+
+// IsPublished reports whether the post is visible on public endpoints
+func (p *Post) IsPublished() bool {
+	return p.Status == PostStatusPublished
+}
+
+// PostStatusFilter returns the status a list filter selects, or nil for any
+// status. An empty filter selects published posts.
+func PostStatusFilter(status PostStatus) *string {
+	switch status {
+	case PostStatusAny:
+		return nil
+	case "":
+		status = PostStatusPublished
+	}
+
+	value := string(status)
+	return &value
 }
 
 // PostListResponse represents the response for listing posts
 type PostListResponse struct {
 	Posts      []Post         `json:"posts"`
 	Pagination PaginationMeta `json:"pagination"`
+	Facets     *SearchFacets  `json:"facets,omitempty"`
+	Counts     *PostCounts    `json:"counts,omitempty"`
 }
 
 // PaginationMeta represents pagination metadata
@@ -66,6 +168,8 @@ type PaginationMeta struct {
 	TotalPages int   `json:"total_pages" example:"13"`
 	HasNext    bool  `json:"has_next" example:"true"`
 	HasPrev    bool  `json:"has_prev" example:"false"`
+	// TotalMode tells how Total was worked out when it was not counted exactly
+	TotalMode PostCountMode `json:"total_mode,omitempty" example:"estimate"`
 }
 
 // ListPostsByCategoryParams contains parameters for querying posts filtered by a specific category.
@@ -74,16 +178,111 @@ type ListPostsByCategoryParams struct {
 	Category string `json:"category" example:"technology"`
 }
 
+// ListPostsByCountryParams contains parameters for querying posts filtered by a specific country.
+type ListPostsByCountryParams struct {
+	BasePostListParams
+	Country string `json:"country" example:"us"`
+}
+
+// ListPostsByAuthorParams contains parameters for querying posts filtered by a specific author.
+type ListPostsByAuthorParams struct {
+	BasePostListParams
+	Author string `json:"author" example:"Jane Doe"`
+}
+
 // ListPostsBySourceParams contains parameters for querying posts filtered by a specific source.
 type ListPostsBySourceParams struct {
 	BasePostListParams
 	Source string `json:"source" example:"TechCrunch"`
 }
 
+// ListPostsPendingContentParams selects posts waiting for full content extraction.
+// An empty Sources list matches every source.
+type ListPostsPendingContentParams struct {
+	Sources []string
+	Limit   int
+}
+
 // SearchPostsParams contains parameters for text-based search across posts.
 type SearchPostsParams struct {
 	BasePostListParams
 	Query string `json:"query" example:"openai"`
+	// Country restricts the search to posts about one country when set
+	Country *string `json:"country,omitempty" example:"us"`
+}
+
+// Facet size limits; the busiest categories and sources and the most recent
+// days are kept
+const (
+	MaxFacetValues = 20
+	MaxFacetDays   = 30
+)
+
+// FacetCount is the number of matching posts sharing one facet value
+type FacetCount struct {
+	Value string `json:"value" example:"technology"`
+	Count int64  `json:"count" example:"14"`
+}
+
+// SearchFacets breaks the posts matching a search down by category, source
+// and publication day. Days are formatted as YYYY-MM-DD in UTC.
+type SearchFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Sources    []FacetCount `json:"sources"`
+	Days       []FacetCount `json:"days"`
+}
+
+// PostCounts breaks every post in a state down by category and source,
+// busiest first, for filter chips. Posts without a category are counted as
+// uncategorized.
+type PostCounts struct {
+	Categories []FacetCount `json:"categories"`
+	Sources    []FacetCount `json:"sources"`
+}
+
+// DiscoverPostsParams asks for a random sample of recent posts
+type DiscoverPostsParams struct {
+	Limit int `json:"limit" validate:"min=1,max=50" example:"10"`
+	// SafeMode excludes posts flagged as sensitive
+	SafeMode bool `json:"safe_mode,omitempty" example:"true"`
+}
+
+// SamplePostsParams selects the pool of recent published posts discovery
+// picks from
+type SamplePostsParams struct {
+	Since    time.Time
+	Size     int
+	SafeMode bool
+}
+
+// PostHighlight holds a post's title and a description snippet with search
+// matches wrapped in the configured highlight delimiters
+type PostHighlight struct {
+	Title       string  `json:"title" example:"Breaking: new <em>Go</em> release"`
+	Description *string `json:"description,omitempty" example:"The <em>Go</em> team announced..."`
+}
+
+// HighlightPostsParams selects the posts to highlight and how matches are marked
+type HighlightPostsParams struct {
+	IDs      []int64
+	Query    string
+	StartSel string
+	StopSel  string
+}
+
+// PostURL is the stored URL of a post
+type PostURL struct {
+	ID  int64
+	URL string
+}
+
+// URLNormalizationResult summarizes a pass rewriting stored post URLs into
+// their normalized form. Merged counts the posts deleted because another post
+// already held their normalized URL.
+type URLNormalizationResult struct {
+	Scanned    int `json:"scanned" example:"5000"`
+	Normalized int `json:"normalized" example:"120"`
+	Merged     int `json:"merged" example:"8"`
 }
 
 // DefaultPostListParams returns default values for post list request
@@ -110,3 +309,25 @@ func CalculatePagination(page, limit int, total int64) PaginationMeta {
 		HasPrev:    page > 1,
 	}
 }
+
+// PeekPagination creates pagination metadata for a page that was not counted
+// exactly: hasNext comes from fetching one item past the page and estimate,
+// when known, from the query planner. The total is at least the items up to
+// this page and, on a last page that is not empty, exactly that.
+func PeekPagination(page, limit, returned int, hasNext bool, estimate int64, mode PostCountMode) PaginationMeta {
+	seen := int64((page-1)*limit + returned)
+	if hasNext {
+		seen++
+	}
+
+	total := max(estimate, seen)
+	if !hasNext && (returned > 0 || page == 1) {
+		total = seen
+	}
+
+	pagination := CalculatePagination(page, limit, total)
+	pagination.HasNext = hasNext
+	pagination.TotalMode = mode
+
+	return pagination
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// ReactionType is the kind of reaction a user leaves on a post
+type ReactionType string
+
+const (
+	ReactionLike       ReactionType = "like"
+	ReactionUpvote     ReactionType = "upvote"
+	ReactionLove       ReactionType = "love"
+	ReactionInsightful ReactionType = "insightful"
+	ReactionFunny      ReactionType = "funny"
+)
+
+// ReactionTypes lists every supported reaction type
+var ReactionTypes = []ReactionType{ReactionLike, ReactionUpvote, ReactionLove, ReactionInsightful, ReactionFunny}
+
+// Reaction is a user's reaction to a post; each user has at most one per post
+type Reaction struct {
+	PostID    int64        `json:"post_id" example:"42"`
+	UserID    string       `json:"user_id" example:"user-123"`
+	Type      ReactionType `json:"type" example:"like"`
+	CreatedAt time.Time    `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	UpdatedAt time.Time    `json:"updated_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// ReactParams represents the request to react to a post
+type ReactParams struct {
+	Type ReactionType `json:"type" validate:"required,oneof=like upvote love insightful funny" example:"like"`
+}
+
+// ReactionSummary is a post's reaction counts after a reaction changed
+type ReactionSummary struct {
+	PostID        int64            `json:"post_id" example:"42"`
+	Type          *ReactionType    `json:"type,omitempty" example:"like"`
+	ReactionCount int              `json:"reaction_count" example:"12"`
+	Reactions     map[string]int64 `json:"reactions"`
+}
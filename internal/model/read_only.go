@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// ReadOnlyStatus reports whether the API is in read-only mode, in which
+// every mutating request is turned away and scheduled jobs are paused
+type ReadOnlyStatus struct {
+	Enabled bool `json:"enabled" example:"true"`
+	// Reason is what the operator gave when enabling read-only mode
+	Reason string     `json:"reason,omitempty" example:"database failover"`
+	Since  *time.Time `json:"since,omitempty" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// EnableReadOnlyRequest is the body of a request putting the API in
+// read-only mode
+type EnableReadOnlyRequest struct {
+	Reason string `json:"reason" validate:"max=200" example:"database failover"`
+}
@@ -1,6 +1,15 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+// Scheduling modes
+const (
+	ScheduleModeFixedRate  = "fixed_rate"
+	ScheduleModeFixedDelay = "fixed_delay"
+)
 
 type JobStatus struct {
 	Name           string        `json:"name" example:"aggregate_all"`
@@ -11,12 +20,28 @@ type JobStatus struct {
 	ErrorCount     int64         `json:"error_count" example:"1"`
 	LastError      string        `json:"last_error,omitempty" example:"timeout error"`
 	IsRunning      bool          `json:"is_running" example:"false"`
+	Enabled        bool          `json:"enabled" example:"true"`
+	Timeout        time.Duration `json:"timeout" swaggertype:"string" example:"5m"`
+	MaxRetries     int           `json:"max_retries" example:"2"`
+	RetryBackoff   time.Duration `json:"retry_backoff" swaggertype:"string" example:"30s"`
+	Mode           string        `json:"mode" example:"fixed_rate"`
+	Jitter         time.Duration `json:"jitter" swaggertype:"string" example:"1m"`
 	AverageRunTime time.Duration `json:"average_run_time" swaggertype:"string" example:"30s"`
+	// After lists the jobs whose successful runs also trigger this one
+	After []string `json:"after,omitempty" example:"top-headlines"`
+	// Dependents lists the jobs triggered by this one's successful runs
+	Dependents []string `json:"dependents,omitempty" example:"stats-view-refresh"`
+	// Queued reports whether runs go through the queue of one-time jobs
+	Queued bool `json:"queued,omitempty" example:"true"`
+	// QuietHours lists the daily UTC windows in which the job does not run;
+	// runs falling in one are moved to its end
+	QuietHours []string `json:"quiet_hours,omitempty" example:"01:00-05:00"`
 }
 
 // SchedulerStatusResponse represents the scheduler status response
 type SchedulerStatusResponse struct {
 	SchedulerRunning bool                 `json:"scheduler_running" example:"true"`
+	SchedulerPaused  bool                 `json:"scheduler_paused" example:"false"`
 	JobsCount        int                  `json:"jobs_count" example:"3"`
 	Timestamp        time.Time            `json:"timestamp" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
 	Jobs             map[string]JobStatus `json:"jobs"`
@@ -36,3 +61,72 @@ type JobTriggerResponse struct {
 	NextRun   *time.Time `json:"next_run,omitempty" swaggertype:"string" example:"2025-08-11T08:11:03Z"`
 	Timestamp time.Time  `json:"timestamp" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
 }
+
+// SchedulerStateResponse represents the result of pausing or resuming the scheduler
+type SchedulerStateResponse struct {
+	Paused    bool      `json:"paused" example:"true"`
+	Timestamp time.Time `json:"timestamp" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// JobToggleResponse represents the result of enabling or disabling a job
+type JobToggleResponse struct {
+	JobName   string    `json:"job_name" example:"aggregate_all"`
+	Enabled   bool      `json:"enabled" example:"false"`
+	Timestamp time.Time `json:"timestamp" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// JobExecution records the outcome of a single job run
+type JobExecution struct {
+	StartedAt time.Time     `json:"started_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	Duration  time.Duration `json:"duration" swaggertype:"string" example:"12s"`
+	Success   bool          `json:"success" example:"false"`
+	Attempts  int           `json:"attempts" example:"1"`
+	// TriggeredBy names the job whose completion started this run; empty
+	// for scheduled runs
+	TriggeredBy string           `json:"triggered_by,omitempty" example:"top-headlines"`
+	Error       string           `json:"error,omitempty" example:"timeout error"`
+	Stats       map[string]int64 `json:"stats,omitempty"`
+}
+
+// JobHistoryResponse represents the recent executions of a job, newest first
+type JobHistoryResponse struct {
+	JobName    string         `json:"job_name" example:"top-headlines"`
+	Executions []JobExecution `json:"executions"`
+	Count      int            `json:"count" example:"20"`
+	Timestamp  time.Time      `json:"timestamp" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// OnceJob is a task run once at a set time. It is kept in Redis until it
+// has run, so it survives restarts.
+type OnceJob struct {
+	ID        string          `json:"id" example:"6TQJ2N7W4RZK3B5X7D2F9M4H1C"`
+	Task      string          `json:"task" example:"post-purge"`
+	RunAt     time.Time       `json:"run_at" swaggertype:"string" example:"2025-08-11T08:00:00Z"`
+	Tenant    string          `json:"tenant" example:"default"`
+	Payload   json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	// Attempts counts the runs started so far, kept apart from the job
+	Attempts int `json:"attempts" example:"1"`
+}
+
+// DeadOnceJob is a one-time job dropped after its last attempt failed
+type DeadOnceJob struct {
+	OnceJob
+	Error    string    `json:"error" example:"timeout error"`
+	FailedAt time.Time `json:"failed_at" swaggertype:"string" example:"2025-08-11T08:03:10Z"`
+}
+
+// OnceJobsResponse represents the pending one-time jobs, soonest first
+type OnceJobsResponse struct {
+	Jobs      []OnceJob `json:"jobs"`
+	Count     int       `json:"count" example:"2"`
+	Timestamp time.Time `json:"timestamp" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// DeadOnceJobsResponse represents the one-time jobs that failed for good,
+// most recent failure first
+type DeadOnceJobsResponse struct {
+	Jobs      []DeadOnceJob `json:"jobs"`
+	Count     int           `json:"count" example:"1"`
+	Timestamp time.Time     `json:"timestamp" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
@@ -0,0 +1,24 @@
+package model
+
+// SchemaStatus compares the database schema with the one the running build
+// was written against
+type SchemaStatus struct {
+	// ExpectedVersion is the last migration the build knows about
+	ExpectedVersion int64 `json:"expected_version" example:"27"`
+	// CurrentVersion is the last migration applied to the database, 0 when
+	// none has run
+	CurrentVersion int64 `json:"current_version" example:"27"`
+	// Dirty is set when the last migration failed part way
+	Dirty      bool `json:"dirty" example:"false"`
+	Compatible bool `json:"compatible" example:"true"`
+}
+
+// Readiness reports whether the server should receive traffic
+type Readiness struct {
+	Ready bool `json:"ready" example:"true"`
+	// ReadOnly is set when the server is in read-only mode and only answers
+	// reads
+	ReadOnly bool          `json:"read_only" example:"false"`
+	Schema   *SchemaStatus `json:"schema,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// SourceRuleList names the list a source rule belongs to
+type SourceRuleList string
+
+const (
+	SourceRuleBlock SourceRuleList = "block"
+	SourceRuleAllow SourceRuleList = "allow"
+)
+
+// SourceRuleType names what a source rule matches
+type SourceRuleType string
+
+const (
+	// SourceRuleDomain matches the host of the article URL and its subdomains
+	SourceRuleDomain SourceRuleType = "domain"
+	// SourceRuleSource matches the source name, ignoring case
+	SourceRuleSource SourceRuleType = "source"
+)
+
+// SourceRule puts a domain or source name on the blocklist or allowlist.
+// Articles matching a blocked rule are not stored; once the allowlist has
+// rules, articles matching none of them are not stored either.
+type SourceRule struct {
+	ID        int64          `json:"id" example:"1"`
+	List      SourceRuleList `json:"list" example:"block"`
+	Type      SourceRuleType `json:"type" example:"domain"`
+	Value     string         `json:"value" example:"spam.example.com"`
+	CreatedAt time.Time      `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	UpdatedAt time.Time      `json:"updated_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// SaveSourceRuleParams represents the request to add a rule or move an
+// existing rule for the same domain or source to another list
+type SaveSourceRuleParams struct {
+	List  SourceRuleList `json:"list" validate:"required,oneof=block allow" example:"block"`
+	Type  SourceRuleType `json:"type" validate:"required,oneof=domain source" example:"domain"`
+	Value string         `json:"value" validate:"required,max=255" example:"spam.example.com"`
+	// HideExisting hides the stored posts a blocked rule matches
+	HideExisting bool `json:"hide_existing,omitempty" example:"true"`
+}
+
+// SaveSourceRuleResult is the saved rule and the number of stored posts it hid
+type SaveSourceRuleResult struct {
+	Rule   SourceRule `json:"rule"`
+	Hidden int64      `json:"hidden" example:"12"`
+}
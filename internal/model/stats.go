@@ -0,0 +1,73 @@
+package model
+
+import "time"
+
+// Metrics that top stats can rank sources and categories by
+const (
+	StatsMetricPosts  = "posts"
+	StatsMetricViews  = "views"
+	StatsMetricClicks = "clicks"
+)
+
+// Periods that top stats can cover, counted back from now
+const (
+	StatsPeriodDay  = "24h"
+	StatsPeriodWeek = "7d"
+)
+
+// Dimensions the hourly post activity is broken down by
+const (
+	ActivityDimensionSource   = "source"
+	ActivityDimensionCategory = "category"
+)
+
+// TopStatsParams represents the request parameters for ranking sources and
+// categories
+type TopStatsParams struct {
+	Metric string `json:"metric" validate:"oneof=posts views clicks" example:"views"`
+	Period string `json:"period" validate:"oneof=24h 7d" example:"24h"`
+	Limit  int    `json:"limit" validate:"min=1,max=100" example:"10"`
+}
+
+// DefaultTopStatsParams returns default values for a top stats request
+func DefaultTopStatsParams() TopStatsParams {
+	return TopStatsParams{
+		Metric: StatsMetricPosts,
+		Period: StatsPeriodDay,
+		Limit:  10,
+	}
+}
+
+// TopStat is the total of the requested metric for one source or category
+type TopStat struct {
+	Key   string `json:"key" example:"technology"`
+	Value int64  `json:"value" example:"1280"`
+}
+
+// ActivityTotal is the total of a metric for one key of a dimension
+type ActivityTotal struct {
+	Dimension string
+	Key       string
+	Value     int64
+}
+
+// TopStatsResponse represents the sources and categories ranked by the
+// requested metric, highest first
+type TopStatsResponse struct {
+	Metric string    `json:"metric" example:"views"`
+	Period string    `json:"period" example:"24h"`
+	Since  time.Time `json:"since" swaggertype:"string" example:"2025-08-10T07:00:00Z"`
+	// RolledUpAt is when the stats were last computed; it is absent until the
+	// first rollup has run
+	RolledUpAt *time.Time `json:"rolled_up_at,omitempty" swaggertype:"string" example:"2025-08-11T07:05:00Z"`
+	Sources    []TopStat  `json:"sources"`
+	Categories []TopStat  `json:"categories"`
+}
+
+// ActivityRollupResult summarizes a post activity rollup pass
+type ActivityRollupResult struct {
+	Since time.Time `json:"since" swaggertype:"string" example:"2025-08-11T07:00:00Z"`
+	// Hours counts the hours recomputed, the oldest being Since
+	Hours  int   `json:"hours" example:"2"`
+	Pruned int64 `json:"pruned" example:"40"`
+}
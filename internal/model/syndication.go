@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// FeedFormat is the syndication format a feed is rendered in
+type FeedFormat string
+
+const (
+	FeedFormatRSS  FeedFormat = "rss"
+	FeedFormatAtom FeedFormat = "atom"
+)
+
+// SyndicationDocument is a rendered feed or sitemap ready to be served
+type SyndicationDocument struct {
+	Body        []byte
+	ContentType string
+	GeneratedAt time.Time
+}
+
+// SitemapEntry is the part of a post a sitemap needs
+type SitemapEntry struct {
+	PostID    int64
+	UpdatedAt time.Time
+}
+
+// SyndicationRefreshResult summarizes a scheduled regeneration of the cached
+// feeds and sitemap
+type SyndicationRefreshResult struct {
+	Feeds        int
+	SitemapPages int
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Tenant is a branded feed served from the shared deployment. Its posts,
+// comments, reactions and analytics are isolated from every other tenant.
+type Tenant struct {
+	ID   string `json:"id" example:"acme"`
+	Name string `json:"name" example:"Acme News"`
+	// NewsAPIKey overrides the deployment's NewsAPI key; it is never returned
+	NewsAPIKey    string `json:"-"`
+	HasNewsAPIKey bool   `json:"has_news_api_key" example:"true"`
+	// NewsAPIDailyQuota caps the tenant's NewsAPI requests per UTC day; 0 is unlimited
+	NewsAPIDailyQuota int `json:"news_api_daily_quota" example:"500"`
+	// Sources replaces the default sources of the source aggregation job when set
+	Sources   []string  `json:"sources" example:"bbc-news,reuters"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+	UpdatedAt time.Time `json:"updated_at" swaggertype:"string" example:"2025-08-11T07:11:03Z"`
+}
+
+// UpsertTenantParams represents the request to create or update a tenant
+type UpsertTenantParams struct {
+	Name string `json:"name" validate:"required,max=200" example:"Acme News"`
+	// NewsAPIKey is kept when omitted and cleared when empty
+	NewsAPIKey        *string  `json:"news_api_key,omitempty" validate:"omitempty,max=100" example:"abc123"`
+	NewsAPIDailyQuota int      `json:"news_api_daily_quota" validate:"min=0" example:"500"`
+	Sources           []string `json:"sources,omitempty" validate:"max=20,dive,min=1,max=100" example:"bbc-news,reuters"`
+	// Active defaults to true for new tenants and is kept when omitted
+	Active *bool `json:"active,omitempty" example:"true"`
+}
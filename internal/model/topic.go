@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// Topic is a cluster of posts covering the same story. Its ID is the ID of
+// the first post clustered into it.
+type Topic struct {
+	ID              int64      `json:"id" example:"1"`
+	MemberCount     int64      `json:"member_count" example:"4"`
+	LastPublishedAt *time.Time `json:"last_published_at,omitempty" swaggertype:"string" example:"2024-01-20T10:00:00Z"`
+	// Representative is the earliest published member of the topic
+	Representative *Post `json:"representative"`
+}
+
+// TopicListParams represents the request parameters for listing topics
+type TopicListParams struct {
+	Page  int `json:"page" validate:"min=1" example:"1"`
+	Limit int `json:"limit" validate:"min=1,max=100" example:"20"`
+	// MinMembers skips topics with fewer published posts
+	MinMembers int  `json:"min_members" validate:"min=1" example:"2"`
+	SafeMode   bool `json:"safe_mode,omitempty" example:"true"`
+}
+
+// DefaultTopicListParams returns default values for a topic list request.
+// Only stories reported more than once are listed by default.
+func DefaultTopicListParams() TopicListParams {
+	return TopicListParams{
+		Page:       1,
+		Limit:      20,
+		MinMembers: 2,
+	}
+}
+
+// TopicListResponse represents the response for listing topics
+type TopicListResponse struct {
+	Topics     []Topic        `json:"topics"`
+	Pagination PaginationMeta `json:"pagination"`
+}
+
+// TopicCandidate is the part of a post topic clustering compares
+type TopicCandidate struct {
+	ID          int64
+	TopicID     *int64
+	Title       string
+	PublishedAt time.Time
+}
+
+// TopicClusteringResult summarizes a topic clustering pass
+type TopicClusteringResult struct {
+	Processed int `json:"processed" example:"120"`
+	// Clustered counts posts that joined an existing topic rather than starting one
+	Clustered int `json:"clustered" example:"35"`
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// activityDimensions expands a row carrying source and category into one row
+// per dimension, counting posts without a category as uncategorized
+const activityDimensions = `
+	CROSS JOIN LATERAL (VALUES
+		('source', activity.source),
+		('category', COALESCE(NULLIF(activity.category, ''), 'uncategorized'))
+	) AS d(dimension, key)
+`
+
+// activityMetricColumns maps each rankable metric to its column
+var activityMetricColumns = map[string]string{
+	model.StatsMetricPosts:  "posts",
+	model.StatsMetricViews:  "views",
+	model.StatsMetricClicks: "clicks",
+}
+
+// activityRepository implements ActivityRepository interface. Posts and
+// clicks are rolled up from their tables; views only live in Redis.
+type activityRepository struct {
+	db       *pgxpool.Pool
+	replicas *database.ReplicaSet
+	redis    redis.UniversalClient
+	logger   *logger.Logger
+}
+
+// NewActivityRepository creates a new post activity repository
+func NewActivityRepository(db *pgxpool.Pool, replicas *database.ReplicaSet, redis redis.UniversalClient, logger *logger.Logger) ActivityRepository {
+	return &activityRepository{
+		db:       db,
+		replicas: replicas,
+		redis:    redis,
+		logger:   logger,
+	}
+}
+
+// GetRollupWatermark returns the time the last rollup covered activity up to,
+// or nil when none has run yet
+func (r *activityRepository) GetRollupWatermark(ctx context.Context) (*time.Time, error) {
+	start := time.Now()
+
+	var until time.Time
+	err := r.conn(ctx).QueryRow(ctx, `SELECT rolled_up_until FROM post_activity_rollups`).Scan(&until)
+	if errors.Is(err, pgx.ErrNoRows) {
+		r.logger.LogDBOperation("get_rollup_watermark", "post_activity_rollups", time.Since(start).Milliseconds(), nil)
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.LogDBOperation("get_rollup_watermark", "post_activity_rollups", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to get rollup watermark: %w", err)
+	}
+
+	r.logger.LogDBOperation("get_rollup_watermark", "post_activity_rollups", time.Since(start).Milliseconds(), nil)
+
+	return &until, nil
+}
+
+// SaveRollupWatermark records that activity up to until has been rolled up.
+// The watermark never moves backwards.
+func (r *activityRepository) SaveRollupWatermark(ctx context.Context, until time.Time) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO post_activity_rollups (rolled_up_until) VALUES ($1)
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET rolled_up_until = GREATEST(post_activity_rollups.rolled_up_until, EXCLUDED.rolled_up_until),
+			updated_at = NOW()
+	`
+	if _, err := r.conn(ctx).Exec(ctx, query, until.UTC()); err != nil {
+		r.logger.LogDBOperation("save_rollup_watermark", "post_activity_rollups", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to save rollup watermark: %w", err)
+	}
+
+	r.logger.LogDBOperation("save_rollup_watermark", "post_activity_rollups", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// RollupPostsAndClicks recomputes the posts created and the clicks recorded
+// in every hour from since on, which must be the start of an hour
+func (r *activityRepository) RollupPostsAndClicks(ctx context.Context, since time.Time) error {
+	start := time.Now()
+
+	reset := `UPDATE post_activity_hourly SET posts = 0, clicks = 0, updated_at = NOW() WHERE hour >= $1`
+	if _, err := r.conn(ctx).Exec(ctx, reset, since.UTC()); err != nil {
+		r.logger.LogDBOperation("rollup_posts_clicks", "post_activity_hourly", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to reset post activity: %w", err)
+	}
+
+	query := `
+		INSERT INTO post_activity_hourly (hour, dimension, key, posts, clicks)
+		SELECT activity.hour, d.dimension, d.key, SUM(activity.posts), SUM(activity.clicks)
+		FROM (
+			SELECT date_trunc('hour', created_at) AS hour, source, category, 1 AS posts, 0 AS clicks
+			FROM posts
+			WHERE created_at >= $1
+			UNION ALL
+			SELECT date_trunc('hour', c.created_at), p.source, p.category, 0, 1
+			FROM post_clicks c
+			JOIN posts p ON p.id = c.post_id
+			WHERE c.created_at >= $1
+		) AS activity
+	` + activityDimensions + `
+		GROUP BY activity.hour, d.dimension, d.key
+		ON CONFLICT (tenant_id, hour, dimension, key) DO UPDATE
+		SET posts = EXCLUDED.posts, clicks = EXCLUDED.clicks, updated_at = NOW()
+	`
+	if _, err := r.conn(ctx).Exec(ctx, query, since.UTC()); err != nil {
+		r.logger.LogDBOperation("rollup_posts_clicks", "post_activity_hourly", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to roll up posts and clicks: %w", err)
+	}
+
+	r.logger.LogDBOperation("rollup_posts_clicks", "post_activity_hourly", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// GetHourlyViews returns the views each post received in the hour starting
+// at hour. The counters are shared by all tenants.
+func (r *activityRepository) GetHourlyViews(ctx context.Context, hour time.Time) (map[int64]int64, error) {
+	values, err := r.redis.HGetAll(ctx, postViewsHourKey(hour)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hourly post views: %w", err)
+	}
+
+	views := make(map[int64]int64, len(values))
+	for field, value := range values {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			continue
+		}
+		if count, err := strconv.ParseInt(value, 10, 64); err == nil {
+			views[id] = count
+		}
+	}
+
+	return views, nil
+}
+
+// SaveHourlyViews replaces the views rolled up for the hour starting at hour
+// with the given per-post counts. Posts of other tenants are ignored.
+func (r *activityRepository) SaveHourlyViews(ctx context.Context, hour time.Time, views map[int64]int64) error {
+	start := time.Now()
+
+	reset := `UPDATE post_activity_hourly SET views = 0, updated_at = NOW() WHERE hour = $1 AND views <> 0`
+	if _, err := r.conn(ctx).Exec(ctx, reset, hour.UTC()); err != nil {
+		r.logger.LogDBOperation("save_hourly_views", "post_activity_hourly", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to reset hourly views: %w", err)
+	}
+
+	if len(views) == 0 {
+		r.logger.LogDBOperation("save_hourly_views", "post_activity_hourly", time.Since(start).Milliseconds(), nil)
+		return nil
+	}
+
+	ids := make([]int64, 0, len(views))
+	counts := make([]int64, 0, len(views))
+	for id, count := range views {
+		ids = append(ids, id)
+		counts = append(counts, count)
+	}
+
+	query := `
+		INSERT INTO post_activity_hourly (hour, dimension, key, views)
+		SELECT $1, d.dimension, d.key, SUM(activity.views)
+		FROM (
+			SELECT p.source, p.category, v.views
+			FROM unnest($2::bigint[], $3::bigint[]) AS v(post_id, views)
+			JOIN posts p ON p.id = v.post_id
+		) AS activity
+	` + activityDimensions + `
+		GROUP BY d.dimension, d.key
+		ON CONFLICT (tenant_id, hour, dimension, key) DO UPDATE
+		SET views = EXCLUDED.views, updated_at = NOW()
+	`
+	if _, err := r.conn(ctx).Exec(ctx, query, hour.UTC(), ids, counts); err != nil {
+		r.logger.LogDBOperation("save_hourly_views", "post_activity_hourly", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to save hourly views: %w", err)
+	}
+
+	r.logger.LogDBOperation("save_hourly_views", "post_activity_hourly", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// PruneActivity deletes the hourly activity older than before
+func (r *activityRepository) PruneActivity(ctx context.Context, before time.Time) (int64, error) {
+	start := time.Now()
+
+	tag, err := r.conn(ctx).Exec(ctx, `DELETE FROM post_activity_hourly WHERE hour < $1`, before.UTC())
+	if err != nil {
+		r.logger.LogDBOperation("prune_activity", "post_activity_hourly", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to prune post activity: %w", err)
+	}
+
+	r.logger.LogDBOperation("prune_activity", "post_activity_hourly", time.Since(start).Milliseconds(), nil)
+
+	return tag.RowsAffected(), nil
+}
+
+// TopActivity returns, for each dimension, up to limit keys with the highest
+// total of metric from since on, highest first. Keys with no activity are
+// left out.
+func (r *activityRepository) TopActivity(ctx context.Context, metric string, since time.Time, limit int) ([]model.ActivityTotal, error) {
+	column, ok := activityMetricColumns[metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown activity metric %q", metric)
+	}
+
+	start := time.Now()
+
+	query := fmt.Sprintf(`
+		SELECT dimension, key, total
+		FROM (
+			SELECT dimension, key, SUM(%[1]s) AS total,
+				ROW_NUMBER() OVER (PARTITION BY dimension ORDER BY SUM(%[1]s) DESC, key) AS rank
+			FROM post_activity_hourly
+			WHERE hour >= $1
+			GROUP BY dimension, key
+			HAVING SUM(%[1]s) > 0
+		) AS ranked
+		WHERE rank <= $2
+		ORDER BY dimension, rank
+	`, column)
+	rows, err := r.reader(ctx).Query(ctx, query, since.UTC(), limit)
+	if err != nil {
+		r.logger.LogDBOperation("top_activity", "post_activity_hourly", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to rank post activity: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []model.ActivityTotal
+	for rows.Next() {
+		var total model.ActivityTotal
+		if err := rows.Scan(&total.Dimension, &total.Key, &total.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan post activity: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("top_activity", "post_activity_hourly", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate post activity: %w", err)
+	}
+
+	r.logger.LogDBOperation("top_activity", "post_activity_hourly", time.Since(start).Milliseconds(), nil)
+
+	return totals, nil
+}
+
+// conn returns the transaction carried by ctx, or the primary pool
+func (r *activityRepository) conn(ctx context.Context) querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx
+	}
+
+	return r.db
+}
+
+// reader returns the connection used for the ranking query; reads inside a
+// transaction stay on that transaction
+func (r *activityRepository) reader(ctx context.Context) querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx
+	}
+
+	if r.replicas == nil {
+		return r.db
+	}
+
+	return r.replicas.Reader()
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityRepositoryRollupAndRank(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	activity := NewActivityRepository(ts.db, nil, ts.redisClient, ts.logger)
+	clicks := NewClickRepository(ts.db, nil, ts.logger)
+
+	watermark, err := activity.GetRollupWatermark(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, watermark)
+
+	post, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	other := createSamplePost()
+	other.URL = "https://example.com/uncategorized"
+	other.Source = "Other Source"
+	other.Category = nil
+	uncategorized, err := ts.repo.CreatePost(ctx, other)
+	require.NoError(t, err)
+
+	require.NoError(t, clicks.RecordClick(ctx, &model.PostClick{PostID: post.ID}))
+	for range 3 {
+		require.NoError(t, ts.repo.IncrementPostViews(ctx, post.ID, ""))
+	}
+	require.NoError(t, ts.repo.IncrementPostViews(ctx, uncategorized.ID, ""))
+
+	hour := time.Now().UTC().Truncate(time.Hour)
+	require.NoError(t, activity.RollupPostsAndClicks(ctx, hour.Add(-time.Hour)))
+
+	views, err := activity.GetHourlyViews(ctx, hour)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{post.ID: 3, uncategorized.ID: 1}, views)
+	require.NoError(t, activity.SaveHourlyViews(ctx, hour, views))
+
+	// A second rollup recomputes rather than adds up
+	require.NoError(t, activity.RollupPostsAndClicks(ctx, hour.Add(-time.Hour)))
+	require.NoError(t, activity.SaveHourlyViews(ctx, hour, views))
+
+	byViews, err := activity.TopActivity(ctx, model.StatsMetricViews, hour.Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []model.ActivityTotal{
+		{Dimension: "category", Key: "Technology", Value: 3},
+		{Dimension: "category", Key: "uncategorized", Value: 1},
+		{Dimension: "source", Key: "Test Source", Value: 3},
+		{Dimension: "source", Key: "Other Source", Value: 1},
+	}, byViews)
+
+	byClicks, err := activity.TopActivity(ctx, model.StatsMetricClicks, hour.Add(-time.Hour), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []model.ActivityTotal{
+		{Dimension: "category", Key: "Technology", Value: 1},
+		{Dimension: "source", Key: "Test Source", Value: 1},
+	}, byClicks)
+
+	byPosts, err := activity.TopActivity(ctx, model.StatsMetricPosts, hour.Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Len(t, byPosts, 4)
+	assert.Equal(t, int64(1), byPosts[0].Value)
+
+	require.NoError(t, activity.SaveRollupWatermark(ctx, hour.Add(time.Minute)))
+	require.NoError(t, activity.SaveRollupWatermark(ctx, hour))
+	watermark, err = activity.GetRollupWatermark(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, watermark)
+	assert.True(t, watermark.Equal(hour.Add(time.Minute)))
+
+	pruned, err := activity.PruneActivity(ctx, hour.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), pruned)
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/cachetrace"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// swrRefreshTimeout bounds a single background revalidation
+const swrRefreshTimeout = 10 * time.Second
+
+// cacheEntry wraps a cached payload with the time it stops being fresh and
+// how long it took to compute, which scales its early refresh window
+type cacheEntry struct {
+	Data       json.RawMessage `json:"data"`
+	FreshUntil time.Time       `json:"fresh_until"`
+	Delta      time.Duration   `json:"delta,omitempty"`
+}
+
+// swrCache stores JSON payloads in Redis and, when stale-while-revalidate is
+// enabled, serves entries past their soft TTL while refreshing them in the background
+type swrCache struct {
+	redis  redis.UniversalClient
+	logger *logger.Logger
+	cfg    config.CacheConfig
+	ttl    atomic.Int64
+}
+
+func newSWRCache(redis redis.UniversalClient, logger *logger.Logger, cfg config.CacheConfig) *swrCache {
+	cache := &swrCache{
+		redis:  redis,
+		logger: logger,
+		cfg:    cfg,
+	}
+	cache.setTTL(cfg.TTL)
+
+	return cache
+}
+
+// setTTL changes the expiry applied to entries cached from now on
+func (c *swrCache) setTTL(ttl time.Duration) {
+	c.ttl.Store(int64(ttl))
+}
+
+// baseTTL returns the plain cache TTL, ignoring stale-while-revalidate
+func (c *swrCache) baseTTL() time.Duration {
+	return time.Duration(c.ttl.Load())
+}
+
+// ttls returns the freshness window and the Redis key expiry for new entries
+func (c *swrCache) ttls() (soft, hard time.Duration) {
+	if c.cfg.SWREnabled {
+		return c.cfg.SoftTTL, c.cfg.HardTTL
+	}
+
+	ttl := c.baseTTL()
+	return ttl, ttl
+}
+
+// set stores value under key with the configured TTLs, along with delta, the
+// time it took to compute
+func (c *swrCache) set(ctx context.Context, key string, value any, delta time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	soft, hard := c.ttls()
+	entry, err := json.Marshal(cacheEntry{Data: data, FreshUntil: time.Now().Add(soft), Delta: delta})
+	if err != nil {
+		return
+	}
+
+	c.redis.Set(ctx, key, entry, hard).Err()
+	c.logger.LogCacheOperation("set", key, false)
+}
+
+// readThrough returns the cached value for key, loading and caching it on a miss.
+// Stale entries are returned immediately and revalidated asynchronously.
+func readThrough[T any](ctx context.Context, c *swrCache, key string, load func(context.Context) (T, error)) (T, error) {
+	return read(ctx, c, key, load, false)
+}
+
+// readThroughEarly is readThrough for the hottest keys, which are refreshed
+// early at random (XFetch) so that many readers do not miss at once when
+// they expire. The closer an entry is to going stale and the longer it took
+// to compute, the likelier a read recomputes it; without stale-while-
+// revalidate the reader recomputes it itself, otherwise it is revalidated
+// in the background.
+func readThroughEarly[T any](ctx context.Context, c *swrCache, key string, load func(context.Context) (T, error)) (T, error) {
+	return read(ctx, c, key, load, c.cfg.EarlyRefreshEnabled)
+}
+
+// read serves key from the cache, refreshing it early at random when early is set
+func read[T any](ctx context.Context, c *swrCache, key string, load func(context.Context) (T, error), early bool) (T, error) {
+	cached, err := c.redis.Get(ctx, key).Bytes()
+	if err == nil {
+		var entry cacheEntry
+		var value T
+		if json.Unmarshal(cached, &entry) == nil && json.Unmarshal(entry.Data, &value) == nil {
+			now := time.Now()
+			if c.cfg.SWREnabled && now.After(entry.FreshUntil) {
+				c.logger.LogCacheOperation("get_stale", key, true)
+				cachetrace.Record(ctx, true)
+				revalidate(ctx, c, key, load)
+				return value, nil
+			}
+
+			if !early || !refreshEarly(now, entry, c.cfg.EarlyRefreshBeta, 1-rand.Float64()) {
+				c.logger.LogCacheOperation("get", key, true)
+				cachetrace.Record(ctx, true)
+				return value, nil
+			}
+
+			c.logger.LogCacheOperation("get_early", key, true)
+			if c.cfg.SWREnabled {
+				cachetrace.Record(ctx, true)
+				revalidate(ctx, c, key, load)
+				return value, nil
+			}
+			cachetrace.Record(ctx, false)
+			return compute(ctx, c, key, load)
+		}
+	}
+	c.logger.LogCacheOperation("get", key, false)
+	cachetrace.Record(ctx, false)
+
+	return compute(ctx, c, key, load)
+}
+
+// compute loads the value of key and caches it with the time it took
+func compute[T any](ctx context.Context, c *swrCache, key string, load func(context.Context) (T, error)) (T, error) {
+	start := time.Now()
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	c.set(ctx, key, value, time.Since(start))
+
+	return value, nil
+}
+
+// refreshEarly reports whether an entry should be recomputed ahead of going
+// stale, given a uniform random number in (0, 1]: the entry expires early by
+// delta scaled by beta and an exponentially distributed factor
+func refreshEarly(now time.Time, entry cacheEntry, beta, random float64) bool {
+	if entry.Delta <= 0 {
+		return false
+	}
+
+	gap := time.Duration(-float64(entry.Delta) * beta * math.Log(random))
+
+	return !now.Add(gap).Before(entry.FreshUntil)
+}
+
+// revalidate refreshes key in the background. A short-lived lock ensures only
+// one replica rebuilds a given entry at a time.
+func revalidate[T any](ctx context.Context, c *swrCache, key string, load func(context.Context) (T, error)) {
+	lockKey := "lock:" + key
+	acquired, err := c.redis.SetNX(ctx, lockKey, 1, swrRefreshTimeout).Result()
+	if err != nil || !acquired {
+		return
+	}
+
+	go func() {
+		refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), swrRefreshTimeout)
+		defer cancel()
+		defer c.redis.Del(refreshCtx, lockKey)
+
+		if _, err := compute(refreshCtx, c, key, load); err != nil {
+			c.logger.Warn("Background cache refresh failed", "key", key, "error", err.Error())
+			return
+		}
+
+		c.logger.LogCacheOperation("revalidate", key, false)
+	}()
+}
+
+// patternEscaper quotes the glob characters of a value placed in a SCAN
+// pattern, so that it only matches itself
+var patternEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// deleteMatching deletes the keys matching pattern. A cluster is scanned
+// master by master, as each only sees its own keys, and keys are deleted
+// one per command since they may hash to different slots.
+func deleteMatching(ctx context.Context, rdb redis.UniversalClient, pattern string) error {
+	if cluster, ok := rdb.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return deleteScanned(ctx, node, pattern)
+		})
+	}
+
+	return deleteScanned(ctx, rdb, pattern)
+}
+
+// keysMatching returns the keys matching pattern, scanning every master of a cluster
+func keysMatching(ctx context.Context, rdb redis.UniversalClient, pattern string) ([]string, error) {
+	if cluster, ok := rdb.(*redis.ClusterClient); ok {
+		var mu sync.Mutex
+		var keys []string
+		err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			nodeKeys, err := scanKeys(ctx, node, pattern)
+			mu.Lock()
+			keys = append(keys, nodeKeys...)
+			mu.Unlock()
+			return err
+		})
+		return keys, err
+	}
+
+	return scanKeys(ctx, rdb, pattern)
+}
+
+// scanKeys returns the keys of one node matching pattern
+func scanKeys(ctx context.Context, node redis.Cmdable, pattern string) ([]string, error) {
+	var keys []string
+	iter := node.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+
+	return keys, iter.Err()
+}
+
+// deleteScanned deletes the keys of one node matching pattern, scanning
+// incrementally rather than blocking the node with KEYS
+func deleteScanned(ctx context.Context, node redis.Cmdable, pattern string) error {
+	var cursor uint64
+	for {
+		keys, next, err := node.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			pipe := node.Pipeline()
+			for _, key := range keys {
+				pipe.Del(ctx, key)
+			}
+			if _, err := pipe.Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
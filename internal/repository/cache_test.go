@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshEarly(t *testing.T) {
+	now := time.Now()
+	entry := cacheEntry{FreshUntil: now.Add(time.Second), Delta: 100 * time.Millisecond}
+
+	// -ln(1) is 0, so an entry is only refreshed once it is stale
+	assert.False(t, refreshEarly(now, entry, 1, 1))
+	assert.True(t, refreshEarly(entry.FreshUntil, entry, 1, 1))
+
+	// -ln(1e-5) is about 11.5, stretching the 100ms delta past the second left
+	assert.True(t, refreshEarly(now, entry, 1, 1e-5))
+	assert.False(t, refreshEarly(now, entry, 0.5, 1e-5))
+
+	// Entries cached before their compute time was recorded are left alone
+	entry.Delta = 0
+	assert.False(t, refreshEarly(now, entry, 1, 1e-5))
+}
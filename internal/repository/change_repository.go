@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// changeRepository implements ChangeRepository interface
+type changeRepository struct {
+	db     *pgxpool.Pool
+	logger *logger.Logger
+}
+
+// NewChangeRepository creates a new post change feed repository. Changes
+// are always read from the primary: a lagging replica could let a cursor
+// move past changes it has not applied yet.
+func NewChangeRepository(db *pgxpool.Pool, logger *logger.Logger) ChangeRepository {
+	return &changeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListPostChanges merges the posts updated and the tombstones of the posts
+// deleted in the requested window, oldest change first. A post is listed
+// once, as of its last change: created when it was created after the
+// window start, updated otherwise, and deleted when it is gone or no longer
+// published.
+func (r *changeRepository) ListPostChanges(ctx context.Context, params *model.ListPostChangesParams) ([]model.PostChange, error) {
+	start := time.Now()
+
+	query := `
+		SELECT id, changed_at, change FROM (
+			SELECT id, updated_at AS changed_at,
+				CASE WHEN status <> 'published' THEN 'deleted' WHEN created_at > $1 THEN 'created' ELSE 'updated' END AS change
+			FROM posts
+			WHERE (updated_at, id) > ($1, $2) AND updated_at <= $3
+			UNION ALL
+			SELECT post_id, deleted_at, 'deleted'
+			FROM post_deletions
+			WHERE (deleted_at, post_id) > ($1, $2) AND deleted_at <= $3
+		) changes
+		ORDER BY changed_at, id
+		LIMIT $4
+	`
+	rows, err := r.db.Query(ctx, query, params.After.UTC(), params.AfterID, params.Until.UTC(), params.Limit)
+	if err != nil {
+		r.logger.LogDBOperation("list_changes", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list post changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []model.PostChange{}
+	for rows.Next() {
+		var change model.PostChange
+		if err := rows.Scan(&change.ID, &change.ChangedAt, &change.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan post change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("list_changes", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate post changes: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_changes", "posts", time.Since(start).Milliseconds(), nil)
+
+	return changes, nil
+}
+
+// PruneDeletions drops the tombstones of posts deleted before the given time
+// and returns how many were dropped
+func (r *changeRepository) PruneDeletions(ctx context.Context, before time.Time) (int64, error) {
+	start := time.Now()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM post_deletions WHERE deleted_at < $1`, before.UTC())
+	if err != nil {
+		r.logger.LogDBOperation("prune_deletions", "post_deletions", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to prune post deletions: %w", err)
+	}
+
+	r.logger.LogDBOperation("prune_deletions", "post_deletions", time.Since(start).Milliseconds(), nil)
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeRepositoryListAndPrune(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	changes := NewChangeRepository(ts.db, ts.logger)
+	since := time.Now().UTC().Add(-time.Minute)
+
+	kept, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	removedParams := createSamplePost()
+	removedParams.URL = "https://example.com/removed-post"
+	removed, err := ts.repo.CreatePost(ctx, removedParams)
+	require.NoError(t, err)
+	require.NoError(t, ts.repo.DeletePost(ctx, removed.ID))
+
+	window := &model.ListPostChangesParams{After: since, Until: time.Now().UTC().Add(time.Minute), Limit: 10}
+	got, err := changes.ListPostChanges(ctx, window)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, model.PostChange{ID: kept.ID, Type: model.PostChangeCreated, ChangedAt: got[0].ChangedAt}, got[0])
+	assert.Equal(t, model.PostChange{ID: removed.ID, Type: model.PostChangeDeleted, ChangedAt: got[1].ChangedAt}, got[1])
+
+	// Resuming after the first change skips it
+	window.After, window.AfterID = got[0].ChangedAt, got[0].ID
+	rest, err := changes.ListPostChanges(ctx, window)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, removed.ID, rest[0].ID)
+
+	// Posts created before the window start are reported as updated
+	window.After, window.AfterID = kept.CreatedAt, kept.ID
+	_, err = ts.db.Exec(ctx, "UPDATE posts SET updated_at = NOW() + INTERVAL '1 second' WHERE id = $1", kept.ID)
+	require.NoError(t, err)
+	window.Until = time.Now().UTC().Add(time.Minute)
+	updated, err := changes.ListPostChanges(ctx, window)
+	require.NoError(t, err)
+	require.Len(t, updated, 2)
+	assert.Equal(t, model.PostChange{ID: kept.ID, Type: model.PostChangeUpdated, ChangedAt: updated[1].ChangedAt}, updated[1])
+
+	pruned, err := changes.PruneDeletions(ctx, since)
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+
+	pruned, err = changes.PruneDeletions(ctx, time.Now().UTC().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pruned)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// clickRepository implements ClickRepository interface
+type clickRepository struct {
+	db       *pgxpool.Pool
+	replicas *database.ReplicaSet
+	logger   *logger.Logger
+}
+
+// NewClickRepository creates a new click repository
+func NewClickRepository(db *pgxpool.Pool, replicas *database.ReplicaSet, logger *logger.Logger) ClickRepository {
+	return &clickRepository{
+		db:       db,
+		replicas: replicas,
+		logger:   logger,
+	}
+}
+
+// RecordClick stores a click-through for a post
+func (r *clickRepository) RecordClick(ctx context.Context, click *model.PostClick) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO post_clicks (post_id, referrer, user_agent)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRow(ctx, query, click.PostID, click.Referrer, click.UserAgent).Scan(&click.ID, &click.CreatedAt)
+	if err != nil {
+		r.logger.LogDBOperation("record_click", "post_clicks", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to record click: %w", err)
+	}
+
+	r.logger.LogDBOperation("record_click", "post_clicks", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// CountClicksByPost returns click totals for every post created since the given
+// time, including posts without clicks so they still count towards views
+func (r *clickRepository) CountClicksByPost(ctx context.Context, since time.Time) ([]model.PostClickCount, error) {
+	start := time.Now()
+
+	query := `
+		SELECT p.id, p.source, p.category, COUNT(c.id)
+		FROM posts p
+		LEFT JOIN post_clicks c ON c.post_id = p.id
+		WHERE p.created_at >= $1
+		GROUP BY p.id, p.source, p.category
+	`
+
+	var db querier = r.db
+	if r.replicas != nil {
+		db = r.replicas.Reader()
+	}
+
+	rows, err := db.Query(ctx, query, since)
+	if err != nil {
+		r.logger.LogDBOperation("count_clicks_by_post", "post_clicks", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to count clicks: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []model.PostClickCount
+	for rows.Next() {
+		var count model.PostClickCount
+		if err := rows.Scan(&count.PostID, &count.Source, &count.Category, &count.Clicks); err != nil {
+			return nil, fmt.Errorf("failed to scan click count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("count_clicks_by_post", "post_clicks", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate click counts: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_clicks_by_post", "post_clicks", time.Since(start).Milliseconds(), nil)
+
+	return counts, nil
+}
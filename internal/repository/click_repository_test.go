@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClickRepositoryRecordAndCount(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	clicks := NewClickRepository(ts.db, nil, ts.logger)
+
+	clicked, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	other := createSamplePost()
+	other.URL = "https://example.com/other-article"
+	unclicked, err := ts.repo.CreatePost(ctx, other)
+	require.NoError(t, err)
+
+	referrer := "https://example.com/feed"
+	for i := 0; i < 2; i++ {
+		click := &model.PostClick{PostID: clicked.ID, Referrer: &referrer}
+		require.NoError(t, clicks.RecordClick(ctx, click))
+		assert.NotZero(t, click.ID)
+	}
+
+	counts, err := clicks.CountClicksByPost(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, counts, 2)
+
+	byPost := make(map[int64]int64)
+	for _, count := range counts {
+		byPost[count.PostID] = count.Clicks
+	}
+	assert.Equal(t, int64(2), byPost[clicked.ID])
+	assert.Equal(t, int64(0), byPost[unclicked.ID])
+}
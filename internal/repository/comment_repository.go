@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// commentColumns is the column list every comment query selects, in scan order
+const commentColumns = `id, post_id, parent_id, author, body, deleted_at IS NOT NULL, created_at, updated_at`
+
+// commentRepository implements CommentRepository interface
+type commentRepository struct {
+	db       *pgxpool.Pool
+	replicas *database.ReplicaSet
+	logger   *logger.Logger
+}
+
+// NewCommentRepository creates a new comment repository
+func NewCommentRepository(db *pgxpool.Pool, replicas *database.ReplicaSet, logger *logger.Logger) CommentRepository {
+	return &commentRepository{
+		db:       db,
+		replicas: replicas,
+		logger:   logger,
+	}
+}
+
+// CreateComment stores a comment and fills in its ID and timestamps
+func (r *commentRepository) CreateComment(ctx context.Context, comment *model.Comment) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO comments (post_id, parent_id, author, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.conn(ctx).QueryRow(ctx, query, comment.PostID, comment.ParentID, comment.Author, comment.Body).
+		Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		r.logger.LogDBOperation("create_comment", "comments", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	r.logger.LogDBOperation("create_comment", "comments", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// GetComment retrieves a comment on a post, including a deleted one
+func (r *commentRepository) GetComment(ctx context.Context, postID, id int64) (*model.Comment, error) {
+	start := time.Now()
+
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE post_id = $1 AND id = $2`
+	comment, err := scanComment(r.conn(ctx).QueryRow(ctx, query, postID, id))
+	if err != nil {
+		r.logger.LogDBOperation("get_comment", "comments", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	r.logger.LogDBOperation("get_comment", "comments", time.Since(start).Milliseconds(), nil)
+
+	return comment, nil
+}
+
+// ListRootComments returns a page of a post's top-level comments, oldest first
+func (r *commentRepository) ListRootComments(ctx context.Context, postID int64, limit, offset int) ([]model.Comment, error) {
+	start := time.Now()
+
+	query := `
+		SELECT ` + commentColumns + ` FROM comments
+		WHERE post_id = $1 AND parent_id IS NULL
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.reader(ctx).Query(ctx, query, postID, limit, offset)
+	if err != nil {
+		r.logger.LogDBOperation("list_root_comments", "comments", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	comments, err := collectComments(rows)
+	r.logger.LogDBOperation("list_root_comments", "comments", time.Since(start).Milliseconds(), err)
+
+	return comments, err
+}
+
+// CountRootComments counts a post's top-level comments
+func (r *commentRepository) CountRootComments(ctx context.Context, postID int64) (int64, error) {
+	start := time.Now()
+
+	var count int64
+	err := r.reader(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM comments WHERE post_id = $1 AND parent_id IS NULL`, postID).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_root_comments", "comments", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_root_comments", "comments", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// ListReplies returns every descendant of the given comments, oldest first
+func (r *commentRepository) ListReplies(ctx context.Context, rootIDs []int64) ([]model.Comment, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	start := time.Now()
+
+	query := `
+		WITH RECURSIVE thread AS (
+			SELECT * FROM comments WHERE parent_id = ANY($1)
+			UNION ALL
+			SELECT c.* FROM comments c JOIN thread t ON c.parent_id = t.id
+		)
+		SELECT ` + commentColumns + ` FROM thread
+		ORDER BY created_at, id
+	`
+	rows, err := r.reader(ctx).Query(ctx, query, rootIDs)
+	if err != nil {
+		r.logger.LogDBOperation("list_replies", "comments", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list replies: %w", err)
+	}
+
+	replies, err := collectComments(rows)
+	r.logger.LogDBOperation("list_replies", "comments", time.Since(start).Milliseconds(), err)
+
+	return replies, err
+}
+
+// SoftDeleteComment marks a comment as deleted, keeping its place in the
+// thread. It reports false when the comment does not exist or is already deleted.
+func (r *commentRepository) SoftDeleteComment(ctx context.Context, postID, id int64) (bool, error) {
+	start := time.Now()
+
+	query := `
+		UPDATE comments SET deleted_at = NOW(), updated_at = NOW()
+		WHERE post_id = $1 AND id = $2 AND deleted_at IS NULL
+	`
+	tag, err := r.conn(ctx).Exec(ctx, query, postID, id)
+	if err != nil {
+		r.logger.LogDBOperation("soft_delete_comment", "comments", time.Since(start).Milliseconds(), err)
+		return false, fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	r.logger.LogDBOperation("soft_delete_comment", "comments", time.Since(start).Milliseconds(), nil)
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// conn returns the transaction carried by ctx, or the primary pool
+func (r *commentRepository) conn(ctx context.Context) querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx
+	}
+
+	return r.db
+}
+
+// reader returns the connection used for read-only queries; reads inside a
+// transaction stay on that transaction
+func (r *commentRepository) reader(ctx context.Context) querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx
+	}
+
+	if r.replicas == nil {
+		return r.db
+	}
+
+	return r.replicas.Reader()
+}
+
+// scanComment scans a single row selected with commentColumns. The author and
+// body of a deleted comment are never returned.
+func scanComment(row pgx.Row) (*model.Comment, error) {
+	var comment model.Comment
+
+	err := row.Scan(
+		&comment.ID,
+		&comment.PostID,
+		&comment.ParentID,
+		&comment.Author,
+		&comment.Body,
+		&comment.Deleted,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.Deleted {
+		comment.Author = ""
+		comment.Body = ""
+	}
+
+	return &comment, nil
+}
+
+// collectComments scans all rows selected with commentColumns and closes rows
+func collectComments(rows pgx.Rows) ([]model.Comment, error) {
+	defer rows.Close()
+
+	comments := []model.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+
+		comments = append(comments, *comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate comments: %w", err)
+	}
+
+	return comments, nil
+}
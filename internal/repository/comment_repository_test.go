@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentRepositoryThreadsAndSoftDelete(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	post, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	comments := NewCommentRepository(ts.db, nil, ts.logger)
+
+	root := &model.Comment{PostID: post.ID, Author: "jane", Body: "First"}
+	require.NoError(t, comments.CreateComment(ctx, root))
+
+	reply := &model.Comment{PostID: post.ID, ParentID: &root.ID, Author: "sam", Body: "Reply"}
+	require.NoError(t, comments.CreateComment(ctx, reply))
+
+	nested := &model.Comment{PostID: post.ID, ParentID: &reply.ID, Author: "kim", Body: "Nested"}
+	require.NoError(t, comments.CreateComment(ctx, nested))
+
+	roots, err := comments.ListRootComments(ctx, post.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, roots, 1)
+	assert.Equal(t, root.ID, roots[0].ID)
+
+	count, err := comments.CountRootComments(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	replies, err := comments.ListReplies(ctx, []int64{root.ID})
+	require.NoError(t, err)
+	require.Len(t, replies, 2)
+	assert.Equal(t, reply.ID, replies[0].ID)
+	assert.Equal(t, nested.ID, replies[1].ID)
+
+	deleted, err := comments.SoftDeleteComment(ctx, post.ID, reply.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	// Deleting twice reports nothing deleted
+	deleted, err = comments.SoftDeleteComment(ctx, post.ID, reply.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	got, err := comments.GetComment(ctx, post.ID, reply.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Deleted)
+	assert.Empty(t, got.Body)
+	assert.Empty(t, got.Author)
+}
+
+func TestPostRepositoryAdjustCommentCount(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	post, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	require.NoError(t, ts.repo.AdjustCommentCount(ctx, post.ID, 2))
+	require.NoError(t, ts.repo.AdjustCommentCount(ctx, post.ID, -1))
+
+	got, err := ts.repo.GetPostByID(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.CommentCount)
+	assert.Equal(t, post.Version, got.Version)
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// deadLetterColumns are the columns scanned by scanDeadLetter
+const deadLetterColumns = `id, url, source, country, payload, error_type, error, attempts, created_at, last_failed_at`
+
+// deadLetterRepository implements DeadLetterRepository interface
+type deadLetterRepository struct {
+	db     *pgxpool.Pool
+	logger *logger.Logger
+}
+
+// NewDeadLetterRepository creates a new dead letter repository
+func NewDeadLetterRepository(db *pgxpool.Pool, logger *logger.Logger) DeadLetterRepository {
+	return &deadLetterRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// SaveDeadLetter stores an article that failed to persist. An article that
+// already has an entry gets the new payload and error, and one more attempt.
+func (r *deadLetterRepository) SaveDeadLetter(ctx context.Context, letter *model.DeadLetter) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO dead_letters (url, source, country, payload, error_type, error)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant_id, url) DO UPDATE SET
+			source = EXCLUDED.source,
+			country = EXCLUDED.country,
+			payload = EXCLUDED.payload,
+			error_type = EXCLUDED.error_type,
+			error = EXCLUDED.error,
+			attempts = dead_letters.attempts + 1,
+			last_failed_at = NOW()
+	`
+	_, err := r.db.Exec(ctx, query, letter.URL, letter.Source, letter.Country, letter.Payload, letter.ErrorType, letter.Error)
+	if err != nil {
+		r.logger.LogDBOperation("save_dead_letter", "dead_letters", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+
+	r.logger.LogDBOperation("save_dead_letter", "dead_letters", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// GetDeadLetter returns a dead letter by ID, or nil when there is none
+func (r *deadLetterRepository) GetDeadLetter(ctx context.Context, id int64) (*model.DeadLetter, error) {
+	start := time.Now()
+
+	query := `SELECT ` + deadLetterColumns + ` FROM dead_letters WHERE id = $1`
+	letter, err := scanDeadLetter(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		r.logger.LogDBOperation("get_dead_letter", "dead_letters", time.Since(start).Milliseconds(), nil)
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.LogDBOperation("get_dead_letter", "dead_letters", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+
+	r.logger.LogDBOperation("get_dead_letter", "dead_letters", time.Since(start).Milliseconds(), nil)
+
+	return letter, nil
+}
+
+// ListDeadLetters returns dead letters, most recent failure first
+func (r *deadLetterRepository) ListDeadLetters(ctx context.Context, limit, offset int) ([]model.DeadLetter, error) {
+	start := time.Now()
+
+	query := `
+		SELECT ` + deadLetterColumns + `
+		FROM dead_letters
+		ORDER BY last_failed_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		r.logger.LogDBOperation("list_dead_letters", "dead_letters", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	letters := []model.DeadLetter{}
+	for rows.Next() {
+		letter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		letters = append(letters, *letter)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("list_dead_letters", "dead_letters", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate dead letters: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_dead_letters", "dead_letters", time.Since(start).Milliseconds(), nil)
+
+	return letters, nil
+}
+
+// CountDeadLetters returns the number of dead letters
+func (r *deadLetterRepository) CountDeadLetters(ctx context.Context) (int64, error) {
+	start := time.Now()
+
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM dead_letters`).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_dead_letters", "dead_letters", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_dead_letters", "dead_letters", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// DeleteDeadLetter removes a dead letter, reporting whether it was there
+func (r *deadLetterRepository) DeleteDeadLetter(ctx context.Context, id int64) (bool, error) {
+	start := time.Now()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM dead_letters WHERE id = $1`, id)
+	if err != nil {
+		r.logger.LogDBOperation("delete_dead_letter", "dead_letters", time.Since(start).Milliseconds(), err)
+		return false, fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+
+	r.logger.LogDBOperation("delete_dead_letter", "dead_letters", time.Since(start).Milliseconds(), nil)
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// scanDeadLetter reads a row of deadLetterColumns
+func scanDeadLetter(row pgx.Row) (*model.DeadLetter, error) {
+	var letter model.DeadLetter
+	err := row.Scan(
+		&letter.ID,
+		&letter.URL,
+		&letter.Source,
+		&letter.Country,
+		&letter.Payload,
+		&letter.ErrorType,
+		&letter.Error,
+		&letter.Attempts,
+		&letter.CreatedAt,
+		&letter.LastFailedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &letter, nil
+}
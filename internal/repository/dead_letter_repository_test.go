@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterRepositorySaveRetryAndDelete(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	deadLetters := NewDeadLetterRepository(ts.db, ts.logger)
+
+	source := "TechCrunch"
+	country := "us"
+	letter := &model.DeadLetter{
+		URL:       "https://example.com/ai",
+		Source:    &source,
+		Country:   &country,
+		Payload:   json.RawMessage(`{"title":"New breakthrough in AI","url":"https://example.com/ai"}`),
+		ErrorType: model.AggregationErrorDB,
+		Error:     "post could not be stored: connection refused",
+	}
+	require.NoError(t, deadLetters.SaveDeadLetter(ctx, letter))
+
+	// Failing again updates the entry and counts the attempt
+	letter.ErrorType = model.AggregationErrorParse
+	letter.Error = "article could not be parsed"
+	require.NoError(t, deadLetters.SaveDeadLetter(ctx, letter))
+
+	count, err := deadLetters.CountDeadLetters(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	letters, err := deadLetters.ListDeadLetters(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, 2, letters[0].Attempts)
+	assert.Equal(t, model.AggregationErrorParse, letters[0].ErrorType)
+	assert.Equal(t, "article could not be parsed", letters[0].Error)
+	assert.Equal(t, country, *letters[0].Country)
+	assert.JSONEq(t, string(letter.Payload), string(letters[0].Payload))
+
+	stored, err := deadLetters.GetDeadLetter(ctx, letters[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, letter.URL, stored.URL)
+
+	deleted, err := deadLetters.DeleteDeadLetter(ctx, stored.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	stored, err = deadLetters.GetDeadLetter(ctx, letters[0].ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	deleted, err = deadLetters.DeleteDeadLetter(ctx, letters[0].ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
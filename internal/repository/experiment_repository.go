@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// experimentsKey is the Redis hash holding experiment definitions by name
+const experimentsKey = "experiments"
+
+// experimentRepository implements ExperimentRepository. Definitions live in
+// Redis so every replica sees the same experiments; events go to Postgres.
+type experimentRepository struct {
+	db     *pgxpool.Pool
+	redis  redis.UniversalClient
+	logger *logger.Logger
+}
+
+// NewExperimentRepository creates a new experiment repository
+func NewExperimentRepository(db *pgxpool.Pool, redis redis.UniversalClient, logger *logger.Logger) ExperimentRepository {
+	return &experimentRepository{
+		db:     db,
+		redis:  redis,
+		logger: logger,
+	}
+}
+
+// SaveExperiment creates or replaces an experiment definition
+func (r *experimentRepository) SaveExperiment(ctx context.Context, experiment *model.Experiment) error {
+	data, err := json.Marshal(experiment)
+	if err != nil {
+		return fmt.Errorf("failed to encode experiment: %w", err)
+	}
+
+	if err := r.redis.HSet(ctx, tenant.Key(ctx, experimentsKey), experiment.Name, data).Err(); err != nil {
+		return fmt.Errorf("failed to save experiment: %w", err)
+	}
+
+	return nil
+}
+
+// ListExperiments returns all experiment definitions ordered by name
+func (r *experimentRepository) ListExperiments(ctx context.Context) ([]model.Experiment, error) {
+	values, err := r.redis.HGetAll(ctx, tenant.Key(ctx, experimentsKey)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiments: %w", err)
+	}
+
+	experiments := make([]model.Experiment, 0, len(values))
+	for name, value := range values {
+		var experiment model.Experiment
+		if err := json.Unmarshal([]byte(value), &experiment); err != nil {
+			r.logger.Warn("Skipping malformed experiment", "name", name, "error", err.Error())
+			continue
+		}
+		experiments = append(experiments, experiment)
+	}
+
+	sort.Slice(experiments, func(i, j int) bool {
+		return experiments[i].Name < experiments[j].Name
+	})
+
+	return experiments, nil
+}
+
+// DeleteExperiment removes an experiment definition, reporting whether it existed
+func (r *experimentRepository) DeleteExperiment(ctx context.Context, name string) (bool, error) {
+	deleted, err := r.redis.HDel(ctx, tenant.Key(ctx, experimentsKey), name).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete experiment: %w", err)
+	}
+
+	return deleted > 0, nil
+}
+
+// RecordEvent stores an exposure or click event
+func (r *experimentRepository) RecordEvent(ctx context.Context, event *model.ExperimentEvent) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO experiment_events (experiment, variant, event_type, user_id, post_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.Exec(ctx, query, event.Experiment, event.Variant, event.Type, event.UserID, event.PostID)
+	if err != nil {
+		r.logger.LogDBOperation("record_event", "experiment_events", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to record experiment event: %w", err)
+	}
+
+	r.logger.LogDBOperation("record_event", "experiment_events", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// GetResults aggregates exposures and clicks per variant for an experiment
+func (r *experimentRepository) GetResults(ctx context.Context, experiment string) ([]model.VariantResult, error) {
+	start := time.Now()
+
+	query := `
+		SELECT variant,
+			COUNT(*) FILTER (WHERE event_type = 'exposure'),
+			COUNT(*) FILTER (WHERE event_type = 'click')
+		FROM experiment_events
+		WHERE experiment = $1
+		GROUP BY variant
+		ORDER BY variant
+	`
+	rows, err := r.db.Query(ctx, query, experiment)
+	if err != nil {
+		r.logger.LogDBOperation("get_results", "experiment_events", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to get experiment results: %w", err)
+	}
+	defer rows.Close()
+
+	var results []model.VariantResult
+	for rows.Next() {
+		var result model.VariantResult
+		if err := rows.Scan(&result.Variant, &result.Exposures, &result.Clicks); err != nil {
+			return nil, fmt.Errorf("failed to scan experiment result: %w", err)
+		}
+		if result.Exposures > 0 {
+			result.CTR = float64(result.Clicks) / float64(result.Exposures)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("get_results", "experiment_events", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate experiment results: %w", err)
+	}
+
+	r.logger.LogDBOperation("get_results", "experiment_events", time.Since(start).Milliseconds(), nil)
+
+	return results, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+	"github.com/redis/go-redis/v9"
+)
+
+// l1ResubscribeDelay is how long the listener waits before receiving again
+// after the subscription failed
+const l1ResubscribeDelay = time.Second
+
+// l1Invalidation is the message broadcast when a replica invalidates keys
+// of its L1 cache
+type l1Invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+// l1Invalidator keeps the L1 caches of the replicas sharing a Redis in
+// step: keys evicted on one replica are published on a channel, and every
+// other replica evicts them from its own L1 when the message arrives.
+// Delivery is best effort; entries whose message is lost still expire
+// after the L1 TTL.
+type l1Invalidator struct {
+	redis   redis.UniversalClient
+	channel string
+	local   *lru.Cache[string, any]
+	origin  string
+	logger  *logger.Logger
+}
+
+// newL1Invalidator creates an invalidator for local and starts listening
+// for the invalidations of other replicas. The subscription lasts as long
+// as the process.
+func newL1Invalidator(rdb redis.UniversalClient, channel string, local *lru.Cache[string, any], logger *logger.Logger) *l1Invalidator {
+	origin := make([]byte, 8)
+	rand.Read(origin)
+
+	inv := &l1Invalidator{
+		redis:   rdb,
+		channel: channel,
+		local:   local,
+		origin:  hex.EncodeToString(origin),
+		logger:  logger,
+	}
+
+	go inv.listen(context.Background())
+
+	return inv
+}
+
+// publish tells the other replicas to evict keys. A failed publish is only
+// logged, the other replicas' copies then expiring on their own.
+func (i *l1Invalidator) publish(ctx context.Context, keys ...string) {
+	payload, err := json.Marshal(l1Invalidation{Origin: i.origin, Keys: keys})
+	if err != nil {
+		return
+	}
+
+	if err := i.redis.Publish(context.WithoutCancel(ctx), i.channel, payload).Err(); err != nil {
+		i.logger.Warn("Failed to publish L1 cache invalidation", "channel", i.channel, "error", err.Error())
+		return
+	}
+
+	i.logger.LogCacheOperation("publish_invalidation", i.channel, false)
+}
+
+// listen evicts the keys invalidated by other replicas until ctx is done.
+// Messages published while the subscription was down are lost, so the
+// whole L1 is dropped whenever it is re-established.
+func (i *l1Invalidator) listen(ctx context.Context) {
+	pubsub := i.redis.Subscribe(ctx, i.channel)
+	defer pubsub.Close()
+
+	subscribed := false
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			i.logger.Warn("L1 cache invalidation subscription failed", "channel", i.channel, "error", err.Error())
+			time.Sleep(l1ResubscribeDelay)
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			if subscribed {
+				i.local.Purge()
+				i.logger.Info("L1 cache invalidation subscription restored, L1 cache dropped", "channel", i.channel)
+			}
+			subscribed = true
+		case *redis.Message:
+			i.evict(msg.Payload)
+		}
+	}
+}
+
+// evict drops the keys named in a broadcast invalidation, ignoring this
+// replica's own messages
+func (i *l1Invalidator) evict(payload string) {
+	var invalidation l1Invalidation
+	if err := json.Unmarshal([]byte(payload), &invalidation); err != nil {
+		i.logger.Warn("Ignoring malformed L1 cache invalidation", "channel", i.channel, "error", err.Error())
+		return
+	}
+
+	if invalidation.Origin == i.origin {
+		return
+	}
+
+	i.local.Delete(invalidation.Keys...)
+	i.logger.LogCacheOperation("evict_local", i.channel, false)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestL1InvalidatorEvict(t *testing.T) {
+	local := lru.New[string, any](10, time.Minute)
+	inv := &l1Invalidator{
+		local:  local,
+		origin: "self",
+		logger: logger.New(&config.Config{App: config.AppConfig{LogLevel: "debug"}}),
+	}
+
+	local.Set("post:id:1", 1)
+	local.Set("post:id:2", 2)
+
+	// A replica's own messages are ignored, having been applied already
+	own, err := json.Marshal(l1Invalidation{Origin: "self", Keys: []string{"post:id:1"}})
+	require.NoError(t, err)
+	inv.evict(string(own))
+	assert.Equal(t, 2, local.Len())
+
+	other, err := json.Marshal(l1Invalidation{Origin: "other", Keys: []string{"post:id:1", "posts:count"}})
+	require.NoError(t, err)
+	inv.evict(string(other))
+	_, ok := local.Get("post:id:1")
+	assert.False(t, ok)
+	_, ok = local.Get("post:id:2")
+	assert.True(t, ok)
+
+	inv.evict("not json")
+	assert.Equal(t, 1, local.Len())
+}
+
+func TestPostRepositoryL1InvalidationAcrossReplicas(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	cacheCfg := config.CacheConfig{
+		TTL:                   time.Minute,
+		L1Enabled:             true,
+		L1Size:                100,
+		L1TTL:                 time.Minute,
+		L1InvalidationEnabled: true,
+		L1InvalidationChannel: "cache:l1:invalidate",
+	}
+	writer := NewPostRepository(ts.db, nil, ts.redisClient, ts.logger, cacheCfg)
+	reader := NewPostRepository(ts.db, nil, ts.redisClient, ts.logger, cacheCfg)
+
+	post, err := writer.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	// Fill the reader's L1
+	cached, err := reader.GetPostByID(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.Title, cached.Title)
+
+	require.NoError(t, writer.DeletePost(ctx, post.ID))
+
+	assert.Eventually(t, func() bool {
+		_, err := reader.GetPostByID(ctx, post.ID)
+		return err != nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
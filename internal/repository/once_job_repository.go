@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// The one-time job queue is a sorted set of job IDs scored by when they are
+// due, next to a hash of the jobs by ID and a hash of how many times each
+// was claimed. Jobs whose last attempt failed move to the dead hash. The
+// hash tag keeps them all in one cluster slot, as the scripts touch several.
+const (
+	onceJobQueueKey    = "{scheduler:once}:queue"
+	onceJobsKey        = "{scheduler:once}:jobs"
+	onceJobAttemptsKey = "{scheduler:once}:attempts"
+	deadOnceJobsKey    = "{scheduler:once}:dead"
+)
+
+// claimOnceJobsScript returns the jobs due by ARGV[1], each as its ID, job
+// and attempt count, and pushes them back to ARGV[2], so other replicas leave
+// them alone while they run but pick them up again should this one die
+// first. IDs left without a job are dropped.
+var claimOnceJobsScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[3]))
+local jobs = {}
+for _, id in ipairs(due) do
+	local job = redis.call('HGET', KEYS[2], id)
+	if job then
+		redis.call('ZADD', KEYS[1], ARGV[2], id)
+		table.insert(jobs, id)
+		table.insert(jobs, job)
+		table.insert(jobs, tostring(redis.call('HINCRBY', KEYS[3], id, 1)))
+	else
+		redis.call('ZREM', KEYS[1], id)
+		redis.call('HDEL', KEYS[3], id)
+	end
+end
+return jobs
+`)
+
+// requeueDeadOnceJobScript moves dead job ARGV[1] back to the queue as
+// ARGV[2], due at ARGV[3], unless another request got to it first
+var requeueDeadOnceJobScript = redis.NewScript(`
+if redis.call('HDEL', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+redis.call('ZADD', KEYS[3], ARGV[3], ARGV[1])
+return 1
+`)
+
+// onceJobRepository implements OnceJobRepository interface
+type onceJobRepository struct {
+	redis  redis.UniversalClient
+	logger *logger.Logger
+}
+
+// NewOnceJobRepository creates a new one-time job repository
+func NewOnceJobRepository(redis redis.UniversalClient, logger *logger.Logger) OnceJobRepository {
+	return &onceJobRepository{
+		redis:  redis,
+		logger: logger,
+	}
+}
+
+// SaveOnceJob queues a job to run at job.RunAt unless a job with the same
+// ID is already queued, reporting whether it was added
+func (r *onceJobRepository) SaveOnceJob(ctx context.Context, job *model.OnceJob) (bool, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode one-time job: %w", err)
+	}
+
+	added, err := r.redis.HSetNX(ctx, onceJobsKey, job.ID, data).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to save one-time job: %w", err)
+	}
+	if !added {
+		return false, nil
+	}
+
+	// NX keeps the lease of a job claimed between the two writes
+	err = r.redis.ZAddNX(ctx, onceJobQueueKey, redis.Z{Score: float64(job.RunAt.UnixMilli()), Member: job.ID}).Err()
+	if err != nil {
+		r.redis.HDel(context.WithoutCancel(ctx), onceJobsKey, job.ID)
+		return false, fmt.Errorf("failed to save one-time job: %w", err)
+	}
+
+	r.logger.LogCacheOperation("save_once_job", job.ID, false)
+
+	return true, nil
+}
+
+// ClaimDueOnceJobs returns up to limit jobs due by now and hides them from
+// other claims for lease. Jobs that cannot be decoded would be claimed again
+// at every lease, so they are dead-lettered instead.
+func (r *onceJobRepository) ClaimDueOnceJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OnceJob, error) {
+	values, err := claimOnceJobsScript.Run(ctx, r.redis, []string{onceJobQueueKey, onceJobsKey, onceJobAttemptsKey},
+		now.UnixMilli(), now.Add(lease).UnixMilli(), limit,
+	).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim one-time jobs: %w", err)
+	}
+
+	jobs := make([]model.OnceJob, 0, len(values)/3)
+	for i := 0; i+2 < len(values); i += 3 {
+		id := values[i]
+		attempts, _ := strconv.Atoi(values[i+2])
+
+		var job model.OnceJob
+		if err := json.Unmarshal([]byte(values[i+1]), &job); err != nil {
+			r.logger.Warn("Dead-lettering unreadable one-time job", "id", id, "error", err.Error())
+			dead := &model.DeadOnceJob{
+				OnceJob:  model.OnceJob{ID: id, Attempts: attempts},
+				Error:    "unreadable job: " + err.Error(),
+				FailedAt: now,
+			}
+			if err := r.DeadLetterOnceJob(ctx, dead); err != nil {
+				r.logger.Warn("Failed to dead-letter unreadable one-time job", "id", id, "error", err.Error())
+			}
+			continue
+		}
+		job.Attempts = attempts
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// RescheduleOnceJob moves a queued job's next run to at, which also ends or
+// extends its lease. Jobs no longer queued are left alone.
+func (r *onceJobRepository) RescheduleOnceJob(ctx context.Context, id string, at time.Time) error {
+	err := r.redis.ZAddXX(ctx, onceJobQueueKey, redis.Z{Score: float64(at.UnixMilli()), Member: id}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to reschedule one-time job: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOnceJob removes a job from the queue, reporting whether it was there
+func (r *onceJobRepository) DeleteOnceJob(ctx context.Context, id string) (bool, error) {
+	pipe := r.redis.TxPipeline()
+	deleted := pipe.HDel(ctx, onceJobsKey, id)
+	pipe.ZRem(ctx, onceJobQueueKey, id)
+	pipe.HDel(ctx, onceJobAttemptsKey, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("failed to delete one-time job: %w", err)
+	}
+
+	return deleted.Val() > 0, nil
+}
+
+// DeadLetterOnceJob takes a job off the queue and keeps it among the dead
+// jobs for inspection
+func (r *onceJobRepository) DeadLetterOnceJob(ctx context.Context, job *model.DeadOnceJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead one-time job: %w", err)
+	}
+
+	pipe := r.redis.TxPipeline()
+	pipe.HSet(ctx, deadOnceJobsKey, job.ID, data)
+	pipe.HDel(ctx, onceJobsKey, job.ID)
+	pipe.ZRem(ctx, onceJobQueueKey, job.ID)
+	pipe.HDel(ctx, onceJobAttemptsKey, job.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to dead-letter one-time job: %w", err)
+	}
+
+	r.logger.LogCacheOperation("dead_letter_once_job", job.ID, false)
+
+	return nil
+}
+
+// ListDeadOnceJobs returns the dead jobs, most recent failure first
+func (r *onceJobRepository) ListDeadOnceJobs(ctx context.Context) ([]model.DeadOnceJob, error) {
+	values, err := r.redis.HVals(ctx, deadOnceJobsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead one-time jobs: %w", err)
+	}
+
+	jobs := make([]model.DeadOnceJob, 0, len(values))
+	for _, value := range values {
+		var job model.DeadOnceJob
+		if err := json.Unmarshal([]byte(value), &job); err != nil {
+			r.logger.Warn("Skipping unreadable dead one-time job", "error", err.Error())
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	slices.SortStableFunc(jobs, func(a, b model.DeadOnceJob) int {
+		return b.FailedAt.Compare(a.FailedAt)
+	})
+
+	return jobs, nil
+}
+
+// RequeueDeadOnceJob queues a dead job again to run at at with a fresh
+// attempt count. It returns nil when there is no such dead job.
+func (r *onceJobRepository) RequeueDeadOnceJob(ctx context.Context, id string, at time.Time) (*model.OnceJob, error) {
+	value, err := r.redis.HGet(ctx, deadOnceJobsKey, id).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead one-time job: %w", err)
+	}
+
+	var dead model.DeadOnceJob
+	if err := json.Unmarshal([]byte(value), &dead); err != nil {
+		return nil, fmt.Errorf("failed to decode dead one-time job: %w", err)
+	}
+
+	job := dead.OnceJob
+	job.RunAt = at.UTC()
+	job.Attempts = 0
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode one-time job: %w", err)
+	}
+
+	moved, err := requeueDeadOnceJobScript.Run(ctx, r.redis, []string{deadOnceJobsKey, onceJobsKey, onceJobQueueKey},
+		id, data, at.UnixMilli(),
+	).Int()
+	if err != nil {
+		return nil, fmt.Errorf("failed to requeue dead one-time job: %w", err)
+	}
+	if moved == 0 {
+		return nil, nil
+	}
+
+	r.logger.LogCacheOperation("requeue_dead_once_job", id, false)
+
+	return &job, nil
+}
+
+// DeleteDeadOnceJob discards a dead job, reporting whether it was there
+func (r *onceJobRepository) DeleteDeadOnceJob(ctx context.Context, id string) (bool, error) {
+	deleted, err := r.redis.HDel(ctx, deadOnceJobsKey, id).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete dead one-time job: %w", err)
+	}
+
+	return deleted > 0, nil
+}
+
+// ListOnceJobs returns the queued jobs, soonest first. Jobs that are running
+// are listed too, with the time they were due.
+func (r *onceJobRepository) ListOnceJobs(ctx context.Context) ([]model.OnceJob, error) {
+	ids, err := r.redis.ZRange(ctx, onceJobQueueKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list one-time jobs: %w", err)
+	}
+	if len(ids) == 0 {
+		return []model.OnceJob{}, nil
+	}
+
+	values, err := r.redis.HMGet(ctx, onceJobsKey, ids...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list one-time jobs: %w", err)
+	}
+
+	attempts, err := r.redis.HMGet(ctx, onceJobAttemptsKey, ids...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list one-time jobs: %w", err)
+	}
+
+	jobs := make([]model.OnceJob, 0, len(values))
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var job model.OnceJob
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			r.logger.Warn("Skipping unreadable one-time job", "error", err.Error())
+			continue
+		}
+		if count, ok := attempts[i].(string); ok {
+			job.Attempts, _ = strconv.Atoi(count)
+		}
+		jobs = append(jobs, job)
+	}
+
+	slices.SortStableFunc(jobs, func(a, b model.OnceJob) int {
+		return a.RunAt.Compare(b.RunAt)
+	})
+
+	return jobs, nil
+}
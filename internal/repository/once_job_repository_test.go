@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnceJobRepositoryClaimAndDelete(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	jobs := NewOnceJobRepository(ts.redisClient, ts.logger)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	due := &model.OnceJob{ID: "due", Task: "post-purge", RunAt: now.Add(-time.Minute), Tenant: "default", Payload: json.RawMessage(`{"post_id":7}`)}
+	later := &model.OnceJob{ID: "later", Task: "post-purge", RunAt: now.Add(time.Hour), Tenant: "default"}
+	for _, job := range []*model.OnceJob{later, due} {
+		added, err := jobs.SaveOnceJob(ctx, job)
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+
+	// A job already queued is not queued twice
+	added, err := jobs.SaveOnceJob(ctx, due)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	claimed, err := jobs.ClaimDueOnceJobs(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "due", claimed[0].ID)
+	assert.Equal(t, 1, claimed[0].Attempts)
+	assert.JSONEq(t, `{"post_id":7}`, string(claimed[0].Payload))
+
+	// A claimed job is hidden until its lease ends
+	claimed, err = jobs.ClaimDueOnceJobs(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	claimed, err = jobs.ClaimDueOnceJobs(ctx, now.Add(2*time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "due", claimed[0].ID)
+	assert.Equal(t, 2, claimed[0].Attempts)
+
+	listed, err := jobs.ListOnceJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, "due", listed[0].ID)
+	assert.Equal(t, "later", listed[1].ID)
+
+	deleted, err := jobs.DeleteOnceJob(ctx, "due")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = jobs.DeleteOnceJob(ctx, "due")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	listed, err = jobs.ListOnceJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "later", listed[0].ID)
+}
+
+func TestOnceJobRepositoryDeadLetter(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	jobs := NewOnceJobRepository(ts.redisClient, ts.logger)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	_, err := jobs.SaveOnceJob(ctx, &model.OnceJob{ID: "flaky", Task: "webhook", RunAt: now, Tenant: "default"})
+	require.NoError(t, err)
+
+	claimed, err := jobs.ClaimDueOnceJobs(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	// A retry makes the job due again before its lease ends
+	require.NoError(t, jobs.RescheduleOnceJob(ctx, "flaky", now))
+	claimed, err = jobs.ClaimDueOnceJobs(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, 2, claimed[0].Attempts)
+
+	dead := &model.DeadOnceJob{OnceJob: claimed[0], Error: "webhook unreachable", FailedAt: now}
+	require.NoError(t, jobs.DeadLetterOnceJob(ctx, dead))
+
+	listed, err := jobs.ListOnceJobs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	deadJobs, err := jobs.ListDeadOnceJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, deadJobs, 1)
+	assert.Equal(t, "flaky", deadJobs[0].ID)
+	assert.Equal(t, 2, deadJobs[0].Attempts)
+	assert.Equal(t, "webhook unreachable", deadJobs[0].Error)
+
+	requeued, err := jobs.RequeueDeadOnceJob(ctx, "flaky", now)
+	require.NoError(t, err)
+	require.NotNil(t, requeued)
+	assert.Equal(t, 0, requeued.Attempts)
+
+	requeued, err = jobs.RequeueDeadOnceJob(ctx, "flaky", now)
+	require.NoError(t, err)
+	assert.Nil(t, requeued)
+
+	claimed, err = jobs.ClaimDueOnceJobs(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, 1, claimed[0].Attempts)
+
+	deleted, err := jobs.DeleteDeadOnceJob(ctx, "flaky")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
+
+func TestOnceJobRepositoryDeadLettersUnreadableJob(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	jobs := NewOnceJobRepository(ts.redisClient, ts.logger)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	require.NoError(t, ts.redisClient.HSet(ctx, onceJobsKey, "garbled", "{not json").Err())
+	require.NoError(t, ts.redisClient.ZAdd(ctx, onceJobQueueKey, redis.Z{Score: float64(now.UnixMilli()), Member: "garbled"}).Err())
+
+	claimed, err := jobs.ClaimDueOnceJobs(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	// It is not claimed again once its lease would have ended
+	claimed, err = jobs.ClaimDueOnceJobs(ctx, now.Add(2*time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	queued, err := ts.redisClient.ZCard(ctx, onceJobQueueKey).Result()
+	require.NoError(t, err)
+	assert.Zero(t, queued)
+
+	deadJobs, err := jobs.ListDeadOnceJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, deadJobs, 1)
+	assert.Equal(t, "garbled", deadJobs[0].ID)
+	assert.Equal(t, 1, deadJobs[0].Attempts)
+	assert.Contains(t, deadJobs[0].Error, "unreadable job")
+}
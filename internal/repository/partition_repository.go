@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// partitionRepository implements PartitionRepository interface
+type partitionRepository struct {
+	db     *pgxpool.Pool
+	logger *logger.Logger
+}
+
+// NewPartitionRepository creates a new partition maintenance repository
+func NewPartitionRepository(db *pgxpool.Pool, logger *logger.Logger) PartitionRepository {
+	return &partitionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// EnsurePostPartitions creates the monthly posts partitions from the current
+// month to monthsAhead months later, and those of any month with posts
+// waiting in the default partition. It returns the number created.
+func (r *partitionRepository) EnsurePostPartitions(ctx context.Context, monthsAhead int) (int, error) {
+	start := time.Now()
+
+	var created int
+	if err := r.db.QueryRow(ctx, `SELECT ensure_posts_partitions($1)`, monthsAhead).Scan(&created); err != nil {
+		r.logger.LogDBOperation("ensure_partitions", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to ensure posts partitions: %w", err)
+	}
+
+	r.logger.LogDBOperation("ensure_partitions", "posts", time.Since(start).Milliseconds(), nil)
+
+	return created, nil
+}
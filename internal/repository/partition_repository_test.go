@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionRepositoryEnsurePostPartitions(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	partitions := NewPartitionRepository(ts.db, ts.logger)
+
+	// The current month and the three after it were created with the schema
+	created, err := partitions.EnsurePostPartitions(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, created)
+
+	// A month without a partition is held by the default partition until
+	// maintenance creates one and moves the post across
+	params := createSamplePost()
+	publishedAt := time.Date(2020, time.January, 15, 12, 0, 0, 0, time.UTC)
+	params.PublishedAt = &publishedAt
+	post, err := ts.repo.CreatePost(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, "posts_default", postPartition(t, ts, post.ID))
+
+	created, err = partitions.EnsurePostPartitions(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, created)
+	assert.Equal(t, "posts_2020_01", postPartition(t, ts, post.ID))
+
+	found, err := ts.repo.GetPostByURL(ctx, params.URL)
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, found.ID)
+}
+
+func TestPartitionRepositoryUpsertMovesPostAcrossPartitions(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	clicks := NewClickRepository(ts.db, nil, ts.logger)
+
+	params := createSamplePost()
+	params.PublishedAt = nil
+	post, err := ts.repo.UpsertPost(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, "posts_default", postPartition(t, ts, post.ID))
+	require.NoError(t, clicks.RecordClick(ctx, &model.PostClick{PostID: post.ID}))
+
+	newer := createSamplePost()
+	now := time.Now().UTC()
+	newer.PublishedAt = &now
+	updated, err := ts.repo.UpsertPost(ctx, newer)
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, updated.ID)
+	assert.NotEqual(t, "posts_default", postPartition(t, ts, post.ID))
+
+	// Moving the post keeps its URL claimed and its clicks in place
+	exists, err := ts.repo.ExistsByURL(ctx, params.URL)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, int64(1), countRows(t, ts, "post_clicks"))
+
+	_, err = ts.repo.CreatePost(ctx, createSamplePost())
+	assert.True(t, isUniqueViolation(err))
+
+	require.NoError(t, ts.repo.DeletePost(ctx, post.ID))
+
+	exists, err = ts.repo.ExistsByURL(ctx, params.URL)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, int64(0), countRows(t, ts, "post_clicks"))
+}
+
+// postPartition returns the partition holding the post with the given ID
+func postPartition(t *testing.T, ts *testSuite, id int64) string {
+	var partition string
+	require.NoError(t, ts.db.QueryRow(context.Background(), `SELECT tableoid::regclass::text FROM posts WHERE id = $1`, id).Scan(&partition))
+	return partition
+}
+
+// countRows returns the number of rows in table
+func countRows(t *testing.T, ts *testSuite, table string) int64 {
+	var count int64
+	require.NoError(t, ts.db.QueryRow(context.Background(), `SELECT COUNT(*) FROM `+table).Scan(&count))
+	return count
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pinRepository implements PinRepository interface
+type pinRepository struct {
+	db     *pgxpool.Pool
+	logger *logger.Logger
+}
+
+// NewPinRepository creates a new pin repository
+func NewPinRepository(db *pgxpool.Pool, logger *logger.Logger) PinRepository {
+	return &pinRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// SavePin pins a post to pin.Position, or moves the post's pin there, and
+// fills in PinnedAt. The post pinned to that position before is unpinned and
+// expired pins are dropped along the way.
+func (r *pinRepository) SavePin(ctx context.Context, pin *model.PostPin) error {
+	start := time.Now()
+
+	// The delete never touches the pinned post's own row, which the insert
+	// may update in the same statement
+	query := `
+		WITH dropped AS (
+			DELETE FROM pinned_posts
+			WHERE post_id <> $1 AND (position = $2 OR expires_at <= NOW())
+		)
+		INSERT INTO pinned_posts (post_id, position, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, post_id) DO UPDATE SET
+			position = EXCLUDED.position,
+			expires_at = EXCLUDED.expires_at,
+			pinned_at = NOW()
+		RETURNING pinned_at
+	`
+	err := r.db.QueryRow(ctx, query, pin.PostID, pin.Position, pin.ExpiresAt).Scan(&pin.PinnedAt)
+	if err != nil {
+		r.logger.LogDBOperation("save_pin", "pinned_posts", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to save pin: %w", err)
+	}
+
+	r.logger.LogDBOperation("save_pin", "pinned_posts", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// DeletePin unpins a post, reporting whether it was pinned
+func (r *pinRepository) DeletePin(ctx context.Context, postID int64) (bool, error) {
+	start := time.Now()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM pinned_posts WHERE post_id = $1`, postID)
+	if err != nil {
+		r.logger.LogDBOperation("delete_pin", "pinned_posts", time.Since(start).Milliseconds(), err)
+		return false, fmt.Errorf("failed to delete pin: %w", err)
+	}
+
+	r.logger.LogDBOperation("delete_pin", "pinned_posts", time.Since(start).Milliseconds(), nil)
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListActivePins returns the unexpired pins at positions up to maxPosition,
+// ordered by position with the most recent pin first on a tie
+func (r *pinRepository) ListActivePins(ctx context.Context, maxPosition int) ([]model.PostPin, error) {
+	start := time.Now()
+
+	query := `
+		SELECT post_id, position, pinned_at, expires_at FROM pinned_posts
+		WHERE position <= $1 AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY position, pinned_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, maxPosition)
+	if err != nil {
+		r.logger.LogDBOperation("list_active_pins", "pinned_posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list pins: %w", err)
+	}
+	defer rows.Close()
+
+	pins := []model.PostPin{}
+	for rows.Next() {
+		var pin model.PostPin
+		if err := rows.Scan(&pin.PostID, &pin.Position, &pin.PinnedAt, &pin.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pin: %w", err)
+		}
+		pins = append(pins, pin)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("list_active_pins", "pinned_posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate pins: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_active_pins", "pinned_posts", time.Since(start).Milliseconds(), nil)
+
+	return pins, nil
+}
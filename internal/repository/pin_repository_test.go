@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinRepositorySaveListDelete(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	pins := NewPinRepository(ts.db, ts.logger)
+
+	var posts []*model.Post
+	for _, url := range []string{"https://example.com/first", "https://example.com/second", "https://example.com/third"} {
+		params := createSamplePost()
+		params.URL = url
+		post, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+		posts = append(posts, post)
+	}
+
+	require.NoError(t, pins.SavePin(ctx, &model.PostPin{PostID: posts[0].ID, Position: 2}))
+	second := &model.PostPin{PostID: posts[1].ID, Position: 1}
+	require.NoError(t, pins.SavePin(ctx, second))
+	assert.False(t, second.PinnedAt.IsZero())
+
+	active, err := pins.ListActivePins(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	assert.Equal(t, posts[1].ID, active[0].PostID)
+	assert.Equal(t, posts[0].ID, active[1].PostID)
+
+	// Positions past the limit are left out
+	active, err = pins.ListActivePins(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, posts[1].ID, active[0].PostID)
+
+	// Pinning to a taken position unpins the post there; moving a pin keeps it
+	require.NoError(t, pins.SavePin(ctx, &model.PostPin{PostID: posts[2].ID, Position: 1}))
+	require.NoError(t, pins.SavePin(ctx, &model.PostPin{PostID: posts[0].ID, Position: 3}))
+	active, err = pins.ListActivePins(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	assert.Equal(t, posts[2].ID, active[0].PostID)
+	assert.Equal(t, posts[0].ID, active[1].PostID)
+	assert.Equal(t, 3, active[1].Position)
+
+	// Expired pins are not listed
+	_, err = ts.db.Exec(ctx, "UPDATE pinned_posts SET expires_at = NOW() - INTERVAL '1 minute' WHERE post_id = $1", posts[2].ID)
+	require.NoError(t, err)
+	active, err = pins.ListActivePins(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, posts[0].ID, active[0].PostID)
+
+	deleted, err := pins.DeletePin(ctx, posts[0].ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = pins.DeletePin(ctx, posts[0].ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
+
+func TestPinRepositoryDeletedPostIsUnpinned(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	pins := NewPinRepository(ts.db, ts.logger)
+
+	post, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	expiresAt := time.Now().UTC().Add(time.Hour)
+	require.NoError(t, pins.SavePin(ctx, &model.PostPin{PostID: post.ID, Position: 1, ExpiresAt: &expiresAt}))
+	require.NoError(t, ts.repo.DeletePost(ctx, post.ID))
+
+	active, err := pins.ListActivePins(ctx, 5)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+}
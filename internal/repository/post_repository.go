@@ -2,253 +2,431 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/cachetrace"
+	"github.com/amirzre/news-feed-system/pkg/database"
 	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/amirzre/news-feed-system/pkg/urlnorm"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
 
+// postViewsKey is the Redis hash holding per-post view counters
+const postViewsKey = "posts:views"
+
+// missingPostMarker is cached under a post's key while the post does not
+// exist; it is never valid post JSON
+const missingPostMarker = "missing"
+
+// postViewsHourTTL keeps the hourly view counters long enough for the stats
+// rollup to catch up after an outage
+const postViewsHourTTL = 8 * 24 * time.Hour
+
+// postViewerKey returns the key marking that viewer saw a post within the
+// dedup window; the viewer is hashed so that client details are not stored
+func postViewerKey(id int64, viewer string) string {
+	h := fnv.New64a()
+	h.Write([]byte(viewer))
+	return fmt.Sprintf("posts:viewed:%d:%x", id, h.Sum64())
+}
+
+// postURLKey returns the key caching the ID of the post stored under a
+// normalized URL; the URL is hashed to keep keys short
+func postURLKey(ctx context.Context, url string) string {
+	h := fnv.New128a()
+	h.Write([]byte(url))
+	return tenant.Key(ctx, fmt.Sprintf("post:url:%x", h.Sum(nil)))
+}
+
+// postViewsHourKey returns the Redis hash holding the per-post view counters
+// of the hour starting at hour
+func postViewsHourKey(hour time.Time) string {
+	return "posts:views:hour:" + hour.UTC().Format("2006010215")
+}
+
 // postRepository implements PostRepository interface with caching
 type postRepository struct {
 	db       *pgxpool.Pool
-	redis    *redis.Client
+	replicas *database.ReplicaSet
+	redis    redis.UniversalClient
 	logger   *logger.Logger
-	cacheTTL time.Duration
+	lists    *swrCache
+	local    *lru.Cache[string, any]
+	// invalidations evicts keys from the L1 of other replicas; nil when
+	// disabled
+	invalidations *l1Invalidator
+	// urls answers most existence checks of stored URLs; nil when disabled
+	urls *urlFilter
+	// notFoundTTL is how long a missing post ID is remembered; zero disables
+	notFoundTTL time.Duration
+	// viewTolerance is how old post_counts_daily may be and still be read;
+	// zero reads counts from posts only
+	viewTolerance atomic.Int64
+	// viewerDedupWindow is how long repeat views of a post by one viewer
+	// count once; zero counts every view
+	viewerDedupWindow atomic.Int64
 }
 
 // NewPostRepository creates a new post repository
-func NewPostRepository(db *pgxpool.Pool, redis *redis.Client, logger *logger.Logger, cacheTTL time.Duration) PostRepository {
-	return &postRepository{
+func NewPostRepository(db *pgxpool.Pool, replicas *database.ReplicaSet, redis redis.UniversalClient, logger *logger.Logger, cacheCfg config.CacheConfig) PostRepository {
+	repo := &postRepository{
 		db:       db,
+		replicas: replicas,
 		redis:    redis,
 		logger:   logger,
-		cacheTTL: cacheTTL,
+		lists:    newSWRCache(redis, logger, cacheCfg),
+
+		notFoundTTL: cacheCfg.NotFoundTTL,
+	}
+
+	if cacheCfg.URLFilterEnabled {
+		repo.urls = newURLFilter(redis, cacheCfg.URLFilterExpectedURLs, cacheCfg.URLFilterFalsePositiveRate)
+	}
+
+	if cacheCfg.L1Enabled {
+		repo.local = lru.New[string, any](cacheCfg.L1Size, cacheCfg.L1TTL)
+
+		if cacheCfg.L1InvalidationEnabled {
+			repo.invalidations = newL1Invalidator(redis, cacheCfg.L1InvalidationChannel, repo.local, logger)
+		}
 	}
+
+	return repo
+}
+
+// SetCacheTTL changes the TTL of posts and lists cached from now on
+func (r *postRepository) SetCacheTTL(ttl time.Duration) {
+	r.lists.setTTL(ttl)
+}
+
+// SetViewStaleTolerance changes how long after its last refresh the
+// post_counts_daily view still answers counts and statistics. Zero always
+// counts from posts.
+func (r *postRepository) SetViewStaleTolerance(tolerance time.Duration) {
+	r.viewTolerance.Store(int64(tolerance))
+}
+
+// SetViewerDedupWindow changes how long repeat views of a post by the same
+// viewer are counted once. Zero counts every view.
+func (r *postRepository) SetViewerDedupWindow(window time.Duration) {
+	r.viewerDedupWindow.Store(int64(window))
 }
 
 // Create creates a new post in the database
 func (r *postRepository) CreatePost(ctx context.Context, params *model.CreatePostParams) (*model.Post, error) {
-	query := `
-		INSERT INTO posts (title, description, content, url, source, category, image_url, published_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at
-	`
-	var post model.Post
-	var publishedAt sql.NullTime
-
-	err := r.db.QueryRow(ctx, query,
+	start := time.Now()
+
+	post, err := scanPost(r.conn(ctx).QueryRow(ctx, stmtCreatePost,
 		params.Title,
 		params.Description,
 		params.Content,
-		params.URL,
+		urlnorm.Normalize(params.URL),
 		params.Source,
 		params.Category,
+		params.Country,
 		params.ImageURL,
 		params.PublishedAt,
-	).Scan(
-		&post.ID,
-		&post.Title,
-		&post.Description,
-		&post.Content,
-		&post.URL,
-		&post.Source,
-		&post.Category,
-		&post.ImageURL,
-		&publishedAt,
-		&post.CreatedAt,
-		&post.UpdatedAt,
-	)
-
-	if err != nil {
-		r.logger.LogDBOperation("create", "posts", time.Since(time.Now()).Milliseconds(), err)
+		params.Sensitive,
+		createStatus(params.Status),
+		params.Author,
+	))
+	if err != nil {
+		r.logger.LogDBOperation("create", "posts", time.Since(start).Milliseconds(), err)
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
-	if publishedAt.Valid {
-		post.PublishedAt = &publishedAt.Time
+	r.logger.LogDBOperation("create", "posts", time.Since(start).Milliseconds(), nil)
+
+	afterCommit(ctx, func(ctx context.Context) {
+		r.addURL(ctx, post.URL)
+		r.writeThroughPost(ctx, post)
+		r.invalidateFilteredCaches(ctx, post.Category, post.Source)
+	})
+
+	return post, nil
+}
+
+// UpsertPost inserts a post or refreshes the stored post with the same URL
+// when the incoming one was published later. pgx.ErrNoRows is returned when
+// the stored post is already up to date.
+func (r *postRepository) UpsertPost(ctx context.Context, params *model.CreatePostParams) (*model.Post, error) {
+	start := time.Now()
+
+	post, err := r.upsertPost(ctx, params)
+	// A concurrent upsert may claim the URL first; a second attempt then
+	// finds and refreshes that post. Inside a transaction the failure has
+	// already aborted it, so it is returned as is.
+	if _, inTx := txFromContext(ctx); isUniqueViolation(err) && !inTx {
+		post, err = r.upsertPost(ctx, params)
+	}
+	if err != nil {
+		r.logger.LogDBOperation("upsert", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to upsert post: %w", err)
 	}
 
-	r.invalidateListCaches(ctx)
+	r.logger.LogDBOperation("upsert", "posts", time.Since(start).Milliseconds(), nil)
+
+	afterCommit(ctx, func(ctx context.Context) {
+		r.addURL(ctx, post.URL)
+		// A refreshed post may already sit on any list page
+		if post.Version == 1 {
+			r.writeThroughPost(ctx, post)
+		} else {
+			r.cachePost(ctx, post)
+			r.invalidateListCaches(ctx)
+		}
+		r.invalidateFilteredCaches(ctx, post.Category, post.Source)
+	})
+
+	return post, nil
+}
 
-	return &post, nil
+// upsertPost runs stmtUpsertPost once
+func (r *postRepository) upsertPost(ctx context.Context, params *model.CreatePostParams) (*model.Post, error) {
+	return scanPost(r.conn(ctx).QueryRow(ctx, stmtUpsertPost,
+		params.Title,
+		params.Description,
+		params.Content,
+		urlnorm.Normalize(params.URL),
+		params.Source,
+		params.Category,
+		params.Country,
+		params.ImageURL,
+		params.PublishedAt,
+		params.Sensitive,
+		params.Author,
+	))
 }
 
-// GetPostByURL retrieves a post by URL from database
+// GetPostByURL retrieves a post by URL. Like every URL stored or looked up,
+// url is compared in its normalized form. The ID a URL resolves to is cached,
+// so repeated lookups are served from the post cache; a cached ID whose post
+// was deleted or moved to another URL is dropped and the database decides.
 func (r *postRepository) GetPostByURL(ctx context.Context, url string) (*model.Post, error) {
 	start := time.Now()
+	url = urlnorm.Normalize(url)
+	cacheKey := postURLKey(ctx, url)
+
+	if id, err := r.redis.Get(ctx, cacheKey).Int64(); err == nil {
+		if post, err := r.GetPostByID(ctx, id); err == nil && post.URL == url {
+			r.logger.LogCacheOperation("get", cacheKey, true)
+			return post, nil
+		}
+		r.redis.Del(ctx, cacheKey)
+	}
+	r.logger.LogCacheOperation("get", cacheKey, false)
 
-	query := `
-		SELECT id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at
-		FROM posts WHERE url = $1 LIMIT 1
-	`
-
-	var post model.Post
-	var publishedAt sql.NullTime
-
-	err := r.db.QueryRow(ctx, query, url).Scan(
-		&post.ID,
-		&post.Title,
-		&post.Description,
-		&post.Content,
-		&post.URL,
-		&post.Source,
-		&post.Category,
-		&post.ImageURL,
-		&publishedAt,
-		&post.CreatedAt,
-		&post.UpdatedAt,
-	)
+	post, err := scanPost(r.conn(ctx).QueryRow(ctx, stmtGetPostByURL, url))
 	if err != nil {
 		r.logger.LogDBOperation("get_by_url", "posts", time.Since(start).Milliseconds(), err)
 		return nil, fmt.Errorf("failed to get post by url: %w", err)
 	}
 
-	if publishedAt.Valid {
-		post.PublishedAt = &publishedAt.Time
+	r.logger.LogDBOperation("get_by_url", "posts", time.Since(start).Milliseconds(), nil)
+
+	// Inside a transaction the post may not outlive a rollback
+	if _, inTx := txFromContext(ctx); !inTx {
+		r.redis.Set(ctx, cacheKey, post.ID, r.lists.baseTTL()).Err()
+		r.logger.LogCacheOperation("set", cacheKey, false)
+		r.cachePost(ctx, post)
 	}
 
-	r.logger.LogDBOperation("get_by_url", "posts", time.Since(start).Milliseconds(), nil)
+	return post, nil
+}
+
+// ExistsByURL reports whether a post with the given URL is stored. URLs in
+// the URL filter are taken as stored without asking the database; outside
+// the filter, or when it cannot be read, the database decides.
+func (r *postRepository) ExistsByURL(ctx context.Context, url string) (bool, error) {
+	start := time.Now()
+	url = urlnorm.Normalize(url)
+
+	// Inside a transaction the filter may hold URLs it has not committed yet
+	if _, inTx := txFromContext(ctx); r.urls != nil && !inTx {
+		if found, err := r.urls.mayContain(ctx, url); err == nil && found {
+			r.logger.LogCacheOperation("url_filter", r.urls.key(ctx), true)
+			return true, nil
+		}
+		r.logger.LogCacheOperation("url_filter", r.urls.key(ctx), false)
+	}
+
+	var one int
+	err := r.conn(ctx).QueryRow(ctx, stmtPostExistsByURL, url).Scan(&one)
+	if errors.Is(err, pgx.ErrNoRows) {
+		r.logger.LogDBOperation("exists_by_url", "posts", time.Since(start).Milliseconds(), nil)
+		return false, nil
+	}
+	if err != nil {
+		r.logger.LogDBOperation("exists_by_url", "posts", time.Since(start).Milliseconds(), err)
+		return false, fmt.Errorf("failed to check post existence by url: %w", err)
+	}
+
+	r.logger.LogDBOperation("exists_by_url", "posts", time.Since(start).Milliseconds(), nil)
 
-	return &post, nil
+	return true, nil
 }
 
-// GetPostByID retrieves a post by ID with caching
+// GetPostByID retrieves a post by ID with caching. Missing IDs are cached
+// for a short while too, so walking the ID space does not reach the database.
 func (r *postRepository) GetPostByID(ctx context.Context, id int64) (*model.Post, error) {
 	start := time.Now()
-	cacheKey := fmt.Sprintf("post:id:%d", id)
+	cacheKey := tenant.Key(ctx, fmt.Sprintf("post:id:%d", id))
+
+	if post, ok := r.getLocal(ctx, cacheKey); ok {
+		post := post.(model.Post)
+		return &post, nil
+	}
 
 	cached, err := r.redis.Get(ctx, cacheKey).Result()
+	if err == nil && cached == missingPostMarker {
+		r.logger.LogCacheOperation("get_missing", cacheKey, true)
+		cachetrace.Record(ctx, true)
+		return nil, fmt.Errorf("failed to get post by id: %w", pgx.ErrNoRows)
+	}
 	if err == nil {
 		var post model.Post
 		if err := json.Unmarshal([]byte(cached), &post); err == nil {
 			r.logger.LogCacheOperation("get", cacheKey, true)
+			cachetrace.Record(ctx, true)
+			r.setLocal(cacheKey, post)
 			return &post, nil
 		}
 	}
 	r.logger.LogCacheOperation("get", cacheKey, false)
+	cachetrace.Record(ctx, false)
 
-	query := `
-		SELECT id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at
-		FROM posts WHERE id = $1 LIMIT 1
-	`
-	var post model.Post
-	var publishedAt sql.NullTime
-
-	err = r.db.QueryRow(ctx, query, id).Scan(
-		&post.ID,
-		&post.Title,
-		&post.Description,
-		&post.Content,
-		&post.URL,
-		&post.Source,
-		&post.Category,
-		&post.ImageURL,
-		&publishedAt,
-		&post.CreatedAt,
-		&post.UpdatedAt,
-	)
+	post, err := scanPost(r.conn(ctx).QueryRow(ctx, stmtGetPostByID, id))
 	if err != nil {
 		r.logger.LogDBOperation("get_by_id", "posts", time.Since(start).Milliseconds(), err)
+		// Inside a transaction the post may only be missing until a rollback
+		if _, inTx := txFromContext(ctx); errors.Is(err, pgx.ErrNoRows) && !inTx && r.notFoundTTL > 0 {
+			r.redis.Set(ctx, cacheKey, missingPostMarker, r.notFoundTTL).Err()
+			r.logger.LogCacheOperation("set_missing", cacheKey, false)
+		}
 		return nil, fmt.Errorf("failed to get post by id: %w", err)
 	}
-	if publishedAt.Valid {
-		post.PublishedAt = &publishedAt.Time
-	}
 
 	r.logger.LogDBOperation("get_by_id", "posts", time.Since(start).Milliseconds(), nil)
 
-	if postJson, err := json.Marshal(post); err == nil {
-		r.redis.Set(ctx, cacheKey, postJson, r.cacheTTL).Err()
-		r.logger.LogCacheOperation("set", cacheKey, false)
-	}
+	r.cachePost(ctx, post)
 
-	return &post, nil
+	return post, nil
 }
 
-// UpdatePost updates a post in the database
+// UpdatePost updates a post in the database. When nothing would change the
+// write is skipped and the stored post is returned at its current version.
 func (r *postRepository) UpdatePost(ctx context.Context, id int64, params *model.UpdatePostParams) (*model.Post, error) {
 	start := time.Now()
 
-	query := `
-		UPDATE posts 
-		SET title = $2, description = $3, content = $4, category = $5, image_url = $6, updated_at = NOW()
-		WHERE id = $1 
-		RETURNING id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at
-	`
-	var post model.Post
-	var publishedAt sql.NullTime
-
-	err := r.db.QueryRow(ctx, query, id,
+	var previousCategory *string
+	post, err := scanPost(r.conn(ctx).QueryRow(ctx, stmtUpdatePost, id,
 		params.Title,
 		params.Description,
 		params.Content,
 		params.Category,
 		params.ImageURL,
-	).Scan(
-		&post.ID,
-		&post.Title,
-		&post.Description,
-		&post.Content,
-		&post.URL,
-		&post.Source,
-		&post.Category,
-		&post.ImageURL,
-		&publishedAt,
-		&post.CreatedAt,
-		&post.UpdatedAt,
-	)
+		params.Version,
+		params.Sensitive,
+		params.Author,
+	), &previousCategory)
 	if err != nil {
 		r.logger.LogDBOperation("update", "posts", time.Since(start).Milliseconds(), err)
 		return nil, fmt.Errorf("failed to update post: %w", err)
 	}
 
-	if publishedAt.Valid {
-		post.PublishedAt = &publishedAt.Time
-	}
-
 	r.logger.LogDBOperation("update", "posts", time.Since(start).Milliseconds(), nil)
 
-	r.invalidatePostCaches(ctx, id)
-	r.invalidateListCaches(ctx)
+	// An unchanged post keeps its version, and the cached copies stay valid
+	if post.Version == params.Version {
+		return post, nil
+	}
+
+	afterCommit(ctx, func(ctx context.Context) {
+		r.invalidatePostCaches(ctx, id)
+		r.invalidateListCaches(ctx)
+		r.invalidateFilteredCaches(ctx, post.Category, post.Source)
+		if !sameCategory(previousCategory, post.Category) {
+			r.invalidateCategoryCaches(ctx, previousCategory)
+		}
+	})
 
-	return &post, nil
+	return post, nil
 }
 
 // DeletePost deletes a post from the database
 func (r *postRepository) DeletePost(ctx context.Context, id int64) error {
 	start := time.Now()
 
-	query := `DELETE FROM posts WHERE id = $1`
-
-	post, err := r.db.Exec(ctx, query, id)
+	var category *string
+	var source string
+	err := r.conn(ctx).QueryRow(ctx, stmtDeletePost, id).Scan(&category, &source)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("post with id %d not found", id)
+	}
 	if err != nil {
 		r.logger.LogDBOperation("delete", "posts", time.Since(start).Milliseconds(), err)
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
 
-	if post.RowsAffected() == 0 {
-		return fmt.Errorf("post with id %d not found", id)
-	}
-
 	r.logger.LogDBOperation("delete", "posts", time.Since(start).Milliseconds(), nil)
 
-	r.invalidatePostCaches(ctx, id)
-	r.invalidateListCaches(ctx)
+	afterCommit(ctx, func(ctx context.Context) {
+		r.invalidatePostCaches(ctx, id)
+		r.invalidateListCaches(ctx)
+		r.invalidateFilteredCaches(ctx, category, source)
+	})
 
 	return nil
 }
 
+// UpdatePostStatus moves a post to another state
+func (r *postRepository) UpdatePostStatus(ctx context.Context, id int64, status model.PostStatus) (*model.Post, error) {
+	start := time.Now()
+
+	post, err := scanPost(r.conn(ctx).QueryRow(ctx, stmtUpdatePostStatus, id, status))
+	if err != nil {
+		r.logger.LogDBOperation("update_status", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to update post status: %w", err)
+	}
+
+	r.logger.LogDBOperation("update_status", "posts", time.Since(start).Milliseconds(), nil)
+
+	afterCommit(ctx, func(ctx context.Context) {
+		r.invalidatePostCaches(ctx, id)
+		r.invalidateListCaches(ctx)
+		r.invalidateFilteredCaches(ctx, post.Category, post.Source)
+	})
+
+	return post, nil
+}
+
 // ListPosts retrieves posts with pagination
 func (r *postRepository) ListPosts(ctx context.Context, params *model.PostListParams) ([]model.Post, error) {
 	start := time.Now()
 
 	limit := params.Limit
 	offset := (params.Page - 1) * params.Limit
+	if params.Peek {
+		limit++
+	}
+	popular := params.Sort == model.PostSortPopular
+	base := model.BasePostListParams{Limit: limit, Offset: offset, SafeMode: params.SafeMode, Popular: popular, Status: params.Status, Collapse: params.Collapse}
 
 	var posts []model.Post
 	var err error
@@ -256,79 +434,43 @@ func (r *postRepository) ListPosts(ctx context.Context, params *model.PostListPa
 	switch {
 	case params.Search != nil && *params.Search != "":
 		posts, err = r.SearchPosts(ctx, &model.SearchPostsParams{
-			BasePostListParams: model.BasePostListParams{Limit: limit, Offset: offset},
+			BasePostListParams: base,
 			Query:              *params.Search,
+			Country:            params.Country,
 		})
 	case params.Category != nil && *params.Category != "":
 		posts, err = r.ListPostsByCategory(ctx, &model.ListPostsByCategoryParams{
-			BasePostListParams: model.BasePostListParams{Limit: limit, Offset: offset},
+			BasePostListParams: base,
 			Category:           *params.Category,
 		})
 	case params.Source != nil && *params.Source != "":
 		posts, err = r.ListPostsBySource(ctx, &model.ListPostsBySourceParams{
-			BasePostListParams: model.BasePostListParams{Limit: limit, Offset: offset},
+			BasePostListParams: base,
 			Source:             *params.Source,
 		})
+	case params.Country != nil && *params.Country != "":
+		posts, err = r.ListPostsByCountry(ctx, &model.ListPostsByCountryParams{
+			BasePostListParams: base,
+			Country:            *params.Country,
+		})
+	case params.Author != nil && *params.Author != "":
+		posts, err = r.ListPostsByAuthor(ctx, &model.ListPostsByAuthorParams{
+			BasePostListParams: base,
+			Author:             *params.Author,
+		})
 	default:
-		cacheKey := fmt.Sprintf("posts:list:%d:%d", params.Page, params.Limit)
-		cached, cacheErr := r.redis.Get(ctx, cacheKey).Result()
-		if cacheErr == nil {
-			if err := json.Unmarshal([]byte(cached), &posts); err == nil {
-				r.logger.LogCacheOperation("get", cacheKey, true)
-				return posts, nil
-			}
-		}
-		r.logger.LogCacheOperation("get", cacheKey, false)
-
-		query := `
-			SELECT id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at
-			FROM posts ORDER BY published_at DESC LIMIT $1 OFFSET $2
-		`
-		rows, err := r.db.Query(ctx, query, limit, offset)
-		if err != nil {
-			r.logger.LogDBOperation("list", "posts", time.Since(start).Milliseconds(), err)
-			return nil, fmt.Errorf("failed to list posts: %w", err)
-		}
-		defer rows.Close()
-
-		for rows.Next() {
-			var post model.Post
-			var publishedAt sql.NullTime
-
-			err = rows.Scan(
-				&post.ID,
-				&post.Title,
-				&post.Description,
-				&post.Content,
-				&post.URL,
-				&post.Source,
-				&post.Category,
-				&post.ImageURL,
-				&publishedAt,
-				&post.CreatedAt,
-				&post.UpdatedAt,
-			)
-			if err != nil {
-
-				return nil, fmt.Errorf("failed to scan post: %w", err)
-			}
-			if publishedAt.Valid {
-				post.PublishedAt = &publishedAt.Time
-			}
-
-			posts = append(posts, post)
+		cacheKey := listCacheKey(tenant.Key(ctx, fmt.Sprintf("posts:list:%d:%d", params.Page, params.Limit)), base)
+		if params.Peek {
+			cacheKey += ":peek"
 		}
-
-		if err := rows.Err(); err != nil {
-			r.logger.LogDBOperation("list", "posts", time.Since(start).Milliseconds(), err)
-			return nil, fmt.Errorf("failed to iterate posts: %w", err)
+		load := func(ctx context.Context) ([]model.Post, error) {
+			return r.queryPosts(ctx, stmtListPosts, limit, offset, params.SafeMode, popular, model.PostStatusFilter(params.Status), params.Collapse)
 		}
-
-		if err == nil {
-			if postsJSON, jsonErr := json.Marshal(posts); jsonErr == nil {
-				r.redis.Set(ctx, cacheKey, postsJSON, r.cacheTTL).Err()
-				r.logger.LogCacheOperation("set", cacheKey, false)
-			}
+		// The first page is by far the most read, so it is refreshed early
+		if params.Page == 1 {
+			posts, err = readThroughEarly(ctx, r.lists, cacheKey, load)
+		} else {
+			posts, err = readThrough(ctx, r.lists, cacheKey, load)
 		}
 	}
 
@@ -342,53 +484,46 @@ func (r *postRepository) ListPosts(ctx context.Context, params *model.PostListPa
 	return posts, nil
 }
 
-// ListPostsByCategory retrieves posts by category
-func (r *postRepository) ListPostsByCategory(ctx context.Context, params *model.ListPostsByCategoryParams) ([]model.Post, error) {
+// SamplePosts returns a random sample of the published posts since
+// params.Since, spread across categories. The sample is cached with the post
+// lists, so the same posts come back until the cache expires or a write
+// drops the lists.
+func (r *postRepository) SamplePosts(ctx context.Context, params *model.SamplePostsParams) ([]model.Post, error) {
 	start := time.Now()
 
-	query := `
-		SELECT id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at
-		FROM posts WHERE category = $1 ORDER BY published_at DESC LIMIT $2 OFFSET $3
-	`
-	rows, err := r.db.Query(ctx, query, params.Category, params.Limit, params.Offset)
+	cacheKey := listCacheKey(tenant.Key(ctx, fmt.Sprintf("posts:list:sample:%d", params.Size)), model.BasePostListParams{SafeMode: params.SafeMode})
+	posts, err := readThrough(ctx, r.lists, cacheKey, func(ctx context.Context) ([]model.Post, error) {
+		return r.queryPosts(ctx, stmtSamplePosts, params.Since, params.Size, params.SafeMode)
+	})
 	if err != nil {
-		r.logger.LogDBOperation("list_by_category", "posts", time.Since(start).Milliseconds(), err)
-		return nil, fmt.Errorf("failed to list posts by category: %w", err)
+		r.logger.LogDBOperation("sample", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to sample posts: %w", err)
 	}
-	defer rows.Close()
 
-	var posts []model.Post
-	for rows.Next() {
-		var post model.Post
-		var publishedAt sql.NullTime
-
-		err = rows.Scan(
-			&post.ID,
-			&post.Title,
-			&post.Description,
-			&post.Content,
-			&post.URL,
-			&post.Source,
-			&post.Category,
-			&post.ImageURL,
-			&publishedAt,
-			&post.CreatedAt,
-			&post.UpdatedAt,
-		)
-		if err != nil {
-			r.logger.LogDBOperation("list_by_category", "posts", time.Since(start).Milliseconds(), err)
-			return nil, fmt.Errorf("failed to scan post: %w", err)
-		}
-		if publishedAt.Valid {
-			post.PublishedAt = &publishedAt.Time
-		}
+	r.logger.LogDBOperation("sample", "posts", time.Since(start).Milliseconds(), nil)
+
+	return posts, nil
+}
+
+// ListPostsByCategory retrieves posts by category
+func (r *postRepository) ListPostsByCategory(ctx context.Context, params *model.ListPostsByCategoryParams) ([]model.Post, error) {
+	start := time.Now()
 
-		posts = append(posts, post)
+	cacheKey := listCacheKey(tenant.Key(ctx, fmt.Sprintf("posts:category:%s:%d:%d", params.Category, params.Limit, params.Offset)), params.BasePostListParams)
+	load := func(ctx context.Context) ([]model.Post, error) {
+		return r.queryPosts(ctx, stmtListPostsByCategory, params.Category, params.Limit, params.Offset, params.SafeMode, params.Popular, model.PostStatusFilter(params.Status), params.Collapse)
 	}
 
-	if err := rows.Err(); err != nil {
+	var posts []model.Post
+	var err error
+	if params.Offset == 0 {
+		posts, err = readThroughEarly(ctx, r.lists, cacheKey, load)
+	} else {
+		posts, err = readThrough(ctx, r.lists, cacheKey, load)
+	}
+	if err != nil {
 		r.logger.LogDBOperation("list_by_category", "posts", time.Since(start).Milliseconds(), err)
-		return nil, fmt.Errorf("failed to iterate posts by category: %w", err)
+		return nil, fmt.Errorf("failed to list posts by category: %w", err)
 	}
 
 	r.logger.LogDBOperation("list_by_category", "posts", time.Since(start).Milliseconds(), nil)
@@ -400,178 +535,1295 @@ func (r *postRepository) ListPostsByCategory(ctx context.Context, params *model.
 func (r *postRepository) ListPostsBySource(ctx context.Context, params *model.ListPostsBySourceParams) ([]model.Post, error) {
 	start := time.Now()
 
-	query := `
-		SELECT id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at
-		FROM posts WHERE source = $1 ORDER BY published_at DESC LIMIT $2 OFFSET $3
-	`
-	rows, err := r.db.Query(ctx, query, params.Source, params.Limit, params.Offset)
+	cacheKey := listCacheKey(tenant.Key(ctx, fmt.Sprintf("posts:source:%s:%d:%d", params.Source, params.Limit, params.Offset)), params.BasePostListParams)
+	load := func(ctx context.Context) ([]model.Post, error) {
+		return r.queryPosts(ctx, stmtListPostsBySource, params.Source, params.Limit, params.Offset, params.SafeMode, params.Popular, model.PostStatusFilter(params.Status), params.Collapse)
+	}
+
+	var posts []model.Post
+	var err error
+	if params.Offset == 0 {
+		posts, err = readThroughEarly(ctx, r.lists, cacheKey, load)
+	} else {
+		posts, err = readThrough(ctx, r.lists, cacheKey, load)
+	}
 	if err != nil {
 		r.logger.LogDBOperation("list_by_source", "posts", time.Since(start).Milliseconds(), err)
 		return nil, fmt.Errorf("failed to list posts by source: %w", err)
 	}
-	defer rows.Close()
 
-	var posts []model.Post
-	for rows.Next() {
-		var post model.Post
-		var publishedAt sql.NullTime
-
-		err = rows.Scan(
-			&post.ID,
-			&post.Title,
-			&post.Description,
-			&post.Content,
-			&post.URL,
-			&post.Source,
-			&post.Category,
-			&post.ImageURL,
-			&publishedAt,
-			&post.CreatedAt,
-			&post.UpdatedAt,
-		)
-		if err != nil {
-			r.logger.LogDBOperation("list_by_source", "posts", time.Since(start).Milliseconds(), err)
-			return nil, fmt.Errorf("failed to scan post: %w", err)
-		}
-		if publishedAt.Valid {
-			post.PublishedAt = &publishedAt.Time
-		}
+	r.logger.LogDBOperation("list_by_source", "posts", time.Since(start).Milliseconds(), nil)
+
+	return posts, nil
+}
+
+// ListPostsByCountry retrieves posts aggregated for a country
+func (r *postRepository) ListPostsByCountry(ctx context.Context, params *model.ListPostsByCountryParams) ([]model.Post, error) {
+	start := time.Now()
 
-		posts = append(posts, post)
+	posts, err := r.queryPosts(ctx, stmtListPostsByCountry, strings.ToLower(params.Country), params.Limit, params.Offset, params.SafeMode, params.Popular, model.PostStatusFilter(params.Status), params.Collapse)
+	if err != nil {
+		r.logger.LogDBOperation("list_by_country", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list posts by country: %w", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		r.logger.LogDBOperation("list_by_source", "posts", time.Since(start).Milliseconds(), err)
-		return nil, fmt.Errorf("failed to iterate posts by source: %w", err)
+	r.logger.LogDBOperation("list_by_country", "posts", time.Since(start).Milliseconds(), nil)
+
+	return posts, nil
+}
+
+// ListPostsByAuthor retrieves posts by author
+func (r *postRepository) ListPostsByAuthor(ctx context.Context, params *model.ListPostsByAuthorParams) ([]model.Post, error) {
+	start := time.Now()
+
+	posts, err := r.queryPosts(ctx, stmtListPostsByAuthor, params.Author, params.Limit, params.Offset, params.SafeMode, params.Popular, model.PostStatusFilter(params.Status), params.Collapse)
+	if err != nil {
+		r.logger.LogDBOperation("list_by_author", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list posts by author: %w", err)
 	}
 
-	r.logger.LogDBOperation("list_by_source", "posts", time.Since(start).Milliseconds(), nil)
+	r.logger.LogDBOperation("list_by_author", "posts", time.Since(start).Milliseconds(), nil)
 
 	return posts, nil
 }
 
-// SearchPosts searches posts
-func (r *postRepository) SearchPosts(ctx context.Context, params *model.SearchPostsParams) ([]model.Post, error) {
+// ListPostsPendingContent retrieves the newest posts whose full content has
+// not been extracted yet, optionally restricted to the given sources
+func (r *postRepository) ListPostsPendingContent(ctx context.Context, params *model.ListPostsPendingContentParams) ([]model.Post, error) {
 	start := time.Now()
 
-	query := `
-		SELECT id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at
-		FROM posts 
-		WHERE title ILIKE '%' || $1 || '%' OR description ILIKE '%' || $1 || '%'
-		ORDER BY published_at DESC LIMIT $2 OFFSET $3
-	`
-	rows, err := r.db.Query(ctx, query, params.Query, params.Limit, params.Offset)
+	sources := params.Sources
+	if sources == nil {
+		sources = []string{}
+	}
+
+	posts, err := r.queryPosts(ctx, stmtListPostsPendingContent, sources, params.Limit)
 	if err != nil {
-		r.logger.LogDBOperation("search", "posts", time.Since(start).Milliseconds(), err)
-		return nil, fmt.Errorf("failed to search posts: %w", err)
+		r.logger.LogDBOperation("list_pending_content", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list posts pending content: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_pending_content", "posts", time.Since(start).Milliseconds(), nil)
+
+	return posts, nil
+}
+
+// UpdatePostContent stores extracted content and marks the post as processed.
+// A nil content keeps the current content, so failed extractions are not retried.
+func (r *postRepository) UpdatePostContent(ctx context.Context, id int64, content *string) error {
+	start := time.Now()
+
+	_, err := r.conn(ctx).Exec(ctx, stmtUpdatePostContent, id, content)
+	if err != nil {
+		r.logger.LogDBOperation("update_content", "posts", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to update post content: %w", err)
+	}
+
+	r.logger.LogDBOperation("update_content", "posts", time.Since(start).Milliseconds(), nil)
+
+	if content != nil {
+		afterCommit(ctx, func(ctx context.Context) {
+			r.invalidatePostCaches(ctx, id)
+		})
+	}
+
+	return nil
+}
+
+// ListPostURLs retrieves the next page of post URLs, ordered by ID and
+// starting after afterID
+func (r *postRepository) ListPostURLs(ctx context.Context, afterID int64, limit int) ([]model.PostURL, error) {
+	start := time.Now()
+
+	rows, err := r.conn(ctx).Query(ctx, stmtListPostURLsAfter, afterID, limit)
+	if err != nil {
+		r.logger.LogDBOperation("list_urls", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list post urls: %w", err)
 	}
 	defer rows.Close()
 
-	var posts []model.Post
+	var urls []model.PostURL
 	for rows.Next() {
-		var post model.Post
-		var publishedAt sql.NullTime
-
-		err = rows.Scan(
-			&post.ID,
-			&post.Title,
-			&post.Description,
-			&post.Content,
-			&post.URL,
-			&post.Source,
-			&post.Category,
-			&post.ImageURL,
-			&publishedAt,
-			&post.CreatedAt,
-			&post.UpdatedAt,
-		)
-		if err != nil {
-			r.logger.LogDBOperation("search", "posts", time.Since(start).Milliseconds(), err)
-			return nil, fmt.Errorf("failed to scan post: %w", err)
+		var u model.PostURL
+		if err := rows.Scan(&u.ID, &u.URL); err != nil {
+			r.logger.LogDBOperation("list_urls", "posts", time.Since(start).Milliseconds(), err)
+			return nil, fmt.Errorf("failed to scan post url: %w", err)
 		}
-		if publishedAt.Valid {
-			post.PublishedAt = &publishedAt.Time
-		}
-
-		posts = append(posts, post)
+		urls = append(urls, u)
 	}
-
 	if err := rows.Err(); err != nil {
-		r.logger.LogDBOperation("search", "posts", time.Since(start).Milliseconds(), err)
-		return nil, fmt.Errorf("failed to iterate search results: %w", err)
+		r.logger.LogDBOperation("list_urls", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list post urls: %w", err)
 	}
 
-	r.logger.LogDBOperation("search", "posts", time.Since(start).Milliseconds(), nil)
+	r.logger.LogDBOperation("list_urls", "posts", time.Since(start).Milliseconds(), nil)
 
-	return posts, nil
+	return urls, nil
 }
 
-// CountPosts counts all posts
-func (r *postRepository) CountPosts(ctx context.Context) (int64, error) {
+// RenamePostURL changes the stored URL of a post. It reports false, leaving
+// the post as is, when another post already holds url. Cached lists keep the
+// old URL until they expire, sparing a pass over many posts from clearing
+// them once per post.
+func (r *postRepository) RenamePostURL(ctx context.Context, id int64, url string) (bool, error) {
 	start := time.Now()
-	cacheKey := "posts:count"
 
-	cached, err := r.redis.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var count int64
-		if err := json.Unmarshal([]byte(cached), &count); err == nil {
-			r.logger.LogCacheOperation("get", cacheKey, true)
-			return count, nil
-		}
+	var renamed int64
+	err := r.conn(ctx).QueryRow(ctx, stmtRenamePostURL, id, url).Scan(&renamed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		r.logger.LogDBOperation("rename_url", "posts", time.Since(start).Milliseconds(), nil)
+		return false, nil
+	}
+	if err != nil {
+		r.logger.LogDBOperation("rename_url", "posts", time.Since(start).Milliseconds(), err)
+		return false, fmt.Errorf("failed to rename post url: %w", err)
 	}
-	r.logger.LogCacheOperation("get", cacheKey, false)
 
-	query := `SELECT COUNT(*) FROM posts`
+	r.logger.LogDBOperation("rename_url", "posts", time.Since(start).Milliseconds(), nil)
 
-	var count int64
-	err = r.db.QueryRow(ctx, query).Scan(&count)
+	afterCommit(ctx, func(ctx context.Context) {
+		r.addURL(ctx, url)
+		r.invalidatePostCaches(ctx, id)
+	})
+
+	return true, nil
+}
+
+// ListPostsForReprocess retrieves the next page of posts matching a
+// reprocess filter, ordered by ID and starting after params.AfterID
+func (r *postRepository) ListPostsForReprocess(ctx context.Context, params *model.ListPostsForReprocessParams) ([]model.Post, error) {
+	start := time.Now()
+
+	posts, err := r.queryPosts(ctx, stmtListPostsForReprocess,
+		params.From, params.To, params.Category, params.MissingContent, params.AfterID, params.Limit)
 	if err != nil {
-		r.logger.LogDBOperation("count", "posts", time.Since(start).Milliseconds(), err)
-		return 0, fmt.Errorf("failed to count posts: %w", err)
+		r.logger.LogDBOperation("list_for_reprocess", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list posts for reprocess: %w", err)
 	}
 
-	r.logger.LogDBOperation("count", "posts", time.Since(start).Milliseconds(), nil)
+	r.logger.LogDBOperation("list_for_reprocess", "posts", time.Since(start).Milliseconds(), nil)
 
-	if countJSON, err := json.Marshal(count); err == nil {
-		r.redis.Set(ctx, cacheKey, countJSON, r.cacheTTL).Err()
-		r.logger.LogCacheOperation("set", cacheKey, false)
+	return posts, nil
+}
+
+// CountPostsForReprocess returns the number of posts matching a reprocess filter
+func (r *postRepository) CountPostsForReprocess(ctx context.Context, params *model.ReprocessParams) (int64, error) {
+	start := time.Now()
+
+	var count int64
+	err := r.reader(ctx).QueryRow(ctx, stmtCountPostsForReprocess,
+		params.From, params.To, params.Category, params.MissingContent).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_for_reprocess", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count posts for reprocess: %w", err)
 	}
 
+	r.logger.LogDBOperation("count_for_reprocess", "posts", time.Since(start).Milliseconds(), nil)
+
 	return count, nil
 }
 
-// CountByCategory returns the number of posts in a category
-func (r *postRepository) CountPostsByCategory(ctx context.Context, category string) (int64, error) {
+// CountBulkPosts counts the posts matching a bulk filter or, given changes,
+// those a bulk update would change
+func (r *postRepository) CountBulkPosts(ctx context.Context, filter *model.BulkPostFilter, changes *model.BulkPostChanges) (int64, error) {
 	start := time.Now()
 
-	query := `SELECT COUNT(*) FROM posts WHERE category = $1`
-
 	var count int64
-	err := r.db.QueryRow(ctx, query, category).Scan(&count)
+	var err error
+	if changes == nil {
+		err = r.reader(ctx).QueryRow(ctx, stmtCountBulkPosts, bulkFilterArgs(filter)...).Scan(&count)
+	} else {
+		args := append(bulkFilterArgs(filter), changes.Status, changes.Category, changes.Sensitive)
+		err = r.reader(ctx).QueryRow(ctx, stmtCountBulkUpdate, args...).Scan(&count)
+	}
 	if err != nil {
-		r.logger.LogDBOperation("count_by_category", "posts", time.Since(start).Milliseconds(), err)
-		return 0, fmt.Errorf("failed to count posts by category: %w", err)
+		r.logger.LogDBOperation("count_bulk", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count posts for bulk operation: %w", err)
 	}
 
-	r.logger.LogDBOperation("count_by_category", "posts", time.Since(start).Milliseconds(), nil)
+	r.logger.LogDBOperation("count_bulk", "posts", time.Since(start).Milliseconds(), nil)
 
 	return count, nil
 }
 
-// Helper methods for cache invalidation
-func (r *postRepository) invalidatePostCaches(ctx context.Context, id int64) {
-	cacheKey := fmt.Sprintf("post:id:%d", id)
-	r.redis.Del(ctx, cacheKey).Err()
-	r.logger.LogCacheOperation("delete", cacheKey, false)
+// BulkUpdatePosts applies changes to the next batch of posts matching the
+// filter. It returns the last ID scanned, zero once no posts are left, and
+// how many posts changed; posts already matching the changes are skipped.
+func (r *postRepository) BulkUpdatePosts(ctx context.Context, params *model.BulkPostsBatchParams, changes *model.BulkPostChanges) (int64, int, error) {
+	start := time.Now()
+
+	args := append(bulkFilterArgs(&params.BulkPostFilter), changes.Status, changes.Category, changes.Sensitive, params.AfterID, params.Limit)
+	lastID, ids, err := r.bulkBatch(ctx, stmtBulkUpdatePosts, args)
+	if err != nil {
+		r.logger.LogDBOperation("bulk_update", "posts", time.Since(start).Milliseconds(), err)
+		return 0, 0, fmt.Errorf("failed to bulk update posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("bulk_update", "posts", time.Since(start).Milliseconds(), nil)
+
+	return lastID, len(ids), nil
 }
 
-func (r *postRepository) invalidateListCaches(ctx context.Context) {
-	keys, err := r.redis.Keys(ctx, "posts:list:*").Result()
-	if err == nil && len(keys) > 0 {
-		r.redis.Del(ctx, keys...).Err()
-		r.logger.LogCacheOperation("delete_pattern", "posts:list:*", false)
+// BulkDeletePosts deletes the next batch of posts matching the filter. It
+// returns the last ID scanned, zero once no posts are left, and how many
+// posts were deleted.
+func (r *postRepository) BulkDeletePosts(ctx context.Context, params *model.BulkPostsBatchParams) (int64, int, error) {
+	start := time.Now()
+
+	args := append(bulkFilterArgs(&params.BulkPostFilter), params.AfterID, params.Limit)
+	lastID, ids, err := r.bulkBatch(ctx, stmtBulkDeletePosts, args)
+	if err != nil {
+		r.logger.LogDBOperation("bulk_delete", "posts", time.Since(start).Milliseconds(), err)
+		return 0, 0, fmt.Errorf("failed to bulk delete posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("bulk_delete", "posts", time.Since(start).Milliseconds(), nil)
+
+	return lastID, len(ids), nil
+}
+
+// bulkBatch runs one batch of a bulk query and drops the caches of the
+// posts it changed along with every list they may appear in
+func (r *postRepository) bulkBatch(ctx context.Context, query string, args []any) (int64, []int64, error) {
+	var lastID *int64
+	var ids []int64
+	if err := r.conn(ctx).QueryRow(ctx, query, args...).Scan(&lastID, &ids); err != nil {
+		return 0, nil, err
 	}
 
-	r.redis.Del(ctx, "posts:count").Err()
-	r.logger.LogCacheOperation("delete", "posts:count", false)
+	if len(ids) > 0 {
+		afterCommit(ctx, func(ctx context.Context) {
+			for _, id := range ids {
+				r.invalidatePostCaches(ctx, id)
+			}
+			r.invalidateListCaches(ctx)
+			r.invalidateAllFilteredCaches(ctx)
+		})
+	}
+
+	if lastID == nil {
+		return 0, ids, nil
+	}
+
+	return *lastID, ids, nil
+}
+
+// bulkFilterArgs returns the arguments of bulkFilter
+func bulkFilterArgs(filter *model.BulkPostFilter) []any {
+	var ids []int64
+	if len(filter.IDs) > 0 {
+		ids = filter.IDs
+	}
+
+	return []any{ids, filter.Source, filter.Category, filter.From, filter.To, filter.Domain}
+}
+
+// UpdatePostSensitive stores the classifier's verdict for a post
+func (r *postRepository) UpdatePostSensitive(ctx context.Context, id int64, sensitive bool) error {
+	start := time.Now()
+
+	var category *string
+	var source string
+	err := r.conn(ctx).QueryRow(ctx, stmtUpdatePostSensitive, id, sensitive).Scan(&category, &source)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.logger.LogDBOperation("update_sensitive", "posts", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to update post sensitive flag: %w", err)
+	}
+
+	r.logger.LogDBOperation("update_sensitive", "posts", time.Since(start).Milliseconds(), nil)
+
+	afterCommit(ctx, func(ctx context.Context) {
+		r.invalidatePostCaches(ctx, id)
+		r.invalidateListCaches(ctx)
+		r.invalidateFilteredCaches(ctx, category, source)
+	})
+
+	return nil
+}
+
+// AdjustCommentCount adds delta to a post's denormalized comment count
+func (r *postRepository) AdjustCommentCount(ctx context.Context, id int64, delta int) error {
+	start := time.Now()
+
+	var category *string
+	var source string
+	err := r.conn(ctx).QueryRow(ctx, stmtAdjustCommentCount, id, delta).Scan(&category, &source)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.logger.LogDBOperation("adjust_comment_count", "posts", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to adjust post comment count: %w", err)
+	}
+
+	r.logger.LogDBOperation("adjust_comment_count", "posts", time.Since(start).Milliseconds(), nil)
+
+	afterCommit(ctx, func(ctx context.Context) {
+		r.invalidatePostCaches(ctx, id)
+		r.invalidateListCaches(ctx)
+		r.invalidateFilteredCaches(ctx, category, source)
+	})
+
+	return nil
+}
+
+// AdjustReactionCount adds delta to a post's denormalized reaction count
+func (r *postRepository) AdjustReactionCount(ctx context.Context, id int64, delta int) error {
+	start := time.Now()
+
+	var category *string
+	var source string
+	err := r.conn(ctx).QueryRow(ctx, stmtAdjustReactionCount, id, delta).Scan(&category, &source)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.logger.LogDBOperation("adjust_reaction_count", "posts", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to adjust post reaction count: %w", err)
+	}
+
+	r.logger.LogDBOperation("adjust_reaction_count", "posts", time.Since(start).Milliseconds(), nil)
+
+	afterCommit(ctx, func(ctx context.Context) {
+		r.invalidatePostCaches(ctx, id)
+		r.invalidateListCaches(ctx)
+		r.invalidateFilteredCaches(ctx, category, source)
+	})
+
+	return nil
+}
+
+// SearchPosts searches the titles, descriptions and authors of posts, in one
+// country when params.Country is set
+func (r *postRepository) SearchPosts(ctx context.Context, params *model.SearchPostsParams) ([]model.Post, error) {
+	start := time.Now()
+
+	posts, err := r.queryPosts(ctx, stmtSearchPosts, params.Query, params.Limit, params.Offset, params.SafeMode, params.Popular, model.PostStatusFilter(params.Status), params.Collapse,
+		lowerCountry(params.Country))
+	if err != nil {
+		r.logger.LogDBOperation("search", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("search", "posts", time.Since(start).Milliseconds(), nil)
+
+	return posts, nil
+}
+
+// SearchFacets counts the posts matching a search per category, source and
+// publication day
+func (r *postRepository) SearchFacets(ctx context.Context, params *model.SearchPostsParams) (*model.SearchFacets, error) {
+	start := time.Now()
+
+	rows, err := r.reader(ctx).Query(ctx, stmtSearchFacets, params.Query, params.SafeMode, model.PostStatusFilter(params.Status),
+		model.MaxFacetValues, model.MaxFacetDays, lowerCountry(params.Country))
+	if err != nil {
+		r.logger.LogDBOperation("search_facets", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to count search facets: %w", err)
+	}
+	defer rows.Close()
+
+	facets := &model.SearchFacets{
+		Categories: []model.FacetCount{},
+		Sources:    []model.FacetCount{},
+		Days:       []model.FacetCount{},
+	}
+	for rows.Next() {
+		var facet string
+		var count model.FacetCount
+		if err := rows.Scan(&facet, &count.Value, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan search facet: %w", err)
+		}
+
+		switch facet {
+		case "category":
+			facets.Categories = append(facets.Categories, count)
+		case "source":
+			facets.Sources = append(facets.Sources, count)
+		case "day":
+			facets.Days = append(facets.Days, count)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("search_facets", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate search facets: %w", err)
+	}
+
+	r.logger.LogDBOperation("search_facets", "posts", time.Since(start).Milliseconds(), nil)
+
+	return facets, nil
+}
+
+// HighlightPosts marks the words of a search in the titles and descriptions
+// of the given posts. Titles are highlighted whole, descriptions are cut to
+// a snippet around the matches.
+func (r *postRepository) HighlightPosts(ctx context.Context, params *model.HighlightPostsParams) (map[int64]model.PostHighlight, error) {
+	highlights := make(map[int64]model.PostHighlight, len(params.IDs))
+	if len(params.IDs) == 0 {
+		return highlights, nil
+	}
+
+	start := time.Now()
+
+	selectors := fmt.Sprintf(`StartSel="%s", StopSel="%s"`, params.StartSel, params.StopSel)
+	titleOptions := selectors + ", HighlightAll=true"
+	descriptionOptions := selectors + ", MaxWords=35, MinWords=15, MaxFragments=2"
+
+	rows, err := r.reader(ctx).Query(ctx, stmtHighlightPosts, params.IDs, params.Query, titleOptions, descriptionOptions)
+	if err != nil {
+		r.logger.LogDBOperation("highlight", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to highlight posts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var highlight model.PostHighlight
+		if err := rows.Scan(&id, &highlight.Title, &highlight.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan post highlight: %w", err)
+		}
+		highlights[id] = highlight
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("highlight", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate post highlights: %w", err)
+	}
+
+	r.logger.LogDBOperation("highlight", "posts", time.Since(start).Milliseconds(), nil)
+
+	return highlights, nil
+}
+
+// CountPosts counts the posts in a state. Only the count of published posts,
+// which every public list needs, is cached.
+func (r *postRepository) CountPosts(ctx context.Context, status model.PostStatus) (int64, error) {
+	start := time.Now()
+
+	filter := model.PostStatusFilter(status)
+	if filter == nil || model.PostStatus(*filter) != model.PostStatusPublished {
+		count, err := r.countPosts(ctx, stmtCountPostsFromView, stmtCountPosts, filter)
+		if err != nil {
+			r.logger.LogDBOperation("count", "posts", time.Since(start).Milliseconds(), err)
+			return 0, fmt.Errorf("failed to count posts: %w", err)
+		}
+
+		r.logger.LogDBOperation("count", "posts", time.Since(start).Milliseconds(), nil)
+
+		return count, nil
+	}
+
+	cacheKey := tenant.Key(ctx, "posts:count")
+
+	if count, ok := r.getLocal(ctx, cacheKey); ok {
+		return count.(int64), nil
+	}
+
+	count, err := readThroughEarly(ctx, r.lists, cacheKey, func(ctx context.Context) (int64, error) {
+		return r.countPosts(ctx, stmtCountPostsFromView, stmtCountPosts, filter)
+	})
+	if err != nil {
+		r.logger.LogDBOperation("count", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("count", "posts", time.Since(start).Milliseconds(), nil)
+	r.setLocal(cacheKey, count)
+
+	return count, nil
+}
+
+// PostCounts counts the posts in a state per category and source, keeping
+// the busiest of each. Like CountPosts it reads post_counts_daily while the
+// view is fresh, and the published counts are cached.
+func (r *postRepository) PostCounts(ctx context.Context, status model.PostStatus) (*model.PostCounts, error) {
+	start := time.Now()
+
+	filter := model.PostStatusFilter(status)
+	if filter == nil || model.PostStatus(*filter) != model.PostStatusPublished {
+		counts, err := r.postCounts(ctx, filter)
+		if err != nil {
+			r.logger.LogDBOperation("post_counts", "posts", time.Since(start).Milliseconds(), err)
+			return nil, fmt.Errorf("failed to count posts per category and source: %w", err)
+		}
+
+		r.logger.LogDBOperation("post_counts", "posts", time.Since(start).Milliseconds(), nil)
+
+		return counts, nil
+	}
+
+	cacheKey := tenant.Key(ctx, "posts:counts")
+
+	if counts, ok := r.getLocal(ctx, cacheKey); ok {
+		counts := counts.(model.PostCounts)
+		return &counts, nil
+	}
+
+	counts, err := readThroughEarly(ctx, r.lists, cacheKey, func(ctx context.Context) (*model.PostCounts, error) {
+		return r.postCounts(ctx, filter)
+	})
+	if err != nil {
+		r.logger.LogDBOperation("post_counts", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to count posts per category and source: %w", err)
+	}
+
+	r.logger.LogDBOperation("post_counts", "posts", time.Since(start).Milliseconds(), nil)
+	r.setLocal(cacheKey, *counts)
+
+	return counts, nil
+}
+
+// postCounts runs stmtPostCounts, or its view counterpart while
+// post_counts_daily is fresh enough
+func (r *postRepository) postCounts(ctx context.Context, status *string) (*model.PostCounts, error) {
+	query := stmtPostCounts
+	if r.postCountsFresh(ctx) {
+		query = stmtPostCountsFromView
+	}
+
+	rows, err := r.reader(ctx).Query(ctx, query, status, model.MaxFacetValues)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := &model.PostCounts{
+		Categories: []model.FacetCount{},
+		Sources:    []model.FacetCount{},
+	}
+	for rows.Next() {
+		var facet string
+		var count model.FacetCount
+		if err := rows.Scan(&facet, &count.Value, &count.Count); err != nil {
+			return nil, err
+		}
+
+		switch facet {
+		case "category":
+			counts.Categories = append(counts.Categories, count)
+		case "source":
+			counts.Sources = append(counts.Sources, count)
+		}
+	}
+
+	return counts, rows.Err()
+}
+
+// CountByCategory returns the number of posts in a category and state
+func (r *postRepository) CountPostsByCategory(ctx context.Context, category string, status model.PostStatus) (int64, error) {
+	start := time.Now()
+
+	count, err := r.countPosts(ctx, stmtCountPostsByCategoryFromView, stmtCountPostsByCategory, category, model.PostStatusFilter(status))
+	if err != nil {
+		r.logger.LogDBOperation("count_by_category", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count posts by category: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_by_category", "posts", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// CountPostsBySource returns the number of posts from a source in a state
+func (r *postRepository) CountPostsBySource(ctx context.Context, source string, status model.PostStatus) (int64, error) {
+	start := time.Now()
+
+	var count int64
+	err := r.reader(ctx).QueryRow(ctx, stmtCountPostsBySource, source, model.PostStatusFilter(status)).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_by_source", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count posts by source: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_by_source", "posts", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// CountPostsByCountry returns the number of posts aggregated for a country in a state
+func (r *postRepository) CountPostsByCountry(ctx context.Context, country string, status model.PostStatus) (int64, error) {
+	start := time.Now()
+
+	var count int64
+	err := r.reader(ctx).QueryRow(ctx, stmtCountPostsByCountry, strings.ToLower(country), model.PostStatusFilter(status)).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_by_country", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count posts by country: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_by_country", "posts", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// CountPostsByAuthor returns the number of posts by an author in a state
+func (r *postRepository) CountPostsByAuthor(ctx context.Context, author string, status model.PostStatus) (int64, error) {
+	start := time.Now()
+
+	var count int64
+	err := r.reader(ctx).QueryRow(ctx, stmtCountPostsByAuthor, author, model.PostStatusFilter(status)).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_by_author", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count posts by author: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_by_author", "posts", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// CountSearchPosts returns the number of posts a search matches in a state,
+// in its country if set
+func (r *postRepository) CountSearchPosts(ctx context.Context, params *model.SearchPostsParams) (int64, error) {
+	start := time.Now()
+
+	var count int64
+	err := r.reader(ctx).QueryRow(ctx, stmtCountSearchPosts, params.Query, model.PostStatusFilter(params.Status),
+		lowerCountry(params.Country)).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_search", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count searched posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_search", "posts", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// CountSafePosts returns the number of posts not flagged as sensitive in a
+// state. Like ListPosts, only the first of search, category, source, country
+// and author that is set filters the count, a search being narrowed to its
+// country as well.
+func (r *postRepository) CountSafePosts(ctx context.Context, params *model.PostListParams) (int64, error) {
+	start := time.Now()
+
+	var search, category, source, country, author *string
+	switch {
+	case params.Search != nil && *params.Search != "":
+		search = params.Search
+		country = lowerCountry(params.Country)
+	case params.Category != nil && *params.Category != "":
+		category = params.Category
+	case params.Source != nil && *params.Source != "":
+		source = params.Source
+	case params.Country != nil && *params.Country != "":
+		lowered := strings.ToLower(*params.Country)
+		country = &lowered
+	case params.Author != nil && *params.Author != "":
+		author = params.Author
+	}
+
+	var count int64
+	err := r.reader(ctx).QueryRow(ctx, stmtCountSafePosts, category, source, country, search,
+		model.PostStatusFilter(params.Status), author).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_safe", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count safe posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_safe", "posts", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// CountCollapsedPosts counts the posts of a collapsed list, one per topic.
+// Like ListPosts, only the first of search, category, source, country and
+// author that is set filters the count.
+func (r *postRepository) CountCollapsedPosts(ctx context.Context, params *model.PostListParams) (int64, error) {
+	start := time.Now()
+
+	var search, category, source, country, author *string
+	switch {
+	case params.Search != nil && *params.Search != "":
+		search = params.Search
+	case params.Category != nil && *params.Category != "":
+		category = params.Category
+	case params.Source != nil && *params.Source != "":
+		source = params.Source
+	case params.Country != nil && *params.Country != "":
+		lowered := strings.ToLower(*params.Country)
+		country = &lowered
+	case params.Author != nil && *params.Author != "":
+		author = params.Author
+	}
+
+	var count int64
+	err := r.reader(ctx).QueryRow(ctx, stmtCountCollapsedPosts, params.SafeMode, category, source, country, search,
+		model.PostStatusFilter(params.Status), author).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_collapsed", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count collapsed posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_collapsed", "posts", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// EstimatePosts estimates the posts a list matches from the planner's
+// statistics, which is far cheaper than counting them on a large table.
+// Like ListPosts, only the first of search, category, source, country and
+// author that is set filters the estimate; collapsing is not accounted for.
+func (r *postRepository) EstimatePosts(ctx context.Context, params *model.PostListParams) (int64, error) {
+	start := time.Now()
+
+	var search, category, source, country, author *string
+	switch {
+	case params.Search != nil && *params.Search != "":
+		search = params.Search
+		country = lowerCountry(params.Country)
+	case params.Category != nil && *params.Category != "":
+		category = params.Category
+	case params.Source != nil && *params.Source != "":
+		source = params.Source
+	case params.Country != nil && *params.Country != "":
+		lowered := strings.ToLower(*params.Country)
+		country = &lowered
+	case params.Author != nil && *params.Author != "":
+		author = params.Author
+	}
+
+	var estimate int64
+	err := r.reader(ctx).QueryRow(ctx, stmtEstimatePosts, params.SafeMode, category, source, country, search,
+		model.PostStatusFilter(params.Status), author).Scan(&estimate)
+	if err != nil {
+		r.logger.LogDBOperation("estimate", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to estimate posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("estimate", "posts", time.Since(start).Milliseconds(), nil)
+
+	return estimate, nil
+}
+
+// IncrementPostViews records a view of a post, both in its running total and
+// in the counters of the current hour. A viewer's repeat views within the
+// dedup window are counted once, so refresh loops do not inflate trending;
+// views without a viewer are always counted.
+func (r *postRepository) IncrementPostViews(ctx context.Context, id int64, viewer string) error {
+	if window := time.Duration(r.viewerDedupWindow.Load()); window > 0 && viewer != "" {
+		first, err := r.redis.SetNX(ctx, postViewerKey(id, viewer), 1, window).Result()
+		if err != nil {
+			return fmt.Errorf("failed to check repeat post view: %w", err)
+		}
+		if !first {
+			return nil
+		}
+	}
+
+	field := strconv.FormatInt(id, 10)
+	hourKey := postViewsHourKey(time.Now().UTC().Truncate(time.Hour))
+
+	pipe := r.redis.Pipeline()
+	pipe.HIncrBy(ctx, postViewsKey, field, 1)
+	pipe.HIncrBy(ctx, hourKey, field, 1)
+	pipe.Expire(ctx, hourKey, postViewsHourTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to increment post views: %w", err)
+	}
+
+	return nil
+}
+
+// GetPostViews returns the recorded view counts for the given posts
+func (r *postRepository) GetPostViews(ctx context.Context, ids []int64) (map[int64]int64, error) {
+	views := make(map[int64]int64, len(ids))
+	if len(ids) == 0 {
+		return views, nil
+	}
+
+	fields := make([]string, len(ids))
+	for i, id := range ids {
+		fields[i] = strconv.FormatInt(id, 10)
+	}
+
+	values, err := r.redis.HMGet(ctx, postViewsKey, fields...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post views: %w", err)
+	}
+
+	for i, value := range values {
+		if str, ok := value.(string); ok {
+			if count, err := strconv.ParseInt(str, 10, 64); err == nil {
+				views[ids[i]] = count
+			}
+		}
+	}
+
+	return views, nil
+}
+
+// ListSitemapEntries returns a page of published post IDs and modification
+// times, ordered by ID
+func (r *postRepository) ListSitemapEntries(ctx context.Context, limit, offset int) ([]model.SitemapEntry, error) {
+	start := time.Now()
+
+	rows, err := r.reader(ctx).Query(ctx, stmtListSitemapEntries, limit, offset)
+	if err != nil {
+		r.logger.LogDBOperation("list_sitemap_entries", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list sitemap entries: %w", err)
+	}
+
+	defer rows.Close()
+
+	entries := []model.SitemapEntry{}
+	for rows.Next() {
+		var entry model.SitemapEntry
+		if err := rows.Scan(&entry.PostID, &entry.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sitemap entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("list_sitemap_entries", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate sitemap entries: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_sitemap_entries", "posts", time.Since(start).Milliseconds(), nil)
+
+	return entries, nil
+}
+
+// PostStats counts the posts created between from and to per day and, for
+// the category, source and country groupings, per category, source or
+// country. While the
+// post_counts_daily view is fresh it answers the whole days of the window;
+// partial days at either end are always counted from posts.
+func (r *postRepository) PostStats(ctx context.Context, groupBy string, from, to time.Time) ([]model.PostStatsBucket, error) {
+	start := time.Now()
+
+	firstDay := from.Truncate(24 * time.Hour)
+	if firstDay.Before(from) {
+		firstDay = firstDay.Add(24 * time.Hour)
+	}
+	lastDay := to.Truncate(24 * time.Hour)
+
+	ranges := []postStatsRange{{stmtPostStats, from, to}}
+	if lastDay.After(firstDay) && r.postCountsFresh(ctx) {
+		ranges = []postStatsRange{
+			{stmtPostStats, from, firstDay},
+			{stmtPostStatsFromView, firstDay, lastDay},
+			{stmtPostStats, lastDay, to},
+		}
+	}
+
+	buckets := []model.PostStatsBucket{}
+	for _, rng := range ranges {
+		if !rng.from.Before(rng.to) {
+			continue
+		}
+
+		counted, err := r.postStatsBuckets(ctx, rng, groupBy)
+		if err != nil {
+			r.logger.LogDBOperation("post_stats", "posts", time.Since(start).Milliseconds(), err)
+			return nil, err
+		}
+		buckets = append(buckets, counted...)
+	}
+
+	// The ranges cover distinct days, each already ordered busiest first
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return buckets[i].Day < buckets[j].Day
+	})
+
+	r.logger.LogDBOperation("post_stats", "posts", time.Since(start).Milliseconds(), nil)
+
+	return buckets, nil
+}
+
+// postStatsRange is a part of a post statistics window and the query that
+// counts it
+type postStatsRange struct {
+	query    string
+	from, to time.Time
+}
+
+// postStatsBuckets counts the posts of one part of a statistics window
+func (r *postRepository) postStatsBuckets(ctx context.Context, rng postStatsRange, groupBy string) ([]model.PostStatsBucket, error) {
+	rows, err := r.reader(ctx).Query(ctx, rng.query, rng.from, rng.to, groupBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count post stats: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []model.PostStatsBucket
+	for rows.Next() {
+		var bucket model.PostStatsBucket
+		if err := rows.Scan(&bucket.Day, &bucket.Key, &bucket.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan post stats: %w", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate post stats: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// countPosts runs viewQuery against post_counts_daily while the view is
+// fresh enough, and liveQuery against posts otherwise
+func (r *postRepository) countPosts(ctx context.Context, viewQuery, liveQuery string, args ...any) (int64, error) {
+	query := liveQuery
+	if r.postCountsFresh(ctx) {
+		query = viewQuery
+	}
+
+	var count int64
+	err := r.reader(ctx).QueryRow(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// postCountsFresh reports whether post_counts_daily was refreshed within the
+// stale tolerance. It is checked on the connection that reads the view, so a
+// lagging replica is judged by its own copy. Failures count as stale.
+func (r *postRepository) postCountsFresh(ctx context.Context) bool {
+	tolerance := time.Duration(r.viewTolerance.Load())
+	if tolerance <= 0 {
+		return false
+	}
+
+	var fresh bool
+	if err := r.reader(ctx).QueryRow(ctx, stmtPostCountsFresh, tolerance.Seconds()).Scan(&fresh); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			r.logger.Warn("Failed to check post counts view freshness", "error", err.Error())
+		}
+		return false
+	}
+
+	return fresh
+}
+
+// createStatus returns the state a new post is stored in
+func createStatus(status model.PostStatus) model.PostStatus {
+	if status == "" {
+		return model.PostStatusPublished
+	}
+	return status
+}
+
+// queryPosts runs a read-only post query and collects the results
+func (r *postRepository) queryPosts(ctx context.Context, query string, args ...any) ([]model.Post, error) {
+	rows, err := r.reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectPosts(rows)
+}
+
+// conn returns the transaction carried by ctx or the primary pool
+func (r *postRepository) conn(ctx context.Context) querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx
+	}
+
+	return r.db
+}
+
+// reader returns the connection used for read-only list, search and count
+// queries; reads inside a transaction stay on that transaction
+func (r *postRepository) reader(ctx context.Context) querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx
+	}
+
+	if r.replicas == nil {
+		return r.db
+	}
+
+	return r.replicas.Reader()
+}
+
+// RebuildURLFilter rebuilds the URL filter of the tenant in ctx from the
+// stored URLs, dropping those of deleted posts, and returns how many it holds
+func (r *postRepository) RebuildURLFilter(ctx context.Context) (int, error) {
+	start := time.Now()
+
+	if r.urls == nil {
+		return 0, nil
+	}
+
+	rows, err := r.reader(ctx).Query(ctx, stmtListPostURLs)
+	if err != nil {
+		r.logger.LogDBOperation("rebuild_url_filter", "post_urls", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to list post urls: %w", err)
+	}
+	defer rows.Close()
+
+	added, err := r.urls.rebuild(ctx, func() (string, bool, error) {
+		if !rows.Next() {
+			return "", false, rows.Err()
+		}
+
+		var url string
+		err := rows.Scan(&url)
+		return url, err == nil, err
+	})
+	if err != nil {
+		r.logger.LogDBOperation("rebuild_url_filter", "post_urls", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to rebuild url filter: %w", err)
+	}
+
+	r.logger.LogDBOperation("rebuild_url_filter", "post_urls", time.Since(start).Milliseconds(), nil)
+
+	return added, nil
+}
+
+// addURL records a stored URL in the URL filter. A URL missing from the
+// filter only costs a database lookup, so failures are not reported.
+func (r *postRepository) addURL(ctx context.Context, url string) {
+	if r.urls == nil {
+		return
+	}
+
+	if err := r.urls.add(ctx, url); err != nil {
+		r.logger.Warn("Failed to add url to url filter", "error", err.Error())
+	}
+}
+
+// Helper methods for cache invalidation
+// cachePost stores post under its ID key, replacing a cached copy or a
+// missing marker
+func (r *postRepository) cachePost(ctx context.Context, post *model.Post) {
+	cacheKey := tenant.Key(ctx, fmt.Sprintf("post:id:%d", post.ID))
+	if postJson, err := json.Marshal(post); err == nil {
+		r.redis.Set(ctx, cacheKey, postJson, r.lists.baseTTL()).Err()
+		r.logger.LogCacheOperation("set", cacheKey, false)
+	}
+	r.setLocal(cacheKey, *post)
+}
+
+// writeThroughPost caches a post that was just created and puts it at the
+// head of the cached first list pages it belongs on, so the read that
+// usually follows a write is served from the cache. Later pages shift by
+// one and are dropped along with the first pages it cannot be placed on.
+func (r *postRepository) writeThroughPost(ctx context.Context, post *model.Post) {
+	r.cachePost(ctx, post)
+
+	prefix := tenant.Key(ctx, "posts:list:1:")
+	keys, err := keysMatching(ctx, r.redis, prefix+"*")
+	if err != nil {
+		r.invalidateListCaches(ctx)
+		return
+	}
+
+	type cachedPage struct {
+		entry []byte
+		ttl   time.Duration
+	}
+	pages := make(map[string]cachedPage, len(keys))
+	for _, key := range keys {
+		cached, err := r.redis.Get(ctx, key).Bytes()
+		if err != nil {
+			continue
+		}
+		ttl, err := r.redis.PTTL(ctx, key).Result()
+		if err != nil || ttl <= 0 {
+			continue
+		}
+		if entry, ok := prependToPage(strings.TrimPrefix(key, prefix), cached, post); ok {
+			pages[key] = cachedPage{entry: entry, ttl: ttl}
+		}
+	}
+
+	r.invalidateListCaches(ctx)
+
+	for key, page := range pages {
+		r.redis.Set(ctx, key, page.entry, page.ttl).Err()
+		r.logger.LogCacheOperation("prepend", key, false)
+	}
+}
+
+// prependToPage returns the cached first list page with the options in
+// suffix (the key after the page number) once post is added, which is the
+// page unchanged when post does not belong on it. It reports false when the
+// page cannot be updated in place: popular and collapsed lists depend on
+// other posts, and a post published before the head of the page lands
+// somewhere further down the list.
+func prependToPage(suffix string, cached []byte, post *model.Post) ([]byte, bool) {
+	options := strings.Split(suffix, ":")
+	limit, err := strconv.Atoi(options[0])
+	if err != nil {
+		return nil, false
+	}
+
+	status := model.PostStatusPublished
+	safe := false
+	for _, option := range options[1:] {
+		switch option {
+		case "popular", "collapsed":
+			return nil, false
+		case "safe":
+			safe = true
+		case "peek":
+			limit++
+		default:
+			status = model.PostStatus(option)
+		}
+	}
+
+	if (safe && post.Sensitive) || (status != model.PostStatusAny && status != post.Status) {
+		return cached, true
+	}
+
+	var entry cacheEntry
+	var posts []model.Post
+	if json.Unmarshal(cached, &entry) != nil || json.Unmarshal(entry.Data, &posts) != nil {
+		return nil, false
+	}
+
+	if len(posts) > 0 {
+		// Posts without a publication date sort first
+		head := posts[0].PublishedAt
+		if post.PublishedAt != nil && (head == nil || post.PublishedAt.Before(*head)) {
+			return nil, false
+		}
+		if slices.ContainsFunc(posts, func(p model.Post) bool { return p.ID == post.ID }) {
+			return cached, true
+		}
+	}
+
+	posts = append([]model.Post{*post}, posts...)
+	if len(posts) > limit {
+		posts = posts[:limit]
+	}
+
+	data, err := json.Marshal(posts)
+	if err != nil {
+		return nil, false
+	}
+	entry.Data = data
+
+	updated, err := json.Marshal(entry)
+	if err != nil {
+		return nil, false
+	}
+
+	return updated, true
+}
+
+func (r *postRepository) invalidatePostCaches(ctx context.Context, id int64) {
+	cacheKey := tenant.Key(ctx, fmt.Sprintf("post:id:%d", id))
+	r.redis.Del(ctx, cacheKey).Err()
+	r.evictLocal(ctx, cacheKey)
+	r.logger.LogCacheOperation("delete", cacheKey, false)
+}
+
+func (r *postRepository) invalidateListCaches(ctx context.Context) {
+	pattern := tenant.Key(ctx, "posts:list:*")
+	if err := deleteMatching(ctx, r.redis, pattern); err == nil {
+		r.logger.LogCacheOperation("delete_pattern", pattern, false)
+	}
+
+	countKeys := []string{tenant.Key(ctx, "posts:count"), tenant.Key(ctx, "posts:counts")}
+	for _, countKey := range countKeys {
+		r.redis.Del(ctx, countKey).Err()
+		r.logger.LogCacheOperation("delete", countKey, false)
+	}
+	r.evictLocal(ctx, countKeys...)
+}
+
+// invalidateFilteredCaches drops the cached category and source lists a post
+// appears in, leaving the lists of other categories and sources cached
+func (r *postRepository) invalidateFilteredCaches(ctx context.Context, category *string, source string) {
+	r.invalidateCategoryCaches(ctx, category)
+
+	if source != "" {
+		pattern := tenant.Key(ctx, "posts:source:"+patternEscaper.Replace(source)+":*")
+		if err := deleteMatching(ctx, r.redis, pattern); err == nil {
+			r.logger.LogCacheOperation("delete_pattern", pattern, false)
+		}
+	}
+}
+
+// invalidateAllFilteredCaches drops the cached lists of every category and source
+func (r *postRepository) invalidateAllFilteredCaches(ctx context.Context) {
+	for _, pattern := range []string{tenant.Key(ctx, "posts:category:*"), tenant.Key(ctx, "posts:source:*")} {
+		if err := deleteMatching(ctx, r.redis, pattern); err == nil {
+			r.logger.LogCacheOperation("delete_pattern", pattern, false)
+		}
+	}
+}
+
+func (r *postRepository) invalidateCategoryCaches(ctx context.Context, category *string) {
+	if category == nil || *category == "" {
+		return
+	}
+
+	pattern := tenant.Key(ctx, "posts:category:"+patternEscaper.Replace(*category)+":*")
+	if err := deleteMatching(ctx, r.redis, pattern); err == nil {
+		r.logger.LogCacheOperation("delete_pattern", pattern, false)
+	}
+}
+
+// listCacheKey appends the list options that change a page to key
+func listCacheKey(key string, params model.BasePostListParams) string {
+	if params.SafeMode {
+		key += ":safe"
+	}
+	if params.Popular {
+		key += ":popular"
+	}
+	if params.Status != "" && params.Status != model.PostStatusPublished {
+		key += ":" + string(params.Status)
+	}
+	if params.Collapse {
+		key += ":collapsed"
+	}
+
+	return key
+}
+
+// sameCategory reports whether two optional categories are equal
+func sameCategory(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// lowerCountry returns an optional country code in lowercase, or nil when
+// it is unset or empty
+func lowerCountry(country *string) *string {
+	if country == nil || *country == "" {
+		return nil
+	}
+
+	lowered := strings.ToLower(*country)
+	return &lowered
+}
+
+// Helper methods for the optional in-process L1 cache
+func (r *postRepository) getLocal(ctx context.Context, key string) (any, bool) {
+	if r.local == nil {
+		return nil, false
+	}
+
+	value, ok := r.local.Get(key)
+	r.logger.LogCacheOperation("get_local", key, ok)
+	if ok {
+		cachetrace.Record(ctx, true)
+	}
+
+	return value, ok
+}
+
+func (r *postRepository) setLocal(key string, value any) {
+	if r.local != nil {
+		r.local.Set(key, value)
+	}
+}
+
+func (r *postRepository) evictLocal(ctx context.Context, keys ...string) {
+	if r.local != nil {
+		r.local.Delete(keys...)
+	}
+	if r.invalidations != nil {
+		r.invalidations.publish(ctx, keys...)
+	}
+}
+
+// isUniqueViolation reports whether err was raised by a unique constraint
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
 }
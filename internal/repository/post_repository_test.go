@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -9,7 +10,9 @@ import (
 
 	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/database"
 	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +26,7 @@ import (
 
 type testSuite struct {
 	db          *pgxpool.Pool
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 	repo        PostRepository
 	logger      *logger.Logger
 
@@ -67,8 +70,12 @@ func setupTestSuite(t *testing.T) *testSuite {
 	redisPort, err := redisContainer.MappedPort(ctx, "6379")
 	require.NoError(t, err)
 
-	// Create database pool
-	db, err := pgxpool.New(ctx, pgConnStr)
+	// Create database pool, preparing the repository statements like the
+	// server's pools
+	poolConfig, err := pgxpool.ParseConfig(pgConnStr)
+	require.NoError(t, err)
+	poolConfig.BeforeAcquire = database.PrepareStatements
+	db, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	require.NoError(t, err)
 
 	// Create Redis client
@@ -87,7 +94,7 @@ func setupTestSuite(t *testing.T) *testSuite {
 	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
 	testLogger := logger.New(cfg)
 
-	repo := NewPostRepository(db, redisClient, testLogger, time.Minute)
+	repo := NewPostRepository(db, nil, redisClient, testLogger, config.CacheConfig{TTL: time.Minute})
 
 	return &testSuite{
 		db:             db,
@@ -118,32 +125,309 @@ func (ts *testSuite) teardown(t *testing.T) {
 
 func createTestTables(ctx context.Context, db *pgxpool.Pool) error {
 	query := `
+		CREATE TABLE IF NOT EXISTS tenants (
+			id VARCHAR(50) PRIMARY KEY,
+			name VARCHAR(200) NOT NULL,
+			news_api_key VARCHAR(200),
+			news_api_daily_quota INTEGER NOT NULL DEFAULT 0,
+			sources TEXT[] NOT NULL DEFAULT '{}',
+			active BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW()
+		);
+
+		INSERT INTO tenants (id, name) VALUES ('default', 'Default') ON CONFLICT DO NOTHING;
+
 		CREATE TABLE IF NOT EXISTS posts (
-			id SERIAL PRIMARY KEY,
+			id SERIAL,
+			tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' REFERENCES tenants(id),
 			title VARCHAR(500) NOT NULL,
 			description TEXT,
 			content TEXT,
-			url VARCHAR(1000) UNIQUE NOT NULL,
+			url VARCHAR(1000) NOT NULL,
 			source VARCHAR(100) NOT NULL,
 			category VARCHAR(50),
+			country VARCHAR(2),
 			image_url VARCHAR(1000),
 			published_at TIMESTAMP,
 			created_at TIMESTAMP DEFAULT NOW(),
-			updated_at TIMESTAMP DEFAULT NOW()
-		);
+			updated_at TIMESTAMP DEFAULT NOW(),
+			version INTEGER NOT NULL DEFAULT 1,
+			status VARCHAR(20) NOT NULL DEFAULT 'published',
+			content_extracted_at TIMESTAMP,
+			sensitive BOOLEAN NOT NULL DEFAULT FALSE,
+			comment_count INTEGER NOT NULL DEFAULT 0,
+			reaction_count INTEGER NOT NULL DEFAULT 0,
+			topic_id BIGINT,
+			content_hash TEXT,
+			author VARCHAR(255)
+		) PARTITION BY RANGE (published_at);
+
+		CREATE TABLE IF NOT EXISTS posts_default PARTITION OF posts DEFAULT;
 		
+		CREATE INDEX idx_posts_id ON posts(id);
 		CREATE INDEX idx_posts_published_at ON posts(published_at DESC);
 		CREATE INDEX idx_posts_source ON posts(source);
 		CREATE INDEX idx_posts_category ON posts(category);
 		CREATE INDEX idx_posts_created_at ON posts(created_at DESC);
 		CREATE INDEX idx_posts_category_published ON posts(category, published_at DESC);
+		CREATE INDEX idx_posts_country_published ON posts(country, published_at DESC);
+		CREATE INDEX idx_posts_author_published ON posts(author, published_at DESC);
+
+		CREATE TABLE IF NOT EXISTS post_urls (
+			tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' REFERENCES tenants(id),
+			url VARCHAR(1000) NOT NULL,
+			post_id INTEGER NOT NULL,
+			PRIMARY KEY (tenant_id, url)
+		);
+
+		CREATE OR REPLACE FUNCTION create_posts_partition(month_start DATE) RETURNS BOOLEAN
+			LANGUAGE plpgsql
+			AS $$
+		DECLARE
+			partition_name TEXT := format('posts_%s', to_char(month_start, 'YYYY_MM'));
+			month_end DATE := (month_start + INTERVAL '1 month')::date;
+		BEGIN
+			IF to_regclass(partition_name) IS NOT NULL THEN
+				RETURN FALSE;
+			END IF;
+
+			IF EXISTS (SELECT 1 FROM posts_default WHERE published_at >= month_start AND published_at < month_end) THEN
+				ALTER TABLE posts DETACH PARTITION posts_default;
+				EXECUTE format('CREATE TABLE %I PARTITION OF posts FOR VALUES FROM (%L) TO (%L)', partition_name, month_start, month_end);
+				EXECUTE format('INSERT INTO %I SELECT * FROM posts_default WHERE published_at >= %L AND published_at < %L', partition_name, month_start, month_end);
+				DELETE FROM posts_default WHERE published_at >= month_start AND published_at < month_end;
+				ALTER TABLE posts ATTACH PARTITION posts_default DEFAULT;
+			ELSE
+				EXECUTE format('CREATE TABLE %I PARTITION OF posts FOR VALUES FROM (%L) TO (%L)', partition_name, month_start, month_end);
+			END IF;
+
+			RETURN TRUE;
+		END
+		$$;
+
+		CREATE OR REPLACE FUNCTION ensure_posts_partitions(months_ahead INTEGER) RETURNS INTEGER
+			LANGUAGE plpgsql
+			AS $$
+		DECLARE
+			month_start DATE;
+			created INTEGER := 0;
+		BEGIN
+			FOR month_start IN
+				SELECT generate_series(date_trunc('month', NOW()), date_trunc('month', NOW()) + make_interval(months => months_ahead), INTERVAL '1 month')::date
+				UNION
+				SELECT DISTINCT date_trunc('month', published_at)::date FROM posts_default WHERE published_at IS NOT NULL
+				ORDER BY 1
+			LOOP
+				IF create_posts_partition(month_start) THEN
+					created := created + 1;
+				END IF;
+			END LOOP;
+
+			RETURN created;
+		END
+		$$;
+
+		CREATE OR REPLACE FUNCTION sync_post_urls() RETURNS TRIGGER
+			LANGUAGE plpgsql
+			AS $$
+		BEGIN
+			IF TG_OP = 'INSERT' THEN
+				INSERT INTO post_urls (tenant_id, url, post_id) VALUES (NEW.tenant_id, NEW.url, NEW.id)
+				ON CONFLICT (tenant_id, url) DO NOTHING;
+
+				IF NOT FOUND AND NOT EXISTS (
+					SELECT 1 FROM post_urls WHERE tenant_id = NEW.tenant_id AND url = NEW.url AND post_id = NEW.id
+				) THEN
+					RAISE unique_violation USING
+						MESSAGE = format('duplicate post url %s', NEW.url),
+						CONSTRAINT = 'post_urls_pkey';
+				END IF;
+
+				RETURN NULL;
+			END IF;
+
+			IF NOT EXISTS (SELECT 1 FROM posts WHERE id = OLD.id) THEN
+				DELETE FROM post_urls WHERE tenant_id = OLD.tenant_id AND url = OLD.url AND post_id = OLD.id;
+				DELETE FROM post_clicks WHERE post_id = OLD.id;
+				DELETE FROM comments WHERE post_id = OLD.id;
+				DELETE FROM post_reactions WHERE post_id = OLD.id;
+			END IF;
+
+			RETURN NULL;
+		END
+		$$;
+
+		CREATE TRIGGER posts_sync_urls AFTER INSERT OR DELETE ON posts
+			FOR EACH ROW EXECUTE FUNCTION sync_post_urls();
+
+		CREATE TABLE IF NOT EXISTS post_deletions (
+			tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' REFERENCES tenants(id),
+			post_id INTEGER NOT NULL,
+			deleted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (tenant_id, post_id)
+		);
+
+		CREATE OR REPLACE FUNCTION record_post_deletion() RETURNS TRIGGER
+			LANGUAGE plpgsql
+			AS $$
+		BEGIN
+			IF NOT EXISTS (SELECT 1 FROM posts WHERE id = OLD.id) THEN
+				INSERT INTO post_deletions (tenant_id, post_id) VALUES (OLD.tenant_id, OLD.id)
+				ON CONFLICT (tenant_id, post_id) DO UPDATE SET deleted_at = NOW();
+			END IF;
+
+			RETURN NULL;
+		END
+		$$;
+
+		CREATE TRIGGER posts_record_deletion AFTER DELETE ON posts
+			FOR EACH ROW EXECUTE FUNCTION record_post_deletion();
+
+		CREATE OR REPLACE FUNCTION post_content_hash(title TEXT, description TEXT, content TEXT, category TEXT, image_url TEXT) RETURNS TEXT
+			LANGUAGE sql IMMUTABLE PARALLEL SAFE
+			AS $$
+		SELECT md5(
+			COALESCE(title, '') || E'\x1f' || COALESCE(description, '') || E'\x1f' || COALESCE(content, '') || E'\x1f' ||
+			COALESCE(category, '') || E'\x1f' || COALESCE(image_url, '')
+		)
+		$$;
+
+		CREATE OR REPLACE FUNCTION set_post_content_hash() RETURNS TRIGGER
+			LANGUAGE plpgsql
+			AS $$
+		BEGIN
+			NEW.content_hash := post_content_hash(NEW.title, NEW.description, NEW.content, NEW.category, NEW.image_url);
+			RETURN NEW;
+		END
+		$$;
+
+		CREATE TRIGGER posts_content_hash BEFORE INSERT OR UPDATE OF title, description, content, category, image_url ON posts
+			FOR EACH ROW EXECUTE FUNCTION set_post_content_hash();
+
+		SELECT ensure_posts_partitions(3);
+
+		CREATE TABLE IF NOT EXISTS post_clicks (
+			id BIGSERIAL PRIMARY KEY,
+			post_id INTEGER NOT NULL,
+			referrer VARCHAR(1000),
+			user_agent VARCHAR(500),
+			created_at TIMESTAMP DEFAULT NOW()
+		);
+
+		CREATE TABLE IF NOT EXISTS comments (
+			id BIGSERIAL PRIMARY KEY,
+			post_id INTEGER NOT NULL,
+			parent_id BIGINT REFERENCES comments(id) ON DELETE CASCADE,
+			author VARCHAR(100) NOT NULL,
+			body TEXT NOT NULL,
+			deleted_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW()
+		);
+
+		CREATE TABLE IF NOT EXISTS post_reactions (
+			post_id INTEGER NOT NULL,
+			user_id VARCHAR(100) NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW(),
+			PRIMARY KEY (post_id, user_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS quarantined_articles (
+			id BIGSERIAL PRIMARY KEY,
+			tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' REFERENCES tenants(id),
+			url VARCHAR(1000) NOT NULL,
+			title VARCHAR(500) NOT NULL,
+			source VARCHAR(100),
+			rule VARCHAR(50) NOT NULL,
+			reason VARCHAR(500) NOT NULL,
+			created_at TIMESTAMP DEFAULT NOW(),
+			UNIQUE (tenant_id, url)
+		);
+
+		CREATE TABLE IF NOT EXISTS search_queries (
+			id BIGSERIAL PRIMARY KEY,
+			tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' REFERENCES tenants(id),
+			term VARCHAR(200) NOT NULL,
+			result_count INTEGER NOT NULL,
+			latency_ms INTEGER NOT NULL,
+			zero_results BOOLEAN NOT NULL,
+			created_at TIMESTAMP DEFAULT NOW()
+		);
+
+		CREATE TABLE IF NOT EXISTS fetch_watermarks (
+			tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' REFERENCES tenants(id),
+			scope VARCHAR(20) NOT NULL,
+			key VARCHAR(100) NOT NULL,
+			fetched_until TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP DEFAULT NOW(),
+			PRIMARY KEY (tenant_id, scope, key)
+		);
+
+		CREATE TABLE IF NOT EXISTS source_rules (
+			id BIGSERIAL PRIMARY KEY,
+			tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' REFERENCES tenants(id),
+			list VARCHAR(10) NOT NULL,
+			type VARCHAR(10) NOT NULL,
+			value VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP DEFAULT NOW(),
+			updated_at TIMESTAMP DEFAULT NOW(),
+			UNIQUE (tenant_id, type, value)
+		);
+
+		CREATE TABLE IF NOT EXISTS post_activity_hourly (
+			tenant_id VARCHAR(50) NOT NULL DEFAULT 'default' REFERENCES tenants(id),
+			hour TIMESTAMP NOT NULL,
+			dimension VARCHAR(20) NOT NULL,
+			key VARCHAR(100) NOT NULL,
+			posts BIGINT NOT NULL DEFAULT 0,
+			views BIGINT NOT NULL DEFAULT 0,
+			clicks BIGINT NOT NULL DEFAULT 0,
+			updated_at TIMESTAMP DEFAULT NOW(),
+			PRIMARY KEY (tenant_id, hour, dimension, key)
+		);
+
+		CREATE TABLE IF NOT EXISTS post_activity_rollups (
+			tenant_id VARCHAR(50) PRIMARY KEY DEFAULT 'default' REFERENCES tenants(id),
+			rolled_up_until TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP DEFAULT NOW()
+		);
+
+		CREATE OR REPLACE FUNCTION current_tenant() RETURNS VARCHAR
+			LANGUAGE sql STABLE
+			AS $$ SELECT COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), 'default') $$;
+
+		CREATE MATERIALIZED VIEW IF NOT EXISTS post_counts_daily AS
+		SELECT tenant_id, created_at::date AS day, COALESCE(category, '') AS category, source,
+			COALESCE(country, '') AS country, status, COUNT(*) AS posts
+		FROM posts
+		GROUP BY 1, 2, 3, 4, 5, 6;
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_post_counts_daily_key ON post_counts_daily(tenant_id, day, category, source, country, status);
+
+		CREATE TABLE IF NOT EXISTS materialized_view_refreshes (
+			name VARCHAR(100) PRIMARY KEY,
+			refreshed_at TIMESTAMP NOT NULL
+		);
+
+		CREATE OR REPLACE FUNCTION refresh_post_counts() RETURNS VOID
+			LANGUAGE plpgsql
+			AS $$
+		BEGIN
+			REFRESH MATERIALIZED VIEW CONCURRENTLY post_counts_daily;
+			INSERT INTO materialized_view_refreshes (name, refreshed_at) VALUES ('post_counts_daily', NOW())
+			ON CONFLICT (name) DO UPDATE SET refreshed_at = EXCLUDED.refreshed_at;
+		END
+		$$;
 	`
 	_, err := db.Exec(ctx, query)
 	return err
 }
 
 func (ts *testSuite) cleanupData(ctx context.Context) {
-	ts.db.Exec(ctx, "TRUNCATE posts RESTART IDENTITY CASCADE")
+	ts.db.Exec(ctx, "TRUNCATE posts, post_urls, post_deletions, post_clicks, comments, post_reactions, pinned_posts, quarantined_articles, dead_letters, search_queries, fetch_watermarks, source_rules, post_activity_hourly, post_activity_rollups, materialized_view_refreshes RESTART IDENTITY CASCADE")
 	ts.redisClient.FlushAll(ctx)
 }
 
@@ -229,6 +513,251 @@ func TestPostRepositoryGetPostByURL(t *testing.T) {
 	assert.Equal(t, createdPost.URL, post.URL)
 }
 
+func TestPostRepositoryGetPostByURLCached(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	createdPost, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	_, err = ts.repo.GetPostByURL(ctx, createdPost.URL)
+	require.NoError(t, err)
+
+	id, err := ts.redisClient.Get(ctx, postURLKey(ctx, createdPost.URL)).Int64()
+	require.NoError(t, err)
+	assert.Equal(t, createdPost.ID, id)
+
+	// A cached ID whose post is gone is not trusted
+	require.NoError(t, ts.repo.DeletePost(ctx, createdPost.ID))
+
+	_, err = ts.repo.GetPostByURL(ctx, createdPost.URL)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestPostRepositoryExistsByURL(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	params := createSamplePost()
+	_, err := ts.repo.CreatePost(ctx, params)
+	require.NoError(t, err)
+
+	exists, err := ts.repo.ExistsByURL(ctx, params.URL)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = ts.repo.ExistsByURL(ctx, "https://example.com/missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestPostRepositoryNormalizesURLs(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	params := createSamplePost()
+	params.URL = "https://Example.com/story/?utm_source=feed&id=7#top"
+	post, err := ts.repo.CreatePost(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/story?id=7", post.URL)
+
+	exists, err := ts.repo.ExistsByURL(ctx, "https://example.com/story?id=7&fbclid=abc")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	found, err := ts.repo.GetPostByURL(ctx, "https://EXAMPLE.com/story/?id=7")
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, found.ID)
+}
+
+func TestPostRepositoryRenamePostURL(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	// Stored before URLs were normalized
+	var legacyID, otherID int64
+	err := ts.db.QueryRow(ctx, `INSERT INTO posts (title, url, source) VALUES ('Legacy', 'https://example.com/a/?utm_source=x', 'Test') RETURNING id`).Scan(&legacyID)
+	require.NoError(t, err)
+	err = ts.db.QueryRow(ctx, `INSERT INTO posts (title, url, source) VALUES ('Other', 'https://example.com/b/', 'Test') RETURNING id`).Scan(&otherID)
+	require.NoError(t, err)
+
+	urls, err := ts.repo.ListPostURLs(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, urls, 2)
+	assert.Equal(t, model.PostURL{ID: legacyID, URL: "https://example.com/a/?utm_source=x"}, urls[0])
+
+	renamed, err := ts.repo.RenamePostURL(ctx, legacyID, "https://example.com/a")
+	require.NoError(t, err)
+	assert.True(t, renamed)
+
+	post, err := ts.repo.GetPostByURL(ctx, "https://example.com/a")
+	require.NoError(t, err)
+	assert.Equal(t, legacyID, post.ID)
+
+	// The URL is already held, so the other post keeps its own
+	renamed, err = ts.repo.RenamePostURL(ctx, otherID, "https://example.com/a")
+	require.NoError(t, err)
+	assert.False(t, renamed)
+
+	urls, err = ts.repo.ListPostURLs(ctx, legacyID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []model.PostURL{{ID: otherID, URL: "https://example.com/b/"}}, urls)
+}
+
+func TestPostRepositoryExistsByURLFilter(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	repo := NewPostRepository(ts.db, nil, ts.redisClient, ts.logger, config.CacheConfig{
+		TTL:                        time.Minute,
+		URLFilterEnabled:           true,
+		URLFilterExpectedURLs:      1000,
+		URLFilterFalsePositiveRate: 0.001,
+	})
+
+	post, err := repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	exists, err := repo.ExistsByURL(ctx, post.URL)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// Deleted behind the repository's back, the URL stays in the filter
+	// until it is rebuilt
+	_, err = ts.db.Exec(ctx, `DELETE FROM posts WHERE id = $1`, post.ID)
+	require.NoError(t, err)
+
+	exists, err = repo.ExistsByURL(ctx, post.URL)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	urls, err := repo.RebuildURLFilter(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, urls)
+
+	exists, err = repo.ExistsByURL(ctx, post.URL)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestPostRepositoryUpsertPost(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	params := createSamplePost()
+	createdPost, err := ts.repo.UpsertPost(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, params.Title, createdPost.Title)
+
+	_, err = ts.repo.UpsertPost(ctx, params)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// Re-sent with a later date but the same content, the article is skipped
+	resent := createSamplePost()
+	resentAt := params.PublishedAt.Add(10 * time.Minute)
+	resent.PublishedAt = &resentAt
+	_, err = ts.repo.UpsertPost(ctx, resent)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	newer := createSamplePost()
+	newer.Title = "Updated Post Title"
+	publishedAt := params.PublishedAt.Add(30 * time.Minute)
+	newer.PublishedAt = &publishedAt
+
+	updatedPost, err := ts.repo.UpsertPost(ctx, newer)
+	require.NoError(t, err)
+	assert.Equal(t, createdPost.ID, updatedPost.ID)
+	assert.Equal(t, "Updated Post Title", updatedPost.Title)
+	assert.Equal(t, createdPost.Version+1, updatedPost.Version)
+}
+
+func TestPostRepositoryPendingContent(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	createdPost, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	pending, err := ts.repo.ListPostsPendingContent(ctx, &model.ListPostsPendingContentParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	pending, err = ts.repo.ListPostsPendingContent(ctx, &model.ListPostsPendingContentParams{Sources: []string{"Other"}, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	content := "Full article content"
+	require.NoError(t, ts.repo.UpdatePostContent(ctx, createdPost.ID, &content))
+
+	post, err := ts.repo.GetPostByID(ctx, createdPost.ID)
+	require.NoError(t, err)
+	assert.Equal(t, content, *post.Content)
+
+	pending, err = ts.repo.ListPostsPendingContent(ctx, &model.ListPostsPendingContentParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestPostRepositoryReprocess(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/reprocess-%d", i)
+		post, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+		ids = append(ids, post.ID)
+	}
+	require.NoError(t, ts.repo.UpdatePostContent(ctx, ids[0], nil))
+
+	filter := model.ReprocessParams{MissingContent: true}
+
+	count, err := ts.repo.CountPostsForReprocess(ctx, &filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	posts, err := ts.repo.ListPostsForReprocess(ctx, &model.ListPostsForReprocessParams{ReprocessParams: filter, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, ids[1], posts[0].ID)
+
+	posts, err = ts.repo.ListPostsForReprocess(ctx, &model.ListPostsForReprocessParams{ReprocessParams: filter, AfterID: posts[0].ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, ids[2], posts[0].ID)
+
+	require.NoError(t, ts.repo.UpdatePostSensitive(ctx, ids[2], true))
+	post, err := ts.repo.GetPostByID(ctx, ids[2])
+	require.NoError(t, err)
+	assert.True(t, post.Sensitive)
+}
+
 func TestPostRepositoryGetPostByID(t *testing.T) {
 	ts := setupTestSuite(t)
 	defer ts.teardown(t)
@@ -263,6 +792,136 @@ func TestPostRepositoryGetPostByIDNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to get post by id")
 }
 
+func TestPostRepositoryCreatePostWritesThrough(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	first, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	listParams := &model.PostListParams{Page: 1, Limit: 10}
+	_, err = ts.repo.ListPosts(ctx, listParams)
+	require.NoError(t, err)
+	_, err = ts.repo.ListPosts(ctx, &model.PostListParams{Page: 2, Limit: 10})
+	require.NoError(t, err)
+
+	params := createSamplePost()
+	params.URL = "https://example.com/newer-post"
+	publishedAt := time.Now().UTC()
+	params.PublishedAt = &publishedAt
+	created, err := ts.repo.CreatePost(ctx, params)
+	require.NoError(t, err)
+
+	// The new post is served from the cache right away
+	cached, err := ts.redisClient.Get(ctx, fmt.Sprintf("post:id:%d", created.ID)).Result()
+	require.NoError(t, err)
+	assert.Contains(t, cached, params.URL)
+
+	var entry cacheEntry
+	var page []model.Post
+	cachedPage, err := ts.redisClient.Get(ctx, "posts:list:1:10").Bytes()
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(cachedPage, &entry))
+	require.NoError(t, json.Unmarshal(entry.Data, &page))
+	require.Len(t, page, 2)
+	assert.Equal(t, created.ID, page[0].ID)
+	assert.Equal(t, first.ID, page[1].ID)
+
+	exists, err := ts.redisClient.Exists(ctx, "posts:list:2:10").Result()
+	require.NoError(t, err)
+	assert.Zero(t, exists)
+}
+
+func TestPrependToPage(t *testing.T) {
+	now := time.Now().UTC()
+	earlier := now.Add(-time.Hour)
+	head := model.Post{ID: 1, Status: model.PostStatusPublished, PublishedAt: &earlier}
+	post := &model.Post{ID: 2, Status: model.PostStatusPublished, PublishedAt: &now}
+
+	data, err := json.Marshal([]model.Post{head})
+	require.NoError(t, err)
+	cached, err := json.Marshal(cacheEntry{Data: data, FreshUntil: now})
+	require.NoError(t, err)
+
+	pageIDs := func(entry []byte) []int64 {
+		var e cacheEntry
+		var posts []model.Post
+		require.NoError(t, json.Unmarshal(entry, &e))
+		require.NoError(t, json.Unmarshal(e.Data, &posts))
+		ids := make([]int64, len(posts))
+		for i, p := range posts {
+			ids[i] = p.ID
+		}
+		return ids
+	}
+
+	updated, ok := prependToPage("10", cached, post)
+	require.True(t, ok)
+	assert.Equal(t, []int64{2, 1}, pageIDs(updated))
+
+	updated, ok = prependToPage("1:any", cached, post)
+	require.True(t, ok)
+	assert.Equal(t, []int64{2}, pageIDs(updated))
+
+	// Pages the post does not belong on are kept as they are
+	updated, ok = prependToPage("10:draft", cached, post)
+	require.True(t, ok)
+	assert.Equal(t, cached, updated)
+
+	sensitive := *post
+	sensitive.Sensitive = true
+	updated, ok = prependToPage("10:safe", cached, &sensitive)
+	require.True(t, ok)
+	assert.Equal(t, cached, updated)
+
+	// Pages the post would land inside of cannot be updated in place
+	_, ok = prependToPage("10:popular", cached, post)
+	assert.False(t, ok)
+
+	older := *post
+	older.PublishedAt = &time.Time{}
+	_, ok = prependToPage("10", cached, &older)
+	assert.False(t, ok)
+}
+
+func TestPostRepositoryGetPostByIDCachesNotFound(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	repo := NewPostRepository(ts.db, nil, ts.redisClient, ts.logger, config.CacheConfig{TTL: time.Minute, NotFoundTTL: time.Minute})
+
+	_, err := repo.GetPostByID(ctx, 99999)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// Inserted behind the repository's back, the post stays missing until
+	// the cached result expires
+	_, err = ts.db.Exec(ctx, `INSERT INTO posts (id, title, url, source) VALUES (99999, 'Direct', 'https://example.com/direct', 'Test')`)
+	require.NoError(t, err)
+
+	_, err = repo.GetPostByID(ctx, 99999)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = ts.db.Exec(ctx, `DELETE FROM posts WHERE id = 99999`)
+	require.NoError(t, err)
+	_, err = ts.db.Exec(ctx, `SELECT setval('posts_id_seq', 99998)`)
+	require.NoError(t, err)
+
+	// Creating the post drops the cached result
+	created, err := repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+	require.Equal(t, int64(99999), created.ID)
+
+	post, err := repo.GetPostByID(ctx, 99999)
+	require.NoError(t, err)
+	assert.Equal(t, created.URL, post.URL)
+}
+
 func TestPostRepositoryUpdatePost(t *testing.T) {
 	ts := setupTestSuite(t)
 	defer ts.teardown(t)
@@ -284,6 +943,7 @@ func TestPostRepositoryUpdatePost(t *testing.T) {
 		Content:     &content,
 		Category:    &category,
 		ImageURL:    &imageURL,
+		Version:     createdPost.Version,
 	}
 
 	updatedPost, err := ts.repo.UpdatePost(ctx, createdPost.ID, updateParams)
@@ -296,6 +956,42 @@ func TestPostRepositoryUpdatePost(t *testing.T) {
 	assert.Equal(t, updateParams.Category, updatedPost.Category)
 	assert.Equal(t, updateParams.ImageURL, updatedPost.ImageURL)
 	assert.True(t, updatedPost.UpdatedAt.After(createdPost.UpdatedAt))
+	assert.Equal(t, createdPost.Version+1, updatedPost.Version)
+
+	_, err = ts.repo.UpdatePost(ctx, createdPost.ID, updateParams)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestPostRepositoryUpdatePostUnchanged(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	createdPost, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	updateParams := &model.UpdatePostParams{
+		Title:       createdPost.Title,
+		Description: createdPost.Description,
+		Content:     createdPost.Content,
+		Category:    createdPost.Category,
+		ImageURL:    createdPost.ImageURL,
+		Version:     createdPost.Version,
+		Sensitive:   createdPost.Sensitive,
+	}
+
+	post, err := ts.repo.UpdatePost(ctx, createdPost.ID, updateParams)
+	require.NoError(t, err)
+	assert.Equal(t, createdPost.Version, post.Version)
+	assert.Equal(t, createdPost.UpdatedAt, post.UpdatedAt)
+
+	// A change to the sensitive flag alone is still written
+	updateParams.Sensitive = !createdPost.Sensitive
+	post, err = ts.repo.UpdatePost(ctx, createdPost.ID, updateParams)
+	require.NoError(t, err)
+	assert.Equal(t, createdPost.Version+1, post.Version)
 }
 
 func TestPostRepositoryUpdatePostNotFound(t *testing.T) {
@@ -315,6 +1011,7 @@ func TestPostRepositoryUpdatePostNotFound(t *testing.T) {
 		Content:     &content,
 		Category:    &category,
 		ImageURL:    &imageURL,
+		Version:     1,
 	}
 
 	_, err := ts.repo.UpdatePost(ctx, 99999, updateParams)
@@ -361,7 +1058,7 @@ func TestPostRepositoryCountPosts(t *testing.T) {
 	ctx := context.Background()
 	defer ts.cleanupData(ctx)
 
-	count, err := ts.repo.CountPosts(ctx)
+	count, err := ts.repo.CountPosts(ctx, model.PostStatusPublished)
 	require.NoError(t, err)
 	assert.Equal(t, int64(0), count)
 
@@ -372,11 +1069,11 @@ func TestPostRepositoryCountPosts(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	count, err = ts.repo.CountPosts(ctx)
+	count, err = ts.repo.CountPosts(ctx, model.PostStatusPublished)
 	require.NoError(t, err)
 	assert.Equal(t, int64(3), count)
 
-	count2, err := ts.repo.CountPosts(ctx)
+	count2, err := ts.repo.CountPosts(ctx, model.PostStatusPublished)
 	require.NoError(t, err)
 	assert.Equal(t, count, count2)
 }
@@ -397,36 +1094,152 @@ func TestPostRepositoryCountPostsByCategory(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	techCount, err := ts.repo.CountPostsByCategory(ctx, "Technology")
+	techCount, err := ts.repo.CountPostsByCategory(ctx, "Technology", model.PostStatusPublished)
 	require.NoError(t, err)
 	assert.Equal(t, int64(2), techCount)
 
-	sportsCount, err := ts.repo.CountPostsByCategory(ctx, "Sports")
+	sportsCount, err := ts.repo.CountPostsByCategory(ctx, "Sports", model.PostStatusPublished)
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), sportsCount)
 
-	nonExistentCount, err := ts.repo.CountPostsByCategory(ctx, "NonExistent")
+	nonExistentCount, err := ts.repo.CountPostsByCategory(ctx, "NonExistent", model.PostStatusPublished)
 	require.NoError(t, err)
 	assert.Equal(t, int64(0), nonExistentCount)
 }
 
-func TestPostRepositoryListPosts(t *testing.T) {
+func TestPostRepositoryPostCounts(t *testing.T) {
 	ts := setupTestSuite(t)
 	defer ts.teardown(t)
 
 	ctx := context.Background()
 	defer ts.cleanupData(ctx)
 
-	for i := 0; i < 5; i++ {
+	categories := []string{"Technology", "Sports", "Technology", ""}
+	for i, category := range categories {
 		params := createSamplePost()
 		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
-		params.Title = fmt.Sprintf("Post %d", i)
+		params.Category = &category
 		_, err := ts.repo.CreatePost(ctx, params)
 		require.NoError(t, err)
 	}
 
-	listParams := &model.PostListParams{
-		Page:  1,
+	counts, err := ts.repo.PostCounts(ctx, model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, []model.FacetCount{
+		{Value: "Technology", Count: 2},
+		{Value: "Sports", Count: 1},
+		{Value: "uncategorized", Count: 1},
+	}, counts.Categories)
+	assert.Equal(t, []model.FacetCount{{Value: createSamplePost().Source, Count: 4}}, counts.Sources)
+
+	params := createSamplePost()
+	params.URL = "https://example.com/post-new"
+	_, err = ts.repo.CreatePost(ctx, params)
+	require.NoError(t, err)
+
+	counts, err = ts.repo.PostCounts(ctx, model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), counts.Sources[0].Count)
+}
+
+func TestPostRepositorySamplePosts(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	categories := []string{"Technology", "Technology", "Technology", "Sports"}
+	for i, category := range categories {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
+		params.Category = &category
+		_, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+	}
+
+	old := createSamplePost()
+	old.URL = "https://example.com/post-old"
+	publishedAt := time.Now().UTC().Add(-30 * 24 * time.Hour)
+	old.PublishedAt = &publishedAt
+	_, err := ts.repo.CreatePost(ctx, old)
+	require.NoError(t, err)
+
+	params := &model.SamplePostsParams{Since: time.Now().Add(-7 * 24 * time.Hour), Size: 2}
+	posts, err := ts.repo.SamplePosts(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+
+	var sampled []string
+	for _, post := range posts {
+		sampled = append(sampled, *post.Category)
+	}
+	assert.ElementsMatch(t, []string{"Technology", "Sports"}, sampled)
+
+	params.Size = 10
+	posts, err = ts.repo.SamplePosts(ctx, params)
+	require.NoError(t, err)
+	assert.Len(t, posts, 4)
+}
+
+func TestPostRepositoryPostStatus(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	published, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusPublished, published.Status)
+
+	params := createSamplePost()
+	params.URL = "https://example.com/draft"
+	params.Status = model.PostStatusDraft
+	draft, err := ts.repo.CreatePost(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusDraft, draft.Status)
+
+	publicList, err := ts.repo.ListPosts(ctx, &model.PostListParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, publicList, 1)
+
+	drafts, err := ts.repo.ListPosts(ctx, &model.PostListParams{Page: 1, Limit: 10, Status: model.PostStatusDraft})
+	require.NoError(t, err)
+	require.Len(t, drafts, 1)
+	assert.Equal(t, draft.ID, drafts[0].ID)
+
+	all, err := ts.repo.CountPosts(ctx, model.PostStatusAny)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), all)
+
+	hidden, err := ts.repo.UpdatePostStatus(ctx, published.ID, model.PostStatusHidden)
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusHidden, hidden.Status)
+	assert.Equal(t, published.Version+1, hidden.Version)
+
+	count, err := ts.repo.CountPosts(ctx, model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestPostRepositoryListPosts(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	for i := 0; i < 5; i++ {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
+		params.Title = fmt.Sprintf("Post %d", i)
+		_, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+	}
+
+	listParams := &model.PostListParams{
+		Page:  1,
 		Limit: 3,
 	}
 
@@ -446,6 +1259,132 @@ func TestPostRepositoryListPosts(t *testing.T) {
 	assert.Equal(t, posts[0].ID, posts3[0].ID)
 }
 
+func TestPostRepositoryListPostsSafeMode(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	for i := 0; i < 4; i++ {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
+		params.Sensitive = i%2 == 0
+		_, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+	}
+
+	listParams := &model.PostListParams{Page: 1, Limit: 10, SafeMode: true}
+
+	posts, err := ts.repo.ListPosts(ctx, listParams)
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+	for _, post := range posts {
+		assert.False(t, post.Sensitive)
+	}
+
+	count, err := ts.repo.CountSafePosts(ctx, listParams)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	listParams.SafeMode = false
+	posts, err = ts.repo.ListPosts(ctx, listParams)
+	require.NoError(t, err)
+	assert.Len(t, posts, 4)
+}
+
+func TestPostRepositoryCountSafePostsMatchesList(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	sources := []string{"Wire", "Daily"}
+	countries := []string{"us", "gb"}
+	for i := 0; i < 8; i++ {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/safe-%d", i)
+		params.Title = fmt.Sprintf("Story %d", i)
+		if i < 3 {
+			params.Title = fmt.Sprintf("Election story %d", i)
+		}
+		category := []string{"politics", "sports"}[i%2]
+		params.Category = &category
+		params.Source = sources[i/2%2]
+		params.Country = &countries[i/4]
+		params.Sensitive = i == 0 || i == 5
+		_, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+	}
+
+	search, category, source, country, author := "election", "politics", "Wire", "us", "John Smith"
+	tests := []struct {
+		name   string
+		params model.PostListParams
+	}{
+		{"search", model.PostListParams{Search: &search}},
+		{"search in a country", model.PostListParams{Search: &search, Country: &country}},
+		{"category", model.PostListParams{Category: &category}},
+		{"source", model.PostListParams{Source: &source}},
+		{"country", model.PostListParams{Country: &country}},
+		{"author", model.PostListParams{Author: &author}},
+		{"category wins over country", model.PostListParams{Category: &category, Country: &country}},
+		{"source wins over country", model.PostListParams{Source: &source, Country: &country}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.Page, params.Limit, params.SafeMode = 1, 100, true
+
+			posts, err := ts.repo.ListPosts(ctx, &params)
+			require.NoError(t, err)
+
+			count, err := ts.repo.CountSafePosts(ctx, &params)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(posts)), count)
+		})
+	}
+}
+
+func TestPostRepositoryListPostsPeekAndEstimate(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	for i := 0; i < 5; i++ {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
+		_, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+	}
+
+	listParams := &model.PostListParams{Page: 2, Limit: 2, Peek: true}
+
+	posts, err := ts.repo.ListPosts(ctx, listParams)
+	require.NoError(t, err)
+	assert.Len(t, posts, 3)
+
+	listParams.Page = 3
+	posts, err = ts.repo.ListPosts(ctx, listParams)
+	require.NoError(t, err)
+	assert.Len(t, posts, 1)
+
+	_, err = ts.db.Exec(ctx, "ANALYZE posts")
+	require.NoError(t, err)
+
+	estimate, err := ts.repo.EstimatePosts(ctx, listParams)
+	require.NoError(t, err)
+	assert.Positive(t, estimate)
+
+	search := "no such post"
+	_, err = ts.repo.EstimatePosts(ctx, &model.PostListParams{Search: &search, SafeMode: true})
+	require.NoError(t, err)
+}
+
 func TestPostRepositoryListPostsByCategory(t *testing.T) {
 	ts := setupTestSuite(t)
 	defer ts.teardown(t)
@@ -515,6 +1454,243 @@ func TestPostRepositoryListPostsBySource(t *testing.T) {
 	}
 }
 
+func TestPostRepositoryFilteredListCaching(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	var technologyPost *model.Post
+	for i, category := range []string{"Technology", "Sports"} {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
+		params.Category = &category
+		post, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+		if category == "Technology" {
+			technologyPost = post
+		}
+	}
+
+	base := model.BasePostListParams{Limit: 10}
+	list := func(category string) []model.Post {
+		posts, err := ts.repo.ListPostsByCategory(ctx, &model.ListPostsByCategoryParams{BasePostListParams: base, Category: category})
+		require.NoError(t, err)
+		return posts
+	}
+	cached := func(key string) bool {
+		n, err := ts.redisClient.Exists(ctx, key).Result()
+		require.NoError(t, err)
+		return n == 1
+	}
+
+	assert.Len(t, list("Technology"), 1)
+	assert.Len(t, list("Sports"), 1)
+	_, err := ts.repo.ListPostsBySource(ctx, &model.ListPostsBySourceParams{BasePostListParams: base, Source: technologyPost.Source})
+	require.NoError(t, err)
+	assert.True(t, cached("posts:category:Technology:10:0"))
+	assert.True(t, cached("posts:category:Sports:10:0"))
+	assert.True(t, cached("posts:source:"+technologyPost.Source+":10:0"))
+
+	// Moving a post drops the lists of its old and new category and its
+	// source, but not those of unrelated categories
+	health := "Health"
+	_, err = ts.repo.UpdatePost(ctx, technologyPost.ID, &model.UpdatePostParams{
+		Title:    technologyPost.Title,
+		Category: &health,
+		Version:  technologyPost.Version,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, cached("posts:category:Technology:10:0"))
+	assert.False(t, cached("posts:source:"+technologyPost.Source+":10:0"))
+	assert.True(t, cached("posts:category:Sports:10:0"))
+	assert.Empty(t, list("Technology"))
+	assert.Len(t, list("Health"), 1)
+}
+
+func TestPostRepositoryBulkUpdateAndDelete(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	var ids []int64
+	for i, category := range []string{"Technology", "Sports", "Technology", "Technology", "Sports"} {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
+		params.Category = &category
+		post, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+		ids = append(ids, post.ID)
+	}
+
+	technology := "Technology"
+	hidden := model.PostStatusHidden
+	filter := model.BulkPostFilter{Category: &technology}
+	changes := &model.BulkPostChanges{Status: &hidden}
+
+	count, err := ts.repo.CountBulkPosts(ctx, &filter, changes)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	// Batches of two take two rounds to change the three posts, and a third
+	// to find nothing is left
+	batch := &model.BulkPostsBatchParams{BulkPostFilter: filter, Limit: 2}
+	total := 0
+	for {
+		lastID, updated, err := ts.repo.BulkUpdatePosts(ctx, batch, changes)
+		require.NoError(t, err)
+		total += updated
+		if lastID == 0 {
+			break
+		}
+		batch.AfterID = lastID
+	}
+	assert.Equal(t, 3, total)
+
+	post, err := ts.repo.GetPostByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusHidden, post.Status)
+
+	// Posts already hidden are not changed again
+	count, err = ts.repo.CountBulkPosts(ctx, &filter, changes)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// A domain matches the host of the post URL, but not a longer name
+	for domain, want := range map[string]int64{"example.com": 5, "ample.com": 0} {
+		count, err = ts.repo.CountBulkPosts(ctx, &model.BulkPostFilter{Domain: &domain}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, count, domain)
+	}
+
+	deleteFilter := model.BulkPostFilter{IDs: []int64{ids[1], ids[4]}}
+	count, err = ts.repo.CountBulkPosts(ctx, &deleteFilter, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	_, deleted, err := ts.repo.BulkDeletePosts(ctx, &model.BulkPostsBatchParams{BulkPostFilter: deleteFilter, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	_, err = ts.repo.GetPostByID(ctx, ids[1])
+	assert.Error(t, err)
+}
+
+func TestPostRepositoryListPostsByCountry(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	countries := []string{"us", "gb", "us"}
+	for i, country := range countries {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
+		params.Country = &country
+		_, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+	}
+
+	posts, err := ts.repo.ListPostsByCountry(ctx, &model.ListPostsByCountryParams{
+		BasePostListParams: model.BasePostListParams{Limit: 10},
+		Country:            "US",
+	})
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	for _, post := range posts {
+		require.NotNil(t, post.Country)
+		assert.Equal(t, "us", *post.Country)
+	}
+
+	count, err := ts.repo.CountPostsByCountry(ctx, "gb", model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestPostRepositoryCountPostsBySourceAndSearch(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	posts := []struct{ title, source, country string }{
+		{"Election night", "Wire", "us"},
+		{"Election recount", "Daily", "gb"},
+		{"Cup final", "Wire", "gb"},
+	}
+	for i, post := range posts {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
+		params.Title = post.title
+		params.Source = post.source
+		params.Country = &post.country
+		_, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+	}
+
+	count, err := ts.repo.CountPostsBySource(ctx, "Wire", model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = ts.repo.CountSearchPosts(ctx, &model.SearchPostsParams{Query: "election"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	country := "GB"
+	count, err = ts.repo.CountSearchPosts(ctx, &model.SearchPostsParams{Query: "election", Country: &country})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestPostRepositoryListPostsByAuthor(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	authors := []string{"Jane Doe", "John Smith", "Jane Doe"}
+	for i, author := range authors {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/post-%d", i)
+		params.Author = &author
+		_, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+	}
+
+	posts, err := ts.repo.ListPostsByAuthor(ctx, &model.ListPostsByAuthorParams{
+		BasePostListParams: model.BasePostListParams{Limit: 10},
+		Author:             "Jane Doe",
+	})
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	for _, post := range posts {
+		require.NotNil(t, post.Author)
+		assert.Equal(t, "Jane Doe", *post.Author)
+	}
+
+	count, err := ts.repo.CountPostsByAuthor(ctx, "John Smith", model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// Searches match authors as well as titles and descriptions
+	found, err := ts.repo.SearchPosts(ctx, &model.SearchPostsParams{
+		BasePostListParams: model.BasePostListParams{Limit: 10},
+		Query:              "smith",
+	})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "John Smith", *found[0].Author)
+}
+
 func TestPostRepositorySearchPosts(t *testing.T) {
 	ts := setupTestSuite(t)
 	defer ts.teardown(t)
@@ -560,6 +1736,90 @@ func TestPostRepositorySearchPosts(t *testing.T) {
 	}
 }
 
+func TestPostRepositorySearchFacets(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	business := "Business"
+	day := time.Date(2025, 8, 11, 9, 0, 0, 0, time.UTC)
+	testData := []struct {
+		title    string
+		source   string
+		category *string
+	}{
+		{"Go 1.25 released", "TechCrunch", nil},
+		{"Go in production", "Wired", nil},
+		{"Go startups raise funding", "TechCrunch", &business},
+		{"Python 4 rumours", "Wired", nil},
+	}
+
+	for i, data := range testData {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/facet-%d", i)
+		params.Title = data.title
+		params.Source = data.source
+		if data.category != nil {
+			params.Category = data.category
+		}
+		publishedAt := day.Add(-time.Duration(i) * 24 * time.Hour)
+		params.PublishedAt = &publishedAt
+		_, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+	}
+
+	facets, err := ts.repo.SearchFacets(ctx, &model.SearchPostsParams{Query: "Go"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []model.FacetCount{{Value: "Technology", Count: 2}, {Value: "Business", Count: 1}}, facets.Categories)
+	assert.Equal(t, []model.FacetCount{{Value: "TechCrunch", Count: 2}, {Value: "Wired", Count: 1}}, facets.Sources)
+	assert.Equal(t, []model.FacetCount{
+		{Value: "2025-08-11", Count: 1},
+		{Value: "2025-08-10", Count: 1},
+		{Value: "2025-08-09", Count: 1},
+	}, facets.Days)
+}
+
+func TestPostRepositoryHighlightPosts(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	withDescription := createSamplePost()
+	withDescription.URL = "https://example.com/highlight-1"
+	withDescription.Title = "Go 1.25 released"
+	description := "The Go team shipped a new release"
+	withDescription.Description = &description
+	first, err := ts.repo.CreatePost(ctx, withDescription)
+	require.NoError(t, err)
+
+	withoutDescription := createSamplePost()
+	withoutDescription.URL = "https://example.com/highlight-2"
+	withoutDescription.Title = "Why we chose go"
+	withoutDescription.Description = nil
+	second, err := ts.repo.CreatePost(ctx, withoutDescription)
+	require.NoError(t, err)
+
+	highlights, err := ts.repo.HighlightPosts(ctx, &model.HighlightPostsParams{
+		IDs:      []int64{first.ID, second.ID},
+		Query:    "go",
+		StartSel: "[",
+		StopSel:  "]",
+	})
+	require.NoError(t, err)
+	require.Len(t, highlights, 2)
+
+	assert.Equal(t, "[Go] 1.25 released", highlights[first.ID].Title)
+	require.NotNil(t, highlights[first.ID].Description)
+	assert.Contains(t, *highlights[first.ID].Description, "The [Go] team")
+	assert.Equal(t, "Why we chose [go]", highlights[second.ID].Title)
+	assert.Nil(t, highlights[second.ID].Description)
+}
+
 func TestPostRepositoryListPostsWithFilters(t *testing.T) {
 	ts := setupTestSuite(t)
 	defer ts.teardown(t)
@@ -618,3 +1878,241 @@ func contains(s, substr string) bool {
 	}
 	return strings.Contains(s, substr)
 }
+
+func TestPostRepositoryCountPostsStaleWhileRevalidate(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	repo := NewPostRepository(ts.db, nil, ts.redisClient, ts.logger, config.CacheConfig{
+		TTL:        time.Minute,
+		SWREnabled: true,
+		SoftTTL:    50 * time.Millisecond,
+		HardTTL:    time.Minute,
+	})
+
+	_, err := repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	count, err := repo.CountPosts(ctx, model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// Insert behind the repository's back so the cached count goes stale
+	_, err = ts.db.Exec(ctx, `INSERT INTO posts (title, url, source) VALUES ('Direct', 'https://example.com/direct', 'Test')`)
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	count, err = repo.CountPosts(ctx, model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "stale value should be served while revalidating")
+
+	assert.Eventually(t, func() bool {
+		count, err := repo.CountPosts(ctx, model.PostStatusPublished)
+		return err == nil && count == 2
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestPostRepositoryListSitemapEntries(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	var ids []int64
+	for i := range 3 {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/sitemap-%d", i)
+		post, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+		ids = append(ids, post.ID)
+	}
+
+	entries, err := ts.repo.ListSitemapEntries(ctx, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, ids[0], entries[0].PostID)
+	assert.Equal(t, ids[1], entries[1].PostID)
+	assert.False(t, entries[0].UpdatedAt.IsZero())
+
+	entries, err = ts.repo.ListSitemapEntries(ctx, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ids[2], entries[0].PostID)
+}
+
+func TestPostRepositoryPostStats(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	day := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+	for i, post := range []struct {
+		category, source string
+		createdAt        time.Time
+	}{
+		{"technology", "Wired", day.Add(time.Hour)},
+		{"technology", "Verge", day.Add(2 * time.Hour)},
+		{"", "Wired", day.Add(3 * time.Hour)},
+		{"sports", "ESPN", day.Add(25 * time.Hour)},
+		{"sports", "ESPN", day.Add(49 * time.Hour)},
+	} {
+		_, err := ts.db.Exec(ctx,
+			`INSERT INTO posts (title, url, source, category, created_at) VALUES ('Stats', $1, $2, $3, $4)`,
+			fmt.Sprintf("https://example.com/stats-%d", i), post.source, post.category, post.createdAt)
+		require.NoError(t, err)
+	}
+
+	buckets, err := ts.repo.PostStats(ctx, model.PostStatsGroupByCategory, day, day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	assert.Equal(t, []model.PostStatsBucket{
+		{Day: "2025-08-10", Key: "technology", Count: 2},
+		{Day: "2025-08-10", Key: "uncategorized", Count: 1},
+		{Day: "2025-08-11", Key: "sports", Count: 1},
+	}, buckets)
+
+	buckets, err = ts.repo.PostStats(ctx, model.PostStatsGroupBySource, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []model.PostStatsBucket{
+		{Day: "2025-08-10", Key: "Wired", Count: 2},
+		{Day: "2025-08-10", Key: "Verge", Count: 1},
+	}, buckets)
+
+	buckets, err = ts.repo.PostStats(ctx, model.PostStatsGroupByDay, day, day.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	assert.Equal(t, []model.PostStatsBucket{
+		{Day: "2025-08-10", Count: 3},
+		{Day: "2025-08-11", Count: 1},
+		{Day: "2025-08-12", Count: 1},
+	}, buckets)
+}
+
+func TestPostRepositoryIncrementPostViewsDedupesViewers(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	post, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	ts.repo.SetViewerDedupWindow(time.Minute)
+	defer ts.repo.SetViewerDedupWindow(0)
+
+	for range 3 {
+		require.NoError(t, ts.repo.IncrementPostViews(ctx, post.ID, "user:1"))
+	}
+	require.NoError(t, ts.repo.IncrementPostViews(ctx, post.ID, "user:2"))
+	// Views without a viewer cannot be told apart and always count
+	require.NoError(t, ts.repo.IncrementPostViews(ctx, post.ID, ""))
+	require.NoError(t, ts.repo.IncrementPostViews(ctx, post.ID, ""))
+
+	views, err := ts.repo.GetPostViews(ctx, []int64{post.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), views[post.ID])
+}
+
+func TestPostRepositoryCountsFromView(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+	defer ts.repo.SetViewStaleTolerance(0)
+
+	views := NewViewRepository(ts.db, ts.logger)
+
+	day := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+	insert := func(i int, category string, createdAt time.Time) {
+		_, err := ts.db.Exec(ctx,
+			`INSERT INTO posts (title, url, source, category, created_at) VALUES ('Counts', $1, 'Wired', $2, $3)`,
+			fmt.Sprintf("https://example.com/counts-%d", i), category, createdAt)
+		require.NoError(t, err)
+	}
+	insert(0, "technology", day.Add(time.Hour))
+	insert(1, "technology", day.Add(26*time.Hour))
+	insert(2, "sports", day.Add(27*time.Hour))
+
+	require.NoError(t, views.RefreshPostCounts(ctx))
+	ts.repo.SetViewStaleTolerance(time.Hour)
+
+	// Posts added after the refresh are only seen by live queries
+	insert(3, "technology", day.Add(28*time.Hour))
+
+	count, err := ts.repo.CountPostsByCategory(ctx, "technology", "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// Whole days come from the view, the partial last day from posts
+	buckets, err := ts.repo.PostStats(ctx, model.PostStatsGroupByCategory, day, day.Add(27*time.Hour+30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []model.PostStatsBucket{
+		{Day: "2025-08-10", Key: "technology", Count: 1},
+		{Day: "2025-08-11", Key: "sports", Count: 1},
+		{Day: "2025-08-11", Key: "technology", Count: 1},
+	}, buckets)
+
+	// A stale view is bypassed
+	_, err = ts.db.Exec(ctx, `UPDATE materialized_view_refreshes SET refreshed_at = NOW() - INTERVAL '2 hours'`)
+	require.NoError(t, err)
+
+	count, err = ts.repo.CountPostsByCategory(ctx, "technology", "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestValidateStatements(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	err := ValidateStatements(context.Background(), ts.db)
+	assert.NoError(t, err)
+}
+
+func TestUnitOfWorkRollback(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	uow := NewUnitOfWork(ts.db, ts.logger)
+
+	err := uow.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := ts.repo.CreatePost(ctx, createSamplePost())
+		require.NoError(t, err)
+		return fmt.Errorf("abort")
+	})
+	assert.EqualError(t, err, "abort")
+
+	count, err := ts.repo.CountPosts(ctx, model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestUnitOfWorkCommit(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	defer ts.cleanupData(ctx)
+
+	uow := NewUnitOfWork(ts.db, ts.logger)
+
+	err := uow.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := ts.repo.CreatePost(ctx, createSamplePost())
+		return err
+	})
+	require.NoError(t, err)
+
+	count, err := ts.repo.CountPosts(ctx, model.PostStatusPublished)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// quarantineRepository implements QuarantineRepository interface
+type quarantineRepository struct {
+	db     *pgxpool.Pool
+	logger *logger.Logger
+}
+
+// NewQuarantineRepository creates a new quarantine repository
+func NewQuarantineRepository(db *pgxpool.Pool, logger *logger.Logger) QuarantineRepository {
+	return &quarantineRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// QuarantineArticle stores a rejected article for review. An article that is
+// already quarantined keeps its first entry.
+func (r *quarantineRepository) QuarantineArticle(ctx context.Context, article *model.QuarantinedArticle) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO quarantined_articles (url, title, source, rule, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, url) DO NOTHING
+	`
+	_, err := r.db.Exec(ctx, query, article.URL, article.Title, article.Source, article.Rule, article.Reason)
+	if err != nil {
+		r.logger.LogDBOperation("quarantine_article", "quarantined_articles", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to quarantine article: %w", err)
+	}
+
+	r.logger.LogDBOperation("quarantine_article", "quarantined_articles", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// ListQuarantined returns quarantined articles, newest first
+func (r *quarantineRepository) ListQuarantined(ctx context.Context, limit, offset int) ([]model.QuarantinedArticle, error) {
+	start := time.Now()
+
+	query := `
+		SELECT id, url, title, source, rule, reason, created_at
+		FROM quarantined_articles
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		r.logger.LogDBOperation("list_quarantined", "quarantined_articles", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list quarantined articles: %w", err)
+	}
+	defer rows.Close()
+
+	articles := []model.QuarantinedArticle{}
+	for rows.Next() {
+		var article model.QuarantinedArticle
+		if err := rows.Scan(&article.ID, &article.URL, &article.Title, &article.Source, &article.Rule, &article.Reason, &article.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan quarantined article: %w", err)
+		}
+		articles = append(articles, article)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("list_quarantined", "quarantined_articles", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate quarantined articles: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_quarantined", "quarantined_articles", time.Since(start).Milliseconds(), nil)
+
+	return articles, nil
+}
+
+// CountQuarantined returns the number of quarantined articles
+func (r *quarantineRepository) CountQuarantined(ctx context.Context) (int64, error) {
+	start := time.Now()
+
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM quarantined_articles`).Scan(&count)
+	if err != nil {
+		r.logger.LogDBOperation("count_quarantined", "quarantined_articles", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count quarantined articles: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_quarantined", "quarantined_articles", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantineRepositoryQuarantineAndList(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	quarantine := NewQuarantineRepository(ts.db, ts.logger)
+
+	source := "Example News"
+	article := &model.QuarantinedArticle{
+		URL:    "https://spam.example.com/article",
+		Title:  "You won't believe this",
+		Source: &source,
+		Rule:   model.FilterRuleClickbait,
+		Reason: "title matches clickbait phrase",
+	}
+	require.NoError(t, quarantine.QuarantineArticle(ctx, article))
+
+	// Quarantining the same URL again keeps a single entry
+	require.NoError(t, quarantine.QuarantineArticle(ctx, article))
+
+	count, err := quarantine.CountQuarantined(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	articles, err := quarantine.ListQuarantined(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, articles, 1)
+	assert.Equal(t, article.URL, articles[0].URL)
+	assert.Equal(t, model.FilterRuleClickbait, articles[0].Rule)
+	assert.Equal(t, source, *articles[0].Source)
+}
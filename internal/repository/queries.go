@@ -0,0 +1,606 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postColumns is the column list every post query selects, in scan order
+const postColumns = `id, title, description, content, url, source, author, category, country, image_url, published_at, created_at, updated_at, version, status, sensitive, comment_count, reaction_count, topic_id`
+
+// bulkFilter selects the posts of a bulk operation by ID list, source,
+// category, publication date range and URL domain, each left off when NULL.
+// Stored URLs are normalized, so their host is already lowercase; prefixing
+// it with a dot matches the domain and its subdomains alike.
+const bulkFilter = `($1::bigint[] IS NULL OR id = ANY($1))
+		AND ($2::text IS NULL OR source = $2)
+		AND ($3::text IS NULL OR category = $3)
+		AND (published_at >= COALESCE($4::timestamp, '-infinity') AND published_at <= COALESCE($5::timestamp, 'infinity')
+			OR published_at IS NULL AND $4::timestamp IS NULL AND $5::timestamp IS NULL)
+		AND ($6::text IS NULL OR right('.' || substring(url from '^[a-z][a-z0-9+.-]*://([^/:?#]+)'), length($6) + 1) = '.' || $6)`
+
+// bulkChanged matches the posts a bulk update would change
+const bulkChanged = `($7::text IS NOT NULL AND status IS DISTINCT FROM $7
+			OR $8::text IS NOT NULL AND category IS DISTINCT FROM $8
+			OR $9::boolean IS NOT NULL AND sensitive IS DISTINCT FROM $9)`
+
+// reprocessFilter is shared by the reprocess list and count queries. The
+// date range is spelled out so the planner can prune the posts partitions
+// outside it; posts without a publication date only match an open range.
+const reprocessFilter = `(published_at >= COALESCE($1::timestamp, '-infinity') AND published_at <= COALESCE($2::timestamp, 'infinity')
+			OR published_at IS NULL AND $1::timestamp IS NULL AND $2::timestamp IS NULL)
+		AND ($3::text IS NULL OR category = $3)
+		AND (NOT $4 OR content_extracted_at IS NULL)`
+
+// collapseFilter opens the check that no earlier post of the same topic, in
+// the same state, is also listed. List queries close it after adding their
+// own conditions on member, so each topic collapses to its first match.
+const collapseFilter = `topic_id IS NULL OR NOT EXISTS (
+			SELECT 1 FROM posts AS member
+			WHERE member.topic_id = posts.topic_id AND member.id < posts.id AND member.status = posts.status`
+
+// Post queries. pgx prepares and caches each statement per connection on first use.
+const (
+	queryCreatePost = `
+		INSERT INTO posts (title, description, content, url, source, category, country, image_url, published_at, sensitive, status, author)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING ` + postColumns
+
+	// queryUpsertPost refreshes an existing post only when the incoming article
+	// is newer and its content differs, so no row is returned for a stale or
+	// identical article. posts is partitioned and has no unique key on url to
+	// conflict on, so the stored post is found through post_urls.
+	queryUpsertPost = `
+		WITH existing AS (
+			SELECT post_id FROM post_urls WHERE url = $4
+		), updated AS (
+			UPDATE posts
+			SET title = $1, description = $2, content = $3, image_url = $8, published_at = $9, sensitive = $10, author = $11,
+				version = version + 1, updated_at = NOW()
+			WHERE id = (SELECT post_id FROM existing) AND (published_at IS NULL OR $9::timestamp > published_at)
+				AND (content_hash IS DISTINCT FROM post_content_hash($1, $2, $3, category, $8) OR author IS DISTINCT FROM $11)
+			RETURNING ` + postColumns + `
+		), inserted AS (
+			INSERT INTO posts (title, description, content, url, source, category, country, image_url, published_at, sensitive, author)
+			SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+			WHERE NOT EXISTS (SELECT 1 FROM existing)
+			RETURNING ` + postColumns + `
+		)
+		SELECT ` + postColumns + ` FROM updated
+		UNION ALL
+		SELECT ` + postColumns + ` FROM inserted`
+
+	queryGetPostByURL = `
+		SELECT ` + postColumns + ` FROM posts
+		WHERE id = (SELECT post_id FROM post_urls WHERE url = $1) LIMIT 1`
+
+	queryPostExistsByURL = `SELECT 1 FROM post_urls WHERE url = $1 LIMIT 1`
+
+	queryListPostURLs = `SELECT url FROM post_urls`
+
+	queryGetPostByID = `SELECT ` + postColumns + ` FROM posts WHERE id = $1 LIMIT 1`
+
+	// queryUpdatePost writes a post only when something changed; otherwise the
+	// post is returned as stored, still at the version given. Either way the
+	// category the post had before is returned too, so the lists of both
+	// categories can be invalidated when it moves
+	queryUpdatePost = `
+		WITH previous AS (
+			SELECT category FROM posts WHERE id = $1
+		), updated AS (
+			UPDATE posts
+			SET title = $2, description = $3, content = $4, category = $5, image_url = $6, sensitive = $8, author = $9,
+				version = version + 1, updated_at = NOW()
+			WHERE id = $1 AND version = $7
+				AND (content_hash IS DISTINCT FROM post_content_hash($2, $3, $4, $5, $6) OR sensitive IS DISTINCT FROM $8
+					OR author IS DISTINCT FROM $9)
+			RETURNING ` + postColumns + `
+		)
+		SELECT ` + postColumns + `, (SELECT category FROM previous) FROM updated
+		UNION ALL
+		SELECT ` + postColumns + `, category FROM posts
+		WHERE id = $1 AND version = $7 AND NOT EXISTS (SELECT 1 FROM updated)`
+
+	queryDeletePost = `DELETE FROM posts WHERE id = $1 RETURNING category, source`
+
+	// queryUpdatePostStatus moves a post to another state; publishing a post
+	// that never had a publication date stamps it with the current time
+	queryUpdatePostStatus = `
+		UPDATE posts
+		SET status = $2,
+			published_at = CASE WHEN $2 = 'published' THEN COALESCE(published_at, NOW()) ELSE published_at END,
+			version = version + 1, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + postColumns
+
+	// List queries take a safe mode flag that, when true, excludes sensitive
+	// posts, a popular flag that orders by reaction count first, and a status
+	// that, when NULL, matches every state
+	queryListPosts = `
+		SELECT ` + postColumns + ` FROM posts
+		WHERE NOT ($3 AND sensitive) AND ($5::text IS NULL OR status = $5)
+			AND (NOT $6 OR ` + collapseFilter + ` AND NOT ($3 AND member.sensitive)))
+		ORDER BY CASE WHEN $4 THEN reaction_count ELSE 0 END DESC, published_at DESC LIMIT $1 OFFSET $2`
+
+	// querySamplePosts samples $2 of the published posts since $1 at random,
+	// taking one post of every category before a second of any so that busy
+	// categories do not crowd out the rest
+	querySamplePosts = `
+		SELECT ` + postColumns + ` FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY COALESCE(category, '') ORDER BY random()) AS pick
+			FROM posts
+			WHERE status = 'published' AND published_at >= $1 AND NOT ($3 AND sensitive)
+		) sampled
+		ORDER BY pick, random() LIMIT $2`
+
+	queryListPostsByCategory = `
+		SELECT ` + postColumns + ` FROM posts
+		WHERE category = $1 AND NOT ($4 AND sensitive) AND ($6::text IS NULL OR status = $6)
+			AND (NOT $7 OR ` + collapseFilter + ` AND member.category = $1 AND NOT ($4 AND member.sensitive)))
+		ORDER BY CASE WHEN $5 THEN reaction_count ELSE 0 END DESC, published_at DESC LIMIT $2 OFFSET $3`
+
+	queryListPostsBySource = `
+		SELECT ` + postColumns + ` FROM posts
+		WHERE source = $1 AND NOT ($4 AND sensitive) AND ($6::text IS NULL OR status = $6)
+			AND (NOT $7 OR ` + collapseFilter + ` AND member.source = $1 AND NOT ($4 AND member.sensitive)))
+		ORDER BY CASE WHEN $5 THEN reaction_count ELSE 0 END DESC, published_at DESC LIMIT $2 OFFSET $3`
+
+	queryListPostsByCountry = `
+		SELECT ` + postColumns + ` FROM posts
+		WHERE country = $1 AND NOT ($4 AND sensitive) AND ($6::text IS NULL OR status = $6)
+			AND (NOT $7 OR ` + collapseFilter + ` AND member.country = $1 AND NOT ($4 AND member.sensitive)))
+		ORDER BY CASE WHEN $5 THEN reaction_count ELSE 0 END DESC, published_at DESC LIMIT $2 OFFSET $3`
+
+	queryListPostsByAuthor = `
+		SELECT ` + postColumns + ` FROM posts
+		WHERE author = $1 AND NOT ($4 AND sensitive) AND ($6::text IS NULL OR status = $6)
+			AND (NOT $7 OR ` + collapseFilter + ` AND member.author = $1 AND NOT ($4 AND member.sensitive)))
+		ORDER BY CASE WHEN $5 THEN reaction_count ELSE 0 END DESC, published_at DESC LIMIT $2 OFFSET $3`
+
+	// Searches match the query anywhere in the title, description or author
+	querySearchPosts = `
+		SELECT ` + postColumns + ` FROM posts
+		WHERE (title ILIKE '%' || $1 || '%' OR description ILIKE '%' || $1 || '%' OR author ILIKE '%' || $1 || '%')
+			AND NOT ($4 AND sensitive)
+			AND ($6::text IS NULL OR status = $6) AND ($8::text IS NULL OR country = $8)
+			AND (NOT $7 OR ` + collapseFilter + `
+				AND (member.title ILIKE '%' || $1 || '%' OR member.description ILIKE '%' || $1 || '%'
+					OR member.author ILIKE '%' || $1 || '%')
+				AND NOT ($4 AND member.sensitive)
+				AND ($8::text IS NULL OR member.country = $8)))
+		ORDER BY CASE WHEN $5 THEN reaction_count ELSE 0 END DESC, published_at DESC LIMIT $2 OFFSET $3`
+
+	// querySearchFacets counts the posts matching a search, in country $6 if
+	// set, per category, source and publication day, keeping the $4 busiest
+	// categories and sources and the $5 most recent days
+	querySearchFacets = `
+		WITH matches AS (
+			SELECT category, source, published_at FROM posts
+			WHERE (title ILIKE '%' || $1 || '%' OR description ILIKE '%' || $1 || '%' OR author ILIKE '%' || $1 || '%')
+				AND NOT ($2 AND sensitive)
+				AND ($3::text IS NULL OR status = $3) AND ($6::text IS NULL OR country = $6)
+		), facets AS (
+			SELECT 'category' AS facet, COALESCE(NULLIF(category, ''), 'uncategorized') AS value, COUNT(*) AS count
+			FROM matches GROUP BY 2
+			UNION ALL
+			SELECT 'source', source, COUNT(*) FROM matches GROUP BY 2
+			UNION ALL
+			SELECT 'day', to_char(published_at, 'YYYY-MM-DD'), COUNT(*) FROM matches WHERE published_at IS NOT NULL GROUP BY 2
+		)
+		SELECT facet, value, count FROM (
+			SELECT facet, value, count, ROW_NUMBER() OVER (
+				PARTITION BY facet ORDER BY CASE WHEN facet = 'day' THEN value END DESC, count DESC, value
+			) AS rank
+			FROM facets
+		) ranked
+		WHERE rank <= CASE WHEN facet = 'day' THEN $5 ELSE $4 END
+		ORDER BY facet, rank`
+
+	// queryHighlightPosts marks the words of search $2 in the titles and
+	// descriptions of posts $1; $3 and $4 are the ts_headline options
+	queryHighlightPosts = `
+		SELECT id, ts_headline('simple', title, q, $3),
+			CASE WHEN description IS NULL THEN NULL ELSE ts_headline('simple', description, q, $4) END
+		FROM posts, plainto_tsquery('simple', $2) AS q
+		WHERE id = ANY($1)`
+
+	queryListPostsPendingContent = `
+		SELECT ` + postColumns + ` FROM posts
+		WHERE content_extracted_at IS NULL AND (cardinality($1::text[]) = 0 OR source = ANY($1))
+		ORDER BY created_at DESC LIMIT $2`
+
+	queryListPostURLsAfter = `SELECT id, url FROM posts WHERE id > $1 ORDER BY id LIMIT $2`
+
+	// queryRenamePostURL moves a post and its post_urls claim to a new URL
+	// unless another post already claims it, returning no row in that case
+	queryRenamePostURL = `
+		WITH claimed AS (
+			UPDATE post_urls SET url = $2
+			WHERE post_id = $1 AND NOT EXISTS (SELECT 1 FROM post_urls WHERE url = $2)
+			RETURNING post_id
+		)
+		UPDATE posts SET url = $2
+		WHERE id IN (SELECT post_id FROM claimed)
+		RETURNING id`
+
+	queryUpdatePostContent = `
+		UPDATE posts
+		SET content = COALESCE($2, content), content_extracted_at = NOW(), updated_at = NOW()
+		WHERE id = $1`
+
+	// queryListPostsForReprocess pages by ID through the posts matching an
+	// optional published date range, category and missing content filter
+	queryListPostsForReprocess = `
+		SELECT ` + postColumns + ` FROM posts
+		WHERE ` + reprocessFilter + ` AND id > $5
+		ORDER BY id LIMIT $6`
+
+	queryCountPostsForReprocess = `SELECT COUNT(*) FROM posts WHERE ` + reprocessFilter
+
+	// Bulk queries take the filter as $1 to $6 and, for updates, the changes
+	// as $7 to $9; a batch reports the last ID it scanned, NULL once none are
+	// left, and the IDs it changed
+	queryCountBulkPosts = `SELECT COUNT(*) FROM posts WHERE ` + bulkFilter
+
+	queryCountBulkUpdate = `SELECT COUNT(*) FROM posts WHERE ` + bulkFilter + ` AND ` + bulkChanged
+
+	queryBulkUpdatePosts = `
+		WITH batch AS (
+			SELECT id FROM posts WHERE ` + bulkFilter + ` AND id > $10
+			ORDER BY id LIMIT $11
+		), updated AS (
+			UPDATE posts
+			SET status = COALESCE($7, status),
+				published_at = CASE WHEN $7 = 'published' THEN COALESCE(published_at, NOW()) ELSE published_at END,
+				category = COALESCE($8, category),
+				sensitive = COALESCE($9, sensitive),
+				version = version + 1, updated_at = NOW()
+			FROM batch
+			WHERE posts.id = batch.id AND ` + bulkChanged + `
+			RETURNING posts.id
+		)
+		SELECT (SELECT MAX(id) FROM batch), ARRAY(SELECT id FROM updated)`
+
+	queryBulkDeletePosts = `
+		WITH batch AS (
+			SELECT id FROM posts WHERE ` + bulkFilter + ` AND id > $7
+			ORDER BY id LIMIT $8
+		), deleted AS (
+			DELETE FROM posts USING batch WHERE posts.id = batch.id
+			RETURNING posts.id
+		)
+		SELECT (SELECT MAX(id) FROM batch), ARRAY(SELECT id FROM deleted)`
+
+	queryUpdatePostSensitive = `UPDATE posts SET sensitive = $2, updated_at = NOW() WHERE id = $1 RETURNING category, source`
+
+	// queryAdjustCommentCount keeps the denormalized comment count in step with
+	// the comments table; it is not an edit, so version is left alone
+	queryAdjustCommentCount = `UPDATE posts SET comment_count = GREATEST(comment_count + $2, 0) WHERE id = $1 RETURNING category, source`
+
+	queryAdjustReactionCount = `UPDATE posts SET reaction_count = GREATEST(reaction_count + $2, 0) WHERE id = $1 RETURNING category, source`
+
+	queryCountPosts = `SELECT COUNT(*) FROM posts WHERE ($1::text IS NULL OR status = $1)`
+
+	// queryListSitemapEntries pages by ID so sitemap pages stay stable as new
+	// posts arrive
+	queryListSitemapEntries = `SELECT id, updated_at FROM posts WHERE status = 'published' ORDER BY id LIMIT $1 OFFSET $2`
+
+	queryCountPostsByCategory = `SELECT COUNT(*) FROM posts WHERE category = $1 AND ($2::text IS NULL OR status = $2)`
+
+	queryCountPostsBySource = `SELECT COUNT(*) FROM posts WHERE source = $1 AND ($2::text IS NULL OR status = $2)`
+
+	queryCountPostsByCountry = `SELECT COUNT(*) FROM posts WHERE country = $1 AND ($2::text IS NULL OR status = $2)`
+
+	queryCountPostsByAuthor = `SELECT COUNT(*) FROM posts WHERE author = $1 AND ($2::text IS NULL OR status = $2)`
+
+	// queryCountSearchPosts counts the posts querySearchPosts matches, in
+	// country $3 if set
+	queryCountSearchPosts = `
+		SELECT COUNT(*) FROM posts
+		WHERE (title ILIKE '%' || $1 || '%' OR description ILIKE '%' || $1 || '%' OR author ILIKE '%' || $1 || '%')
+			AND ($2::text IS NULL OR status = $2) AND ($3::text IS NULL OR country = $3)`
+
+	// queryCountCollapsedPosts counts a collapsed list, applying each filter
+	// that is set to the listed posts and the earlier members of their topics
+	queryCountCollapsedPosts = `
+		SELECT COUNT(*) FROM posts
+		WHERE NOT ($1 AND sensitive) AND ($2::text IS NULL OR category = $2) AND ($3::text IS NULL OR source = $3)
+			AND ($4::text IS NULL OR country = $4) AND ($7::text IS NULL OR author = $7)
+			AND ($5::text IS NULL OR title ILIKE '%' || $5 || '%' OR description ILIKE '%' || $5 || '%' OR author ILIKE '%' || $5 || '%')
+			AND ($6::text IS NULL OR status = $6)
+			AND (` + collapseFilter + `
+				AND NOT ($1 AND member.sensitive) AND ($2::text IS NULL OR member.category = $2)
+				AND ($3::text IS NULL OR member.source = $3) AND ($4::text IS NULL OR member.country = $4)
+				AND ($7::text IS NULL OR member.author = $7)
+				AND ($5::text IS NULL OR member.title ILIKE '%' || $5 || '%' OR member.description ILIKE '%' || $5 || '%'
+					OR member.author ILIKE '%' || $5 || '%')))`
+
+	// queryEstimatePosts estimates the posts of a list from the query plan
+	// instead of counting them. The filters are the CountCollapsedPosts ones
+	// without the collapsing, quoted into the planned query as literals.
+	queryEstimatePosts = `
+		SELECT count_estimate(format(
+			'SELECT 1 FROM posts
+			WHERE NOT (%1$L::boolean AND sensitive) AND (%2$L::text IS NULL OR category = %2$L)
+				AND (%3$L::text IS NULL OR source = %3$L) AND (%4$L::text IS NULL OR country = %4$L)
+				AND (%7$L::text IS NULL OR author = %7$L)
+				AND (%5$L::text IS NULL OR title ILIKE ''%%'' || %5$L || ''%%'' OR description ILIKE ''%%'' || %5$L || ''%%''
+					OR author ILIKE ''%%'' || %5$L || ''%%'')
+				AND (%6$L::text IS NULL OR status = %6$L)',
+			$1::boolean, $2::text, $3::text, $4::text, $5::text, $6::text, $7::text))`
+
+	// queryPostStats counts the posts created in [$1, $2) per day and, when $3
+	// names one, per category, source or country. The created_at index
+	// covering those columns lets it run as an index-only scan.
+	queryPostStats = `
+		SELECT to_char(created_at, 'YYYY-MM-DD') AS day,
+			CASE $3::text
+				WHEN 'category' THEN COALESCE(NULLIF(category, ''), 'uncategorized')
+				WHEN 'source' THEN source
+				WHEN 'country' THEN COALESCE(NULLIF(country, ''), 'unknown')
+				ELSE ''
+			END AS key,
+			COUNT(*)
+		FROM posts
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY 1, 2
+		ORDER BY 1, 3 DESC, 2`
+
+	// The post_counts_daily view has no row-level security, so its queries
+	// select the session's tenant themselves
+	queryPostCountsFresh = `
+		SELECT NOW() - refreshed_at <= make_interval(secs => $1)
+		FROM materialized_view_refreshes WHERE name = 'post_counts_daily'`
+
+	queryCountPostsFromView = `
+		SELECT COALESCE(SUM(posts), 0)::bigint FROM post_counts_daily
+		WHERE tenant_id = current_tenant() AND ($1::text IS NULL OR status = $1)`
+
+	queryCountPostsByCategoryFromView = `
+		SELECT COALESCE(SUM(posts), 0)::bigint FROM post_counts_daily
+		WHERE tenant_id = current_tenant() AND category = $1 AND ($2::text IS NULL OR status = $2)`
+
+	// queryPostStatsFromView is queryPostStats over whole days
+	queryPostStatsFromView = `
+		SELECT to_char(day, 'YYYY-MM-DD') AS day,
+			CASE $3::text
+				WHEN 'category' THEN COALESCE(NULLIF(category, ''), 'uncategorized')
+				WHEN 'source' THEN source
+				WHEN 'country' THEN COALESCE(NULLIF(country, ''), 'unknown')
+				ELSE ''
+			END AS key,
+			SUM(posts)::bigint
+		FROM post_counts_daily
+		WHERE tenant_id = current_tenant() AND day >= $1::date AND day < $2::date
+		GROUP BY 1, 2
+		ORDER BY 1, 3 DESC, 2`
+
+	// queryPostCounts counts the posts in state $1 per category and source,
+	// keeping the $2 busiest of each
+	queryPostCounts = `
+		WITH counts AS (
+			SELECT 'category' AS facet, COALESCE(NULLIF(category, ''), 'uncategorized') AS value, COUNT(*) AS count
+			FROM posts WHERE ($1::text IS NULL OR status = $1) GROUP BY 2
+			UNION ALL
+			SELECT 'source', source, COUNT(*) FROM posts WHERE ($1::text IS NULL OR status = $1) GROUP BY 2
+		)
+		SELECT facet, value, count FROM (
+			SELECT facet, value, count, ROW_NUMBER() OVER (PARTITION BY facet ORDER BY count DESC, value) AS rank
+			FROM counts
+		) ranked
+		WHERE rank <= $2
+		ORDER BY facet, rank`
+
+	// queryPostCountsFromView is queryPostCounts over post_counts_daily
+	queryPostCountsFromView = `
+		WITH counts AS (
+			SELECT 'category' AS facet, COALESCE(NULLIF(category, ''), 'uncategorized') AS value, SUM(posts)::bigint AS count
+			FROM post_counts_daily WHERE tenant_id = current_tenant() AND ($1::text IS NULL OR status = $1) GROUP BY 2
+			UNION ALL
+			SELECT 'source', source, SUM(posts)::bigint
+			FROM post_counts_daily WHERE tenant_id = current_tenant() AND ($1::text IS NULL OR status = $1) GROUP BY 2
+		)
+		SELECT facet, value, count FROM (
+			SELECT facet, value, count, ROW_NUMBER() OVER (PARTITION BY facet ORDER BY count DESC, value) AS rank
+			FROM counts
+		) ranked
+		WHERE rank <= $2
+		ORDER BY facet, rank`
+
+	// queryCountSafePosts counts the posts not flagged as sensitive, applying
+	// each filter that is set; the search matches like querySearchPosts
+	queryCountSafePosts = `
+		SELECT COUNT(*) FROM posts
+		WHERE NOT sensitive AND ($1::text IS NULL OR category = $1) AND ($2::text IS NULL OR source = $2)
+			AND ($3::text IS NULL OR country = $3) AND ($6::text IS NULL OR author = $6)
+			AND ($4::text IS NULL OR title ILIKE '%' || $4 || '%' OR description ILIKE '%' || $4 || '%' OR author ILIKE '%' || $4 || '%')
+			AND ($5::text IS NULL OR status = $5)`
+)
+
+// Names the post statements are prepared under on every pooled connection
+const (
+	stmtCreatePost                   = "create_post"
+	stmtUpsertPost                   = "upsert_post"
+	stmtGetPostByURL                 = "get_post_by_url"
+	stmtPostExistsByURL              = "post_exists_by_url"
+	stmtListPostURLs                 = "list_post_urls"
+	stmtGetPostByID                  = "get_post_by_id"
+	stmtUpdatePost                   = "update_post"
+	stmtDeletePost                   = "delete_post"
+	stmtUpdatePostStatus             = "update_post_status"
+	stmtListPosts                    = "list_posts"
+	stmtListPostsByCategory          = "list_posts_by_category"
+	stmtListPostsBySource            = "list_posts_by_source"
+	stmtListPostsByCountry           = "list_posts_by_country"
+	stmtListPostsByAuthor            = "list_posts_by_author"
+	stmtSearchPosts                  = "search_posts"
+	stmtSearchFacets                 = "search_facets"
+	stmtHighlightPosts               = "highlight_posts"
+	stmtListPostsPendingContent      = "list_posts_pending_content"
+	stmtListPostURLsAfter            = "list_post_urls_after"
+	stmtRenamePostURL                = "rename_post_url"
+	stmtUpdatePostContent            = "update_post_content"
+	stmtListPostsForReprocess        = "list_posts_for_reprocess"
+	stmtCountPostsForReprocess       = "count_posts_for_reprocess"
+	stmtCountBulkPosts               = "count_bulk_posts"
+	stmtCountBulkUpdate              = "count_bulk_update"
+	stmtBulkUpdatePosts              = "bulk_update_posts"
+	stmtBulkDeletePosts              = "bulk_delete_posts"
+	stmtUpdatePostSensitive          = "update_post_sensitive"
+	stmtAdjustCommentCount           = "adjust_comment_count"
+	stmtAdjustReactionCount          = "adjust_reaction_count"
+	stmtCountPosts                   = "count_posts"
+	stmtListSitemapEntries           = "list_sitemap_entries"
+	stmtCountPostsByCategory         = "count_posts_by_category"
+	stmtCountPostsBySource           = "count_posts_by_source"
+	stmtCountPostsByCountry          = "count_posts_by_country"
+	stmtCountPostsByAuthor           = "count_posts_by_author"
+	stmtCountSearchPosts             = "count_search_posts"
+	stmtCountSafePosts               = "count_safe_posts"
+	stmtCountCollapsedPosts          = "count_collapsed_posts"
+	stmtEstimatePosts                = "estimate_posts"
+	stmtPostStats                    = "post_stats"
+	stmtPostCountsFresh              = "post_counts_fresh"
+	stmtCountPostsFromView           = "count_posts_from_view"
+	stmtCountPostsByCategoryFromView = "count_category_from_view"
+	stmtPostStatsFromView            = "post_stats_from_view"
+	stmtPostCounts                   = "post_counts"
+	stmtSamplePosts                  = "sample_posts"
+	stmtPostCountsFromView           = "post_counts_from_view"
+)
+
+// postStatements maps the name of every post statement to its SQL
+var postStatements = map[string]string{
+	stmtCreatePost:                   queryCreatePost,
+	stmtUpsertPost:                   queryUpsertPost,
+	stmtGetPostByURL:                 queryGetPostByURL,
+	stmtPostExistsByURL:              queryPostExistsByURL,
+	stmtListPostURLs:                 queryListPostURLs,
+	stmtGetPostByID:                  queryGetPostByID,
+	stmtUpdatePost:                   queryUpdatePost,
+	stmtDeletePost:                   queryDeletePost,
+	stmtUpdatePostStatus:             queryUpdatePostStatus,
+	stmtListPosts:                    queryListPosts,
+	stmtListPostsByCategory:          queryListPostsByCategory,
+	stmtListPostsBySource:            queryListPostsBySource,
+	stmtListPostsByCountry:           queryListPostsByCountry,
+	stmtListPostsByAuthor:            queryListPostsByAuthor,
+	stmtSearchPosts:                  querySearchPosts,
+	stmtSearchFacets:                 querySearchFacets,
+	stmtHighlightPosts:               queryHighlightPosts,
+	stmtListPostsPendingContent:      queryListPostsPendingContent,
+	stmtListPostURLsAfter:            queryListPostURLsAfter,
+	stmtRenamePostURL:                queryRenamePostURL,
+	stmtUpdatePostContent:            queryUpdatePostContent,
+	stmtListPostsForReprocess:        queryListPostsForReprocess,
+	stmtCountPostsForReprocess:       queryCountPostsForReprocess,
+	stmtCountBulkPosts:               queryCountBulkPosts,
+	stmtCountBulkUpdate:              queryCountBulkUpdate,
+	stmtBulkUpdatePosts:              queryBulkUpdatePosts,
+	stmtBulkDeletePosts:              queryBulkDeletePosts,
+	stmtUpdatePostSensitive:          queryUpdatePostSensitive,
+	stmtAdjustCommentCount:           queryAdjustCommentCount,
+	stmtAdjustReactionCount:          queryAdjustReactionCount,
+	stmtCountPosts:                   queryCountPosts,
+	stmtListSitemapEntries:           queryListSitemapEntries,
+	stmtCountPostsByCategory:         queryCountPostsByCategory,
+	stmtCountPostsBySource:           queryCountPostsBySource,
+	stmtCountPostsByCountry:          queryCountPostsByCountry,
+	stmtCountPostsByAuthor:           queryCountPostsByAuthor,
+	stmtCountSearchPosts:             queryCountSearchPosts,
+	stmtCountSafePosts:               queryCountSafePosts,
+	stmtCountCollapsedPosts:          queryCountCollapsedPosts,
+	stmtEstimatePosts:                queryEstimatePosts,
+	stmtPostStats:                    queryPostStats,
+	stmtPostCountsFresh:              queryPostCountsFresh,
+	stmtCountPostsFromView:           queryCountPostsFromView,
+	stmtCountPostsByCategoryFromView: queryCountPostsByCategoryFromView,
+	stmtPostStatsFromView:            queryPostStatsFromView,
+	stmtPostCounts:                   queryPostCounts,
+	stmtSamplePosts:                  querySamplePosts,
+	stmtPostCountsFromView:           queryPostCountsFromView,
+}
+
+// Post statements are prepared on every pooled connection and run by name
+func init() {
+	database.PrepareQueries(postStatements)
+}
+
+// ValidateStatements checks that every repository statement prepares against
+// the database, so SQL or schema errors surface at startup instead of on
+// first use. The connection keeps them prepared.
+func ValidateStatements(ctx context.Context, db *pgxpool.Pool) error {
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	for name, sql := range postStatements {
+		if _, err := conn.Conn().Prepare(ctx, name, sql); err != nil {
+			return fmt.Errorf("invalid statement %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// scanPost scans a single row selected with postColumns, followed by any
+// extra columns into extra
+func scanPost(row pgx.Row, extra ...any) (*model.Post, error) {
+	var post model.Post
+
+	dest := []any{
+		&post.ID,
+		&post.Title,
+		&post.Description,
+		&post.Content,
+		&post.URL,
+		&post.Source,
+		&post.Author,
+		&post.Category,
+		&post.Country,
+		&post.ImageURL,
+		&post.PublishedAt,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+		&post.Version,
+		&post.Status,
+		&post.Sensitive,
+		&post.CommentCount,
+		&post.ReactionCount,
+		&post.TopicID,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+// collectPosts scans all rows selected with postColumns and closes rows
+func collectPosts(rows pgx.Rows) ([]model.Post, error) {
+	defer rows.Close()
+
+	var posts []model.Post
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post: %w", err)
+		}
+
+		posts = append(posts, *post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate posts: %w", err)
+	}
+
+	return posts, nil
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// reactionCountsKey is the Redis hash caching a post's reaction counts by type
+const reactionCountsKey = "post:reactions:%d"
+
+// reactionRepository implements ReactionRepository interface. Per-type counts
+// are cached in Redis; PostgreSQL stays the source of truth.
+type reactionRepository struct {
+	db       *pgxpool.Pool
+	replicas *database.ReplicaSet
+	redis    redis.UniversalClient
+	logger   *logger.Logger
+	ttl      time.Duration
+}
+
+// NewReactionRepository creates a new reaction repository
+func NewReactionRepository(db *pgxpool.Pool, replicas *database.ReplicaSet, redis redis.UniversalClient, logger *logger.Logger, ttl time.Duration) ReactionRepository {
+	return &reactionRepository{
+		db:       db,
+		replicas: replicas,
+		redis:    redis,
+		logger:   logger,
+		ttl:      ttl,
+	}
+}
+
+// UpsertReaction stores a user's reaction, replacing any earlier reaction of
+// theirs on the same post. It reports whether the reaction is new.
+func (r *reactionRepository) UpsertReaction(ctx context.Context, reaction *model.Reaction) (bool, error) {
+	start := time.Now()
+
+	// xmax is zero only for a freshly inserted row
+	query := `
+		INSERT INTO post_reactions (post_id, user_id, type)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, user_id) DO UPDATE SET type = EXCLUDED.type, updated_at = NOW()
+		RETURNING created_at, updated_at, xmax = 0
+	`
+	var inserted bool
+	err := r.conn(ctx).QueryRow(ctx, query, reaction.PostID, reaction.UserID, reaction.Type).
+		Scan(&reaction.CreatedAt, &reaction.UpdatedAt, &inserted)
+	if err != nil {
+		r.logger.LogDBOperation("upsert_reaction", "post_reactions", time.Since(start).Milliseconds(), err)
+		return false, fmt.Errorf("failed to save reaction: %w", err)
+	}
+
+	r.logger.LogDBOperation("upsert_reaction", "post_reactions", time.Since(start).Milliseconds(), nil)
+
+	afterCommit(ctx, func(ctx context.Context) {
+		r.invalidateCounts(ctx, reaction.PostID)
+	})
+
+	return inserted, nil
+}
+
+// DeleteReaction removes a user's reaction from a post. It reports false when
+// the user had not reacted.
+func (r *reactionRepository) DeleteReaction(ctx context.Context, postID int64, userID string) (bool, error) {
+	start := time.Now()
+
+	tag, err := r.conn(ctx).Exec(ctx, `DELETE FROM post_reactions WHERE post_id = $1 AND user_id = $2`, postID, userID)
+	if err != nil {
+		r.logger.LogDBOperation("delete_reaction", "post_reactions", time.Since(start).Milliseconds(), err)
+		return false, fmt.Errorf("failed to delete reaction: %w", err)
+	}
+
+	r.logger.LogDBOperation("delete_reaction", "post_reactions", time.Since(start).Milliseconds(), nil)
+
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	afterCommit(ctx, func(ctx context.Context) {
+		r.invalidateCounts(ctx, postID)
+	})
+
+	return true, nil
+}
+
+// GetReactionCounts returns per-type reaction counts for the given posts.
+// Posts without reactions map to an empty set of counts.
+func (r *reactionRepository) GetReactionCounts(ctx context.Context, postIDs []int64) (map[int64]map[string]int64, error) {
+	counts := make(map[int64]map[string]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return counts, nil
+	}
+
+	pipe := r.redis.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(postIDs))
+	for i, id := range postIDs {
+		cmds[i] = pipe.HGetAll(ctx, fmt.Sprintf(reactionCountsKey, id))
+	}
+	// A Redis failure only means every post is loaded from the database
+	pipe.Exec(ctx)
+
+	var misses []int64
+	for i, id := range postIDs {
+		fields, err := cmds[i].Result()
+		if err != nil || len(fields) == 0 {
+			misses = append(misses, id)
+			continue
+		}
+
+		counts[id] = make(map[string]int64)
+		for reactionType, value := range fields {
+			if count, err := strconv.ParseInt(value, 10, 64); err == nil && count > 0 {
+				counts[id][reactionType] = count
+			}
+		}
+	}
+	r.logger.LogCacheOperation("get", "post:reactions", len(misses) == 0)
+
+	if len(misses) == 0 {
+		return counts, nil
+	}
+
+	loaded, err := r.loadCounts(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every type is cached, zeros included, so a post without reactions is still a hit
+	pipe = r.redis.Pipeline()
+	for _, id := range misses {
+		counts[id] = loaded[id]
+
+		fields := make(map[string]any, len(model.ReactionTypes))
+		for _, reactionType := range model.ReactionTypes {
+			fields[string(reactionType)] = loaded[id][string(reactionType)]
+		}
+
+		key := fmt.Sprintf(reactionCountsKey, id)
+		pipe.HSet(ctx, key, fields)
+		pipe.Expire(ctx, key, r.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logger.Warn("Failed to cache reaction counts", "error", err.Error())
+	}
+
+	return counts, nil
+}
+
+// loadCounts reads per-type reaction counts from the database
+func (r *reactionRepository) loadCounts(ctx context.Context, postIDs []int64) (map[int64]map[string]int64, error) {
+	start := time.Now()
+
+	query := `
+		SELECT post_id, type, COUNT(*) FROM post_reactions
+		WHERE post_id = ANY($1)
+		GROUP BY post_id, type
+	`
+	rows, err := r.reader(ctx).Query(ctx, query, postIDs)
+	if err != nil {
+		r.logger.LogDBOperation("count_reactions", "post_reactions", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]map[string]int64, len(postIDs))
+	for _, id := range postIDs {
+		counts[id] = make(map[string]int64)
+	}
+
+	for rows.Next() {
+		var postID, count int64
+		var reactionType string
+		if err := rows.Scan(&postID, &reactionType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction count: %w", err)
+		}
+		counts[postID][reactionType] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("count_reactions", "post_reactions", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate reaction counts: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_reactions", "post_reactions", time.Since(start).Milliseconds(), nil)
+
+	return counts, nil
+}
+
+// invalidateCounts drops a post's cached reaction counts
+func (r *reactionRepository) invalidateCounts(ctx context.Context, postID int64) {
+	key := fmt.Sprintf(reactionCountsKey, postID)
+	r.redis.Del(ctx, key).Err()
+	r.logger.LogCacheOperation("delete", key, false)
+}
+
+// conn returns the transaction carried by ctx, or the primary pool
+func (r *reactionRepository) conn(ctx context.Context) querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx
+	}
+
+	return r.db
+}
+
+// reader returns the connection used for read-only queries; reads inside a
+// transaction stay on that transaction
+func (r *reactionRepository) reader(ctx context.Context) querier {
+	if state, ok := txFromContext(ctx); ok {
+		return state.tx
+	}
+
+	if r.replicas == nil {
+		return r.db
+	}
+
+	return r.replicas.Reader()
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReactionRepositoryOnePerUserAndCachedCounts(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	post, err := ts.repo.CreatePost(ctx, createSamplePost())
+	require.NoError(t, err)
+
+	reactions := NewReactionRepository(ts.db, nil, ts.redisClient, ts.logger, time.Minute)
+
+	inserted, err := reactions.UpsertReaction(ctx, &model.Reaction{PostID: post.ID, UserID: "user-1", Type: model.ReactionLike})
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	// Reacting again replaces the earlier reaction
+	inserted, err = reactions.UpsertReaction(ctx, &model.Reaction{PostID: post.ID, UserID: "user-1", Type: model.ReactionLove})
+	require.NoError(t, err)
+	assert.False(t, inserted)
+
+	_, err = reactions.UpsertReaction(ctx, &model.Reaction{PostID: post.ID, UserID: "user-2", Type: model.ReactionLove})
+	require.NoError(t, err)
+
+	counts, err := reactions.GetReactionCounts(ctx, []int64{post.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"love": 2}, counts[post.ID])
+
+	cached, err := ts.redisClient.HGet(ctx, fmt.Sprintf(reactionCountsKey, post.ID), "love").Result()
+	require.NoError(t, err)
+	assert.Equal(t, "2", cached)
+
+	deleted, err := reactions.DeleteReaction(ctx, post.ID, "user-2")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	counts, err = reactions.GetReactionCounts(ctx, []int64{post.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"love": 1}, counts[post.ID])
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/database"
 	"github.com/amirzre/news-feed-system/pkg/logger"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
@@ -13,26 +15,230 @@ import (
 // PostRepository defines the contract for post data operations
 type PostRepository interface {
 	CreatePost(ctx context.Context, params *model.CreatePostParams) (*model.Post, error)
+	UpsertPost(ctx context.Context, params *model.CreatePostParams) (*model.Post, error)
 	GetPostByURL(ctx context.Context, url string) (*model.Post, error)
+	ExistsByURL(ctx context.Context, url string) (bool, error)
 	GetPostByID(ctx context.Context, id int64) (*model.Post, error)
 	UpdatePost(ctx context.Context, id int64, params *model.UpdatePostParams) (*model.Post, error)
 	DeletePost(ctx context.Context, id int64) error
-	CountPosts(ctx context.Context) (int64, error)
-	CountPostsByCategory(ctx context.Context, category string) (int64, error)
+	UpdatePostStatus(ctx context.Context, id int64, status model.PostStatus) (*model.Post, error)
+	CountPosts(ctx context.Context, status model.PostStatus) (int64, error)
+	CountPostsByCategory(ctx context.Context, category string, status model.PostStatus) (int64, error)
+	CountPostsBySource(ctx context.Context, source string, status model.PostStatus) (int64, error)
+	CountPostsByCountry(ctx context.Context, country string, status model.PostStatus) (int64, error)
+	CountPostsByAuthor(ctx context.Context, author string, status model.PostStatus) (int64, error)
+	CountSearchPosts(ctx context.Context, params *model.SearchPostsParams) (int64, error)
+	CountSafePosts(ctx context.Context, params *model.PostListParams) (int64, error)
+	CountCollapsedPosts(ctx context.Context, params *model.PostListParams) (int64, error)
+	EstimatePosts(ctx context.Context, params *model.PostListParams) (int64, error)
+	PostCounts(ctx context.Context, status model.PostStatus) (*model.PostCounts, error)
+	SamplePosts(ctx context.Context, params *model.SamplePostsParams) ([]model.Post, error)
 	ListPosts(ctx context.Context, params *model.PostListParams) ([]model.Post, error)
 	ListPostsByCategory(ctx context.Context, params *model.ListPostsByCategoryParams) ([]model.Post, error)
 	ListPostsBySource(ctx context.Context, params *model.ListPostsBySourceParams) ([]model.Post, error)
+	ListPostsByCountry(ctx context.Context, params *model.ListPostsByCountryParams) ([]model.Post, error)
+	ListPostsByAuthor(ctx context.Context, params *model.ListPostsByAuthorParams) ([]model.Post, error)
 	SearchPosts(ctx context.Context, params *model.SearchPostsParams) ([]model.Post, error)
+	SearchFacets(ctx context.Context, params *model.SearchPostsParams) (*model.SearchFacets, error)
+	HighlightPosts(ctx context.Context, params *model.HighlightPostsParams) (map[int64]model.PostHighlight, error)
+	ListPostsPendingContent(ctx context.Context, params *model.ListPostsPendingContentParams) ([]model.Post, error)
+	UpdatePostContent(ctx context.Context, id int64, content *string) error
+	ListPostsForReprocess(ctx context.Context, params *model.ListPostsForReprocessParams) ([]model.Post, error)
+	CountPostsForReprocess(ctx context.Context, params *model.ReprocessParams) (int64, error)
+	CountBulkPosts(ctx context.Context, filter *model.BulkPostFilter, changes *model.BulkPostChanges) (int64, error)
+	BulkUpdatePosts(ctx context.Context, params *model.BulkPostsBatchParams, changes *model.BulkPostChanges) (int64, int, error)
+	BulkDeletePosts(ctx context.Context, params *model.BulkPostsBatchParams) (int64, int, error)
+	UpdatePostSensitive(ctx context.Context, id int64, sensitive bool) error
+	AdjustCommentCount(ctx context.Context, id int64, delta int) error
+	AdjustReactionCount(ctx context.Context, id int64, delta int) error
+	IncrementPostViews(ctx context.Context, id int64, viewer string) error
+	GetPostViews(ctx context.Context, ids []int64) (map[int64]int64, error)
+	ListSitemapEntries(ctx context.Context, limit, offset int) ([]model.SitemapEntry, error)
+	PostStats(ctx context.Context, groupBy string, from, to time.Time) ([]model.PostStatsBucket, error)
+	RebuildURLFilter(ctx context.Context) (int, error)
+	ListPostURLs(ctx context.Context, afterID int64, limit int) ([]model.PostURL, error)
+	RenamePostURL(ctx context.Context, id int64, url string) (bool, error)
+	SetCacheTTL(ttl time.Duration)
+	SetViewStaleTolerance(tolerance time.Duration)
+	SetViewerDedupWindow(window time.Duration)
+}
+
+// ExperimentRepository defines the contract for experiment data operations
+type ExperimentRepository interface {
+	SaveExperiment(ctx context.Context, experiment *model.Experiment) error
+	ListExperiments(ctx context.Context) ([]model.Experiment, error)
+	DeleteExperiment(ctx context.Context, name string) (bool, error)
+	RecordEvent(ctx context.Context, event *model.ExperimentEvent) error
+	GetResults(ctx context.Context, experiment string) ([]model.VariantResult, error)
+}
+
+// ClickRepository defines the contract for click-through data operations
+type ClickRepository interface {
+	RecordClick(ctx context.Context, click *model.PostClick) error
+	CountClicksByPost(ctx context.Context, since time.Time) ([]model.PostClickCount, error)
+}
+
+// SearchQueryRepository defines the contract for search analytics data operations
+type SearchQueryRepository interface {
+	RecordSearch(ctx context.Context, search *model.SearchQuery) error
+	CountSearches(ctx context.Context, since time.Time) (*model.SearchTotals, error)
+	TopSearchTerms(ctx context.Context, since time.Time, zeroResultsOnly bool, limit int) ([]model.SearchTermStat, error)
+}
+
+// QuarantineRepository defines the contract for rejected article storage
+type QuarantineRepository interface {
+	QuarantineArticle(ctx context.Context, article *model.QuarantinedArticle) error
+	ListQuarantined(ctx context.Context, limit, offset int) ([]model.QuarantinedArticle, error)
+	CountQuarantined(ctx context.Context) (int64, error)
+}
+
+// CommentRepository defines the contract for post comment data operations
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *model.Comment) error
+	GetComment(ctx context.Context, postID, id int64) (*model.Comment, error)
+	ListRootComments(ctx context.Context, postID int64, limit, offset int) ([]model.Comment, error)
+	CountRootComments(ctx context.Context, postID int64) (int64, error)
+	ListReplies(ctx context.Context, rootIDs []int64) ([]model.Comment, error)
+	SoftDeleteComment(ctx context.Context, postID, id int64) (bool, error)
+}
+
+// ReactionRepository defines the contract for post reaction data operations
+type ReactionRepository interface {
+	UpsertReaction(ctx context.Context, reaction *model.Reaction) (bool, error)
+	DeleteReaction(ctx context.Context, postID int64, userID string) (bool, error)
+	GetReactionCounts(ctx context.Context, postIDs []int64) (map[int64]map[string]int64, error)
+}
+
+// TopicRepository defines the contract for story clustering data operations
+type TopicRepository interface {
+	ListUnclusteredPosts(ctx context.Context, limit int) ([]model.TopicCandidate, error)
+	ListClusteredPosts(ctx context.Context, since time.Time, limit int) ([]model.TopicCandidate, error)
+	AssignTopics(ctx context.Context, assignments map[int64]int64) error
+	ListTopics(ctx context.Context, params *model.TopicListParams) ([]model.Topic, error)
+	CountTopics(ctx context.Context, params *model.TopicListParams) (int64, error)
+}
+
+// WatermarkRepository defines the contract for incremental fetch progress
+type WatermarkRepository interface {
+	GetWatermarks(ctx context.Context, scope model.FetchScope, keys []string) (map[string]time.Time, error)
+	SaveWatermarks(ctx context.Context, scope model.FetchScope, watermarks map[string]time.Time) error
+}
+
+// ChangeRepository defines the contract for the post change feed
+type ChangeRepository interface {
+	ListPostChanges(ctx context.Context, params *model.ListPostChangesParams) ([]model.PostChange, error)
+	PruneDeletions(ctx context.Context, before time.Time) (int64, error)
+}
+
+// DeadLetterRepository defines the contract for storing articles that failed to persist
+type DeadLetterRepository interface {
+	SaveDeadLetter(ctx context.Context, letter *model.DeadLetter) error
+	GetDeadLetter(ctx context.Context, id int64) (*model.DeadLetter, error)
+	ListDeadLetters(ctx context.Context, limit, offset int) ([]model.DeadLetter, error)
+	CountDeadLetters(ctx context.Context) (int64, error)
+	DeleteDeadLetter(ctx context.Context, id int64) (bool, error)
+}
+
+// PinRepository defines the contract for editorial post pin data operations
+type PinRepository interface {
+	SavePin(ctx context.Context, pin *model.PostPin) error
+	DeletePin(ctx context.Context, postID int64) (bool, error)
+	ListActivePins(ctx context.Context, maxPosition int) ([]model.PostPin, error)
+}
+
+// OnceJobRepository defines the contract for the queue of one-time jobs
+type OnceJobRepository interface {
+	SaveOnceJob(ctx context.Context, job *model.OnceJob) (bool, error)
+	ClaimDueOnceJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OnceJob, error)
+	RescheduleOnceJob(ctx context.Context, id string, at time.Time) error
+	DeleteOnceJob(ctx context.Context, id string) (bool, error)
+	ListOnceJobs(ctx context.Context) ([]model.OnceJob, error)
+	DeadLetterOnceJob(ctx context.Context, job *model.DeadOnceJob) error
+	ListDeadOnceJobs(ctx context.Context) ([]model.DeadOnceJob, error)
+	RequeueDeadOnceJob(ctx context.Context, id string, at time.Time) (*model.OnceJob, error)
+	DeleteDeadOnceJob(ctx context.Context, id string) (bool, error)
+}
+
+// ActivityRepository defines the contract for the hourly post activity rollup
+type ActivityRepository interface {
+	GetRollupWatermark(ctx context.Context) (*time.Time, error)
+	SaveRollupWatermark(ctx context.Context, until time.Time) error
+	RollupPostsAndClicks(ctx context.Context, since time.Time) error
+	GetHourlyViews(ctx context.Context, hour time.Time) (map[int64]int64, error)
+	SaveHourlyViews(ctx context.Context, hour time.Time, views map[int64]int64) error
+	PruneActivity(ctx context.Context, before time.Time) (int64, error)
+	TopActivity(ctx context.Context, metric string, since time.Time, limit int) ([]model.ActivityTotal, error)
+}
+
+// ViewRepository defines the contract for refreshing materialized views
+type ViewRepository interface {
+	RefreshPostCounts(ctx context.Context) error
+}
+
+// PartitionRepository defines the contract for maintaining table partitions
+type PartitionRepository interface {
+	EnsurePostPartitions(ctx context.Context, monthsAhead int) (int, error)
+}
+
+// TenantRepository defines the contract for tenant data operations
+type TenantRepository interface {
+	GetTenant(ctx context.Context, id string) (*model.Tenant, error)
+	ListTenants(ctx context.Context) ([]model.Tenant, error)
+	SaveTenant(ctx context.Context, tenant *model.Tenant) error
+	IncrementNewsAPIUsage(ctx context.Context, id string, day time.Time) (int64, error)
+}
+
+// SourceRuleRepository defines the contract for source blocklist and allowlist data operations
+type SourceRuleRepository interface {
+	ListSourceRules(ctx context.Context) ([]model.SourceRule, error)
+	SaveSourceRule(ctx context.Context, rule *model.SourceRule) error
+	DeleteSourceRule(ctx context.Context, id int64) (bool, error)
 }
 
 // Repository holds all repository implementations
 type Repository struct {
-	Post PostRepository
+	Post       PostRepository
+	Experiment ExperimentRepository
+	Click      ClickRepository
+	Search     SearchQueryRepository
+	Quarantine QuarantineRepository
+	Comment    CommentRepository
+	Reaction   ReactionRepository
+	Topic      TopicRepository
+	Watermark  WatermarkRepository
+	Activity   ActivityRepository
+	View       ViewRepository
+	Partition  PartitionRepository
+	Tenant     TenantRepository
+	SourceRule SourceRuleRepository
+	Change     ChangeRepository
+	OnceJob    OnceJobRepository
+	DeadLetter DeadLetterRepository
+	Pin        PinRepository
+	Tx         UnitOfWork
 }
 
 // New creates a new repository instance with all entity repositories
-func New(db *pgxpool.Pool, redis *redis.Client, logger *logger.Logger, cacheTTL time.Duration) *Repository {
+func New(db *pgxpool.Pool, replicas *database.ReplicaSet, redis redis.UniversalClient, logger *logger.Logger, cacheCfg config.CacheConfig) *Repository {
 	return &Repository{
-		Post: NewPostRepository(db, redis, logger, cacheTTL),
+		Post:       NewPostRepository(db, replicas, redis, logger, cacheCfg),
+		Experiment: NewExperimentRepository(db, redis, logger),
+		Click:      NewClickRepository(db, replicas, logger),
+		Search:     NewSearchQueryRepository(db, replicas, logger),
+		Quarantine: NewQuarantineRepository(db, logger),
+		Comment:    NewCommentRepository(db, replicas, logger),
+		Reaction:   NewReactionRepository(db, replicas, redis, logger, cacheCfg.TTL),
+		Topic:      NewTopicRepository(db, replicas, logger),
+		Watermark:  NewWatermarkRepository(db, logger),
+		Activity:   NewActivityRepository(db, replicas, redis, logger),
+		View:       NewViewRepository(db, logger),
+		Partition:  NewPartitionRepository(db, logger),
+		Tenant:     NewTenantRepository(db, redis, logger),
+		SourceRule: NewSourceRuleRepository(db, logger),
+		Change:     NewChangeRepository(db, logger),
+		OnceJob:    NewOnceJobRepository(redis, logger),
+		DeadLetter: NewDeadLetterRepository(db, logger),
+		Pin:        NewPinRepository(db, logger),
+		Tx:         NewUnitOfWork(db, logger),
 	}
 }
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SchemaVersion is the last migration this build was written against. Bump
+// it with every new migration in migrations/.
+const SchemaVersion int64 = 27
+
+// querySchemaVersion reads the version recorded by golang-migrate and newsctl
+const querySchemaVersion = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+// GetSchemaStatus compares the migration version of the database with
+// SchemaVersion. A database migrated past the build is compatible only when
+// allowNewer is set; a dirty one never is.
+func GetSchemaStatus(ctx context.Context, db *pgxpool.Pool, allowNewer bool) (*model.SchemaStatus, error) {
+	status := &model.SchemaStatus{ExpectedVersion: SchemaVersion}
+
+	err := db.QueryRow(ctx, querySchemaVersion).Scan(&status.CurrentVersion, &status.Dirty)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) && !isUndefinedTable(err) {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	status.Compatible = schemaCompatible(status.CurrentVersion, status.Dirty, allowNewer)
+
+	return status, nil
+}
+
+// schemaCompatible reports whether a build expecting SchemaVersion can run
+// against a database at version
+func schemaCompatible(version int64, dirty, allowNewer bool) bool {
+	if dirty {
+		return false
+	}
+
+	return version == SchemaVersion || (allowNewer && version > SchemaVersion)
+}
+
+// isUndefinedTable reports whether err is PostgreSQL's undefined_table error,
+// which schema_migrations gives before the first migration
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "42P01"
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaVersionMatchesMigrations(t *testing.T) {
+	entries, err := os.ReadDir("../../migrations")
+	require.NoError(t, err)
+
+	var latest int64
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok || !strings.HasSuffix(entry.Name(), ".up.sql") {
+			continue
+		}
+
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		require.NoError(t, err)
+		latest = max(latest, version)
+	}
+
+	assert.Equal(t, latest, SchemaVersion, "bump SchemaVersion with each new migration")
+}
+
+func TestSchemaCompatible(t *testing.T) {
+	assert.True(t, schemaCompatible(SchemaVersion, false, false))
+	assert.False(t, schemaCompatible(SchemaVersion, true, false))
+	assert.False(t, schemaCompatible(SchemaVersion-1, false, true))
+	assert.False(t, schemaCompatible(0, false, false))
+
+	assert.False(t, schemaCompatible(SchemaVersion+1, false, false))
+	assert.True(t, schemaCompatible(SchemaVersion+1, false, true))
+	assert.False(t, schemaCompatible(SchemaVersion+1, true, true))
+}
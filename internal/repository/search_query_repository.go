@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// searchQueryRepository implements SearchQueryRepository interface
+type searchQueryRepository struct {
+	db       *pgxpool.Pool
+	replicas *database.ReplicaSet
+	logger   *logger.Logger
+}
+
+// NewSearchQueryRepository creates a new search query repository
+func NewSearchQueryRepository(db *pgxpool.Pool, replicas *database.ReplicaSet, logger *logger.Logger) SearchQueryRepository {
+	return &searchQueryRepository{
+		db:       db,
+		replicas: replicas,
+		logger:   logger,
+	}
+}
+
+// RecordSearch stores a search term with its result count and latency
+func (r *searchQueryRepository) RecordSearch(ctx context.Context, search *model.SearchQuery) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO search_queries (term, result_count, latency_ms, zero_results)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRow(ctx, query, search.Term, search.ResultCount, search.LatencyMs, search.ZeroResults).Scan(&search.ID, &search.CreatedAt)
+	if err != nil {
+		r.logger.LogDBOperation("record_search", "search_queries", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to record search: %w", err)
+	}
+
+	r.logger.LogDBOperation("record_search", "search_queries", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// CountSearches returns the number of searches since the given time and how
+// many of them returned no results
+func (r *searchQueryRepository) CountSearches(ctx context.Context, since time.Time) (*model.SearchTotals, error) {
+	start := time.Now()
+
+	query := `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE zero_results)
+		FROM search_queries
+		WHERE created_at >= $1
+	`
+
+	var totals model.SearchTotals
+	if err := r.reader().QueryRow(ctx, query, since).Scan(&totals.Searches, &totals.ZeroResultSearches); err != nil {
+		r.logger.LogDBOperation("count_searches", "search_queries", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to count searches: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_searches", "search_queries", time.Since(start).Milliseconds(), nil)
+
+	return &totals, nil
+}
+
+// TopSearchTerms returns the most frequent search terms since the given time.
+// With zeroResultsOnly set, only searches that found nothing are counted.
+func (r *searchQueryRepository) TopSearchTerms(ctx context.Context, since time.Time, zeroResultsOnly bool, limit int) ([]model.SearchTermStat, error) {
+	start := time.Now()
+
+	query := `
+		SELECT term, COUNT(*), AVG(result_count)::float8, AVG(latency_ms)::float8, MAX(created_at)
+		FROM search_queries
+		WHERE created_at >= $1 AND (NOT $2 OR zero_results)
+		GROUP BY term
+		ORDER BY COUNT(*) DESC, MAX(created_at) DESC, term
+		LIMIT $3
+	`
+	rows, err := r.reader().Query(ctx, query, since, zeroResultsOnly, limit)
+	if err != nil {
+		r.logger.LogDBOperation("top_search_terms", "search_queries", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list search terms: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []model.SearchTermStat{}
+	for rows.Next() {
+		var stat model.SearchTermStat
+		if err := rows.Scan(&stat.Term, &stat.Searches, &stat.AvgResults, &stat.AvgLatencyMs, &stat.LastSearchedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan search term: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("top_search_terms", "search_queries", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate search terms: %w", err)
+	}
+
+	r.logger.LogDBOperation("top_search_terms", "search_queries", time.Since(start).Milliseconds(), nil)
+
+	return stats, nil
+}
+
+// reader returns a replica for analytics reads when one is configured
+func (r *searchQueryRepository) reader() querier {
+	if r.replicas != nil {
+		return r.replicas.Reader()
+	}
+
+	return r.db
+}
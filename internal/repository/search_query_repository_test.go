@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchQueryRepositoryRecordAndAggregate(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	searches := NewSearchQueryRepository(ts.db, nil, ts.logger)
+
+	recorded := []model.SearchQuery{
+		{Term: "golang", ResultCount: 4, LatencyMs: 10},
+		{Term: "golang", ResultCount: 2, LatencyMs: 30},
+		{Term: "quantum kittens", ResultCount: 0, LatencyMs: 5, ZeroResults: true},
+	}
+	for i := range recorded {
+		require.NoError(t, searches.RecordSearch(ctx, &recorded[i]))
+		assert.NotZero(t, recorded[i].ID)
+	}
+
+	since := time.Now().Add(-time.Hour)
+
+	totals, err := searches.CountSearches(ctx, since)
+	require.NoError(t, err)
+	assert.Equal(t, &model.SearchTotals{Searches: 3, ZeroResultSearches: 1}, totals)
+
+	top, err := searches.TopSearchTerms(ctx, since, false, 10)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, "golang", top[0].Term)
+	assert.Equal(t, int64(2), top[0].Searches)
+	assert.Equal(t, 3.0, top[0].AvgResults)
+	assert.Equal(t, 20.0, top[0].AvgLatencyMs)
+
+	zero, err := searches.TopSearchTerms(ctx, since, true, 10)
+	require.NoError(t, err)
+	require.Len(t, zero, 1)
+	assert.Equal(t, "quantum kittens", zero[0].Term)
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sourceRuleColumns is the column list every source rule query selects, in scan order
+const sourceRuleColumns = `id, list, type, value, created_at, updated_at`
+
+// sourceRuleRepository implements SourceRuleRepository interface
+type sourceRuleRepository struct {
+	db     *pgxpool.Pool
+	logger *logger.Logger
+}
+
+// NewSourceRuleRepository creates a new source rule repository
+func NewSourceRuleRepository(db *pgxpool.Pool, logger *logger.Logger) SourceRuleRepository {
+	return &sourceRuleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListSourceRules returns every source rule ordered by list, type and value
+func (r *sourceRuleRepository) ListSourceRules(ctx context.Context) ([]model.SourceRule, error) {
+	start := time.Now()
+
+	rows, err := r.db.Query(ctx, `SELECT `+sourceRuleColumns+` FROM source_rules ORDER BY list, type, value`)
+	if err != nil {
+		r.logger.LogDBOperation("list_source_rules", "source_rules", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list source rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []model.SourceRule{}
+	for rows.Next() {
+		rule, err := scanSourceRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan source rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("list_source_rules", "source_rules", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate source rules: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_source_rules", "source_rules", time.Since(start).Milliseconds(), nil)
+
+	return rules, nil
+}
+
+// SaveSourceRule adds a rule, or moves the rule for the same domain or
+// source to the given list, and fills in its ID and timestamps
+func (r *sourceRuleRepository) SaveSourceRule(ctx context.Context, rule *model.SourceRule) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO source_rules (list, type, value)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, type, value) DO UPDATE SET
+			list = EXCLUDED.list,
+			updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, rule.List, rule.Type, rule.Value).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		r.logger.LogDBOperation("save_source_rule", "source_rules", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to save source rule: %w", err)
+	}
+
+	r.logger.LogDBOperation("save_source_rule", "source_rules", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// DeleteSourceRule removes a rule, reporting whether it existed
+func (r *sourceRuleRepository) DeleteSourceRule(ctx context.Context, id int64) (bool, error) {
+	start := time.Now()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM source_rules WHERE id = $1`, id)
+	if err != nil {
+		r.logger.LogDBOperation("delete_source_rule", "source_rules", time.Since(start).Milliseconds(), err)
+		return false, fmt.Errorf("failed to delete source rule: %w", err)
+	}
+
+	r.logger.LogDBOperation("delete_source_rule", "source_rules", time.Since(start).Milliseconds(), nil)
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// scanSourceRule scans a single row selected with sourceRuleColumns
+func scanSourceRule(row pgx.Row) (*model.SourceRule, error) {
+	var rule model.SourceRule
+
+	err := row.Scan(&rule.ID, &rule.List, &rule.Type, &rule.Value, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceRuleRepositorySaveListDelete(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	rules := NewSourceRuleRepository(ts.db, ts.logger)
+
+	blocked := &model.SourceRule{List: model.SourceRuleBlock, Type: model.SourceRuleDomain, Value: "spam.example.com"}
+	require.NoError(t, rules.SaveSourceRule(ctx, blocked))
+	require.NoError(t, rules.SaveSourceRule(ctx, &model.SourceRule{List: model.SourceRuleAllow, Type: model.SourceRuleSource, Value: "Reuters"}))
+
+	// Saving the same domain again moves it to the other list
+	moved := &model.SourceRule{List: model.SourceRuleAllow, Type: model.SourceRuleDomain, Value: "spam.example.com"}
+	require.NoError(t, rules.SaveSourceRule(ctx, moved))
+	assert.Equal(t, blocked.ID, moved.ID)
+
+	listed, err := rules.ListSourceRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, model.SourceRuleAllow, listed[0].List)
+	assert.Equal(t, "spam.example.com", listed[0].Value)
+
+	deleted, err := rules.DeleteSourceRule(ctx, moved.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = rules.DeleteSourceRule(ctx, moved.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
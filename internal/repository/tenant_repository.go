@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// tenantColumns is the column list every tenant query selects, in scan order
+const tenantColumns = `id, name, COALESCE(news_api_key, ''), news_api_daily_quota, sources, active, created_at, updated_at`
+
+// newsAPIUsageKey is the Redis counter of a tenant's NewsAPI requests on a UTC day
+const newsAPIUsageKey = "tenant:%s:newsapi:%s"
+
+// tenantRepository implements TenantRepository interface
+type tenantRepository struct {
+	db     *pgxpool.Pool
+	redis  redis.UniversalClient
+	logger *logger.Logger
+}
+
+// NewTenantRepository creates a new tenant repository
+func NewTenantRepository(db *pgxpool.Pool, redis redis.UniversalClient, logger *logger.Logger) TenantRepository {
+	return &tenantRepository{
+		db:     db,
+		redis:  redis,
+		logger: logger,
+	}
+}
+
+// GetTenant retrieves a tenant by ID
+func (r *tenantRepository) GetTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	start := time.Now()
+
+	tenant, err := scanTenant(r.db.QueryRow(ctx, `SELECT `+tenantColumns+` FROM tenants WHERE id = $1`, id))
+	if err != nil {
+		r.logger.LogDBOperation("get_tenant", "tenants", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	r.logger.LogDBOperation("get_tenant", "tenants", time.Since(start).Milliseconds(), nil)
+
+	return tenant, nil
+}
+
+// ListTenants returns every tenant ordered by ID
+func (r *tenantRepository) ListTenants(ctx context.Context) ([]model.Tenant, error) {
+	start := time.Now()
+
+	rows, err := r.db.Query(ctx, `SELECT `+tenantColumns+` FROM tenants ORDER BY id`)
+	if err != nil {
+		r.logger.LogDBOperation("list_tenants", "tenants", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := []model.Tenant{}
+	for rows.Next() {
+		tenant, err := scanTenant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		tenants = append(tenants, *tenant)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("list_tenants", "tenants", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate tenants: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_tenants", "tenants", time.Since(start).Milliseconds(), nil)
+
+	return tenants, nil
+}
+
+// SaveTenant creates or replaces a tenant and fills in its timestamps
+func (r *tenantRepository) SaveTenant(ctx context.Context, tenant *model.Tenant) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO tenants (id, name, news_api_key, news_api_daily_quota, sources, active)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			news_api_key = EXCLUDED.news_api_key,
+			news_api_daily_quota = EXCLUDED.news_api_daily_quota,
+			sources = EXCLUDED.sources,
+			active = EXCLUDED.active,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+	sources := tenant.Sources
+	if sources == nil {
+		sources = []string{}
+	}
+
+	err := r.db.QueryRow(ctx, query, tenant.ID, tenant.Name, tenant.NewsAPIKey, tenant.NewsAPIDailyQuota, sources, tenant.Active).
+		Scan(&tenant.CreatedAt, &tenant.UpdatedAt)
+	if err != nil {
+		r.logger.LogDBOperation("save_tenant", "tenants", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to save tenant: %w", err)
+	}
+
+	r.logger.LogDBOperation("save_tenant", "tenants", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// IncrementNewsAPIUsage counts one NewsAPI request against a tenant's usage
+// for the given UTC day and returns the day's total
+func (r *tenantRepository) IncrementNewsAPIUsage(ctx context.Context, id string, day time.Time) (int64, error) {
+	key := fmt.Sprintf(newsAPIUsageKey, id, day.UTC().Format(time.DateOnly))
+
+	pipe := r.redis.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, 48*time.Hour)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to count NewsAPI usage: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
+// CheckTenantIsolation fails when the database role bypasses row-level
+// security, which would let every tenant see every other tenant's rows
+func CheckTenantIsolation(ctx context.Context, db *pgxpool.Pool) error {
+	var bypass bool
+	err := db.QueryRow(ctx, `SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`).Scan(&bypass)
+	if err != nil {
+		return fmt.Errorf("failed to check database role: %w", err)
+	}
+
+	if bypass {
+		return fmt.Errorf("database role bypasses row-level security; connect as a role without SUPERUSER or BYPASSRLS to isolate tenants")
+	}
+
+	return nil
+}
+
+// scanTenant scans a single row selected with tenantColumns
+func scanTenant(row pgx.Row) (*model.Tenant, error) {
+	var tenant model.Tenant
+
+	err := row.Scan(
+		&tenant.ID,
+		&tenant.Name,
+		&tenant.NewsAPIKey,
+		&tenant.NewsAPIDailyQuota,
+		&tenant.Sources,
+		&tenant.Active,
+		&tenant.CreatedAt,
+		&tenant.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant.HasNewsAPIKey = tenant.NewsAPIKey != ""
+
+	return &tenant, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantRepositorySaveAndGet(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	tenants := NewTenantRepository(ts.db, ts.redisClient, ts.logger)
+
+	_, err := tenants.GetTenant(ctx, "acme")
+	assert.True(t, errors.Is(err, pgx.ErrNoRows))
+
+	acme := &model.Tenant{ID: "acme", Name: "Acme News", NewsAPIKey: "acme-key", NewsAPIDailyQuota: 100, Sources: []string{"bbc-news"}, Active: true}
+	require.NoError(t, tenants.SaveTenant(ctx, acme))
+
+	found, err := tenants.GetTenant(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme News", found.Name)
+	assert.Equal(t, "acme-key", found.NewsAPIKey)
+	assert.True(t, found.HasNewsAPIKey)
+	assert.Equal(t, []string{"bbc-news"}, found.Sources)
+
+	// Saving again updates the existing row and clears the key
+	acme.NewsAPIKey = ""
+	acme.Active = false
+	require.NoError(t, tenants.SaveTenant(ctx, acme))
+
+	list, err := tenants.ListTenants(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "acme", list[0].ID)
+	assert.False(t, list[0].HasNewsAPIKey)
+	assert.False(t, list[0].Active)
+	assert.Equal(t, "default", list[1].ID)
+}
+
+func TestTenantRepositoryIncrementNewsAPIUsage(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+	tenants := NewTenantRepository(ts.db, ts.redisClient, ts.logger)
+
+	day := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for want := int64(1); want <= 3; want++ {
+		used, err := tenants.IncrementNewsAPIUsage(ctx, "acme", day)
+		require.NoError(t, err)
+		assert.Equal(t, want, used)
+	}
+
+	// Usage resets with the day
+	used, err := tenants.IncrementNewsAPIUsage(ctx, "acme", day.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), used)
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// topicFilter selects the posts that count towards a public topic listing
+const topicFilter = `topic_id IS NOT NULL AND status = 'published' AND NOT ($1 AND sensitive)`
+
+// topicRepository implements TopicRepository interface. Topics are not stored
+// on their own; they are the groups of posts sharing a topic_id.
+type topicRepository struct {
+	db       *pgxpool.Pool
+	replicas *database.ReplicaSet
+	logger   *logger.Logger
+}
+
+// NewTopicRepository creates a new topic repository
+func NewTopicRepository(db *pgxpool.Pool, replicas *database.ReplicaSet, logger *logger.Logger) TopicRepository {
+	return &topicRepository{
+		db:       db,
+		replicas: replicas,
+		logger:   logger,
+	}
+}
+
+// ListUnclusteredPosts returns the oldest posts not yet assigned to a topic
+func (r *topicRepository) ListUnclusteredPosts(ctx context.Context, limit int) ([]model.TopicCandidate, error) {
+	start := time.Now()
+
+	query := `
+		SELECT id, topic_id, title, COALESCE(published_at, created_at) FROM posts
+		WHERE topic_id IS NULL
+		ORDER BY id LIMIT $1
+	`
+	candidates, err := r.queryCandidates(ctx, query, limit)
+	if err != nil {
+		r.logger.LogDBOperation("list_unclustered", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list unclustered posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_unclustered", "posts", time.Since(start).Milliseconds(), nil)
+
+	return candidates, nil
+}
+
+// ListClusteredPosts returns up to limit of the newest posts published since
+// the given time that already belong to a topic. The window is compared to
+// published_at directly so only its partitions and the default one are read.
+func (r *topicRepository) ListClusteredPosts(ctx context.Context, since time.Time, limit int) ([]model.TopicCandidate, error) {
+	start := time.Now()
+
+	query := `
+		SELECT id, topic_id, title, COALESCE(published_at, created_at) FROM posts
+		WHERE topic_id IS NOT NULL AND (published_at >= $1 OR published_at IS NULL AND created_at >= $1)
+		ORDER BY id DESC LIMIT $2
+	`
+	candidates, err := r.queryCandidates(ctx, query, since, limit)
+	if err != nil {
+		r.logger.LogDBOperation("list_clustered", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list clustered posts: %w", err)
+	}
+
+	r.logger.LogDBOperation("list_clustered", "posts", time.Since(start).Milliseconds(), nil)
+
+	return candidates, nil
+}
+
+// AssignTopics sets the topic of each post, keyed by post ID
+func (r *topicRepository) AssignTopics(ctx context.Context, assignments map[int64]int64) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+
+	ids := make([]int64, 0, len(assignments))
+	topics := make([]int64, 0, len(assignments))
+	for id, topic := range assignments {
+		ids = append(ids, id)
+		topics = append(topics, topic)
+	}
+
+	// Not an edit, so version and updated_at are left alone
+	query := `
+		UPDATE posts SET topic_id = assigned.topic_id
+		FROM unnest($1::bigint[], $2::bigint[]) AS assigned(id, topic_id)
+		WHERE posts.id = assigned.id
+	`
+	if _, err := r.db.Exec(ctx, query, ids, topics); err != nil {
+		r.logger.LogDBOperation("assign_topics", "posts", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to assign topics: %w", err)
+	}
+
+	r.logger.LogDBOperation("assign_topics", "posts", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
+
+// ListTopics returns a page of topics with their published member count and
+// representative post, most recently active first
+func (r *topicRepository) ListTopics(ctx context.Context, params *model.TopicListParams) ([]model.Topic, error) {
+	start := time.Now()
+
+	query := `
+		SELECT topic_id, COUNT(*), MAX(published_at), MIN(id) FROM posts
+		WHERE ` + topicFilter + `
+		GROUP BY topic_id
+		HAVING COUNT(*) >= $2
+		ORDER BY MAX(published_at) DESC NULLS LAST, topic_id DESC
+		LIMIT $3 OFFSET $4
+	`
+	offset := (params.Page - 1) * params.Limit
+	rows, err := r.reader().Query(ctx, query, params.SafeMode, params.MinMembers, params.Limit, offset)
+	if err != nil {
+		r.logger.LogDBOperation("list_topics", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+	defer rows.Close()
+
+	topics := []model.Topic{}
+	var representativeIDs []int64
+	for rows.Next() {
+		var topic model.Topic
+		var representativeID int64
+		if err := rows.Scan(&topic.ID, &topic.MemberCount, &topic.LastPublishedAt, &representativeID); err != nil {
+			return nil, fmt.Errorf("failed to scan topic: %w", err)
+		}
+		topics = append(topics, topic)
+		representativeIDs = append(representativeIDs, representativeID)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("list_topics", "posts", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate topics: %w", err)
+	}
+
+	if len(representativeIDs) > 0 {
+		postRows, err := r.reader().Query(ctx, `SELECT `+postColumns+` FROM posts WHERE id = ANY($1)`, representativeIDs)
+		if err != nil {
+			r.logger.LogDBOperation("list_topics", "posts", time.Since(start).Milliseconds(), err)
+			return nil, fmt.Errorf("failed to load topic representatives: %w", err)
+		}
+
+		posts, err := collectPosts(postRows)
+		if err != nil {
+			r.logger.LogDBOperation("list_topics", "posts", time.Since(start).Milliseconds(), err)
+			return nil, fmt.Errorf("failed to load topic representatives: %w", err)
+		}
+
+		byID := make(map[int64]*model.Post, len(posts))
+		for i := range posts {
+			byID[posts[i].ID] = &posts[i]
+		}
+		for i, id := range representativeIDs {
+			topics[i].Representative = byID[id]
+		}
+	}
+
+	r.logger.LogDBOperation("list_topics", "posts", time.Since(start).Milliseconds(), nil)
+
+	return topics, nil
+}
+
+// CountTopics counts the topics a listing with the same parameters pages through
+func (r *topicRepository) CountTopics(ctx context.Context, params *model.TopicListParams) (int64, error) {
+	start := time.Now()
+
+	query := `
+		SELECT COUNT(*) FROM (
+			SELECT topic_id FROM posts
+			WHERE ` + topicFilter + `
+			GROUP BY topic_id
+			HAVING COUNT(*) >= $2
+		) topics
+	`
+
+	var count int64
+	if err := r.reader().QueryRow(ctx, query, params.SafeMode, params.MinMembers).Scan(&count); err != nil {
+		r.logger.LogDBOperation("count_topics", "posts", time.Since(start).Milliseconds(), err)
+		return 0, fmt.Errorf("failed to count topics: %w", err)
+	}
+
+	r.logger.LogDBOperation("count_topics", "posts", time.Since(start).Milliseconds(), nil)
+
+	return count, nil
+}
+
+// queryCandidates runs a query selecting id, topic_id, title and publication
+// time and collects the rows
+func (r *topicRepository) queryCandidates(ctx context.Context, query string, args ...any) ([]model.TopicCandidate, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []model.TopicCandidate{}
+	for rows.Next() {
+		var candidate model.TopicCandidate
+		if err := rows.Scan(&candidate.ID, &candidate.TopicID, &candidate.Title, &candidate.PublishedAt); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+// reader returns a replica for topic listings when one is configured
+func (r *topicRepository) reader() querier {
+	if r.replicas != nil {
+		return r.replicas.Reader()
+	}
+
+	return r.db
+}
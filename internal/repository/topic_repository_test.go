@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicRepositoryClusterAndList(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	topics := NewTopicRepository(ts.db, nil, ts.logger)
+
+	var ids []int64
+	for i := range 3 {
+		params := createSamplePost()
+		params.URL = fmt.Sprintf("https://example.com/topic-%d", i)
+		post, err := ts.repo.CreatePost(ctx, params)
+		require.NoError(t, err)
+		ids = append(ids, post.ID)
+	}
+
+	pending, err := topics.ListUnclusteredPosts(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	assert.Equal(t, ids[0], pending[0].ID)
+
+	require.NoError(t, topics.AssignTopics(ctx, map[int64]int64{ids[0]: ids[0], ids[1]: ids[0], ids[2]: ids[2]}))
+
+	pending, err = topics.ListUnclusteredPosts(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	clustered, err := topics.ListClusteredPosts(ctx, time.Now().Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Len(t, clustered, 3)
+
+	params := &model.TopicListParams{Page: 1, Limit: 10, MinMembers: 2}
+	listed, err := topics.ListTopics(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, ids[0], listed[0].ID)
+	assert.Equal(t, int64(2), listed[0].MemberCount)
+	require.NotNil(t, listed[0].Representative)
+	assert.Equal(t, ids[0], listed[0].Representative.ID)
+
+	count, err := topics.CountTopics(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	collapsed, err := ts.repo.ListPosts(ctx, &model.PostListParams{Page: 1, Limit: 10, Collapse: true})
+	require.NoError(t, err)
+	assert.Len(t, collapsed, 2)
+
+	total, err := ts.repo.CountCollapsedPosts(ctx, &model.PostListParams{Collapse: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+}
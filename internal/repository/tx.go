@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// UnitOfWork defines the contract for running repository calls atomically
+type UnitOfWork interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// querier is implemented by both *pgxpool.Pool and pgx.Tx
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type txKey struct{}
+
+// txState is carried in the context for the lifetime of a transaction
+type txState struct {
+	tx          pgx.Tx
+	afterCommit []func(context.Context)
+}
+
+// unitOfWork implements UnitOfWork on top of a pgx pool
+type unitOfWork struct {
+	db     *pgxpool.Pool
+	logger *logger.Logger
+}
+
+// NewUnitOfWork creates a new unit of work bound to the primary pool
+func NewUnitOfWork(db *pgxpool.Pool, logger *logger.Logger) UnitOfWork {
+	return &unitOfWork{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// WithinTx runs fn in a transaction carried by ctx. Repository calls made with
+// that context join the transaction; nested calls reuse the outer one.
+func (u *unitOfWork) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	state := &txState{tx: tx}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			u.logger.Warn("Failed to roll back transaction", "error", rbErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, hook := range state.afterCommit {
+		hook(ctx)
+	}
+
+	return nil
+}
+
+// txFromContext returns the transaction carried by ctx, if any
+func txFromContext(ctx context.Context) (*txState, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	return state, ok
+}
+
+// afterCommit runs fn once the transaction in ctx commits, or immediately
+// when ctx carries no transaction
+func afterCommit(ctx context.Context, fn func(context.Context)) {
+	if state, ok := txFromContext(ctx); ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+
+	fn(ctx)
+}
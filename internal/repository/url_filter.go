@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/redis/go-redis/v9"
+)
+
+// urlFilterBatchSize is the number of URLs written per pipeline while the
+// filter is rebuilt
+const urlFilterBatchSize = 1000
+
+// urlFilter is a Bloom filter of the stored post URLs, kept as a Redis bitmap
+// per tenant. A URL it does not contain may still be stored, as the filter
+// is only as complete as its last rebuild plus the URLs added since, while a
+// URL it contains is stored but for the configured false positive rate.
+type urlFilter struct {
+	redis  redis.UniversalClient
+	bits   uint64
+	hashes int
+}
+
+// newURLFilter sizes a filter holding expected URLs at the false positive rate
+func newURLFilter(redis redis.UniversalClient, expected int, falsePositiveRate float64) *urlFilter {
+	bits := math.Ceil(-float64(expected) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := math.Round(bits / float64(expected) * math.Ln2)
+
+	return &urlFilter{
+		redis:  redis,
+		bits:   uint64(bits),
+		hashes: max(int(hashes), 1),
+	}
+}
+
+// key returns the bitmap of the tenant in ctx. The size is part of the key,
+// so a filter built for other settings is never read, and the hash tag keeps
+// the key and its rebuild in the same cluster slot for the final rename.
+func (f *urlFilter) key(ctx context.Context) string {
+	return tenant.Key(ctx, fmt.Sprintf("posts:{url_filter}:%d:%d", f.bits, f.hashes))
+}
+
+// offsets returns the bits set for url, derived from two halves of a 128-bit
+// hash by double hashing
+func (f *urlFilter) offsets(url string) []uint64 {
+	h := fnv.New128a()
+	h.Write([]byte(url))
+	sum := h.Sum(nil)
+	h1 := binary.BigEndian.Uint64(sum[:8])
+	h2 := binary.BigEndian.Uint64(sum[8:])
+
+	offsets := make([]uint64, f.hashes)
+	for i := range offsets {
+		offsets[i] = (h1 + uint64(i)*h2) % f.bits
+	}
+
+	return offsets
+}
+
+// bitfieldArgs builds a BITFIELD command reading or setting the bits of url
+func (f *urlFilter) bitfieldArgs(url string, set bool) []any {
+	args := make([]any, 0, f.hashes*4)
+	for _, offset := range f.offsets(url) {
+		if set {
+			args = append(args, "SET", "u1", offset, 1)
+		} else {
+			args = append(args, "GET", "u1", offset)
+		}
+	}
+
+	return args
+}
+
+// mayContain reports whether url may have been added to the filter
+func (f *urlFilter) mayContain(ctx context.Context, url string) (bool, error) {
+	bits, err := f.redis.BitField(ctx, f.key(ctx), f.bitfieldArgs(url, false)...).Result()
+	if err != nil {
+		return false, err
+	}
+
+	for _, bit := range bits {
+		if bit == 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// add adds url to the filter
+func (f *urlFilter) add(ctx context.Context, url string) error {
+	return f.redis.BitField(ctx, f.key(ctx), f.bitfieldArgs(url, true)...).Err()
+}
+
+// rebuild writes the URLs yielded by next into a fresh bitmap and swaps it
+// in, dropping the URLs of deleted posts. next returns false once done; the
+// filter in use is kept when it fails.
+func (f *urlFilter) rebuild(ctx context.Context, next func() (string, bool, error)) (int, error) {
+	key := f.key(ctx)
+	building := key + ":building"
+
+	if err := f.redis.Del(ctx, building).Err(); err != nil {
+		return 0, err
+	}
+
+	added := 0
+	pipe := f.redis.Pipeline()
+	for {
+		url, ok, err := next()
+		if err != nil {
+			f.redis.Del(ctx, building)
+			return added, err
+		}
+		if ok {
+			pipe.BitField(ctx, building, f.bitfieldArgs(url, true)...)
+			added++
+		}
+
+		if pipe.Len() >= urlFilterBatchSize || (!ok && pipe.Len() > 0) {
+			if _, err := pipe.Exec(ctx); err != nil {
+				return added, err
+			}
+		}
+
+		if !ok {
+			break
+		}
+	}
+
+	if added == 0 {
+		return 0, f.redis.Del(ctx, key).Err()
+	}
+
+	return added, f.redis.Rename(ctx, building, key).Err()
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLFilterSizing(t *testing.T) {
+	f := newURLFilter(nil, 1000000, 0.000001)
+
+	// About 28.8 bits and 20 hashes per URL at a one in a million rate
+	assert.InDelta(t, 28755176, f.bits, 1)
+	assert.Equal(t, 20, f.hashes)
+
+	offsets := f.offsets("https://example.com/a")
+	assert.Len(t, offsets, 20)
+	assert.Equal(t, offsets, f.offsets("https://example.com/a"))
+	assert.NotEqual(t, offsets, f.offsets("https://example.com/b"))
+	for _, offset := range offsets {
+		assert.Less(t, offset, f.bits)
+	}
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// viewRepository implements ViewRepository interface
+type viewRepository struct {
+	db     *pgxpool.Pool
+	logger *logger.Logger
+}
+
+// NewViewRepository creates a new materialized view repository
+func NewViewRepository(db *pgxpool.Pool, logger *logger.Logger) ViewRepository {
+	return &viewRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// RefreshPostCounts recomputes post_counts_daily for every tenant. Readers
+// keep seeing the previous contents until the refresh completes.
+func (r *viewRepository) RefreshPostCounts(ctx context.Context) error {
+	start := time.Now()
+
+	if _, err := r.db.Exec(ctx, `SELECT refresh_post_counts()`); err != nil {
+		r.logger.LogDBOperation("refresh", "post_counts_daily", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to refresh post counts view: %w", err)
+	}
+
+	r.logger.LogDBOperation("refresh", "post_counts_daily", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// watermarkRepository implements WatermarkRepository interface
+type watermarkRepository struct {
+	db     *pgxpool.Pool
+	logger *logger.Logger
+}
+
+// NewWatermarkRepository creates a new fetch watermark repository
+func NewWatermarkRepository(db *pgxpool.Pool, logger *logger.Logger) WatermarkRepository {
+	return &watermarkRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetWatermarks returns the stored watermark of each key in scope. Keys that
+// have never been fetched are absent from the result.
+func (r *watermarkRepository) GetWatermarks(ctx context.Context, scope model.FetchScope, keys []string) (map[string]time.Time, error) {
+	start := time.Now()
+
+	query := `
+		SELECT key, fetched_until
+		FROM fetch_watermarks
+		WHERE scope = $1 AND key = ANY($2)
+	`
+	rows, err := r.db.Query(ctx, query, scope, keys)
+	if err != nil {
+		r.logger.LogDBOperation("get_watermarks", "fetch_watermarks", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to get fetch watermarks: %w", err)
+	}
+	defer rows.Close()
+
+	watermarks := make(map[string]time.Time, len(keys))
+	for rows.Next() {
+		var key string
+		var fetchedUntil time.Time
+		if err := rows.Scan(&key, &fetchedUntil); err != nil {
+			return nil, fmt.Errorf("failed to scan fetch watermark: %w", err)
+		}
+		watermarks[key] = fetchedUntil
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.LogDBOperation("get_watermarks", "fetch_watermarks", time.Since(start).Milliseconds(), err)
+		return nil, fmt.Errorf("failed to iterate fetch watermarks: %w", err)
+	}
+
+	r.logger.LogDBOperation("get_watermarks", "fetch_watermarks", time.Since(start).Milliseconds(), nil)
+
+	return watermarks, nil
+}
+
+// SaveWatermarks stores a watermark for each key in scope. A watermark never
+// moves backwards, so a slow run cannot undo the progress of a newer one.
+func (r *watermarkRepository) SaveWatermarks(ctx context.Context, scope model.FetchScope, watermarks map[string]time.Time) error {
+	if len(watermarks) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+
+	keys := make([]string, 0, len(watermarks))
+	times := make([]time.Time, 0, len(watermarks))
+	for key, t := range watermarks {
+		keys = append(keys, key)
+		times = append(times, t.UTC())
+	}
+
+	query := `
+		INSERT INTO fetch_watermarks (scope, key, fetched_until)
+		SELECT $1, key, fetched_until
+		FROM unnest($2::text[], $3::timestamp[]) AS w(key, fetched_until)
+		ON CONFLICT (tenant_id, scope, key) DO UPDATE
+		SET fetched_until = GREATEST(fetch_watermarks.fetched_until, EXCLUDED.fetched_until),
+			updated_at = NOW()
+	`
+	_, err := r.db.Exec(ctx, query, scope, keys, times)
+	if err != nil {
+		r.logger.LogDBOperation("save_watermarks", "fetch_watermarks", time.Since(start).Milliseconds(), err)
+		return fmt.Errorf("failed to save fetch watermarks: %w", err)
+	}
+
+	r.logger.LogDBOperation("save_watermarks", "fetch_watermarks", time.Since(start).Milliseconds(), nil)
+
+	return nil
+}
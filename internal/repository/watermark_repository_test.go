@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatermarkRepositorySaveAndGet(t *testing.T) {
+	ts := setupTestSuite(t)
+	defer ts.teardown(t)
+
+	ctx := context.Background()
+	ts.cleanupData(ctx)
+
+	watermarks := NewWatermarkRepository(ts.db, ts.logger)
+
+	noon := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, watermarks.SaveWatermarks(ctx, model.FetchScopeSource, map[string]time.Time{
+		"techcrunch": noon,
+		"wired":      noon.Add(-time.Hour),
+	}))
+
+	// A watermark moves forward but never back
+	require.NoError(t, watermarks.SaveWatermarks(ctx, model.FetchScopeSource, map[string]time.Time{
+		"techcrunch": noon.Add(-2 * time.Hour),
+		"wired":      noon.Add(time.Hour),
+	}))
+
+	got, err := watermarks.GetWatermarks(ctx, model.FetchScopeSource, []string{"techcrunch", "wired", "bbc-news"})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.True(t, got["techcrunch"].Equal(noon))
+	assert.True(t, got["wired"].Equal(noon.Add(time.Hour)))
+
+	// Scopes are independent
+	other, err := watermarks.GetWatermarks(ctx, model.FetchScopeEverything, []string{"techcrunch"})
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/newsapi"
+)
+
+// Sentinel errors classifying aggregation failures. Every failure recorded in
+// an AggregationResponse wraps one of these, or ErrPostExists for duplicates.
+var (
+	ErrNewsProvider = errors.New("news provider request failed")
+	ErrArticleParse = errors.New("article could not be parsed")
+	ErrPostStorage  = errors.New("post could not be stored")
+)
+
+// providerError wraps a failed NewsAPI request so it classifies as a provider error
+func providerError(err error) error {
+	return fmt.Errorf("%w: %w", ErrNewsProvider, err)
+}
+
+// interrupted reports whether err is due to the run's context being
+// cancelled or reaching its deadline rather than a failure of its own
+func interrupted(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// rateLimited reports whether errs include a rate limited request, after which
+// a run stops sending requests to NewsAPI
+func rateLimited(errs []model.AggregationError) bool {
+	for _, e := range errs {
+		if e.Type == model.AggregationErrorRateLimited {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storageError wraps a failed post creation, keeping parse and duplicate
+// failures distinguishable and treating anything else as a storage error
+func storageError(err error) error {
+	if errors.Is(err, ErrArticleParse) || errors.Is(err, ErrPostExists) || errors.Is(err, ErrPostStorage) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrPostStorage, err)
+}
+
+// aggregationErrorType maps an aggregation failure to its response error type
+func aggregationErrorType(err error) model.AggregationErrorType {
+	var rateLimited *newsapi.ErrRateLimited
+
+	switch {
+	case errors.As(err, &rateLimited):
+		return model.AggregationErrorRateLimited
+	case errors.Is(err, ErrPostExists):
+		return model.AggregationErrorDuplicate
+	case errors.Is(err, ErrNewsProvider):
+		return model.AggregationErrorProvider
+	case errors.Is(err, ErrArticleParse):
+		return model.AggregationErrorParse
+	default:
+		return model.AggregationErrorDB
+	}
+}
+
+// newAggregationError builds a response error entry for a failure
+func newAggregationError(err error) model.AggregationError {
+	return model.AggregationError{Type: aggregationErrorType(err), Message: err.Error()}
+}
+
+// tallyErrors fills the per-type error counts of a response from its recorded
+// errors and duplicate total
+func tallyErrors(result *model.AggregationResponse) {
+	counts := make(map[model.AggregationErrorType]int)
+	for _, e := range result.Errors {
+		counts[e.Type]++
+	}
+	if result.TotalDuplicates > 0 {
+		counts[model.AggregationErrorDuplicate] += result.TotalDuplicates
+	}
+	if len(counts) > 0 {
+		result.ErrorCounts = counts
+	}
+}
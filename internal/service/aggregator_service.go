@@ -4,28 +4,60 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
 	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/workerpool"
+)
+
+// Defaults used when an aggregation run does not override them
+const (
+	defaultCategoryPageSize = 50
+	defaultSourcePageSize   = 100
+	defaultLanguage         = "en"
 )
 
 // aggregatorService implements AggregatorService interface
 type aggregatorService struct {
 	newsService NewsService
 	postService PostService
+	filter      ArticleFilterService
+	deadLetters DeadLetterService
+	countries   []string
 	logger      *logger.Logger
+	watermarks  repository.WatermarkRepository
+	ingest      config.IngestConfig
 	maxWorkers  int
+	// persist stores articles for every fetch, bounding the database
+	// connections a run holds however many articles it fetches
+	persist *workerpool.Pool
 }
 
-// NewAggregatorService creates a new aggregator service
-func NewAggregatorService(newsService NewsService, postService PostService, logger *logger.Logger) AggregatorService {
+// NewAggregatorService creates a new aggregator service that fetches
+// top headlines for each of the given countries. Every article passes
+// through filter before a post is created, and articles that cannot be
+// stored are kept in deadLetters. Posts are created on a shared pool of
+// ingest.Workers workers, and watermarks records how far each query has
+// been fetched for incremental runs.
+func NewAggregatorService(newsService NewsService, postService PostService, filter ArticleFilterService, deadLetters DeadLetterService, watermarks repository.WatermarkRepository, countries []string, ingest config.IngestConfig, logger *logger.Logger) AggregatorService {
 	return &aggregatorService{
 		newsService: newsService,
 		postService: postService,
+		filter:      filter,
+		deadLetters: deadLetters,
+		countries:   normalizeCountries(countries),
 		logger:      logger,
+		watermarks:  watermarks,
+		ingest:      ingest,
 		maxWorkers:  5,
+		persist:     workerpool.New(ingest.Workers, ingest.QueueSize),
 	}
 }
 
@@ -35,7 +67,8 @@ func (s *aggregatorService) AggregateTopHeadlines(ctx context.Context) (*model.A
 	s.logger.Info("Starting top headlines aggregation")
 
 	categories := GetDefaultCategories()
-	result := s.aggregateByCategories(ctx, categories, true)
+	result := s.aggregateByCategories(ctx, categories, s.countries, model.AggregationQuery{}, true)
+	tallyErrors(result)
 
 	result.Duration = time.Since(start)
 	s.logger.LogServiceOperation("aggregator", "aggregate_top_headlines", result.TotalErrors == 0, result.Duration.Milliseconds())
@@ -45,18 +78,28 @@ func (s *aggregatorService) AggregateTopHeadlines(ctx context.Context) (*model.A
 		"total_created", result.TotalCreated,
 		"total_duplicates", result.TotalDuplicates,
 		"total_errors", result.TotalErrors,
+		"total_rejected", result.TotalRejected,
+		"timed_out", result.TimedOut,
 		"durationMS", result.Duration.Milliseconds(),
 	)
 
 	return result, nil
 }
 
-// AggregateByCategories aggregates news from specific categories
-func (s *aggregatorService) AggregateByCategories(ctx context.Context, categories []string) (*model.AggregationResponse, error) {
+// AggregateByCategories aggregates news from specific categories. An empty
+// country list falls back to the configured countries.
+func (s *aggregatorService) AggregateByCategories(ctx context.Context, categories, countries []string, query model.AggregationQuery) (*model.AggregationResponse, error) {
 	start := time.Now()
-	s.logger.Info("Starting category-based aggregation", "categories", categories)
 
-	result := s.aggregateByCategories(ctx, categories, true)
+	countries = normalizeCountries(countries)
+	if len(countries) == 0 {
+		countries = s.countries
+	}
+
+	s.logger.Info("Starting category-based aggregation", "categories", categories, "countries", countries)
+
+	result := s.aggregateByCategories(ctx, categories, countries, query, true)
+	tallyErrors(result)
 	result.Duration = time.Since(start)
 
 	s.logger.LogServiceOperation("aggregator", "aggregate_by_categories", result.TotalErrors == 0, result.Duration.Milliseconds())
@@ -65,11 +108,12 @@ func (s *aggregatorService) AggregateByCategories(ctx context.Context, categorie
 }
 
 // AggregateBySources aggregates news from specific sources
-func (s *aggregatorService) AggregateBySources(ctx context.Context, sources []string) (*model.AggregationResponse, error) {
+func (s *aggregatorService) AggregateBySources(ctx context.Context, sources []string, query model.AggregationQuery) (*model.AggregationResponse, error) {
 	start := time.Now()
 	s.logger.Info("Starting source-based aggregation", "sources", sources)
 
-	result := s.aggregateBySources(ctx, sources)
+	result := s.aggregateBySources(ctx, sources, query)
+	tallyErrors(result)
 	result.Duration = time.Since(start)
 
 	s.logger.LogServiceOperation("aggregator", "aggregate_by_sources", result.TotalErrors == 0, result.Duration.Milliseconds())
@@ -87,26 +131,33 @@ func (s *aggregatorService) AggregateAll(ctx context.Context) (*model.Aggregatio
 
 	result := &model.AggregationResponse{
 		Categories: make(map[string]model.CategoryStats),
+		Countries:  make(map[string]model.CountryStats),
 		Sources:    make(map[string]model.SourceStats),
-		Errors:     []string{},
+		Errors:     []model.AggregationError{},
 	}
 
 	// Aggregate by categories
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		categoryResult := s.aggregateByCategories(ctx, GetDefaultCategories(), true)
+		categoryResult := s.aggregateByCategories(ctx, GetDefaultCategories(), s.countries, model.AggregationQuery{}, true)
 
 		mu.Lock()
 		result.TotalFetched += categoryResult.TotalFetched
 		result.TotalCreated += categoryResult.TotalCreated
 		result.TotalDuplicates += categoryResult.TotalDuplicates
 		result.TotalErrors += categoryResult.TotalErrors
+		mergeRejections(result, categoryResult.RejectionCounts)
 
 		for k, v := range categoryResult.Categories {
 			result.Categories[k] = v
 		}
+		for k, v := range categoryResult.Countries {
+			result.Countries[k] = v
+		}
 		result.Errors = append(result.Errors, categoryResult.Errors...)
+		result.SkippedCategories = categoryResult.SkippedCategories
+		result.TimedOut = result.TimedOut || categoryResult.TimedOut
 		mu.Unlock()
 	}()
 
@@ -114,22 +165,26 @@ func (s *aggregatorService) AggregateAll(ctx context.Context) (*model.Aggregatio
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		sourceResult := s.aggregateBySources(ctx, GetDefaultSources())
+		sourceResult := s.aggregateBySources(ctx, GetDefaultSources(), model.AggregationQuery{})
 
 		mu.Lock()
 		result.TotalFetched += sourceResult.TotalFetched
 		result.TotalCreated += sourceResult.TotalCreated
 		result.TotalDuplicates += sourceResult.TotalDuplicates
 		result.TotalErrors += sourceResult.TotalErrors
+		mergeRejections(result, sourceResult.RejectionCounts)
 
 		for k, v := range sourceResult.Sources {
 			result.Sources[k] = v
 		}
 		result.Errors = append(result.Errors, sourceResult.Errors...)
+		result.SkippedSources = sourceResult.SkippedSources
+		result.TimedOut = result.TimedOut || sourceResult.TimedOut
 		mu.Unlock()
 	}()
 
 	wg.Wait()
+	tallyErrors(result)
 
 	result.Duration = time.Since(start)
 	s.logger.LogServiceOperation("aggregator", "aggregate_all", result.TotalErrors == 0, result.Duration.Milliseconds())
@@ -139,122 +194,224 @@ func (s *aggregatorService) AggregateAll(ctx context.Context) (*model.Aggregatio
 		"total_created", result.TotalCreated,
 		"total_duplicates", result.TotalDuplicates,
 		"total_errors", result.TotalErrors,
+		"total_rejected", result.TotalRejected,
+		"timed_out", result.TimedOut,
 		"durationMS", result.Duration.Milliseconds(),
 	)
 
 	return result, nil
 }
 
-// aggregateByCategories is the internal implementation for category-based aggregation
-func (s *aggregatorService) aggregateByCategories(ctx context.Context, categories []string, useTopHeadlines bool) *model.AggregationResponse {
+// aggregateByCategories is the internal implementation for category-based aggregation.
+// Top headlines are fetched once per category and country; the everything
+// endpoint has no country filter so it is queried once per category.
+func (s *aggregatorService) aggregateByCategories(ctx context.Context, categories, countries []string, query model.AggregationQuery, useTopHeadlines bool) *model.AggregationResponse {
 	result := &model.AggregationResponse{
 		Categories: make(map[string]model.CategoryStats),
+		Countries:  make(map[string]model.CountryStats),
 		Sources:    make(map[string]model.SourceStats),
-		Errors:     []string{},
+		Errors:     []model.AggregationError{},
+	}
+
+	if !useTopHeadlines {
+		countries = []string{""}
 	}
 
 	// Use a semaphore to limit concurrent requests
 	semaphore := make(chan struct{}, s.maxWorkers)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
+	skipped := make(map[string]struct{})
+	var throttled atomic.Bool
 
 	for _, category := range categories {
-		wg.Add(1)
-		go func(cat string) {
-			defer wg.Done()
-
-			// Acquire semaphore
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
+		for _, country := range countries {
+			wg.Add(1)
+			go func(cat, ctry string) {
+				defer wg.Done()
+
+				// Acquire semaphore, giving up once the run is over
+				select {
+				case semaphore <- struct{}{}:
+				case <-ctx.Done():
+				}
+				if ctx.Err() != nil {
+					mu.Lock()
+					skipped[cat] = struct{}{}
+					mu.Unlock()
+					return
+				}
+				defer func() { <-semaphore }()
+
+				// Once NewsAPI throttles the run, further requests would fail too
+				if throttled.Load() {
+					mu.Lock()
+					skipped[cat] = struct{}{}
+					mu.Unlock()
+					return
+				}
 
-			categoryStats := s.processCategoryNews(ctx, cat, useTopHeadlines)
+				stats, errs, rejections, complete := s.processCategoryNews(ctx, cat, ctry, query, useTopHeadlines)
+				if rateLimited(errs) {
+					throttled.Store(true)
+				}
 
-			mu.Lock()
-			result.TotalFetched += categoryStats.Fetched
-			result.TotalCreated += categoryStats.Created
-			result.TotalDuplicates += categoryStats.Duplicates
-			result.TotalErrors += categoryStats.Errors
-			result.Categories[cat] = categoryStats
-			mu.Unlock()
-		}(category)
+				mu.Lock()
+				if !complete {
+					skipped[cat] = struct{}{}
+				}
+				result.Errors = append(result.Errors, errs...)
+				mergeRejections(result, rejections)
+				result.TotalFetched += stats.Fetched
+				result.TotalCreated += stats.Created
+				result.TotalDuplicates += stats.Duplicates
+				result.TotalErrors += stats.Errors
+				result.Categories[cat] = model.CategoryStats{BaseStats: addStats(result.Categories[cat].BaseStats, stats)}
+				if ctry != "" {
+					result.Countries[ctry] = model.CountryStats{BaseStats: addStats(result.Countries[ctry].BaseStats, stats)}
+				}
+				mu.Unlock()
+			}(category, country)
+		}
 	}
 
 	wg.Wait()
 
+	for category := range skipped {
+		result.SkippedCategories = append(result.SkippedCategories, category)
+	}
+	markInterrupted(ctx, result)
+
 	return result
 }
 
-// processCategoryNews processes news for a single category
-func (s *aggregatorService) processCategoryNews(ctx context.Context, category string, useTopHeadlines bool) model.CategoryStats {
-	stats := model.CategoryStats{}
-
-	var response *model.NewsAPIResponse
-	var err error
+// processCategoryNews processes news for a single category in a country.
+// Top headlines cannot be filtered by date upstream, so the query's date
+// range is applied to the fetched articles instead. complete is false when the
+// run's context ended before every article was fetched and stored.
+func (s *aggregatorService) processCategoryNews(ctx context.Context, category, country string, query model.AggregationQuery, useTopHeadlines bool) (stats model.BaseStats, errs []model.AggregationError, rejections map[model.FilterRule]int, complete bool) {
+	rejections = make(map[model.FilterRule]int)
+
+	params := model.NewsParams{
+		Category: category,
+		Country:  country,
+		Language: query.Language,
+		PageSize: pageSizeOrDefault(query.PageSize, defaultCategoryPageSize),
+	}
+	fetch := s.newsService.GetTopHeadlines
+	scope, key := model.FetchScopeTopHeadlines, category+":"+country
 
-	if useTopHeadlines {
-		response, err = s.newsService.GetNewsByCategory(ctx, category, 50)
-	} else {
-		req := &model.NewsParams{
+	if !useTopHeadlines {
+		params = model.NewsParams{
 			Query:    category,
-			Language: "en",
-			PageSize: 50,
+			Language: languageOrDefault(query.Language),
+			PageSize: pageSizeOrDefault(query.PageSize, defaultCategoryPageSize),
+			From:     query.From,
+			To:       query.To,
 		}
-		response, err = s.newsService.GetEverything(ctx, req)
+		fetch = s.newsService.GetEverything
+		scope, key = model.FetchScopeEverything, category
 	}
 
-	if err != nil {
-		s.logger.Error("Failed to fetch news for category", "category", category, "error", err.Error())
-		stats.Errors++
-		return stats
+	if lastFetched := s.lastFetched(ctx, query, scope, []string{key}); lastFetched != nil {
+		params.SeenUntil = lastFetched
+		if !useTopHeadlines {
+			params.From = s.resumeFrom(*lastFetched)
+		}
 	}
 
-	stats.Fetched = len(response.Articles)
+	// A response alongside an error holds the pages fetched before it
+	response, fetchErr := fetch(ctx, &params)
+	if fetchErr != nil {
+		if interrupted(fetchErr) {
+			s.logger.Warn("Skipped category news", "category", category, "country", country, "error", fetchErr.Error())
+			if response == nil {
+				return stats, errs, rejections, false
+			}
+		} else {
+			err := providerError(fmt.Errorf("category %q country %q: %w", category, country, fetchErr))
+			s.logger.Error("Failed to fetch news for category", "category", category, "country", country, "error", err.Error())
+			stats.Errors++
+			errs = append(errs, newAggregationError(err))
+			if response == nil {
+				return stats, errs, rejections, true
+			}
+		}
+	}
 
+	var accepted []model.NewsAPIArticleParams
 	for _, article := range response.Articles {
+		if !query.Covers(article.PublishedAt) {
+			continue
+		}
+
+		stats.Fetched++
+
 		if article.Source.Name != "" {
-			post, err := s.postService.CreatePostFromNewsAPI(ctx, &article)
-			if err != nil {
-				if errors.Is(err, ErrPostExists) {
-					stats.Duplicates++
-				} else {
-					stats.Errors++
-					s.logger.Warn("Failed to create post from article",
-						"url", article.URL,
-						"error", err.Error(),
-					)
-				}
+			if rejection := s.filter.Check(ctx, &article); rejection != nil {
+				stats.Rejected++
+				rejections[rejection.Rule]++
 				continue
 			}
 
-			if post != nil {
-				stats.Created++
-			}
+			article.Country = country
+			accepted = append(accepted, article)
+		}
+	}
+
+	skipped, err := s.persistArticles(ctx, accepted, func(article *model.NewsAPIArticleParams, err error) {
+		if err == nil {
+			stats.Created++
+			return
 		}
+
+		if errors.Is(err, ErrPostExists) {
+			stats.Duplicates++
+			return
+		}
+
+		stats.Errors++
+		errs = append(errs, newAggregationError(fmt.Errorf("%s: %w", article.URL, err)))
+		s.logger.Warn("Failed to create post from article",
+			"url", article.URL,
+			"error", err.Error(),
+		)
+	})
+	if err != nil {
+		s.logger.Warn("Stopped storing category news", "category", category, "country", country, "skipped", skipped, "error", err.Error())
+	}
+
+	// Pages missed by a failed request are fetched again next run
+	if err == nil && fetchErr == nil {
+		s.saveWatermarks(ctx, query, scope, []string{key}, response.Articles)
 	}
 
 	s.logger.Debug("Processed category news",
 		"category", category,
+		"country", country,
 		"fetched", stats.Fetched,
 		"created", stats.Created,
 		"duplicates", stats.Duplicates,
 		"errors", stats.Errors,
+		"rejected", stats.Rejected,
 	)
 
-	return stats
+	return stats, errs, rejections, err == nil && !interrupted(fetchErr)
 }
 
 // aggregateBySources is the internal implementation for source-based aggregation
-func (s *aggregatorService) aggregateBySources(ctx context.Context, sources []string) *model.AggregationResponse {
+func (s *aggregatorService) aggregateBySources(ctx context.Context, sources []string, query model.AggregationQuery) *model.AggregationResponse {
 	result := &model.AggregationResponse{
 		Categories: make(map[string]model.CategoryStats),
 		Sources:    make(map[string]model.SourceStats),
-		Errors:     []string{},
+		Errors:     []model.AggregationError{},
 	}
 
 	batchSize := 3
 	semaphore := make(chan struct{}, s.maxWorkers)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
+	var throttled atomic.Bool
 
 	for i := 0; i < len(sources); i += batchSize {
 		end := i + batchSize
@@ -268,16 +425,38 @@ func (s *aggregatorService) aggregateBySources(ctx context.Context, sources []st
 		go func(sourceBatch []string) {
 			defer wg.Done()
 
-			semaphore <- struct{}{}
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				mu.Lock()
+				result.SkippedSources = append(result.SkippedSources, sourceBatch...)
+				mu.Unlock()
+				return
+			}
 			defer func() { <-semaphore }()
 
-			batchStats := s.processSourceNews(ctx, sourceBatch)
+			// Once NewsAPI throttles the run, further requests would fail too
+			if throttled.Load() {
+				mu.Lock()
+				result.SkippedSources = append(result.SkippedSources, sourceBatch...)
+				mu.Unlock()
+				return
+			}
+
+			batchStats := s.processSourceNews(ctx, sourceBatch, query)
+			if rateLimited(batchStats.Errors) {
+				throttled.Store(true)
+			}
 
 			mu.Lock()
+			result.SkippedSources = append(result.SkippedSources, batchStats.SkippedSources...)
 			result.TotalFetched += batchStats.TotalFetched
 			result.TotalCreated += batchStats.TotalCreated
 			result.TotalDuplicates += batchStats.TotalDuplicates
 			result.TotalErrors += batchStats.TotalErrors
+			mergeRejections(result, batchStats.RejectionCounts)
 
 			for k, v := range batchStats.Sources {
 				result.Sources[k] = v
@@ -288,23 +467,51 @@ func (s *aggregatorService) aggregateBySources(ctx context.Context, sources []st
 	}
 
 	wg.Wait()
+	markInterrupted(ctx, result)
+
 	return result
 }
 
-// processSourceNews processes news from a batch of sources
-func (s *aggregatorService) processSourceNews(ctx context.Context, sources []string) *model.AggregationResponse {
+// processSourceNews processes news from a batch of sources. The whole batch is
+// reported as skipped when the run's context ends before every article was
+// fetched and stored.
+func (s *aggregatorService) processSourceNews(ctx context.Context, sources []string, query model.AggregationQuery) *model.AggregationResponse {
 	result := &model.AggregationResponse{
 		Sources: make(map[string]model.SourceStats),
-		Errors:  []string{},
+		Errors:  []model.AggregationError{},
 	}
 
-	response, err := s.newsService.GetNewsBySources(ctx, sources, 100)
-	if err != nil {
-		errorMsg := fmt.Sprintf("Failed to fetch news for sources %v: %v", sources, err)
-		s.logger.Error(errorMsg)
-		result.Errors = append(result.Errors, errorMsg)
-		result.TotalErrors++
-		return result
+	params := model.NewsParams{
+		Sources:  sources,
+		Language: languageOrDefault(query.Language),
+		PageSize: pageSizeOrDefault(query.PageSize, defaultSourcePageSize),
+		From:     query.From,
+		To:       query.To,
+	}
+
+	if lastFetched := s.lastFetched(ctx, query, model.FetchScopeSource, sources); lastFetched != nil {
+		params.SeenUntil = lastFetched
+		params.From = s.resumeFrom(*lastFetched)
+	}
+
+	// A response alongside an error holds the pages fetched before it
+	response, fetchErr := s.newsService.GetEverything(ctx, &params)
+	if fetchErr != nil {
+		if interrupted(fetchErr) {
+			s.logger.Warn("Skipped source news", "sources", sources, "error", fetchErr.Error())
+			result.SkippedSources = sources
+			if response == nil {
+				return result
+			}
+		} else {
+			err := providerError(fmt.Errorf("sources %v: %w", sources, fetchErr))
+			s.logger.Error("Failed to fetch news for sources", "sources", sources, "error", err.Error())
+			result.Errors = append(result.Errors, newAggregationError(err))
+			result.TotalErrors++
+			if response == nil {
+				return result
+			}
+		}
 	}
 
 	result.TotalFetched = len(response.Articles)
@@ -314,12 +521,27 @@ func (s *aggregatorService) processSourceNews(ctx context.Context, sources []str
 		sourceStats[source] = model.SourceStats{}
 	}
 
+	var accepted []model.NewsAPIArticleParams
 	for _, article := range response.Articles {
 		sourceName := article.Source.Name
 
-		post, err := s.postService.CreatePostFromNewsAPI(ctx, &article)
+		if rejection := s.filter.Check(ctx, &article); rejection != nil {
+			mergeRejections(result, map[model.FilterRule]int{rejection.Rule: 1})
+			if stats, ok := sourceStats[sourceName]; ok {
+				stats.Rejected++
+				sourceStats[sourceName] = stats
+			}
+			continue
+		}
+
+		accepted = append(accepted, article)
+	}
+
+	skipped, err := s.persistArticles(ctx, accepted, func(article *model.NewsAPIArticleParams, err error) {
+		sourceName := article.Source.Name
+
 		if err != nil {
-			if err.Error() == "post with this URL already exists" {
+			if errors.Is(err, ErrPostExists) {
 				result.TotalDuplicates++
 				if stats, ok := sourceStats[sourceName]; ok {
 					stats.Duplicates++
@@ -327,6 +549,7 @@ func (s *aggregatorService) processSourceNews(ctx context.Context, sources []str
 				}
 			} else {
 				result.TotalErrors++
+				result.Errors = append(result.Errors, newAggregationError(fmt.Errorf("%s: %w", article.URL, err)))
 				if stats, ok := sourceStats[sourceName]; ok {
 					stats.Errors++
 					sourceStats[sourceName] = stats
@@ -337,17 +560,24 @@ func (s *aggregatorService) processSourceNews(ctx context.Context, sources []str
 					"error", err.Error(),
 				)
 			}
-			continue
+			return
 		}
 
-		if post != nil {
-			result.TotalCreated++
-			if stats, ok := sourceStats[sourceName]; ok {
-				stats.Created++
-				stats.Fetched++
-				sourceStats[sourceName] = stats
-			}
+		result.TotalCreated++
+		if stats, ok := sourceStats[sourceName]; ok {
+			stats.Created++
+			stats.Fetched++
+			sourceStats[sourceName] = stats
 		}
+	})
+	if err != nil {
+		result.SkippedSources = sources
+		s.logger.Warn("Stopped storing source news", "sources", sources, "skipped", skipped, "error", err.Error())
+	}
+
+	// Pages missed by a failed request are fetched again next run
+	if err == nil && fetchErr == nil {
+		s.saveWatermarks(ctx, query, model.FetchScopeSource, sources, response.Articles)
 	}
 
 	result.Sources = sourceStats
@@ -358,7 +588,192 @@ func (s *aggregatorService) processSourceNews(ctx context.Context, sources []str
 		"created", result.TotalCreated,
 		"duplicates", result.TotalDuplicates,
 		"errors", result.TotalErrors,
+		"rejected", result.TotalRejected,
 	)
 
 	return result
 }
+
+// lastFetched returns the oldest watermark of keys, before which every
+// article has already been fetched. It returns nil, for a regular fetch, when
+// incremental fetching is off, the query has its own date range, or a key has
+// not been fetched before.
+func (s *aggregatorService) lastFetched(ctx context.Context, query model.AggregationQuery, scope model.FetchScope, keys []string) *time.Time {
+	if !s.ingest.Incremental || query.From != nil || query.To != nil {
+		return nil
+	}
+
+	watermarks, err := s.watermarks.GetWatermarks(ctx, scope, keys)
+	if err != nil {
+		s.logger.Warn("Failed to load fetch watermarks", "scope", scope, "keys", keys, "error", err.Error())
+		return nil
+	}
+
+	var oldest time.Time
+	for i, key := range keys {
+		watermark, ok := watermarks[key]
+		if !ok {
+			return nil
+		}
+		if i == 0 || watermark.Before(oldest) {
+			oldest = watermark
+		}
+	}
+
+	return &oldest
+}
+
+// resumeFrom returns the start of the date range for an incremental fetch,
+// reaching back Overlap before lastFetched for articles indexed late
+func (s *aggregatorService) resumeFrom(lastFetched time.Time) *time.Time {
+	from := lastFetched.Add(-s.ingest.Overlap)
+	return &from
+}
+
+// saveWatermarks advances the watermark of every key to the newest of the
+// fetched articles, so the next incremental run resumes from there
+func (s *aggregatorService) saveWatermarks(ctx context.Context, query model.AggregationQuery, scope model.FetchScope, keys []string, articles []model.NewsAPIArticleParams) {
+	if !s.ingest.Incremental || query.From != nil || query.To != nil {
+		return
+	}
+
+	newest := newestPublished(articles)
+	if newest.IsZero() {
+		return
+	}
+
+	watermarks := make(map[string]time.Time, len(keys))
+	for _, key := range keys {
+		watermarks[key] = newest
+	}
+
+	if err := s.watermarks.SaveWatermarks(ctx, scope, watermarks); err != nil {
+		s.logger.Warn("Failed to save fetch watermarks", "scope", scope, "keys", keys, "error", err.Error())
+	}
+}
+
+// newestPublished returns the latest publication time among articles, or the
+// zero time when none has a valid timestamp
+func newestPublished(articles []model.NewsAPIArticleParams) time.Time {
+	var newest time.Time
+	for _, article := range articles {
+		t, err := time.Parse(time.RFC3339, article.PublishedAt)
+		if err == nil && t.After(newest) {
+			newest = t
+		}
+	}
+
+	return newest
+}
+
+// markInterrupted sorts the skipped categories and sources of a result and
+// flags it as timed out when work was skipped because its deadline passed
+func markInterrupted(ctx context.Context, result *model.AggregationResponse) {
+	sort.Strings(result.SkippedCategories)
+	sort.Strings(result.SkippedSources)
+
+	skipped := len(result.SkippedCategories) > 0 || len(result.SkippedSources) > 0
+	result.TimedOut = skipped && errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// persistArticles creates a post from each article on the shared worker pool
+// and waits for them all. record is called with each article's outcome, one
+// call at a time; duplicates are reported as ErrPostExists and other
+// failures are classified by storageError and kept as dead letters. Once
+// ctx is done no more articles are queued, but those already queued are
+// still stored so the run drains cleanly; the number of articles left
+// unqueued is returned with the error.
+func (s *aggregatorService) persistArticles(ctx context.Context, articles []model.NewsAPIArticleParams, record func(article *model.NewsAPIArticleParams, err error)) (int, error) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	// Queued articles outlive a cancelled run; ctx values such as the tenant are kept
+	storeCtx := context.WithoutCancel(ctx)
+
+	for i := range articles {
+		article := &articles[i]
+
+		wg.Add(1)
+		err := s.persist.Submit(ctx, func() {
+			defer wg.Done()
+
+			post, err := s.postService.CreatePostFromNewsAPI(storeCtx, article)
+			// A nil post without an error means the URL was already stored
+			if post == nil && err == nil {
+				err = ErrPostExists
+			}
+			if err != nil {
+				err = storageError(err)
+			}
+			if err != nil && !errors.Is(err, ErrPostExists) {
+				s.deadLetters.Record(storeCtx, article, err)
+			}
+
+			mu.Lock()
+			record(article, err)
+			mu.Unlock()
+		})
+		if err != nil {
+			wg.Done()
+			wg.Wait()
+			return len(articles) - i, storageError(fmt.Errorf("aggregation stopped with %d articles unstored: %w", len(articles)-i, err))
+		}
+	}
+
+	wg.Wait()
+
+	return 0, nil
+}
+
+// pageSizeOrDefault returns the requested page size, or fallback when unset
+func pageSizeOrDefault(pageSize, fallback int) int {
+	if pageSize > 0 {
+		return pageSize
+	}
+	return fallback
+}
+
+// languageOrDefault returns the requested language, or the default when unset
+func languageOrDefault(language string) string {
+	if language != "" {
+		return language
+	}
+	return defaultLanguage
+}
+
+// addStats returns the field-wise sum of two stat counters
+func addStats(a, b model.BaseStats) model.BaseStats {
+	return model.BaseStats{
+		Fetched:    a.Fetched + b.Fetched,
+		Created:    a.Created + b.Created,
+		Duplicates: a.Duplicates + b.Duplicates,
+		Errors:     a.Errors + b.Errors,
+		Rejected:   a.Rejected + b.Rejected,
+	}
+}
+
+// mergeRejections adds per-rule rejection counts to the result and its total
+func mergeRejections(result *model.AggregationResponse, counts map[model.FilterRule]int) {
+	for rule, count := range counts {
+		if result.RejectionCounts == nil {
+			result.RejectionCounts = make(map[model.FilterRule]int)
+		}
+		result.RejectionCounts[rule] += count
+		result.TotalRejected += count
+	}
+}
+
+// normalizeCountries lowercases and de-duplicates country codes, dropping blanks
+func normalizeCountries(countries []string) []string {
+	seen := make(map[string]bool, len(countries))
+	normalized := make([]string, 0, len(countries))
+	for _, country := range countries {
+		country = strings.ToLower(strings.TrimSpace(country))
+		if country == "" || seen[country] {
+			continue
+		}
+		seen[country] = true
+		normalized = append(normalized, country)
+	}
+	return normalized
+}
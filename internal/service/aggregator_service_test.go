@@ -3,12 +3,15 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/model"
 	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/workerpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -35,8 +38,8 @@ func (m *MockNewsService) GetEverything(ctx context.Context, req *model.NewsPara
 	return args.Get(0).(*model.NewsAPIResponse), args.Error(1)
 }
 
-func (m *MockNewsService) GetNewsByCategory(ctx context.Context, category string, pageSize int) (*model.NewsAPIResponse, error) {
-	args := m.Called(ctx, category, pageSize)
+func (m *MockNewsService) GetNewsByCategory(ctx context.Context, category, country string, pageSize int) (*model.NewsAPIResponse, error) {
+	args := m.Called(ctx, category, country, pageSize)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -51,6 +54,18 @@ func (m *MockNewsService) GetNewsBySources(ctx context.Context, sources []string
 	return args.Get(0).(*model.NewsAPIResponse), args.Error(1)
 }
 
+func (m *MockNewsService) GetSources(ctx context.Context, req *model.NewsSourcesParams) (*model.NewsSourcesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NewsSourcesResponse), args.Error(1)
+}
+
+func (m *MockNewsService) SetAPIKey(apiKey string) {
+	m.Called(apiKey)
+}
+
 // MockPostService is a mock implementation of PostService
 type MockPostService struct {
 	mock.Mock
@@ -69,8 +84,16 @@ func (m *MockPostService) PostExists(ctx context.Context, url string) (bool, err
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockPostService) GetPostByID(ctx context.Context, id int64) (*model.Post, error) {
-	args := m.Called(ctx, id)
+func (m *MockPostService) GetPostByID(ctx context.Context, id int64, viewer string) (*model.Post, error) {
+	args := m.Called(ctx, id, viewer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Post), args.Error(1)
+}
+
+func (m *MockPostService) GetPostByURL(ctx context.Context, url string) (*model.Post, error) {
+	args := m.Called(ctx, url)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -85,6 +108,14 @@ func (m *MockPostService) ListPosts(ctx context.Context, req *model.PostListPara
 	return args.Get(0).(*model.PostListResponse), args.Error(1)
 }
 
+func (m *MockPostService) DiscoverPosts(ctx context.Context, req *model.DiscoverPostsParams) ([]model.Post, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Post), args.Error(1)
+}
+
 func (m *MockPostService) UpdatePost(ctx context.Context, id int64, req *model.UpdatePostParams) (*model.Post, error) {
 	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
@@ -98,6 +129,22 @@ func (m *MockPostService) DeletePost(ctx context.Context, id int64) error {
 	return args.Error(0)
 }
 
+func (m *MockPostService) PublishPost(ctx context.Context, id int64) (*model.Post, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Post), args.Error(1)
+}
+
+func (m *MockPostService) HidePost(ctx context.Context, id int64) (*model.Post, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Post), args.Error(1)
+}
+
 func (m *MockPostService) CreatePostFromNewsAPI(ctx context.Context, article *model.NewsAPIArticleParams) (*model.Post, error) {
 	args := m.Called(ctx, article)
 	if args.Get(0) == nil {
@@ -106,11 +153,70 @@ func (m *MockPostService) CreatePostFromNewsAPI(ctx context.Context, article *mo
 	return args.Get(0).(*model.Post), args.Error(1)
 }
 
+func (m *MockPostService) PreviewPostFromNewsAPI(ctx context.Context, article *model.NewsAPIArticleParams) (*model.ArticlePreview, error) {
+	args := m.Called(ctx, article)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ArticlePreview), args.Error(1)
+}
+
+func (m *MockPostService) RebuildURLFilter(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPostService) NormalizePostURLs(ctx context.Context, batchSize int) (*model.URLNormalizationResult, error) {
+	args := m.Called(ctx, batchSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.URLNormalizationResult), args.Error(1)
+}
+
+func (m *MockPostService) BulkUpdatePosts(ctx context.Context, req *model.BulkUpdatePostsParams) (*model.BulkPostsResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BulkPostsResult), args.Error(1)
+}
+
+func (m *MockPostService) BulkDeletePosts(ctx context.Context, req *model.BulkDeletePostsParams) (*model.BulkPostsResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BulkPostsResult), args.Error(1)
+}
+
+// MockWatermarkRepository is a mock implementation of WatermarkRepository
+type MockWatermarkRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatermarkRepository) GetWatermarks(ctx context.Context, scope model.FetchScope, keys []string) (map[string]time.Time, error) {
+	args := m.Called(ctx, scope, keys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]time.Time), args.Error(1)
+}
+
+func (m *MockWatermarkRepository) SaveWatermarks(ctx context.Context, scope model.FetchScope, watermarks map[string]time.Time) error {
+	args := m.Called(ctx, scope, watermarks)
+	return args.Error(0)
+}
+
 // AggregatorServiceTestSuite defines the test suite for AggregatorService
 type AggregatorServiceTestSuite struct {
 	suite.Suite
 	mockNewsService *MockNewsService
 	mockPostService *MockPostService
+	watermarks      *MockWatermarkRepository
+	deadLetterRepo  *MockDeadLetterRepository
+	deadLetters     DeadLetterService
+	filter          ArticleFilterService
 	logger          *logger.Logger
 	service         AggregatorService
 	ctx             context.Context
@@ -121,14 +227,20 @@ func (suite *AggregatorServiceTestSuite) SetupTest() {
 
 	suite.mockNewsService = new(MockNewsService)
 	suite.mockPostService = new(MockPostService)
+	suite.watermarks = new(MockWatermarkRepository)
 	suite.logger = logger.New(cfg)
-	suite.service = NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.logger)
+	suite.filter = NewArticleFilterService(nil, noSourceRules{}, config.FilterConfig{}, suite.logger)
+	suite.deadLetterRepo = new(MockDeadLetterRepository)
+	suite.deadLetterRepo.On("SaveDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+	suite.deadLetters = NewDeadLetterService(suite.deadLetterRepo, suite.mockPostService, suite.logger)
+	suite.service = NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 2, QueueSize: 10}, suite.logger)
 	suite.ctx = context.Background()
 }
 
 func (suite *AggregatorServiceTestSuite) TearDownTest() {
 	suite.mockNewsService.AssertExpectations(suite.T())
 	suite.mockPostService.AssertExpectations(suite.T())
+	suite.watermarks.AssertExpectations(suite.T())
 }
 
 func (suite *AggregatorServiceTestSuite) createMockNewsAPIResponse(articleCount int) *model.NewsAPIResponse {
@@ -156,6 +268,18 @@ func (suite *AggregatorServiceTestSuite) createMockNewsAPIResponse(articleCount
 	}
 }
 
+// withCountry returns a copy of the article tagged with the country the aggregator fetched it for
+func withCountry(article model.NewsAPIArticleParams, country string) *model.NewsAPIArticleParams {
+	article.Country = country
+	return &article
+}
+
+// storeCtx matches the context posts are created with, which is detached from
+// the aggregation run so queued articles survive its cancellation
+var storeCtx = mock.MatchedBy(func(ctx context.Context) bool {
+	return ctx.Done() == nil
+})
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -187,11 +311,11 @@ func (suite *AggregatorServiceTestSuite) TestAggregateTopHeadlinesSuccess() {
 	categories := GetDefaultCategories()
 	for _, category := range categories {
 		mockResponse := suite.createMockNewsAPIResponse(3)
-		suite.mockNewsService.On("GetNewsByCategory", suite.ctx, category, 50).Return(mockResponse, nil)
+		suite.mockNewsService.On("GetTopHeadlines", suite.ctx, &model.NewsParams{Category: category, Country: "us", PageSize: 50}).Return(mockResponse, nil)
 
 		for _, article := range mockResponse.Articles {
 			mockPost := suite.createMockPost(1)
-			suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &article).Return(mockPost, nil)
+			suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, withCountry(article, "us")).Return(mockPost, nil)
 		}
 	}
 
@@ -210,20 +334,22 @@ func (suite *AggregatorServiceTestSuite) TestAggregateTopHeadlinesWithDuplicates
 	categories := []string{"technology"}
 
 	mockResponse := suite.createMockNewsAPIResponse(2)
-	suite.mockNewsService.On("GetNewsByCategory", suite.ctx, "technology", 50).Return(mockResponse, nil)
+	suite.mockNewsService.On("GetTopHeadlines", suite.ctx, &model.NewsParams{Category: "technology", Country: "us", PageSize: 50}).Return(mockResponse, nil)
 
 	mockPost := suite.createMockPost(1)
-	suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &mockResponse.Articles[0]).Return(mockPost, nil)
-	suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &mockResponse.Articles[1]).Return(nil, ErrPostExists)
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, withCountry(mockResponse.Articles[0], "us")).Return(mockPost, nil)
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, withCountry(mockResponse.Articles[1], "us")).Return(nil, ErrPostExists)
 
 	service := &aggregatorService{
 		newsService: suite.mockNewsService,
 		postService: suite.mockPostService,
+		filter:      suite.filter,
 		logger:      suite.logger,
 		maxWorkers:  5,
+		persist:     workerpool.New(2, 10),
 	}
 
-	result := service.aggregateByCategories(suite.ctx, categories, true)
+	result := service.aggregateByCategories(suite.ctx, categories, []string{"us"}, model.AggregationQuery{}, true)
 
 	assert.Equal(suite.T(), 2, result.TotalFetched)
 	assert.Equal(suite.T(), 1, result.TotalCreated)
@@ -234,16 +360,18 @@ func (suite *AggregatorServiceTestSuite) TestAggregateTopHeadlinesWithDuplicates
 func (suite *AggregatorServiceTestSuite) TestAggregateTopHeadlinesWithErrors() {
 	categories := []string{"technology"}
 
-	suite.mockNewsService.On("GetNewsByCategory", suite.ctx, "technology", 50).Return(nil, errors.New("API error"))
+	suite.mockNewsService.On("GetTopHeadlines", suite.ctx, &model.NewsParams{Category: "technology", Country: "us", PageSize: 50}).Return(nil, errors.New("API error"))
 
 	service := &aggregatorService{
 		newsService: suite.mockNewsService,
 		postService: suite.mockPostService,
+		filter:      suite.filter,
 		logger:      suite.logger,
 		maxWorkers:  5,
+		persist:     workerpool.New(2, 10),
 	}
 
-	result := service.aggregateByCategories(suite.ctx, categories, true)
+	result := service.aggregateByCategories(suite.ctx, categories, []string{"us"}, model.AggregationQuery{}, true)
 
 	assert.Equal(suite.T(), 0, result.TotalFetched)
 	assert.Equal(suite.T(), 0, result.TotalCreated)
@@ -256,15 +384,15 @@ func (suite *AggregatorServiceTestSuite) TestAggregateByCategoriesSuccess() {
 
 	for _, category := range categories {
 		mockResponse := suite.createMockNewsAPIResponse(2)
-		suite.mockNewsService.On("GetNewsByCategory", suite.ctx, category, 50).Return(mockResponse, nil)
+		suite.mockNewsService.On("GetTopHeadlines", suite.ctx, &model.NewsParams{Category: category, Country: "us", PageSize: 50}).Return(mockResponse, nil)
 
 		for _, article := range mockResponse.Articles {
 			mockPost := suite.createMockPost(1)
-			suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &article).Return(mockPost, nil)
+			suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, withCountry(article, "us")).Return(mockPost, nil)
 		}
 	}
 
-	result, err := suite.service.AggregateByCategories(suite.ctx, categories)
+	result, err := suite.service.AggregateByCategories(suite.ctx, categories, nil, model.AggregationQuery{})
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
@@ -274,10 +402,61 @@ func (suite *AggregatorServiceTestSuite) TestAggregateByCategoriesSuccess() {
 	assert.Len(suite.T(), result.Categories, 2)
 }
 
+func (suite *AggregatorServiceTestSuite) TestAggregateByCategoriesWithCountries() {
+	countries := []string{"us", "gb"}
+
+	for _, country := range countries {
+		mockResponse := suite.createMockNewsAPIResponse(1)
+		mockResponse.Articles[0].URL = "https://example.com/" + country
+		suite.mockNewsService.On("GetTopHeadlines", suite.ctx, &model.NewsParams{Category: "technology", Country: country, PageSize: 50}).Return(mockResponse, nil)
+		suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, withCountry(mockResponse.Articles[0], country)).Return(suite.createMockPost(1), nil)
+	}
+
+	result, err := suite.service.AggregateByCategories(suite.ctx, []string{"technology"}, []string{"US", "gb", "us"}, model.AggregationQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.TotalCreated)
+	assert.Equal(suite.T(), 2, result.Categories["technology"].Created)
+	assert.Len(suite.T(), result.Countries, 2)
+	assert.Equal(suite.T(), 1, result.Countries["us"].Created)
+	assert.Equal(suite.T(), 1, result.Countries["gb"].Created)
+}
+
+func (suite *AggregatorServiceTestSuite) TestAggregateByCategoriesWithQueryOverrides() {
+	from := time.Now().Add(-time.Hour)
+	query := model.AggregationQuery{PageSize: 10, Language: "de", From: &from}
+
+	mockResponse := suite.createMockNewsAPIResponse(2)
+	mockResponse.Articles[1].PublishedAt = time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+	suite.mockNewsService.On("GetTopHeadlines", suite.ctx, &model.NewsParams{Category: "technology", Country: "us", Language: "de", PageSize: 10}).Return(mockResponse, nil)
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, withCountry(mockResponse.Articles[0], "us")).Return(suite.createMockPost(1), nil)
+
+	result, err := suite.service.AggregateByCategories(suite.ctx, []string{"technology"}, nil, query)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalFetched)
+	assert.Equal(suite.T(), 1, result.TotalCreated)
+}
+
+func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesWithQueryOverrides() {
+	from := time.Now().Add(-24 * time.Hour)
+	to := time.Now()
+	sources := []string{"techcrunch"}
+	query := model.AggregationQuery{PageSize: 20, Language: "fr", From: &from, To: &to}
+
+	mockResponse := suite.createMockNewsAPIResponse(0)
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Sources: sources, Language: "fr", PageSize: 20, From: &from, To: &to}).Return(mockResponse, nil)
+
+	result, err := suite.service.AggregateBySources(suite.ctx, sources, query)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.TotalErrors)
+}
+
 func (suite *AggregatorServiceTestSuite) TestAggregateByCategoriesEmptyList() {
 	categories := []string{}
 
-	result, err := suite.service.AggregateByCategories(suite.ctx, categories)
+	result, err := suite.service.AggregateByCategories(suite.ctx, categories, nil, model.AggregationQuery{})
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
@@ -291,14 +470,14 @@ func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesSuccess() {
 	sources := []string{"techcrunch", "bbc-news"}
 
 	mockResponse := suite.createMockNewsAPIResponse(3)
-	suite.mockNewsService.On("GetNewsBySources", suite.ctx, []string{"techcrunch", "bbc-news"}, 100).Return(mockResponse, nil)
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Sources: []string{"techcrunch", "bbc-news"}, Language: "en", PageSize: 100}).Return(mockResponse, nil)
 
 	for _, article := range mockResponse.Articles {
 		mockPost := suite.createMockPost(1)
-		suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &article).Return(mockPost, nil)
+		suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, &article).Return(mockPost, nil)
 	}
 
-	result, err := suite.service.AggregateBySources(suite.ctx, sources)
+	result, err := suite.service.AggregateBySources(suite.ctx, sources, model.AggregationQuery{})
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
@@ -311,63 +490,128 @@ func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesSuccess() {
 func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesWithNewsServiceError() {
 	sources := []string{"techcrunch"}
 
-	suite.mockNewsService.On("GetNewsBySources", suite.ctx, sources, 100).Return(nil, errors.New("API error"))
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Sources: sources, Language: "en", PageSize: 100}).Return(nil, errors.New("API error"))
 
-	result, err := suite.service.AggregateBySources(suite.ctx, sources)
+	result, err := suite.service.AggregateBySources(suite.ctx, sources, model.AggregationQuery{})
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
 	assert.Equal(suite.T(), 0, result.TotalFetched)
 	assert.Equal(suite.T(), 0, result.TotalCreated)
 	assert.Equal(suite.T(), 1, result.TotalErrors)
-	assert.NotEmpty(suite.T(), result.Errors)
+	assert.Len(suite.T(), result.Errors, 1)
+	assert.Equal(suite.T(), model.AggregationErrorProvider, result.Errors[0].Type)
+	assert.Equal(suite.T(), 1, result.ErrorCounts[model.AggregationErrorProvider])
 }
 
 func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesWithPostServiceError() {
 	sources := []string{"techcrunch"}
 
 	mockResponse := suite.createMockNewsAPIResponse(2)
-	suite.mockNewsService.On("GetNewsBySources", suite.ctx, sources, 100).Return(mockResponse, nil)
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Sources: sources, Language: "en", PageSize: 100}).Return(mockResponse, nil)
 
 	mockPost := suite.createMockPost(1)
-	suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &mockResponse.Articles[0]).Return(mockPost, nil)
-	suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &mockResponse.Articles[1]).Return(nil, errors.New("database error"))
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, &mockResponse.Articles[0]).Return(mockPost, nil)
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, &mockResponse.Articles[1]).Return(nil, errors.New("database error"))
 
-	result, err := suite.service.AggregateBySources(suite.ctx, sources)
+	result, err := suite.service.AggregateBySources(suite.ctx, sources, model.AggregationQuery{})
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
 	assert.Equal(suite.T(), 2, result.TotalFetched)
 	assert.Equal(suite.T(), 1, result.TotalCreated)
 	assert.Equal(suite.T(), 1, result.TotalErrors)
+	assert.Equal(suite.T(), 1, result.ErrorCounts[model.AggregationErrorDB])
+	suite.deadLetterRepo.AssertCalled(suite.T(), "SaveDeadLetter", storeCtx, mock.MatchedBy(func(letter *model.DeadLetter) bool {
+		return letter.URL == mockResponse.Articles[1].URL && letter.ErrorType == model.AggregationErrorDB &&
+			strings.Contains(letter.Error, "database error")
+	}))
+	suite.deadLetterRepo.AssertNumberOfCalls(suite.T(), "SaveDeadLetter", 1)
 }
 
-func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesWithDuplicateByErrorMessage() {
+func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesWithWrappedDuplicate() {
 	sources := []string{"techcrunch"}
 
-	mockResponse := suite.createMockNewsAPIResponse(1)
-	suite.mockNewsService.On("GetNewsBySources", suite.ctx, sources, 100).Return(mockResponse, nil)
+	mockResponse := suite.createMockNewsAPIResponse(2)
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Sources: sources, Language: "en", PageSize: 100}).Return(mockResponse, nil)
 
-	suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &mockResponse.Articles[0]).Return(nil, errors.New("post with this URL already exists"))
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, &mockResponse.Articles[0]).Return(nil, fmt.Errorf("create: %w", ErrPostExists))
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, &mockResponse.Articles[1]).Return(nil, nil)
 
-	result, err := suite.service.AggregateBySources(suite.ctx, sources)
+	result, err := suite.service.AggregateBySources(suite.ctx, sources, model.AggregationQuery{})
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
-	assert.Equal(suite.T(), 1, result.TotalFetched)
+	assert.Equal(suite.T(), 2, result.TotalFetched)
 	assert.Equal(suite.T(), 0, result.TotalCreated)
-	assert.Equal(suite.T(), 1, result.TotalDuplicates)
+	assert.Equal(suite.T(), 2, result.TotalDuplicates)
+	assert.Empty(suite.T(), result.Errors)
+	assert.Equal(suite.T(), 2, result.ErrorCounts[model.AggregationErrorDuplicate])
+	suite.deadLetterRepo.AssertNotCalled(suite.T(), "SaveDeadLetter", mock.Anything, mock.Anything)
+}
+
+func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesClassifiesParseErrors() {
+	sources := []string{"techcrunch"}
+
+	mockResponse := suite.createMockNewsAPIResponse(1)
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Sources: sources, Language: "en", PageSize: 100}).Return(mockResponse, nil)
+
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, &mockResponse.Articles[0]).Return(nil, fmt.Errorf("convert: %w", ErrArticleParse))
+
+	result, err := suite.service.AggregateBySources(suite.ctx, sources, model.AggregationQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalErrors)
+	assert.Equal(suite.T(), model.AggregationErrorParse, result.Errors[0].Type)
+}
+
+func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesRejectsFilteredArticles() {
+	sources := []string{"techcrunch"}
+	filter := NewArticleFilterService(nil, noSourceRules{}, config.FilterConfig{BlockedDomains: []string{"spam.example.com"}}, suite.logger)
+	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 2, QueueSize: 10}, suite.logger)
+
+	mockResponse := suite.createMockNewsAPIResponse(2)
+	mockResponse.Articles[1].URL = "https://news.spam.example.com/article"
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Sources: sources, Language: "en", PageSize: 100}).Return(mockResponse, nil)
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, &mockResponse.Articles[0]).Return(&model.Post{ID: 1}, nil)
+
+	result, err := service.AggregateBySources(suite.ctx, sources, model.AggregationQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalCreated)
+	assert.Equal(suite.T(), 1, result.TotalRejected)
+	assert.Equal(suite.T(), 1, result.RejectionCounts[model.FilterRuleBlockedDomain])
+	suite.mockPostService.AssertNumberOfCalls(suite.T(), "CreatePostFromNewsAPI", 1)
+}
+
+func (suite *AggregatorServiceTestSuite) TestAggregateByCategoriesRejectsFilteredArticles() {
+	filter := NewArticleFilterService(nil, noSourceRules{}, config.FilterConfig{MinContentLength: 100}, suite.logger)
+	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 2, QueueSize: 10}, suite.logger)
+
+	mockResponse := suite.createMockNewsAPIResponse(2)
+	mockResponse.Articles[0].Content = stringPtr("Short teaser… [+2400 chars]")
+	suite.mockNewsService.On("GetTopHeadlines", suite.ctx, &model.NewsParams{Category: "technology", Country: "us", PageSize: 50}).Return(mockResponse, nil)
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, withCountry(mockResponse.Articles[0], "us")).Return(&model.Post{ID: 1}, nil)
+
+	result, err := service.AggregateByCategories(suite.ctx, []string{"technology"}, nil, model.AggregationQuery{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.TotalCreated)
+	assert.Equal(suite.T(), 1, result.TotalRejected)
+	assert.Equal(suite.T(), 1, result.Categories["technology"].Rejected)
+	assert.Equal(suite.T(), 1, result.Countries["us"].Rejected)
+	assert.Equal(suite.T(), 1, result.RejectionCounts[model.FilterRuleMinContentLength])
 }
 
 func (suite *AggregatorServiceTestSuite) TestAggregateAllSuccess() {
 	categories := GetDefaultCategories()
 	for _, category := range categories {
 		mockResponse := suite.createMockNewsAPIResponse(1)
-		suite.mockNewsService.On("GetNewsByCategory", suite.ctx, category, 50).Return(mockResponse, nil)
+		suite.mockNewsService.On("GetTopHeadlines", suite.ctx, &model.NewsParams{Category: category, Country: "us", PageSize: 50}).Return(mockResponse, nil)
 
 		for _, article := range mockResponse.Articles {
 			mockPost := suite.createMockPost(1)
-			suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &article).Return(mockPost, nil)
+			suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, withCountry(article, "us")).Return(mockPost, nil)
 		}
 	}
 
@@ -380,11 +624,11 @@ func (suite *AggregatorServiceTestSuite) TestAggregateAllSuccess() {
 		batch := sources[i:end]
 
 		mockResponse := suite.createMockNewsAPIResponse(1)
-		suite.mockNewsService.On("GetNewsBySources", suite.ctx, batch, 100).Return(mockResponse, nil)
+		suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Sources: batch, Language: "en", PageSize: 100}).Return(mockResponse, nil)
 
 		for _, article := range mockResponse.Articles {
 			mockPost := suite.createMockPost(1)
-			suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, &article).Return(mockPost, nil)
+			suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, &article).Return(mockPost, nil)
 		}
 	}
 
@@ -400,7 +644,7 @@ func (suite *AggregatorServiceTestSuite) TestAggregateAllSuccess() {
 }
 
 func (suite *AggregatorServiceTestSuite) TestNewAggregatorService() {
-	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.logger)
+	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 2, QueueSize: 10}, suite.logger)
 
 	assert.NotNil(suite.T(), service)
 
@@ -409,6 +653,7 @@ func (suite *AggregatorServiceTestSuite) TestNewAggregatorService() {
 
 	assert.Equal(suite.T(), suite.mockNewsService, aggregatorServiceImpl.newsService)
 	assert.Equal(suite.T(), suite.mockPostService, aggregatorServiceImpl.postService)
+	assert.Equal(suite.T(), suite.filter, aggregatorServiceImpl.filter)
 	assert.Equal(suite.T(), suite.logger, aggregatorServiceImpl.logger)
 	assert.Equal(suite.T(), 5, aggregatorServiceImpl.maxWorkers)
 }
@@ -421,17 +666,190 @@ func (suite *AggregatorServiceTestSuite) TestAggregateTopHeadlinesContextCancele
 	service := &aggregatorService{
 		newsService: suite.mockNewsService,
 		postService: suite.mockPostService,
+		filter:      suite.filter,
 		logger:      suite.logger,
 		maxWorkers:  5,
+		persist:     workerpool.New(2, 10),
 	}
 
-	suite.mockNewsService.On("GetNewsByCategory", canceledCtx, "technology", 50).Return(nil, context.Canceled).Maybe()
+	suite.mockNewsService.On("GetTopHeadlines", canceledCtx, &model.NewsParams{Category: "technology", Country: "us", PageSize: 50}).Return(nil, context.Canceled).Maybe()
 
-	result := service.aggregateByCategories(canceledCtx, categories, true)
+	result := service.aggregateByCategories(canceledCtx, categories, []string{"us"}, model.AggregationQuery{}, true)
 
 	assert.Equal(suite.T(), 0, result.TotalFetched)
 	assert.Equal(suite.T(), 0, result.TotalCreated)
-	assert.Greater(suite.T(), result.TotalErrors, 0)
+	assert.Equal(suite.T(), 0, result.TotalErrors)
+	assert.Equal(suite.T(), []string{"technology"}, result.SkippedCategories)
+	assert.False(suite.T(), result.TimedOut)
+}
+
+func (suite *AggregatorServiceTestSuite) TestAggregateByCategoriesDeadlineReturnsPartialResults() {
+	ctx, cancel := context.WithTimeout(suite.ctx, time.Hour)
+	defer cancel()
+
+	mockResponse := suite.createMockNewsAPIResponse(1)
+	suite.mockNewsService.On("GetTopHeadlines", ctx, &model.NewsParams{Category: "business", Country: "us", PageSize: 50}).Return(mockResponse, nil)
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, withCountry(mockResponse.Articles[0], "us")).Return(suite.createMockPost(1), nil)
+	// The deadline passes while technology is being fetched
+	suite.mockNewsService.On("GetTopHeadlines", ctx, &model.NewsParams{Category: "technology", Country: "us", PageSize: 50}).Return(nil, fmt.Errorf("request: %w", context.DeadlineExceeded))
+
+	service := &aggregatorService{
+		newsService: suite.mockNewsService,
+		postService: suite.mockPostService,
+		filter:      suite.filter,
+		logger:      suite.logger,
+		maxWorkers:  1,
+		persist:     workerpool.New(2, 10),
+	}
+
+	result := service.aggregateByCategories(ctx, []string{"business", "technology"}, []string{"us"}, model.AggregationQuery{}, true)
+
+	assert.Equal(suite.T(), 1, result.TotalCreated)
+	assert.Equal(suite.T(), 0, result.TotalErrors)
+	assert.Equal(suite.T(), []string{"technology"}, result.SkippedCategories)
+	// The context itself has not expired, so the run is not flagged as timed out
+	assert.False(suite.T(), result.TimedOut)
+}
+
+func (suite *AggregatorServiceTestSuite) TestAggregateBySourcesTimedOut() {
+	ctx, cancel := context.WithDeadline(suite.ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	service := &aggregatorService{
+		newsService: suite.mockNewsService,
+		postService: suite.mockPostService,
+		filter:      suite.filter,
+		logger:      suite.logger,
+		maxWorkers:  5,
+		persist:     workerpool.New(2, 10),
+	}
+
+	result := service.aggregateBySources(ctx, []string{"wired", "bbc-news", "techcrunch", "the-verge"}, model.AggregationQuery{})
+
+	assert.True(suite.T(), result.TimedOut)
+	assert.Equal(suite.T(), []string{"bbc-news", "techcrunch", "the-verge", "wired"}, result.SkippedSources)
+	assert.Equal(suite.T(), 0, result.TotalErrors)
+	suite.mockNewsService.AssertNotCalled(suite.T(), "GetEverything", mock.Anything, mock.Anything)
+}
+
+func (suite *AggregatorServiceTestSuite) TestPersistArticlesSingleWorker() {
+	mockResponse := suite.createMockNewsAPIResponse(3)
+	for i, article := range mockResponse.Articles {
+		suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, &article).Return(suite.createMockPost(int64(i+1)), nil)
+	}
+
+	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 1, QueueSize: 0}, suite.logger).(*aggregatorService)
+
+	created := 0
+	skipped, err := service.persistArticles(suite.ctx, mockResponse.Articles, func(_ *model.NewsAPIArticleParams, err error) {
+		assert.NoError(suite.T(), err)
+		created++
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, skipped)
+	assert.Equal(suite.T(), 3, created)
+	suite.mockPostService.AssertNumberOfCalls(suite.T(), "CreatePostFromNewsAPI", 3)
+}
+
+func (suite *AggregatorServiceTestSuite) TestPersistArticlesContextCanceled() {
+	canceledCtx, cancel := context.WithCancel(suite.ctx)
+	cancel()
+
+	mockResponse := suite.createMockNewsAPIResponse(3)
+	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 1, QueueSize: 10}, suite.logger).(*aggregatorService)
+
+	skipped, err := service.persistArticles(canceledCtx, mockResponse.Articles, func(*model.NewsAPIArticleParams, error) {
+		suite.T().Error("no article should be stored")
+	})
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+	assert.Equal(suite.T(), 3, skipped)
+	suite.mockPostService.AssertNotCalled(suite.T(), "CreatePostFromNewsAPI", mock.Anything, mock.Anything)
+}
+
+// articlesPublishedAt returns a response with one article per timestamp, each
+// with a distinct URL
+func (suite *AggregatorServiceTestSuite) articlesPublishedAt(totalResults int, published ...time.Time) *model.NewsAPIResponse {
+	response := suite.createMockNewsAPIResponse(len(published))
+	response.TotalResults = totalResults
+	for i, t := range published {
+		response.Articles[i].URL = fmt.Sprintf("https://example.com/%d", t.Unix())
+		response.Articles[i].PublishedAt = t.Format(time.RFC3339)
+	}
+	return response
+}
+
+func (suite *AggregatorServiceTestSuite) TestProcessCategoryNewsResumesFromWatermark() {
+	watermark := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+	resumeFrom := watermark.Add(-15 * time.Minute)
+
+	response := suite.articlesPublishedAt(4, watermark.Add(3*time.Hour), watermark.Add(2*time.Hour), watermark.Add(time.Hour), watermark.Add(-5*time.Minute))
+
+	suite.watermarks.On("GetWatermarks", suite.ctx, model.FetchScopeEverything, []string{"technology"}).Return(map[string]time.Time{"technology": watermark}, nil)
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Query: "technology", Language: "en", PageSize: 2, From: &resumeFrom, SeenUntil: &watermark}).Return(response, nil)
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, mock.Anything).Return(suite.createMockPost(1), nil).Times(4)
+	suite.watermarks.On("SaveWatermarks", suite.ctx, model.FetchScopeEverything, map[string]time.Time{"technology": watermark.Add(3 * time.Hour)}).Return(nil)
+
+	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 2, QueueSize: 10, Incremental: true, Overlap: 15 * time.Minute}, suite.logger).(*aggregatorService)
+
+	stats, errs, _, complete := service.processCategoryNews(suite.ctx, "technology", "", model.AggregationQuery{PageSize: 2}, false)
+
+	assert.True(suite.T(), complete)
+	assert.Empty(suite.T(), errs)
+	assert.Equal(suite.T(), 4, stats.Fetched)
+	assert.Equal(suite.T(), 4, stats.Created)
+}
+
+func (suite *AggregatorServiceTestSuite) TestProcessCategoryNewsFirstIncrementalRun() {
+	published := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+	response := suite.articlesPublishedAt(30, published, published.Add(-time.Hour))
+
+	suite.watermarks.On("GetWatermarks", suite.ctx, model.FetchScopeTopHeadlines, []string{"technology:us"}).Return(map[string]time.Time{}, nil)
+	suite.mockNewsService.On("GetTopHeadlines", suite.ctx, &model.NewsParams{Category: "technology", Country: "us", PageSize: 2}).Return(response, nil).Once()
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, mock.Anything).Return(suite.createMockPost(1), nil).Times(2)
+	suite.watermarks.On("SaveWatermarks", suite.ctx, model.FetchScopeTopHeadlines, map[string]time.Time{"technology:us": published}).Return(nil)
+
+	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 2, QueueSize: 10, Incremental: true}, suite.logger).(*aggregatorService)
+
+	stats, _, _, complete := service.processCategoryNews(suite.ctx, "technology", "us", model.AggregationQuery{PageSize: 2}, true)
+
+	assert.True(suite.T(), complete)
+	assert.Equal(suite.T(), 2, stats.Created)
+}
+
+func (suite *AggregatorServiceTestSuite) TestProcessSourceNewsKeepsWatermarkOnPageError() {
+	watermark := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+	page1 := suite.articlesPublishedAt(10, watermark.Add(2*time.Hour), watermark.Add(time.Hour))
+
+	suite.watermarks.On("GetWatermarks", suite.ctx, model.FetchScopeSource, []string{"techcrunch", "wired"}).
+		Return(map[string]time.Time{"techcrunch": watermark, "wired": watermark.Add(time.Hour)}, nil)
+	// The oldest watermark of the batch is used; the second page fails
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Sources: []string{"techcrunch", "wired"}, Language: "en", PageSize: 2, From: &watermark, SeenUntil: &watermark}).Return(page1, errors.New("rate limited"))
+	suite.mockPostService.On("CreatePostFromNewsAPI", storeCtx, mock.Anything).Return(suite.createMockPost(1), nil).Times(2)
+
+	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 2, QueueSize: 10, Incremental: true}, suite.logger).(*aggregatorService)
+
+	result := service.processSourceNews(suite.ctx, []string{"techcrunch", "wired"}, model.AggregationQuery{PageSize: 2})
+
+	assert.Equal(suite.T(), 2, result.TotalCreated)
+	assert.Equal(suite.T(), 1, result.TotalErrors)
+	assert.Empty(suite.T(), result.SkippedSources)
+	suite.watermarks.AssertNotCalled(suite.T(), "SaveWatermarks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AggregatorServiceTestSuite) TestProcessCategoryNewsExplicitRangeIgnoresWatermark() {
+	from := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	query := model.AggregationQuery{PageSize: 2, From: &from}
+
+	suite.mockNewsService.On("GetEverything", suite.ctx, &model.NewsParams{Query: "technology", Language: "en", PageSize: 2, From: &from}).Return(suite.articlesPublishedAt(0), nil)
+
+	service := NewAggregatorService(suite.mockNewsService, suite.mockPostService, suite.filter, suite.deadLetters, suite.watermarks, []string{"us"}, config.IngestConfig{Workers: 2, QueueSize: 10, Incremental: true}, suite.logger).(*aggregatorService)
+
+	_, _, _, complete := service.processCategoryNews(suite.ctx, "technology", "", query, false)
+
+	assert.True(suite.T(), complete)
+	suite.watermarks.AssertNotCalled(suite.T(), "GetWatermarks", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Run the test suite
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/botdetect"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// Column limits for click metadata, matching the post_clicks table
+const (
+	maxReferrerLength  = 1000
+	maxUserAgentLength = 500
+)
+
+// maxSearchTermLength matches the term column of the search_queries table
+const maxSearchTermLength = 200
+
+// maxPostStatsRange bounds the window of post statistics to keep the number
+// of buckets, and the rows counted, in check
+const maxPostStatsRange = 366 * 24 * time.Hour
+
+var (
+	ErrSearchTermEmpty       = errors.New("search term is empty")
+	ErrPostStatsRangeInvalid = errors.New("post stats range must start before it ends and span at most 366 days")
+)
+
+// analyticsService implements AnalyticsService interface
+type analyticsService struct {
+	postRepo   repository.PostRepository
+	clickRepo  repository.ClickRepository
+	searchRepo repository.SearchQueryRepository
+	logger     *logger.Logger
+}
+
+// NewAnalyticsService creates a new analytics service
+func NewAnalyticsService(postRepo repository.PostRepository, clickRepo repository.ClickRepository, searchRepo repository.SearchQueryRepository, logger *logger.Logger) AnalyticsService {
+	return &analyticsService{
+		postRepo:   postRepo,
+		clickRepo:  clickRepo,
+		searchRepo: searchRepo,
+		logger:     logger,
+	}
+}
+
+// RecordClick stores a click-through for a post and returns the post so callers can redirect to it.
+// No click is stored in read-only mode. When the click cannot be stored the post is returned along
+// with the error, so a redirect does not depend on it.
+func (s *analyticsService) RecordClick(ctx context.Context, postID int64, referrer, userAgent string) (*model.Post, error) {
+	start := time.Now()
+
+	if postID <= 0 {
+		s.logger.LogServiceOperation("analytics", "record_click", false, time.Since(start).Milliseconds())
+		return nil, ErrPostIDInvalid
+	}
+
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		s.logger.LogServiceOperation("analytics", "record_click", false, time.Since(start).Milliseconds())
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	if !post.IsPublished() {
+		s.logger.LogServiceOperation("analytics", "record_click", false, time.Since(start).Milliseconds())
+		return nil, ErrPostNotFound
+	}
+
+	// Bots follow links without reading; they still get the redirect
+	if botdetect.FromContext(ctx) || ReadOnlyFromContext(ctx) {
+		s.logger.LogServiceOperation("analytics", "record_click", true, time.Since(start).Milliseconds())
+		return post, nil
+	}
+
+	click := &model.PostClick{
+		PostID:    postID,
+		Referrer:  optionalTruncated(referrer, maxReferrerLength),
+		UserAgent: optionalTruncated(userAgent, maxUserAgentLength),
+	}
+	if err := s.clickRepo.RecordClick(ctx, click); err != nil {
+		s.logger.LogServiceOperation("analytics", "record_click", false, time.Since(start).Milliseconds())
+		return post, err
+	}
+
+	s.logger.LogServiceOperation("analytics", "record_click", true, time.Since(start).Milliseconds())
+
+	return post, nil
+}
+
+// GetCTRStats returns views, clicks and click-through rate per source or
+// category for posts created within the requested number of days
+func (s *analyticsService) GetCTRStats(ctx context.Context, req *model.CTRParams) (*model.CTRResponse, error) {
+	start := time.Now()
+
+	if req.GroupBy == "" {
+		req.GroupBy = model.CTRGroupBySource
+	}
+	if req.Days <= 0 {
+		req.Days = 7
+	}
+
+	since := time.Now().AddDate(0, 0, -req.Days)
+
+	counts, err := s.clickRepo.CountClicksByPost(ctx, since)
+	if err != nil {
+		s.logger.LogServiceOperation("analytics", "get_ctr_stats", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	ids := make([]int64, len(counts))
+	for i, count := range counts {
+		ids[i] = count.PostID
+	}
+
+	views, err := s.postRepo.GetPostViews(ctx, ids)
+	if err != nil {
+		s.logger.LogServiceOperation("analytics", "get_ctr_stats", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to get post views: %w", err)
+	}
+
+	groups := make(map[string]*model.CTRStat)
+	for _, count := range counts {
+		key := count.Source
+		if req.GroupBy == model.CTRGroupByCategory {
+			key = "uncategorized"
+			if count.Category != nil && *count.Category != "" {
+				key = *count.Category
+			}
+		}
+
+		stat, ok := groups[key]
+		if !ok {
+			stat = &model.CTRStat{Key: key}
+			groups[key] = stat
+		}
+		stat.Views += views[count.PostID]
+		stat.Clicks += count.Clicks
+	}
+
+	stats := make([]model.CTRStat, 0, len(groups))
+	for _, stat := range groups {
+		if stat.Views > 0 {
+			stat.CTR = float64(stat.Clicks) / float64(stat.Views)
+		}
+		stats = append(stats, *stat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Clicks != stats[j].Clicks {
+			return stats[i].Clicks > stats[j].Clicks
+		}
+		return stats[i].Key < stats[j].Key
+	})
+
+	s.logger.LogServiceOperation("analytics", "get_ctr_stats", true, time.Since(start).Milliseconds())
+
+	return &model.CTRResponse{GroupBy: req.GroupBy, Since: since, Stats: stats}, nil
+}
+
+// RecordSearch stores a search with its result count and latency. Terms are
+// normalised so that "Climate  Summit" and "climate summit" are counted together.
+// Nothing is stored in read-only mode.
+func (s *analyticsService) RecordSearch(ctx context.Context, term string, resultCount int64, latency time.Duration) error {
+	start := time.Now()
+
+	if ReadOnlyFromContext(ctx) {
+		return nil
+	}
+
+	term = normalizeSearchTerm(term)
+	if term == "" {
+		s.logger.LogServiceOperation("analytics", "record_search", false, time.Since(start).Milliseconds())
+		return ErrSearchTermEmpty
+	}
+
+	search := &model.SearchQuery{
+		Term:        term,
+		ResultCount: resultCount,
+		LatencyMs:   latency.Milliseconds(),
+		ZeroResults: resultCount == 0,
+	}
+	if err := s.searchRepo.RecordSearch(ctx, search); err != nil {
+		s.logger.LogServiceOperation("analytics", "record_search", false, time.Since(start).Milliseconds())
+		return err
+	}
+
+	s.logger.LogServiceOperation("analytics", "record_search", true, time.Since(start).Milliseconds())
+
+	return nil
+}
+
+// GetSearchAnalytics returns the most frequent search terms and the terms
+// that found nothing within the requested number of days
+func (s *analyticsService) GetSearchAnalytics(ctx context.Context, req *model.SearchAnalyticsParams) (*model.SearchAnalyticsResponse, error) {
+	start := time.Now()
+
+	if req.Days <= 0 {
+		req.Days = 7
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+
+	since := time.Now().AddDate(0, 0, -req.Days)
+
+	totals, err := s.searchRepo.CountSearches(ctx, since)
+	if err != nil {
+		s.logger.LogServiceOperation("analytics", "get_search_analytics", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	top, err := s.searchRepo.TopSearchTerms(ctx, since, false, req.Limit)
+	if err != nil {
+		s.logger.LogServiceOperation("analytics", "get_search_analytics", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	zero, err := s.searchRepo.TopSearchTerms(ctx, since, true, req.Limit)
+	if err != nil {
+		s.logger.LogServiceOperation("analytics", "get_search_analytics", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	result := &model.SearchAnalyticsResponse{
+		Since:              since,
+		TotalSearches:      totals.Searches,
+		ZeroResultSearches: totals.ZeroResultSearches,
+		TopQueries:         top,
+		ZeroResultQueries:  zero,
+	}
+	if totals.Searches > 0 {
+		result.ZeroResultRate = float64(totals.ZeroResultSearches) / float64(totals.Searches)
+	}
+
+	s.logger.LogServiceOperation("analytics", "get_search_analytics", true, time.Since(start).Milliseconds())
+
+	return result, nil
+}
+
+// GetPostStats counts the posts ingested per day within the requested window,
+// per category, source or country unless grouped by day alone. The window defaults to
+// the last 30 days, and days without posts are reported with a zero count
+// when grouping by day.
+func (s *analyticsService) GetPostStats(ctx context.Context, req *model.PostStatsParams) (*model.PostStatsResponse, error) {
+	start := time.Now()
+
+	if req.GroupBy == "" {
+		req.GroupBy = model.PostStatsGroupByDay
+	}
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+	if req.From.IsZero() {
+		req.From = req.To.AddDate(0, 0, -30)
+	}
+	req.From, req.To = req.From.UTC(), req.To.UTC()
+
+	if !req.From.Before(req.To) || req.To.Sub(req.From) > maxPostStatsRange {
+		s.logger.LogServiceOperation("analytics", "get_post_stats", false, time.Since(start).Milliseconds())
+		return nil, ErrPostStatsRangeInvalid
+	}
+
+	buckets, err := s.postRepo.PostStats(ctx, req.GroupBy, req.From, req.To)
+	if err != nil {
+		s.logger.LogServiceOperation("analytics", "get_post_stats", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	if req.GroupBy == model.PostStatsGroupByDay {
+		buckets = fillPostStatsDays(buckets, req.From, req.To)
+	}
+
+	result := &model.PostStatsResponse{
+		GroupBy: req.GroupBy,
+		From:    req.From,
+		To:      req.To,
+		Buckets: buckets,
+	}
+	for _, bucket := range buckets {
+		result.Total += bucket.Count
+	}
+
+	s.logger.LogServiceOperation("analytics", "get_post_stats", true, time.Since(start).Milliseconds())
+
+	return result, nil
+}
+
+// fillPostStatsDays returns one bucket for each day from from up to to, taking
+// the counts of the given day buckets and zero for the days they lack
+func fillPostStatsDays(buckets []model.PostStatsBucket, from, to time.Time) []model.PostStatsBucket {
+	counts := make(map[string]int64, len(buckets))
+	for _, bucket := range buckets {
+		counts[bucket.Day] = bucket.Count
+	}
+
+	filled := []model.PostStatsBucket{}
+	for day := from.Truncate(24 * time.Hour); day.Before(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(time.DateOnly)
+		filled = append(filled, model.PostStatsBucket{Day: key, Count: counts[key]})
+	}
+
+	return filled
+}
+
+// normalizeSearchTerm lower-cases a search term, collapses whitespace and caps
+// it at the column length without splitting a multi-byte character
+func normalizeSearchTerm(term string) string {
+	term = strings.ToLower(strings.Join(strings.Fields(term), " "))
+
+	if len(term) > maxSearchTermLength {
+		term = strings.ToValidUTF8(term[:maxSearchTermLength], "")
+	}
+
+	return term
+}
+
+// optionalTruncated returns nil for empty values and caps the rest at max bytes
+func optionalTruncated(value string, max int) *string {
+	if value == "" {
+		return nil
+	}
+
+	if len(value) > max {
+		value = value[:max]
+	}
+
+	return &value
+}
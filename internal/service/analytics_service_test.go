@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/botdetect"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockClickRepository is a mock implementation of ClickRepository
+type MockClickRepository struct {
+	mock.Mock
+}
+
+func (m *MockClickRepository) RecordClick(ctx context.Context, click *model.PostClick) error {
+	args := m.Called(ctx, click)
+	return args.Error(0)
+}
+
+func (m *MockClickRepository) CountClicksByPost(ctx context.Context, since time.Time) ([]model.PostClickCount, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PostClickCount), args.Error(1)
+}
+
+// MockSearchQueryRepository is a mock implementation of SearchQueryRepository
+type MockSearchQueryRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchQueryRepository) RecordSearch(ctx context.Context, search *model.SearchQuery) error {
+	args := m.Called(ctx, search)
+	return args.Error(0)
+}
+
+func (m *MockSearchQueryRepository) CountSearches(ctx context.Context, since time.Time) (*model.SearchTotals, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SearchTotals), args.Error(1)
+}
+
+func (m *MockSearchQueryRepository) TopSearchTerms(ctx context.Context, since time.Time, zeroResultsOnly bool, limit int) ([]model.SearchTermStat, error) {
+	args := m.Called(ctx, since, zeroResultsOnly, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SearchTermStat), args.Error(1)
+}
+
+// AnalyticsServiceTestSuite defines the test suite for AnalyticsService
+type AnalyticsServiceTestSuite struct {
+	suite.Suite
+	mockPostRepo   *MockPostRepository
+	mockClickRepo  *MockClickRepository
+	mockSearchRepo *MockSearchQueryRepository
+	service        AnalyticsService
+	ctx            context.Context
+}
+
+func (suite *AnalyticsServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockPostRepo = new(MockPostRepository)
+	suite.mockClickRepo = new(MockClickRepository)
+	suite.mockSearchRepo = new(MockSearchQueryRepository)
+	suite.service = NewAnalyticsService(suite.mockPostRepo, suite.mockClickRepo, suite.mockSearchRepo, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *AnalyticsServiceTestSuite) TearDownTest() {
+	suite.mockPostRepo.AssertExpectations(suite.T())
+	suite.mockClickRepo.AssertExpectations(suite.T())
+	suite.mockSearchRepo.AssertExpectations(suite.T())
+}
+
+func (suite *AnalyticsServiceTestSuite) TestRecordClickSuccess() {
+	post := &model.Post{ID: 1, URL: "https://example.com/article", Status: model.PostStatusPublished}
+
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(1)).Return(post, nil)
+	suite.mockClickRepo.On("RecordClick", suite.ctx, mock.MatchedBy(func(c *model.PostClick) bool {
+		return c.PostID == 1 && c.Referrer == nil && *c.UserAgent == "test-agent"
+	})).Return(nil)
+
+	result, err := suite.service.RecordClick(suite.ctx, 1, "", "test-agent")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), post.URL, result.URL)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestRecordClickSkipsBots() {
+	post := &model.Post{ID: 1, URL: "https://example.com/article", Status: model.PostStatusPublished}
+	ctx := botdetect.WithBot(suite.ctx)
+
+	suite.mockPostRepo.On("GetPostByID", ctx, int64(1)).Return(post, nil)
+
+	result, err := suite.service.RecordClick(ctx, 1, "", "Googlebot/2.1")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), post.URL, result.URL)
+	suite.mockClickRepo.AssertNotCalled(suite.T(), "RecordClick", mock.Anything, mock.Anything)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestRecordClickSkipsReadOnly() {
+	post := &model.Post{ID: 1, URL: "https://example.com/article", Status: model.PostStatusPublished}
+	ctx := WithReadOnly(suite.ctx)
+
+	suite.mockPostRepo.On("GetPostByID", ctx, int64(1)).Return(post, nil)
+
+	result, err := suite.service.RecordClick(ctx, 1, "", "test-agent")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), post.URL, result.URL)
+	suite.mockClickRepo.AssertNotCalled(suite.T(), "RecordClick", mock.Anything, mock.Anything)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestRecordClickStoreFailureReturnsPost() {
+	post := &model.Post{ID: 1, URL: "https://example.com/article", Status: model.PostStatusPublished}
+
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(1)).Return(post, nil)
+	suite.mockClickRepo.On("RecordClick", suite.ctx, mock.Anything).Return(errors.New("database down"))
+
+	result, err := suite.service.RecordClick(suite.ctx, 1, "", "test-agent")
+
+	assert.Error(suite.T(), err)
+	require.NotNil(suite.T(), result)
+	assert.Equal(suite.T(), post.URL, result.URL)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestRecordClickPostNotFound() {
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(99)).Return(nil, pgx.ErrNoRows)
+
+	result, err := suite.service.RecordClick(suite.ctx, 99, "", "")
+
+	assert.ErrorIs(suite.T(), err, ErrPostNotFound)
+	assert.Nil(suite.T(), result)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetCTRStatsGroupsByCategory() {
+	tech := "technology"
+	counts := []model.PostClickCount{
+		{PostID: 1, Source: "A", Category: &tech, Clicks: 3},
+		{PostID: 2, Source: "B", Category: &tech, Clicks: 1},
+		{PostID: 3, Source: "B", Clicks: 0},
+	}
+
+	suite.mockClickRepo.On("CountClicksByPost", suite.ctx, mock.AnythingOfType("time.Time")).Return(counts, nil)
+	suite.mockPostRepo.On("GetPostViews", suite.ctx, []int64{1, 2, 3}).Return(map[int64]int64{1: 30, 2: 10, 3: 5}, nil)
+
+	result, err := suite.service.GetCTRStats(suite.ctx, &model.CTRParams{GroupBy: model.CTRGroupByCategory, Days: 7})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Stats, 2)
+	assert.Equal(suite.T(), model.CTRStat{Key: "technology", Views: 40, Clicks: 4, CTR: 0.1}, result.Stats[0])
+	assert.Equal(suite.T(), model.CTRStat{Key: "uncategorized", Views: 5}, result.Stats[1])
+}
+
+func (suite *AnalyticsServiceTestSuite) TestRecordSearchNormalizesTerm() {
+	suite.mockSearchRepo.On("RecordSearch", suite.ctx, mock.MatchedBy(func(q *model.SearchQuery) bool {
+		return q.Term == "climate summit" && q.ResultCount == 0 && q.ZeroResults && q.LatencyMs == 42
+	})).Return(nil)
+
+	err := suite.service.RecordSearch(suite.ctx, "  Climate \t Summit ", 0, 42*time.Millisecond)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestRecordSearchSkipsReadOnly() {
+	err := suite.service.RecordSearch(WithReadOnly(suite.ctx), "climate", 3, time.Millisecond)
+
+	assert.NoError(suite.T(), err)
+	suite.mockSearchRepo.AssertNotCalled(suite.T(), "RecordSearch", mock.Anything, mock.Anything)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestRecordSearchEmptyTerm() {
+	err := suite.service.RecordSearch(suite.ctx, "   ", 3, time.Millisecond)
+
+	assert.ErrorIs(suite.T(), err, ErrSearchTermEmpty)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetSearchAnalytics() {
+	top := []model.SearchTermStat{{Term: "golang", Searches: 3}, {Term: "quantum kittens", Searches: 1}}
+	zero := []model.SearchTermStat{{Term: "quantum kittens", Searches: 1}}
+
+	suite.mockSearchRepo.On("CountSearches", suite.ctx, mock.AnythingOfType("time.Time")).Return(&model.SearchTotals{Searches: 4, ZeroResultSearches: 1}, nil)
+	suite.mockSearchRepo.On("TopSearchTerms", suite.ctx, mock.AnythingOfType("time.Time"), false, 10).Return(top, nil)
+	suite.mockSearchRepo.On("TopSearchTerms", suite.ctx, mock.AnythingOfType("time.Time"), true, 10).Return(zero, nil)
+
+	result, err := suite.service.GetSearchAnalytics(suite.ctx, &model.SearchAnalyticsParams{Days: 7, Limit: 10})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(4), result.TotalSearches)
+	assert.Equal(suite.T(), 0.25, result.ZeroResultRate)
+	assert.Equal(suite.T(), top, result.TopQueries)
+	assert.Equal(suite.T(), zero, result.ZeroResultQueries)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetPostStatsFillsMissingDays() {
+	from := time.Date(2025, 8, 9, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+	buckets := []model.PostStatsBucket{{Day: "2025-08-09", Count: 4}, {Day: "2025-08-11", Count: 2}}
+
+	suite.mockPostRepo.On("PostStats", suite.ctx, model.PostStatsGroupByDay, from, to).Return(buckets, nil)
+
+	result, err := suite.service.GetPostStats(suite.ctx, &model.PostStatsParams{GroupBy: model.PostStatsGroupByDay, From: from, To: to})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(6), result.Total)
+	assert.Equal(suite.T(), []model.PostStatsBucket{
+		{Day: "2025-08-09", Count: 4},
+		{Day: "2025-08-10", Count: 0},
+		{Day: "2025-08-11", Count: 2},
+	}, result.Buckets)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetPostStatsByCategory() {
+	to := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+	buckets := []model.PostStatsBucket{{Day: "2025-08-11", Key: "technology", Count: 3}}
+
+	suite.mockPostRepo.On("PostStats", suite.ctx, model.PostStatsGroupByCategory, to.AddDate(0, 0, -30), to).Return(buckets, nil)
+
+	result, err := suite.service.GetPostStats(suite.ctx, &model.PostStatsParams{GroupBy: model.PostStatsGroupByCategory, To: to})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), to.AddDate(0, 0, -30), result.From, "the window defaults to 30 days")
+	assert.Equal(suite.T(), buckets, result.Buckets)
+	assert.Equal(suite.T(), int64(3), result.Total)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetPostStatsInvalidRange() {
+	to := time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+
+	for _, from := range []time.Time{to, to.AddDate(0, 0, 1), to.AddDate(-2, 0, 0)} {
+		_, err := suite.service.GetPostStats(suite.ctx, &model.PostStatsParams{GroupBy: model.PostStatsGroupByDay, From: from, To: to})
+		assert.ErrorIs(suite.T(), err, ErrPostStatsRangeInvalid)
+	}
+}
+
+func TestAnalyticsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsServiceTestSuite))
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// clickbaitThreshold is the heuristic score at which a title counts as clickbait
+const clickbaitThreshold = 2
+
+var (
+	clickbaitPhrases = []string{
+		"you won't believe",
+		"you wont believe",
+		"what happened next",
+		"will blow your mind",
+		"will shock you",
+		"one weird trick",
+		"this one trick",
+		"doctors hate",
+		"you need to see",
+		"jaw-dropping",
+		"gone wrong",
+	}
+	clickbaitNumberPattern = regexp.MustCompile(`(?i)\bnumber \d+ will\b`)
+	repeatedPunctuation    = regexp.MustCompile(`[!?]{2,}`)
+
+	// truncationMarker is how NewsAPI ends truncated content, e.g. "[+2345 chars]"
+	truncationMarker = regexp.MustCompile(`\[\+(\d+) chars\]\s*$`)
+)
+
+// articleFilterService implements ArticleFilterService interface
+type articleFilterService struct {
+	repo             repository.QuarantineRepository
+	sources          SourceRuleService
+	blockedDomains   []string
+	titlePatterns    []*regexp.Regexp
+	minContentLength int
+	clickbait        bool
+	quarantine       bool
+	logger           *logger.Logger
+}
+
+// NewArticleFilterService creates the filter applied to aggregated articles,
+// which checks the source rules before the configured ones. Title patterns
+// must already be valid; config validation guarantees that.
+func NewArticleFilterService(repo repository.QuarantineRepository, sources SourceRuleService, cfg config.FilterConfig, logger *logger.Logger) ArticleFilterService {
+	patterns := make([]*regexp.Regexp, 0, len(cfg.TitlePatterns))
+	for _, pattern := range cfg.TitlePatterns {
+		patterns = append(patterns, regexp.MustCompile(pattern))
+	}
+
+	domains := make([]string, 0, len(cfg.BlockedDomains))
+	for _, domain := range cfg.BlockedDomains {
+		domains = append(domains, strings.TrimPrefix(strings.ToLower(domain), "."))
+	}
+
+	return &articleFilterService{
+		repo:             repo,
+		sources:          sources,
+		blockedDomains:   domains,
+		titlePatterns:    patterns,
+		minContentLength: cfg.MinContentLength,
+		clickbait:        cfg.Clickbait,
+		quarantine:       cfg.Quarantine,
+		logger:           logger,
+	}
+}
+
+// Check runs the article through every rule and returns the first rejection,
+// or nil when the article may be stored. Rejected articles are quarantined
+// when enabled; a failed quarantine write is logged and does not let the
+// article through.
+func (s *articleFilterService) Check(ctx context.Context, article *model.NewsAPIArticleParams) *model.FilterRejection {
+	rejection := s.checkSourceRules(ctx, article)
+	if rejection == nil {
+		rejection = s.evaluate(article)
+	}
+	if rejection == nil {
+		return nil
+	}
+
+	s.logger.Debug("Rejected article",
+		"url", article.URL,
+		"rule", rejection.Rule,
+		"reason", rejection.Reason,
+	)
+
+	if s.quarantine {
+		var source *string
+		if article.Source.Name != "" {
+			source = &article.Source.Name
+		}
+
+		err := s.repo.QuarantineArticle(ctx, &model.QuarantinedArticle{
+			URL:    article.URL,
+			Title:  article.Title,
+			Source: source,
+			Rule:   rejection.Rule,
+			Reason: rejection.Reason,
+		})
+		if err != nil {
+			s.logger.Warn("Failed to quarantine article", "url", article.URL, "error", err.Error())
+		}
+	}
+
+	return rejection
+}
+
+// ListQuarantined retrieves quarantined articles with pagination
+func (s *articleFilterService) ListQuarantined(ctx context.Context, req *model.QuarantineListParams) (*model.QuarantineListResponse, error) {
+	start := time.Now()
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	articles, err := s.repo.ListQuarantined(ctx, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		s.logger.LogServiceOperation("article_filter", "list_quarantined", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to list quarantined articles: %w", err)
+	}
+
+	total, err := s.repo.CountQuarantined(ctx)
+	if err != nil {
+		s.logger.LogServiceOperation("article_filter", "list_quarantined", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to count quarantined articles: %w", err)
+	}
+
+	s.logger.LogServiceOperation("article_filter", "list_quarantined", true, time.Since(start).Milliseconds())
+
+	return &model.QuarantineListResponse{
+		Articles:   articles,
+		Pagination: model.CalculatePagination(req.Page, req.Limit, total),
+	}, nil
+}
+
+// checkSourceRules applies the admin-managed source rules. When they cannot
+// be read the article is let through, as post creation checks them again and
+// refuses to store it.
+func (s *articleFilterService) checkSourceRules(ctx context.Context, article *model.NewsAPIArticleParams) *model.FilterRejection {
+	rejection, err := s.sources.Check(ctx, article.URL, article.Source.Name)
+	if err != nil {
+		s.logger.Warn("Failed to check source rules", "url", article.URL, "error", err.Error())
+		return nil
+	}
+
+	return rejection
+}
+
+// evaluate applies the rules in order of cost and returns the first match
+func (s *articleFilterService) evaluate(article *model.NewsAPIArticleParams) *model.FilterRejection {
+	if domain := s.blockedDomain(article.URL); domain != "" {
+		return &model.FilterRejection{
+			Rule:   model.FilterRuleBlockedDomain,
+			Reason: fmt.Sprintf("domain %q is blocked", domain),
+		}
+	}
+
+	for _, pattern := range s.titlePatterns {
+		if pattern.MatchString(article.Title) {
+			return &model.FilterRejection{
+				Rule:   model.FilterRuleTitlePattern,
+				Reason: fmt.Sprintf("title matches %q", pattern.String()),
+			}
+		}
+	}
+
+	if s.minContentLength > 0 {
+		if length := contentLength(article); length < s.minContentLength {
+			return &model.FilterRejection{
+				Rule:   model.FilterRuleMinContentLength,
+				Reason: fmt.Sprintf("content has %d characters, minimum is %d", length, s.minContentLength),
+			}
+		}
+	}
+
+	if s.clickbait {
+		if signals := clickbaitSignals(article.Title); len(signals) > 0 {
+			return &model.FilterRejection{
+				Rule:   model.FilterRuleClickbait,
+				Reason: "title " + strings.Join(signals, ", "),
+			}
+		}
+	}
+
+	return nil
+}
+
+// blockedDomain returns the blocked domain the article URL belongs to, if any
+func (s *articleFilterService) blockedDomain(articleURL string) string {
+	if len(s.blockedDomains) == 0 {
+		return ""
+	}
+
+	parsed, err := url.Parse(articleURL)
+	if err != nil {
+		return ""
+	}
+
+	return matchDomain(strings.ToLower(parsed.Hostname()), s.blockedDomains)
+}
+
+// contentLength returns the article's full content length in characters.
+// NewsAPI truncates content and appends "[+N chars]", which is counted
+// towards the length. Articles without content fall back to the description.
+func contentLength(article *model.NewsAPIArticleParams) int {
+	text := ""
+	switch {
+	case article.Content != nil && strings.TrimSpace(*article.Content) != "":
+		text = *article.Content
+	case article.Description != nil:
+		text = *article.Description
+	}
+
+	if match := truncationMarker.FindStringSubmatchIndex(text); match != nil {
+		remaining, _ := strconv.Atoi(text[match[2]:match[3]])
+		return utf8.RuneCountInString(strings.TrimSpace(text[:match[0]])) + remaining
+	}
+
+	return utf8.RuneCountInString(strings.TrimSpace(text))
+}
+
+// clickbaitSignals scores a title with simple heuristics and returns the
+// signals found when the score reaches the clickbait threshold
+func clickbaitSignals(title string) []string {
+	var signals []string
+	score := 0
+
+	lower := strings.ToLower(title)
+	for _, phrase := range clickbaitPhrases {
+		if strings.Contains(lower, phrase) {
+			signals = append(signals, fmt.Sprintf("contains %q", phrase))
+			score += 2
+			break
+		}
+	}
+
+	if clickbaitNumberPattern.MatchString(title) {
+		signals = append(signals, "teases a numbered item")
+		score += 2
+	}
+
+	if repeatedPunctuation.MatchString(title) {
+		signals = append(signals, "has repeated punctuation")
+		score++
+	}
+
+	letters, upper := 0, 0
+	for _, r := range title {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters >= 10 && upper*2 > letters {
+		signals = append(signals, "is mostly upper case")
+		score++
+	}
+
+	if score < clickbaitThreshold {
+		return nil
+	}
+
+	return signals
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockQuarantineRepository is a mock implementation of QuarantineRepository
+type MockQuarantineRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuarantineRepository) QuarantineArticle(ctx context.Context, article *model.QuarantinedArticle) error {
+	args := m.Called(ctx, article)
+	return args.Error(0)
+}
+
+func (m *MockQuarantineRepository) ListQuarantined(ctx context.Context, limit, offset int) ([]model.QuarantinedArticle, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.QuarantinedArticle), args.Error(1)
+}
+
+func (m *MockQuarantineRepository) CountQuarantined(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// ArticleFilterServiceTestSuite defines the test suite for ArticleFilterService
+type ArticleFilterServiceTestSuite struct {
+	suite.Suite
+	mockRepo *MockQuarantineRepository
+	logger   *logger.Logger
+	service  ArticleFilterService
+	ctx      context.Context
+}
+
+func (suite *ArticleFilterServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockQuarantineRepository)
+	suite.logger = logger.New(cfg)
+	suite.service = NewArticleFilterService(suite.mockRepo, noSourceRules{}, config.FilterConfig{
+		BlockedDomains:   []string{"spam.example.com"},
+		TitlePatterns:    []string{`(?i)^sponsored:`},
+		MinContentLength: 50,
+		Clickbait:        true,
+	}, suite.logger)
+	suite.ctx = context.Background()
+}
+
+func (suite *ArticleFilterServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ArticleFilterServiceTestSuite) article(url, title, content string) *model.NewsAPIArticleParams {
+	article := &model.NewsAPIArticleParams{
+		Title:   title,
+		URL:     url,
+		Content: &content,
+	}
+	article.Source.Name = "Example News"
+	return article
+}
+
+func (suite *ArticleFilterServiceTestSuite) TestCheckAcceptsCleanArticle() {
+	article := suite.article("https://example.com/story", "Central bank holds rates steady", "Policymakers kept the benchmark rate unchanged… [+3000 chars]")
+
+	assert.Nil(suite.T(), suite.service.Check(suite.ctx, article))
+}
+
+func (suite *ArticleFilterServiceTestSuite) TestCheckRules() {
+	long := "A long enough body of text that clears the minimum content length rule."
+
+	tests := []struct {
+		name    string
+		article *model.NewsAPIArticleParams
+		rule    model.FilterRule
+	}{
+		{"blocked domain", suite.article("https://spam.example.com/a", "Normal title", long), model.FilterRuleBlockedDomain},
+		{"blocked subdomain", suite.article("https://www.spam.example.com/a", "Normal title", long), model.FilterRuleBlockedDomain},
+		{"title pattern", suite.article("https://example.com/a", "Sponsored: buy now", long), model.FilterRuleTitlePattern},
+		{"short content", suite.article("https://example.com/a", "Normal title", "Too short"), model.FilterRuleMinContentLength},
+		{"clickbait phrase", suite.article("https://example.com/a", "You won't believe what this actor did", long), model.FilterRuleClickbait},
+		{"clickbait shouting", suite.article("https://example.com/a", "THIS CHANGES EVERYTHING!!", long), model.FilterRuleClickbait},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			rejection := suite.service.Check(suite.ctx, tt.article)
+			if assert.NotNil(suite.T(), rejection) {
+				assert.Equal(suite.T(), tt.rule, rejection.Rule)
+				assert.NotEmpty(suite.T(), rejection.Reason)
+			}
+		})
+	}
+}
+
+func (suite *ArticleFilterServiceTestSuite) TestCheckSingleClickbaitSignalIsAllowed() {
+	article := suite.article("https://example.com/a", "Markets rally after surprise jobs report!!", "A long enough body of text that clears the minimum content length rule.")
+
+	assert.Nil(suite.T(), suite.service.Check(suite.ctx, article))
+}
+
+func (suite *ArticleFilterServiceTestSuite) TestCheckQuarantinesRejectedArticle() {
+	service := NewArticleFilterService(suite.mockRepo, noSourceRules{}, config.FilterConfig{
+		BlockedDomains: []string{"spam.example.com"},
+		Quarantine:     true,
+	}, suite.logger)
+	article := suite.article("https://spam.example.com/a", "Normal title", "")
+
+	suite.mockRepo.On("QuarantineArticle", suite.ctx, mock.MatchedBy(func(q *model.QuarantinedArticle) bool {
+		return q.URL == article.URL && q.Rule == model.FilterRuleBlockedDomain && *q.Source == "Example News"
+	})).Return(errors.New("database error"))
+
+	rejection := service.Check(suite.ctx, article)
+
+	assert.NotNil(suite.T(), rejection)
+}
+
+func (suite *ArticleFilterServiceTestSuite) TestCheckSourceRules() {
+	sourceRules := new(MockSourceRuleRepository)
+	service := NewArticleFilterService(suite.mockRepo, NewSourceRuleService(sourceRules, nil, suite.logger), config.FilterConfig{
+		Quarantine: true,
+	}, suite.logger)
+	article := suite.article("https://example.com/a", "Normal title", "")
+
+	sourceRules.On("ListSourceRules", suite.ctx).Return([]model.SourceRule{
+		{List: model.SourceRuleBlock, Type: model.SourceRuleSource, Value: "example news"},
+	}, nil)
+	suite.mockRepo.On("QuarantineArticle", suite.ctx, mock.MatchedBy(func(q *model.QuarantinedArticle) bool {
+		return q.URL == article.URL && q.Rule == model.FilterRuleSourceList
+	})).Return(nil)
+
+	rejection := service.Check(suite.ctx, article)
+
+	if assert.NotNil(suite.T(), rejection) {
+		assert.Equal(suite.T(), model.FilterRuleSourceList, rejection.Rule)
+	}
+}
+
+func (suite *ArticleFilterServiceTestSuite) TestCheckLetsArticleThroughWhenSourceRulesFail() {
+	sourceRules := new(MockSourceRuleRepository)
+	service := NewArticleFilterService(suite.mockRepo, NewSourceRuleService(sourceRules, nil, suite.logger), config.FilterConfig{}, suite.logger)
+
+	sourceRules.On("ListSourceRules", suite.ctx).Return(nil, errors.New("database error"))
+
+	assert.Nil(suite.T(), service.Check(suite.ctx, suite.article("https://example.com/a", "Normal title", "")))
+}
+
+func (suite *ArticleFilterServiceTestSuite) TestListQuarantined() {
+	articles := []model.QuarantinedArticle{{ID: 1, URL: "https://spam.example.com/a"}}
+
+	suite.mockRepo.On("ListQuarantined", suite.ctx, 10, 10).Return(articles, nil)
+	suite.mockRepo.On("CountQuarantined", suite.ctx).Return(int64(11), nil)
+
+	result, err := suite.service.ListQuarantined(suite.ctx, &model.QuarantineListParams{Page: 2, Limit: 10})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), articles, result.Articles)
+	assert.Equal(suite.T(), 2, result.Pagination.TotalPages)
+}
+
+func (suite *ArticleFilterServiceTestSuite) TestListQuarantinedError() {
+	dbError := errors.New("database error")
+
+	suite.mockRepo.On("ListQuarantined", suite.ctx, 20, 0).Return(nil, dbError)
+
+	result, err := suite.service.ListQuarantined(suite.ctx, &model.QuarantineListParams{})
+
+	assert.ErrorIs(suite.T(), err, dbError)
+	assert.Nil(suite.T(), result)
+}
+
+func TestContentLengthCountsTruncatedCharacters(t *testing.T) {
+	content := "Short teaser… [+2400 chars]"
+	description := "Only a description"
+
+	assert.Equal(t, 2413, contentLength(&model.NewsAPIArticleParams{Content: &content}))
+	assert.Equal(t, 18, contentLength(&model.NewsAPIArticleParams{Description: &description}))
+	assert.Equal(t, 0, contentLength(&model.NewsAPIArticleParams{}))
+}
+
+func TestArticleFilterServiceSuite(t *testing.T) {
+	suite.Run(t, new(ArticleFilterServiceTestSuite))
+}
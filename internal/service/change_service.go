@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// changeFeedSettle holds back the newest changes. A post's updated_at is set
+// when its transaction starts, so a write committing shortly after a client
+// polled could otherwise land behind the cursor the client was given.
+const changeFeedSettle = 5 * time.Second
+
+var (
+	ErrChangesMarkerInvalid = errors.New("since must be an RFC 3339 timestamp or a cursor returned by the change feed")
+	ErrChangesMarkerExpired = errors.New("since is older than the change feed retention")
+)
+
+// changeService implements ChangeService interface
+type changeService struct {
+	repo   repository.ChangeRepository
+	cfg    config.ChangesConfig
+	logger *logger.Logger
+}
+
+// NewChangeService creates a new post change feed service
+func NewChangeService(repo repository.ChangeRepository, cfg config.ChangesConfig, logger *logger.Logger) ChangeService {
+	return &changeService{
+		repo:   repo,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// ListPostChanges returns the IDs of the posts created, updated and deleted
+// since req.Since, at most req.Limit changes, with the cursor to poll from
+// next. Markers older than the retention are refused with
+// ErrChangesMarkerExpired, as deletions before them may be forgotten.
+func (s *changeService) ListPostChanges(ctx context.Context, req *model.PostChangesParams) (*model.PostChangesResponse, error) {
+	start := time.Now()
+
+	if req.Limit <= 0 {
+		req.Limit = 100
+	}
+	if req.Limit > 1000 {
+		req.Limit = 1000
+	}
+
+	after, afterID, err := parseChangeMarker(req.Since)
+	if err != nil {
+		s.logger.LogServiceOperation("change", "list", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	if after.Before(start.Add(-s.cfg.Retention)) {
+		s.logger.LogServiceOperation("change", "list", false, time.Since(start).Milliseconds())
+		return nil, ErrChangesMarkerExpired
+	}
+
+	// One change more than asked for tells whether another page is waiting
+	changes, err := s.repo.ListPostChanges(ctx, &model.ListPostChangesParams{
+		After:   after,
+		AfterID: afterID,
+		Until:   start.Add(-changeFeedSettle),
+		Limit:   req.Limit + 1,
+	})
+	if err != nil {
+		s.logger.LogServiceOperation("change", "list", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to list post changes: %w", err)
+	}
+
+	response := &model.PostChangesResponse{
+		Created: []int64{},
+		Updated: []int64{},
+		Deleted: []int64{},
+	}
+	if len(changes) > req.Limit {
+		changes = changes[:req.Limit]
+		response.HasMore = true
+	}
+
+	for _, change := range changes {
+		switch change.Type {
+		case model.PostChangeCreated:
+			response.Created = append(response.Created, change.ID)
+		case model.PostChangeUpdated:
+			response.Updated = append(response.Updated, change.ID)
+		case model.PostChangeDeleted:
+			response.Deleted = append(response.Deleted, change.ID)
+		}
+	}
+
+	if len(changes) > 0 {
+		last := changes[len(changes)-1]
+		after, afterID = last.ChangedAt, last.ID
+	}
+	response.Cursor = encodeChangeCursor(after, afterID)
+
+	s.logger.LogServiceOperation("change", "list", true, time.Since(start).Milliseconds())
+
+	return response, nil
+}
+
+// PruneDeletions forgets the posts of the current tenant deleted longer ago
+// than the retention and returns how many were forgotten
+func (s *changeService) PruneDeletions(ctx context.Context) (int64, error) {
+	start := time.Now()
+
+	pruned, err := s.repo.PruneDeletions(ctx, start.Add(-s.cfg.Retention))
+	if err != nil {
+		s.logger.LogServiceOperation("change", "prune_deletions", false, time.Since(start).Milliseconds())
+		return 0, err
+	}
+
+	s.logger.LogServiceOperation("change", "prune_deletions", true, time.Since(start).Milliseconds())
+
+	return pruned, nil
+}
+
+// encodeChangeCursor packs the time and post ID of a change into an opaque
+// cursor
+func encodeChangeCursor(changedAt time.Time, id int64) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%d", changedAt.UnixMicro(), id))
+}
+
+// parseChangeMarker reads a change feed marker: an RFC 3339 timestamp, which
+// starts the feed at that time, or a cursor, which resumes it after the
+// change it was made from
+func parseChangeMarker(marker string) (time.Time, int64, error) {
+	marker = strings.TrimSpace(marker)
+
+	if since, err := time.Parse(time.RFC3339Nano, marker); err == nil {
+		return since.UTC(), 0, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(marker)
+	if err != nil {
+		return time.Time{}, 0, ErrChangesMarkerInvalid
+	}
+
+	micros, id, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return time.Time{}, 0, ErrChangesMarkerInvalid
+	}
+
+	unixMicro, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, ErrChangesMarkerInvalid
+	}
+
+	afterID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || afterID < 0 {
+		return time.Time{}, 0, ErrChangesMarkerInvalid
+	}
+
+	return time.UnixMicro(unixMicro).UTC(), afterID, nil
+}
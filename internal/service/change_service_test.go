@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockChangeRepository is a mock implementation of ChangeRepository
+type MockChangeRepository struct {
+	mock.Mock
+}
+
+func (m *MockChangeRepository) ListPostChanges(ctx context.Context, params *model.ListPostChangesParams) ([]model.PostChange, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PostChange), args.Error(1)
+}
+
+func (m *MockChangeRepository) PruneDeletions(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// ChangeServiceTestSuite defines the test suite for ChangeService
+type ChangeServiceTestSuite struct {
+	suite.Suite
+	mockRepo *MockChangeRepository
+	service  ChangeService
+	ctx      context.Context
+}
+
+func (suite *ChangeServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockChangeRepository)
+	suite.service = NewChangeService(suite.mockRepo, config.ChangesConfig{
+		Retention: 30 * 24 * time.Hour,
+	}, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *ChangeServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ChangeServiceTestSuite) TestListPostChangesFromTimestamp() {
+	since := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	changedAt := since.Add(10 * time.Minute)
+
+	suite.mockRepo.On("ListPostChanges", mock.Anything, mock.MatchedBy(func(params *model.ListPostChangesParams) bool {
+		return params.After.Equal(since) && params.AfterID == 0 && params.Limit == 101 &&
+			params.Until.Before(time.Now().Add(-changeFeedSettle+time.Second))
+	})).Return([]model.PostChange{
+		{ID: 1, Type: model.PostChangeCreated, ChangedAt: changedAt},
+		{ID: 2, Type: model.PostChangeUpdated, ChangedAt: changedAt},
+		{ID: 3, Type: model.PostChangeDeleted, ChangedAt: changedAt.Add(time.Minute)},
+	}, nil).Once()
+
+	resp, err := suite.service.ListPostChanges(suite.ctx, &model.PostChangesParams{Since: since.Format(time.RFC3339)})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int64{1}, resp.Created)
+	assert.Equal(suite.T(), []int64{2}, resp.Updated)
+	assert.Equal(suite.T(), []int64{3}, resp.Deleted)
+	assert.False(suite.T(), resp.HasMore)
+
+	after, afterID, err := parseChangeMarker(resp.Cursor)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), after.Equal(changedAt.Add(time.Minute)))
+	assert.Equal(suite.T(), int64(3), afterID)
+}
+
+func (suite *ChangeServiceTestSuite) TestListPostChangesFromCursor() {
+	changedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Microsecond)
+	cursor := encodeChangeCursor(changedAt, 42)
+
+	suite.mockRepo.On("ListPostChanges", mock.Anything, mock.MatchedBy(func(params *model.ListPostChangesParams) bool {
+		return params.After.Equal(changedAt) && params.AfterID == 42 && params.Limit == 3
+	})).Return([]model.PostChange{
+		{ID: 43, Type: model.PostChangeUpdated, ChangedAt: changedAt.Add(time.Second)},
+		{ID: 44, Type: model.PostChangeUpdated, ChangedAt: changedAt.Add(2 * time.Second)},
+		{ID: 45, Type: model.PostChangeUpdated, ChangedAt: changedAt.Add(3 * time.Second)},
+	}, nil).Once()
+
+	resp, err := suite.service.ListPostChanges(suite.ctx, &model.PostChangesParams{Since: cursor, Limit: 2})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int64{43, 44}, resp.Updated)
+	assert.True(suite.T(), resp.HasMore)
+	assert.Equal(suite.T(), encodeChangeCursor(changedAt.Add(2*time.Second), 44), resp.Cursor)
+}
+
+func (suite *ChangeServiceTestSuite) TestListPostChangesNoChangesKeepsMarker() {
+	changedAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Microsecond)
+	cursor := encodeChangeCursor(changedAt, 7)
+
+	suite.mockRepo.On("ListPostChanges", mock.Anything, mock.Anything).Return([]model.PostChange{}, nil).Once()
+
+	resp, err := suite.service.ListPostChanges(suite.ctx, &model.PostChangesParams{Since: cursor})
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), resp.Created)
+	assert.Empty(suite.T(), resp.Updated)
+	assert.Empty(suite.T(), resp.Deleted)
+	assert.Equal(suite.T(), cursor, resp.Cursor)
+}
+
+func (suite *ChangeServiceTestSuite) TestListPostChangesExpiredMarker() {
+	since := time.Now().Add(-31 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	_, err := suite.service.ListPostChanges(suite.ctx, &model.PostChangesParams{Since: since})
+
+	assert.ErrorIs(suite.T(), err, ErrChangesMarkerExpired)
+	suite.mockRepo.AssertNotCalled(suite.T(), "ListPostChanges", mock.Anything, mock.Anything)
+}
+
+func (suite *ChangeServiceTestSuite) TestListPostChangesInvalidMarker() {
+	for _, since := range []string{"yesterday", "bm90LWEtY3Vyc29y", "MTIzOmFiYw"} {
+		_, err := suite.service.ListPostChanges(suite.ctx, &model.PostChangesParams{Since: since})
+
+		assert.ErrorIs(suite.T(), err, ErrChangesMarkerInvalid, since)
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "ListPostChanges", mock.Anything, mock.Anything)
+}
+
+func (suite *ChangeServiceTestSuite) TestListPostChangesRepositoryError() {
+	since := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	suite.mockRepo.On("ListPostChanges", mock.Anything, mock.Anything).Return(nil, errors.New("database error")).Once()
+
+	_, err := suite.service.ListPostChanges(suite.ctx, &model.PostChangesParams{Since: since})
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ChangeServiceTestSuite) TestPruneDeletions() {
+	suite.mockRepo.On("PruneDeletions", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().Add(-29 * 24 * time.Hour))
+	})).Return(int64(5), nil).Once()
+
+	pruned, err := suite.service.PruneDeletions(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(5), pruned)
+}
+
+func TestChangeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangeServiceTestSuite))
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrCommentInvalid       = errors.New("comment author and body are required")
+	ErrParentCommentInvalid = errors.New("parent comment does not exist on this post or was deleted")
+)
+
+// commentService implements CommentService interface
+type commentService struct {
+	repo     repository.CommentRepository
+	postRepo repository.PostRepository
+	tx       repository.UnitOfWork
+	logger   *logger.Logger
+}
+
+// NewCommentService creates a new comment service
+func NewCommentService(repo repository.CommentRepository, postRepo repository.PostRepository, tx repository.UnitOfWork, logger *logger.Logger) CommentService {
+	return &commentService{
+		repo:     repo,
+		postRepo: postRepo,
+		tx:       tx,
+		logger:   logger,
+	}
+}
+
+// CreateComment adds a comment, or a reply when a parent is given, and bumps
+// the post's comment count in the same transaction
+func (s *commentService) CreateComment(ctx context.Context, postID int64, req *model.CreateCommentParams) (*model.Comment, error) {
+	start := time.Now()
+
+	comment := &model.Comment{
+		PostID:   postID,
+		ParentID: req.ParentID,
+		Author:   strings.TrimSpace(req.Author),
+		Body:     strings.TrimSpace(req.Body),
+	}
+	if comment.Author == "" || comment.Body == "" {
+		s.logger.LogServiceOperation("comment", "create", false, time.Since(start).Milliseconds())
+		return nil, ErrCommentInvalid
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.ensurePost(ctx, postID); err != nil {
+			return err
+		}
+
+		if req.ParentID != nil {
+			parent, err := s.repo.GetComment(ctx, postID, *req.ParentID)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrParentCommentInvalid
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get parent comment: %w", err)
+			}
+			if parent.Deleted {
+				return ErrParentCommentInvalid
+			}
+		}
+
+		if err := s.repo.CreateComment(ctx, comment); err != nil {
+			return err
+		}
+
+		return s.postRepo.AdjustCommentCount(ctx, postID, 1)
+	})
+	if err != nil {
+		s.logger.LogServiceOperation("comment", "create", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	s.logger.LogServiceOperation("comment", "create", true, time.Since(start).Milliseconds())
+
+	return comment, nil
+}
+
+// ListComments returns a page of a post's top-level comments, each with its
+// replies nested beneath it
+func (s *commentService) ListComments(ctx context.Context, req *model.CommentListParams) (*model.CommentListResponse, error) {
+	start := time.Now()
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	if err := s.ensurePost(ctx, req.PostID); err != nil {
+		s.logger.LogServiceOperation("comment", "list", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	roots, err := s.repo.ListRootComments(ctx, req.PostID, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		s.logger.LogServiceOperation("comment", "list", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	total, err := s.repo.CountRootComments(ctx, req.PostID)
+	if err != nil {
+		s.logger.LogServiceOperation("comment", "list", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	rootIDs := make([]int64, len(roots))
+	for i, root := range roots {
+		rootIDs[i] = root.ID
+	}
+
+	replies, err := s.repo.ListReplies(ctx, rootIDs)
+	if err != nil {
+		s.logger.LogServiceOperation("comment", "list", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	s.logger.LogServiceOperation("comment", "list", true, time.Since(start).Milliseconds())
+
+	return &model.CommentListResponse{
+		Comments:   buildCommentTree(roots, replies),
+		Pagination: model.CalculatePagination(req.Page, req.Limit, total),
+	}, nil
+}
+
+// DeleteComment soft-deletes a comment and drops it from the post's comment count
+func (s *commentService) DeleteComment(ctx context.Context, postID, id int64) error {
+	start := time.Now()
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		deleted, err := s.repo.SoftDeleteComment(ctx, postID, id)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return ErrCommentNotFound
+		}
+
+		return s.postRepo.AdjustCommentCount(ctx, postID, -1)
+	})
+	if err != nil {
+		s.logger.LogServiceOperation("comment", "delete", false, time.Since(start).Milliseconds())
+		return err
+	}
+
+	s.logger.LogServiceOperation("comment", "delete", true, time.Since(start).Milliseconds())
+
+	return nil
+}
+
+// ensurePost maps a missing or unpublished post to ErrPostNotFound
+func (s *commentService) ensurePost(ctx context.Context, postID int64) error {
+	if postID <= 0 {
+		return ErrPostIDInvalid
+	}
+
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPostNotFound
+		}
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+
+	if !post.IsPublished() {
+		return ErrPostNotFound
+	}
+
+	return nil
+}
+
+// buildCommentTree nests replies under their parents. Replies arrive oldest
+// first, so each thread keeps chronological order.
+func buildCommentTree(roots, replies []model.Comment) []model.Comment {
+	children := make(map[int64][]model.Comment)
+	for _, reply := range replies {
+		children[*reply.ParentID] = append(children[*reply.ParentID], reply)
+	}
+
+	var attach func(comment model.Comment) model.Comment
+	attach = func(comment model.Comment) model.Comment {
+		for _, child := range children[comment.ID] {
+			comment.Replies = append(comment.Replies, attach(child))
+		}
+		return comment
+	}
+
+	tree := make([]model.Comment, len(roots))
+	for i, root := range roots {
+		tree[i] = attach(root)
+	}
+
+	return tree
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockCommentRepository is a mock implementation of CommentRepository
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) CreateComment(ctx context.Context, comment *model.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetComment(ctx context.Context, postID, id int64) (*model.Comment, error) {
+	args := m.Called(ctx, postID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) ListRootComments(ctx context.Context, postID int64, limit, offset int) ([]model.Comment, error) {
+	args := m.Called(ctx, postID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) CountRootComments(ctx context.Context, postID int64) (int64, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCommentRepository) ListReplies(ctx context.Context, rootIDs []int64) ([]model.Comment, error) {
+	args := m.Called(ctx, rootIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) SoftDeleteComment(ctx context.Context, postID, id int64) (bool, error) {
+	args := m.Called(ctx, postID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// CommentServiceTestSuite defines the test suite for CommentService
+type CommentServiceTestSuite struct {
+	suite.Suite
+	mockRepo     *MockCommentRepository
+	mockPostRepo *MockPostRepository
+	service      CommentService
+	ctx          context.Context
+}
+
+func (suite *CommentServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockCommentRepository)
+	suite.mockPostRepo = new(MockPostRepository)
+	suite.service = NewCommentService(suite.mockRepo, suite.mockPostRepo, passthroughUnitOfWork{}, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *CommentServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockPostRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CommentServiceTestSuite) TestCreateCommentIncrementsCount() {
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(1)).Return(&model.Post{ID: 1, Status: model.PostStatusPublished}, nil)
+	suite.mockRepo.On("CreateComment", suite.ctx, mock.MatchedBy(func(c *model.Comment) bool {
+		return c.PostID == 1 && c.Author == "jane" && c.Body == "Nice" && c.ParentID == nil
+	})).Return(nil)
+	suite.mockPostRepo.On("AdjustCommentCount", suite.ctx, int64(1), 1).Return(nil)
+
+	comment, err := suite.service.CreateComment(suite.ctx, 1, &model.CreateCommentParams{Author: " jane ", Body: "Nice"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "jane", comment.Author)
+}
+
+func (suite *CommentServiceTestSuite) TestCreateCommentPostNotFound() {
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(99)).Return(nil, pgx.ErrNoRows)
+
+	comment, err := suite.service.CreateComment(suite.ctx, 99, &model.CreateCommentParams{Author: "jane", Body: "Nice"})
+
+	assert.ErrorIs(suite.T(), err, ErrPostNotFound)
+	assert.Nil(suite.T(), comment)
+}
+
+func (suite *CommentServiceTestSuite) TestCreateReplyToDeletedParent() {
+	parentID := int64(5)
+
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(1)).Return(&model.Post{ID: 1, Status: model.PostStatusPublished}, nil)
+	suite.mockRepo.On("GetComment", suite.ctx, int64(1), parentID).Return(&model.Comment{ID: parentID, PostID: 1, Deleted: true}, nil)
+
+	comment, err := suite.service.CreateComment(suite.ctx, 1, &model.CreateCommentParams{Author: "jane", Body: "Reply", ParentID: &parentID})
+
+	assert.ErrorIs(suite.T(), err, ErrParentCommentInvalid)
+	assert.Nil(suite.T(), comment)
+}
+
+func (suite *CommentServiceTestSuite) TestCreateReplyToMissingParent() {
+	parentID := int64(5)
+
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(1)).Return(&model.Post{ID: 1, Status: model.PostStatusPublished}, nil)
+	suite.mockRepo.On("GetComment", suite.ctx, int64(1), parentID).Return(nil, pgx.ErrNoRows)
+
+	_, err := suite.service.CreateComment(suite.ctx, 1, &model.CreateCommentParams{Author: "jane", Body: "Reply", ParentID: &parentID})
+
+	assert.ErrorIs(suite.T(), err, ErrParentCommentInvalid)
+}
+
+func (suite *CommentServiceTestSuite) TestListCommentsBuildsThreads() {
+	parent := func(id int64) *int64 { return &id }
+	roots := []model.Comment{{ID: 1, PostID: 1}, {ID: 2, PostID: 1}}
+	replies := []model.Comment{
+		{ID: 3, PostID: 1, ParentID: parent(1)},
+		{ID: 4, PostID: 1, ParentID: parent(3)},
+		{ID: 5, PostID: 1, ParentID: parent(1)},
+	}
+
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(1)).Return(&model.Post{ID: 1, Status: model.PostStatusPublished}, nil)
+	suite.mockRepo.On("ListRootComments", suite.ctx, int64(1), 20, 0).Return(roots, nil)
+	suite.mockRepo.On("CountRootComments", suite.ctx, int64(1)).Return(int64(2), nil)
+	suite.mockRepo.On("ListReplies", suite.ctx, []int64{1, 2}).Return(replies, nil)
+
+	result, err := suite.service.ListComments(suite.ctx, &model.CommentListParams{PostID: 1})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Comments, 2)
+	assert.Len(suite.T(), result.Comments[0].Replies, 2)
+	assert.Equal(suite.T(), int64(3), result.Comments[0].Replies[0].ID)
+	assert.Equal(suite.T(), int64(4), result.Comments[0].Replies[0].Replies[0].ID)
+	assert.Equal(suite.T(), int64(5), result.Comments[0].Replies[1].ID)
+	assert.Empty(suite.T(), result.Comments[1].Replies)
+	assert.Equal(suite.T(), int64(2), result.Pagination.Total)
+}
+
+func (suite *CommentServiceTestSuite) TestDeleteCommentDecrementsCount() {
+	suite.mockRepo.On("SoftDeleteComment", suite.ctx, int64(1), int64(7)).Return(true, nil)
+	suite.mockPostRepo.On("AdjustCommentCount", suite.ctx, int64(1), -1).Return(nil)
+
+	err := suite.service.DeleteComment(suite.ctx, 1, 7)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CommentServiceTestSuite) TestDeleteCommentNotFound() {
+	suite.mockRepo.On("SoftDeleteComment", suite.ctx, int64(1), int64(7)).Return(false, nil)
+
+	err := suite.service.DeleteComment(suite.ctx, 1, 7)
+
+	assert.ErrorIs(suite.T(), err, ErrCommentNotFound)
+}
+
+func TestCommentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentServiceTestSuite))
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// ConfigApplier pushes reloaded settings into a running component
+type ConfigApplier func(cfg *config.Config)
+
+// configService implements ConfigService interface
+type configService struct {
+	mu       sync.Mutex
+	current  *config.Config
+	load     func() (*config.Config, error)
+	appliers []ConfigApplier
+	logger   *logger.Logger
+}
+
+// NewConfigService creates a config service that re-reads settings with load
+// and hands them to the registered appliers
+func NewConfigService(current *config.Config, load func() (*config.Config, error), logger *logger.Logger) ConfigService {
+	return &configService{
+		current: current,
+		load:    load,
+		logger:  logger,
+	}
+}
+
+// OnReload registers a component to receive settings after each successful reload
+func (s *configService) OnReload(apply ConfigApplier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.appliers = append(s.appliers, apply)
+}
+
+// Reload loads and validates the configuration, then applies it only if it
+// is valid. Settings that need a restart are read but not applied.
+func (s *configService) Reload(ctx context.Context) (*model.ConfigReloadResult, error) {
+	start := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, err := s.load()
+	if err != nil {
+		s.logger.LogServiceOperation("config_service", "reload", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	changed := s.current.ReloadableChanges(next)
+	if len(changed) > 0 {
+		for _, apply := range s.appliers {
+			apply(next)
+		}
+	}
+	s.current = next
+
+	s.logger.Info("Configuration reloaded", "changed", changed)
+	s.logger.LogServiceOperation("config_service", "reload", true, time.Since(start).Milliseconds())
+
+	if changed == nil {
+		changed = []string{}
+	}
+
+	return &model.ConfigReloadResult{
+		ReloadedAt: time.Now(),
+		Changed:    changed,
+	}, nil
+}
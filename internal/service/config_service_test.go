@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// ConfigServiceTestSuite defines the test suite for ConfigService
+type ConfigServiceTestSuite struct {
+	suite.Suite
+	current *config.Config
+	next    *config.Config
+	loadErr error
+	applied []*config.Config
+	service ConfigService
+}
+
+func (suite *ConfigServiceTestSuite) SetupTest() {
+	suite.current = &config.Config{
+		App:   config.AppConfig{LogLevel: "info"},
+		Cache: config.CacheConfig{TTL: time.Hour},
+	}
+	suite.next = nil
+	suite.loadErr = nil
+	suite.applied = nil
+
+	load := func() (*config.Config, error) {
+		return suite.next, suite.loadErr
+	}
+
+	suite.service = NewConfigService(suite.current, load, logger.New(suite.current))
+	suite.service.OnReload(func(cfg *config.Config) {
+		suite.applied = append(suite.applied, cfg)
+	})
+}
+
+func (suite *ConfigServiceTestSuite) TestReloadAppliesChanges() {
+	suite.next = &config.Config{
+		App:   config.AppConfig{LogLevel: "debug"},
+		Cache: config.CacheConfig{TTL: 10 * time.Minute},
+	}
+
+	result, err := suite.service.Reload(context.Background())
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"LOG_LEVEL", "CACHE_TTL"}, result.Changed)
+	assert.Equal(suite.T(), []*config.Config{suite.next}, suite.applied)
+}
+
+func (suite *ConfigServiceTestSuite) TestReloadWithoutChangesSkipsAppliers() {
+	next := *suite.current
+	suite.next = &next
+
+	result, err := suite.service.Reload(context.Background())
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Changed)
+	assert.Empty(suite.T(), suite.applied)
+}
+
+func (suite *ConfigServiceTestSuite) TestReloadInvalidConfigKeepsCurrent() {
+	suite.loadErr = errors.New("config validation failed: news API key is required")
+
+	result, err := suite.service.Reload(context.Background())
+
+	assert.ErrorIs(suite.T(), err, ErrInvalidConfig)
+	assert.Nil(suite.T(), result)
+	assert.Empty(suite.T(), suite.applied)
+
+	// A later valid reload is still compared against the original settings
+	suite.loadErr = nil
+	suite.next = &config.Config{
+		App:   config.AppConfig{LogLevel: "info"},
+		Cache: config.CacheConfig{TTL: 30 * time.Minute},
+	}
+
+	result, err = suite.service.Reload(context.Background())
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"CACHE_TTL"}, result.Changed)
+}
+
+func TestConfigServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigServiceTestSuite))
+}
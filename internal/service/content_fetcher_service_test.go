@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testArticleHTML = `<html><head><title>Article</title><script>var tracking = true;</script></head>
+<body>
+	<nav><p>Home, World, Business, Technology, Sports and more sections</p></nav>
+	<div class="article-content">
+		<p>The first paragraph of the article explains what happened, where, and why it matters.</p>
+		<p>The second paragraph adds detail, quotes, and background for <b>readers</b> who want more.</p>
+	</div>
+	<div class="sidebar"><p>Related: another story you might like, with a long teaser.</p></div>
+	<footer><p>Copyright, all rights reserved, terms of service, privacy policy</p></footer>
+</body></html>`
+
+const testStoryHTML = `<html lang="en-GB"><head>
+	<title>Taiwan chip makers race to ship AI accelerators | Example News</title>
+	<meta property="og:title" content="Taiwan chip makers race to ship AI accelerators - Example News">
+	<meta property="og:site_name" content="Example News">
+	<meta name="description" content="Demand for accelerators &amp; memory keeps growing.">
+	<meta property="og:image" content="/images/chips.jpg">
+	<meta name="author" content="Jane Doe">
+	<meta property="article:published_time" content="2024-01-20T10:00:00+02:00">
+	<meta property="article:tag" content="Chips">
+	<meta name="keywords" content="chips, artificial intelligence, ">
+</head>
+<body>
+	<div class="article-content">
+		<p>Chip makers in Taiwan are racing to ship accelerators, as demand from data centers grows.</p>
+		<p>Analysts expect supply to stay tight through the year, with memory in short supply too.</p>
+	</div>
+</body></html>`
+
+// ContentFetcherServiceTestSuite defines the test suite for ContentFetcherService
+type ContentFetcherServiceTestSuite struct {
+	suite.Suite
+	mockRepo *MockPostRepository
+	posts    PostService
+	server   *httptest.Server
+	service  ContentFetcherService
+
+	robotsRequests int
+	ctx            context.Context
+}
+
+func (suite *ContentFetcherServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.robotsRequests = 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(testArticleHTML))
+	})
+	mux.HandleFunc("/story", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(testStoryHTML))
+	})
+	mux.HandleFunc("/untitled", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><body><p>No title here.</p></body></html>`))
+	})
+	mux.HandleFunc("/feed.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		suite.robotsRequests++
+		w.Write([]byte("User-agent: *\nDisallow: /\n\nUser-agent: test-agent\nDisallow: /private/\nAllow: /private/open\n"))
+	})
+	mux.HandleFunc("/private/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(testArticleHTML))
+	})
+	suite.server = httptest.NewServer(mux)
+
+	suite.mockRepo = new(MockPostRepository)
+	classifier := NewSensitivityClassifier(config.ClassifierConfig{}, logger.New(cfg))
+	suite.posts = NewPostService(suite.mockRepo, new(MockReactionRepository), passthroughUnitOfWork{}, classifier, noSourceRules{}, keepImages{}, nil, false, config.SearchConfig{}, logger.New(cfg))
+	suite.service = NewContentFetcherService(suite.mockRepo, suite.posts, classifier, config.ContentFetchConfig{
+		Sources:   []string{"Test Source"},
+		BatchSize: 10,
+		RobotsTTL: time.Hour,
+		Timeout:   5 * time.Second,
+		MaxBytes:  1 << 20,
+		UserAgent: "test-agent",
+	}, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *ContentFetcherServiceTestSuite) TearDownTest() {
+	suite.server.Close()
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestFetchContentExtractsArticle() {
+	content, err := suite.service.FetchContent(suite.ctx, suite.server.URL+"/article")
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), content, "The first paragraph of the article")
+	assert.Contains(suite.T(), content, "\n\nThe second paragraph adds detail, quotes, and background for readers")
+	assert.NotContains(suite.T(), content, "tracking")
+	assert.NotContains(suite.T(), content, "Copyright")
+	assert.NotContains(suite.T(), content, "Related")
+	assert.NotContains(suite.T(), content, "<b>")
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestFetchContentRespectsRobots() {
+	_, err := suite.service.FetchContent(suite.ctx, suite.server.URL+"/private/article")
+	assert.ErrorIs(suite.T(), err, ErrContentDisallowed)
+
+	content, err := suite.service.FetchContent(suite.ctx, suite.server.URL+"/private/open")
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), content)
+
+	assert.Equal(suite.T(), 1, suite.robotsRequests, "robots.txt should be cached per host")
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestFetchContentNotFound() {
+	_, err := suite.service.FetchContent(suite.ctx, suite.server.URL+"/missing")
+
+	assert.ErrorIs(suite.T(), err, ErrContentFetch)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestFetchContentNotHTML() {
+	_, err := suite.service.FetchContent(suite.ctx, suite.server.URL+"/feed.json")
+
+	assert.ErrorIs(suite.T(), err, ErrContentUnsupported)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestFetchContentInvalidURL() {
+	_, err := suite.service.FetchContent(suite.ctx, "ftp://example.com/article")
+
+	assert.ErrorIs(suite.T(), err, ErrContentFetch)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestPreviewArticle() {
+	articleURL := suite.server.URL + "/story"
+	suite.mockRepo.On("ExistsByURL", suite.ctx, articleURL).Return(true, nil)
+
+	preview, err := suite.service.PreviewArticle(suite.ctx, articleURL)
+
+	assert.NoError(suite.T(), err)
+	post := preview.Post
+	assert.Equal(suite.T(), "Taiwan chip makers race to ship AI accelerators", post.Title)
+	assert.Equal(suite.T(), "Example News", post.Source)
+	assert.Equal(suite.T(), articleURL, post.URL)
+	assert.Equal(suite.T(), "Demand for accelerators & memory keeps growing.", *post.Description)
+	assert.Equal(suite.T(), suite.server.URL+"/images/chips.jpg", *post.ImageURL)
+	assert.Equal(suite.T(), "Jane Doe", *post.Author)
+	assert.Equal(suite.T(), "tw", *post.Country)
+	assert.True(suite.T(), post.PublishedAt.Equal(time.Date(2024, 1, 20, 8, 0, 0, 0, time.UTC)))
+	assert.Contains(suite.T(), *post.Content, "\n\nAnalysts expect supply to stay tight")
+	assert.Equal(suite.T(), model.PostStatusPublished, post.Status)
+
+	assert.Equal(suite.T(), "en", preview.Language)
+	assert.Equal(suite.T(), []string{"Chips", "artificial intelligence"}, preview.Tags)
+	assert.True(suite.T(), preview.Duplicate)
+	assert.Empty(suite.T(), preview.Blocked)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreatePost", mock.Anything, mock.Anything)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestPreviewArticleFallsBackToHost() {
+	articleURL := suite.server.URL + "/article"
+	suite.mockRepo.On("ExistsByURL", suite.ctx, articleURL).Return(false, nil)
+
+	preview, err := suite.service.PreviewArticle(suite.ctx, articleURL)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Article", preview.Post.Title)
+	assert.Equal(suite.T(), "127.0.0.1", preview.Post.Source)
+	assert.Nil(suite.T(), preview.Post.Description)
+	assert.Nil(suite.T(), preview.Post.PublishedAt)
+	assert.Equal(suite.T(), []string{}, preview.Tags)
+	assert.False(suite.T(), preview.Duplicate)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestPreviewArticleErrors() {
+	_, err := suite.service.PreviewArticle(suite.ctx, suite.server.URL+"/untitled")
+	assert.ErrorIs(suite.T(), err, ErrContentUntitled)
+
+	_, err = suite.service.PreviewArticle(suite.ctx, suite.server.URL+"/private/article")
+	assert.ErrorIs(suite.T(), err, ErrContentDisallowed)
+
+	_, err = suite.service.PreviewArticle(suite.ctx, suite.server.URL+"/feed.json")
+	assert.ErrorIs(suite.T(), err, ErrContentUnsupported)
+
+	_, err = suite.service.PreviewArticle(suite.ctx, suite.server.URL+"/missing")
+	assert.ErrorIs(suite.T(), err, ErrContentFetch)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestEnrichPendingPosts() {
+	truncated := "The first paragraph… [+1200 chars]"
+	long := strings.Repeat("Already complete content. ", 20)
+	posts := []model.Post{
+		{ID: 1, URL: suite.server.URL + "/article", Content: &truncated},
+		{ID: 2, URL: suite.server.URL + "/missing"},
+		{ID: 3, URL: suite.server.URL + "/article", Content: &long},
+		{ID: 4, URL: suite.server.URL + "/private/article"},
+	}
+
+	suite.mockRepo.On("ListPostsPendingContent", suite.ctx, &model.ListPostsPendingContentParams{
+		Sources: []string{"Test Source"},
+		Limit:   10,
+	}).Return(posts, nil)
+	suite.mockRepo.On("UpdatePostContent", suite.ctx, int64(1), mock.MatchedBy(func(content *string) bool {
+		return content != nil && strings.HasPrefix(*content, "The first paragraph of the article")
+	})).Return(nil)
+	suite.mockRepo.On("UpdatePostContent", suite.ctx, int64(2), (*string)(nil)).Return(nil)
+	suite.mockRepo.On("UpdatePostContent", suite.ctx, int64(3), (*string)(nil)).Return(nil)
+	suite.mockRepo.On("UpdatePostContent", suite.ctx, int64(4), (*string)(nil)).Return(nil)
+
+	result, err := suite.service.EnrichPendingPosts(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), &model.ContentEnrichmentResult{Processed: 4, Extracted: 1, Skipped: 2, Failed: 1}, result)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestEnrichPendingPostsListError() {
+	dbError := errors.New("database error")
+
+	suite.mockRepo.On("ListPostsPendingContent", suite.ctx, mock.Anything).Return(nil, dbError)
+
+	result, err := suite.service.EnrichPendingPosts(suite.ctx)
+
+	assert.ErrorIs(suite.T(), err, dbError)
+	assert.Nil(suite.T(), result)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestEnrichPendingPostsUpdateError() {
+	dbError := errors.New("database error")
+	posts := []model.Post{{ID: 1, URL: suite.server.URL + "/article"}}
+
+	suite.mockRepo.On("ListPostsPendingContent", suite.ctx, mock.Anything).Return(posts, nil)
+	suite.mockRepo.On("UpdatePostContent", suite.ctx, int64(1), mock.Anything).Return(dbError)
+
+	result, err := suite.service.EnrichPendingPosts(suite.ctx)
+
+	assert.ErrorIs(suite.T(), err, dbError)
+	assert.Equal(suite.T(), 1, result.Extracted)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestStartReprocess() {
+	category := "world"
+	params := &model.ReprocessParams{Category: &category}
+	posts := []model.Post{
+		{ID: 1, Title: "Graphic content warning", URL: suite.server.URL + "/article"},
+		{ID: 2, Title: "Markets rally", URL: suite.server.URL + "/missing", Sensitive: true},
+	}
+
+	suite.mockRepo.On("CountPostsForReprocess", suite.ctx, params).Return(int64(2), nil)
+	suite.mockRepo.On("ListPostsForReprocess", mock.Anything, &model.ListPostsForReprocessParams{
+		ReprocessParams: *params,
+		Limit:           reprocessBatchSize,
+	}).Return(posts, nil)
+	suite.mockRepo.On("UpdatePostContent", mock.Anything, int64(1), mock.AnythingOfType("*string")).Return(nil)
+	suite.mockRepo.On("UpdatePostContent", mock.Anything, int64(2), (*string)(nil)).Return(nil)
+	suite.mockRepo.On("UpdatePostSensitive", mock.Anything, int64(1), true).Return(nil)
+	suite.mockRepo.On("UpdatePostSensitive", mock.Anything, int64(2), false).Return(nil)
+
+	run, err := suite.service.StartReprocess(suite.ctx, params)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), model.ReprocessStatusRunning, run.Status)
+	assert.Equal(suite.T(), 2, run.Total)
+
+	var finished *model.ReprocessRun
+	assert.Eventually(suite.T(), func() bool {
+		finished, err = suite.service.GetReprocessRun(run.ID)
+		return err == nil && finished.Status != model.ReprocessStatusRunning
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(suite.T(), model.ReprocessStatusCompleted, finished.Status)
+	assert.Equal(suite.T(), 2, finished.Processed)
+	assert.Equal(suite.T(), 1, finished.Extracted)
+	assert.Equal(suite.T(), 1, finished.Failed)
+	assert.Equal(suite.T(), 1, finished.Flagged)
+	assert.NotNil(suite.T(), finished.FinishedAt)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestStartReprocessFailedRun() {
+	dbError := errors.New("database error")
+	params := &model.ReprocessParams{MissingContent: true}
+
+	suite.mockRepo.On("CountPostsForReprocess", suite.ctx, params).Return(int64(5), nil)
+	suite.mockRepo.On("ListPostsForReprocess", mock.Anything, mock.Anything).Return(nil, dbError)
+
+	run, err := suite.service.StartReprocess(suite.ctx, params)
+	assert.NoError(suite.T(), err)
+
+	assert.Eventually(suite.T(), func() bool {
+		run, err = suite.service.GetReprocessRun(run.ID)
+		return err == nil && run.Status == model.ReprocessStatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(suite.T(), run.Error, "database error")
+
+	// A finished run frees the slot for the next one
+	next, err := suite.service.StartReprocess(suite.ctx, params)
+	assert.NoError(suite.T(), err)
+	assert.Eventually(suite.T(), func() bool {
+		next, err = suite.service.GetReprocessRun(next.ID)
+		return err == nil && next.Status == model.ReprocessStatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestStartReprocessInvalidRange() {
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	run, err := suite.service.StartReprocess(suite.ctx, &model.ReprocessParams{From: &from, To: &to})
+
+	assert.ErrorIs(suite.T(), err, ErrReprocessInvalidRange)
+	assert.Nil(suite.T(), run)
+}
+
+func (suite *ContentFetcherServiceTestSuite) TestGetReprocessRunNotFound() {
+	run, err := suite.service.GetReprocessRun("unknown")
+
+	assert.ErrorIs(suite.T(), err, ErrReprocessRunNotFound)
+	assert.Nil(suite.T(), run)
+}
+
+func TestWaitForHostSpacesRequestsToSameHost(t *testing.T) {
+	svc := &contentFetcherService{
+		cfg:       config.ContentFetchConfig{Delay: 50 * time.Millisecond},
+		lastFetch: make(map[string]time.Time),
+	}
+	ctx := context.Background()
+
+	start := time.Now()
+	assert.NoError(t, svc.waitForHost(ctx, "example.com", svc.cfg.Delay))
+	assert.NoError(t, svc.waitForHost(ctx, "other.com", svc.cfg.Delay))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	assert.NoError(t, svc.waitForHost(ctx, "example.com", svc.cfg.Delay))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestAcquireHostLimitsConcurrency(t *testing.T) {
+	svc := &contentFetcherService{
+		cfg:       config.ContentFetchConfig{MaxPerHost: 1},
+		hostSlots: make(map[string]chan struct{}),
+	}
+
+	release, err := svc.acquireHost(context.Background(), "example.com")
+	assert.NoError(t, err)
+
+	_, err = svc.acquireHost(context.Background(), "other.com")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = svc.acquireHost(ctx, "example.com")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	_, err = svc.acquireHost(context.Background(), "example.com")
+	assert.NoError(t, err)
+}
+
+// Run the test suite
+func TestContentFetcherServiceSuite(t *testing.T) {
+	suite.Run(t, new(ContentFetcherServiceTestSuite))
+}
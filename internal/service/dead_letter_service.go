@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// deadLetterService implements DeadLetterService interface
+type deadLetterService struct {
+	repo        repository.DeadLetterRepository
+	postService PostService
+	logger      *logger.Logger
+}
+
+// NewDeadLetterService creates a new dead letter service. Dead letters are
+// stored again through postService when retried.
+func NewDeadLetterService(repo repository.DeadLetterRepository, postService PostService, logger *logger.Logger) DeadLetterService {
+	return &deadLetterService{
+		repo:        repo,
+		postService: postService,
+		logger:      logger,
+	}
+}
+
+// Record keeps an article that failed to persist with the error it failed
+// with. A failure to record it is only logged, the article then being lost
+// as it was before dead letters existed.
+func (s *deadLetterService) Record(ctx context.Context, article *model.NewsAPIArticleParams, cause error) {
+	payload, err := json.Marshal(article)
+	if err != nil {
+		s.logger.Warn("Failed to encode dead letter", "url", article.URL, "error", err.Error())
+		return
+	}
+
+	letter := &model.DeadLetter{
+		URL:       article.URL,
+		Payload:   payload,
+		ErrorType: aggregationErrorType(cause),
+		Error:     cause.Error(),
+	}
+	if article.Source.Name != "" {
+		letter.Source = &article.Source.Name
+	}
+	if article.Country != "" {
+		letter.Country = &article.Country
+	}
+
+	if err := s.repo.SaveDeadLetter(ctx, letter); err != nil {
+		s.logger.Error("Failed to record dead letter", "url", article.URL, "cause", cause.Error(), "error", err.Error())
+	}
+}
+
+// ListDeadLetters retrieves dead letters with pagination, most recent
+// failure first
+func (s *deadLetterService) ListDeadLetters(ctx context.Context, req *model.DeadLetterListParams) (*model.DeadLetterListResponse, error) {
+	start := time.Now()
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	letters, err := s.repo.ListDeadLetters(ctx, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		s.logger.LogServiceOperation("dead_letter", "list_dead_letters", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	total, err := s.repo.CountDeadLetters(ctx)
+	if err != nil {
+		s.logger.LogServiceOperation("dead_letter", "list_dead_letters", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	s.logger.LogServiceOperation("dead_letter", "list_dead_letters", true, time.Since(start).Milliseconds())
+
+	return &model.DeadLetterListResponse{
+		DeadLetters: letters,
+		Pagination:  model.CalculatePagination(req.Page, req.Limit, total),
+	}, nil
+}
+
+// RetryDeadLetter stores a dead letter's article again. It is removed once
+// stored, or when it turns out to be stored already; an article that fails
+// again keeps its entry with the new error and the error is returned.
+func (s *deadLetterService) RetryDeadLetter(ctx context.Context, id int64) (*model.DeadLetterRetryResponse, error) {
+	start := time.Now()
+
+	letter, err := s.repo.GetDeadLetter(ctx, id)
+	if err != nil {
+		s.logger.LogServiceOperation("dead_letter", "retry_dead_letter", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	if letter == nil {
+		s.logger.LogServiceOperation("dead_letter", "retry_dead_letter", false, time.Since(start).Milliseconds())
+		return nil, ErrDeadLetterNotFound
+	}
+
+	var article model.NewsAPIArticleParams
+	if err := json.Unmarshal(letter.Payload, &article); err != nil {
+		s.logger.LogServiceOperation("dead_letter", "retry_dead_letter", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to decode dead letter: %w: %w", ErrArticleParse, err)
+	}
+	if letter.Country != nil {
+		article.Country = *letter.Country
+	}
+
+	post, err := s.postService.CreatePostFromNewsAPI(ctx, &article)
+	if err != nil {
+		err = storageError(err)
+		s.Record(ctx, &article, err)
+		s.logger.LogServiceOperation("dead_letter", "retry_dead_letter", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	if _, err := s.repo.DeleteDeadLetter(ctx, id); err != nil {
+		s.logger.Warn("Failed to remove stored dead letter", "id", id, "error", err.Error())
+	}
+
+	s.logger.Info("Dead letter stored", "id", id, "url", letter.URL, "duplicate", post == nil)
+	s.logger.LogServiceOperation("dead_letter", "retry_dead_letter", true, time.Since(start).Milliseconds())
+
+	// A nil post without an error means the URL was already stored
+	return &model.DeadLetterRetryResponse{Post: post, Duplicate: post == nil}, nil
+}
+
+// DiscardDeadLetter drops a dead letter without storing its article
+func (s *deadLetterService) DiscardDeadLetter(ctx context.Context, id int64) error {
+	start := time.Now()
+
+	deleted, err := s.repo.DeleteDeadLetter(ctx, id)
+	if err != nil {
+		s.logger.LogServiceOperation("dead_letter", "discard_dead_letter", false, time.Since(start).Milliseconds())
+		return fmt.Errorf("failed to discard dead letter: %w", err)
+	}
+	if !deleted {
+		s.logger.LogServiceOperation("dead_letter", "discard_dead_letter", false, time.Since(start).Milliseconds())
+		return ErrDeadLetterNotFound
+	}
+
+	s.logger.Info("Dead letter discarded", "id", id)
+	s.logger.LogServiceOperation("dead_letter", "discard_dead_letter", true, time.Since(start).Milliseconds())
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockDeadLetterRepository is a mock implementation of DeadLetterRepository
+type MockDeadLetterRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeadLetterRepository) SaveDeadLetter(ctx context.Context, letter *model.DeadLetter) error {
+	args := m.Called(ctx, letter)
+	return args.Error(0)
+}
+
+func (m *MockDeadLetterRepository) GetDeadLetter(ctx context.Context, id int64) (*model.DeadLetter, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DeadLetter), args.Error(1)
+}
+
+func (m *MockDeadLetterRepository) ListDeadLetters(ctx context.Context, limit, offset int) ([]model.DeadLetter, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.DeadLetter), args.Error(1)
+}
+
+func (m *MockDeadLetterRepository) CountDeadLetters(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDeadLetterRepository) DeleteDeadLetter(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// DeadLetterServiceTestSuite defines the test suite for DeadLetterService
+type DeadLetterServiceTestSuite struct {
+	suite.Suite
+	mockRepo        *MockDeadLetterRepository
+	mockPostService *MockPostService
+	service         DeadLetterService
+	ctx             context.Context
+}
+
+func (suite *DeadLetterServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockDeadLetterRepository)
+	suite.mockPostService = new(MockPostService)
+	suite.service = NewDeadLetterService(suite.mockRepo, suite.mockPostService, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *DeadLetterServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockPostService.AssertExpectations(suite.T())
+}
+
+// deadLetter returns a stored dead letter for a TechCrunch article fetched for country
+func (suite *DeadLetterServiceTestSuite) deadLetter(country string) *model.DeadLetter {
+	payload := `{"source":{"id":"techcrunch","name":"TechCrunch"},"title":"New breakthrough in AI","url":"https://example.com/ai","publishedAt":"2024-01-20T10:00:00Z"}`
+	return &model.DeadLetter{
+		ID:        7,
+		URL:       "https://example.com/ai",
+		Country:   &country,
+		Payload:   json.RawMessage(payload),
+		ErrorType: model.AggregationErrorDB,
+		Error:     "post could not be stored: connection refused",
+		Attempts:  1,
+	}
+}
+
+func (suite *DeadLetterServiceTestSuite) TestRecordKeepsArticleAndError() {
+	article := &model.NewsAPIArticleParams{Title: "Headline", URL: "https://example.com/a", Country: "gb"}
+	article.Source.Name = "BBC"
+
+	suite.mockRepo.On("SaveDeadLetter", suite.ctx, mock.MatchedBy(func(letter *model.DeadLetter) bool {
+		return letter.URL == "https://example.com/a" && *letter.Source == "BBC" && *letter.Country == "gb" &&
+			letter.ErrorType == model.AggregationErrorParse && letter.Error != "" &&
+			strings.Contains(string(letter.Payload), `"title":"Headline"`)
+	})).Return(nil).Once()
+
+	suite.service.Record(suite.ctx, article, storageError(ErrArticleParse))
+}
+
+func (suite *DeadLetterServiceTestSuite) TestListDeadLettersPaginates() {
+	letters := []model.DeadLetter{*suite.deadLetter("us")}
+	suite.mockRepo.On("ListDeadLetters", suite.ctx, 10, 10).Return(letters, nil).Once()
+	suite.mockRepo.On("CountDeadLetters", suite.ctx).Return(int64(11), nil).Once()
+
+	result, err := suite.service.ListDeadLetters(suite.ctx, &model.DeadLetterListParams{Page: 2, Limit: 10})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.DeadLetters, 1)
+	assert.Equal(suite.T(), int64(11), result.Pagination.Total)
+}
+
+func (suite *DeadLetterServiceTestSuite) TestRetryDeadLetterStoresAndRemoves() {
+	suite.mockRepo.On("GetDeadLetter", suite.ctx, int64(7)).Return(suite.deadLetter("us"), nil).Once()
+	post := &model.Post{ID: 42, URL: "https://example.com/ai"}
+	suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, mock.MatchedBy(func(article *model.NewsAPIArticleParams) bool {
+		return article.URL == "https://example.com/ai" && article.Source.Name == "TechCrunch" && article.Country == "us"
+	})).Return(post, nil).Once()
+	suite.mockRepo.On("DeleteDeadLetter", suite.ctx, int64(7)).Return(true, nil).Once()
+
+	result, err := suite.service.RetryDeadLetter(suite.ctx, 7)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), post, result.Post)
+	assert.False(suite.T(), result.Duplicate)
+}
+
+func (suite *DeadLetterServiceTestSuite) TestRetryDeadLetterAlreadyStored() {
+	suite.mockRepo.On("GetDeadLetter", suite.ctx, int64(7)).Return(suite.deadLetter("us"), nil).Once()
+	suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, mock.Anything).Return(nil, nil).Once()
+	suite.mockRepo.On("DeleteDeadLetter", suite.ctx, int64(7)).Return(true, nil).Once()
+
+	result, err := suite.service.RetryDeadLetter(suite.ctx, 7)
+
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result.Post)
+	assert.True(suite.T(), result.Duplicate)
+}
+
+func (suite *DeadLetterServiceTestSuite) TestRetryDeadLetterFailsAgain() {
+	suite.mockRepo.On("GetDeadLetter", suite.ctx, int64(7)).Return(suite.deadLetter("us"), nil).Once()
+	suite.mockPostService.On("CreatePostFromNewsAPI", suite.ctx, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	suite.mockRepo.On("SaveDeadLetter", suite.ctx, mock.MatchedBy(func(letter *model.DeadLetter) bool {
+		return letter.URL == "https://example.com/ai" && letter.ErrorType == model.AggregationErrorDB
+	})).Return(nil).Once()
+
+	_, err := suite.service.RetryDeadLetter(suite.ctx, 7)
+
+	assert.ErrorIs(suite.T(), err, ErrPostStorage)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteDeadLetter", mock.Anything, mock.Anything)
+}
+
+func (suite *DeadLetterServiceTestSuite) TestRetryDeadLetterNotFound() {
+	suite.mockRepo.On("GetDeadLetter", suite.ctx, int64(8)).Return(nil, nil).Once()
+
+	_, err := suite.service.RetryDeadLetter(suite.ctx, 8)
+
+	assert.ErrorIs(suite.T(), err, ErrDeadLetterNotFound)
+}
+
+func (suite *DeadLetterServiceTestSuite) TestDiscardDeadLetterNotFound() {
+	suite.mockRepo.On("DeleteDeadLetter", suite.ctx, int64(8)).Return(false, nil).Once()
+
+	err := suite.service.DiscardDeadLetter(suite.ctx, 8)
+
+	assert.ErrorIs(suite.T(), err, ErrDeadLetterNotFound)
+}
+
+func TestDeadLetterServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterServiceTestSuite))
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// experimentBuckets is the number of buckets users are hashed into; variant
+// allocations are expressed as a share of these buckets and must sum to it
+const experimentBuckets = 100
+
+var (
+	ErrExperimentNotFound       = errors.New("experiment not found")
+	ErrInvalidExperiment        = errors.New("experiment is invalid")
+	ErrExperimentVariantUnknown = errors.New("experiment variant not found")
+)
+
+// experimentService implements ExperimentService interface
+type experimentService struct {
+	repo   repository.ExperimentRepository
+	logger *logger.Logger
+}
+
+// NewExperimentService creates a new experiment service
+func NewExperimentService(repo repository.ExperimentRepository, logger *logger.Logger) ExperimentService {
+	return &experimentService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// UpsertExperiment creates or replaces the experiment with the given name
+func (s *experimentService) UpsertExperiment(ctx context.Context, name string, req *model.UpsertExperimentParams) (*model.Experiment, error) {
+	start := time.Now()
+
+	name = strings.TrimSpace(name)
+	if err := validateExperiment(name, req.Variants); err != nil {
+		s.logger.LogServiceOperation("experiment", "upsert_experiment", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	for i := range req.Variants {
+		req.Variants[i].Weights.SourceWeights = normalizeWeightKeys(req.Variants[i].Weights.SourceWeights)
+		req.Variants[i].Weights.CategoryBoosts = normalizeWeightKeys(req.Variants[i].Weights.CategoryBoosts)
+	}
+
+	now := time.Now()
+	experiment := &model.Experiment{
+		Name:      name,
+		Enabled:   req.Enabled,
+		Variants:  req.Variants,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	existing, err := s.findExperiment(ctx, name)
+	if err != nil && !errors.Is(err, ErrExperimentNotFound) {
+		s.logger.LogServiceOperation("experiment", "upsert_experiment", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+	if existing != nil {
+		experiment.CreatedAt = existing.CreatedAt
+	}
+
+	if err := s.repo.SaveExperiment(ctx, experiment); err != nil {
+		s.logger.LogServiceOperation("experiment", "upsert_experiment", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	s.logger.LogServiceOperation("experiment", "upsert_experiment", true, time.Since(start).Milliseconds())
+
+	return experiment, nil
+}
+
+// ListExperiments returns all experiments ordered by name
+func (s *experimentService) ListExperiments(ctx context.Context) ([]model.Experiment, error) {
+	return s.repo.ListExperiments(ctx)
+}
+
+// DeleteExperiment removes an experiment definition. Recorded events are kept.
+func (s *experimentService) DeleteExperiment(ctx context.Context, name string) error {
+	deleted, err := s.repo.DeleteExperiment(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return ErrExperimentNotFound
+	}
+
+	return nil
+}
+
+// AssignFeedVariant buckets userID into the active feed experiment. The first
+// enabled experiment by name applies; nil is returned when none is enabled.
+func (s *experimentService) AssignFeedVariant(ctx context.Context, userID string) (*model.ExperimentAssignment, *model.RankingWeights, error) {
+	if userID == "" {
+		return nil, nil, nil
+	}
+
+	experiments, err := s.repo.ListExperiments(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, experiment := range experiments {
+		if !experiment.Enabled {
+			continue
+		}
+
+		variant := BucketVariant(experiment, userID)
+		if variant == nil {
+			continue
+		}
+
+		assignment := &model.ExperimentAssignment{
+			Experiment: experiment.Name,
+			Variant:    variant.Name,
+		}
+		weights := variant.Weights
+
+		return assignment, &weights, nil
+	}
+
+	return nil, nil, nil
+}
+
+// RecordEvent stores an exposure or click after checking the variant exists
+func (s *experimentService) RecordEvent(ctx context.Context, event *model.ExperimentEvent) error {
+	start := time.Now()
+
+	experiment, err := s.findExperiment(ctx, event.Experiment)
+	if err != nil {
+		s.logger.LogServiceOperation("experiment", "record_event", false, time.Since(start).Milliseconds())
+		return err
+	}
+
+	known := false
+	for _, variant := range experiment.Variants {
+		if variant.Name == event.Variant {
+			known = true
+			break
+		}
+	}
+	if !known {
+		s.logger.LogServiceOperation("experiment", "record_event", false, time.Since(start).Milliseconds())
+		return ErrExperimentVariantUnknown
+	}
+
+	if err := s.repo.RecordEvent(ctx, event); err != nil {
+		s.logger.LogServiceOperation("experiment", "record_event", false, time.Since(start).Milliseconds())
+		return err
+	}
+
+	s.logger.LogServiceOperation("experiment", "record_event", true, time.Since(start).Milliseconds())
+
+	return nil
+}
+
+// GetResults returns exposure, click and CTR totals per variant
+func (s *experimentService) GetResults(ctx context.Context, name string) (*model.ExperimentResults, error) {
+	if _, err := s.findExperiment(ctx, name); err != nil {
+		return nil, err
+	}
+
+	variants, err := s.repo.GetResults(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if variants == nil {
+		variants = []model.VariantResult{}
+	}
+
+	return &model.ExperimentResults{Experiment: name, Variants: variants}, nil
+}
+
+// findExperiment looks up a single experiment by name
+func (s *experimentService) findExperiment(ctx context.Context, name string) (*model.Experiment, error) {
+	experiments, err := s.repo.ListExperiments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range experiments {
+		if experiments[i].Name == name {
+			return &experiments[i], nil
+		}
+	}
+
+	return nil, ErrExperimentNotFound
+}
+
+// BucketVariant deterministically maps userID onto one of the experiment's
+// variants. Hashing includes the experiment name so the same user lands in
+// independent buckets across experiments.
+func BucketVariant(experiment model.Experiment, userID string) *model.ExperimentVariant {
+	h := fnv.New32a()
+	h.Write([]byte(experiment.Name + ":" + userID))
+	bucket := int(h.Sum32() % experimentBuckets)
+
+	cumulative := 0
+	for i := range experiment.Variants {
+		cumulative += experiment.Variants[i].Allocation
+		if bucket < cumulative {
+			return &experiment.Variants[i]
+		}
+	}
+
+	return nil
+}
+
+// validateExperiment checks names are unique, weights are usable and
+// allocations cover every bucket exactly once
+func validateExperiment(name string, variants []model.ExperimentVariant) error {
+	if name == "" || len(variants) == 0 {
+		return ErrInvalidExperiment
+	}
+
+	seen := make(map[string]bool, len(variants))
+	total := 0
+	for _, variant := range variants {
+		if variant.Name == "" || seen[variant.Name] || variant.Allocation < 0 {
+			return ErrInvalidExperiment
+		}
+		seen[variant.Name] = true
+		total += variant.Allocation
+
+		weights := variant.Weights
+		if weights.RecencyWeight < 0 || weights.ViewWeight < 0 || weights.RecencyHalfLife <= 0 {
+			return fmt.Errorf("%w: variant %s has invalid ranking weights", ErrInvalidExperiment, variant.Name)
+		}
+	}
+
+	if total != experimentBuckets {
+		return fmt.Errorf("%w: allocations must sum to %d, got %d", ErrInvalidExperiment, experimentBuckets, total)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockExperimentRepository is a mock implementation of ExperimentRepository
+type MockExperimentRepository struct {
+	mock.Mock
+}
+
+func (m *MockExperimentRepository) SaveExperiment(ctx context.Context, experiment *model.Experiment) error {
+	args := m.Called(ctx, experiment)
+	return args.Error(0)
+}
+
+func (m *MockExperimentRepository) ListExperiments(ctx context.Context) ([]model.Experiment, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Experiment), args.Error(1)
+}
+
+func (m *MockExperimentRepository) DeleteExperiment(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockExperimentRepository) RecordEvent(ctx context.Context, event *model.ExperimentEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockExperimentRepository) GetResults(ctx context.Context, experiment string) ([]model.VariantResult, error) {
+	args := m.Called(ctx, experiment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.VariantResult), args.Error(1)
+}
+
+// ExperimentServiceTestSuite defines the test suite for ExperimentService
+type ExperimentServiceTestSuite struct {
+	suite.Suite
+	mockRepo *MockExperimentRepository
+	service  ExperimentService
+	ctx      context.Context
+}
+
+func (suite *ExperimentServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockExperimentRepository)
+	suite.service = NewExperimentService(suite.mockRepo, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *ExperimentServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ExperimentServiceTestSuite) testExperiment() model.Experiment {
+	return model.Experiment{
+		Name:    "recency-vs-views",
+		Enabled: true,
+		Variants: []model.ExperimentVariant{
+			{Name: "control", Allocation: 50, Weights: model.DefaultRankingWeights()},
+			{Name: "views", Allocation: 50, Weights: model.DefaultRankingWeights()},
+		},
+	}
+}
+
+func (suite *ExperimentServiceTestSuite) TestUpsertExperimentSuccess() {
+	experiment := suite.testExperiment()
+	req := &model.UpsertExperimentParams{Enabled: true, Variants: experiment.Variants}
+
+	suite.mockRepo.On("ListExperiments", suite.ctx).Return([]model.Experiment{}, nil)
+	suite.mockRepo.On("SaveExperiment", suite.ctx, mock.AnythingOfType("*model.Experiment")).Return(nil)
+
+	result, err := suite.service.UpsertExperiment(suite.ctx, "recency-vs-views", req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "recency-vs-views", result.Name)
+	assert.Len(suite.T(), result.Variants, 2)
+}
+
+func (suite *ExperimentServiceTestSuite) TestUpsertExperimentRejectsBadAllocation() {
+	experiment := suite.testExperiment()
+	experiment.Variants[1].Allocation = 40
+	req := &model.UpsertExperimentParams{Enabled: true, Variants: experiment.Variants}
+
+	result, err := suite.service.UpsertExperiment(suite.ctx, "recency-vs-views", req)
+
+	assert.ErrorIs(suite.T(), err, ErrInvalidExperiment)
+	assert.Nil(suite.T(), result)
+}
+
+func (suite *ExperimentServiceTestSuite) TestAssignFeedVariantSkipsDisabled() {
+	experiment := suite.testExperiment()
+	experiment.Enabled = false
+
+	suite.mockRepo.On("ListExperiments", suite.ctx).Return([]model.Experiment{experiment}, nil)
+
+	assignment, weights, err := suite.service.AssignFeedVariant(suite.ctx, "user-1")
+
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), assignment)
+	assert.Nil(suite.T(), weights)
+}
+
+func (suite *ExperimentServiceTestSuite) TestRecordEventUnknownVariant() {
+	suite.mockRepo.On("ListExperiments", suite.ctx).Return([]model.Experiment{suite.testExperiment()}, nil)
+
+	err := suite.service.RecordEvent(suite.ctx, &model.ExperimentEvent{
+		Experiment: "recency-vs-views",
+		Variant:    "missing",
+		Type:       model.ExperimentEventClick,
+	})
+
+	assert.ErrorIs(suite.T(), err, ErrExperimentVariantUnknown)
+}
+
+func (suite *ExperimentServiceTestSuite) TestDeleteExperimentNotFound() {
+	suite.mockRepo.On("DeleteExperiment", suite.ctx, "missing").Return(false, nil)
+
+	err := suite.service.DeleteExperiment(suite.ctx, "missing")
+
+	assert.ErrorIs(suite.T(), err, ErrExperimentNotFound)
+}
+
+func TestBucketVariantIsDeterministic(t *testing.T) {
+	experiment := model.Experiment{
+		Name: "recency-vs-views",
+		Variants: []model.ExperimentVariant{
+			{Name: "control", Allocation: 50},
+			{Name: "views", Allocation: 50},
+		},
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		userID := string(rune('a'+i%26)) + string(rune('0'+i/26%10)) + string(rune('A'+i/260))
+		first := BucketVariant(experiment, userID)
+		second := BucketVariant(experiment, userID)
+
+		assert.Equal(t, first.Name, second.Name)
+		counts[first.Name]++
+	}
+
+	assert.Greater(t, counts["control"], 0)
+	assert.Greater(t, counts["views"], 0)
+}
+
+func TestExperimentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ExperimentServiceTestSuite))
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// rankingCandidateLimit bounds how many recent posts are scored per request
+const rankingCandidateLimit = 500
+
+var ErrInvalidRankingWeights = errors.New("ranking weights are invalid")
+
+// feedRankingService implements FeedRankingService interface
+type feedRankingService struct {
+	repo        repository.PostRepository
+	experiments ExperimentService
+	pins        PinService
+	logger      *logger.Logger
+	mu          sync.RWMutex
+	weights     model.RankingWeights
+}
+
+// NewFeedRankingService creates a new feed ranking service. When pins is set,
+// the first page of the feed starts with the pinned posts.
+func NewFeedRankingService(repo repository.PostRepository, experiments ExperimentService, pins PinService, logger *logger.Logger) FeedRankingService {
+	return &feedRankingService{
+		repo:        repo,
+		experiments: experiments,
+		pins:        pins,
+		logger:      logger,
+		weights:     model.DefaultRankingWeights(),
+	}
+}
+
+// GetRankedFeed returns a page of recent posts ordered by score. The first
+// page of the feed across categories starts with the pinned posts.
+func (s *feedRankingService) GetRankedFeed(ctx context.Context, req *model.RankedFeedParams) (*model.RankedFeedResponse, error) {
+	start := time.Now()
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	candidates, err := s.repo.ListPosts(ctx, &model.PostListParams{
+		Page:     1,
+		Limit:    rankingCandidateLimit,
+		Category: req.Category,
+		SafeMode: req.SafeMode,
+	})
+	if err != nil {
+		s.logger.LogServiceOperation("feed_ranking", "get_ranked_feed", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to list candidate posts: %w", err)
+	}
+
+	var pinned []model.Post
+	if s.pins != nil && req.Page == 1 && (req.Category == nil || *req.Category == "") {
+		pinned, err = s.pins.PinnedPosts(ctx, req.SafeMode)
+		if err != nil {
+			s.logger.Warn("Failed to load pinned posts, ranking without them", "error", err.Error())
+		}
+	}
+
+	ids := make([]int64, 0, len(candidates)+len(pinned))
+	for _, post := range candidates {
+		ids = append(ids, post.ID)
+	}
+	for _, post := range pinned {
+		ids = append(ids, post.ID)
+	}
+
+	views, err := s.repo.GetPostViews(ctx, ids)
+	if err != nil {
+		s.logger.Warn("Failed to load post views, ranking without them", "error", err.Error())
+		views = map[int64]int64{}
+	}
+
+	weights := s.GetWeights()
+	assignment := s.assignVariant(ctx, req.UserID, &weights)
+	now := time.Now()
+
+	ranked := make([]model.RankedPost, len(candidates))
+	for i, post := range candidates {
+		ranked[i] = model.RankedPost{
+			Post:  post,
+			Views: views[post.ID],
+			Score: ScorePost(&post, views[post.ID], weights, now),
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+
+	total := int64(len(ranked))
+	offset := (req.Page - 1) * req.Limit
+	end := offset + req.Limit
+	if offset > len(ranked) {
+		offset = len(ranked)
+	}
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+
+	response := &model.RankedFeedResponse{
+		Posts:      withPinnedRanked(pinned, ranked[offset:end], views, weights, now),
+		Pagination: model.CalculatePagination(req.Page, req.Limit, total),
+		Experiment: assignment,
+	}
+
+	s.logger.LogServiceOperation("feed_ranking", "get_ranked_feed", true, time.Since(start).Milliseconds())
+
+	return response, nil
+}
+
+// withPinnedRanked puts the pinned posts, scored like the others, ahead of
+// the ranked page, dropping their regular place in it
+func withPinnedRanked(pinned []model.Post, page []model.RankedPost, views map[int64]int64, weights model.RankingWeights, now time.Time) []model.RankedPost {
+	if len(pinned) == 0 {
+		return page
+	}
+
+	ids := make(map[int64]bool, len(pinned))
+	merged := make([]model.RankedPost, 0, len(pinned)+len(page))
+	for _, post := range pinned {
+		ids[post.ID] = true
+		merged = append(merged, model.RankedPost{
+			Post:  post,
+			Views: views[post.ID],
+			Score: ScorePost(&post, views[post.ID], weights, now),
+		})
+	}
+
+	for _, post := range page {
+		if !ids[post.ID] {
+			merged = append(merged, post)
+		}
+	}
+
+	return merged
+}
+
+// assignVariant applies the user's experiment variant weights, if any, and
+// records the exposure. Experiment failures fall back to the default weights.
+func (s *feedRankingService) assignVariant(ctx context.Context, userID string, weights *model.RankingWeights) *model.ExperimentAssignment {
+	if s.experiments == nil || userID == "" {
+		return nil
+	}
+
+	assignment, variantWeights, err := s.experiments.AssignFeedVariant(ctx, userID)
+	if err != nil {
+		s.logger.Warn("Failed to assign experiment variant, using default weights", "error", err.Error())
+		return nil
+	}
+	if assignment == nil {
+		return nil
+	}
+
+	*weights = *variantWeights
+
+	event := &model.ExperimentEvent{
+		Experiment: assignment.Experiment,
+		Variant:    assignment.Variant,
+		Type:       model.ExperimentEventExposure,
+		UserID:     userID,
+	}
+	if err := s.experiments.RecordEvent(ctx, event); err != nil {
+		s.logger.Warn("Failed to record experiment exposure", "experiment", assignment.Experiment, "error", err.Error())
+	}
+
+	return assignment
+}
+
+// GetWeights returns a copy of the active ranking weights
+func (s *feedRankingService) GetWeights() model.RankingWeights {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	weights := s.weights
+	weights.SourceWeights = maps.Clone(s.weights.SourceWeights)
+	weights.CategoryBoosts = maps.Clone(s.weights.CategoryBoosts)
+
+	return weights
+}
+
+// UpdateWeights validates and atomically replaces the active ranking weights
+func (s *feedRankingService) UpdateWeights(weights model.RankingWeights) error {
+	if weights.RecencyWeight < 0 || weights.ViewWeight < 0 || weights.RecencyHalfLife <= 0 {
+		return ErrInvalidRankingWeights
+	}
+
+	for _, w := range weights.SourceWeights {
+		if w < 0 {
+			return ErrInvalidRankingWeights
+		}
+	}
+
+	for _, w := range weights.CategoryBoosts {
+		if w < 0 {
+			return ErrInvalidRankingWeights
+		}
+	}
+
+	s.mu.Lock()
+	s.weights = model.RankingWeights{
+		RecencyWeight:   weights.RecencyWeight,
+		RecencyHalfLife: weights.RecencyHalfLife,
+		ViewWeight:      weights.ViewWeight,
+		SourceWeights:   normalizeWeightKeys(weights.SourceWeights),
+		CategoryBoosts:  normalizeWeightKeys(weights.CategoryBoosts),
+	}
+	s.mu.Unlock()
+
+	s.logger.Info("Feed ranking weights updated",
+		"recency_weight", weights.RecencyWeight,
+		"recency_half_life", weights.RecencyHalfLife.String(),
+		"view_weight", weights.ViewWeight,
+	)
+
+	return nil
+}
+
+// ScorePost computes a post's feed score. Recency decays exponentially with the
+// configured half-life, views contribute logarithmically, and the sum is scaled
+// by the source weight and category boost (both default to 1).
+func ScorePost(post *model.Post, views int64, weights model.RankingWeights, now time.Time) float64 {
+	publishedAt := post.CreatedAt
+	if post.PublishedAt != nil {
+		publishedAt = *post.PublishedAt
+	}
+
+	age := now.Sub(publishedAt)
+	if age < 0 {
+		age = 0
+	}
+
+	recency := 1.0
+	if weights.RecencyHalfLife > 0 {
+		recency = math.Pow(0.5, age.Hours()/weights.RecencyHalfLife.Hours())
+	}
+
+	score := weights.RecencyWeight*recency + weights.ViewWeight*math.Log1p(float64(views))
+
+	if w, ok := weights.SourceWeights[strings.ToLower(post.Source)]; ok {
+		score *= w
+	}
+
+	if post.Category != nil {
+		if boost, ok := weights.CategoryBoosts[strings.ToLower(*post.Category)]; ok {
+			score *= boost
+		}
+	}
+
+	return score
+}
+
+// normalizeWeightKeys lower-cases map keys so lookups are case-insensitive
+func normalizeWeightKeys(weights map[string]float64) map[string]float64 {
+	normalized := make(map[string]float64, len(weights))
+	for k, v := range weights {
+		normalized[strings.ToLower(strings.TrimSpace(k))] = v
+	}
+	return normalized
+}
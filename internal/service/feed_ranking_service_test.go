@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// FeedRankingServiceTestSuite defines the test suite for FeedRankingService
+type FeedRankingServiceTestSuite struct {
+	suite.Suite
+	mockRepo           *MockPostRepository
+	mockExperimentRepo *MockExperimentRepository
+	service            FeedRankingService
+	ctx                context.Context
+}
+
+func (suite *FeedRankingServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	log := logger.New(cfg)
+
+	suite.mockRepo = new(MockPostRepository)
+	suite.mockExperimentRepo = new(MockExperimentRepository)
+	suite.service = NewFeedRankingService(suite.mockRepo, NewExperimentService(suite.mockExperimentRepo, log), nil, log)
+	suite.ctx = context.Background()
+}
+
+func (suite *FeedRankingServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockExperimentRepo.AssertExpectations(suite.T())
+}
+
+func (suite *FeedRankingServiceTestSuite) TestGetRankedFeedOrdersByScore() {
+	old := time.Now().Add(-48 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	posts := []model.Post{
+		{ID: 1, Title: "Old", Source: "A", PublishedAt: &old},
+		{ID: 2, Title: "Recent", Source: "B", PublishedAt: &recent},
+	}
+
+	suite.mockRepo.On("ListPosts", suite.ctx, mock.AnythingOfType("*model.PostListParams")).Return(posts, nil)
+	suite.mockRepo.On("GetPostViews", suite.ctx, []int64{1, 2}).Return(map[int64]int64{}, nil)
+
+	result, err := suite.service.GetRankedFeed(suite.ctx, &model.RankedFeedParams{Page: 1, Limit: 10})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Posts, 2)
+	assert.Equal(suite.T(), int64(2), result.Posts[0].ID)
+	assert.Equal(suite.T(), int64(2), result.Pagination.Total)
+}
+
+func (suite *FeedRankingServiceTestSuite) TestGetRankedFeedStartsWithPinned() {
+	old := time.Now().Add(-48 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	posts := []model.Post{
+		{ID: 1, Title: "Old", Source: "A", PublishedAt: &old},
+		{ID: 2, Title: "Recent", Source: "B", PublishedAt: &recent},
+	}
+	pinned := model.Post{ID: 1, Title: "Old", Source: "A", PublishedAt: &old, Pinned: true}
+	service := NewFeedRankingService(suite.mockRepo, nil, fixedPins{posts: []model.Post{pinned}}, logger.New(&config.Config{App: config.AppConfig{LogLevel: "debug"}}))
+
+	suite.mockRepo.On("ListPosts", suite.ctx, mock.AnythingOfType("*model.PostListParams")).Return(posts, nil)
+	suite.mockRepo.On("GetPostViews", suite.ctx, []int64{1, 2, 1}).Return(map[int64]int64{1: 5}, nil)
+
+	result, err := service.GetRankedFeed(suite.ctx, &model.RankedFeedParams{Page: 1, Limit: 10})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Posts, 2)
+	assert.Equal(suite.T(), int64(1), result.Posts[0].ID)
+	assert.True(suite.T(), result.Posts[0].Pinned)
+	assert.Equal(suite.T(), int64(5), result.Posts[0].Views)
+	assert.Equal(suite.T(), int64(2), result.Posts[1].ID)
+	assert.Equal(suite.T(), int64(2), result.Pagination.Total)
+}
+
+func (suite *FeedRankingServiceTestSuite) TestGetRankedFeedPaginatesPastEnd() {
+	suite.mockRepo.On("ListPosts", suite.ctx, mock.AnythingOfType("*model.PostListParams")).Return([]model.Post{{ID: 1}}, nil)
+	suite.mockRepo.On("GetPostViews", suite.ctx, []int64{1}).Return(map[int64]int64{}, nil)
+
+	result, err := suite.service.GetRankedFeed(suite.ctx, &model.RankedFeedParams{Page: 3, Limit: 10})
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result.Posts)
+}
+
+func (suite *FeedRankingServiceTestSuite) TestGetRankedFeedAppliesExperimentVariant() {
+	old := time.Now().Add(-48 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	posts := []model.Post{
+		{ID: 1, Title: "Old", Source: "A", PublishedAt: &old},
+		{ID: 2, Title: "Recent", Source: "B", PublishedAt: &recent},
+	}
+
+	weights := model.DefaultRankingWeights()
+	weights.SourceWeights = map[string]float64{"a": 1000}
+	experiment := model.Experiment{
+		Name:     "source-boost",
+		Enabled:  true,
+		Variants: []model.ExperimentVariant{{Name: "boost-a", Allocation: 100, Weights: weights}},
+	}
+
+	suite.mockRepo.On("ListPosts", suite.ctx, mock.AnythingOfType("*model.PostListParams")).Return(posts, nil)
+	suite.mockRepo.On("GetPostViews", suite.ctx, []int64{1, 2}).Return(map[int64]int64{}, nil)
+	suite.mockExperimentRepo.On("ListExperiments", suite.ctx).Return([]model.Experiment{experiment}, nil)
+	suite.mockExperimentRepo.On("RecordEvent", suite.ctx, mock.MatchedBy(func(e *model.ExperimentEvent) bool {
+		return e.Type == model.ExperimentEventExposure && e.Variant == "boost-a" && e.UserID == "user-1"
+	})).Return(nil)
+
+	result, err := suite.service.GetRankedFeed(suite.ctx, &model.RankedFeedParams{Page: 1, Limit: 10, UserID: "user-1"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), result.Posts[0].ID)
+	assert.Equal(suite.T(), &model.ExperimentAssignment{Experiment: "source-boost", Variant: "boost-a"}, result.Experiment)
+}
+
+func (suite *FeedRankingServiceTestSuite) TestUpdateWeightsRejectsInvalid() {
+	weights := model.DefaultRankingWeights()
+	weights.RecencyHalfLife = 0
+
+	err := suite.service.UpdateWeights(weights)
+
+	assert.ErrorIs(suite.T(), err, ErrInvalidRankingWeights)
+	assert.Equal(suite.T(), model.DefaultRankingWeights().RecencyHalfLife, suite.service.GetWeights().RecencyHalfLife)
+}
+
+func (suite *FeedRankingServiceTestSuite) TestUpdateWeightsNormalizesKeys() {
+	weights := model.DefaultRankingWeights()
+	weights.CategoryBoosts = map[string]float64{" Technology ": 2}
+
+	err := suite.service.UpdateWeights(weights)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2.0, suite.service.GetWeights().CategoryBoosts["technology"])
+}
+
+func TestScorePost(t *testing.T) {
+	now := time.Now()
+	published := now.Add(-6 * time.Hour)
+	category := "Technology"
+	post := &model.Post{Source: "TechCrunch", Category: &category, PublishedAt: &published}
+
+	weights := model.DefaultRankingWeights()
+	weights.ViewWeight = 0
+
+	assert.InDelta(t, 0.5, ScorePost(post, 0, weights, now), 1e-9)
+
+	weights.CategoryBoosts = map[string]float64{"technology": 2}
+	weights.SourceWeights = map[string]float64{"techcrunch": 1.5}
+	assert.InDelta(t, 1.5, ScorePost(post, 0, weights, now), 1e-9)
+
+	weights.ViewWeight = 1
+	assert.Greater(t, ScorePost(post, 100, weights, now), ScorePost(post, 0, weights, now))
+}
+
+func TestFeedRankingServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FeedRankingServiceTestSuite))
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+)
+
+const (
+	// imageHostCacheSize bounds how many hosts' HTTPS support is remembered
+	imageHostCacheSize = 4096
+
+	// maxImageURLLength matches the limit on image_url in post requests
+	maxImageURLLength = 1000
+)
+
+// imageSanitizer implements ImageSanitizer interface
+type imageSanitizer struct {
+	cfg        config.ImageConfig
+	httpClient *http.Client
+	https      *lru.Cache[string, bool]
+	logger     *logger.Logger
+}
+
+// NewImageSanitizer creates the sanitizer applied to the image URLs of
+// ingested articles. Hosts are probed over HTTPS at most once per
+// cfg.ProbeCacheTTL.
+func NewImageSanitizer(cfg config.ImageConfig, logger *logger.Logger) ImageSanitizer {
+	return &imageSanitizer{
+		cfg: cfg,
+		httpClient: &http.Client{
+			Timeout: cfg.ProbeTimeout,
+		},
+		https:  lru.New[string, bool](imageHostCacheSize, cfg.ProbeCacheTTL),
+		logger: logger,
+	}
+}
+
+// Sanitize returns the image URL to store, or nil when imageURL is missing
+// or is not an absolute http(s) URL. http:// URLs are upgraded to https://
+// when their host serves the image over HTTPS.
+func (s *imageSanitizer) Sanitize(ctx context.Context, imageURL *string) *string {
+	if imageURL == nil {
+		return nil
+	}
+
+	raw := strings.TrimSpace(*imageURL)
+	if raw == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || len(raw) > maxImageURLLength || parsed.Host == "" || parsed.User != nil {
+		s.logger.Debug("Dropping invalid image URL", "url", raw)
+		return nil
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "https":
+		return &raw
+	case "http":
+		if !s.cfg.HTTPSUpgrade {
+			return &raw
+		}
+
+		upgraded := *parsed
+		upgraded.Scheme = "https"
+		upgraded.Host = strings.TrimSuffix(parsed.Host, ":80")
+
+		secure := upgraded.String()
+		if s.supportsHTTPS(ctx, upgraded.Host, secure) {
+			return &secure
+		}
+
+		return &raw
+	default:
+		s.logger.Debug("Dropping image URL with unsafe scheme", "scheme", parsed.Scheme)
+		return nil
+	}
+}
+
+// supportsHTTPS reports whether host answers a HEAD request for secureURL,
+// remembering the answer for the host. Probes cut short by ctx are not
+// remembered.
+func (s *imageSanitizer) supportsHTTPS(ctx context.Context, host, secureURL string) bool {
+	host = strings.ToLower(host)
+	if supported, ok := s.https.Get(host); ok {
+		return supported
+	}
+
+	start := time.Now()
+
+	supported, err := s.probe(ctx, secureURL)
+	if err != nil && ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		s.logger.Debug("HTTPS probe failed", "host", host, "error", err.Error())
+	}
+
+	s.https.Set(host, supported)
+	s.logger.LogServiceOperation("image_sanitizer", "probe", err == nil, time.Since(start).Milliseconds())
+
+	return supported
+}
+
+// probe sends a HEAD request for secureURL. Redirects are followed as long
+// as they stay on HTTPS.
+func (s *imageSanitizer) probe(ctx context.Context, secureURL string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, secureURL, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode < http.StatusBadRequest && resp.Request.URL.Scheme == "https", nil
+}
@@ -2,47 +2,66 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/model"
 	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+	"github.com/amirzre/news-feed-system/pkg/newsapi"
 )
 
+// sourcesCacheSize bounds how many filtered source listings are kept
+const sourcesCacheSize = 256
+
 // newsService implements NewsService interface
 type newsService struct {
-	httpClient *http.Client
-	apiKey     string
-	baseURL    string
-	logger     *logger.Logger
+	client   *newsapi.Client
+	apiKey   atomic.Pointer[string]
+	maxPages int
+	sources  *lru.Cache[string, *model.NewsSourcesResponse]
+	tenants  TenantService
+	logger   *logger.Logger
 }
 
-// NewNewsService creates a new news service
-func NewNewsService(cfg *config.Config, logger *logger.Logger) NewsService {
-	return &newsService{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		apiKey:  cfg.NewsAPI.APIKey,
-		baseURL: cfg.NewsAPI.BaseURL,
-		logger:  logger,
+// NewNewsService creates a new news service. When tenants is set, requests
+// use the key and count against the quota of the tenant ctx is scoped to.
+// Queries return up to cfg.NewsAPI.MaxPages pages of results, and source
+// listings are cached for cfg.NewsAPI.SourcesCacheTTL.
+func NewNewsService(cfg *config.Config, tenants TenantService, logger *logger.Logger) NewsService {
+	svc := &newsService{
+		client:   newsapi.New(cfg.NewsAPI.BaseURL, 30*time.Second),
+		maxPages: max(cfg.NewsAPI.MaxPages, 1),
+		sources:  lru.New[string, *model.NewsSourcesResponse](sourcesCacheSize, cfg.NewsAPI.SourcesCacheTTL),
+		tenants:  tenants,
+		logger:   logger,
 	}
+	svc.SetAPIKey(cfg.NewsAPI.APIKey)
+
+	return svc
 }
 
-// GetTopHeadlines fetches top headlines from NewsAPI
+// SetAPIKey replaces the NewsAPI key used by subsequent requests
+func (s *newsService) SetAPIKey(apiKey string) {
+	s.apiKey.Store(&apiKey)
+}
+
+// GetTopHeadlines fetches top headlines from NewsAPI, following further
+// pages as described on fetchPages
 func (s *newsService) GetTopHeadlines(ctx context.Context, req *model.NewsParams) (*model.NewsAPIResponse, error) {
 	start := time.Now()
 
-	endpoint := fmt.Sprintf("%s/top-headlines", s.baseURL)
+	apiKey, err := s.requestAPIKey(ctx)
+	if err != nil {
+		s.logger.LogServiceOperation("news_service", "get_top_headlines", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to get top headlines: %w", err)
+	}
 
 	params := url.Values{}
-	params.Set("apiKey", s.apiKey)
 
 	if req.Query != "" {
 		params.Set("q", req.Query)
@@ -59,35 +78,35 @@ func (s *newsService) GetTopHeadlines(ctx context.Context, req *model.NewsParams
 	if req.PageSize > 0 {
 		params.Set("pageSize", strconv.Itoa(req.PageSize))
 	}
-	if req.Page > 0 {
-		params.Set("page", strconv.Itoa(req.Page))
-	}
-
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
-	response, err := s.makeRequest(ctx, fullURL)
+	response, pages, err := s.fetchPages(ctx, "/top-headlines", apiKey, params, req)
 	if err != nil {
 		s.logger.LogServiceOperation("news_service", "get_top_headlines", false, time.Since(start).Milliseconds())
-		return nil, fmt.Errorf("failed to get top headlines: %w", err)
+		return response, fmt.Errorf("failed to get top headlines: %w", err)
 	}
 
 	s.logger.LogServiceOperation("news_service", "get_top_headlines", true, time.Since(start).Milliseconds())
 	s.logger.Debug("Fetched top headlines",
 		"articles_count", len(response.Articles),
 		"total_results", response.TotalResults,
+		"pages", pages,
 	)
 
 	return response, nil
 }
 
-// GetEverything fetches all articles matching the criteria
+// GetEverything fetches all articles matching the criteria, following
+// further pages as described on fetchPages
 func (s *newsService) GetEverything(ctx context.Context, req *model.NewsParams) (*model.NewsAPIResponse, error) {
 	start := time.Now()
 
-	endpoint := fmt.Sprintf("%s/everything", s.baseURL)
+	apiKey, err := s.requestAPIKey(ctx)
+	if err != nil {
+		s.logger.LogServiceOperation("news_service", "get_everything", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to get everything: %w", err)
+	}
 
 	params := url.Values{}
-	params.Set("apiKey", s.apiKey)
 
 	if req.Query != "" {
 		params.Set("q", req.Query)
@@ -108,37 +127,39 @@ func (s *newsService) GetEverything(ctx context.Context, req *model.NewsParams)
 	if req.PageSize > 0 {
 		params.Set("pageSize", strconv.Itoa(req.PageSize))
 	}
-	if req.Page > 0 {
-		params.Set("page", strconv.Itoa(req.Page))
-	}
 
 	from := time.Now().AddDate(0, 0, -7).Format(time.DateOnly)
+	if req.From != nil {
+		from = req.From.UTC().Format(time.RFC3339)
+	}
 	params.Set("from", from)
+	if req.To != nil {
+		params.Set("to", req.To.UTC().Format(time.RFC3339))
+	}
 	params.Set("sortBy", "publishedAt")
 
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
-
-	response, err := s.makeRequest(ctx, fullURL)
+	response, pages, err := s.fetchPages(ctx, "/everything", apiKey, params, req)
 	if err != nil {
 		s.logger.LogServiceOperation("news_service", "get_everything", false, time.Since(start).Milliseconds())
-		return nil, fmt.Errorf("failed to get everything: %w", err)
+		return response, fmt.Errorf("failed to get everything: %w", err)
 	}
 
 	s.logger.LogServiceOperation("news_service", "get_everything", true, time.Since(start).Milliseconds())
 	s.logger.Debug("Fetched everything articles",
 		"articles_count", len(response.Articles),
 		"total_results", response.TotalResults,
+		"pages", pages,
 	)
 
 	return response, nil
 }
 
-// GetNewsByCategory fetches news by category
-func (s *newsService) GetNewsByCategory(ctx context.Context, category string, pageSize int) (*model.NewsAPIResponse, error) {
+// GetNewsByCategory fetches top headlines for a category in a country.
+// The country already selects the edition, so no language filter is applied.
+func (s *newsService) GetNewsByCategory(ctx context.Context, category, country string, pageSize int) (*model.NewsAPIResponse, error) {
 	params := &model.NewsParams{
 		Category: category,
-		Country:  "us",
-		Language: "en",
+		Country:  country,
 		PageSize: pageSize,
 	}
 
@@ -156,65 +177,157 @@ func (s *newsService) GetNewsBySources(ctx context.Context, sources []string, pa
 	return s.GetEverything(ctx, params)
 }
 
-// makeRequest makes an HTTP request to NewsAPI and handles the response
-func (s *newsService) makeRequest(ctx context.Context, url string) (*model.NewsAPIResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+// GetSources lists the sources NewsAPI offers that match req. The catalog is
+// the same for every tenant, so listings are cached across tenants and only a
+// miss reserves a request against the quota of the tenant ctx is scoped to.
+func (s *newsService) GetSources(ctx context.Context, req *model.NewsSourcesParams) (*model.NewsSourcesResponse, error) {
+	start := time.Now()
+
+	key := "sources:" + req.Category + ":" + req.Language + ":" + req.Country
+	if cached, ok := s.sources.Get(key); ok {
+		s.logger.LogCacheOperation("get", key, true)
+		s.logger.LogServiceOperation("news_service", "get_sources", true, time.Since(start).Milliseconds())
+
+		hit := *cached
+		hit.Cached = true
+		return &hit, nil
+	}
+	s.logger.LogCacheOperation("get", key, false)
+
+	apiKey, err := s.requestAPIKey(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		s.logger.LogServiceOperation("news_service", "get_sources", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to get sources: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "news-feed-system/1.0")
-	req.Header.Set("Accept", "application/json")
+	params := url.Values{}
+	params.Set("apiKey", apiKey)
+	if req.Category != "" {
+		params.Set("category", req.Category)
+	}
+	if req.Language != "" {
+		params.Set("language", req.Language)
+	}
+	if req.Country != "" {
+		params.Set("country", req.Country)
+	}
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	var upstream model.NewsAPISourcesResponse
+	if _, err := s.client.Get(ctx, "/top-headlines/sources", params, &upstream); err != nil {
+		s.logger.LogServiceOperation("news_service", "get_sources", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to get sources: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	sources := upstream.Sources
+	if sources == nil {
+		sources = []model.NewsAPISource{}
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, s.handleAPIError(resp.StatusCode, body)
+	response := &model.NewsSourcesResponse{
+		Sources:   sources,
+		Count:     len(sources),
+		Filters:   *req,
+		FetchedAt: time.Now().UTC(),
 	}
+	s.sources.Set(key, response)
+
+	s.logger.LogServiceOperation("news_service", "get_sources", true, time.Since(start).Milliseconds())
+	s.logger.Debug("Fetched sources", "sources_count", len(sources))
 
-	var newsResponse model.NewsAPIResponse
-	if err := json.Unmarshal(body, &newsResponse); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	return response, nil
+}
+
+// fetchPages requests the pages of a query and combines their articles. A
+// request for a specific page fetches only that page. Otherwise pages are
+// fetched in turn, up to the configured maximum, until every result has been
+// fetched, a page reaches an article published at or before req.SeenUntil,
+// or NewsAPI reports the plan's result limit. apiKey is used for the first
+// page; each further page reserves another request against the tenant's
+// quota. Paging fails with a *newsapi.ErrRateLimited once the rate limit
+// headers report no requests left. When a later page fails, the articles of
+// the earlier pages are returned with its error.
+func (s *newsService) fetchPages(ctx context.Context, path, apiKey string, params url.Values, req *model.NewsParams) (*model.NewsAPIResponse, int, error) {
+	firstPage, maxPages := 1, s.maxPages
+	if req.Page > 0 {
+		firstPage, maxPages = req.Page, 1
 	}
 
-	if newsResponse.Status != "ok" {
-		return nil, fmt.Errorf("API returened error status: %s", newsResponse.Status)
+	var combined *model.NewsAPIResponse
+	pages := 0
+	for page := firstPage; pages < maxPages; page++ {
+		if pages > 0 {
+			var err error
+			if apiKey, err = s.requestAPIKey(ctx); err != nil {
+				return combined, pages, err
+			}
+		}
+
+		params.Set("apiKey", apiKey)
+		if req.Page > 0 || page > 1 {
+			params.Set("page", strconv.Itoa(page))
+		}
+
+		var response model.NewsAPIResponse
+		limit, err := s.client.Get(ctx, path, params, &response)
+		if err != nil {
+			if combined != nil && newsapi.IsMaximumResultsReached(err) {
+				break
+			}
+			return combined, pages, err
+		}
+		pages++
+
+		if limit.Known {
+			s.logger.Debug("NewsAPI rate limit", "limit", limit.Limit, "remaining", limit.Remaining)
+		}
+
+		if combined == nil {
+			combined = &model.NewsAPIResponse{Status: response.Status, TotalResults: response.TotalResults}
+		}
+		combined.Articles = append(combined.Articles, response.Articles...)
+
+		if len(response.Articles) == 0 || len(combined.Articles) >= response.TotalResults {
+			break
+		}
+		if req.SeenUntil != nil && reachesSeen(response.Articles, *req.SeenUntil) {
+			break
+		}
+
+		if pages < maxPages && limit.Exhausted() {
+			return combined, pages, &newsapi.ErrRateLimited{RetryAfter: limit.RetryAfter()}
+		}
+	}
+
+	return combined, pages, nil
+}
+
+// reachesSeen reports whether any article was published at or before
+// seenUntil, meaning later pages hold only articles already fetched
+func reachesSeen(articles []model.NewsAPIArticleParams, seenUntil time.Time) bool {
+	for _, article := range articles {
+		t, err := time.Parse(time.RFC3339, article.PublishedAt)
+		if err == nil && !t.After(seenUntil) {
+			return true
+		}
 	}
 
-	return &newsResponse, nil
+	return false
 }
 
-// handleAPIError handles different NewsAPI error responses
-func (s *newsService) handleAPIError(statusCode int, body []byte) error {
-	switch statusCode {
-	case http.StatusUnauthorized:
-		return fmt.Errorf("invalid API key")
-	case http.StatusTooManyRequests:
-		return fmt.Errorf("rate limit exceeded")
-	case http.StatusBadRequest:
-		var errorResp struct {
-			Status  string `json:"status"`
-			Code    string `json:"code"`
-			Message string `json:"message"`
+// requestAPIKey returns the NewsAPI key for a request, preferring the key of
+// the tenant ctx is scoped to and enforcing its daily quota
+func (s *newsService) requestAPIKey(ctx context.Context) (string, error) {
+	if s.tenants != nil {
+		apiKey, err := s.tenants.ReserveNewsAPIRequest(ctx)
+		if err != nil {
+			return "", err
 		}
-		if err := json.Unmarshal(body, &errorResp); err == nil {
-			return fmt.Errorf("API error: %s - %s", errorResp.Code, errorResp.Message)
+		if apiKey != "" {
+			return apiKey, nil
 		}
-		return fmt.Errorf("bad request")
-	case http.StatusInternalServerError:
-		return fmt.Errorf("NewsAPI server error")
-	default:
-		return fmt.Errorf("unexpected HTTP status: %d", statusCode)
 	}
+
+	return *s.apiKey.Load(), nil
 }
 
 // GetDefaultSources returns a list of popular news sources
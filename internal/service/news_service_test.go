@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -41,7 +44,7 @@ func (suite *NewsServiceTestSuite) SetupTest() {
 
 	suite.logger = logger.New(cfg)
 
-	suite.service = NewNewsService(cfg, suite.logger)
+	suite.service = NewNewsService(cfg, nil, suite.logger)
 }
 
 func (suite *NewsServiceTestSuite) TearDownTest() {
@@ -240,7 +243,7 @@ func (suite *NewsServiceTestSuite) TestGetTopHeadlinesInvalidAPIKey() {
 			BaseURL: suite.httpServer.URL,
 		},
 	}
-	invalidService := NewNewsService(cfg, suite.logger)
+	invalidService := NewNewsService(cfg, nil, suite.logger)
 
 	req := &model.NewsParams{
 		Query: "test",
@@ -332,7 +335,7 @@ func (suite *NewsServiceTestSuite) TestGetNewsByCategorySuccess() {
 	category := "technology"
 	pageSize := 15
 
-	result, err := suite.service.GetNewsByCategory(suite.ctx, category, pageSize)
+	result, err := suite.service.GetNewsByCategory(suite.ctx, category, "us", pageSize)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
@@ -343,7 +346,7 @@ func (suite *NewsServiceTestSuite) TestGetNewsByCategoryEmptyCategory() {
 	category := ""
 	pageSize := 10
 
-	result, err := suite.service.GetNewsByCategory(suite.ctx, category, pageSize)
+	result, err := suite.service.GetNewsByCategory(suite.ctx, category, "us", pageSize)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
@@ -354,7 +357,7 @@ func (suite *NewsServiceTestSuite) TestGetNewsByCategoryZeroPageSize() {
 	category := "business"
 	pageSize := 0
 
-	result, err := suite.service.GetNewsByCategory(suite.ctx, category, pageSize)
+	result, err := suite.service.GetNewsByCategory(suite.ctx, category, "us", pageSize)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
@@ -412,7 +415,7 @@ func (suite *NewsServiceTestSuite) TestHTTPErrors_RateLimit() {
 			BaseURL: suite.httpServer.URL + "/error/rate-limit",
 		},
 	}
-	errorService := NewNewsService(cfg, suite.logger)
+	errorService := NewNewsService(cfg, nil, suite.logger)
 
 	req := &model.NewsParams{Query: "test"}
 
@@ -430,7 +433,7 @@ func (suite *NewsServiceTestSuite) TestHTTPErrors_ServerError() {
 			BaseURL: suite.httpServer.URL + "/error/server",
 		},
 	}
-	errorService := NewNewsService(cfg, suite.logger)
+	errorService := NewNewsService(cfg, nil, suite.logger)
 
 	req := &model.NewsParams{Query: "test"}
 
@@ -448,7 +451,7 @@ func (suite *NewsServiceTestSuite) TestHTTPErrors_BadRequest() {
 			BaseURL: suite.httpServer.URL + "/error/bad-request",
 		},
 	}
-	errorService := NewNewsService(cfg, suite.logger)
+	errorService := NewNewsService(cfg, nil, suite.logger)
 
 	req := &model.NewsParams{Query: "test"}
 
@@ -466,7 +469,7 @@ func (suite *NewsServiceTestSuite) TestHTTPErrors_InvalidJSON() {
 			BaseURL: suite.httpServer.URL + "/error/invalid-json",
 		},
 	}
-	errorService := NewNewsService(cfg, suite.logger)
+	errorService := NewNewsService(cfg, nil, suite.logger)
 
 	req := &model.NewsParams{Query: "test"}
 
@@ -484,14 +487,14 @@ func (suite *NewsServiceTestSuite) TestHTTPErrors_APIErrorStatus() {
 			BaseURL: suite.httpServer.URL + "/error/api-error-status",
 		},
 	}
-	errorService := NewNewsService(cfg, suite.logger)
+	errorService := NewNewsService(cfg, nil, suite.logger)
 
 	req := &model.NewsParams{Query: "test"}
 
 	result, err := errorService.GetTopHeadlines(suite.ctx, req)
 
 	assert.Error(suite.T(), err)
-	assert.Contains(suite.T(), err.Error(), "API returened error status")
+	assert.Contains(suite.T(), err.Error(), "API returned error status")
 	assert.Nil(suite.T(), result)
 }
 
@@ -576,6 +579,199 @@ func (suite *NewsServiceTestSuite) TestEdgeCases_NegativePage() {
 	assert.Equal(suite.T(), "ok", result.Status)
 }
 
+// pagedNewsService returns a news service backed by a server holding total
+// articles served perPage at a time, one hour apart starting from newest. A
+// request for failPage answers with failCode; requested pages are recorded.
+func (suite *NewsServiceTestSuite) pagedNewsService(total, perPage, maxPages, failPage int, failCode string, newest time.Time) (NewsService, *[]string) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requested = append(requested, page)
+		if page == "" {
+			page = "1"
+		}
+
+		n, _ := strconv.Atoi(page)
+		if n == failPage {
+			w.WriteHeader(http.StatusBadRequest)
+			suite.writeErrorResponse(w, http.StatusBadRequest, failCode, "page unavailable")
+			return
+		}
+
+		response := &model.NewsAPIResponse{Status: "ok", TotalResults: total}
+		for i := (n - 1) * perPage; i < min(n*perPage, total); i++ {
+			response.Articles = append(response.Articles, model.NewsAPIArticleParams{
+				Title:       fmt.Sprintf("Article %d", i),
+				URL:         fmt.Sprintf("https://example.com/%d", i),
+				PublishedAt: newest.Add(-time.Duration(i) * time.Hour).Format(time.RFC3339),
+			})
+		}
+		suite.writeNewsAPIResponse(w, response)
+	}))
+	suite.T().Cleanup(server.Close)
+
+	cfg := &config.Config{NewsAPI: config.NewsAPIConfig{APIKey: "test-api-key", BaseURL: server.URL, MaxPages: maxPages}}
+
+	return NewNewsService(cfg, nil, suite.logger), &requested
+}
+
+func (suite *NewsServiceTestSuite) TestGetEverythingFetchesAllPages() {
+	service, requested := suite.pagedNewsService(5, 2, 5, 0, "", time.Now())
+
+	result, err := service.GetEverything(suite.ctx, &model.NewsParams{Query: "ai", PageSize: 2})
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Articles, 5)
+	assert.Equal(suite.T(), 5, result.TotalResults)
+	assert.Equal(suite.T(), []string{"", "2", "3"}, *requested)
+}
+
+func (suite *NewsServiceTestSuite) TestGetTopHeadlinesStopsAtMaxPages() {
+	service, requested := suite.pagedNewsService(10, 2, 2, 0, "", time.Now())
+
+	result, err := service.GetTopHeadlines(suite.ctx, &model.NewsParams{Category: "technology", PageSize: 2})
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Articles, 4)
+	assert.Len(suite.T(), *requested, 2)
+}
+
+func (suite *NewsServiceTestSuite) TestGetEverythingStopsAtSeenArticles() {
+	newest := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+	seenUntil := newest.Add(-150 * time.Minute)
+	service, requested := suite.pagedNewsService(10, 2, 5, 0, "", newest)
+
+	// Article 3 of page 2 was published before seenUntil
+	result, err := service.GetEverything(suite.ctx, &model.NewsParams{Query: "ai", PageSize: 2, SeenUntil: &seenUntil})
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Articles, 4)
+	assert.Equal(suite.T(), []string{"", "2"}, *requested)
+}
+
+func (suite *NewsServiceTestSuite) TestGetEverythingSpecificPage() {
+	service, requested := suite.pagedNewsService(10, 2, 5, 0, "", time.Now())
+
+	result, err := service.GetEverything(suite.ctx, &model.NewsParams{Query: "ai", PageSize: 2, Page: 3})
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Articles, 2)
+	assert.Equal(suite.T(), "Article 4", result.Articles[0].Title)
+	assert.Equal(suite.T(), []string{"3"}, *requested)
+}
+
+func (suite *NewsServiceTestSuite) TestGetEverythingMaximumResultsReached() {
+	service, _ := suite.pagedNewsService(10, 2, 5, 3, "maximumResultsReached", time.Now())
+
+	result, err := service.GetEverything(suite.ctx, &model.NewsParams{Query: "ai", PageSize: 2})
+
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Articles, 4)
+}
+
+func (suite *NewsServiceTestSuite) TestGetEverythingLaterPageFails() {
+	service, _ := suite.pagedNewsService(10, 2, 5, 2, "rateLimited", time.Now())
+
+	result, err := service.GetEverything(suite.ctx, &model.NewsParams{Query: "ai", PageSize: 2})
+
+	assert.Error(suite.T(), err)
+	require.NotNil(suite.T(), result)
+	assert.Len(suite.T(), result.Articles, 2)
+}
+
+// sourcesNewsService returns a news service backed by a server listing one
+// source per request, or a server error while *failing is set. The query
+// of each request is recorded.
+func (suite *NewsServiceTestSuite) sourcesNewsService(failing *bool) (NewsService, *[]url.Values) {
+	var requested []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Query())
+		if r.URL.Path != "/top-headlines/sources" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if failing != nil && *failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			suite.writeErrorResponse(w, http.StatusInternalServerError, "unexpectedError", "failure")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&model.NewsAPISourcesResponse{
+			Status: "ok",
+			Sources: []model.NewsAPISource{{
+				ID:       "techcrunch",
+				Name:     "TechCrunch",
+				Category: r.URL.Query().Get("category"),
+				Language: "en",
+				Country:  "us",
+			}},
+		})
+	}))
+	suite.T().Cleanup(server.Close)
+
+	cfg := &config.Config{NewsAPI: config.NewsAPIConfig{APIKey: "test-api-key", BaseURL: server.URL, SourcesCacheTTL: time.Hour}}
+
+	return NewNewsService(cfg, nil, suite.logger), &requested
+}
+
+func (suite *NewsServiceTestSuite) TestGetSourcesPassesFilters() {
+	service, requested := suite.sourcesNewsService(nil)
+
+	result, err := service.GetSources(suite.ctx, &model.NewsSourcesParams{Category: "technology", Language: "en", Country: "us"})
+
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), result.Sources, 1)
+	assert.Equal(suite.T(), "techcrunch", result.Sources[0].ID)
+	assert.Equal(suite.T(), 1, result.Count)
+	assert.False(suite.T(), result.Cached)
+	assert.Equal(suite.T(), "technology", result.Filters.Category)
+
+	require.Len(suite.T(), *requested, 1)
+	query := (*requested)[0]
+	assert.Equal(suite.T(), "technology", query.Get("category"))
+	assert.Equal(suite.T(), "en", query.Get("language"))
+	assert.Equal(suite.T(), "us", query.Get("country"))
+	assert.Equal(suite.T(), "test-api-key", query.Get("apiKey"))
+}
+
+func (suite *NewsServiceTestSuite) TestGetSourcesCachesListing() {
+	service, requested := suite.sourcesNewsService(nil)
+	req := &model.NewsSourcesParams{Category: "technology"}
+
+	first, err := service.GetSources(suite.ctx, req)
+	require.NoError(suite.T(), err)
+
+	second, err := service.GetSources(suite.ctx, req)
+	require.NoError(suite.T(), err)
+
+	assert.False(suite.T(), first.Cached)
+	assert.True(suite.T(), second.Cached)
+	assert.Equal(suite.T(), first.Sources, second.Sources)
+	assert.Equal(suite.T(), first.FetchedAt, second.FetchedAt)
+	assert.Len(suite.T(), *requested, 1)
+
+	// Other filters are a separate listing
+	_, err = service.GetSources(suite.ctx, &model.NewsSourcesParams{Category: "sports"})
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), *requested, 2)
+}
+
+func (suite *NewsServiceTestSuite) TestGetSourcesDoesNotCacheFailures() {
+	failing := true
+	service, requested := suite.sourcesNewsService(&failing)
+	req := &model.NewsSourcesParams{}
+
+	_, err := service.GetSources(suite.ctx, req)
+	assert.Error(suite.T(), err)
+
+	failing = false
+	result, err := service.GetSources(suite.ctx, req)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), result.Cached)
+	assert.Len(suite.T(), *requested, 2)
+}
+
 // Run the test suite
 func TestNewsServiceSuite(t *testing.T) {
 	suite.Run(t, new(NewsServiceTestSuite))
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// partitionService implements PartitionService interface
+type partitionService struct {
+	repo   repository.PartitionRepository
+	cfg    config.PartitionConfig
+	logger *logger.Logger
+}
+
+// NewPartitionService creates a new partition maintenance service
+func NewPartitionService(repo repository.PartitionRepository, cfg config.PartitionConfig, logger *logger.Logger) PartitionService {
+	return &partitionService{
+		repo:   repo,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// MaintainPostPartitions creates the posts partitions of the coming months
+// before any post needs them, so new posts never pile up in the default
+// partition. Partitions span every tenant, so it runs once per pass.
+func (s *partitionService) MaintainPostPartitions(ctx context.Context) (int, error) {
+	start := time.Now()
+
+	created, err := s.repo.EnsurePostPartitions(ctx, s.cfg.MonthsAhead)
+	if err != nil {
+		s.logger.LogServiceOperation("partition", "maintain_posts", false, time.Since(start).Milliseconds())
+		return 0, err
+	}
+
+	s.logger.LogServiceOperation("partition", "maintain_posts", true, time.Since(start).Milliseconds())
+
+	return created, nil
+}
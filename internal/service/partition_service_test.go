@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockPartitionRepository is a mock implementation of PartitionRepository
+type MockPartitionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPartitionRepository) EnsurePostPartitions(ctx context.Context, monthsAhead int) (int, error) {
+	args := m.Called(ctx, monthsAhead)
+	return args.Int(0), args.Error(1)
+}
+
+// PartitionServiceTestSuite defines the test suite for PartitionService
+type PartitionServiceTestSuite struct {
+	suite.Suite
+	mockRepo *MockPartitionRepository
+	service  PartitionService
+	ctx      context.Context
+}
+
+func (suite *PartitionServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockPartitionRepository)
+	suite.service = NewPartitionService(suite.mockRepo, config.PartitionConfig{
+		MaintenanceEnabled: true,
+		MonthsAhead:        3,
+	}, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *PartitionServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *PartitionServiceTestSuite) TestMaintainPostPartitions() {
+	suite.mockRepo.On("EnsurePostPartitions", mock.Anything, 3).Return(2, nil).Once()
+
+	created, err := suite.service.MaintainPostPartitions(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, created)
+}
+
+func (suite *PartitionServiceTestSuite) TestMaintainPostPartitionsError() {
+	suite.mockRepo.On("EnsurePostPartitions", mock.Anything, 3).Return(0, errors.New("database error")).Once()
+
+	_, err := suite.service.MaintainPostPartitions(suite.ctx)
+
+	assert.Error(suite.T(), err)
+}
+
+func TestPartitionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PartitionServiceTestSuite))
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrPinPositionInvalid = errors.New("pin position is out of range")
+	ErrPinExpiryInvalid   = errors.New("pin expiry must be in the future")
+	ErrPinPostUnpublished = errors.New("only published posts can be pinned")
+	ErrPinNotFound        = errors.New("post is not pinned")
+)
+
+// pinService implements PinService interface
+type pinService struct {
+	repo   repository.PinRepository
+	posts  repository.PostRepository
+	cfg    config.PinConfig
+	logger *logger.Logger
+}
+
+// NewPinService creates a new pin service. Posts are pinned to positions 1
+// to cfg.MaxPosts; pinning to a taken position unpins the post there.
+func NewPinService(repo repository.PinRepository, posts repository.PostRepository, cfg config.PinConfig, logger *logger.Logger) PinService {
+	return &pinService{
+		repo:   repo,
+		posts:  posts,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// PinPost pins a published post to req.Position until req.ExpiresAt, or for
+// good when no expiry is given. Pinning a pinned post moves its pin.
+func (s *pinService) PinPost(ctx context.Context, id int64, req *model.PinPostParams) (*model.PostPin, error) {
+	start := time.Now()
+
+	if id <= 0 {
+		s.logger.LogServiceOperation("pin", "pin", false, time.Since(start).Milliseconds())
+		return nil, ErrPostIDInvalid
+	}
+
+	if req.Position < 1 || req.Position > s.cfg.MaxPosts {
+		s.logger.LogServiceOperation("pin", "pin", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("%w: positions run from 1 to %d", ErrPinPositionInvalid, s.cfg.MaxPosts)
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		s.logger.LogServiceOperation("pin", "pin", false, time.Since(start).Milliseconds())
+		return nil, ErrPinExpiryInvalid
+	}
+
+	post, err := s.posts.GetPostByID(ctx, id)
+	if err != nil {
+		s.logger.LogServiceOperation("pin", "pin", false, time.Since(start).Milliseconds())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	if !post.IsPublished() {
+		s.logger.LogServiceOperation("pin", "pin", false, time.Since(start).Milliseconds())
+		return nil, ErrPinPostUnpublished
+	}
+
+	pin := &model.PostPin{PostID: id, Position: req.Position}
+	if req.ExpiresAt != nil {
+		expiresAt := req.ExpiresAt.UTC()
+		pin.ExpiresAt = &expiresAt
+	}
+
+	if err := s.repo.SavePin(ctx, pin); err != nil {
+		s.logger.LogServiceOperation("pin", "pin", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	s.logger.LogServiceOperation("pin", "pin", true, time.Since(start).Milliseconds())
+
+	return pin, nil
+}
+
+// UnpinPost removes a post's pin
+func (s *pinService) UnpinPost(ctx context.Context, id int64) error {
+	start := time.Now()
+
+	if id <= 0 {
+		s.logger.LogServiceOperation("pin", "unpin", false, time.Since(start).Milliseconds())
+		return ErrPostIDInvalid
+	}
+
+	deleted, err := s.repo.DeletePin(ctx, id)
+	if err != nil {
+		s.logger.LogServiceOperation("pin", "unpin", false, time.Since(start).Milliseconds())
+		return err
+	}
+
+	if !deleted {
+		s.logger.LogServiceOperation("pin", "unpin", false, time.Since(start).Milliseconds())
+		return ErrPinNotFound
+	}
+
+	s.logger.LogServiceOperation("pin", "unpin", true, time.Since(start).Milliseconds())
+
+	return nil
+}
+
+// PinnedPosts returns the posts under an active pin in position order,
+// marked as pinned. Posts that are no longer published, and sensitive posts
+// in safe mode, are left out.
+func (s *pinService) PinnedPosts(ctx context.Context, safeMode bool) ([]model.Post, error) {
+	start := time.Now()
+
+	pins, err := s.repo.ListActivePins(ctx, s.cfg.MaxPosts)
+	if err != nil {
+		s.logger.LogServiceOperation("pin", "list_pinned", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	posts := make([]model.Post, 0, len(pins))
+	for _, pin := range pins {
+		post, err := s.posts.GetPostByID(ctx, pin.PostID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			s.logger.LogServiceOperation("pin", "list_pinned", false, time.Since(start).Milliseconds())
+			return nil, fmt.Errorf("failed to get pinned post: %w", err)
+		}
+
+		if !post.IsPublished() || (safeMode && post.Sensitive) {
+			continue
+		}
+
+		post.Pinned = true
+		posts = append(posts, *post)
+	}
+
+	s.logger.LogServiceOperation("pin", "list_pinned", true, time.Since(start).Milliseconds())
+
+	return posts, nil
+}
+
+// withPinned puts the pinned posts ahead of posts, dropping their regular
+// place in posts
+func withPinned(pinned, posts []model.Post) []model.Post {
+	if len(pinned) == 0 {
+		return posts
+	}
+
+	ids := make(map[int64]bool, len(pinned))
+	for _, post := range pinned {
+		ids[post.ID] = true
+	}
+
+	merged := make([]model.Post, 0, len(pinned)+len(posts))
+	merged = append(merged, pinned...)
+	for _, post := range posts {
+		if !ids[post.ID] {
+			merged = append(merged, post)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockPinRepository is a mock implementation of PinRepository
+type MockPinRepository struct {
+	mock.Mock
+}
+
+func (m *MockPinRepository) SavePin(ctx context.Context, pin *model.PostPin) error {
+	args := m.Called(ctx, pin)
+	return args.Error(0)
+}
+
+func (m *MockPinRepository) DeletePin(ctx context.Context, postID int64) (bool, error) {
+	args := m.Called(ctx, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPinRepository) ListActivePins(ctx context.Context, maxPosition int) ([]model.PostPin, error) {
+	args := m.Called(ctx, maxPosition)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PostPin), args.Error(1)
+}
+
+// fixedPins is a PinService whose pinned posts are fixed
+type fixedPins struct {
+	posts []model.Post
+	err   error
+}
+
+func (p fixedPins) PinPost(ctx context.Context, id int64, req *model.PinPostParams) (*model.PostPin, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p fixedPins) UnpinPost(ctx context.Context, id int64) error {
+	return errors.New("not implemented")
+}
+
+func (p fixedPins) PinnedPosts(ctx context.Context, safeMode bool) ([]model.Post, error) {
+	return p.posts, p.err
+}
+
+// PinServiceTestSuite defines the test suite for PinService
+type PinServiceTestSuite struct {
+	suite.Suite
+	mockRepo  *MockPinRepository
+	mockPosts *MockPostRepository
+	service   PinService
+	ctx       context.Context
+}
+
+func (suite *PinServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockPinRepository)
+	suite.mockPosts = new(MockPostRepository)
+	suite.service = NewPinService(suite.mockRepo, suite.mockPosts, config.PinConfig{MaxPosts: 3}, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *PinServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockPosts.AssertExpectations(suite.T())
+}
+
+func (suite *PinServiceTestSuite) TestPinPostSuccess() {
+	expiresAt := time.Now().Add(time.Hour)
+
+	suite.mockPosts.On("GetPostByID", suite.ctx, int64(42)).Return(&model.Post{ID: 42, Status: model.PostStatusPublished}, nil)
+	suite.mockRepo.On("SavePin", suite.ctx, mock.MatchedBy(func(pin *model.PostPin) bool {
+		return pin.PostID == 42 && pin.Position == 2 && pin.ExpiresAt.Equal(expiresAt)
+	})).Return(nil)
+
+	pin, err := suite.service.PinPost(suite.ctx, 42, &model.PinPostParams{Position: 2, ExpiresAt: &expiresAt})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(42), pin.PostID)
+	assert.Equal(suite.T(), 2, pin.Position)
+}
+
+func (suite *PinServiceTestSuite) TestPinPostPositionOutOfRange() {
+	_, err := suite.service.PinPost(suite.ctx, 42, &model.PinPostParams{Position: 4})
+
+	assert.ErrorIs(suite.T(), err, ErrPinPositionInvalid)
+	assert.Contains(suite.T(), err.Error(), "1 to 3")
+}
+
+func (suite *PinServiceTestSuite) TestPinPostExpiryInPast() {
+	expiresAt := time.Now().Add(-time.Minute)
+
+	_, err := suite.service.PinPost(suite.ctx, 42, &model.PinPostParams{Position: 1, ExpiresAt: &expiresAt})
+
+	assert.ErrorIs(suite.T(), err, ErrPinExpiryInvalid)
+}
+
+func (suite *PinServiceTestSuite) TestPinPostNotFound() {
+	suite.mockPosts.On("GetPostByID", suite.ctx, int64(42)).Return(nil, fmt.Errorf("failed to get post by id: %w", pgx.ErrNoRows))
+
+	_, err := suite.service.PinPost(suite.ctx, 42, &model.PinPostParams{Position: 1})
+
+	assert.ErrorIs(suite.T(), err, ErrPostNotFound)
+}
+
+func (suite *PinServiceTestSuite) TestPinPostUnpublished() {
+	suite.mockPosts.On("GetPostByID", suite.ctx, int64(42)).Return(&model.Post{ID: 42, Status: model.PostStatusDraft}, nil)
+
+	_, err := suite.service.PinPost(suite.ctx, 42, &model.PinPostParams{Position: 1})
+
+	assert.ErrorIs(suite.T(), err, ErrPinPostUnpublished)
+}
+
+func (suite *PinServiceTestSuite) TestUnpinPost() {
+	suite.mockRepo.On("DeletePin", suite.ctx, int64(42)).Return(true, nil).Once()
+	suite.mockRepo.On("DeletePin", suite.ctx, int64(42)).Return(false, nil).Once()
+
+	assert.NoError(suite.T(), suite.service.UnpinPost(suite.ctx, 42))
+	assert.ErrorIs(suite.T(), suite.service.UnpinPost(suite.ctx, 42), ErrPinNotFound)
+}
+
+func (suite *PinServiceTestSuite) TestPinnedPostsSkipsUnavailablePosts() {
+	pins := []model.PostPin{{PostID: 1, Position: 1}, {PostID: 2, Position: 2}, {PostID: 3, Position: 3}, {PostID: 4, Position: 3}}
+
+	suite.mockRepo.On("ListActivePins", suite.ctx, 3).Return(pins, nil)
+	suite.mockPosts.On("GetPostByID", suite.ctx, int64(1)).Return(&model.Post{ID: 1, Status: model.PostStatusPublished}, nil)
+	suite.mockPosts.On("GetPostByID", suite.ctx, int64(2)).Return(nil, fmt.Errorf("failed to get post by id: %w", pgx.ErrNoRows))
+	suite.mockPosts.On("GetPostByID", suite.ctx, int64(3)).Return(&model.Post{ID: 3, Status: model.PostStatusHidden}, nil)
+	suite.mockPosts.On("GetPostByID", suite.ctx, int64(4)).Return(&model.Post{ID: 4, Status: model.PostStatusPublished, Sensitive: true}, nil)
+
+	posts, err := suite.service.PinnedPosts(suite.ctx, true)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []model.Post{{ID: 1, Status: model.PostStatusPublished, Pinned: true}}, posts)
+}
+
+func TestPinServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PinServiceTestSuite))
+}
@@ -4,53 +4,126 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/model"
 	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/botdetect"
+	"github.com/amirzre/news-feed-system/pkg/geo"
+	"github.com/amirzre/news-feed-system/pkg/htmlsafe"
 	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/textnorm"
+	"github.com/amirzre/news-feed-system/pkg/urlnorm"
 	"github.com/jackc/pgx/v5"
 )
 
 // postService implements PostService interface
 type postService struct {
-	repo   repository.PostRepository
-	logger *logger.Logger
+	repo           repository.PostRepository
+	reactions      repository.ReactionRepository
+	tx             repository.UnitOfWork
+	classifier     SensitivityClassifier
+	sources        SourceRuleService
+	images         ImageSanitizer
+	pins           PinService
+	upsertArticles bool
+	search         config.SearchConfig
+	logger         *logger.Logger
 }
 
-// NewPostService creates a new post service
-func NewPostService(repo repository.PostRepository, logger *logger.Logger) PostService {
+// NewPostService creates a new post service. When upsertArticles is set,
+// NewsAPI articles whose URL is already stored refresh the existing post
+// instead of being skipped. Every created or updated post is run through the
+// classifier to set its sensitive flag after unsafe HTML is stripped from its
+// description and content, and posts the source rules block are refused.
+// NewsAPI articles have their title, description and author normalized and
+// their image URL cleaned by images; those fetched for no country are tagged
+// with the country their title names. Posts returned by reads carry their
+// reaction counts. Highlighted search results use the delimiters in search.
+// Unfiltered lists of published posts start with the posts pinned through
+// pins, when it is set.
+func NewPostService(repo repository.PostRepository, reactions repository.ReactionRepository, tx repository.UnitOfWork, classifier SensitivityClassifier, sources SourceRuleService, images ImageSanitizer, pins PinService, upsertArticles bool, search config.SearchConfig, logger *logger.Logger) PostService {
 	return &postService{
-		repo:   repo,
-		logger: logger,
+		repo:           repo,
+		reactions:      reactions,
+		tx:             tx,
+		classifier:     classifier,
+		sources:        sources,
+		images:         images,
+		pins:           pins,
+		upsertArticles: upsertArticles,
+		search:         search,
+		logger:         logger,
 	}
 }
 
 var (
-	ErrPostExists    = errors.New("post with this URL already exists")
-	ErrPostIDInvalid = errors.New("post ID is invalid")
-	ErrPostNotFound  = errors.New("post not found")
+	ErrPostExists     = errors.New("post with this URL already exists")
+	ErrPostIDInvalid  = errors.New("post ID is invalid")
+	ErrPostNotFound   = errors.New("post not found")
+	ErrPostURLInvalid = errors.New("post URL must be an absolute http or https URL")
+
+	ErrPostVersionRequired = errors.New("post version is required")
+	ErrPostVersionConflict = errors.New("post was modified by another request")
+
+	ErrBulkFilterEmpty  = errors.New("bulk operation needs a filter or post IDs")
+	ErrBulkNoChanges    = errors.New("bulk update sets no fields")
+	ErrBulkInvalidRange = errors.New("bulk range must start before it ends")
+)
+
+// bulkBatchSize is the number of posts a bulk operation changes per statement,
+// so that no single statement holds locks on many posts
+const bulkBatchSize = 500
+
+// Discovery picks from a sample of discoverPoolSize posts published within
+// the last discoverWindow
+const (
+	discoverWindow   = 7 * 24 * time.Hour
+	discoverPoolSize = 200
 )
 
-// CreatePost creates a new post
+// CreatePost creates a new post, published unless another status is requested
 func (s *postService) CreatePost(ctx context.Context, req *model.CreatePostParams) (*model.Post, error) {
 	start := time.Now()
 
-	exists, err := s.PostExists(ctx, req.URL)
-	if err != nil {
+	if err := s.checkSource(ctx, req); err != nil {
 		s.logger.LogServiceOperation("post", "create", false, time.Since(start).Milliseconds())
-		return nil, fmt.Errorf("failed to check post existence: %w", err)
+		return nil, err
 	}
 
-	if exists {
-		s.logger.LogServiceOperation("post", "create", false, time.Since(start).Milliseconds())
-		return nil, ErrPostExists
+	if req.Status == "" {
+		req.Status = model.PostStatusPublished
 	}
+	req.Description = htmlsafe.SanitizePtr(req.Description)
+	req.Content = htmlsafe.SanitizePtr(req.Content)
+	req.Sensitive = s.isSensitive(ctx, req.Title, req.Description, req.Content)
+
+	var post *model.Post
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		exists, err := s.PostExists(ctx, req.URL)
+		if err != nil {
+			return fmt.Errorf("failed to check post existence: %w", err)
+		}
 
-	post, err := s.repo.CreatePost(ctx, req)
+		if exists {
+			return ErrPostExists
+		}
+
+		post, err = s.repo.CreatePost(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create post service: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
 		s.logger.LogServiceOperation("post", "create", false, time.Since(start).Milliseconds())
-		return nil, fmt.Errorf("failed to create post service: %w", err)
+		return nil, err
 	}
 
 	s.logger.LogServiceOperation("post", "create", true, time.Since(start).Milliseconds())
@@ -58,8 +131,9 @@ func (s *postService) CreatePost(ctx context.Context, req *model.CreatePostParam
 	return post, nil
 }
 
-// GetPostByID retrieves a post by ID
-func (s *postService) GetPostByID(ctx context.Context, id int64) (*model.Post, error) {
+// GetPostByID retrieves a published post by ID and records a view of it by
+// viewer, an opaque identity of the reader that may be empty
+func (s *postService) GetPostByID(ctx context.Context, id int64, viewer string) (*model.Post, error) {
 	start := time.Now()
 
 	if id <= 0 {
@@ -78,12 +152,68 @@ func (s *postService) GetPostByID(ctx context.Context, id int64) (*model.Post, e
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
 
+	// Drafts and hidden posts are only reachable through the admin list
+	if !post.IsPublished() {
+		s.logger.LogServiceOperation("post", "get_by_id", false, time.Since(start).Milliseconds())
+		return nil, ErrPostNotFound
+	}
+
+	// Views by bots would skew trending, and none are written in read-only mode
+	if !botdetect.FromContext(ctx) && !ReadOnlyFromContext(ctx) {
+		if err := s.repo.IncrementPostViews(ctx, id, viewer); err != nil {
+			s.logger.Warn("Failed to record post view", "id", id, "error", err.Error())
+		}
+	}
+
+	s.attachReactions(ctx, []*model.Post{post})
+
 	s.logger.LogServiceOperation("post", "get_by_id", true, time.Since(start).Milliseconds())
 
 	return post, nil
 }
 
-// ListPosts retrieves posts with pagination and filtering
+// GetPostByURL retrieves the published post stored under rawURL, which is
+// compared in its normalized form. Unlike GetPostByID no view is recorded, as
+// a lookup only checks whether an article is known.
+func (s *postService) GetPostByURL(ctx context.Context, rawURL string) (*model.Post, error) {
+	start := time.Now()
+
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		s.logger.LogServiceOperation("post", "get_by_url", false, time.Since(start).Milliseconds())
+		return nil, ErrPostURLInvalid
+	}
+
+	post, err := s.repo.GetPostByURL(ctx, rawURL)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.LogServiceOperation("post", "get_by_url", false, time.Since(start).Milliseconds())
+			return nil, ErrPostNotFound
+		}
+
+		s.logger.LogServiceOperation("post", "get_by_url", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to get post by url: %w", err)
+	}
+
+	if !post.IsPublished() {
+		s.logger.LogServiceOperation("post", "get_by_url", false, time.Since(start).Milliseconds())
+		return nil, ErrPostNotFound
+	}
+
+	s.attachReactions(ctx, []*model.Post{post})
+
+	s.logger.LogServiceOperation("post", "get_by_url", true, time.Since(start).Milliseconds())
+
+	return post, nil
+}
+
+// ListPosts retrieves posts with pagination and filtering. Only published
+// posts are listed unless req.Status asks for another state. Searches with
+// req.Facets set also get facet counts over every matching post, and with
+// req.Highlight set each post carries its search matches highlighted. With
+// req.Collapse set only the first post of each topic is listed. The first
+// page of published posts that no filter or search narrows starts with the
+// pinned posts, whatever the sort order.
 func (s *postService) ListPosts(ctx context.Context, req *model.PostListParams) (*model.PostListResponse, error) {
 	start := time.Now()
 
@@ -97,29 +227,84 @@ func (s *postService) ListPosts(ctx context.Context, req *model.PostListParams)
 		req.Limit = 100
 	}
 
+	// Without an exact count, one post past the page tells whether there is
+	// a next page
+	req.Peek = req.Count == model.PostCountNone || req.Count == model.PostCountEstimate
+
 	posts, err := s.repo.ListPosts(ctx, req)
 	if err != nil {
 		s.logger.LogServiceOperation("post", "list", false, time.Since(start).Milliseconds())
 		return nil, fmt.Errorf("failed to list posts: %w", err)
 	}
 
-	var total int64
-	if req.Category != nil && *req.Category != "" {
-		total, err = s.repo.CountPostsByCategory(ctx, *req.Category)
-	} else {
-		total, err = s.repo.CountPosts(ctx)
+	var pagination model.PaginationMeta
+	switch req.Count {
+	case model.PostCountNone, model.PostCountEstimate:
+		hasNext := len(posts) > req.Limit
+		if hasNext {
+			posts = posts[:req.Limit]
+		}
+
+		var estimate int64
+		if req.Count == model.PostCountEstimate {
+			estimate, err = s.repo.EstimatePosts(ctx, req)
+			if err != nil {
+				s.logger.LogServiceOperation("post", "list", false, time.Since(start).Milliseconds())
+				return nil, fmt.Errorf("failed to estimate posts: %w", err)
+			}
+		}
+
+		pagination = model.PeekPagination(req.Page, req.Limit, len(posts), hasNext, estimate, req.Count)
+	default:
+		total, err := s.countPosts(ctx, req)
+		if err != nil {
+			s.logger.LogServiceOperation("post", "list", false, time.Since(start).Milliseconds())
+			return nil, fmt.Errorf("failed to count posts: %w", err)
+		}
+
+		pagination = model.CalculatePagination(req.Page, req.Limit, total)
+	}
+
+	var facets *model.SearchFacets
+	if req.Facets && req.Search != nil && *req.Search != "" {
+		facets, err = s.repo.SearchFacets(ctx, &model.SearchPostsParams{
+			BasePostListParams: model.BasePostListParams{SafeMode: req.SafeMode, Status: req.Status},
+			Query:              *req.Search,
+			Country:            req.Country,
+		})
+		if err != nil {
+			s.logger.LogServiceOperation("post", "list", false, time.Since(start).Milliseconds())
+			return nil, fmt.Errorf("failed to count search facets: %w", err)
+		}
 	}
 
-	if err != nil {
-		s.logger.LogServiceOperation("post", "list", false, time.Since(start).Milliseconds())
-		return nil, fmt.Errorf("failed to count posts: %w", err)
+	var counts *model.PostCounts
+	if req.IncludeCounts {
+		counts, err = s.repo.PostCounts(ctx, req.Status)
+		if err != nil {
+			s.logger.LogServiceOperation("post", "list", false, time.Since(start).Milliseconds())
+			return nil, fmt.Errorf("failed to count posts per category and source: %w", err)
+		}
 	}
 
-	pagination := model.CalculatePagination(req.Page, req.Limit, total)
+	if s.showsPinned(req) {
+		posts = withPinned(s.pinnedPosts(ctx, req.SafeMode), posts)
+	}
+
+	refs := make([]*model.Post, len(posts))
+	for i := range posts {
+		refs[i] = &posts[i]
+	}
+	s.attachReactions(ctx, refs)
+	if req.Highlight && req.Search != nil && *req.Search != "" {
+		s.attachHighlights(ctx, refs, *req.Search)
+	}
 
 	response := &model.PostListResponse{
 		Posts:      posts,
 		Pagination: pagination,
+		Facets:     facets,
+		Counts:     counts,
 	}
 
 	s.logger.LogServiceOperation("post", "list", true, time.Since(start).Milliseconds())
@@ -127,6 +312,138 @@ func (s *postService) ListPosts(ctx context.Context, req *model.PostListParams)
 	return response, nil
 }
 
+// countPosts counts every post a list request matches. Like ListPosts, only
+// the first of search, category, source, country and author that is set
+// filters the count.
+func (s *postService) countPosts(ctx context.Context, req *model.PostListParams) (int64, error) {
+	switch {
+	case req.Collapse:
+		return s.repo.CountCollapsedPosts(ctx, req)
+	case req.SafeMode:
+		return s.repo.CountSafePosts(ctx, req)
+	case req.Search != nil && *req.Search != "":
+		return s.repo.CountSearchPosts(ctx, &model.SearchPostsParams{
+			BasePostListParams: model.BasePostListParams{Status: req.Status},
+			Query:              *req.Search,
+			Country:            req.Country,
+		})
+	case req.Category != nil && *req.Category != "":
+		return s.repo.CountPostsByCategory(ctx, *req.Category, req.Status)
+	case req.Source != nil && *req.Source != "":
+		return s.repo.CountPostsBySource(ctx, *req.Source, req.Status)
+	case req.Country != nil && *req.Country != "":
+		return s.repo.CountPostsByCountry(ctx, *req.Country, req.Status)
+	case req.Author != nil && *req.Author != "":
+		return s.repo.CountPostsByAuthor(ctx, *req.Author, req.Status)
+	default:
+		return s.repo.CountPosts(ctx, req.Status)
+	}
+}
+
+// showsPinned reports whether a post list page starts with the pinned posts:
+// only the first page of the unfiltered list of published posts does
+func (s *postService) showsPinned(req *model.PostListParams) bool {
+	if s.pins == nil || req.Page != 1 {
+		return false
+	}
+
+	if req.Status != "" && req.Status != model.PostStatusPublished {
+		return false
+	}
+
+	for _, filter := range []*string{req.Category, req.Source, req.Country, req.Author, req.Search} {
+		if filter != nil && *filter != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pinnedPosts returns the pinned posts, or none when they cannot be loaded so
+// that the list is still served
+func (s *postService) pinnedPosts(ctx context.Context, safeMode bool) []model.Post {
+	pinned, err := s.pins.PinnedPosts(ctx, safeMode)
+	if err != nil {
+		s.logger.Warn("Failed to load pinned posts, listing without them", "error", err.Error())
+		return nil
+	}
+
+	return pinned
+}
+
+// DiscoverPosts returns up to req.Limit recent published posts in random
+// order, alternating between categories, for explore views. Posts are
+// picked from a cached sample, so successive calls shuffle the same posts
+// until the sample is drawn again.
+func (s *postService) DiscoverPosts(ctx context.Context, req *model.DiscoverPostsParams) ([]model.Post, error) {
+	start := time.Now()
+
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 50 {
+		req.Limit = 50
+	}
+
+	pool, err := s.repo.SamplePosts(ctx, &model.SamplePostsParams{
+		Since:    time.Now().Add(-discoverWindow),
+		Size:     discoverPoolSize,
+		SafeMode: req.SafeMode,
+	})
+	if err != nil {
+		s.logger.LogServiceOperation("post", "discover", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to sample posts: %w", err)
+	}
+
+	posts := pickAcrossCategories(pool, req.Limit)
+
+	refs := make([]*model.Post, len(posts))
+	for i := range posts {
+		refs[i] = &posts[i]
+	}
+	s.attachReactions(ctx, refs)
+
+	s.logger.LogServiceOperation("post", "discover", true, time.Since(start).Milliseconds())
+
+	return posts, nil
+}
+
+// pickAcrossCategories picks up to n posts of pool at random, taking one
+// post of every category before a second of any
+func pickAcrossCategories(pool []model.Post, n int) []model.Post {
+	type pick struct {
+		post  model.Post
+		round int
+	}
+
+	picks := make([]pick, len(pool))
+	for i, j := range rand.Perm(len(pool)) {
+		picks[i].post = pool[j]
+	}
+
+	rounds := make(map[string]int)
+	for i := range picks {
+		category := ""
+		if picks[i].post.Category != nil {
+			category = *picks[i].post.Category
+		}
+		picks[i].round = rounds[category]
+		rounds[category]++
+	}
+
+	slices.SortStableFunc(picks, func(a, b pick) int {
+		return a.round - b.round
+	})
+
+	posts := make([]model.Post, 0, min(n, len(picks)))
+	for _, p := range picks[:min(n, len(picks))] {
+		posts = append(posts, p.post)
+	}
+
+	return posts
+}
+
 // UpdatePost updates an existing post
 func (s *postService) UpdatePost(ctx context.Context, id int64, req *model.UpdatePostParams) (*model.Post, error) {
 	start := time.Now()
@@ -136,6 +453,11 @@ func (s *postService) UpdatePost(ctx context.Context, id int64, req *model.Updat
 		return nil, ErrPostIDInvalid
 	}
 
+	if req.Version <= 0 {
+		s.logger.LogServiceOperation("post", "update", false, time.Since(start).Milliseconds())
+		return nil, ErrPostVersionRequired
+	}
+
 	_, err := s.repo.GetPostByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -147,9 +469,17 @@ func (s *postService) UpdatePost(ctx context.Context, id int64, req *model.Updat
 		return nil, fmt.Errorf("failed to check post existence: %w", err)
 	}
 
+	req.Description = htmlsafe.SanitizePtr(req.Description)
+	req.Content = htmlsafe.SanitizePtr(req.Content)
+	req.Sensitive = s.isSensitive(ctx, req.Title, req.Description, req.Content)
+
+	// The post exists, so an update matching no row means the version moved on
 	post, err := s.repo.UpdatePost(ctx, id, req)
 	if err != nil {
 		s.logger.LogServiceOperation("post", "update", false, time.Since(start).Milliseconds())
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPostVersionConflict
+		}
 		return nil, fmt.Errorf("failed to update post: %w", err)
 	}
 
@@ -189,31 +519,265 @@ func (s *postService) DeletePost(ctx context.Context, id int64) error {
 	return nil
 }
 
-// PostExists checks if a post with the given URL already exists
-func (s *postService) PostExists(ctx context.Context, url string) (bool, error) {
+// PublishPost makes a draft or hidden post visible on public endpoints
+func (s *postService) PublishPost(ctx context.Context, id int64) (*model.Post, error) {
+	return s.transition(ctx, id, model.PostStatusPublished, "publish")
+}
+
+// HidePost takes a post off public endpoints without deleting it
+func (s *postService) HidePost(ctx context.Context, id int64) (*model.Post, error) {
+	return s.transition(ctx, id, model.PostStatusHidden, "hide")
+}
+
+// transition moves a post to status. A post already in that state is
+// returned unchanged, so repeating a transition is harmless.
+func (s *postService) transition(ctx context.Context, id int64, status model.PostStatus, operation string) (*model.Post, error) {
 	start := time.Now()
 
-	_, err := s.repo.GetPostByURL(ctx, url)
+	if id <= 0 {
+		s.logger.LogServiceOperation("post", operation, false, time.Since(start).Milliseconds())
+		return nil, ErrPostIDInvalid
+	}
+
+	post, err := s.repo.GetPostByID(ctx, id)
 	if err != nil {
+		s.logger.LogServiceOperation("post", operation, false, time.Since(start).Milliseconds())
 		if errors.Is(err, pgx.ErrNoRows) {
-			s.logger.LogServiceOperation("post", "exists", false, time.Since(start).Milliseconds())
-			return false, nil
+			return nil, ErrPostNotFound
 		}
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	if post.Status != status {
+		post, err = s.repo.UpdatePostStatus(ctx, id, status)
+		if err != nil {
+			s.logger.LogServiceOperation("post", operation, false, time.Since(start).Milliseconds())
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrPostNotFound
+			}
+			return nil, fmt.Errorf("failed to update post status: %w", err)
+		}
+	}
+
+	s.logger.LogServiceOperation("post", operation, true, time.Since(start).Milliseconds())
+
+	return post, nil
+}
+
+// PostExists checks if a post with the given URL already exists.
+// Lookup failures are returned rather than reported as an existing post.
+func (s *postService) PostExists(ctx context.Context, url string) (bool, error) {
+	start := time.Now()
+
+	exists, err := s.repo.ExistsByURL(ctx, url)
+	if err != nil {
+		s.logger.LogServiceOperation("post", "exists", false, time.Since(start).Milliseconds())
+		return false, err
 	}
 
 	s.logger.LogServiceOperation("post", "exists", true, time.Since(start).Milliseconds())
 
-	return true, nil
+	return exists, nil
+}
+
+// NormalizePostURLs rewrites the stored post URLs predating URL normalization
+// into their normalized form, batchSize posts at a time. A post whose
+// normalized URL another post already holds is a duplicate and is deleted.
+func (s *postService) NormalizePostURLs(ctx context.Context, batchSize int) (*model.URLNormalizationResult, error) {
+	start := time.Now()
+	result := &model.URLNormalizationResult{}
+
+	var afterID int64
+	for {
+		urls, err := s.repo.ListPostURLs(ctx, afterID, batchSize)
+		if err != nil {
+			s.logger.LogServiceOperation("post", "normalize_urls", false, time.Since(start).Milliseconds())
+			return result, err
+		}
+
+		for _, u := range urls {
+			result.Scanned++
+			afterID = u.ID
+
+			normalized := urlnorm.Normalize(u.URL)
+			if normalized == u.URL {
+				continue
+			}
+
+			renamed, err := s.repo.RenamePostURL(ctx, u.ID, normalized)
+			if err != nil {
+				s.logger.LogServiceOperation("post", "normalize_urls", false, time.Since(start).Milliseconds())
+				return result, err
+			}
+			if renamed {
+				result.Normalized++
+				continue
+			}
+
+			if err := s.repo.DeletePost(ctx, u.ID); err != nil {
+				s.logger.LogServiceOperation("post", "normalize_urls", false, time.Since(start).Milliseconds())
+				return result, err
+			}
+			s.logger.Debug("Deleted duplicate post", "id", u.ID, "url", normalized)
+			result.Merged++
+		}
+
+		if len(urls) < batchSize {
+			break
+		}
+	}
+
+	s.logger.LogServiceOperation("post", "normalize_urls", true, time.Since(start).Milliseconds())
+
+	return result, nil
+}
+
+// BulkUpdatePosts applies the same changes to every post matching the
+// filter in batches, or only counts the posts that would change on a dry run
+func (s *postService) BulkUpdatePosts(ctx context.Context, req *model.BulkUpdatePostsParams) (*model.BulkPostsResult, error) {
+	start := time.Now()
+
+	if err := validateBulkFilter(&req.Filter); err != nil {
+		s.logger.LogServiceOperation("post", "bulk_update", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+	if req.Changes.IsEmpty() {
+		s.logger.LogServiceOperation("post", "bulk_update", false, time.Since(start).Milliseconds())
+		return nil, ErrBulkNoChanges
+	}
+
+	result := &model.BulkPostsResult{DryRun: req.DryRun}
+	if req.DryRun {
+		count, err := s.repo.CountBulkPosts(ctx, &req.Filter, &req.Changes)
+		if err != nil {
+			s.logger.LogServiceOperation("post", "bulk_update", false, time.Since(start).Milliseconds())
+			return nil, err
+		}
+		result.Affected = count
+
+		s.logger.LogServiceOperation("post", "bulk_update", true, time.Since(start).Milliseconds())
+		return result, nil
+	}
+
+	affected, err := bulkUpdateInBatches(ctx, s.repo, req.Filter, &req.Changes)
+	result.Affected = affected
+	if err != nil {
+		s.logger.LogServiceOperation("post", "bulk_update", false, time.Since(start).Milliseconds())
+		return result, err
+	}
+
+	s.logger.Info("Bulk updated posts", "affected", result.Affected)
+	s.logger.LogServiceOperation("post", "bulk_update", true, time.Since(start).Milliseconds())
+
+	return result, nil
+}
+
+// BulkDeletePosts deletes every post matching the filter in batches, or only
+// counts them on a dry run
+func (s *postService) BulkDeletePosts(ctx context.Context, req *model.BulkDeletePostsParams) (*model.BulkPostsResult, error) {
+	start := time.Now()
+
+	if err := validateBulkFilter(&req.Filter); err != nil {
+		s.logger.LogServiceOperation("post", "bulk_delete", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	result := &model.BulkPostsResult{DryRun: req.DryRun}
+	if req.DryRun {
+		count, err := s.repo.CountBulkPosts(ctx, &req.Filter, nil)
+		if err != nil {
+			s.logger.LogServiceOperation("post", "bulk_delete", false, time.Since(start).Milliseconds())
+			return nil, err
+		}
+		result.Affected = count
+
+		s.logger.LogServiceOperation("post", "bulk_delete", true, time.Since(start).Milliseconds())
+		return result, nil
+	}
+
+	batch := &model.BulkPostsBatchParams{BulkPostFilter: req.Filter, Limit: bulkBatchSize}
+	for {
+		lastID, deleted, err := s.repo.BulkDeletePosts(ctx, batch)
+		if err != nil {
+			s.logger.LogServiceOperation("post", "bulk_delete", false, time.Since(start).Milliseconds())
+			return result, err
+		}
+		result.Affected += int64(deleted)
+
+		if lastID == 0 {
+			break
+		}
+		batch.AfterID = lastID
+	}
+
+	s.logger.Info("Bulk deleted posts", "affected", result.Affected)
+	s.logger.LogServiceOperation("post", "bulk_delete", true, time.Since(start).Milliseconds())
+
+	return result, nil
+}
+
+// bulkUpdateInBatches applies changes to the posts matching filter a batch
+// at a time. It returns how many posts changed, counting the batches written
+// before a failed one.
+func bulkUpdateInBatches(ctx context.Context, repo repository.PostRepository, filter model.BulkPostFilter, changes *model.BulkPostChanges) (int64, error) {
+	var affected int64
+
+	batch := &model.BulkPostsBatchParams{BulkPostFilter: filter, Limit: bulkBatchSize}
+	for {
+		lastID, updated, err := repo.BulkUpdatePosts(ctx, batch, changes)
+		if err != nil {
+			return affected, err
+		}
+		affected += int64(updated)
+
+		if lastID == 0 {
+			return affected, nil
+		}
+		batch.AfterID = lastID
+	}
+}
+
+// validateBulkFilter rejects filters selecting every post, so that a missing
+// body cannot change the whole table, and reversed date ranges
+func validateBulkFilter(filter *model.BulkPostFilter) error {
+	if filter.IsEmpty() {
+		return ErrBulkFilterEmpty
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return ErrBulkInvalidRange
+	}
+
+	return nil
+}
+
+// RebuildURLFilter rebuilds the filter of stored URLs that answers most
+// existence checks, returning how many URLs it holds
+func (s *postService) RebuildURLFilter(ctx context.Context) (int, error) {
+	start := time.Now()
+
+	added, err := s.repo.RebuildURLFilter(ctx)
+	if err != nil {
+		s.logger.LogServiceOperation("post", "rebuild_url_filter", false, time.Since(start).Milliseconds())
+		return 0, err
+	}
+
+	s.logger.LogServiceOperation("post", "rebuild_url_filter", true, time.Since(start).Milliseconds())
+
+	return added, nil
 }
 
 // CreatePostFromNewsAPI creates a post from NewsAPI article with duplicate checking
 func (s *postService) CreatePostFromNewsAPI(ctx context.Context, article *model.NewsAPIArticleParams) (*model.Post, error) {
 	start := time.Now()
 
-	req, err := article.ToPost()
+	req, err := s.newsAPIPost(ctx, article)
 	if err != nil {
 		s.logger.LogServiceOperation("post", "create_from_news_api", false, time.Since(start).Milliseconds())
-		return nil, fmt.Errorf("failed to convert NewsAPI article: %w", err)
+		return nil, err
+	}
+
+	if s.upsertArticles {
+		return s.upsertPostFromNewsAPI(ctx, req, start)
 	}
 
 	exists, err := s.PostExists(ctx, req.URL)
@@ -237,3 +801,201 @@ func (s *postService) CreatePostFromNewsAPI(ctx context.Context, article *model.
 	s.logger.LogServiceOperation("post", "create_from_news_api", true, time.Since(start).Milliseconds())
 	return post, nil
 }
+
+// PreviewPostFromNewsAPI returns the post CreatePostFromNewsAPI would store
+// for article without storing it, noting whether a post with its URL is
+// already stored and whether the source rules would refuse it
+func (s *postService) PreviewPostFromNewsAPI(ctx context.Context, article *model.NewsAPIArticleParams) (*model.ArticlePreview, error) {
+	start := time.Now()
+
+	req, err := s.newsAPIPost(ctx, article)
+	if err != nil {
+		s.logger.LogServiceOperation("post", "preview_from_news_api", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	preview := &model.ArticlePreview{}
+
+	rejection, err := s.sources.Check(ctx, req.URL, req.Source)
+	if err != nil {
+		s.logger.LogServiceOperation("post", "preview_from_news_api", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to check source rules: %w", err)
+	}
+	if rejection != nil {
+		preview.Blocked = rejection.Reason
+	}
+
+	preview.Duplicate, err = s.PostExists(ctx, req.URL)
+	if err != nil {
+		s.logger.LogServiceOperation("post", "preview_from_news_api", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to check post existence: %w", err)
+	}
+
+	req.Status = model.PostStatusPublished
+	req.Description = htmlsafe.SanitizePtr(req.Description)
+	req.Content = htmlsafe.SanitizePtr(req.Content)
+	req.Sensitive = s.isSensitive(ctx, req.Title, req.Description, req.Content)
+
+	preview.Post = *req
+	preview.Sensitive = req.Sensitive
+
+	s.logger.LogServiceOperation("post", "preview_from_news_api", true, time.Since(start).Milliseconds())
+
+	return preview, nil
+}
+
+// newsAPIPost converts a NewsAPI article into the post it is stored as, its
+// text normalized, its country detected and its image URL cleaned
+func (s *postService) newsAPIPost(ctx context.Context, article *model.NewsAPIArticleParams) (*model.CreatePostParams, error) {
+	req, err := article.ToPost()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert NewsAPI article: %w: %w", ErrArticleParse, err)
+	}
+	req.Title = textnorm.Title(req.Title, req.Source)
+	req.Description = textnorm.NormalizePtr(req.Description)
+	req.Author = articleAuthor(req.Author)
+	if req.Country == nil {
+		if country := geo.DetectCountry(req.Title); country != "" {
+			req.Country = &country
+		}
+	}
+	req.ImageURL = s.images.Sanitize(ctx, req.ImageURL)
+
+	return req, nil
+}
+
+// upsertPostFromNewsAPI stores the article or refreshes the stored post when
+// the article is newer. A nil post means the stored post was already current.
+func (s *postService) upsertPostFromNewsAPI(ctx context.Context, req *model.CreatePostParams, start time.Time) (*model.Post, error) {
+	if err := s.checkSource(ctx, req); err != nil {
+		s.logger.LogServiceOperation("post", "create_from_news_api", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	req.Description = htmlsafe.SanitizePtr(req.Description)
+	req.Content = htmlsafe.SanitizePtr(req.Content)
+	req.Sensitive = s.isSensitive(ctx, req.Title, req.Description, req.Content)
+
+	post, err := s.repo.UpsertPost(ctx, req)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Debug("Skipping up-to-date post", "url", req.URL)
+			s.logger.LogServiceOperation("post", "create_from_news_api", true, time.Since(start).Milliseconds())
+			return nil, nil
+		}
+
+		s.logger.LogServiceOperation("post", "create_from_news_api", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to upsert post from NewsAPI: %w", err)
+	}
+
+	s.logger.LogServiceOperation("post", "create_from_news_api", true, time.Since(start).Milliseconds())
+	return post, nil
+}
+
+// checkSource refuses posts whose domain or source the source rules block
+func (s *postService) checkSource(ctx context.Context, req *model.CreatePostParams) error {
+	rejection, err := s.sources.Check(ctx, req.URL, req.Source)
+	if err != nil {
+		return fmt.Errorf("failed to check source rules: %w", err)
+	}
+	if rejection != nil {
+		return fmt.Errorf("%w: %s", ErrSourceBlocked, rejection.Reason)
+	}
+
+	return nil
+}
+
+// attachReactions fills in per-type reaction counts. Counts are decoration,
+// so a lookup failure is logged and the posts are returned without them.
+func (s *postService) attachReactions(ctx context.Context, posts []*model.Post) {
+	if len(posts) == 0 {
+		return
+	}
+
+	ids := make([]int64, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+
+	counts, err := s.reactions.GetReactionCounts(ctx, ids)
+	if err != nil {
+		s.logger.Warn("Failed to load reaction counts", "error", err.Error())
+		return
+	}
+
+	for _, post := range posts {
+		if reactions := counts[post.ID]; len(reactions) > 0 {
+			post.Reactions = reactions
+		}
+	}
+}
+
+// attachHighlights sets the highlighted title and description of each post.
+// Highlighting is decoration, so a failure is logged and the posts are
+// returned without it.
+func (s *postService) attachHighlights(ctx context.Context, posts []*model.Post, query string) {
+	if len(posts) == 0 {
+		return
+	}
+
+	ids := make([]int64, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+
+	highlights, err := s.repo.HighlightPosts(ctx, &model.HighlightPostsParams{
+		IDs:      ids,
+		Query:    query,
+		StartSel: s.search.HighlightStart,
+		StopSel:  s.search.HighlightStop,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to highlight search results", "error", err.Error())
+		return
+	}
+
+	for _, post := range posts {
+		if highlight, ok := highlights[post.ID]; ok {
+			post.Highlight = &highlight
+		}
+	}
+}
+
+// maxAuthorLength matches the author column of posts
+const maxAuthorLength = 255
+
+// articleAuthor normalizes the author of a NewsAPI article, which is free
+// text, truncating it to the length the posts table holds. A blank author is
+// dropped.
+func articleAuthor(author *string) *string {
+	if author == nil {
+		return nil
+	}
+
+	normalized := []rune(textnorm.Normalize(*author))
+	if len(normalized) == 0 {
+		return nil
+	}
+	if len(normalized) > maxAuthorLength {
+		normalized = []rune(strings.TrimSpace(string(normalized[:maxAuthorLength])))
+	}
+
+	trimmed := string(normalized)
+	return &trimmed
+}
+
+// isSensitive classifies a post's text. A classifier failure is logged and
+// the post is left unflagged rather than failing the write.
+func (s *postService) isSensitive(ctx context.Context, title string, description, content *string) bool {
+	sensitive, err := s.classifier.Classify(ctx, &model.ClassificationInput{
+		Title:       title,
+		Description: description,
+		Content:     content,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to classify post", "title", title, "error", err.Error())
+		return false
+	}
+
+	return sensitive
+}
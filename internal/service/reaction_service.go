@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxReactionUserIDLength matches the post_reactions.user_id column
+const maxReactionUserIDLength = 100
+
+var (
+	ErrReactionUserRequired = errors.New("a user ID is required to react")
+	ErrReactionTypeInvalid  = errors.New("reaction type is not supported")
+	ErrReactionNotFound     = errors.New("reaction not found")
+)
+
+// reactionService implements ReactionService interface
+type reactionService struct {
+	repo     repository.ReactionRepository
+	postRepo repository.PostRepository
+	tx       repository.UnitOfWork
+	logger   *logger.Logger
+}
+
+// NewReactionService creates a new reaction service
+func NewReactionService(repo repository.ReactionRepository, postRepo repository.PostRepository, tx repository.UnitOfWork, logger *logger.Logger) ReactionService {
+	return &reactionService{
+		repo:     repo,
+		postRepo: postRepo,
+		tx:       tx,
+		logger:   logger,
+	}
+}
+
+// React records a user's reaction to a post. Reacting again with another type
+// replaces the earlier reaction, so each user counts once per post.
+func (s *reactionService) React(ctx context.Context, postID int64, userID string, req *model.ReactParams) (*model.ReactionSummary, error) {
+	start := time.Now()
+
+	userID, err := normalizeReactionUser(userID)
+	if err != nil {
+		s.logger.LogServiceOperation("reaction", "react", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	if !slices.Contains(model.ReactionTypes, req.Type) {
+		s.logger.LogServiceOperation("reaction", "react", false, time.Since(start).Milliseconds())
+		return nil, ErrReactionTypeInvalid
+	}
+
+	reaction := &model.Reaction{PostID: postID, UserID: userID, Type: req.Type}
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.ensurePost(ctx, postID); err != nil {
+			return err
+		}
+
+		inserted, err := s.repo.UpsertReaction(ctx, reaction)
+		if err != nil {
+			return err
+		}
+		if !inserted {
+			return nil
+		}
+
+		return s.postRepo.AdjustReactionCount(ctx, postID, 1)
+	})
+	if err != nil {
+		s.logger.LogServiceOperation("reaction", "react", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	summary, err := s.summarize(ctx, postID)
+	if err != nil {
+		s.logger.LogServiceOperation("reaction", "react", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+	summary.Type = &reaction.Type
+
+	s.logger.LogServiceOperation("reaction", "react", true, time.Since(start).Milliseconds())
+
+	return summary, nil
+}
+
+// RemoveReaction removes a user's reaction from a post
+func (s *reactionService) RemoveReaction(ctx context.Context, postID int64, userID string) error {
+	start := time.Now()
+
+	userID, err := normalizeReactionUser(userID)
+	if err != nil {
+		s.logger.LogServiceOperation("reaction", "remove", false, time.Since(start).Milliseconds())
+		return err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		deleted, err := s.repo.DeleteReaction(ctx, postID, userID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return ErrReactionNotFound
+		}
+
+		return s.postRepo.AdjustReactionCount(ctx, postID, -1)
+	})
+	if err != nil {
+		s.logger.LogServiceOperation("reaction", "remove", false, time.Since(start).Milliseconds())
+		return err
+	}
+
+	s.logger.LogServiceOperation("reaction", "remove", true, time.Since(start).Milliseconds())
+
+	return nil
+}
+
+// ensurePost maps a missing or unpublished post to ErrPostNotFound
+func (s *reactionService) ensurePost(ctx context.Context, postID int64) error {
+	if postID <= 0 {
+		return ErrPostIDInvalid
+	}
+
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPostNotFound
+		}
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+
+	if !post.IsPublished() {
+		return ErrPostNotFound
+	}
+
+	return nil
+}
+
+// summarize reads a post's current reaction counts
+func (s *reactionService) summarize(ctx context.Context, postID int64) (*model.ReactionSummary, error) {
+	counts, err := s.repo.GetReactionCounts(ctx, []int64{postID})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.ReactionSummary{PostID: postID, Reactions: counts[postID]}
+	if summary.Reactions == nil {
+		summary.Reactions = map[string]int64{}
+	}
+	for _, count := range summary.Reactions {
+		summary.ReactionCount += int(count)
+	}
+
+	return summary, nil
+}
+
+// normalizeReactionUser trims and checks the ID of the reacting user
+func normalizeReactionUser(userID string) (string, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" || len(userID) > maxReactionUserIDLength {
+		return "", ErrReactionUserRequired
+	}
+
+	return userID, nil
+}
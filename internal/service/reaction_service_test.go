@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockReactionRepository is a mock implementation of ReactionRepository
+type MockReactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockReactionRepository) UpsertReaction(ctx context.Context, reaction *model.Reaction) (bool, error) {
+	args := m.Called(ctx, reaction)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReactionRepository) DeleteReaction(ctx context.Context, postID int64, userID string) (bool, error) {
+	args := m.Called(ctx, postID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReactionRepository) GetReactionCounts(ctx context.Context, postIDs []int64) (map[int64]map[string]int64, error) {
+	args := m.Called(ctx, postIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]map[string]int64), args.Error(1)
+}
+
+// ReactionServiceTestSuite defines the test suite for ReactionService
+type ReactionServiceTestSuite struct {
+	suite.Suite
+	mockRepo     *MockReactionRepository
+	mockPostRepo *MockPostRepository
+	service      ReactionService
+	ctx          context.Context
+}
+
+func (suite *ReactionServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockReactionRepository)
+	suite.mockPostRepo = new(MockPostRepository)
+	suite.service = NewReactionService(suite.mockRepo, suite.mockPostRepo, passthroughUnitOfWork{}, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *ReactionServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockPostRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ReactionServiceTestSuite) TestReactNewReactionIncrementsCount() {
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(1)).Return(&model.Post{ID: 1, Status: model.PostStatusPublished}, nil)
+	suite.mockRepo.On("UpsertReaction", suite.ctx, mock.MatchedBy(func(r *model.Reaction) bool {
+		return r.PostID == 1 && r.UserID == "user-1" && r.Type == model.ReactionLike
+	})).Return(true, nil)
+	suite.mockPostRepo.On("AdjustReactionCount", suite.ctx, int64(1), 1).Return(nil)
+	suite.mockRepo.On("GetReactionCounts", suite.ctx, []int64{1}).
+		Return(map[int64]map[string]int64{1: {"like": 2, "love": 1}}, nil)
+
+	summary, err := suite.service.React(suite.ctx, 1, " user-1 ", &model.ReactParams{Type: model.ReactionLike})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, summary.ReactionCount)
+	assert.Equal(suite.T(), model.ReactionLike, *summary.Type)
+}
+
+func (suite *ReactionServiceTestSuite) TestReactChangingTypeKeepsCount() {
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(1)).Return(&model.Post{ID: 1, Status: model.PostStatusPublished}, nil)
+	suite.mockRepo.On("UpsertReaction", suite.ctx, mock.Anything).Return(false, nil)
+	suite.mockRepo.On("GetReactionCounts", suite.ctx, []int64{1}).
+		Return(map[int64]map[string]int64{1: {"upvote": 1}}, nil)
+
+	summary, err := suite.service.React(suite.ctx, 1, "user-1", &model.ReactParams{Type: model.ReactionUpvote})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, summary.ReactionCount)
+	suite.mockPostRepo.AssertNotCalled(suite.T(), "AdjustReactionCount", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ReactionServiceTestSuite) TestReactRequiresUser() {
+	_, err := suite.service.React(suite.ctx, 1, "  ", &model.ReactParams{Type: model.ReactionLike})
+
+	assert.ErrorIs(suite.T(), err, ErrReactionUserRequired)
+}
+
+func (suite *ReactionServiceTestSuite) TestReactRejectsUnknownType() {
+	_, err := suite.service.React(suite.ctx, 1, "user-1", &model.ReactParams{Type: "meh"})
+
+	assert.ErrorIs(suite.T(), err, ErrReactionTypeInvalid)
+}
+
+func (suite *ReactionServiceTestSuite) TestReactPostNotFound() {
+	suite.mockPostRepo.On("GetPostByID", suite.ctx, int64(99)).Return(nil, pgx.ErrNoRows)
+
+	_, err := suite.service.React(suite.ctx, 99, "user-1", &model.ReactParams{Type: model.ReactionLike})
+
+	assert.ErrorIs(suite.T(), err, ErrPostNotFound)
+}
+
+func (suite *ReactionServiceTestSuite) TestRemoveReactionDecrementsCount() {
+	suite.mockRepo.On("DeleteReaction", suite.ctx, int64(1), "user-1").Return(true, nil)
+	suite.mockPostRepo.On("AdjustReactionCount", suite.ctx, int64(1), -1).Return(nil)
+
+	err := suite.service.RemoveReaction(suite.ctx, 1, "user-1")
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ReactionServiceTestSuite) TestRemoveReactionNotFound() {
+	suite.mockRepo.On("DeleteReaction", suite.ctx, int64(1), "user-1").Return(false, nil)
+
+	err := suite.service.RemoveReaction(suite.ctx, 1, "user-1")
+
+	assert.ErrorIs(suite.T(), err, ErrReactionNotFound)
+}
+
+func TestReactionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReactionServiceTestSuite))
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+type readOnlyContextKey struct{}
+
+// WithReadOnly marks ctx as serving a request made in read-only mode, so
+// that reads skip the views, clicks and searches they would otherwise record
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyContextKey{}, true)
+}
+
+// ReadOnlyFromContext reports whether ctx was marked as serving a request
+// made in read-only mode
+func ReadOnlyFromContext(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyContextKey{}).(bool)
+	return readOnly
+}
+
+// readOnlyService implements ReadOnlyService interface
+type readOnlyService struct {
+	scheduler SchedulerService
+	logger    *logger.Logger
+
+	mu     sync.RWMutex
+	status model.ReadOnlyStatus
+	// pausedScheduler is set when read-only mode paused the scheduler, so
+	// leaving it does not resume a scheduler an operator paused
+	pausedScheduler bool
+}
+
+// NewReadOnlyService creates a new read-only mode service, starting in
+// read-only mode when enabled is set
+func NewReadOnlyService(scheduler SchedulerService, enabled bool, logger *logger.Logger) ReadOnlyService {
+	s := &readOnlyService{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+
+	if enabled {
+		s.Enable("READ_ONLY_MODE is set")
+	}
+
+	return s
+}
+
+// Enabled reports whether the API is in read-only mode
+func (s *readOnlyService) Enabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.status.Enabled
+}
+
+// Status returns the current read-only mode
+func (s *readOnlyService) Status() *model.ReadOnlyStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status := s.status
+	return &status
+}
+
+// Enable puts the API in read-only mode and pauses the scheduler. Enabling it
+// again only updates the reason.
+func (s *readOnlyService) Enable(reason string) *model.ReadOnlyStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.Reason = reason
+	if !s.status.Enabled {
+		now := time.Now().UTC()
+		s.status.Enabled = true
+		s.status.Since = &now
+
+		if !s.scheduler.IsPaused() {
+			s.scheduler.Pause()
+			s.pausedScheduler = true
+		}
+
+		s.logger.Warn("Read-only mode enabled", "reason", reason)
+	}
+
+	status := s.status
+	return &status
+}
+
+// Disable takes the API out of read-only mode and resumes the scheduler if
+// read-only mode paused it
+func (s *readOnlyService) Disable() *model.ReadOnlyStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status.Enabled {
+		if s.pausedScheduler {
+			s.scheduler.Resume()
+			s.pausedScheduler = false
+		}
+
+		s.logger.Info("Read-only mode disabled")
+	}
+
+	s.status = model.ReadOnlyStatus{}
+
+	return &model.ReadOnlyStatus{}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// ReadOnlyServiceTestSuite defines the test suite for ReadOnlyService
+type ReadOnlyServiceTestSuite struct {
+	suite.Suite
+	scheduler SchedulerService
+	logger    *logger.Logger
+}
+
+func (suite *ReadOnlyServiceTestSuite) SetupTest() {
+	suite.logger = logger.New(&config.Config{App: config.AppConfig{LogLevel: "debug"}})
+	suite.scheduler = NewSchedulerService(nil, config.SchedulerConfig{}, suite.logger)
+}
+
+func (suite *ReadOnlyServiceTestSuite) TestStartsFromConfig() {
+	assert.False(suite.T(), NewReadOnlyService(suite.scheduler, false, suite.logger).Enabled())
+	assert.False(suite.T(), suite.scheduler.IsPaused())
+
+	service := NewReadOnlyService(suite.scheduler, true, suite.logger)
+
+	assert.True(suite.T(), service.Enabled())
+	assert.NotNil(suite.T(), service.Status().Since)
+	assert.True(suite.T(), suite.scheduler.IsPaused())
+}
+
+func (suite *ReadOnlyServiceTestSuite) TestEnableAndDisable() {
+	service := NewReadOnlyService(suite.scheduler, false, suite.logger)
+
+	status := service.Enable("failover")
+	assert.True(suite.T(), status.Enabled)
+	assert.Equal(suite.T(), "failover", status.Reason)
+	assert.True(suite.T(), suite.scheduler.IsPaused())
+
+	since := status.Since
+	status = service.Enable("still failing over")
+	assert.Equal(suite.T(), "still failing over", status.Reason)
+	assert.Equal(suite.T(), since, status.Since)
+
+	status = service.Disable()
+	assert.False(suite.T(), status.Enabled)
+	assert.False(suite.T(), service.Enabled())
+	assert.Nil(suite.T(), service.Status().Since)
+	assert.False(suite.T(), suite.scheduler.IsPaused())
+}
+
+func (suite *ReadOnlyServiceTestSuite) TestKeepsOperatorPause() {
+	suite.scheduler.Pause()
+	service := NewReadOnlyService(suite.scheduler, true, suite.logger)
+
+	service.Disable()
+
+	assert.True(suite.T(), suite.scheduler.IsPaused())
+}
+
+func TestReadOnlyServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReadOnlyServiceTestSuite))
+}
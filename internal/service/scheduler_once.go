@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+)
+
+const (
+	// onceClaimBatch bounds how many due one-time jobs are claimed per poll
+	onceClaimBatch = 20
+	// onceLeaseMargin is added to the timeout of tasks that may outlast the
+	// claim lease, so their job is not delivered again while it runs
+	onceLeaseMargin = time.Minute
+	// queuedJobPrefix starts the ID of the queued run of a scheduled job
+	queuedJobPrefix = "job:"
+)
+
+var (
+	ErrTaskNotRegistered    = errors.New("task not registered")
+	ErrOnceJobNotFound      = errors.New("one-time job not found")
+	ErrDeadOnceJobNotFound  = errors.New("dead one-time job not found")
+	ErrOnceJobsUnavailable  = errors.New("one-time jobs are not available")
+	ErrInvalidOnceJobTiming = errors.New("one-time job needs a run time")
+)
+
+// OnceTaskFunc runs a one-time job with the payload it was scheduled with.
+// ctx is scoped to the tenant that scheduled it.
+type OnceTaskFunc func(ctx context.Context, payload json.RawMessage) error
+
+// onceTask is a registered handler of one-time jobs. Tasks of scheduled
+// jobs added with WithJobQueued run job instead of run.
+type onceTask struct {
+	name    string
+	run     OnceTaskFunc
+	options jobOptions
+	job     *scheduledJob
+}
+
+// queuedRun is the payload of the queued run of a scheduled job
+type queuedRun struct {
+	TriggeredBy string `json:"triggered_by,omitempty"`
+}
+
+// RegisterTask makes name available to ScheduleOnce. Jobs are queued by
+// task name rather than by function, so every replica registers the same
+// tasks at startup to pick up jobs scheduled before a restart. Timeout and
+// retry options apply to each job, a failed job being queued again after
+// the retry backoff; scheduling options are ignored. Queued scheduled jobs
+// are registered under their own name, which is then taken.
+func (s *schedulerService) RegisterTask(name string, task OnceTaskFunc, opts ...JobOption) {
+	options := defaultJobOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	s.tasksMu.Lock()
+	s.tasks[name] = &onceTask{name: name, run: task, options: options}
+	s.tasksMu.Unlock()
+
+	s.logger.Info("Registered one-time task", "name", name)
+}
+
+// ScheduleOnce queues a run of the named task at the given time, with
+// payload encoded as JSON, for the tenant of ctx. Times in the past run as
+// soon as possible. The job is kept in Redis until it has run.
+func (s *schedulerService) ScheduleOnce(ctx context.Context, name string, at time.Time, payload any) (*model.OnceJob, error) {
+	if s.onceJobs == nil {
+		return nil, ErrOnceJobsUnavailable
+	}
+	if at.IsZero() {
+		return nil, ErrInvalidOnceJobTiming
+	}
+
+	s.tasksMu.RLock()
+	_, registered := s.tasks[name]
+	s.tasksMu.RUnlock()
+
+	if !registered {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotRegistered, name)
+	}
+
+	job := &model.OnceJob{
+		ID:        rand.Text(),
+		Task:      name,
+		RunAt:     at.UTC(),
+		Tenant:    tenant.FromContext(ctx),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode one-time job payload: %w", err)
+		}
+		job.Payload = data
+	}
+
+	if _, err := s.onceJobs.SaveOnceJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Scheduled one-time job",
+		"id", job.ID,
+		"task", name,
+		"run_at", job.RunAt.Format(time.RFC3339),
+		"tenant", job.Tenant,
+	)
+
+	return job, nil
+}
+
+// CancelOnce removes a queued one-time job. A job already running is not
+// interrupted.
+func (s *schedulerService) CancelOnce(ctx context.Context, id string) error {
+	if s.onceJobs == nil {
+		return ErrOnceJobsUnavailable
+	}
+
+	deleted, err := s.onceJobs.DeleteOnceJob(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrOnceJobNotFound
+	}
+
+	s.logger.Info("Cancelled one-time job", "id", id)
+
+	return nil
+}
+
+// ListOnceJobs returns the one-time jobs waiting to run, soonest first
+func (s *schedulerService) ListOnceJobs(ctx context.Context) ([]model.OnceJob, error) {
+	if s.onceJobs == nil {
+		return []model.OnceJob{}, nil
+	}
+
+	return s.onceJobs.ListOnceJobs(ctx)
+}
+
+// ListDeadOnceJobs returns the one-time jobs that failed on their last
+// attempt, most recent failure first
+func (s *schedulerService) ListDeadOnceJobs(ctx context.Context) ([]model.DeadOnceJob, error) {
+	if s.onceJobs == nil {
+		return []model.DeadOnceJob{}, nil
+	}
+
+	return s.onceJobs.ListDeadOnceJobs(ctx)
+}
+
+// RetryDeadOnceJob queues a dead job to run again now, with its attempts
+// counted afresh
+func (s *schedulerService) RetryDeadOnceJob(ctx context.Context, id string) (*model.OnceJob, error) {
+	if s.onceJobs == nil {
+		return nil, ErrOnceJobsUnavailable
+	}
+
+	job, err := s.onceJobs.RequeueDeadOnceJob(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrDeadOnceJobNotFound
+	}
+
+	s.logger.Info("Requeued dead one-time job", "id", id, "task", job.Task)
+
+	return job, nil
+}
+
+// DeleteDeadOnceJob discards a dead job
+func (s *schedulerService) DeleteDeadOnceJob(ctx context.Context, id string) error {
+	if s.onceJobs == nil {
+		return ErrOnceJobsUnavailable
+	}
+
+	deleted, err := s.onceJobs.DeleteDeadOnceJob(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrDeadOnceJobNotFound
+	}
+
+	s.logger.Info("Deleted dead one-time job", "id", id)
+
+	return nil
+}
+
+// enqueueJob queues a run of a scheduled job added with WithJobQueued. A
+// run still queued or running is not queued twice.
+func (s *schedulerService) enqueueJob(job *scheduledJob, triggeredBy string) {
+	payload, _ := json.Marshal(queuedRun{TriggeredBy: triggeredBy})
+	now := time.Now().UTC()
+
+	queued := &model.OnceJob{
+		ID:        queuedJobPrefix + job.name,
+		Task:      job.name,
+		RunAt:     now,
+		Tenant:    tenant.Default,
+		Payload:   payload,
+		CreatedAt: now,
+	}
+
+	added, err := s.onceJobs.SaveOnceJob(context.WithoutCancel(s.ctx), queued)
+	if err != nil {
+		s.logger.Error("Failed to queue scheduled job", "name", job.name, "error", err.Error())
+		return
+	}
+	if !added {
+		s.logger.Debug("Skipping scheduled job already queued", "name", job.name, "triggered_by", triggeredBy)
+		return
+	}
+
+	s.logger.Info("Queued scheduled job", "name", job.name, "triggered_by", triggeredBy)
+}
+
+// pollOnceJobs claims and runs due one-time jobs every poll interval until
+// ctx is done. Nothing is claimed while the scheduler is paused.
+func (s *schedulerService) pollOnceJobs(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cfg.OncePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.IsPaused() {
+				s.claimOnceJobs(ctx)
+			}
+		}
+	}
+}
+
+// claimOnceJobs starts the due one-time jobs this replica has a task for.
+// Jobs of unknown tasks are left to run again once their lease ends, in
+// case another replica knows the task.
+func (s *schedulerService) claimOnceJobs(ctx context.Context) {
+	jobs, err := s.onceJobs.ClaimDueOnceJobs(ctx, time.Now(), s.cfg.OnceLease, onceClaimBatch)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("Failed to claim one-time jobs", "error", err.Error())
+		}
+		return
+	}
+
+	for _, job := range jobs {
+		s.tasksMu.RLock()
+		task, registered := s.tasks[job.Task]
+		s.tasksMu.RUnlock()
+
+		if !registered {
+			s.logger.Warn("Skipping one-time job of unregistered task", "id", job.ID, "task", job.Task)
+			continue
+		}
+
+		if lease := task.options.timeout + onceLeaseMargin; lease > s.cfg.OnceLease {
+			if err := s.onceJobs.RescheduleOnceJob(ctx, job.ID, time.Now().Add(lease)); err != nil {
+				s.logger.Warn("Failed to extend one-time job lease", "id", job.ID, "error", err.Error())
+			}
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.runOnceJob(task, job)
+		}()
+	}
+}
+
+// runOnceJob makes one attempt at a claimed job. A job that succeeded is
+// removed from the queue; one that failed is queued again after its
+// backoff, or moved to the dead jobs once it has used up its retries. A job
+// cut short by shutdown stays queued and runs again after its lease.
+func (s *schedulerService) runOnceJob(task *onceTask, job model.OnceJob) {
+	start := time.Now()
+
+	var err error
+	if task.job != nil {
+		err = s.runQueuedJob(task.job, job)
+	} else {
+		run := &scheduledJob{
+			name: task.name,
+			job: func(ctx context.Context) error {
+				return task.run(tenant.WithTenant(ctx, job.Tenant), job.Payload)
+			},
+			options: task.options,
+		}
+
+		var stats map[string]int64
+		err = s.runAttempt(run, &stats)
+	}
+
+	if err != nil && s.ctx.Err() != nil {
+		s.logger.Warn("One-time job interrupted by shutdown", "id", job.ID, "task", job.Task)
+		return
+	}
+
+	ctx := context.WithoutCancel(s.ctx)
+
+	if err == nil {
+		if _, delErr := s.onceJobs.DeleteOnceJob(ctx, job.ID); delErr != nil {
+			s.logger.Error("Failed to remove finished one-time job", "id", job.ID, "error", delErr.Error())
+		}
+
+		s.logger.Info("One-time job completed",
+			"id", job.ID,
+			"task", job.Task,
+			"tenant", job.Tenant,
+			"attempt", job.Attempts,
+			"durationMS", time.Since(start).Milliseconds(),
+		)
+		return
+	}
+
+	if job.Attempts <= task.options.maxRetries {
+		backoff := task.options.retryBackoff << max(job.Attempts-1, 0)
+		if retryErr := s.onceJobs.RescheduleOnceJob(ctx, job.ID, time.Now().Add(backoff)); retryErr != nil {
+			s.logger.Error("Failed to requeue one-time job", "id", job.ID, "error", retryErr.Error())
+		}
+
+		s.logger.Warn("One-time job attempt failed, retrying",
+			"id", job.ID,
+			"task", job.Task,
+			"tenant", job.Tenant,
+			"attempt", job.Attempts,
+			"backoff", backoff.String(),
+			"error", err.Error(),
+		)
+		return
+	}
+
+	dead := &model.DeadOnceJob{OnceJob: job, Error: err.Error(), FailedAt: time.Now().UTC()}
+	if deadErr := s.onceJobs.DeadLetterOnceJob(ctx, dead); deadErr != nil {
+		s.logger.Error("Failed to dead-letter one-time job", "id", job.ID, "error", deadErr.Error())
+	}
+
+	s.logger.Error("One-time job failed",
+		"id", job.ID,
+		"task", job.Task,
+		"tenant", job.Tenant,
+		"attempts", job.Attempts,
+		"error", err.Error(),
+		"durationMS", time.Since(start).Milliseconds(),
+	)
+}
+
+// runQueuedJob makes one attempt at the queued run of a scheduled job,
+// recording it in the job's history, and triggers the jobs that run after
+// it when it succeeds
+func (s *schedulerService) runQueuedJob(scheduled *scheduledJob, job model.OnceJob) error {
+	var run queuedRun
+	if len(job.Payload) > 0 {
+		if err := json.Unmarshal(job.Payload, &run); err != nil {
+			s.logger.Warn("Ignoring unreadable queued job payload", "id", job.ID, "error", err.Error())
+		}
+	}
+
+	scheduled.runMu.Lock()
+	err := s.executeJob(scheduled, run.TriggeredBy, func(stats *map[string]int64) (int, error) {
+		return job.Attempts, s.runAttempt(scheduled, stats)
+	})
+	scheduled.runMu.Unlock()
+
+	if err == nil {
+		s.runDependents(scheduled.name)
+	}
+
+	return err
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockOnceJobRepository is a mock implementation of OnceJobRepository
+type MockOnceJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockOnceJobRepository) SaveOnceJob(ctx context.Context, job *model.OnceJob) (bool, error) {
+	args := m.Called(ctx, job)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOnceJobRepository) ClaimDueOnceJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OnceJob, error) {
+	args := m.Called(ctx, now, lease, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OnceJob), args.Error(1)
+}
+
+func (m *MockOnceJobRepository) RescheduleOnceJob(ctx context.Context, id string, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockOnceJobRepository) DeleteOnceJob(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOnceJobRepository) ListOnceJobs(ctx context.Context) ([]model.OnceJob, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OnceJob), args.Error(1)
+}
+
+func (m *MockOnceJobRepository) DeadLetterOnceJob(ctx context.Context, job *model.DeadOnceJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockOnceJobRepository) ListDeadOnceJobs(ctx context.Context) ([]model.DeadOnceJob, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.DeadOnceJob), args.Error(1)
+}
+
+func (m *MockOnceJobRepository) RequeueDeadOnceJob(ctx context.Context, id string, at time.Time) (*model.OnceJob, error) {
+	args := m.Called(ctx, id, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.OnceJob), args.Error(1)
+}
+
+func (m *MockOnceJobRepository) DeleteDeadOnceJob(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// OnceJobTestSuite defines the test suite for one-time jobs of SchedulerService
+type OnceJobTestSuite struct {
+	suite.Suite
+	mockRepo *MockOnceJobRepository
+	service  SchedulerService
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+func (suite *OnceJobTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockOnceJobRepository)
+	suite.service = NewSchedulerService(suite.mockRepo, config.SchedulerConfig{
+		OncePollInterval: 10 * time.Millisecond,
+		OnceLease:        time.Hour,
+	}, logger.New(cfg))
+	suite.ctx, suite.cancel = context.WithCancel(context.Background())
+}
+
+func (suite *OnceJobTestSuite) TearDownTest() {
+	_ = suite.service.Stop()
+	suite.cancel()
+}
+
+func (suite *OnceJobTestSuite) TestScheduleOnceSavesJob() {
+	suite.service.RegisterTask("post-purge", func(ctx context.Context, payload json.RawMessage) error { return nil })
+
+	runAt := time.Now().Add(10 * time.Minute)
+	suite.mockRepo.On("SaveOnceJob", mock.Anything, mock.MatchedBy(func(job *model.OnceJob) bool {
+		return job.ID != "" && job.Task == "post-purge" && job.RunAt.Equal(runAt) &&
+			job.Tenant == "acme" && string(job.Payload) == `{"post_id":7}`
+	})).Return(true, nil).Once()
+
+	job, err := suite.service.ScheduleOnce(tenant.WithTenant(suite.ctx, "acme"), "post-purge", runAt, map[string]int64{"post_id": 7})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "post-purge", job.Task)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *OnceJobTestSuite) TestScheduleOnceUnregisteredTask() {
+	_, err := suite.service.ScheduleOnce(suite.ctx, "missing", time.Now(), nil)
+
+	assert.ErrorIs(suite.T(), err, ErrTaskNotRegistered)
+	suite.mockRepo.AssertNotCalled(suite.T(), "SaveOnceJob", mock.Anything, mock.Anything)
+}
+
+func (suite *OnceJobTestSuite) TestScheduleOnceWithoutQueue() {
+	scheduler := NewSchedulerService(nil, config.SchedulerConfig{}, logger.New(&config.Config{App: config.AppConfig{LogLevel: "debug"}}))
+	scheduler.RegisterTask("post-purge", func(ctx context.Context, payload json.RawMessage) error { return nil })
+
+	_, err := scheduler.ScheduleOnce(suite.ctx, "post-purge", time.Now(), nil)
+
+	assert.ErrorIs(suite.T(), err, ErrOnceJobsUnavailable)
+}
+
+func (suite *OnceJobTestSuite) TestCancelOnceNotFound() {
+	suite.mockRepo.On("DeleteOnceJob", mock.Anything, "missing").Return(false, nil).Once()
+
+	err := suite.service.CancelOnce(suite.ctx, "missing")
+
+	assert.ErrorIs(suite.T(), err, ErrOnceJobNotFound)
+}
+
+func (suite *OnceJobTestSuite) TestDueJobRunsForItsTenantAndIsRemoved() {
+	ran := make(chan string, 1)
+	suite.service.RegisterTask("post-purge", func(ctx context.Context, payload json.RawMessage) error {
+		ran <- tenant.FromContext(ctx) + " " + string(payload)
+		return nil
+	})
+
+	job := model.OnceJob{ID: "A1", Task: "post-purge", Tenant: "acme", Payload: json.RawMessage(`{"post_id":7}`)}
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{job}, nil).Once()
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{}, nil)
+	deleted := make(chan struct{})
+	suite.mockRepo.On("DeleteOnceJob", mock.Anything, "A1").Return(true, nil).Once().Run(func(mock.Arguments) { close(deleted) })
+
+	assert.NoError(suite.T(), suite.service.Start(suite.ctx))
+
+	select {
+	case got := <-ran:
+		assert.Equal(suite.T(), `acme {"post_id":7}`, got)
+	case <-time.After(time.Second):
+		suite.T().Fatal("one-time job did not run")
+	}
+
+	select {
+	case <-deleted:
+	case <-time.After(time.Second):
+		suite.T().Fatal("one-time job was not removed")
+	}
+}
+
+func (suite *OnceJobTestSuite) TestFailedJobIsRequeuedAfterBackoff() {
+	suite.service.RegisterTask("flaky", func(ctx context.Context, payload json.RawMessage) error {
+		return errors.New("webhook unreachable")
+	}, WithJobRetries(1), WithJobRetryBackoff(time.Minute))
+
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{{ID: "B2", Task: "flaky", Attempts: 1}}, nil).Once()
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{}, nil)
+	requeued := make(chan time.Time, 1)
+	suite.mockRepo.On("RescheduleOnceJob", mock.Anything, "B2", mock.Anything).Return(nil).Once().Run(func(args mock.Arguments) {
+		requeued <- args.Get(2).(time.Time)
+	})
+
+	assert.NoError(suite.T(), suite.service.Start(suite.ctx))
+
+	select {
+	case at := <-requeued:
+		assert.WithinDuration(suite.T(), time.Now().Add(time.Minute), at, 5*time.Second)
+	case <-time.After(time.Second):
+		suite.T().Fatal("failed one-time job was not requeued")
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteOnceJob", mock.Anything, "B2")
+}
+
+func (suite *OnceJobTestSuite) TestJobOutOfRetriesIsDeadLettered() {
+	suite.service.RegisterTask("flaky", func(ctx context.Context, payload json.RawMessage) error {
+		return errors.New("webhook unreachable")
+	}, WithJobRetries(1))
+
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{{ID: "B2", Task: "flaky", Attempts: 2}}, nil).Once()
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{}, nil)
+	dead := make(chan *model.DeadOnceJob, 1)
+	suite.mockRepo.On("DeadLetterOnceJob", mock.Anything, mock.Anything).Return(nil).Once().Run(func(args mock.Arguments) {
+		dead <- args.Get(1).(*model.DeadOnceJob)
+	})
+
+	assert.NoError(suite.T(), suite.service.Start(suite.ctx))
+
+	select {
+	case job := <-dead:
+		assert.Equal(suite.T(), "B2", job.ID)
+		assert.Equal(suite.T(), 2, job.Attempts)
+		assert.Equal(suite.T(), "webhook unreachable", job.Error)
+	case <-time.After(time.Second):
+		suite.T().Fatal("failed one-time job was not dead-lettered")
+	}
+	suite.mockRepo.AssertNotCalled(suite.T(), "RescheduleOnceJob", mock.Anything, "B2", mock.Anything)
+}
+
+func (suite *OnceJobTestSuite) TestLongTaskLeaseIsExtended() {
+	suite.service.RegisterTask("extraction", func(ctx context.Context, payload json.RawMessage) error {
+		return nil
+	}, WithJobTimeout(2*time.Hour))
+
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{{ID: "D4", Task: "extraction", Attempts: 1}}, nil).Once()
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{}, nil)
+	suite.mockRepo.On("RescheduleOnceJob", mock.Anything, "D4", mock.MatchedBy(func(at time.Time) bool {
+		return at.After(time.Now().Add(2 * time.Hour))
+	})).Return(nil).Once()
+	deleted := make(chan struct{})
+	suite.mockRepo.On("DeleteOnceJob", mock.Anything, "D4").Return(true, nil).Once().Run(func(mock.Arguments) { close(deleted) })
+
+	assert.NoError(suite.T(), suite.service.Start(suite.ctx))
+
+	select {
+	case <-deleted:
+	case <-time.After(time.Second):
+		suite.T().Fatal("one-time job was not removed")
+	}
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *OnceJobTestSuite) TestQueuedJobRunsWhenClaimed() {
+	ran := make(chan struct{}, 1)
+	suite.service.AddJob("top-headlines", 10*time.Millisecond, func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}, WithJobQueued())
+
+	queued := make(chan struct{})
+	suite.mockRepo.On("SaveOnceJob", mock.Anything, mock.MatchedBy(func(job *model.OnceJob) bool {
+		return job.ID == "job:top-headlines" && job.Task == "top-headlines"
+	})).Return(true, nil).Once().Run(func(mock.Arguments) { close(queued) })
+	suite.mockRepo.On("SaveOnceJob", mock.Anything, mock.Anything).Return(false, nil)
+	claimed := model.OnceJob{ID: "job:top-headlines", Task: "top-headlines", Attempts: 1, Payload: json.RawMessage(`{}`)}
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{claimed}, nil).Once()
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{}, nil)
+	deleted := make(chan struct{})
+	suite.mockRepo.On("DeleteOnceJob", mock.Anything, "job:top-headlines").Return(true, nil).Once().Run(func(mock.Arguments) { close(deleted) })
+
+	assert.NoError(suite.T(), suite.service.Start(suite.ctx))
+
+	select {
+	case <-queued:
+	case <-time.After(time.Second):
+		suite.T().Fatal("scheduled job was not queued")
+	}
+
+	select {
+	case <-deleted:
+	case <-time.After(time.Second):
+		suite.T().Fatal("queued job was not run")
+	}
+	assert.Len(suite.T(), ran, 1)
+
+	history, err := suite.service.GetJobHistory("top-headlines")
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), history, 1) {
+		assert.True(suite.T(), history[0].Success)
+		assert.Equal(suite.T(), 1, history[0].Attempts)
+	}
+}
+
+func (suite *OnceJobTestSuite) TestJobOfUnknownTaskIsLeftQueued() {
+	claimed := make(chan struct{}, 1)
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{{ID: "C3", Task: "unknown"}}, nil).Once().Run(func(mock.Arguments) { claimed <- struct{}{} })
+	suite.mockRepo.On("ClaimDueOnceJobs", mock.Anything, mock.Anything, time.Hour, onceClaimBatch).Return([]model.OnceJob{}, nil)
+
+	assert.NoError(suite.T(), suite.service.Start(suite.ctx))
+
+	<-claimed
+	time.Sleep(50 * time.Millisecond)
+	suite.mockRepo.AssertNotCalled(suite.T(), "DeleteOnceJob", mock.Anything, "C3")
+}
+
+func TestOnceJobTestSuite(t *testing.T) {
+	suite.Run(t, new(OnceJobTestSuite))
+}
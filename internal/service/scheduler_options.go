@@ -0,0 +1,100 @@
+package service
+
+import (
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+)
+
+// Job defaults used when AddJob is called without options
+const (
+	defaultJobTimeout      = 5 * time.Minute
+	defaultJobRetryBackoff = 10 * time.Second
+)
+
+// JobOption configures how a scheduled job is executed
+type JobOption func(*jobOptions)
+
+// jobOptions holds the execution policy of a scheduled job
+type jobOptions struct {
+	timeout      time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+	mode         string
+	jitter       time.Duration
+	after        []string
+	queued       bool
+}
+
+func defaultJobOptions() jobOptions {
+	return jobOptions{
+		timeout:      defaultJobTimeout,
+		retryBackoff: defaultJobRetryBackoff,
+		mode:         model.ScheduleModeFixedRate,
+	}
+}
+
+// WithJobTimeout bounds a single attempt of the job
+func WithJobTimeout(timeout time.Duration) JobOption {
+	return func(o *jobOptions) {
+		if timeout > 0 {
+			o.timeout = timeout
+		}
+	}
+}
+
+// WithJobRetries retries a failed run up to n more times before recording it as failed
+func WithJobRetries(n int) JobOption {
+	return func(o *jobOptions) {
+		if n >= 0 {
+			o.maxRetries = n
+		}
+	}
+}
+
+// WithJobRetryBackoff sets the delay before the first retry; it doubles for each subsequent retry
+func WithJobRetryBackoff(backoff time.Duration) JobOption {
+	return func(o *jobOptions) {
+		if backoff > 0 {
+			o.retryBackoff = backoff
+		}
+	}
+}
+
+// WithJobFixedDelay waits a full interval after each run finishes instead of
+// keeping to a fixed-rate schedule
+func WithJobFixedDelay() JobOption {
+	return func(o *jobOptions) {
+		o.mode = model.ScheduleModeFixedDelay
+	}
+}
+
+// WithJobJitter delays the job's first run by a random duration up to max
+func WithJobJitter(max time.Duration) JobOption {
+	return func(o *jobOptions) {
+		if max > 0 {
+			o.jitter = max
+		}
+	}
+}
+
+// WithJobAfter also runs the job each time one of the named jobs completes
+// successfully, on top of its own schedule. A job added with a non-positive
+// interval then only runs after them.
+func WithJobAfter(names ...string) JobOption {
+	return func(o *jobOptions) {
+		o.after = append(o.after, names...)
+	}
+}
+
+// WithJobQueued runs the job through the Redis queue of one-time jobs
+// rather than in the scheduling goroutine. Each due run is queued under the
+// job's name and picked up by whichever replica claims it first, so a run
+// lost to a crash is delivered again once its lease ends and a run that
+// keeps failing ends up among the dead jobs. Retries are spread over
+// separate claims. Without a queue the job runs in process as usual.
+func WithJobQueued() JobOption {
+	return func(o *jobOptions) {
+		o.queued = true
+	}
+}
@@ -2,20 +2,80 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/amirzre/news-feed-system/internal/config"
 	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
 	"github.com/amirzre/news-feed-system/pkg/logger"
 )
 
+// jobHistorySize is the number of executions kept per job
+const jobHistorySize = 50
+
+var (
+	ErrJobNotFound        = errors.New("job not found")
+	ErrInvalidJobInterval = errors.New("job interval must be positive")
+	ErrJobDependencyCycle = errors.New("job dependencies form a cycle")
+)
+
 type scheduledJob struct {
 	name     string
 	interval time.Duration
 	job      func(context.Context) error
-	ticker   *time.Ticker
-	status   model.JobStatus
-	mu       sync.RWMutex
+	cancel   context.CancelFunc
+	options  jobOptions
+	// quietHours are the daily windows in which the job does not run
+	quietHours []config.QuietWindow
+	status     model.JobStatus
+	history    executionHistory
+	mu         sync.RWMutex
+	// runMu is held while the job runs, so that a run triggered by another
+	// job and a scheduled run never overlap
+	runMu sync.Mutex
+}
+
+// executionHistory is a fixed-size ring buffer of job executions
+type executionHistory struct {
+	entries []model.JobExecution
+	next    int
+}
+
+// add records an execution, overwriting the oldest once the buffer is full
+func (h *executionHistory) add(execution model.JobExecution) {
+	if len(h.entries) < jobHistorySize {
+		h.entries = append(h.entries, execution)
+		return
+	}
+
+	h.entries[h.next] = execution
+	h.next = (h.next + 1) % jobHistorySize
+}
+
+// list returns the recorded executions, newest first
+func (h *executionHistory) list() []model.JobExecution {
+	executions := make([]model.JobExecution, 0, len(h.entries))
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		executions = append(executions, h.entries[(h.next+i)%len(h.entries)])
+	}
+	return executions
+}
+
+type jobStatsKey struct{}
+
+// SetJobStats attaches counters to the current job execution so they show up in
+// its history. It is a no-op when ctx does not belong to a scheduled run.
+func SetJobStats(ctx context.Context, stats map[string]int64) {
+	if collected, ok := ctx.Value(jobStatsKey{}).(*map[string]int64); ok {
+		*collected = stats
+	}
 }
 
 // schedulerService implements SchedulerService interface
@@ -24,16 +84,37 @@ type schedulerService struct {
 	mu      sync.RWMutex
 	logger  *logger.Logger
 	running bool
+	paused  atomic.Bool
 	ctx     context.Context
 	cancel  context.CancelFunc
 	wg      sync.WaitGroup
+	// dependents maps each job name to the jobs that run after it. It is
+	// rebuilt whenever jobs change, so that finishing jobs can read it
+	// without taking mu, which Stop holds while they wind down.
+	dependents atomic.Pointer[map[string][]*scheduledJob]
+	// tasks are the handlers of one-time jobs by task name. They have their
+	// own lock, as jobs are claimed while Stop holds mu.
+	tasks    map[string]*onceTask
+	tasksMu  sync.RWMutex
+	onceJobs repository.OnceJobRepository
+	cfg      config.SchedulerConfig
+	// quietHours are the quiet windows of each job by name, "*" holding
+	// those of jobs without windows of their own
+	quietHours map[string][]config.QuietWindow
 }
 
-// NewSchedulerService creates a new scheduler service
-func NewSchedulerService(logger *logger.Logger) SchedulerService {
+// NewSchedulerService creates a new scheduler service. One-time jobs are
+// queued in onceJobs; they cannot be scheduled when it is nil.
+func NewSchedulerService(onceJobs repository.OnceJobRepository, cfg config.SchedulerConfig, logger *logger.Logger) SchedulerService {
+	quietHours, _ := config.ParseQuietHours(cfg.QuietHours)
+
 	return &schedulerService{
-		jobs:   make(map[string]*scheduledJob),
-		logger: logger,
+		jobs:       make(map[string]*scheduledJob),
+		tasks:      make(map[string]*onceTask),
+		onceJobs:   onceJobs,
+		cfg:        cfg,
+		quietHours: quietHours,
+		logger:     logger,
 	}
 }
 
@@ -46,6 +127,18 @@ func (s *schedulerService) Start(ctx context.Context) error {
 		return nil
 	}
 
+	if cycle := s.dependencyCycle(); cycle != nil {
+		return fmt.Errorf("%w: %s", ErrJobDependencyCycle, strings.Join(cycle, " -> "))
+	}
+
+	for _, job := range s.jobs {
+		for _, name := range job.options.after {
+			if _, exists := s.jobs[name]; !exists {
+				s.logger.Info("Scheduled job runs after a job that is not registered", "name", job.name, "after", name)
+			}
+		}
+	}
+
 	s.ctx, s.cancel = context.WithCancel(ctx)
 	s.running = true
 
@@ -55,6 +148,11 @@ func (s *schedulerService) Start(ctx context.Context) error {
 		s.startJob(job)
 	}
 
+	if s.onceJobs != nil {
+		s.wg.Add(1)
+		go s.pollOnceJobs(s.ctx)
+	}
+
 	s.logger.Info("Scheduler service started", "jobs_count", len(s.jobs))
 
 	return nil
@@ -71,12 +169,6 @@ func (s *schedulerService) Stop() error {
 
 	s.logger.Info("Stopping scheduler service")
 
-	for _, job := range s.jobs {
-		if job.ticker != nil {
-			job.ticker.Stop()
-		}
-	}
-
 	s.cancel()
 	s.wg.Wait()
 
@@ -92,31 +184,71 @@ func (s *schedulerService) IsRunning() bool {
 	return s.running
 }
 
-// AddJob adds a new scheduled job
-func (s *schedulerService) AddJob(name string, interval time.Duration, job func(context.Context) error) {
+// AddJob adds a new scheduled job. A job added while the scheduler runs
+// whose dependencies would form a cycle is added without them.
+func (s *schedulerService) AddJob(name string, interval time.Duration, job func(context.Context) error, opts ...JobOption) {
+	options := defaultJobOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Stop existing job if it exists
 	if existingJob, exists := s.jobs[name]; exists {
-		if existingJob.ticker != nil {
-			existingJob.ticker.Stop()
+		if existingJob.cancel != nil {
+			existingJob.cancel()
 		}
 	}
 
+	quietHours, exists := s.quietHours[name]
+	if !exists {
+		quietHours = s.quietHours["*"]
+	}
+
 	// Create new job
 	scheduledJob := &scheduledJob{
-		name:     name,
-		interval: interval,
-		job:      job,
+		name:       name,
+		interval:   interval,
+		job:        job,
+		options:    options,
+		quietHours: quietHours,
 		status: model.JobStatus{
-			Name:     name,
-			Interval: interval,
+			Name:         name,
+			Interval:     interval,
+			Enabled:      true,
+			Timeout:      options.timeout,
+			MaxRetries:   options.maxRetries,
+			RetryBackoff: options.retryBackoff,
+			Mode:         options.mode,
+			Jitter:       options.jitter,
+			After:        options.after,
+			Queued:       options.queued,
+			QuietHours:   formatQuietHours(quietHours),
 		},
 	}
 
 	s.jobs[name] = scheduledJob
 
+	if options.queued {
+		s.tasksMu.Lock()
+		s.tasks[name] = &onceTask{name: name, options: options, job: scheduledJob}
+		s.tasksMu.Unlock()
+	}
+
+	if s.running {
+		if cycle := s.dependencyCycle(); cycle != nil {
+			s.logger.Error("Ignoring dependencies of scheduled job that form a cycle",
+				"name", name,
+				"cycle", strings.Join(cycle, " -> "),
+			)
+			scheduledJob.options.after = nil
+			scheduledJob.status.After = nil
+		}
+	}
+	s.rebuildDependents()
+
 	// Start the job if scheduler is running
 	if s.running {
 		s.startJob(scheduledJob)
@@ -125,6 +257,8 @@ func (s *schedulerService) AddJob(name string, interval time.Duration, job func(
 	s.logger.Info("Added scheduled job",
 		"name", name,
 		"interval", interval.String(),
+		"timeout", options.timeout.String(),
+		"max_retries", options.maxRetries,
 	)
 }
 
@@ -134,14 +268,145 @@ func (s *schedulerService) RemoveJob(name string) {
 	defer s.mu.Unlock()
 
 	if job, exists := s.jobs[name]; exists {
-		if job.ticker != nil {
-			job.ticker.Stop()
+		if job.cancel != nil {
+			job.cancel()
 		}
 		delete(s.jobs, name)
+		s.rebuildDependents()
+
+		s.tasksMu.Lock()
+		if task, exists := s.tasks[name]; exists && task.job == job {
+			delete(s.tasks, name)
+		}
+		s.tasksMu.Unlock()
+
 		s.logger.Info("Removed scheduled job", "name", name)
 	}
 }
 
+// Pause suspends execution of all jobs. Tickers keep running so jobs resume on
+// their usual cadence once the scheduler is resumed.
+func (s *schedulerService) Pause() {
+	if s.paused.Swap(true) {
+		return
+	}
+
+	s.logger.Info("Scheduler paused")
+}
+
+// Resume re-enables job execution after a pause
+func (s *schedulerService) Resume() {
+	if !s.paused.Swap(false) {
+		return
+	}
+
+	s.logger.Info("Scheduler resumed")
+}
+
+// IsPaused returns whether job execution is paused
+func (s *schedulerService) IsPaused() bool {
+	return s.paused.Load()
+}
+
+// EnableJob allows a previously disabled job to run again
+func (s *schedulerService) EnableJob(name string) error {
+	return s.setJobEnabled(name, true)
+}
+
+// DisableJob skips a job's scheduled runs until it is enabled again
+func (s *schedulerService) DisableJob(name string) error {
+	return s.setJobEnabled(name, false)
+}
+
+// setJobEnabled toggles the enabled flag of a single job
+func (s *schedulerService) setJobEnabled(name string, enabled bool) error {
+	s.mu.RLock()
+	job, exists := s.jobs[name]
+	s.mu.RUnlock()
+
+	if !exists {
+		return ErrJobNotFound
+	}
+
+	job.mu.Lock()
+	job.status.Enabled = enabled
+	job.mu.Unlock()
+
+	s.logger.Info("Scheduled job toggled", "name", name, "enabled", enabled)
+
+	return nil
+}
+
+// SetJobInterval changes how often a job runs. A running job is rescheduled
+// from now; executions already in progress finish undisturbed.
+func (s *schedulerService) SetJobInterval(name string, interval time.Duration) error {
+	if interval <= 0 {
+		return ErrInvalidJobInterval
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, exists := s.jobs[name]
+	if !exists {
+		return ErrJobNotFound
+	}
+
+	job.mu.Lock()
+	unchanged := job.interval == interval
+	job.interval = interval
+	job.status.Interval = interval
+	job.mu.Unlock()
+
+	if unchanged {
+		return nil
+	}
+
+	if s.running {
+		if job.cancel != nil {
+			job.cancel()
+		}
+		s.startJob(job)
+	}
+
+	s.logger.Info("Scheduled job interval changed", "name", name, "interval", interval.String())
+
+	return nil
+}
+
+// GetJobHistory returns the most recent executions of a job, newest first
+func (s *schedulerService) GetJobHistory(name string) ([]model.JobExecution, error) {
+	s.mu.RLock()
+	job, exists := s.jobs[name]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrJobNotFound
+	}
+
+	job.mu.RLock()
+	defer job.mu.RUnlock()
+
+	return job.history.list(), nil
+}
+
+// shouldSkip reports whether a run should be skipped because the scheduler
+// is paused, or the job is disabled or in its quiet hours
+func (s *schedulerService) shouldSkip(job *scheduledJob) bool {
+	if s.IsPaused() {
+		return true
+	}
+
+	if _, quiet := quietUntil(job.quietHours, time.Now()); quiet {
+		return true
+	}
+
+	job.mu.RLock()
+	defer job.mu.RUnlock()
+
+	return !job.status.Enabled
+}
+
 // GetJobStatus returns the status of all jobs
 func (s *schedulerService) GetJobStatus() map[string]model.JobStatus {
 	s.mu.RLock()
@@ -150,47 +415,296 @@ func (s *schedulerService) GetJobStatus() map[string]model.JobStatus {
 	status := make(map[string]model.JobStatus)
 	for name, job := range s.jobs {
 		job.mu.RLock()
-		status[name] = job.status
+		jobStatus := job.status
 		job.mu.RUnlock()
+
+		jobStatus.Dependents = nil
+		for _, dependent := range s.dependentsOf(name) {
+			jobStatus.Dependents = append(jobStatus.Dependents, dependent.name)
+		}
+
+		status[name] = jobStatus
 	}
 
 	return status
 }
 
-// startJob starts a single job
+// dependentsOf returns the jobs that run after name, ordered by name
+func (s *schedulerService) dependentsOf(name string) []*scheduledJob {
+	dependents := s.dependents.Load()
+	if dependents == nil {
+		return nil
+	}
+
+	return (*dependents)[name]
+}
+
+// rebuildDependents recomputes which jobs run after which; s.mu must be held
+func (s *schedulerService) rebuildDependents() {
+	dependents := make(map[string][]*scheduledJob)
+	for _, job := range s.jobs {
+		for _, name := range job.options.after {
+			dependents[name] = append(dependents[name], job)
+		}
+	}
+
+	for _, jobs := range dependents {
+		slices.SortFunc(jobs, func(a, b *scheduledJob) int {
+			return strings.Compare(a.name, b.name)
+		})
+	}
+
+	s.dependents.Store(&dependents)
+}
+
+// dependencyCycle returns a chain of jobs that run after each other in a
+// loop, first job repeated last, or nil when there is none. Dependencies on
+// jobs that are not registered are ignored. s.mu must be held.
+func (s *schedulerService) dependencyCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(s.jobs))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+
+		for _, after := range s.jobs[name].options.after {
+			if _, exists := s.jobs[after]; !exists {
+				continue
+			}
+
+			switch state[after] {
+			case visiting:
+				start := slices.Index(path, after)
+				return append(slices.Clone(path[start:]), after)
+			case unvisited:
+				if cycle := visit(after); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// startJob starts a single job. The first run is delayed by the interval plus a
+// random startup jitter so jobs added together do not fire in lockstep.
 func (s *schedulerService) startJob(job *scheduledJob) {
-	job.ticker = time.NewTicker(job.interval)
+	if job.interval <= 0 {
+		if len(job.options.after) == 0 {
+			s.logger.Warn("Not scheduling job with non-positive interval", "name", job.name)
+		}
+		return
+	}
 
-	job.mu.Lock()
-	nextRun := time.Now().Add(job.interval)
-	job.status.NextRun = &nextRun
-	job.mu.Unlock()
+	ctx, cancel := context.WithCancel(s.ctx)
+	job.cancel = cancel
+	interval := job.interval
+
+	nextRun := afterQuietHours(job.quietHours, time.Now().Add(interval+startupJitter(job.options.jitter)))
+	s.setNextRun(job, nextRun)
 
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
-		defer job.ticker.Stop()
+
+		timer := time.NewTimer(time.Until(nextRun))
+		defer timer.Stop()
 
 		s.logger.Info("Started scheduled job",
 			"name", job.name,
-			"interval", job.interval.String(),
+			"interval", interval.String(),
+			"mode", job.options.mode,
 			"next_run", nextRun.Format(time.RFC3339),
 		)
 
 		for {
 			select {
-			case <-s.ctx.Done():
+			case <-ctx.Done():
 				s.logger.Info("Stopping job due to context cancellation", "name", job.name)
 				return
-			case <-job.ticker.C:
-				s.executeJob(job)
+			case <-timer.C:
+				if s.shouldSkip(job) {
+					s.logger.Debug("Skipping scheduled job", "name", job.name)
+				} else {
+					s.runJob(job, "")
+				}
+			}
+
+			// The job was rescheduled or removed while it ran
+			if ctx.Err() != nil {
+				return
 			}
+
+			nextRun = s.nextRunAfter(job, nextRun, time.Now())
+			s.setNextRun(job, nextRun)
+			timer.Reset(time.Until(nextRun))
 		}
 	}()
 }
 
-// executeJob executes a single job run with error handling and metrics
-func (s *schedulerService) executeJob(job *scheduledJob) {
+// nextRunAfter computes when a job runs next. Fixed-rate jobs stay anchored to
+// their original schedule, skipping slots missed during a long run, so they do
+// not drift; fixed-delay jobs wait a full interval after the previous run ends.
+// A run falling in the job's quiet hours is moved to their end, and the
+// schedule carries on from there.
+func (s *schedulerService) nextRunAfter(job *scheduledJob, previous, now time.Time) time.Time {
+	job.mu.RLock()
+	interval := job.interval
+	job.mu.RUnlock()
+
+	if job.options.mode == model.ScheduleModeFixedDelay {
+		return afterQuietHours(job.quietHours, now.Add(interval))
+	}
+
+	next := previous.Add(interval)
+	if next.After(now) {
+		return afterQuietHours(job.quietHours, next)
+	}
+
+	missed := now.Sub(next)/interval + 1
+	return afterQuietHours(job.quietHours, next.Add(missed*interval))
+}
+
+// quietUntil reports whether t falls in one of windows, and if so when that
+// window ends
+func quietUntil(windows []config.QuietWindow, t time.Time) (time.Time, bool) {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := t.Sub(midnight)
+
+	for _, window := range windows {
+		switch {
+		case window.Start < window.End && offset >= window.Start && offset < window.End:
+			return midnight.Add(window.End), true
+		case window.Start > window.End && offset >= window.Start:
+			return midnight.Add(24*time.Hour + window.End), true
+		case window.Start > window.End && offset < window.End:
+			return midnight.Add(window.End), true
+		}
+	}
+
+	return t, false
+}
+
+// afterQuietHours moves t to the end of the quiet window it falls in, and of
+// any window that end falls in in turn
+func afterQuietHours(windows []config.QuietWindow, t time.Time) time.Time {
+	for range len(windows) + 1 {
+		end, quiet := quietUntil(windows, t)
+		if !quiet {
+			return t
+		}
+		t = end
+	}
+
+	return t
+}
+
+// formatQuietHours lists windows as HH:MM-HH:MM
+func formatQuietHours(windows []config.QuietWindow) []string {
+	if len(windows) == 0 {
+		return nil
+	}
+
+	formatted := make([]string, len(windows))
+	for i, window := range windows {
+		formatted[i] = window.String()
+	}
+
+	return formatted
+}
+
+// setNextRun records the next scheduled run in the job status
+func (s *schedulerService) setNextRun(job *scheduledJob, nextRun time.Time) {
+	job.mu.Lock()
+	job.status.NextRun = &nextRun
+	job.mu.Unlock()
+}
+
+// startupJitter returns a random delay in [0, max)
+func startupJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+
+	return rand.N(max)
+}
+
+// runJob executes a job unless it is already running, then triggers the
+// jobs that run after it when it succeeded. triggeredBy names the job whose
+// completion started the run, empty for scheduled runs. Queued jobs are
+// only queued here and run when claimed.
+func (s *schedulerService) runJob(job *scheduledJob, triggeredBy string) {
+	if job.options.queued && s.onceJobs != nil {
+		s.enqueueJob(job, triggeredBy)
+		return
+	}
+
+	if !job.runMu.TryLock() {
+		s.logger.Debug("Skipping scheduled job already running", "name", job.name, "triggered_by", triggeredBy)
+		return
+	}
+
+	err := s.executeJob(job, triggeredBy, func(stats *map[string]int64) (int, error) {
+		return s.runWithRetries(job, stats)
+	})
+	job.runMu.Unlock()
+
+	if err == nil {
+		s.runDependents(job.name)
+	}
+}
+
+// runDependents starts the jobs that run after name completes. Each runs in
+// its own goroutine; disabled jobs are skipped, as are all of them while the
+// scheduler is paused or stopping.
+func (s *schedulerService) runDependents(name string) {
+	for _, dependent := range s.dependentsOf(name) {
+		if s.ctx.Err() != nil || s.shouldSkip(dependent) {
+			continue
+		}
+
+		s.logger.Info("Triggering dependent job", "name", dependent.name, "after", name)
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.runJob(dependent, name)
+		}()
+	}
+}
+
+// executeJob executes a single job run with error handling and metrics and
+// returns the error of its last attempt. run makes the attempts and reports
+// how many it made.
+func (s *schedulerService) executeJob(job *scheduledJob, triggeredBy string, run func(stats *map[string]int64) (int, error)) error {
 	start := time.Now()
 
 	job.mu.Lock()
@@ -202,25 +716,31 @@ func (s *schedulerService) executeJob(job *scheduledJob) {
 	s.logger.Info("Executing scheduled job",
 		"name", job.name,
 		"run_count", runCount,
+		"triggered_by", triggeredBy,
 	)
 
-	// Create a timeout context for the job execution
-	jobCtx, cancel := context.WithTimeout(s.ctx, 5*time.Minute)
-	defer cancel()
-
-	// Execute the job
-	err := job.job(jobCtx)
+	var stats map[string]int64
+	attempts, err := run(&stats)
 	duration := time.Since(start)
 
+	execution := model.JobExecution{
+		StartedAt:   start,
+		Duration:    duration,
+		Success:     err == nil,
+		Attempts:    attempts,
+		TriggeredBy: triggeredBy,
+		Stats:       stats,
+	}
+	if err != nil {
+		execution.Error = err.Error()
+	}
+
 	// Update job status
 	job.mu.Lock()
+	job.history.add(execution)
 	job.status.IsRunning = false
 	job.status.LastRun = &start
 
-	// Calculate next run time
-	nextRun := time.Now().Add(job.interval)
-	job.status.NextRun = &nextRun
-
 	// Update average run time
 	if job.status.AverageRunTime == 0 {
 		job.status.AverageRunTime = duration
@@ -251,4 +771,44 @@ func (s *schedulerService) executeJob(job *scheduledJob) {
 			"run_count", runCount,
 		)
 	}
+
+	return err
+}
+
+// runWithRetries runs the job until it succeeds or its retries are exhausted.
+// Each attempt gets its own timeout; it returns the number of attempts made and the last error.
+func (s *schedulerService) runWithRetries(job *scheduledJob, stats *map[string]int64) (int, error) {
+	backoff := job.options.retryBackoff
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = s.runAttempt(job, stats)
+		if err == nil || attempt >= job.options.maxRetries {
+			return attempt + 1, err
+		}
+
+		s.logger.Warn("Scheduled job attempt failed, retrying",
+			"name", job.name,
+			"attempt", attempt+1,
+			"backoff", backoff.String(),
+			"error", err.Error(),
+		)
+
+		select {
+		case <-s.ctx.Done():
+			return attempt + 1, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// runAttempt executes a single attempt of the job bounded by its timeout
+func (s *schedulerService) runAttempt(job *scheduledJob, stats *map[string]int64) error {
+	jobCtx, cancel := context.WithTimeout(s.ctx, job.options.timeout)
+	defer cancel()
+
+	jobCtx = context.WithValue(jobCtx, jobStatsKey{}, stats)
+
+	return job.job(jobCtx)
 }
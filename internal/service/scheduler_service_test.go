@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
 	"github.com/amirzre/news-feed-system/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -26,7 +27,7 @@ func (suite *SchedulerServiceTestSuite) SetupTest() {
 	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
 
 	suite.logger = logger.New(cfg)
-	suite.service = NewSchedulerService(suite.logger)
+	suite.service = NewSchedulerService(nil, config.SchedulerConfig{}, suite.logger)
 	suite.ctx, suite.cancel = context.WithCancel(context.Background())
 }
 
@@ -230,6 +231,368 @@ func (suite *SchedulerServiceTestSuite) TestJobExecutionStopsOnContextCancellati
 	assert.True(suite.T(), finalCount <= initialCount+1, "Job should stop executing after scheduler stop")
 }
 
+func (suite *SchedulerServiceTestSuite) TestPauseSkipsExecutionUntilResume() {
+	var executionCount int32
+	job := suite.createMockJob("paused-job", false, &executionCount)
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	suite.service.Pause()
+	assert.True(suite.T(), suite.service.IsPaused())
+
+	suite.service.AddJob("paused-job", 30*time.Millisecond, job)
+
+	time.Sleep(120 * time.Millisecond)
+	assert.Equal(suite.T(), int32(0), atomic.LoadInt32(&executionCount))
+
+	suite.service.Resume()
+	assert.False(suite.T(), suite.service.IsPaused())
+
+	success := suite.waitForJobExecution(&executionCount, 1, 300*time.Millisecond)
+	assert.True(suite.T(), success, "Job should execute after the scheduler is resumed")
+}
+
+func (suite *SchedulerServiceTestSuite) TestDisableJobSkipsExecution() {
+	var executionCount int32
+	job := suite.createMockJob("disabled-job", false, &executionCount)
+
+	suite.service.AddJob("disabled-job", 30*time.Millisecond, job)
+	assert.True(suite.T(), suite.service.GetJobStatus()["disabled-job"].Enabled)
+
+	err := suite.service.DisableJob("disabled-job")
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), suite.service.GetJobStatus()["disabled-job"].Enabled)
+
+	err = suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	time.Sleep(120 * time.Millisecond)
+	assert.Equal(suite.T(), int32(0), atomic.LoadInt32(&executionCount))
+
+	err = suite.service.EnableJob("disabled-job")
+	assert.NoError(suite.T(), err)
+
+	success := suite.waitForJobExecution(&executionCount, 1, 300*time.Millisecond)
+	assert.True(suite.T(), success, "Job should execute after being enabled")
+}
+
+func (suite *SchedulerServiceTestSuite) TestEnableJobNotFound() {
+	err := suite.service.EnableJob("non-existent-job")
+	assert.ErrorIs(suite.T(), err, ErrJobNotFound)
+}
+
+func (suite *SchedulerServiceTestSuite) TestJobHistoryRecordsExecutions() {
+	var executionCount int32
+	job := func(ctx context.Context) error {
+		atomic.AddInt32(&executionCount, 1)
+		SetJobStats(ctx, map[string]int64{"created": 3})
+		return nil
+	}
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	suite.service.AddJob("history-job", 30*time.Millisecond, job)
+
+	success := suite.waitForJobExecution(&executionCount, 2, 300*time.Millisecond)
+	assert.True(suite.T(), success)
+
+	history, err := suite.service.GetJobHistory("history-job")
+	assert.NoError(suite.T(), err)
+	assert.GreaterOrEqual(suite.T(), len(history), 1)
+	assert.True(suite.T(), history[0].Success)
+	assert.Equal(suite.T(), int64(3), history[0].Stats["created"])
+	if len(history) > 1 {
+		assert.True(suite.T(), !history[0].StartedAt.Before(history[1].StartedAt), "history should be newest first")
+	}
+}
+
+func (suite *SchedulerServiceTestSuite) TestGetJobHistoryNotFound() {
+	history, err := suite.service.GetJobHistory("non-existent-job")
+	assert.ErrorIs(suite.T(), err, ErrJobNotFound)
+	assert.Nil(suite.T(), history)
+}
+
+func (suite *SchedulerServiceTestSuite) TestJobRetriesUntilSuccess() {
+	var executionCount int32
+	job := func(ctx context.Context) error {
+		if atomic.AddInt32(&executionCount, 1)%3 != 0 {
+			return errors.New("transient error")
+		}
+		return nil
+	}
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	suite.service.AddJob("retry-job", 50*time.Millisecond, job, WithJobRetries(2), WithJobRetryBackoff(5*time.Millisecond))
+
+	success := suite.waitForJobExecution(&executionCount, 3, 500*time.Millisecond)
+	assert.True(suite.T(), success)
+	time.Sleep(20 * time.Millisecond)
+
+	history, err := suite.service.GetJobHistory("retry-job")
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), history)
+	assert.True(suite.T(), history[len(history)-1].Success)
+	assert.Equal(suite.T(), 3, history[len(history)-1].Attempts)
+
+	status := suite.service.GetJobStatus()["retry-job"]
+	assert.Equal(suite.T(), 2, status.MaxRetries)
+	assert.Equal(suite.T(), int64(0), status.ErrorCount)
+}
+
+func (suite *SchedulerServiceTestSuite) TestJobTimeoutOption() {
+	var executionCount int32
+	job := func(ctx context.Context) error {
+		atomic.AddInt32(&executionCount, 1)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	suite.service.AddJob("short-timeout-job", 30*time.Millisecond, job, WithJobTimeout(10*time.Millisecond))
+	assert.Equal(suite.T(), 10*time.Millisecond, suite.service.GetJobStatus()["short-timeout-job"].Timeout)
+
+	success := suite.waitForJobExecution(&executionCount, 1, 200*time.Millisecond)
+	assert.True(suite.T(), success)
+	time.Sleep(30 * time.Millisecond)
+
+	history, err := suite.service.GetJobHistory("short-timeout-job")
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), history)
+	assert.Contains(suite.T(), history[len(history)-1].Error, context.DeadlineExceeded.Error())
+}
+
+func (suite *SchedulerServiceTestSuite) TestAddJobDefaultOptions() {
+	suite.service.AddJob("default-job", time.Minute, func(ctx context.Context) error { return nil })
+
+	status := suite.service.GetJobStatus()["default-job"]
+	assert.Equal(suite.T(), defaultJobTimeout, status.Timeout)
+	assert.Equal(suite.T(), 0, status.MaxRetries)
+	assert.Equal(suite.T(), defaultJobRetryBackoff, status.RetryBackoff)
+}
+
+func (suite *SchedulerServiceTestSuite) TestJobJitterDelaysFirstRun() {
+	suite.service.AddJob("jitter-job", time.Hour, func(ctx context.Context) error { return nil }, WithJobJitter(time.Minute))
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	status := suite.service.GetJobStatus()["jitter-job"]
+	assert.Equal(suite.T(), time.Minute, status.Jitter)
+	assert.NotNil(suite.T(), status.NextRun)
+	assert.True(suite.T(), !status.NextRun.Before(time.Now().Add(59*time.Minute)))
+	assert.True(suite.T(), status.NextRun.Before(time.Now().Add(61*time.Minute)))
+}
+
+func (suite *SchedulerServiceTestSuite) TestFixedDelayModeWaitsAfterRun() {
+	var executionCount int32
+	job := func(ctx context.Context) error {
+		atomic.AddInt32(&executionCount, 1)
+		time.Sleep(40 * time.Millisecond)
+		return nil
+	}
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	suite.service.AddJob("fixed-delay-job", 20*time.Millisecond, job, WithJobFixedDelay())
+	assert.Equal(suite.T(), model.ScheduleModeFixedDelay, suite.service.GetJobStatus()["fixed-delay-job"].Mode)
+
+	success := suite.waitForJobExecution(&executionCount, 2, 500*time.Millisecond)
+	assert.True(suite.T(), success)
+
+	history, err := suite.service.GetJobHistory("fixed-delay-job")
+	assert.NoError(suite.T(), err)
+	if len(history) >= 2 {
+		gap := history[0].StartedAt.Sub(history[1].StartedAt.Add(history[1].Duration))
+		assert.GreaterOrEqual(suite.T(), gap, 15*time.Millisecond)
+	}
+}
+
+func (suite *SchedulerServiceTestSuite) TestSetJobIntervalReschedulesJob() {
+	var executionCount int32
+	suite.service.AddJob("slow-job", time.Hour, suite.createMockJob("slow-job", false, &executionCount))
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	err = suite.service.SetJobInterval("slow-job", 20*time.Millisecond)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 20*time.Millisecond, suite.service.GetJobStatus()["slow-job"].Interval)
+
+	success := suite.waitForJobExecution(&executionCount, 2, 500*time.Millisecond)
+	assert.True(suite.T(), success)
+}
+
+func (suite *SchedulerServiceTestSuite) TestSetJobIntervalErrors() {
+	suite.service.AddJob("job", time.Hour, func(ctx context.Context) error { return nil })
+
+	assert.ErrorIs(suite.T(), suite.service.SetJobInterval("missing", time.Minute), ErrJobNotFound)
+	assert.ErrorIs(suite.T(), suite.service.SetJobInterval("job", 0), ErrInvalidJobInterval)
+	assert.Equal(suite.T(), time.Hour, suite.service.GetJobStatus()["job"].Interval)
+}
+
+func TestNextRunAfterFixedRateSkipsMissedSlots(t *testing.T) {
+	s := &schedulerService{}
+	job := &scheduledJob{interval: time.Minute, options: defaultJobOptions()}
+	base := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, base.Add(time.Minute), s.nextRunAfter(job, base, base.Add(10*time.Second)))
+	assert.Equal(t, base.Add(4*time.Minute), s.nextRunAfter(job, base, base.Add(3*time.Minute+10*time.Second)))
+
+	job.options.mode = model.ScheduleModeFixedDelay
+	now := base.Add(3*time.Minute + 10*time.Second)
+	assert.Equal(t, now.Add(time.Minute), s.nextRunAfter(job, base, now))
+}
+
+func TestNextRunAfterMovesOutOfQuietHours(t *testing.T) {
+	s := &schedulerService{}
+	job := &scheduledJob{
+		interval:   30 * time.Minute,
+		options:    defaultJobOptions(),
+		quietHours: []config.QuietWindow{{Start: time.Hour, End: 5 * time.Hour}},
+	}
+	base := time.Date(2025, 8, 11, 0, 45, 0, 0, time.UTC)
+
+	assert.Equal(t, base.Add(15*time.Minute).Add(4*time.Hour), s.nextRunAfter(job, base, base.Add(time.Second)))
+
+	fiveAM := time.Date(2025, 8, 11, 5, 0, 0, 0, time.UTC)
+	assert.Equal(t, fiveAM.Add(30*time.Minute), s.nextRunAfter(job, fiveAM, fiveAM.Add(time.Second)))
+}
+
+func TestQuietUntil(t *testing.T) {
+	overnight := []config.QuietWindow{{Start: 22 * time.Hour, End: 2 * time.Hour}}
+	day := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+
+	end, quiet := quietUntil(overnight, day.Add(23*time.Hour))
+	assert.True(t, quiet)
+	assert.Equal(t, day.Add(26*time.Hour), end)
+
+	end, quiet = quietUntil(overnight, day.Add(time.Hour))
+	assert.True(t, quiet)
+	assert.Equal(t, day.Add(2*time.Hour), end)
+
+	_, quiet = quietUntil(overnight, day.Add(2*time.Hour))
+	assert.False(t, quiet)
+	_, quiet = quietUntil(overnight, day.Add(12*time.Hour))
+	assert.False(t, quiet)
+}
+
+func TestAfterQuietHoursFollowsAdjacentWindows(t *testing.T) {
+	windows := []config.QuietWindow{{Start: 3 * time.Hour, End: 4 * time.Hour}, {Start: time.Hour, End: 3 * time.Hour}}
+	day := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, day.Add(4*time.Hour), afterQuietHours(windows, day.Add(2*time.Hour)))
+	assert.Equal(t, day.Add(30*time.Minute), afterQuietHours(windows, day.Add(30*time.Minute)))
+}
+
+func TestAddJobQuietHoursFromConfig(t *testing.T) {
+	s := NewSchedulerService(nil, config.SchedulerConfig{
+		QuietHours: []string{"top-headlines=01:00-05:00,12:00-13:00", "*=02:00-03:00"},
+	}, logger.New(&config.Config{App: config.AppConfig{LogLevel: "debug"}}))
+
+	s.AddJob("top-headlines", time.Hour, func(context.Context) error { return nil })
+	s.AddJob("stats-rollup", time.Hour, func(context.Context) error { return nil })
+
+	status := s.GetJobStatus()
+	assert.Equal(t, []string{"01:00-05:00", "12:00-13:00"}, status["top-headlines"].QuietHours)
+	assert.Equal(t, []string{"02:00-03:00"}, status["stats-rollup"].QuietHours)
+}
+
+func TestExecutionHistoryWrapsAround(t *testing.T) {
+	var history executionHistory
+	base := time.Now()
+	for i := 0; i < jobHistorySize+5; i++ {
+		history.add(model.JobExecution{StartedAt: base.Add(time.Duration(i) * time.Second)})
+	}
+
+	executions := history.list()
+	assert.Len(t, executions, jobHistorySize)
+	assert.Equal(t, base.Add(time.Duration(jobHistorySize+4)*time.Second), executions[0].StartedAt)
+	assert.Equal(t, base.Add(5*time.Second), executions[jobHistorySize-1].StartedAt)
+}
+
+func (suite *SchedulerServiceTestSuite) TestJobRunsAfterDependency() {
+	var parentCount, childCount int32
+	suite.service.AddJob("parent", 50*time.Millisecond, suite.createMockJob("parent", false, &parentCount))
+	suite.service.AddJob("child", 0, suite.createMockJob("child", false, &childCount), WithJobAfter("parent"))
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	assert.True(suite.T(), suite.waitForJobExecution(&childCount, 1, 300*time.Millisecond), "Child job should run after its parent")
+
+	_ = suite.service.Stop()
+
+	history, err := suite.service.GetJobHistory("child")
+	assert.NoError(suite.T(), err)
+	if assert.NotEmpty(suite.T(), history) {
+		assert.Equal(suite.T(), "parent", history[0].TriggeredBy)
+	}
+}
+
+func (suite *SchedulerServiceTestSuite) TestFailedJobDoesNotTriggerDependents() {
+	var parentCount, childCount int32
+	suite.service.AddJob("parent", 20*time.Millisecond, suite.createMockJob("parent", true, &parentCount))
+	suite.service.AddJob("child", 0, suite.createMockJob("child", false, &childCount), WithJobAfter("parent"))
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	assert.True(suite.T(), suite.waitForJobExecution(&parentCount, 2, 300*time.Millisecond))
+	assert.Equal(suite.T(), int32(0), atomic.LoadInt32(&childCount))
+}
+
+func (suite *SchedulerServiceTestSuite) TestStartRejectsDependencyCycle() {
+	var count int32
+	suite.service.AddJob("a", time.Hour, suite.createMockJob("a", false, &count), WithJobAfter("c"))
+	suite.service.AddJob("b", time.Hour, suite.createMockJob("b", false, &count), WithJobAfter("a"))
+	suite.service.AddJob("c", time.Hour, suite.createMockJob("c", false, &count), WithJobAfter("b", "missing"))
+
+	err := suite.service.Start(suite.ctx)
+
+	assert.ErrorIs(suite.T(), err, ErrJobDependencyCycle)
+	assert.Contains(suite.T(), err.Error(), "a -> c -> b -> a")
+	assert.False(suite.T(), suite.service.IsRunning())
+}
+
+func (suite *SchedulerServiceTestSuite) TestAddJobDropsCyclicDependenciesWhenRunning() {
+	var count int32
+	suite.service.AddJob("a", time.Hour, suite.createMockJob("a", false, &count), WithJobAfter("b"))
+
+	err := suite.service.Start(suite.ctx)
+	assert.NoError(suite.T(), err)
+
+	suite.service.AddJob("b", time.Hour, suite.createMockJob("b", false, &count), WithJobAfter("a"))
+
+	status := suite.service.GetJobStatus()
+	assert.Empty(suite.T(), status["b"].After)
+	assert.Equal(suite.T(), []string{"b"}, status["a"].After)
+}
+
+func (suite *SchedulerServiceTestSuite) TestGetJobStatusListsDependencies() {
+	var count int32
+	suite.service.AddJob("aggregate", time.Hour, suite.createMockJob("aggregate", false, &count))
+	suite.service.AddJob("enrich", time.Hour, suite.createMockJob("enrich", false, &count), WithJobAfter("aggregate"))
+	suite.service.AddJob("refresh", time.Hour, suite.createMockJob("refresh", false, &count), WithJobAfter("enrich"))
+	suite.service.AddJob("index", time.Hour, suite.createMockJob("index", false, &count), WithJobAfter("aggregate"))
+
+	status := suite.service.GetJobStatus()
+	assert.Equal(suite.T(), []string{"enrich", "index"}, status["aggregate"].Dependents)
+	assert.Equal(suite.T(), []string{"aggregate"}, status["enrich"].After)
+	assert.Equal(suite.T(), []string{"refresh"}, status["enrich"].Dependents)
+	assert.Empty(suite.T(), status["refresh"].Dependents)
+
+	// Removing a job drops it from the dependents of others
+	suite.service.RemoveJob("index")
+	assert.Equal(suite.T(), []string{"enrich"}, suite.service.GetJobStatus()["aggregate"].Dependents)
+}
+
 func (suite *SchedulerServiceTestSuite) TestGetJobStatusEmpty() {
 	status := suite.service.GetJobStatus()
 	assert.Empty(suite.T(), status)
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// defaultSensitiveKeywords is used when no keyword list is configured
+var defaultSensitiveKeywords = []string{
+	"nsfw",
+	"porn",
+	"pornography",
+	"nude",
+	"nudity",
+	"explicit",
+	"gore",
+	"graphic content",
+	"beheading",
+	"suicide",
+	"self-harm",
+	"sexual assault",
+}
+
+// keywordClassifier flags text containing any keyword as a whole word
+type keywordClassifier struct {
+	pattern *regexp.Regexp
+}
+
+// httpClassifier asks an external endpoint and falls back to keywords when it fails
+type httpClassifier struct {
+	url        string
+	httpClient *http.Client
+	fallback   SensitivityClassifier
+	logger     *logger.Logger
+}
+
+// NewSensitivityClassifier creates the classifier used to flag sensitive
+// posts. Keywords are matched case-insensitively on word boundaries; when an
+// endpoint URL is configured it is consulted first.
+func NewSensitivityClassifier(cfg config.ClassifierConfig, logger *logger.Logger) SensitivityClassifier {
+	keywords := cfg.Keywords
+	if len(keywords) == 0 {
+		keywords = defaultSensitiveKeywords
+	}
+
+	classifier := newKeywordClassifier(keywords)
+	if cfg.URL == "" {
+		return classifier
+	}
+
+	return &httpClassifier{
+		url: cfg.URL,
+		httpClient: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+		fallback: classifier,
+		logger:   logger,
+	}
+}
+
+// newKeywordClassifier builds a single alternation pattern from the keywords
+func newKeywordClassifier(keywords []string) *keywordClassifier {
+	quoted := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			quoted = append(quoted, regexp.QuoteMeta(keyword))
+		}
+	}
+
+	if len(quoted) == 0 {
+		return &keywordClassifier{}
+	}
+
+	return &keywordClassifier{
+		pattern: regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`),
+	}
+}
+
+// Classify reports whether any keyword appears in the title, description or content
+func (c *keywordClassifier) Classify(_ context.Context, input *model.ClassificationInput) (bool, error) {
+	if c.pattern == nil {
+		return false, nil
+	}
+
+	if c.pattern.MatchString(input.Title) {
+		return true, nil
+	}
+	if input.Description != nil && c.pattern.MatchString(*input.Description) {
+		return true, nil
+	}
+	if input.Content != nil && c.pattern.MatchString(*input.Content) {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Classify posts the input to the endpoint, using the keyword classifier
+// when the endpoint is unreachable or answers with an error
+func (c *httpClassifier) Classify(ctx context.Context, input *model.ClassificationInput) (bool, error) {
+	start := time.Now()
+
+	sensitive, err := c.classifyRemote(ctx, input)
+	if err != nil {
+		c.logger.Warn("Sensitivity classifier endpoint failed, falling back to keywords", "error", err.Error())
+		c.logger.LogServiceOperation("classifier", "classify", false, time.Since(start).Milliseconds())
+		return c.fallback.Classify(ctx, input)
+	}
+
+	c.logger.LogServiceOperation("classifier", "classify", true, time.Since(start).Milliseconds())
+
+	return sensitive, nil
+}
+
+func (c *httpClassifier) classifyRemote(ctx context.Context, input *model.ClassificationInput) (bool, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode classifier request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create classifier request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("classifier request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("classifier returned status %d", resp.StatusCode)
+	}
+
+	var result model.ClassificationResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode classifier response: %w", err)
+	}
+
+	return result.Sensitive, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// SensitivityClassifierTestSuite defines the test suite for SensitivityClassifier
+type SensitivityClassifierTestSuite struct {
+	suite.Suite
+	logger *logger.Logger
+	ctx    context.Context
+}
+
+func (suite *SensitivityClassifierTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.logger = logger.New(cfg)
+	suite.ctx = context.Background()
+}
+
+func (suite *SensitivityClassifierTestSuite) TestKeywordsMatchWholeWords() {
+	classifier := NewSensitivityClassifier(config.ClassifierConfig{Keywords: []string{"gore", "graphic content"}}, suite.logger)
+
+	sensitive, err := classifier.Classify(suite.ctx, &model.ClassificationInput{Title: "Viewers warned of GRAPHIC CONTENT in new footage"})
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), sensitive)
+
+	sensitive, err = classifier.Classify(suite.ctx, &model.ClassificationInput{Title: "Leaked video shows gore from the attack"})
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), sensitive)
+
+	sensitive, err = classifier.Classify(suite.ctx, &model.ClassificationInput{Title: "Singapore gorges on durian season"})
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), sensitive)
+}
+
+func (suite *SensitivityClassifierTestSuite) TestKeywordsCheckDescriptionAndContent() {
+	classifier := NewSensitivityClassifier(config.ClassifierConfig{}, suite.logger)
+	content := "The report describes self-harm among teenagers."
+
+	sensitive, err := classifier.Classify(suite.ctx, &model.ClassificationInput{Title: "Health report", Content: &content})
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), sensitive)
+}
+
+func (suite *SensitivityClassifierTestSuite) TestEndpointDecides() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input model.ClassificationInput
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		_ = json.NewEncoder(w).Encode(model.ClassificationResult{Sensitive: input.Title == "flag me"})
+	}))
+	defer server.Close()
+
+	classifier := NewSensitivityClassifier(config.ClassifierConfig{URL: server.URL, Timeout: time.Second}, suite.logger)
+
+	sensitive, err := classifier.Classify(suite.ctx, &model.ClassificationInput{Title: "flag me"})
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), sensitive)
+
+	// The endpoint's answer wins over a keyword match
+	sensitive, err = classifier.Classify(suite.ctx, &model.ClassificationInput{Title: "nsfw"})
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), sensitive)
+}
+
+func (suite *SensitivityClassifierTestSuite) TestEndpointFailureFallsBackToKeywords() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	classifier := NewSensitivityClassifier(config.ClassifierConfig{URL: server.URL, Timeout: time.Second}, suite.logger)
+
+	sensitive, err := classifier.Classify(suite.ctx, &model.ClassificationInput{Title: "NSFW clip goes viral"})
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), sensitive)
+}
+
+func TestSensitivityClassifierTestSuite(t *testing.T) {
+	suite.Run(t, new(SensitivityClassifierTestSuite))
+}
@@ -14,26 +14,37 @@ import (
 type PostService interface {
 	CreatePost(ctx context.Context, req *model.CreatePostParams) (*model.Post, error)
 	PostExists(ctx context.Context, url string) (bool, error)
-	GetPostByID(ctx context.Context, id int64) (*model.Post, error)
+	GetPostByID(ctx context.Context, id int64, viewer string) (*model.Post, error)
+	GetPostByURL(ctx context.Context, url string) (*model.Post, error)
 	ListPosts(ctx context.Context, req *model.PostListParams) (*model.PostListResponse, error)
+	DiscoverPosts(ctx context.Context, req *model.DiscoverPostsParams) ([]model.Post, error)
 	UpdatePost(ctx context.Context, id int64, req *model.UpdatePostParams) (*model.Post, error)
 	DeletePost(ctx context.Context, id int64) error
+	PublishPost(ctx context.Context, id int64) (*model.Post, error)
+	HidePost(ctx context.Context, id int64) (*model.Post, error)
 	CreatePostFromNewsAPI(ctx context.Context, article *model.NewsAPIArticleParams) (*model.Post, error)
+	PreviewPostFromNewsAPI(ctx context.Context, article *model.NewsAPIArticleParams) (*model.ArticlePreview, error)
+	RebuildURLFilter(ctx context.Context) (int, error)
+	NormalizePostURLs(ctx context.Context, batchSize int) (*model.URLNormalizationResult, error)
+	BulkUpdatePosts(ctx context.Context, req *model.BulkUpdatePostsParams) (*model.BulkPostsResult, error)
+	BulkDeletePosts(ctx context.Context, req *model.BulkDeletePostsParams) (*model.BulkPostsResult, error)
 }
 
 // NewsService defines the contract for news business operations
 type NewsService interface {
 	GetTopHeadlines(ctx context.Context, req *model.NewsParams) (*model.NewsAPIResponse, error)
 	GetEverything(ctx context.Context, req *model.NewsParams) (*model.NewsAPIResponse, error)
-	GetNewsByCategory(ctx context.Context, category string, pageSize int) (*model.NewsAPIResponse, error)
+	GetNewsByCategory(ctx context.Context, category, country string, pageSize int) (*model.NewsAPIResponse, error)
 	GetNewsBySources(ctx context.Context, sources []string, pageSize int) (*model.NewsAPIResponse, error)
+	GetSources(ctx context.Context, req *model.NewsSourcesParams) (*model.NewsSourcesResponse, error)
+	SetAPIKey(apiKey string)
 }
 
 // AggregatorService defines the contract for aggregator business operations
 type AggregatorService interface {
 	AggregateTopHeadlines(ctx context.Context) (*model.AggregationResponse, error)
-	AggregateByCategories(ctx context.Context, categories []string) (*model.AggregationResponse, error)
-	AggregateBySources(ctx context.Context, sources []string) (*model.AggregationResponse, error)
+	AggregateByCategories(ctx context.Context, categories, countries []string, query model.AggregationQuery) (*model.AggregationResponse, error)
+	AggregateBySources(ctx context.Context, sources []string, query model.AggregationQuery) (*model.AggregationResponse, error)
 	AggregateAll(ctx context.Context) (*model.AggregationResponse, error)
 }
 
@@ -42,30 +53,256 @@ type SchedulerService interface {
 	Start(ctx context.Context) error
 	Stop() error
 	IsRunning() bool
-	AddJob(name string, interval time.Duration, job func(context.Context) error)
+	AddJob(name string, interval time.Duration, job func(context.Context) error, opts ...JobOption)
 	RemoveJob(name string)
 	GetJobStatus() map[string]model.JobStatus
+	GetJobHistory(name string) ([]model.JobExecution, error)
+	Pause()
+	Resume()
+	IsPaused() bool
+	EnableJob(name string) error
+	DisableJob(name string) error
+	SetJobInterval(name string, interval time.Duration) error
+	RegisterTask(name string, task OnceTaskFunc, opts ...JobOption)
+	ScheduleOnce(ctx context.Context, name string, at time.Time, payload any) (*model.OnceJob, error)
+	CancelOnce(ctx context.Context, id string) error
+	ListOnceJobs(ctx context.Context) ([]model.OnceJob, error)
+	ListDeadOnceJobs(ctx context.Context) ([]model.DeadOnceJob, error)
+	RetryDeadOnceJob(ctx context.Context, id string) (*model.OnceJob, error)
+	DeleteDeadOnceJob(ctx context.Context, id string) error
+}
+
+// FeedRankingService defines the contract for ranked feed operations
+type FeedRankingService interface {
+	GetRankedFeed(ctx context.Context, req *model.RankedFeedParams) (*model.RankedFeedResponse, error)
+	GetWeights() model.RankingWeights
+	UpdateWeights(weights model.RankingWeights) error
+}
+
+// ExperimentService defines the contract for feed ranking experiment operations
+type ExperimentService interface {
+	UpsertExperiment(ctx context.Context, name string, req *model.UpsertExperimentParams) (*model.Experiment, error)
+	ListExperiments(ctx context.Context) ([]model.Experiment, error)
+	DeleteExperiment(ctx context.Context, name string) error
+	AssignFeedVariant(ctx context.Context, userID string) (*model.ExperimentAssignment, *model.RankingWeights, error)
+	RecordEvent(ctx context.Context, event *model.ExperimentEvent) error
+	GetResults(ctx context.Context, name string) (*model.ExperimentResults, error)
+}
+
+// AnalyticsService defines the contract for click-through, search and post analytics operations
+type AnalyticsService interface {
+	RecordClick(ctx context.Context, postID int64, referrer, userAgent string) (*model.Post, error)
+	GetCTRStats(ctx context.Context, req *model.CTRParams) (*model.CTRResponse, error)
+	RecordSearch(ctx context.Context, term string, resultCount int64, latency time.Duration) error
+	GetSearchAnalytics(ctx context.Context, req *model.SearchAnalyticsParams) (*model.SearchAnalyticsResponse, error)
+	GetPostStats(ctx context.Context, req *model.PostStatsParams) (*model.PostStatsResponse, error)
+}
+
+// ContentFetcherService defines the contract for article content extraction
+type ContentFetcherService interface {
+	FetchContent(ctx context.Context, url string) (string, error)
+	PreviewArticle(ctx context.Context, url string) (*model.ArticlePreview, error)
+	EnrichPendingPosts(ctx context.Context) (*model.ContentEnrichmentResult, error)
+	StartReprocess(ctx context.Context, params *model.ReprocessParams) (*model.ReprocessRun, error)
+	GetReprocessRun(id string) (*model.ReprocessRun, error)
+}
+
+// ArticleFilterService defines the contract for spam and quality filtering
+type ArticleFilterService interface {
+	Check(ctx context.Context, article *model.NewsAPIArticleParams) *model.FilterRejection
+	ListQuarantined(ctx context.Context, req *model.QuarantineListParams) (*model.QuarantineListResponse, error)
+}
+
+// DeadLetterService defines the contract for articles that failed to persist
+type DeadLetterService interface {
+	Record(ctx context.Context, article *model.NewsAPIArticleParams, cause error)
+	ListDeadLetters(ctx context.Context, req *model.DeadLetterListParams) (*model.DeadLetterListResponse, error)
+	RetryDeadLetter(ctx context.Context, id int64) (*model.DeadLetterRetryResponse, error)
+	DiscardDeadLetter(ctx context.Context, id int64) error
+}
+
+// CommentService defines the contract for post comment operations
+type CommentService interface {
+	CreateComment(ctx context.Context, postID int64, req *model.CreateCommentParams) (*model.Comment, error)
+	ListComments(ctx context.Context, req *model.CommentListParams) (*model.CommentListResponse, error)
+	DeleteComment(ctx context.Context, postID, id int64) error
+}
+
+// ReactionService defines the contract for post reaction operations
+type ReactionService interface {
+	React(ctx context.Context, postID int64, userID string, req *model.ReactParams) (*model.ReactionSummary, error)
+	RemoveReaction(ctx context.Context, postID int64, userID string) error
+}
+
+// SyndicationService defines the contract for RSS/Atom feed and sitemap generation
+type SyndicationService interface {
+	Feed(ctx context.Context, category string, format model.FeedFormat) (*model.SyndicationDocument, error)
+	Sitemap(ctx context.Context, page int) (*model.SyndicationDocument, error)
+	Refresh(ctx context.Context) (*model.SyndicationRefreshResult, error)
+}
+
+// TopicService defines the contract for clustering posts covering the same story
+type TopicService interface {
+	ClusterPendingPosts(ctx context.Context) (*model.TopicClusteringResult, error)
+	ListTopics(ctx context.Context, req *model.TopicListParams) (*model.TopicListResponse, error)
+}
+
+// StatsService defines the contract for the top sources and categories,
+// ranked from hourly activity totals maintained by a scheduled rollup
+type StatsService interface {
+	RollupActivity(ctx context.Context) (*model.ActivityRollupResult, error)
+	GetTopStats(ctx context.Context, req *model.TopStatsParams) (*model.TopStatsResponse, error)
+	RefreshViews(ctx context.Context) error
+}
+
+// ChangeService defines the contract for the post change feed polled by
+// syncing clients
+type ChangeService interface {
+	ListPostChanges(ctx context.Context, req *model.PostChangesParams) (*model.PostChangesResponse, error)
+	PruneDeletions(ctx context.Context) (int64, error)
+}
+
+// PartitionService defines the contract for maintaining the monthly
+// partitions of the posts table
+type PartitionService interface {
+	MaintainPostPartitions(ctx context.Context) (int, error)
+}
+
+// TenantService defines the contract for tenant resolution and management
+type TenantService interface {
+	Resolve(ctx context.Context, id string) (*model.Tenant, error)
+	ActiveTenants(ctx context.Context) ([]model.Tenant, error)
+	ListTenants(ctx context.Context) ([]model.Tenant, error)
+	UpsertTenant(ctx context.Context, id string, req *model.UpsertTenantParams) (*model.Tenant, error)
+	ReserveNewsAPIRequest(ctx context.Context) (string, error)
+}
+
+// SourceRuleService defines the contract for the admin-managed source
+// blocklist and allowlist
+type SourceRuleService interface {
+	ListRules(ctx context.Context) ([]model.SourceRule, error)
+	SaveRule(ctx context.Context, req *model.SaveSourceRuleParams) (*model.SaveSourceRuleResult, error)
+	DeleteRule(ctx context.Context, id int64) error
+	Check(ctx context.Context, articleURL, source string) (*model.FilterRejection, error)
+}
+
+// PinService defines the contract for editorial pinning of posts
+type PinService interface {
+	PinPost(ctx context.Context, id int64, req *model.PinPostParams) (*model.PostPin, error)
+	UnpinPost(ctx context.Context, id int64) error
+	PinnedPosts(ctx context.Context, safeMode bool) ([]model.Post, error)
+}
+
+// SensitivityClassifier decides whether a post's text is sensitive
+type SensitivityClassifier interface {
+	Classify(ctx context.Context, input *model.ClassificationInput) (bool, error)
+}
+
+// ImageSanitizer cleans the image URLs of ingested articles
+type ImageSanitizer interface {
+	Sanitize(ctx context.Context, imageURL *string) *string
+}
+
+// ConfigService defines the contract for runtime configuration reloads
+type ConfigService interface {
+	Reload(ctx context.Context) (*model.ConfigReloadResult, error)
+	OnReload(apply ConfigApplier)
+}
+
+// ReadOnlyService defines the contract for switching the API in and out of
+// read-only mode
+type ReadOnlyService interface {
+	Enabled() bool
+	Status() *model.ReadOnlyStatus
+	Enable(reason string) *model.ReadOnlyStatus
+	Disable() *model.ReadOnlyStatus
 }
 
 // Service holds all service implementations
 type Service struct {
-	Post       PostService
-	News       NewsService
-	Aggregator AggregatorService
-	Scheduler  SchedulerService
+	Post        PostService
+	News        NewsService
+	Aggregator  AggregatorService
+	Scheduler   SchedulerService
+	FeedRanking FeedRankingService
+	Experiment  ExperimentService
+	Analytics   AnalyticsService
+	Content     ContentFetcherService
+	Filter      ArticleFilterService
+	DeadLetter  DeadLetterService
+	Comment     CommentService
+	Reaction    ReactionService
+	Syndication SyndicationService
+	Topic       TopicService
+	Stats       StatsService
+	Partition   PartitionService
+	Tenant      TenantService
+	SourceRule  SourceRuleService
+	Change      ChangeService
+	Pin         PinService
+	Config      ConfigService
+	ReadOnly    ReadOnlyService
 }
 
 // New creates a new service instance with all entity services
 func New(repo *repository.Repository, logger *logger.Logger, cfg *config.Config) *Service {
-	postSvc := NewPostService(repo.Post, logger)
-	newsSvc := NewNewsService(cfg, logger)
-	aggregatorSvc := NewAggregatorService(newsSvc, postSvc, logger)
-	schedulerSvc := NewSchedulerService(logger)
+	tenantSvc := NewTenantService(repo.Tenant, cfg.Tenant, logger)
+	classifier := NewSensitivityClassifier(cfg.Classifier, logger)
+	sourceRuleSvc := NewSourceRuleService(repo.SourceRule, repo.Post, logger)
+	images := NewImageSanitizer(cfg.Image, logger)
+	pinSvc := NewPinService(repo.Pin, repo.Post, cfg.Pins, logger)
+	postSvc := NewPostService(repo.Post, repo.Reaction, repo.Tx, classifier, sourceRuleSvc, images, pinSvc, cfg.NewsAPI.UpsertArticles, cfg.Search, logger)
+	newsSvc := NewNewsService(cfg, tenantSvc, logger)
+	filterSvc := NewArticleFilterService(repo.Quarantine, sourceRuleSvc, cfg.Filter, logger)
+	deadLetterSvc := NewDeadLetterService(repo.DeadLetter, postSvc, logger)
+	aggregatorSvc := NewAggregatorService(newsSvc, postSvc, filterSvc, deadLetterSvc, repo.Watermark, cfg.NewsAPI.Countries, cfg.Ingest, logger)
+	schedulerSvc := NewSchedulerService(repo.OnceJob, cfg.Scheduler, logger)
+	experimentSvc := NewExperimentService(repo.Experiment, logger)
+	feedRankingSvc := NewFeedRankingService(repo.Post, experimentSvc, pinSvc, logger)
+	analyticsSvc := NewAnalyticsService(repo.Post, repo.Click, repo.Search, logger)
+	contentSvc := NewContentFetcherService(repo.Post, postSvc, classifier, cfg.ContentFetch, logger)
+	commentSvc := NewCommentService(repo.Comment, repo.Post, repo.Tx, logger)
+	reactionSvc := NewReactionService(repo.Reaction, repo.Post, repo.Tx, logger)
+	syndicationSvc := NewSyndicationService(repo.Post, cfg.Syndication, logger)
+	topicSvc := NewTopicService(repo.Topic, cfg.Topic, logger)
+	statsSvc := NewStatsService(repo.Activity, repo.View, repo.Tx, cfg.Stats, logger)
+	repo.Post.SetViewStaleTolerance(cfg.Stats.ViewStaleTolerance)
+	repo.Post.SetViewerDedupWindow(cfg.Stats.ViewerDedupWindow)
+	partitionSvc := NewPartitionService(repo.Partition, cfg.Partition, logger)
+	changeSvc := NewChangeService(repo.Change, cfg.Changes, logger)
+	readOnlySvc := NewReadOnlyService(schedulerSvc, cfg.Server.ReadOnly, logger)
+
+	configSvc := NewConfigService(cfg, config.Reload, logger)
+	configSvc.OnReload(func(next *config.Config) {
+		logger.SetLevel(next.App.LogLevel)
+		newsSvc.SetAPIKey(next.NewsAPI.APIKey)
+		repo.Post.SetCacheTTL(next.Cache.TTL)
+		repo.Post.SetViewStaleTolerance(next.Stats.ViewStaleTolerance)
+		repo.Post.SetViewerDedupWindow(next.Stats.ViewerDedupWindow)
+	})
 
 	return &Service{
-		Post:       postSvc,
-		News:       newsSvc,
-		Aggregator: aggregatorSvc,
-		Scheduler:  schedulerSvc,
+		Post:        postSvc,
+		News:        newsSvc,
+		Aggregator:  aggregatorSvc,
+		Scheduler:   schedulerSvc,
+		FeedRanking: feedRankingSvc,
+		Experiment:  experimentSvc,
+		Analytics:   analyticsSvc,
+		Content:     contentSvc,
+		Filter:      filterSvc,
+		DeadLetter:  deadLetterSvc,
+		Comment:     commentSvc,
+		Reaction:    reactionSvc,
+		Syndication: syndicationSvc,
+		Topic:       topicSvc,
+		Stats:       statsSvc,
+		Partition:   partitionSvc,
+		Tenant:      tenantSvc,
+		SourceRule:  sourceRuleSvc,
+		Change:      changeSvc,
+		Pin:         pinSvc,
+		Config:      configSvc,
+		ReadOnly:    readOnlySvc,
 	}
 }
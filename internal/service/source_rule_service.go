@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+)
+
+const (
+	// sourceRulesCacheSize bounds how many tenants' rules are kept in memory
+	sourceRulesCacheSize = 1024
+
+	// sourceRulesCacheTTL is how long rules are used before they are read
+	// again, so changes made on another replica apply within that time
+	sourceRulesCacheTTL = time.Minute
+)
+
+var (
+	ErrSourceBlocked      = errors.New("source is blocked")
+	ErrSourceRuleInvalid  = errors.New("source rule value is invalid")
+	ErrSourceRuleNotFound = errors.New("source rule not found")
+)
+
+// sourceRuleSet is a tenant's rules prepared for matching. Domains are
+// lowercase without a leading dot and source names are lowercase.
+type sourceRuleSet struct {
+	blockedDomains []string
+	allowedDomains []string
+	blockedSources map[string]bool
+	allowedSources map[string]bool
+}
+
+// sourceRuleService implements SourceRuleService interface
+type sourceRuleService struct {
+	repo   repository.SourceRuleRepository
+	posts  repository.PostRepository
+	cache  *lru.Cache[string, *sourceRuleSet]
+	logger *logger.Logger
+}
+
+// NewSourceRuleService creates a new source rule service. Blocked rules can
+// hide the stored posts they match through posts.
+func NewSourceRuleService(repo repository.SourceRuleRepository, posts repository.PostRepository, logger *logger.Logger) SourceRuleService {
+	return &sourceRuleService{
+		repo:   repo,
+		posts:  posts,
+		cache:  lru.New[string, *sourceRuleSet](sourceRulesCacheSize, sourceRulesCacheTTL),
+		logger: logger,
+	}
+}
+
+// ListRules returns every rule on both lists
+func (s *sourceRuleService) ListRules(ctx context.Context) ([]model.SourceRule, error) {
+	start := time.Now()
+
+	rules, err := s.repo.ListSourceRules(ctx)
+	s.logger.LogServiceOperation("source_rule", "list", err == nil, time.Since(start).Milliseconds())
+
+	return rules, err
+}
+
+// SaveRule adds a rule or moves the rule for the same domain or source to
+// another list. With HideExisting set, a blocked rule also hides the stored
+// posts it matches.
+func (s *sourceRuleService) SaveRule(ctx context.Context, req *model.SaveSourceRuleParams) (*model.SaveSourceRuleResult, error) {
+	start := time.Now()
+
+	value, ok := normalizeRuleValue(req.Type, req.Value)
+	if !ok {
+		s.logger.LogServiceOperation("source_rule", "save", false, time.Since(start).Milliseconds())
+		return nil, ErrSourceRuleInvalid
+	}
+
+	rule := &model.SourceRule{List: req.List, Type: req.Type, Value: value}
+	if err := s.repo.SaveSourceRule(ctx, rule); err != nil {
+		s.logger.LogServiceOperation("source_rule", "save", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+	s.cache.Delete(tenant.FromContext(ctx))
+
+	result := &model.SaveSourceRuleResult{Rule: *rule}
+	if req.HideExisting && rule.List == model.SourceRuleBlock {
+		filter := model.BulkPostFilter{Source: &rule.Value}
+		if rule.Type == model.SourceRuleDomain {
+			filter = model.BulkPostFilter{Domain: &rule.Value}
+		}
+
+		status := model.PostStatusHidden
+		hidden, err := bulkUpdateInBatches(ctx, s.posts, filter, &model.BulkPostChanges{Status: &status})
+		result.Hidden = hidden
+		if err != nil {
+			s.logger.LogServiceOperation("source_rule", "save", false, time.Since(start).Milliseconds())
+			return result, fmt.Errorf("failed to hide posts of blocked %s: %w", rule.Type, err)
+		}
+
+		s.logger.Info("Hid posts of blocked source", "type", rule.Type, "value", rule.Value, "hidden", hidden)
+	}
+
+	s.logger.LogServiceOperation("source_rule", "save", true, time.Since(start).Milliseconds())
+
+	return result, nil
+}
+
+// DeleteRule removes a rule from its list
+func (s *sourceRuleService) DeleteRule(ctx context.Context, id int64) error {
+	start := time.Now()
+
+	deleted, err := s.repo.DeleteSourceRule(ctx, id)
+	if err != nil {
+		s.logger.LogServiceOperation("source_rule", "delete", false, time.Since(start).Milliseconds())
+		return err
+	}
+	if !deleted {
+		s.logger.LogServiceOperation("source_rule", "delete", false, time.Since(start).Milliseconds())
+		return ErrSourceRuleNotFound
+	}
+	s.cache.Delete(tenant.FromContext(ctx))
+
+	s.logger.LogServiceOperation("source_rule", "delete", true, time.Since(start).Milliseconds())
+
+	return nil
+}
+
+// Check returns why an article from articleURL published by source may not
+// be stored, or nil when the rules allow it. Blocked rules win over allowed
+// ones; once the allowlist has rules, an article must match one of them.
+func (s *sourceRuleService) Check(ctx context.Context, articleURL, source string) (*model.FilterRejection, error) {
+	rules, err := s.rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	host := ""
+	if parsed, err := url.Parse(strings.TrimSpace(articleURL)); err == nil {
+		host = strings.ToLower(parsed.Hostname())
+	}
+	source = strings.TrimSpace(source)
+	name := strings.ToLower(source)
+
+	if domain := matchDomain(host, rules.blockedDomains); domain != "" {
+		return &model.FilterRejection{
+			Rule:   model.FilterRuleSourceList,
+			Reason: fmt.Sprintf("domain %q is blocked", domain),
+		}, nil
+	}
+
+	if rules.blockedSources[name] {
+		return &model.FilterRejection{
+			Rule:   model.FilterRuleSourceList,
+			Reason: fmt.Sprintf("source %q is blocked", source),
+		}, nil
+	}
+
+	if len(rules.allowedDomains) == 0 && len(rules.allowedSources) == 0 {
+		return nil, nil
+	}
+
+	if matchDomain(host, rules.allowedDomains) != "" || rules.allowedSources[name] {
+		return nil, nil
+	}
+
+	return &model.FilterRejection{
+		Rule:   model.FilterRuleSourceList,
+		Reason: fmt.Sprintf("neither domain %q nor source %q is on the allowlist", host, source),
+	}, nil
+}
+
+// rules returns the rules of the tenant in ctx, reading them when they are
+// not cached
+func (s *sourceRuleService) rules(ctx context.Context) (*sourceRuleSet, error) {
+	id := tenant.FromContext(ctx)
+	if cached, ok := s.cache.Get(id); ok {
+		return cached, nil
+	}
+
+	stored, err := s.repo.ListSourceRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load source rules: %w", err)
+	}
+
+	rules := &sourceRuleSet{
+		blockedSources: make(map[string]bool),
+		allowedSources: make(map[string]bool),
+	}
+	for _, rule := range stored {
+		blocked := rule.List == model.SourceRuleBlock
+		switch {
+		case rule.Type == model.SourceRuleDomain && blocked:
+			rules.blockedDomains = append(rules.blockedDomains, rule.Value)
+		case rule.Type == model.SourceRuleDomain:
+			rules.allowedDomains = append(rules.allowedDomains, rule.Value)
+		case blocked:
+			rules.blockedSources[strings.ToLower(rule.Value)] = true
+		default:
+			rules.allowedSources[strings.ToLower(rule.Value)] = true
+		}
+	}
+
+	s.cache.Set(id, rules)
+
+	return rules, nil
+}
+
+// normalizeRuleValue trims a rule's value and lowercases domains, reporting
+// whether the value is usable. Domains are bare hosts such as example.com.
+func normalizeRuleValue(ruleType model.SourceRuleType, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if ruleType != model.SourceRuleDomain {
+		return value, value != ""
+	}
+
+	value = strings.TrimPrefix(strings.ToLower(value), ".")
+	if value == "" || strings.ContainsAny(value, "/:?#@ ") {
+		return "", false
+	}
+
+	return value, true
+}
+
+// matchDomain returns the domain host equals or is a subdomain of, if any
+func matchDomain(host string, domains []string) string {
+	if host == "" {
+		return ""
+	}
+
+	for _, domain := range domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return domain
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockSourceRuleRepository is a mock implementation of SourceRuleRepository
+type MockSourceRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockSourceRuleRepository) ListSourceRules(ctx context.Context) ([]model.SourceRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SourceRule), args.Error(1)
+}
+
+func (m *MockSourceRuleRepository) SaveSourceRule(ctx context.Context, rule *model.SourceRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockSourceRuleRepository) DeleteSourceRule(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// SourceRuleServiceTestSuite defines the test suite for SourceRuleService
+type SourceRuleServiceTestSuite struct {
+	suite.Suite
+	mockRepo  *MockSourceRuleRepository
+	mockPosts *MockPostRepository
+	service   SourceRuleService
+	ctx       context.Context
+}
+
+func (suite *SourceRuleServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockSourceRuleRepository)
+	suite.mockPosts = new(MockPostRepository)
+	suite.service = NewSourceRuleService(suite.mockRepo, suite.mockPosts, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *SourceRuleServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockPosts.AssertExpectations(suite.T())
+}
+
+func (suite *SourceRuleServiceTestSuite) TestCheckBlocklist() {
+	suite.mockRepo.On("ListSourceRules", suite.ctx).Return([]model.SourceRule{
+		{List: model.SourceRuleBlock, Type: model.SourceRuleDomain, Value: "spam.example.com"},
+		{List: model.SourceRuleBlock, Type: model.SourceRuleSource, Value: "Content Farm"},
+	}, nil).Once()
+
+	tests := []struct {
+		name    string
+		url     string
+		source  string
+		blocked bool
+	}{
+		{name: "blocked domain", url: "https://spam.example.com/a", source: "Example", blocked: true},
+		{name: "subdomain of blocked domain", url: "https://News.Spam.example.com/a", source: "Example", blocked: true},
+		{name: "blocked source ignoring case", url: "https://farm.example.org/a", source: "content farm", blocked: true},
+		{name: "parent of blocked domain", url: "https://example.com/a", source: "Example", blocked: false},
+		{name: "domain only sharing a suffix", url: "https://notspam.example.com/a", source: "Example", blocked: false},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			rejection, err := suite.service.Check(suite.ctx, tt.url, tt.source)
+
+			assert.NoError(suite.T(), err)
+			if tt.blocked {
+				assert.NotNil(suite.T(), rejection)
+				assert.Equal(suite.T(), model.FilterRuleSourceList, rejection.Rule)
+			} else {
+				assert.Nil(suite.T(), rejection)
+			}
+		})
+	}
+}
+
+func (suite *SourceRuleServiceTestSuite) TestCheckAllowlist() {
+	suite.mockRepo.On("ListSourceRules", suite.ctx).Return([]model.SourceRule{
+		{List: model.SourceRuleAllow, Type: model.SourceRuleDomain, Value: "bbc.co.uk"},
+		{List: model.SourceRuleAllow, Type: model.SourceRuleSource, Value: "Reuters"},
+		{List: model.SourceRuleBlock, Type: model.SourceRuleDomain, Value: "sport.bbc.co.uk"},
+	}, nil).Once()
+
+	rejection, err := suite.service.Check(suite.ctx, "https://www.bbc.co.uk/news/1", "BBC News")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), rejection)
+
+	rejection, err = suite.service.Check(suite.ctx, "https://www.reuters.com/world/1", "Reuters")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), rejection)
+
+	// Blocked rules win over allowed ones
+	rejection, err = suite.service.Check(suite.ctx, "https://sport.bbc.co.uk/1", "BBC Sport")
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), rejection)
+
+	rejection, err = suite.service.Check(suite.ctx, "https://techcrunch.com/1", "TechCrunch")
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), rejection)
+	assert.Contains(suite.T(), rejection.Reason, "allowlist")
+}
+
+func (suite *SourceRuleServiceTestSuite) TestCheckCachesRulesPerTenant() {
+	acme := tenant.WithTenant(suite.ctx, "acme")
+
+	suite.mockRepo.On("ListSourceRules", suite.ctx).Return([]model.SourceRule{}, nil).Once()
+	suite.mockRepo.On("ListSourceRules", acme).Return([]model.SourceRule{
+		{List: model.SourceRuleBlock, Type: model.SourceRuleSource, Value: "TechCrunch"},
+	}, nil).Once()
+
+	for range 2 {
+		rejection, err := suite.service.Check(suite.ctx, "https://techcrunch.com/1", "TechCrunch")
+		assert.NoError(suite.T(), err)
+		assert.Nil(suite.T(), rejection)
+
+		rejection, err = suite.service.Check(acme, "https://techcrunch.com/1", "TechCrunch")
+		assert.NoError(suite.T(), err)
+		assert.NotNil(suite.T(), rejection)
+	}
+}
+
+func (suite *SourceRuleServiceTestSuite) TestSaveRuleHidesExistingPosts() {
+	domain := "spam.example.com"
+	hidden := model.PostStatusHidden
+	batch := model.BulkPostsBatchParams{BulkPostFilter: model.BulkPostFilter{Domain: &domain}, Limit: bulkBatchSize}
+
+	suite.mockRepo.On("ListSourceRules", suite.ctx).Return([]model.SourceRule{}, nil).Once()
+	suite.mockRepo.On("SaveSourceRule", suite.ctx, mock.MatchedBy(func(rule *model.SourceRule) bool {
+		return rule.List == model.SourceRuleBlock && rule.Type == model.SourceRuleDomain && rule.Value == domain
+	})).Return(nil)
+	suite.mockPosts.On("BulkUpdatePosts", suite.ctx, batch, &model.BulkPostChanges{Status: &hidden}).Return(int64(0), 7, nil)
+
+	// Rules read before the change are dropped from the cache
+	rejection, err := suite.service.Check(suite.ctx, "https://spam.example.com/1", "Spam")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), rejection)
+
+	result, err := suite.service.SaveRule(suite.ctx, &model.SaveSourceRuleParams{
+		List:         model.SourceRuleBlock,
+		Type:         model.SourceRuleDomain,
+		Value:        " .Spam.Example.com ",
+		HideExisting: true,
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), domain, result.Rule.Value)
+	assert.Equal(suite.T(), int64(7), result.Hidden)
+
+	suite.mockRepo.On("ListSourceRules", suite.ctx).Return([]model.SourceRule{result.Rule}, nil).Once()
+	rejection, err = suite.service.Check(suite.ctx, "https://spam.example.com/1", "Spam")
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), rejection)
+}
+
+func (suite *SourceRuleServiceTestSuite) TestSaveRuleInvalidDomain() {
+	for _, value := range []string{"", "https://example.com", "example.com/news"} {
+		result, err := suite.service.SaveRule(suite.ctx, &model.SaveSourceRuleParams{
+			List:  model.SourceRuleBlock,
+			Type:  model.SourceRuleDomain,
+			Value: value,
+		})
+
+		assert.ErrorIs(suite.T(), err, ErrSourceRuleInvalid, value)
+		assert.Nil(suite.T(), result)
+	}
+}
+
+func (suite *SourceRuleServiceTestSuite) TestDeleteRuleNotFound() {
+	suite.mockRepo.On("DeleteSourceRule", suite.ctx, int64(9)).Return(false, nil)
+
+	err := suite.service.DeleteRule(suite.ctx, 9)
+
+	assert.ErrorIs(suite.T(), err, ErrSourceRuleNotFound)
+}
+
+func TestSourceRuleServiceSuite(t *testing.T) {
+	suite.Run(t, new(SourceRuleServiceTestSuite))
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// statsPeriods maps each ranking period to its length
+var statsPeriods = map[string]time.Duration{
+	model.StatsPeriodDay:  24 * time.Hour,
+	model.StatsPeriodWeek: 7 * 24 * time.Hour,
+}
+
+var (
+	ErrStatsMetricInvalid = errors.New("stats metric must be posts, views or clicks")
+	ErrStatsPeriodInvalid = errors.New("stats period must be 24h or 7d")
+)
+
+// statsService implements StatsService interface. Rankings are read from
+// hourly totals maintained by RollupActivity, never from the raw activity.
+type statsService struct {
+	repo   repository.ActivityRepository
+	views  repository.ViewRepository
+	tx     repository.UnitOfWork
+	cfg    config.StatsConfig
+	logger *logger.Logger
+}
+
+// NewStatsService creates a new stats service
+func NewStatsService(repo repository.ActivityRepository, views repository.ViewRepository, tx repository.UnitOfWork, cfg config.StatsConfig, logger *logger.Logger) StatsService {
+	return &statsService{
+		repo:   repo,
+		views:  views,
+		tx:     tx,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// RollupActivity brings the hourly totals of the current tenant up to date.
+// Every hour from the one the previous rollup ended in is recomputed, so
+// activity committed late into that hour is not lost; a first rollup covers
+// the whole retention. Totals past the retention are pruned.
+func (s *statsService) RollupActivity(ctx context.Context) (*model.ActivityRollupResult, error) {
+	start := time.Now()
+	now := start.UTC()
+	horizon := now.Add(-s.cfg.Retention).Truncate(time.Hour)
+
+	result := &model.ActivityRollupResult{}
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		watermark, err := s.repo.GetRollupWatermark(ctx)
+		if err != nil {
+			return err
+		}
+
+		since := horizon
+		if watermark != nil && watermark.After(horizon) {
+			since = watermark.UTC().Truncate(time.Hour)
+		}
+		result.Since = since
+
+		if err := s.repo.RollupPostsAndClicks(ctx, since); err != nil {
+			return err
+		}
+
+		for hour := since; !hour.After(now); hour = hour.Add(time.Hour) {
+			views, err := s.repo.GetHourlyViews(ctx, hour)
+			if err != nil {
+				return err
+			}
+			if err := s.repo.SaveHourlyViews(ctx, hour, views); err != nil {
+				return err
+			}
+			result.Hours++
+		}
+
+		result.Pruned, err = s.repo.PruneActivity(ctx, horizon)
+		if err != nil {
+			return err
+		}
+
+		return s.repo.SaveRollupWatermark(ctx, now)
+	})
+	if err != nil {
+		s.logger.LogServiceOperation("stats", "rollup", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to roll up post activity: %w", err)
+	}
+
+	s.logger.LogServiceOperation("stats", "rollup", true, time.Since(start).Milliseconds())
+
+	return result, nil
+}
+
+// GetTopStats ranks sources and categories by a metric over the hours of the
+// requested period, the current hour included
+func (s *statsService) GetTopStats(ctx context.Context, req *model.TopStatsParams) (*model.TopStatsResponse, error) {
+	start := time.Now()
+
+	if req.Metric == "" {
+		req.Metric = model.StatsMetricPosts
+	}
+	if req.Period == "" {
+		req.Period = model.StatsPeriodDay
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	switch req.Metric {
+	case model.StatsMetricPosts, model.StatsMetricViews, model.StatsMetricClicks:
+	default:
+		s.logger.LogServiceOperation("stats", "top", false, time.Since(start).Milliseconds())
+		return nil, ErrStatsMetricInvalid
+	}
+
+	period, ok := statsPeriods[req.Period]
+	if !ok {
+		s.logger.LogServiceOperation("stats", "top", false, time.Since(start).Milliseconds())
+		return nil, ErrStatsPeriodInvalid
+	}
+
+	since := start.UTC().Truncate(time.Hour).Add(time.Hour - period)
+
+	totals, err := s.repo.TopActivity(ctx, req.Metric, since, req.Limit)
+	if err != nil {
+		s.logger.LogServiceOperation("stats", "top", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to rank post activity: %w", err)
+	}
+
+	rolledUpAt, err := s.repo.GetRollupWatermark(ctx)
+	if err != nil {
+		s.logger.LogServiceOperation("stats", "top", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to get rollup watermark: %w", err)
+	}
+
+	resp := &model.TopStatsResponse{
+		Metric:     req.Metric,
+		Period:     req.Period,
+		Since:      since,
+		RolledUpAt: rolledUpAt,
+		Sources:    []model.TopStat{},
+		Categories: []model.TopStat{},
+	}
+	for _, total := range totals {
+		stat := model.TopStat{Key: total.Key, Value: total.Value}
+		switch total.Dimension {
+		case model.ActivityDimensionSource:
+			resp.Sources = append(resp.Sources, stat)
+		case model.ActivityDimensionCategory:
+			resp.Categories = append(resp.Categories, stat)
+		}
+	}
+
+	s.logger.LogServiceOperation("stats", "top", true, time.Since(start).Milliseconds())
+
+	return resp, nil
+}
+
+// RefreshViews refreshes the materialized views behind post counts and
+// statistics. The views span every tenant, so it runs once per pass.
+func (s *statsService) RefreshViews(ctx context.Context) error {
+	start := time.Now()
+
+	if err := s.views.RefreshPostCounts(ctx); err != nil {
+		s.logger.LogServiceOperation("stats", "refresh_views", false, time.Since(start).Milliseconds())
+		return err
+	}
+
+	s.logger.LogServiceOperation("stats", "refresh_views", true, time.Since(start).Milliseconds())
+
+	return nil
+}
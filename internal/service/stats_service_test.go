@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockActivityRepository is a mock implementation of ActivityRepository
+type MockActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockActivityRepository) GetRollupWatermark(ctx context.Context) (*time.Time, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockActivityRepository) SaveRollupWatermark(ctx context.Context, until time.Time) error {
+	args := m.Called(ctx, until)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) RollupPostsAndClicks(ctx context.Context, since time.Time) error {
+	args := m.Called(ctx, since)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) GetHourlyViews(ctx context.Context, hour time.Time) (map[int64]int64, error) {
+	args := m.Called(ctx, hour)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]int64), args.Error(1)
+}
+
+func (m *MockActivityRepository) SaveHourlyViews(ctx context.Context, hour time.Time, views map[int64]int64) error {
+	args := m.Called(ctx, hour, views)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) PruneActivity(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockActivityRepository) TopActivity(ctx context.Context, metric string, since time.Time, limit int) ([]model.ActivityTotal, error) {
+	args := m.Called(ctx, metric, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ActivityTotal), args.Error(1)
+}
+
+// MockViewRepository is a mock implementation of ViewRepository
+type MockViewRepository struct {
+	mock.Mock
+}
+
+func (m *MockViewRepository) RefreshPostCounts(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// StatsServiceTestSuite defines the test suite for StatsService
+type StatsServiceTestSuite struct {
+	suite.Suite
+	mockRepo  *MockActivityRepository
+	mockViews *MockViewRepository
+	service   StatsService
+	ctx       context.Context
+}
+
+func (suite *StatsServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockActivityRepository)
+	suite.mockViews = new(MockViewRepository)
+	suite.service = NewStatsService(suite.mockRepo, suite.mockViews, passthroughUnitOfWork{}, config.StatsConfig{
+		RollupEnabled:  true,
+		RollupInterval: 5 * time.Minute,
+		Retention:      8 * 24 * time.Hour,
+	}, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *StatsServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+	suite.mockViews.AssertExpectations(suite.T())
+}
+
+func (suite *StatsServiceTestSuite) TestRollupActivityResumesFromWatermarkHour() {
+	watermark := time.Now().UTC().Add(-90 * time.Minute)
+	since := watermark.Truncate(time.Hour)
+	views := map[int64]int64{1: 3, 2: 1}
+
+	suite.mockRepo.On("GetRollupWatermark", mock.Anything).Return(&watermark, nil)
+	suite.mockRepo.On("RollupPostsAndClicks", mock.Anything, since).Return(nil)
+	suite.mockRepo.On("GetHourlyViews", mock.Anything, mock.MatchedBy(func(hour time.Time) bool {
+		return !hour.Before(since) && hour.Truncate(time.Hour).Equal(hour)
+	})).Return(views, nil)
+	suite.mockRepo.On("SaveHourlyViews", mock.Anything, mock.Anything, views).Return(nil)
+	suite.mockRepo.On("PruneActivity", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(since.Add(-7 * 24 * time.Hour))
+	})).Return(int64(4), nil)
+	suite.mockRepo.On("SaveRollupWatermark", mock.Anything, mock.MatchedBy(func(until time.Time) bool {
+		return until.After(watermark)
+	})).Return(nil)
+
+	result, err := suite.service.RollupActivity(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), since, result.Since)
+	assert.GreaterOrEqual(suite.T(), result.Hours, 2)
+	assert.Equal(suite.T(), int64(4), result.Pruned)
+	suite.mockRepo.AssertNumberOfCalls(suite.T(), "SaveHourlyViews", result.Hours)
+}
+
+func (suite *StatsServiceTestSuite) TestRollupActivityFirstRunCoversRetention() {
+	horizon := time.Now().UTC().Add(-8 * 24 * time.Hour).Truncate(time.Hour)
+
+	suite.mockRepo.On("GetRollupWatermark", mock.Anything).Return(nil, nil)
+	suite.mockRepo.On("RollupPostsAndClicks", mock.Anything, mock.MatchedBy(func(since time.Time) bool {
+		return !since.Before(horizon) && since.Sub(horizon) <= time.Hour
+	})).Return(nil)
+	suite.mockRepo.On("GetHourlyViews", mock.Anything, mock.Anything).Return(map[int64]int64{}, nil)
+	suite.mockRepo.On("SaveHourlyViews", mock.Anything, mock.Anything, map[int64]int64{}).Return(nil)
+	suite.mockRepo.On("PruneActivity", mock.Anything, mock.Anything).Return(int64(0), nil)
+	suite.mockRepo.On("SaveRollupWatermark", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := suite.service.RollupActivity(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.GreaterOrEqual(suite.T(), result.Hours, 8*24)
+}
+
+func (suite *StatsServiceTestSuite) TestRollupActivityError() {
+	suite.mockRepo.On("GetRollupWatermark", mock.Anything).Return(nil, nil)
+	suite.mockRepo.On("RollupPostsAndClicks", mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+	result, err := suite.service.RollupActivity(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "SaveRollupWatermark", mock.Anything, mock.Anything)
+}
+
+func (suite *StatsServiceTestSuite) TestGetTopStatsSplitsDimensions() {
+	rolledUpAt := time.Now().UTC().Add(-2 * time.Minute)
+	currentHour := time.Now().UTC().Truncate(time.Hour)
+	totals := []model.ActivityTotal{
+		{Dimension: model.ActivityDimensionCategory, Key: "technology", Value: 120},
+		{Dimension: model.ActivityDimensionCategory, Key: "sports", Value: 80},
+		{Dimension: model.ActivityDimensionSource, Key: "BBC News", Value: 150},
+	}
+
+	suite.mockRepo.On("TopActivity", mock.Anything, model.StatsMetricViews, mock.MatchedBy(func(since time.Time) bool {
+		return since.Equal(currentHour.Add(-6*24*time.Hour - 23*time.Hour))
+	}), 5).Return(totals, nil)
+	suite.mockRepo.On("GetRollupWatermark", mock.Anything).Return(&rolledUpAt, nil)
+
+	resp, err := suite.service.GetTopStats(suite.ctx, &model.TopStatsParams{Metric: "views", Period: "7d", Limit: 5})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []model.TopStat{{Key: "BBC News", Value: 150}}, resp.Sources)
+	assert.Equal(suite.T(), []model.TopStat{{Key: "technology", Value: 120}, {Key: "sports", Value: 80}}, resp.Categories)
+	assert.Equal(suite.T(), &rolledUpAt, resp.RolledUpAt)
+}
+
+func (suite *StatsServiceTestSuite) TestGetTopStatsDefaultsAndEmpty() {
+	suite.mockRepo.On("TopActivity", mock.Anything, model.StatsMetricPosts, mock.Anything, 10).Return(nil, nil)
+	suite.mockRepo.On("GetRollupWatermark", mock.Anything).Return(nil, nil)
+
+	resp, err := suite.service.GetTopStats(suite.ctx, &model.TopStatsParams{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), model.StatsPeriodDay, resp.Period)
+	assert.NotNil(suite.T(), resp.Sources)
+	assert.Empty(suite.T(), resp.Sources)
+	assert.Nil(suite.T(), resp.RolledUpAt)
+}
+
+func (suite *StatsServiceTestSuite) TestGetTopStatsInvalidParams() {
+	_, err := suite.service.GetTopStats(suite.ctx, &model.TopStatsParams{Metric: "shares"})
+	assert.ErrorIs(suite.T(), err, ErrStatsMetricInvalid)
+
+	_, err = suite.service.GetTopStats(suite.ctx, &model.TopStatsParams{Period: "30d"})
+	assert.ErrorIs(suite.T(), err, ErrStatsPeriodInvalid)
+}
+
+func (suite *StatsServiceTestSuite) TestRefreshViews() {
+	suite.mockViews.On("RefreshPostCounts", mock.Anything).Return(nil).Once()
+	assert.NoError(suite.T(), suite.service.RefreshViews(suite.ctx))
+
+	suite.mockViews.On("RefreshPostCounts", mock.Anything).Return(errors.New("database error")).Once()
+	assert.Error(suite.T(), suite.service.RefreshViews(suite.ctx))
+}
+
+func TestStatsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(StatsServiceTestSuite))
+}
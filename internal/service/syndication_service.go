@@ -0,0 +1,428 @@
+package service
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+)
+
+// syndicationCacheSize bounds how many rendered feeds and sitemap pages are kept
+const syndicationCacheSize = 1024
+
+// Links in generated documents. The post path mirrors the versioned API route.
+const (
+	syndicationPostPath     = "/api/v1/posts/%d"
+	syndicationRedirectPath = "/r/%d"
+)
+
+const (
+	contentTypeRSS     = "application/rss+xml; charset=utf-8"
+	contentTypeAtom    = "application/atom+xml; charset=utf-8"
+	contentTypeSitemap = "application/xml; charset=utf-8"
+)
+
+var (
+	ErrFeedFormatInvalid   = errors.New("feed format must be rss or atom")
+	ErrFeedCategoryInvalid = errors.New("feed category is invalid")
+	ErrSitemapPageNotFound = errors.New("sitemap page not found")
+)
+
+// syndicationService implements SyndicationService interface
+type syndicationService struct {
+	repo   repository.PostRepository
+	cfg    config.SyndicationConfig
+	cache  *lru.Cache[string, *model.SyndicationDocument]
+	logger *logger.Logger
+}
+
+// NewSyndicationService creates a new syndication service. Rendered documents
+// are cached for the refresh interval.
+func NewSyndicationService(repo repository.PostRepository, cfg config.SyndicationConfig, logger *logger.Logger) SyndicationService {
+	return &syndicationService{
+		repo:   repo,
+		cfg:    cfg,
+		cache:  lru.New[string, *model.SyndicationDocument](syndicationCacheSize, cfg.RefreshInterval),
+		logger: logger,
+	}
+}
+
+// Feed returns the RSS or Atom feed of the most recent posts, optionally
+// limited to a category
+func (s *syndicationService) Feed(ctx context.Context, category string, format model.FeedFormat) (*model.SyndicationDocument, error) {
+	start := time.Now()
+
+	if format != model.FeedFormatRSS && format != model.FeedFormatAtom {
+		s.logger.LogServiceOperation("syndication", "feed", false, time.Since(start).Milliseconds())
+		return nil, ErrFeedFormatInvalid
+	}
+
+	category = strings.ToLower(strings.TrimSpace(category))
+	if len(category) > 50 {
+		s.logger.LogServiceOperation("syndication", "feed", false, time.Since(start).Milliseconds())
+		return nil, ErrFeedCategoryInvalid
+	}
+
+	doc, err := s.cached(tenant.Key(ctx, feedCacheKey(category, format)), func() (*model.SyndicationDocument, error) {
+		return s.renderFeed(ctx, category, format)
+	})
+	s.logger.LogServiceOperation("syndication", "feed", err == nil, time.Since(start).Milliseconds())
+
+	return doc, err
+}
+
+// Sitemap returns a sitemap page. Page 0 is the root document: a single
+// sitemap when every post fits on one page, otherwise an index of the pages.
+func (s *syndicationService) Sitemap(ctx context.Context, page int) (*model.SyndicationDocument, error) {
+	start := time.Now()
+
+	if page < 0 {
+		s.logger.LogServiceOperation("syndication", "sitemap", false, time.Since(start).Milliseconds())
+		return nil, ErrSitemapPageNotFound
+	}
+
+	doc, err := s.cached(tenant.Key(ctx, sitemapCacheKey(page)), func() (*model.SyndicationDocument, error) {
+		return s.renderSitemap(ctx, page)
+	})
+	s.logger.LogServiceOperation("syndication", "sitemap", err == nil, time.Since(start).Milliseconds())
+
+	return doc, err
+}
+
+// Refresh regenerates the main and default category feeds in both formats,
+// the sitemap root and every sitemap page of the tenant ctx is scoped to
+func (s *syndicationService) Refresh(ctx context.Context) (*model.SyndicationRefreshResult, error) {
+	start := time.Now()
+	result := &model.SyndicationRefreshResult{}
+
+	categories := append([]string{""}, GetDefaultCategories()...)
+	for _, category := range categories {
+		for _, format := range []model.FeedFormat{model.FeedFormatRSS, model.FeedFormatAtom} {
+			doc, err := s.renderFeed(ctx, category, format)
+			if err != nil {
+				s.logger.LogServiceOperation("syndication", "refresh", false, time.Since(start).Milliseconds())
+				return result, err
+			}
+			s.cache.Set(tenant.Key(ctx, feedCacheKey(category, format)), doc)
+			result.Feeds++
+		}
+	}
+
+	total, err := s.repo.CountPosts(ctx, model.PostStatusPublished)
+	if err != nil {
+		s.logger.LogServiceOperation("syndication", "refresh", false, time.Since(start).Milliseconds())
+		return result, fmt.Errorf("failed to count posts: %w", err)
+	}
+
+	for page := 0; page <= s.sitemapPages(total); page++ {
+		doc, err := s.renderSitemap(ctx, page)
+		if err != nil {
+			s.logger.LogServiceOperation("syndication", "refresh", false, time.Since(start).Milliseconds())
+			return result, err
+		}
+		s.cache.Set(tenant.Key(ctx, sitemapCacheKey(page)), doc)
+		if page > 0 {
+			result.SitemapPages++
+		}
+	}
+
+	s.logger.LogServiceOperation("syndication", "refresh", true, time.Since(start).Milliseconds())
+
+	return result, nil
+}
+
+// cached returns the document stored under key, rendering and storing it on a miss
+func (s *syndicationService) cached(key string, render func() (*model.SyndicationDocument, error)) (*model.SyndicationDocument, error) {
+	if doc, ok := s.cache.Get(key); ok {
+		s.logger.LogCacheOperation("get", key, true)
+		return doc, nil
+	}
+	s.logger.LogCacheOperation("get", key, false)
+
+	doc, err := render()
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Set(key, doc)
+
+	return doc, nil
+}
+
+// renderFeed builds a feed document from the most recent posts
+func (s *syndicationService) renderFeed(ctx context.Context, category string, format model.FeedFormat) (*model.SyndicationDocument, error) {
+	params := &model.PostListParams{Page: 1, Limit: s.cfg.FeedSize}
+	if category != "" {
+		params.Category = &category
+	}
+
+	posts, err := s.repo.ListPosts(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list posts: %w", err)
+	}
+
+	now := time.Now().UTC()
+	self := s.cfg.BaseURL + "/rss"
+	title := s.cfg.Title
+	if category != "" {
+		self += "/" + url.PathEscape(category)
+		title += " - " + category
+	}
+
+	if format == model.FeedFormatAtom {
+		return s.encode(s.atomFeed(posts, title, self+"?format=atom", now), contentTypeAtom, now)
+	}
+
+	return s.encode(s.rssFeed(posts, title, self, now), contentTypeRSS, now)
+}
+
+// renderSitemap builds the sitemap root or a numbered sitemap page
+func (s *syndicationService) renderSitemap(ctx context.Context, page int) (*model.SyndicationDocument, error) {
+	now := time.Now().UTC()
+
+	if page == 0 {
+		total, err := s.repo.CountPosts(ctx, model.PostStatusPublished)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count posts: %w", err)
+		}
+
+		pages := s.sitemapPages(total)
+		if pages > 1 {
+			index := sitemapIndex{XMLNS: sitemapNamespace}
+			for p := 1; p <= pages; p++ {
+				index.Sitemaps = append(index.Sitemaps, sitemapRef{Loc: fmt.Sprintf("%s/sitemap.xml?page=%d", s.cfg.BaseURL, p)})
+			}
+			return s.encode(index, contentTypeSitemap, now)
+		}
+
+		page = 1
+	}
+
+	entries, err := s.repo.ListSitemapEntries(ctx, s.cfg.SitemapPageSize, (page-1)*s.cfg.SitemapPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty first page is a valid sitemap of a site without posts
+	if len(entries) == 0 && page > 1 {
+		return nil, ErrSitemapPageNotFound
+	}
+
+	set := sitemapURLSet{XMLNS: sitemapNamespace, URLs: make([]sitemapURL, len(entries))}
+	for i, entry := range entries {
+		set.URLs[i] = sitemapURL{
+			Loc:     s.cfg.BaseURL + fmt.Sprintf(syndicationPostPath, entry.PostID),
+			LastMod: entry.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+
+	return s.encode(set, contentTypeSitemap, now)
+}
+
+// sitemapPages returns how many sitemap pages total posts span
+func (s *syndicationService) sitemapPages(total int64) int {
+	pages := int((total + int64(s.cfg.SitemapPageSize) - 1) / int64(s.cfg.SitemapPageSize))
+	if pages == 0 {
+		return 1
+	}
+
+	return pages
+}
+
+// rssFeed maps posts to an RSS 2.0 document
+func (s *syndicationService) rssFeed(posts []model.Post, title, self string, now time.Time) rssDocument {
+	channel := rssChannel{
+		Title:         title,
+		Link:          s.cfg.BaseURL,
+		Description:   title,
+		LastBuildDate: now.Format(time.RFC1123Z),
+		AtomLink:      rssAtomLink{Href: self, Rel: "self", Type: "application/rss+xml"},
+	}
+
+	for _, post := range posts {
+		item := rssItem{
+			Title: post.Title,
+			Link:  s.cfg.BaseURL + fmt.Sprintf(syndicationRedirectPath, post.ID),
+			GUID:  rssGUID{Value: s.cfg.BaseURL + fmt.Sprintf(syndicationPostPath, post.ID), IsPermaLink: "false"},
+		}
+		if post.Description != nil {
+			item.Description = *post.Description
+		}
+		if post.Category != nil {
+			item.Category = *post.Category
+		}
+		if post.PublishedAt != nil {
+			item.PubDate = post.PublishedAt.UTC().Format(time.RFC1123Z)
+		}
+		channel.Items = append(channel.Items, item)
+	}
+
+	return rssDocument{Version: "2.0", XMLNSAtom: atomNamespace, Channel: channel}
+}
+
+// atomFeed maps posts to an Atom 1.0 document
+func (s *syndicationService) atomFeed(posts []model.Post, title, self string, now time.Time) atomDocument {
+	feed := atomDocument{
+		XMLNS:   atomNamespace,
+		ID:      self,
+		Title:   title,
+		Updated: now.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: self, Rel: "self"},
+			{Href: s.cfg.BaseURL, Rel: "alternate"},
+		},
+	}
+
+	for _, post := range posts {
+		entry := atomEntry{
+			ID:      s.cfg.BaseURL + fmt.Sprintf(syndicationPostPath, post.ID),
+			Title:   post.Title,
+			Link:    atomLink{Href: s.cfg.BaseURL + fmt.Sprintf(syndicationRedirectPath, post.ID), Rel: "alternate"},
+			Updated: post.UpdatedAt.UTC().Format(time.RFC3339),
+			Author:  atomAuthor{Name: post.Source},
+		}
+		if post.Author != nil {
+			entry.Author.Name = *post.Author
+		}
+		if post.Description != nil {
+			entry.Summary = *post.Description
+		}
+		if post.Category != nil {
+			entry.Category = &atomCategory{Term: *post.Category}
+		}
+		if post.PublishedAt != nil {
+			entry.Published = post.PublishedAt.UTC().Format(time.RFC3339)
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+// encode marshals v into a document with an XML declaration
+func (s *syndicationService) encode(v any, contentType string, generatedAt time.Time) (*model.SyndicationDocument, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s document: %w", contentType, err)
+	}
+
+	return &model.SyndicationDocument{
+		Body:        append([]byte(xml.Header), body...),
+		ContentType: contentType,
+		GeneratedAt: generatedAt,
+	}, nil
+}
+
+func feedCacheKey(category string, format model.FeedFormat) string {
+	return "feed:" + string(format) + ":" + category
+}
+
+func sitemapCacheKey(page int) string {
+	return fmt.Sprintf("sitemap:%d", page)
+}
+
+const (
+	atomNamespace    = "http://www.w3.org/2005/Atom"
+	sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+type rssDocument struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	XMLNSAtom string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	LastBuildDate string      `xml:"lastBuildDate"`
+	AtomLink      rssAtomLink `xml:"atom:link"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description,omitempty"`
+	Category    string  `xml:"category,omitempty"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink string `xml:"isPermaLink,attr"`
+}
+
+type atomDocument struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string        `xml:"id"`
+	Title     string        `xml:"title"`
+	Link      atomLink      `xml:"link"`
+	Updated   string        `xml:"updated"`
+	Published string        `xml:"published,omitempty"`
+	Summary   string        `xml:"summary,omitempty"`
+	Author    atomAuthor    `xml:"author"`
+	Category  *atomCategory `xml:"category"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+type sitemapRef struct {
+	Loc string `xml:"loc"`
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// SyndicationServiceTestSuite defines the test suite for SyndicationService
+type SyndicationServiceTestSuite struct {
+	suite.Suite
+	mockRepo *MockPostRepository
+	service  SyndicationService
+	ctx      context.Context
+}
+
+func (suite *SyndicationServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockPostRepository)
+	suite.service = NewSyndicationService(suite.mockRepo, config.SyndicationConfig{
+		BaseURL:         "https://news.example.com",
+		Title:           "News",
+		FeedSize:        20,
+		SitemapPageSize: 2,
+		RefreshInterval: time.Minute,
+	}, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *SyndicationServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *SyndicationServiceTestSuite) feedPosts() []model.Post {
+	description := "Go 1.25 & friends"
+	category := "technology"
+	published := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+
+	return []model.Post{{
+		ID:          1,
+		Title:       "New Go release",
+		Description: &description,
+		URL:         "https://example.com/go",
+		Source:      "TechCrunch",
+		Category:    &category,
+		PublishedAt: &published,
+		UpdatedAt:   published,
+	}}
+}
+
+func (suite *SyndicationServiceTestSuite) TestFeedRSS() {
+	suite.mockRepo.On("ListPosts", suite.ctx, mock.MatchedBy(func(p *model.PostListParams) bool {
+		return p.Page == 1 && p.Limit == 20 && p.Category == nil
+	})).Return(suite.feedPosts(), nil).Once()
+
+	doc, err := suite.service.Feed(suite.ctx, "", model.FeedFormatRSS)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "application/rss+xml; charset=utf-8", doc.ContentType)
+
+	var feed rssDocument
+	assert.NoError(suite.T(), xml.Unmarshal(doc.Body, &feed))
+	assert.Equal(suite.T(), "2.0", feed.Version)
+	assert.Len(suite.T(), feed.Channel.Items, 1)
+	assert.Equal(suite.T(), "https://news.example.com/r/1", feed.Channel.Items[0].Link)
+	assert.Equal(suite.T(), "Go 1.25 & friends", feed.Channel.Items[0].Description)
+	assert.Equal(suite.T(), "Mon, 11 Aug 2025 07:00:00 +0000", feed.Channel.Items[0].PubDate)
+	assert.Contains(suite.T(), string(doc.Body), `<atom:link href="https://news.example.com/rss" rel="self"`)
+}
+
+func (suite *SyndicationServiceTestSuite) TestFeedAtomByCategory() {
+	suite.mockRepo.On("ListPosts", suite.ctx, mock.MatchedBy(func(p *model.PostListParams) bool {
+		return p.Category != nil && *p.Category == "technology"
+	})).Return(suite.feedPosts(), nil).Once()
+
+	doc, err := suite.service.Feed(suite.ctx, "Technology", model.FeedFormatAtom)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "application/atom+xml; charset=utf-8", doc.ContentType)
+
+	var feed atomDocument
+	assert.NoError(suite.T(), xml.Unmarshal(doc.Body, &feed))
+	assert.Equal(suite.T(), "https://news.example.com/rss/technology?format=atom", feed.ID)
+	assert.Len(suite.T(), feed.Entries, 1)
+	assert.Equal(suite.T(), "https://news.example.com/api/v1/posts/1", feed.Entries[0].ID)
+	assert.Equal(suite.T(), "TechCrunch", feed.Entries[0].Author.Name)
+	assert.Equal(suite.T(), "2025-08-11T07:00:00Z", feed.Entries[0].Published)
+}
+
+func (suite *SyndicationServiceTestSuite) TestFeedIsCached() {
+	suite.mockRepo.On("ListPosts", suite.ctx, mock.Anything).Return(suite.feedPosts(), nil).Once()
+
+	first, err := suite.service.Feed(suite.ctx, "", model.FeedFormatRSS)
+	assert.NoError(suite.T(), err)
+
+	second, err := suite.service.Feed(suite.ctx, "", model.FeedFormatRSS)
+	assert.NoError(suite.T(), err)
+	assert.Same(suite.T(), first, second)
+}
+
+func (suite *SyndicationServiceTestSuite) TestFeedInvalidFormat() {
+	doc, err := suite.service.Feed(suite.ctx, "", model.FeedFormat("json"))
+
+	assert.ErrorIs(suite.T(), err, ErrFeedFormatInvalid)
+	assert.Nil(suite.T(), doc)
+}
+
+func (suite *SyndicationServiceTestSuite) TestSitemapSinglePage() {
+	updated := time.Date(2025, 8, 11, 7, 0, 0, 0, time.UTC)
+
+	suite.mockRepo.On("CountPosts", suite.ctx, model.PostStatusPublished).Return(int64(2), nil).Once()
+	suite.mockRepo.On("ListSitemapEntries", suite.ctx, 2, 0).Return([]model.SitemapEntry{
+		{PostID: 1, UpdatedAt: updated},
+		{PostID: 2, UpdatedAt: updated},
+	}, nil).Once()
+
+	doc, err := suite.service.Sitemap(suite.ctx, 0)
+
+	assert.NoError(suite.T(), err)
+
+	var set sitemapURLSet
+	assert.NoError(suite.T(), xml.Unmarshal(doc.Body, &set))
+	assert.Len(suite.T(), set.URLs, 2)
+	assert.Equal(suite.T(), "https://news.example.com/api/v1/posts/2", set.URLs[1].Loc)
+	assert.Equal(suite.T(), "2025-08-11T07:00:00Z", set.URLs[1].LastMod)
+}
+
+func (suite *SyndicationServiceTestSuite) TestSitemapIndex() {
+	suite.mockRepo.On("CountPosts", suite.ctx, model.PostStatusPublished).Return(int64(5), nil).Once()
+
+	doc, err := suite.service.Sitemap(suite.ctx, 0)
+
+	assert.NoError(suite.T(), err)
+
+	var index sitemapIndex
+	assert.NoError(suite.T(), xml.Unmarshal(doc.Body, &index))
+	assert.Len(suite.T(), index.Sitemaps, 3)
+	assert.Equal(suite.T(), "https://news.example.com/sitemap.xml?page=3", index.Sitemaps[2].Loc)
+}
+
+func (suite *SyndicationServiceTestSuite) TestSitemapPageOutOfRange() {
+	suite.mockRepo.On("ListSitemapEntries", suite.ctx, 2, 8).Return([]model.SitemapEntry{}, nil).Once()
+
+	doc, err := suite.service.Sitemap(suite.ctx, 5)
+
+	assert.ErrorIs(suite.T(), err, ErrSitemapPageNotFound)
+	assert.Nil(suite.T(), doc)
+}
+
+func (suite *SyndicationServiceTestSuite) TestRefreshRegeneratesDocuments() {
+	suite.mockRepo.On("ListPosts", suite.ctx, mock.Anything).Return(suite.feedPosts(), nil)
+	suite.mockRepo.On("CountPosts", suite.ctx, model.PostStatusPublished).Return(int64(3), nil)
+	suite.mockRepo.On("ListSitemapEntries", suite.ctx, 2, mock.Anything).Return([]model.SitemapEntry{{PostID: 1}}, nil)
+
+	result, err := suite.service.Refresh(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2*(1+len(GetDefaultCategories())), result.Feeds)
+	assert.Equal(suite.T(), 2, result.SitemapPages)
+
+	// Served from the refreshed cache without touching the repository again
+	suite.mockRepo.ExpectedCalls = nil
+	_, err = suite.service.Feed(suite.ctx, "sports", model.FeedFormatAtom)
+	assert.NoError(suite.T(), err)
+	_, err = suite.service.Sitemap(suite.ctx, 2)
+	assert.NoError(suite.T(), err)
+}
+
+func TestSyndicationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(SyndicationServiceTestSuite))
+}
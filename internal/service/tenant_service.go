@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/lru"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/jackc/pgx/v5"
+)
+
+// tenantCacheSize bounds how many resolved tenants are kept in memory
+const tenantCacheSize = 1024
+
+var (
+	ErrTenantNotFound      = errors.New("tenant not found")
+	ErrTenantIDInvalid     = errors.New("tenant ID must be a lowercase slug of at most 50 characters")
+	ErrTenantQuotaExceeded = errors.New("tenant NewsAPI daily quota exceeded")
+)
+
+// tenantService implements TenantService interface
+type tenantService struct {
+	repo    repository.TenantRepository
+	enabled bool
+	cache   *lru.Cache[string, *model.Tenant]
+	logger  *logger.Logger
+}
+
+// NewTenantService creates a new tenant service. Resolved tenants are cached
+// for cfg.CacheTTL, so changes made on another replica apply within that time.
+func NewTenantService(repo repository.TenantRepository, cfg config.TenantConfig, logger *logger.Logger) TenantService {
+	return &tenantService{
+		repo:    repo,
+		enabled: cfg.Enabled,
+		cache:   lru.New[string, *model.Tenant](tenantCacheSize, cfg.CacheTTL),
+		logger:  logger,
+	}
+}
+
+// Resolve returns the active tenant with the given ID
+func (s *tenantService) Resolve(ctx context.Context, id string) (*model.Tenant, error) {
+	if !tenant.ValidID(id) {
+		return nil, ErrTenantNotFound
+	}
+
+	if cached, ok := s.cache.Get(id); ok {
+		s.logger.LogCacheOperation("get", "tenant:"+id, true)
+		return cached, nil
+	}
+	s.logger.LogCacheOperation("get", "tenant:"+id, false)
+
+	t, err := s.repo.GetTenant(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTenantNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Set(id, t)
+
+	if !t.Active {
+		return nil, ErrTenantNotFound
+	}
+
+	return t, nil
+}
+
+// ActiveTenants returns the tenants scheduled work runs for: every active
+// tenant, or only the default one when multi-tenancy is disabled
+func (s *tenantService) ActiveTenants(ctx context.Context) ([]model.Tenant, error) {
+	if !s.enabled {
+		return []model.Tenant{{ID: tenant.Default, Active: true}}, nil
+	}
+
+	tenants, err := s.repo.ListTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]model.Tenant, 0, len(tenants))
+	for _, t := range tenants {
+		if t.Active {
+			active = append(active, t)
+		}
+	}
+
+	return active, nil
+}
+
+// ListTenants returns every tenant, including inactive ones
+func (s *tenantService) ListTenants(ctx context.Context) ([]model.Tenant, error) {
+	start := time.Now()
+
+	tenants, err := s.repo.ListTenants(ctx)
+	s.logger.LogServiceOperation("tenant", "list", err == nil, time.Since(start).Milliseconds())
+
+	return tenants, err
+}
+
+// UpsertTenant creates a tenant or updates an existing one
+func (s *tenantService) UpsertTenant(ctx context.Context, id string, req *model.UpsertTenantParams) (*model.Tenant, error) {
+	start := time.Now()
+
+	if !tenant.ValidID(id) {
+		s.logger.LogServiceOperation("tenant", "upsert", false, time.Since(start).Milliseconds())
+		return nil, ErrTenantIDInvalid
+	}
+
+	t, err := s.repo.GetTenant(ctx, id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		t = &model.Tenant{ID: id, Active: true}
+	case err != nil:
+		s.logger.LogServiceOperation("tenant", "upsert", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	t.Name = strings.TrimSpace(req.Name)
+	t.NewsAPIDailyQuota = req.NewsAPIDailyQuota
+	t.Sources = req.Sources
+	if req.NewsAPIKey != nil {
+		t.NewsAPIKey = strings.TrimSpace(*req.NewsAPIKey)
+	}
+	if req.Active != nil {
+		t.Active = *req.Active
+	}
+	t.HasNewsAPIKey = t.NewsAPIKey != ""
+
+	if err := s.repo.SaveTenant(ctx, t); err != nil {
+		s.logger.LogServiceOperation("tenant", "upsert", false, time.Since(start).Milliseconds())
+		return nil, err
+	}
+
+	s.cache.Delete(id)
+	s.logger.LogServiceOperation("tenant", "upsert", true, time.Since(start).Milliseconds())
+
+	return t, nil
+}
+
+// ReserveNewsAPIRequest counts a NewsAPI request against the daily quota of
+// the tenant ctx is scoped to and returns the tenant's own API key, or "" to
+// use the deployment's key
+func (s *tenantService) ReserveNewsAPIRequest(ctx context.Context) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	t, err := s.Resolve(ctx, tenant.FromContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	if t.NewsAPIDailyQuota > 0 {
+		used, err := s.repo.IncrementNewsAPIUsage(ctx, t.ID, time.Now())
+		if err != nil {
+			return "", err
+		}
+		if used > int64(t.NewsAPIDailyQuota) {
+			s.logger.Warn("Tenant NewsAPI quota exceeded", "tenant", t.ID, "quota", t.NewsAPIDailyQuota)
+			return "", ErrTenantQuotaExceeded
+		}
+	}
+
+	return t.NewsAPIKey, nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockTenantRepository is a mock implementation of TenantRepository
+type MockTenantRepository struct {
+	mock.Mock
+}
+
+func (m *MockTenantRepository) GetTenant(ctx context.Context, id string) (*model.Tenant, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) ListTenants(ctx context.Context) ([]model.Tenant, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) SaveTenant(ctx context.Context, t *model.Tenant) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockTenantRepository) IncrementNewsAPIUsage(ctx context.Context, id string, day time.Time) (int64, error) {
+	args := m.Called(ctx, id, day)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// TenantServiceTestSuite defines the test suite for TenantService
+type TenantServiceTestSuite struct {
+	suite.Suite
+	mockRepo *MockTenantRepository
+	service  TenantService
+	ctx      context.Context
+}
+
+func (suite *TenantServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockTenantRepository)
+	suite.service = NewTenantService(suite.mockRepo, config.TenantConfig{Enabled: true, CacheTTL: time.Minute}, logger.New(cfg))
+	suite.ctx = context.Background()
+}
+
+func (suite *TenantServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *TenantServiceTestSuite) TestResolveCachesTenant() {
+	suite.mockRepo.On("GetTenant", suite.ctx, "acme").Return(&model.Tenant{ID: "acme", Active: true}, nil).Once()
+
+	for range 2 {
+		t, err := suite.service.Resolve(suite.ctx, "acme")
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "acme", t.ID)
+	}
+}
+
+func (suite *TenantServiceTestSuite) TestResolveUnknownOrInactive() {
+	suite.mockRepo.On("GetTenant", suite.ctx, "ghost").Return(nil, pgx.ErrNoRows)
+	suite.mockRepo.On("GetTenant", suite.ctx, "paused").Return(&model.Tenant{ID: "paused", Active: false}, nil)
+
+	_, err := suite.service.Resolve(suite.ctx, "ghost")
+	assert.ErrorIs(suite.T(), err, ErrTenantNotFound)
+
+	_, err = suite.service.Resolve(suite.ctx, "paused")
+	assert.ErrorIs(suite.T(), err, ErrTenantNotFound)
+
+	// Malformed IDs never reach the database
+	_, err = suite.service.Resolve(suite.ctx, "Not A Slug")
+	assert.ErrorIs(suite.T(), err, ErrTenantNotFound)
+}
+
+func (suite *TenantServiceTestSuite) TestActiveTenantsSkipsInactive() {
+	suite.mockRepo.On("ListTenants", suite.ctx).Return([]model.Tenant{
+		{ID: "acme", Active: true},
+		{ID: "default", Active: true},
+		{ID: "paused", Active: false},
+	}, nil)
+
+	tenants, err := suite.service.ActiveTenants(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), tenants, 2)
+	assert.Equal(suite.T(), "acme", tenants[0].ID)
+	assert.Equal(suite.T(), "default", tenants[1].ID)
+}
+
+func (suite *TenantServiceTestSuite) TestActiveTenantsDisabled() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+	svc := NewTenantService(suite.mockRepo, config.TenantConfig{CacheTTL: time.Minute}, logger.New(cfg))
+
+	tenants, err := svc.ActiveTenants(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []model.Tenant{{ID: tenant.Default, Active: true}}, tenants)
+
+	apiKey, err := svc.ReserveNewsAPIRequest(suite.ctx)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), apiKey)
+}
+
+func (suite *TenantServiceTestSuite) TestUpsertTenantKeepsKeyWhenOmitted() {
+	existing := &model.Tenant{ID: "acme", Name: "Acme", NewsAPIKey: "acme-key", Active: false}
+	suite.mockRepo.On("GetTenant", suite.ctx, "acme").Return(existing, nil)
+	suite.mockRepo.On("SaveTenant", suite.ctx, mock.MatchedBy(func(t *model.Tenant) bool {
+		return t.Name == "Acme News" && t.NewsAPIKey == "acme-key" && !t.Active && t.NewsAPIDailyQuota == 50
+	})).Return(nil)
+
+	t, err := suite.service.UpsertTenant(suite.ctx, "acme", &model.UpsertTenantParams{Name: " Acme News ", NewsAPIDailyQuota: 50})
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), t.HasNewsAPIKey)
+}
+
+func (suite *TenantServiceTestSuite) TestUpsertTenantCreatesActiveTenant() {
+	suite.mockRepo.On("GetTenant", suite.ctx, "acme").Return(nil, pgx.ErrNoRows)
+	suite.mockRepo.On("SaveTenant", suite.ctx, mock.MatchedBy(func(t *model.Tenant) bool {
+		return t.ID == "acme" && t.Active
+	})).Return(nil)
+
+	t, err := suite.service.UpsertTenant(suite.ctx, "acme", &model.UpsertTenantParams{Name: "Acme"})
+
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), t.HasNewsAPIKey)
+}
+
+func (suite *TenantServiceTestSuite) TestUpsertTenantInvalidID() {
+	_, err := suite.service.UpsertTenant(suite.ctx, "Acme!", &model.UpsertTenantParams{Name: "Acme"})
+
+	assert.ErrorIs(suite.T(), err, ErrTenantIDInvalid)
+}
+
+func (suite *TenantServiceTestSuite) TestReserveNewsAPIRequestEnforcesQuota() {
+	ctx := tenant.WithTenant(suite.ctx, "acme")
+	suite.mockRepo.On("GetTenant", ctx, "acme").Return(&model.Tenant{ID: "acme", NewsAPIKey: "acme-key", NewsAPIDailyQuota: 2, Active: true}, nil)
+	suite.mockRepo.On("IncrementNewsAPIUsage", ctx, "acme", mock.Anything).Return(int64(2), nil).Once()
+	suite.mockRepo.On("IncrementNewsAPIUsage", ctx, "acme", mock.Anything).Return(int64(3), nil).Once()
+
+	apiKey, err := suite.service.ReserveNewsAPIRequest(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "acme-key", apiKey)
+
+	_, err = suite.service.ReserveNewsAPIRequest(ctx)
+	assert.ErrorIs(suite.T(), err, ErrTenantQuotaExceeded)
+}
+
+func TestTenantServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TenantServiceTestSuite))
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+)
+
+// maxTopicCandidates caps the clustered posts a pass compares new posts with
+const maxTopicCandidates = 5000
+
+// maxTitleSuffixLength is the longest trailing " - Source" segment stripped
+// from titles before comparing them
+const maxTitleSuffixLength = 40
+
+// titleStopWords carry no information about which story a title covers
+var titleStopWords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "for": {}, "from": {},
+	"has": {}, "have": {}, "in": {}, "is": {}, "it": {}, "its": {}, "of": {}, "on": {}, "or": {}, "over": {},
+	"says": {}, "that": {}, "the": {}, "this": {}, "to": {}, "was": {}, "will": {}, "with": {},
+}
+
+// topicService implements TopicService interface
+type topicService struct {
+	repo   repository.TopicRepository
+	cfg    config.TopicConfig
+	logger *logger.Logger
+}
+
+// NewTopicService creates a new topic service
+func NewTopicService(repo repository.TopicRepository, cfg config.TopicConfig, logger *logger.Logger) TopicService {
+	return &topicService{
+		repo:   repo,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// topicMember is a clustered post prepared for comparison
+type topicMember struct {
+	topicID     int64
+	publishedAt time.Time
+	words       map[string]struct{}
+}
+
+// ClusterPendingPosts assigns a batch of unclustered posts to topics, oldest
+// first. A post joins the topic of the most similar post published within
+// the configured window, or starts a topic of its own.
+func (s *topicService) ClusterPendingPosts(ctx context.Context) (*model.TopicClusteringResult, error) {
+	start := time.Now()
+
+	pending, err := s.repo.ListUnclusteredPosts(ctx, s.cfg.BatchSize)
+	if err != nil {
+		s.logger.LogServiceOperation("topic", "cluster", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to list unclustered posts: %w", err)
+	}
+
+	result := &model.TopicClusteringResult{}
+	if len(pending) == 0 {
+		s.logger.LogServiceOperation("topic", "cluster", true, time.Since(start).Milliseconds())
+		return result, nil
+	}
+
+	earliest := pending[0].PublishedAt
+	for _, post := range pending {
+		if post.PublishedAt.Before(earliest) {
+			earliest = post.PublishedAt
+		}
+	}
+
+	clustered, err := s.repo.ListClusteredPosts(ctx, earliest.Add(-s.cfg.Window), maxTopicCandidates)
+	if err != nil {
+		s.logger.LogServiceOperation("topic", "cluster", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to list clustered posts: %w", err)
+	}
+
+	members := make([]topicMember, 0, len(clustered)+len(pending))
+	for _, post := range clustered {
+		members = append(members, topicMember{topicID: *post.TopicID, publishedAt: post.PublishedAt, words: titleWords(post.Title)})
+	}
+
+	assignments := make(map[int64]int64, len(pending))
+	for _, post := range pending {
+		words := titleWords(post.Title)
+		topicID := post.ID
+
+		bestScore := 0.0
+		for _, member := range members {
+			if absDuration(post.PublishedAt.Sub(member.publishedAt)) > s.cfg.Window {
+				continue
+			}
+
+			if score := jaccard(words, member.words); score >= s.cfg.Threshold && score > bestScore {
+				bestScore = score
+				topicID = member.topicID
+			}
+		}
+
+		if topicID != post.ID {
+			result.Clustered++
+		}
+		result.Processed++
+
+		assignments[post.ID] = topicID
+		members = append(members, topicMember{topicID: topicID, publishedAt: post.PublishedAt, words: words})
+	}
+
+	if err := s.repo.AssignTopics(ctx, assignments); err != nil {
+		s.logger.LogServiceOperation("topic", "cluster", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to assign topics: %w", err)
+	}
+
+	s.logger.LogServiceOperation("topic", "cluster", true, time.Since(start).Milliseconds())
+
+	return result, nil
+}
+
+// ListTopics retrieves a page of topics with their representative posts
+func (s *topicService) ListTopics(ctx context.Context, req *model.TopicListParams) (*model.TopicListResponse, error) {
+	start := time.Now()
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+	if req.MinMembers <= 0 {
+		req.MinMembers = 1
+	}
+
+	topics, err := s.repo.ListTopics(ctx, req)
+	if err != nil {
+		s.logger.LogServiceOperation("topic", "list", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+
+	total, err := s.repo.CountTopics(ctx, req)
+	if err != nil {
+		s.logger.LogServiceOperation("topic", "list", false, time.Since(start).Milliseconds())
+		return nil, fmt.Errorf("failed to count topics: %w", err)
+	}
+
+	s.logger.LogServiceOperation("topic", "list", true, time.Since(start).Milliseconds())
+
+	return &model.TopicListResponse{
+		Topics:     topics,
+		Pagination: model.CalculatePagination(req.Page, req.Limit, total),
+	}, nil
+}
+
+// titleWords returns the distinct meaningful words of a title. A short
+// trailing " - Source" segment, as NewsAPI appends, is dropped first so the
+// same story from different outlets compares equal.
+func titleWords(title string) map[string]struct{} {
+	if i := strings.LastIndex(title, " - "); i > 0 && len(title)-i-3 <= maxTitleSuffixLength {
+		title = title[:i]
+	}
+
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < 2 {
+			continue
+		}
+		if _, ok := titleStopWords[field]; ok {
+			continue
+		}
+		words[field] = struct{}{}
+	}
+
+	return words
+}
+
+// jaccard is the share of words two titles have in common
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for word := range a {
+		if _, ok := b[word]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// MockTopicRepository is a mock implementation of TopicRepository
+type MockTopicRepository struct {
+	mock.Mock
+}
+
+func (m *MockTopicRepository) ListUnclusteredPosts(ctx context.Context, limit int) ([]model.TopicCandidate, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.TopicCandidate), args.Error(1)
+}
+
+func (m *MockTopicRepository) ListClusteredPosts(ctx context.Context, since time.Time, limit int) ([]model.TopicCandidate, error) {
+	args := m.Called(ctx, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.TopicCandidate), args.Error(1)
+}
+
+func (m *MockTopicRepository) AssignTopics(ctx context.Context, assignments map[int64]int64) error {
+	args := m.Called(ctx, assignments)
+	return args.Error(0)
+}
+
+func (m *MockTopicRepository) ListTopics(ctx context.Context, params *model.TopicListParams) ([]model.Topic, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Topic), args.Error(1)
+}
+
+func (m *MockTopicRepository) CountTopics(ctx context.Context, params *model.TopicListParams) (int64, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// TopicServiceTestSuite defines the test suite for TopicService
+type TopicServiceTestSuite struct {
+	suite.Suite
+	mockRepo *MockTopicRepository
+	service  TopicService
+	ctx      context.Context
+	now      time.Time
+}
+
+func (suite *TopicServiceTestSuite) SetupTest() {
+	cfg := &config.Config{App: config.AppConfig{LogLevel: "debug"}}
+
+	suite.mockRepo = new(MockTopicRepository)
+	suite.service = NewTopicService(suite.mockRepo, config.TopicConfig{
+		BatchSize: 100,
+		Window:    48 * time.Hour,
+		Threshold: 0.6,
+	}, logger.New(cfg))
+	suite.ctx = context.Background()
+	suite.now = time.Date(2025, 8, 11, 12, 0, 0, 0, time.UTC)
+}
+
+func (suite *TopicServiceTestSuite) TearDownTest() {
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *TopicServiceTestSuite) TestClusterPendingPostsJoinsExistingTopic() {
+	topicID := int64(3)
+	pending := []model.TopicCandidate{
+		{ID: 10, Title: "Apple unveils the iPhone 17 at its September event - The Verge", PublishedAt: suite.now},
+		{ID: 11, Title: "Central bank raises interest rates again", PublishedAt: suite.now},
+	}
+	clustered := []model.TopicCandidate{
+		{ID: 4, TopicID: &topicID, Title: "Apple unveils iPhone 17 at September event - CNN", PublishedAt: suite.now.Add(-time.Hour)},
+	}
+
+	suite.mockRepo.On("ListUnclusteredPosts", suite.ctx, 100).Return(pending, nil)
+	suite.mockRepo.On("ListClusteredPosts", suite.ctx, suite.now.Add(-48*time.Hour), maxTopicCandidates).Return(clustered, nil)
+	suite.mockRepo.On("AssignTopics", suite.ctx, map[int64]int64{10: 3, 11: 11}).Return(nil)
+
+	result, err := suite.service.ClusterPendingPosts(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.Processed)
+	assert.Equal(suite.T(), 1, result.Clustered)
+}
+
+func (suite *TopicServiceTestSuite) TestClusterPendingPostsWithinBatch() {
+	pending := []model.TopicCandidate{
+		{ID: 20, Title: "Wildfire forces thousands to evacuate in California", PublishedAt: suite.now},
+		{ID: 21, Title: "California wildfire forces thousands to evacuate", PublishedAt: suite.now.Add(time.Hour)},
+		{ID: 22, Title: "California wildfire forces thousands to evacuate", PublishedAt: suite.now.Add(72 * time.Hour)},
+	}
+
+	suite.mockRepo.On("ListUnclusteredPosts", suite.ctx, 100).Return(pending, nil)
+	suite.mockRepo.On("ListClusteredPosts", suite.ctx, suite.now.Add(-48*time.Hour), maxTopicCandidates).Return([]model.TopicCandidate{}, nil)
+	suite.mockRepo.On("AssignTopics", suite.ctx, map[int64]int64{20: 20, 21: 20, 22: 22}).Return(nil)
+
+	result, err := suite.service.ClusterPendingPosts(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, result.Processed)
+	assert.Equal(suite.T(), 1, result.Clustered)
+}
+
+func (suite *TopicServiceTestSuite) TestClusterPendingPostsNothingPending() {
+	suite.mockRepo.On("ListUnclusteredPosts", suite.ctx, 100).Return([]model.TopicCandidate{}, nil)
+
+	result, err := suite.service.ClusterPendingPosts(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.Processed)
+	suite.mockRepo.AssertNotCalled(suite.T(), "AssignTopics", mock.Anything, mock.Anything)
+}
+
+func (suite *TopicServiceTestSuite) TestClusterPendingPostsAssignError() {
+	pending := []model.TopicCandidate{{ID: 1, Title: "Markets rally", PublishedAt: suite.now}}
+
+	suite.mockRepo.On("ListUnclusteredPosts", suite.ctx, 100).Return(pending, nil)
+	suite.mockRepo.On("ListClusteredPosts", suite.ctx, mock.Anything, maxTopicCandidates).Return([]model.TopicCandidate{}, nil)
+	suite.mockRepo.On("AssignTopics", suite.ctx, mock.Anything).Return(errors.New("database error"))
+
+	result, err := suite.service.ClusterPendingPosts(suite.ctx)
+
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "failed to assign topics")
+	assert.Nil(suite.T(), result)
+}
+
+func (suite *TopicServiceTestSuite) TestListTopics() {
+	req := &model.TopicListParams{Page: 2, Limit: 10, MinMembers: 2}
+	topics := []model.Topic{{ID: 5, MemberCount: 3, Representative: &model.Post{ID: 5}}}
+
+	suite.mockRepo.On("ListTopics", suite.ctx, req).Return(topics, nil)
+	suite.mockRepo.On("CountTopics", suite.ctx, req).Return(int64(11), nil)
+
+	result, err := suite.service.ListTopics(suite.ctx, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), topics, result.Topics)
+	assert.Equal(suite.T(), int64(11), result.Pagination.Total)
+	assert.Equal(suite.T(), 2, result.Pagination.TotalPages)
+}
+
+func (suite *TopicServiceTestSuite) TestListTopicsError() {
+	req := &model.TopicListParams{Page: 1, Limit: 10, MinMembers: 2}
+
+	suite.mockRepo.On("ListTopics", suite.ctx, req).Return(nil, errors.New("database error"))
+
+	result, err := suite.service.ListTopics(suite.ctx, req)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+}
+
+func TestTitleWordsDropsSourceSuffixAndStopWords(t *testing.T) {
+	words := titleWords("The Fed raises rates - Reuters")
+
+	assert.Equal(t, map[string]struct{}{"fed": {}, "raises": {}, "rates": {}}, words)
+}
+
+func TestTopicServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TopicServiceTestSuite))
+}
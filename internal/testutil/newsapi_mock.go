@@ -0,0 +1,112 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/model"
+	"github.com/amirzre/news-feed-system/pkg/newsapi"
+)
+
+// MockAPIKey is the only API key the NewsAPI mock accepts
+const MockAPIKey = "integration-test-key"
+
+// NewsAPIMock serves /top-headlines and /everything like NewsAPI. Every query
+// returns ArticlesPerQuery articles on its first page, derived from the query
+// parameters so that distinct queries never return the same article.
+type NewsAPIMock struct {
+	ArticlesPerQuery int
+
+	server   *httptest.Server
+	mu       sync.Mutex
+	requests []string
+}
+
+// NewNewsAPIMock starts a NewsAPI mock that is shut down when t finishes
+func NewNewsAPIMock(t *testing.T) *NewsAPIMock {
+	t.Helper()
+
+	m := &NewsAPIMock{ArticlesPerQuery: 2}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /top-headlines", m.handle)
+	mux.HandleFunc("GET /everything", m.handle)
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+// URL returns the base URL to configure as NEWS_API_BASE_URL
+func (m *NewsAPIMock) URL() string {
+	return m.server.URL
+}
+
+// Requests returns the path and query of every request received so far
+func (m *NewsAPIMock) Requests() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.requests...)
+}
+
+// handle answers a NewsAPI query, rejecting unknown keys and pages beyond
+// the first the way NewsAPI does
+func (m *NewsAPIMock) handle(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	m.requests = append(m.requests, r.URL.RequestURI())
+	perQuery := m.ArticlesPerQuery
+	m.mu.Unlock()
+
+	query := r.URL.Query()
+	if query.Get("apiKey") != MockAPIKey {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{
+			"status": "error", "code": "apiKeyInvalid", "message": "Your API key is invalid",
+		})
+		return
+	}
+
+	if page := query.Get("page"); page != "" && page != "1" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"status": "error", "code": newsapi.CodeMaximumResultsReached, "message": "You have requested too many results",
+		})
+		return
+	}
+
+	key := strings.Trim(strings.Join([]string{
+		strings.TrimPrefix(r.URL.Path, "/"), query.Get("category"), query.Get("country"), query.Get("sources"), query.Get("q"),
+	}, "-"), "-")
+	key = strings.NewReplacer(",", "-", " ", "-").Replace(key)
+
+	resp := model.NewsAPIResponse{Status: "ok", TotalResults: perQuery, Articles: []model.NewsAPIArticleParams{}}
+	published := time.Now().UTC().Truncate(time.Second)
+	for i := range perQuery {
+		description := fmt.Sprintf("Mock article %d for %s", i+1, key)
+		content := fmt.Sprintf("Content of mock article %d for %s", i+1, key)
+
+		article := model.NewsAPIArticleParams{
+			Title:       fmt.Sprintf("Mock %s story %d", key, i+1),
+			Description: &description,
+			URL:         fmt.Sprintf("https://news.example.com/%s/%d", key, i+1),
+			PublishedAt: published.Add(-time.Duration(i) * time.Minute).Format(time.RFC3339),
+			Content:     &content,
+		}
+		article.Source.Name = "Mock News"
+		resp.Articles = append(resp.Articles, article)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// writeJSON writes v as a JSON response with status
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
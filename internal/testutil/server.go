@@ -0,0 +1,176 @@
+// Package testutil boots the complete server for black-box integration tests.
+// The database and Redis come from the environment, as for the server itself,
+// and NewsAPI is replaced by an in-process mock.
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/internal/handler"
+	"github.com/amirzre/news-feed-system/internal/repository"
+	"github.com/amirzre/news-feed-system/internal/service"
+	"github.com/amirzre/news-feed-system/pkg/database"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/response"
+	"github.com/amirzre/news-feed-system/pkg/validator"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// Server is a running instance of the API backed by a freshly migrated
+// database
+type Server struct {
+	URL     string
+	Config  *config.Config
+	NewsAPI *NewsAPIMock
+	Service *service.Service
+
+	client *http.Client
+}
+
+// StartServer wipes the configured database and Redis, applies the
+// migrations and serves the API with the router, middleware and services of
+// cmd/server. The scheduler is never started, so only requests made by the
+// test reach NewsAPI. Everything is shut down when t finishes.
+//
+// The database named by DB_* must be disposable: its public schema is dropped.
+func StartServer(t *testing.T) *Server {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cfg, err := config.Load()
+	require.NoError(t, err, "failed to load configuration")
+
+	mock := NewNewsAPIMock(t)
+	cfg.NewsAPI.BaseURL = mock.URL()
+	cfg.NewsAPI.APIKey = MockAPIKey
+	cfg.NewsAPI.Countries = []string{"us"}
+	cfg.Tenant.Enabled = false
+
+	log := logger.New(cfg)
+	t.Cleanup(func() { log.Close() })
+
+	db, err := database.NewDatabase(cfg, log)
+	require.NoError(t, err, "failed to connect, is the test profile running? see make test-integration")
+	t.Cleanup(db.Close)
+
+	require.NoError(t, resetDatabase(ctx, db.PG), "failed to migrate the test database")
+	require.NoError(t, db.Redis.FlushDB(ctx).Err(), "failed to flush Redis")
+	require.NoError(t, repository.ValidateStatements(ctx, db.PG))
+
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	e.Validator = validator.NewValidator()
+	e.HTTPErrorHandler = response.HTTPErrorHandler
+	e.Use(handler.Recover(log))
+
+	repo := repository.New(db.PG, db.Replicas, db.Redis, log, cfg.Cache)
+	svc := service.New(repo, log, cfg)
+	handler.SetupRoutes(e, handler.New(svc, log))
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	return &Server{
+		URL:     server.URL,
+		Config:  cfg,
+		NewsAPI: mock,
+		Service: svc,
+		client:  server.Client(),
+	}
+}
+
+// Do sends a request to path, which is relative to the API base path, with
+// body encoded as JSON unless it is nil
+func (s *Server) Do(t *testing.T, method, path string, body any) *http.Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, s.URL+handler.APIBasePath+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+// DecodeData decodes the API response envelope of resp, storing its data in
+// v when v is not nil, and returns the envelope
+func DecodeData(t *testing.T, resp *http.Response, v any) response.APIResponse {
+	t.Helper()
+
+	var envelope struct {
+		response.APIResponse
+		Data json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&envelope))
+
+	if v != nil {
+		require.NotEmpty(t, envelope.Data, "response has no data")
+		require.NoError(t, json.Unmarshal(envelope.Data, v))
+	}
+
+	return envelope.APIResponse
+}
+
+// resetDatabase recreates the public schema and applies every up migration
+// in order
+func resetDatabase(ctx context.Context, db *pgxpool.Pool) error {
+	if _, err := db.Exec(ctx, "DROP SCHEMA public CASCADE; CREATE SCHEMA public"); err != nil {
+		return fmt.Errorf("failed to reset schema: %w", err)
+	}
+
+	migrations, err := filepath.Glob(filepath.Join(moduleRoot(), "migrations", "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	slices.Sort(migrations)
+
+	for _, path := range migrations {
+		statements, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec(ctx, string(statements)); err != nil {
+			return fmt.Errorf("migration %s failed: %w", filepath.Base(path), err)
+		}
+	}
+
+	return nil
+}
+
+// moduleRoot returns the repository root, which holds the migrations
+func moduleRoot() string {
+	_, file, _, _ := runtime.Caller(0)
+	return strings.TrimSuffix(filepath.Dir(file), filepath.Join("internal", "testutil"))
+}
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_experiment_events_created_at;
+DROP INDEX IF EXISTS idx_experiment_events_experiment;
+
+DROP TABLE IF EXISTS experiment_events;
@@ -0,0 +1,12 @@
+CREATE TABLE experiment_events (
+    id BIGSERIAL PRIMARY KEY,
+    experiment VARCHAR(100) NOT NULL,
+    variant VARCHAR(100) NOT NULL,
+    event_type VARCHAR(20) NOT NULL,
+    user_id VARCHAR(200) NOT NULL,
+    post_id BIGINT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_experiment_events_experiment ON experiment_events(experiment, variant, event_type);
+CREATE INDEX idx_experiment_events_created_at ON experiment_events(created_at DESC);
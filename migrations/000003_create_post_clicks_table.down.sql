@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_post_clicks_created_at;
+DROP INDEX IF EXISTS idx_post_clicks_post_id;
+
+DROP TABLE IF EXISTS post_clicks;
@@ -0,0 +1,10 @@
+CREATE TABLE post_clicks (
+    id BIGSERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    referrer VARCHAR(1000),
+    user_agent VARCHAR(500),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_post_clicks_post_id ON post_clicks(post_id);
+CREATE INDEX idx_post_clicks_created_at ON post_clicks(created_at DESC);
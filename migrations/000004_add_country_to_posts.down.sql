@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_posts_country_published;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS country;
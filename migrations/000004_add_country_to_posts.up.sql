@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD COLUMN country VARCHAR(2);
+
+CREATE INDEX idx_posts_country_published ON posts(country, published_at DESC);
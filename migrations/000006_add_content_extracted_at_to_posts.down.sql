@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_posts_content_pending;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS content_extracted_at;
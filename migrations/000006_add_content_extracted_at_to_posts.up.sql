@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD COLUMN content_extracted_at TIMESTAMP;
+
+CREATE INDEX idx_posts_content_pending ON posts(created_at DESC) WHERE content_extracted_at IS NULL;
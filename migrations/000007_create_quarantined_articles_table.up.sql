@@ -0,0 +1,11 @@
+CREATE TABLE quarantined_articles (
+    id BIGSERIAL PRIMARY KEY,
+    url VARCHAR(1000) UNIQUE NOT NULL,
+    title VARCHAR(500) NOT NULL,
+    source VARCHAR(100),
+    rule VARCHAR(50) NOT NULL,
+    reason VARCHAR(500) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_quarantined_articles_created_at ON quarantined_articles(created_at DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD COLUMN sensitive BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_posts_safe_published ON posts(published_at DESC) WHERE NOT sensitive;
@@ -0,0 +1,6 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS comment_count;
+
+DROP INDEX IF EXISTS idx_comments_parent_id;
+DROP INDEX IF EXISTS idx_comments_post_root;
+
+DROP TABLE IF EXISTS comments;
@@ -0,0 +1,15 @@
+CREATE TABLE comments (
+    id BIGSERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    parent_id BIGINT REFERENCES comments(id) ON DELETE CASCADE,
+    author VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    deleted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_comments_post_root ON comments(post_id, created_at) WHERE parent_id IS NULL;
+CREATE INDEX idx_comments_parent_id ON comments(parent_id);
+
+ALTER TABLE posts ADD COLUMN comment_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,12 @@
+CREATE TABLE post_reactions (
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id)
+);
+
+ALTER TABLE posts ADD COLUMN reaction_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_posts_reaction_count ON posts(reaction_count DESC, published_at DESC);
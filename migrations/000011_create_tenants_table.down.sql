@@ -0,0 +1,37 @@
+DROP POLICY IF EXISTS tenant_isolation ON quarantined_articles;
+ALTER TABLE quarantined_articles DISABLE ROW LEVEL SECURITY;
+ALTER TABLE quarantined_articles NO FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON experiment_events;
+ALTER TABLE experiment_events DISABLE ROW LEVEL SECURITY;
+ALTER TABLE experiment_events NO FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON post_clicks;
+ALTER TABLE post_clicks DISABLE ROW LEVEL SECURITY;
+ALTER TABLE post_clicks NO FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON post_reactions;
+ALTER TABLE post_reactions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE post_reactions NO FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON comments;
+ALTER TABLE comments DISABLE ROW LEVEL SECURITY;
+ALTER TABLE comments NO FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON posts;
+ALTER TABLE posts DISABLE ROW LEVEL SECURITY;
+ALTER TABLE posts NO FORCE ROW LEVEL SECURITY;
+
+DROP INDEX IF EXISTS idx_posts_tenant_published;
+
+-- Restoring the global URL constraints fails if several tenants stored the same article
+ALTER TABLE quarantined_articles DROP CONSTRAINT IF EXISTS quarantined_articles_tenant_url_key;
+ALTER TABLE quarantined_articles ADD CONSTRAINT quarantined_articles_url_key UNIQUE (url);
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_tenant_url_key;
+ALTER TABLE posts ADD CONSTRAINT posts_url_key UNIQUE (url);
+
+ALTER TABLE quarantined_articles DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE experiment_events DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE post_clicks DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE post_reactions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE comments DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS tenant_id;
+
+DROP FUNCTION IF EXISTS current_tenant();
+
+DROP TABLE IF EXISTS tenants;
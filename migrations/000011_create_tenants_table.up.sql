@@ -0,0 +1,60 @@
+CREATE TABLE tenants (
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    news_api_key VARCHAR(100),
+    news_api_daily_quota INTEGER NOT NULL DEFAULT 0,
+    sources TEXT[] NOT NULL DEFAULT '{}',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+INSERT INTO tenants (id, name) VALUES ('default', 'Default');
+
+-- current_tenant returns the tenant the session is scoped to. The application
+-- sets app.tenant_id on each connection it hands out; sessions that never set
+-- it, such as migrations and single-tenant deployments, see the default tenant.
+CREATE FUNCTION current_tenant() RETURNS VARCHAR
+    LANGUAGE sql STABLE
+    AS $$ SELECT COALESCE(NULLIF(current_setting('app.tenant_id', true), ''), 'default') $$;
+
+ALTER TABLE posts ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id);
+ALTER TABLE comments ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id);
+ALTER TABLE post_reactions ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id);
+ALTER TABLE post_clicks ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id);
+ALTER TABLE experiment_events ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id);
+ALTER TABLE quarantined_articles ADD COLUMN tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id);
+
+-- The same article may be stored once per tenant
+ALTER TABLE posts DROP CONSTRAINT posts_url_key;
+ALTER TABLE posts ADD CONSTRAINT posts_tenant_url_key UNIQUE (tenant_id, url);
+ALTER TABLE quarantined_articles DROP CONSTRAINT quarantined_articles_url_key;
+ALTER TABLE quarantined_articles ADD CONSTRAINT quarantined_articles_tenant_url_key UNIQUE (tenant_id, url);
+
+CREATE INDEX idx_posts_tenant_published ON posts(tenant_id, published_at DESC);
+
+-- Every query sees only the rows of the session's tenant. FORCE applies the
+-- policies to the table owner too; superusers and BYPASSRLS roles still skip them.
+ALTER TABLE posts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE posts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON posts USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+ALTER TABLE comments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE comments FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON comments USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+ALTER TABLE post_reactions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_reactions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON post_reactions USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+ALTER TABLE post_clicks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_clicks FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON post_clicks USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+ALTER TABLE experiment_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE experiment_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON experiment_events USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+ALTER TABLE quarantined_articles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE quarantined_articles FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON quarantined_articles USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
@@ -0,0 +1,4 @@
+ALTER TABLE posts ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'published'
+    CHECK (status IN ('draft', 'published', 'hidden'));
+
+CREATE INDEX idx_posts_status_published ON posts(status, published_at DESC);
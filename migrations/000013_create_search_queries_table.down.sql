@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_search_queries_term;
+DROP INDEX IF EXISTS idx_search_queries_created_at;
+
+DROP TABLE IF EXISTS search_queries;
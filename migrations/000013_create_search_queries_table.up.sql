@@ -0,0 +1,16 @@
+CREATE TABLE search_queries (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    term VARCHAR(200) NOT NULL,
+    result_count INTEGER NOT NULL,
+    latency_ms INTEGER NOT NULL,
+    zero_results BOOLEAN NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_search_queries_created_at ON search_queries(created_at DESC);
+CREATE INDEX idx_search_queries_term ON search_queries(term);
+
+ALTER TABLE search_queries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE search_queries FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON search_queries USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
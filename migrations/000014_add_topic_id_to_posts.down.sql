@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_posts_unclustered;
+DROP INDEX IF EXISTS idx_posts_topic_id;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS topic_id;
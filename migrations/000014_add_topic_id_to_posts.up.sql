@@ -0,0 +1,6 @@
+-- Posts covering the same story share a topic_id, the ID of the first post
+-- clustered into the topic. NULL means the post has not been clustered yet.
+ALTER TABLE posts ADD COLUMN topic_id BIGINT;
+
+CREATE INDEX idx_posts_topic_id ON posts(topic_id);
+CREATE INDEX idx_posts_unclustered ON posts(id) WHERE topic_id IS NULL;
@@ -0,0 +1,14 @@
+-- fetch_watermarks records, per tenant, the newest article seen for each
+-- aggregation query so the next run can resume from there
+CREATE TABLE fetch_watermarks (
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('top_headlines', 'everything', 'source')),
+    key VARCHAR(100) NOT NULL,
+    fetched_until TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, scope, key)
+);
+
+ALTER TABLE fetch_watermarks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE fetch_watermarks FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON fetch_watermarks USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
@@ -0,0 +1,3 @@
+-- Post statistics count posts per creation day, category and source; covering
+-- both columns lets those counts run as index-only scans within a tenant
+CREATE INDEX idx_posts_tenant_created_stats ON posts(tenant_id, created_at) INCLUDE (category, source);
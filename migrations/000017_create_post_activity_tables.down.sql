@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS post_activity_rollups;
+DROP TABLE IF EXISTS post_activity_hourly;
@@ -0,0 +1,29 @@
+-- post_activity_hourly holds, per tenant and hour, the posts ingested and the
+-- views and clicks received for each source and category. It is filled
+-- incrementally by the stats rollup job and read by the top stats endpoint.
+CREATE TABLE post_activity_hourly (
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    hour TIMESTAMP NOT NULL,
+    dimension VARCHAR(20) NOT NULL CHECK (dimension IN ('source', 'category')),
+    key VARCHAR(100) NOT NULL,
+    posts BIGINT NOT NULL DEFAULT 0,
+    views BIGINT NOT NULL DEFAULT 0,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, hour, dimension, key)
+);
+
+-- post_activity_rollups records, per tenant, how far the rollup has run
+CREATE TABLE post_activity_rollups (
+    tenant_id VARCHAR(50) PRIMARY KEY DEFAULT current_tenant() REFERENCES tenants(id),
+    rolled_up_until TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+ALTER TABLE post_activity_hourly ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_activity_hourly FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON post_activity_hourly USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+ALTER TABLE post_activity_rollups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_activity_rollups FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON post_activity_rollups USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
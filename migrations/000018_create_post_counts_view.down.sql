@@ -0,0 +1,5 @@
+DROP FUNCTION IF EXISTS refresh_post_counts();
+
+DROP TABLE IF EXISTS materialized_view_refreshes;
+
+DROP MATERIALIZED VIEW IF EXISTS post_counts_daily;
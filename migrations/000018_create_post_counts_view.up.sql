@@ -0,0 +1,44 @@
+-- post_counts_daily counts posts per tenant, day, category, source and status
+-- so counts and ingestion statistics need not scan posts. It is refreshed by
+-- the stats-view-refresh job; readers fall back to posts once the last
+-- refresh is older than STATS_VIEW_STALE_TOLERANCE.
+--
+-- Materialized views do not support row-level security, so every read must
+-- filter on tenant_id itself. The view is refreshed with the privileges of
+-- its owner: run migrations as a role that bypasses row-level security, as
+-- the default superuser does, or the view only counts the default tenant.
+CREATE MATERIALIZED VIEW post_counts_daily AS
+SELECT tenant_id,
+    created_at::date AS day,
+    COALESCE(category, '') AS category,
+    source,
+    status,
+    COUNT(*) AS posts
+FROM posts
+GROUP BY 1, 2, 3, 4, 5;
+
+-- A unique index lets the view be refreshed without blocking readers
+CREATE UNIQUE INDEX idx_post_counts_daily_key ON post_counts_daily(tenant_id, day, category, source, status);
+
+-- materialized_view_refreshes records when each materialized view was last
+-- refreshed; it holds no tenant data
+CREATE TABLE materialized_view_refreshes (
+    name VARCHAR(100) PRIMARY KEY,
+    refreshed_at TIMESTAMP NOT NULL
+);
+
+INSERT INTO materialized_view_refreshes (name, refreshed_at) VALUES ('post_counts_daily', NOW());
+
+-- refresh_post_counts refreshes post_counts_daily as its owner, so the
+-- application role needs neither ownership of the view nor access to every
+-- tenant, and records the refresh
+CREATE FUNCTION refresh_post_counts() RETURNS VOID
+    LANGUAGE plpgsql SECURITY DEFINER SET search_path = public
+    AS $$
+BEGIN
+    REFRESH MATERIALIZED VIEW CONCURRENTLY post_counts_daily;
+
+    INSERT INTO materialized_view_refreshes (name, refreshed_at) VALUES ('post_counts_daily', NOW())
+    ON CONFLICT (name) DO UPDATE SET refreshed_at = EXCLUDED.refreshed_at;
+END
+$$;
@@ -0,0 +1,81 @@
+DROP MATERIALIZED VIEW IF EXISTS post_counts_daily;
+
+DROP TRIGGER IF EXISTS posts_sync_urls ON posts;
+DROP FUNCTION IF EXISTS sync_post_urls();
+DROP FUNCTION IF EXISTS ensure_posts_partitions(INTEGER);
+DROP FUNCTION IF EXISTS create_posts_partition(DATE);
+DROP TABLE IF EXISTS post_urls;
+
+ALTER TABLE posts RENAME TO posts_partitioned;
+ALTER SEQUENCE posts_id_seq OWNED BY NONE;
+
+CREATE TABLE posts (
+    id INTEGER PRIMARY KEY DEFAULT nextval('posts_id_seq'),
+    title VARCHAR(500) NOT NULL,
+    description TEXT,
+    content TEXT,
+    url VARCHAR(1000) NOT NULL,
+    source VARCHAR(100) NOT NULL,
+    category VARCHAR(50),
+    image_url VARCHAR(1000),
+    published_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    country VARCHAR(2),
+    version INTEGER NOT NULL DEFAULT 1,
+    content_extracted_at TIMESTAMP,
+    sensitive BOOLEAN NOT NULL DEFAULT FALSE,
+    comment_count INTEGER NOT NULL DEFAULT 0,
+    reaction_count INTEGER NOT NULL DEFAULT 0,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published', 'hidden')),
+    topic_id BIGINT
+);
+
+INSERT INTO posts (
+    id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at,
+    country, version, content_extracted_at, sensitive, comment_count, reaction_count, tenant_id, status, topic_id
+)
+SELECT id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at,
+    country, version, content_extracted_at, sensitive, comment_count, reaction_count, tenant_id, status, topic_id
+FROM posts_partitioned;
+
+DROP TABLE posts_partitioned;
+ALTER SEQUENCE posts_id_seq OWNED BY posts.id;
+
+ALTER TABLE posts ADD CONSTRAINT posts_tenant_url_key UNIQUE (tenant_id, url);
+
+CREATE INDEX idx_posts_published_at ON posts(published_at DESC);
+CREATE INDEX idx_posts_source ON posts(source);
+CREATE INDEX idx_posts_category ON posts(category);
+CREATE INDEX idx_posts_created_at ON posts(created_at DESC);
+CREATE INDEX idx_posts_category_published ON posts(category, published_at DESC);
+CREATE INDEX idx_posts_country_published ON posts(country, published_at DESC);
+CREATE INDEX idx_posts_content_pending ON posts(created_at DESC) WHERE content_extracted_at IS NULL;
+CREATE INDEX idx_posts_safe_published ON posts(published_at DESC) WHERE NOT sensitive;
+CREATE INDEX idx_posts_reaction_count ON posts(reaction_count DESC, published_at DESC);
+CREATE INDEX idx_posts_tenant_published ON posts(tenant_id, published_at DESC);
+CREATE INDEX idx_posts_status_published ON posts(status, published_at DESC);
+CREATE INDEX idx_posts_topic_id ON posts(topic_id);
+CREATE INDEX idx_posts_unclustered ON posts(id) WHERE topic_id IS NULL;
+CREATE INDEX idx_posts_tenant_created_stats ON posts(tenant_id, created_at) INCLUDE (category, source);
+
+ALTER TABLE posts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE posts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON posts USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+ALTER TABLE post_clicks ADD CONSTRAINT post_clicks_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE;
+ALTER TABLE comments ADD CONSTRAINT comments_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE;
+ALTER TABLE post_reactions ADD CONSTRAINT post_reactions_post_id_fkey FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE;
+
+CREATE MATERIALIZED VIEW post_counts_daily AS
+SELECT tenant_id,
+    created_at::date AS day,
+    COALESCE(category, '') AS category,
+    source,
+    status,
+    COUNT(*) AS posts
+FROM posts
+GROUP BY 1, 2, 3, 4, 5;
+
+CREATE UNIQUE INDEX idx_post_counts_daily_key ON post_counts_daily(tenant_id, day, category, source, status);
@@ -0,0 +1,205 @@
+-- posts is range partitioned by month of published_at, so queries bounded by
+-- publication date only scan the months they cover. Posts without a
+-- publication date, and any month without its own partition yet, land in
+-- posts_default. ensure_posts_partitions, run by the partition maintenance
+-- job, creates the upcoming months ahead of time.
+--
+-- Like the materialized views, the data is copied with the privileges of the
+-- migration role: run migrations as a role that bypasses row-level security,
+-- as the default superuser does, or only the default tenant is kept.
+
+-- The view reads posts, so it is rebuilt on the partitioned table below
+DROP MATERIALIZED VIEW post_counts_daily;
+
+ALTER TABLE posts RENAME TO posts_legacy;
+ALTER SEQUENCE posts_id_seq OWNED BY NONE;
+
+-- Foreign keys cannot reference a partitioned table whose unique keys lack
+-- the partition key; the trigger below removes dependent rows instead
+ALTER TABLE post_clicks DROP CONSTRAINT IF EXISTS post_clicks_post_id_fkey;
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS comments_post_id_fkey;
+ALTER TABLE post_reactions DROP CONSTRAINT IF EXISTS post_reactions_post_id_fkey;
+
+CREATE TABLE posts (
+    id INTEGER NOT NULL DEFAULT nextval('posts_id_seq'),
+    title VARCHAR(500) NOT NULL,
+    description TEXT,
+    content TEXT,
+    url VARCHAR(1000) NOT NULL,
+    source VARCHAR(100) NOT NULL,
+    category VARCHAR(50),
+    image_url VARCHAR(1000),
+    published_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    country VARCHAR(2),
+    version INTEGER NOT NULL DEFAULT 1,
+    content_extracted_at TIMESTAMP,
+    sensitive BOOLEAN NOT NULL DEFAULT FALSE,
+    comment_count INTEGER NOT NULL DEFAULT 0,
+    reaction_count INTEGER NOT NULL DEFAULT 0,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published', 'hidden')),
+    topic_id BIGINT
+) PARTITION BY RANGE (published_at);
+
+CREATE TABLE posts_default PARTITION OF posts DEFAULT;
+
+-- post_urls keeps URLs unique per tenant across partitions, which a unique
+-- index on posts could only do if it included published_at
+CREATE TABLE post_urls (
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    url VARCHAR(1000) NOT NULL,
+    post_id INTEGER NOT NULL,
+    PRIMARY KEY (tenant_id, url)
+);
+
+CREATE INDEX idx_post_urls_post_id ON post_urls(post_id);
+
+-- create_posts_partition creates the partition holding the month starting
+-- at month_start unless it exists, moving any rows the default partition
+-- holds for that month into it. It reports whether a partition was created.
+CREATE FUNCTION create_posts_partition(month_start DATE) RETURNS BOOLEAN
+    LANGUAGE plpgsql SECURITY DEFINER SET search_path = public
+    AS $$
+DECLARE
+    partition_name TEXT := format('posts_%s', to_char(month_start, 'YYYY_MM'));
+    month_end DATE := (month_start + INTERVAL '1 month')::date;
+BEGIN
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+
+    -- A partition cannot be added while the default partition holds rows in
+    -- its range, so those rows are moved across while it is detached
+    IF EXISTS (SELECT 1 FROM posts_default WHERE published_at >= month_start AND published_at < month_end) THEN
+        ALTER TABLE posts DETACH PARTITION posts_default;
+        EXECUTE format('CREATE TABLE %I PARTITION OF posts FOR VALUES FROM (%L) TO (%L)', partition_name, month_start, month_end);
+        EXECUTE format('INSERT INTO %I SELECT * FROM posts_default WHERE published_at >= %L AND published_at < %L', partition_name, month_start, month_end);
+        DELETE FROM posts_default WHERE published_at >= month_start AND published_at < month_end;
+        ALTER TABLE posts ATTACH PARTITION posts_default DEFAULT;
+    ELSE
+        EXECUTE format('CREATE TABLE %I PARTITION OF posts FOR VALUES FROM (%L) TO (%L)', partition_name, month_start, month_end);
+    END IF;
+
+    RETURN TRUE;
+END
+$$;
+
+-- ensure_posts_partitions creates the partitions of the current month and
+-- the months_ahead following it, and of every month that has posts waiting
+-- in the default partition. It returns the number of partitions created.
+CREATE FUNCTION ensure_posts_partitions(months_ahead INTEGER) RETURNS INTEGER
+    LANGUAGE plpgsql SECURITY DEFINER SET search_path = public
+    AS $$
+DECLARE
+    month_start DATE;
+    created INTEGER := 0;
+BEGIN
+    FOR month_start IN
+        SELECT generate_series(date_trunc('month', NOW()), date_trunc('month', NOW()) + make_interval(months => months_ahead), INTERVAL '1 month')::date
+        UNION
+        SELECT DISTINCT date_trunc('month', published_at)::date FROM posts_default WHERE published_at IS NOT NULL
+        ORDER BY 1
+    LOOP
+        IF create_posts_partition(month_start) THEN
+            created := created + 1;
+        END IF;
+    END LOOP;
+
+    RETURN created;
+END
+$$;
+
+-- Partitions are created before the copy so no row passes through the
+-- default partition
+SELECT create_posts_partition(month_start)
+FROM (SELECT DISTINCT date_trunc('month', published_at)::date AS month_start FROM posts_legacy WHERE published_at IS NOT NULL) months;
+SELECT ensure_posts_partitions(3);
+
+INSERT INTO posts (
+    id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at,
+    country, version, content_extracted_at, sensitive, comment_count, reaction_count, tenant_id, status, topic_id
+)
+SELECT id, title, description, content, url, source, category, image_url, published_at, created_at, updated_at,
+    country, version, content_extracted_at, sensitive, comment_count, reaction_count, tenant_id, status, topic_id
+FROM posts_legacy;
+
+INSERT INTO post_urls (tenant_id, url, post_id) SELECT tenant_id, url, id FROM posts;
+
+DROP TABLE posts_legacy;
+ALTER SEQUENCE posts_id_seq OWNED BY posts.id;
+
+-- Indexes are created on every partition, existing and future
+CREATE INDEX idx_posts_id ON posts(id);
+CREATE INDEX idx_posts_published_at ON posts(published_at DESC);
+CREATE INDEX idx_posts_source ON posts(source);
+CREATE INDEX idx_posts_category ON posts(category);
+CREATE INDEX idx_posts_created_at ON posts(created_at DESC);
+CREATE INDEX idx_posts_category_published ON posts(category, published_at DESC);
+CREATE INDEX idx_posts_country_published ON posts(country, published_at DESC);
+CREATE INDEX idx_posts_content_pending ON posts(created_at DESC) WHERE content_extracted_at IS NULL;
+CREATE INDEX idx_posts_safe_published ON posts(published_at DESC) WHERE NOT sensitive;
+CREATE INDEX idx_posts_reaction_count ON posts(reaction_count DESC, published_at DESC);
+CREATE INDEX idx_posts_tenant_published ON posts(tenant_id, published_at DESC);
+CREATE INDEX idx_posts_status_published ON posts(status, published_at DESC);
+CREATE INDEX idx_posts_topic_id ON posts(topic_id);
+CREATE INDEX idx_posts_unclustered ON posts(id) WHERE topic_id IS NULL;
+CREATE INDEX idx_posts_tenant_created_stats ON posts(tenant_id, created_at) INCLUDE (category, source);
+
+ALTER TABLE posts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE posts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON posts USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+ALTER TABLE post_urls ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_urls FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON post_urls USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+-- sync_post_urls claims the URL of each inserted post and, once a post is
+-- gone, releases its URL and deletes its clicks, comments and reactions. An
+-- update that moves a post to another partition deletes and inserts the row,
+-- so a post still present under its ID is left alone.
+CREATE FUNCTION sync_post_urls() RETURNS TRIGGER
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO post_urls (tenant_id, url, post_id) VALUES (NEW.tenant_id, NEW.url, NEW.id)
+        ON CONFLICT (tenant_id, url) DO NOTHING;
+
+        IF NOT FOUND AND NOT EXISTS (
+            SELECT 1 FROM post_urls WHERE tenant_id = NEW.tenant_id AND url = NEW.url AND post_id = NEW.id
+        ) THEN
+            RAISE unique_violation USING
+                MESSAGE = format('duplicate post url %s', NEW.url),
+                CONSTRAINT = 'post_urls_pkey';
+        END IF;
+
+        RETURN NULL;
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM posts WHERE id = OLD.id) THEN
+        DELETE FROM post_urls WHERE tenant_id = OLD.tenant_id AND url = OLD.url AND post_id = OLD.id;
+        DELETE FROM post_clicks WHERE post_id = OLD.id;
+        DELETE FROM comments WHERE post_id = OLD.id;
+        DELETE FROM post_reactions WHERE post_id = OLD.id;
+    END IF;
+
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER posts_sync_urls AFTER INSERT OR DELETE ON posts
+    FOR EACH ROW EXECUTE FUNCTION sync_post_urls();
+
+CREATE MATERIALIZED VIEW post_counts_daily AS
+SELECT tenant_id,
+    created_at::date AS day,
+    COALESCE(category, '') AS category,
+    source,
+    status,
+    COUNT(*) AS posts
+FROM posts
+GROUP BY 1, 2, 3, 4, 5;
+
+CREATE UNIQUE INDEX idx_post_counts_daily_key ON post_counts_daily(tenant_id, day, category, source, status);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS posts_content_hash ON posts;
+DROP FUNCTION IF EXISTS set_post_content_hash();
+ALTER TABLE posts DROP COLUMN IF EXISTS content_hash;
+DROP FUNCTION IF EXISTS post_content_hash(TEXT, TEXT, TEXT, TEXT, TEXT);
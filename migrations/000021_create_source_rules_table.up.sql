@@ -0,0 +1,16 @@
+-- source_rules is the admin-managed blocklist and allowlist of article
+-- domains and source names, checked before articles are stored
+CREATE TABLE source_rules (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    list VARCHAR(10) NOT NULL CHECK (list IN ('block', 'allow')),
+    type VARCHAR(10) NOT NULL CHECK (type IN ('domain', 'source')),
+    value VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (tenant_id, type, value)
+);
+
+ALTER TABLE source_rules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE source_rules FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON source_rules USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
@@ -0,0 +1,18 @@
+DROP INDEX idx_posts_tenant_created_stats;
+CREATE INDEX idx_posts_tenant_created_stats ON posts(tenant_id, created_at) INCLUDE (category, source);
+
+DROP MATERIALIZED VIEW post_counts_daily;
+
+CREATE MATERIALIZED VIEW post_counts_daily AS
+SELECT tenant_id,
+    created_at::date AS day,
+    COALESCE(category, '') AS category,
+    source,
+    status,
+    COUNT(*) AS posts
+FROM posts
+GROUP BY 1, 2, 3, 4, 5;
+
+CREATE UNIQUE INDEX idx_post_counts_daily_key ON post_counts_daily(tenant_id, day, category, source, status);
+
+UPDATE materialized_view_refreshes SET refreshed_at = NOW() WHERE name = 'post_counts_daily';
@@ -0,0 +1,24 @@
+-- post_counts_daily gains the country of the posts, so ingestion statistics
+-- can be grouped by country. The view is rebuilt with the privileges of the
+-- migration role: run migrations as a role that bypasses row-level security,
+-- as the default superuser does, or the view only counts the default tenant.
+DROP MATERIALIZED VIEW post_counts_daily;
+
+CREATE MATERIALIZED VIEW post_counts_daily AS
+SELECT tenant_id,
+    created_at::date AS day,
+    COALESCE(category, '') AS category,
+    source,
+    COALESCE(country, '') AS country,
+    status,
+    COUNT(*) AS posts
+FROM posts
+GROUP BY 1, 2, 3, 4, 5, 6;
+
+CREATE UNIQUE INDEX idx_post_counts_daily_key ON post_counts_daily(tenant_id, day, category, source, country, status);
+
+-- Keep statistics over recent posts index-only when grouped by country
+DROP INDEX idx_posts_tenant_created_stats;
+CREATE INDEX idx_posts_tenant_created_stats ON posts(tenant_id, created_at) INCLUDE (category, source, country);
+
+UPDATE materialized_view_refreshes SET refreshed_at = NOW() WHERE name = 'post_counts_daily';
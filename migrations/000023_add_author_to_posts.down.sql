@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_posts_author_published;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS author;
@@ -0,0 +1,3 @@
+ALTER TABLE posts ADD COLUMN author VARCHAR(255);
+
+CREATE INDEX idx_posts_author_published ON posts(author, published_at DESC);
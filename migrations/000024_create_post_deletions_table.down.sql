@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS posts_record_deletion ON posts;
+DROP FUNCTION IF EXISTS record_post_deletion();
+DROP INDEX IF EXISTS idx_posts_tenant_updated;
+DROP TABLE IF EXISTS post_deletions;
@@ -0,0 +1,37 @@
+-- post_deletions keeps a tombstone for every deleted post, so the change
+-- feed can tell syncing clients which posts are gone. Tombstones older than
+-- the change feed retention are pruned.
+CREATE TABLE post_deletions (
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    post_id INTEGER NOT NULL,
+    deleted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, post_id)
+);
+
+CREATE INDEX idx_post_deletions_tenant_deleted ON post_deletions(tenant_id, deleted_at, post_id);
+
+ALTER TABLE post_deletions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_deletions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON post_deletions USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+-- The change feed pages through posts in order of last update
+CREATE INDEX idx_posts_tenant_updated ON posts(tenant_id, updated_at, id);
+
+-- record_post_deletion writes the tombstone of a deleted post. Like
+-- sync_post_urls, it leaves alone a post an update moved to another
+-- partition, which is deleted and inserted again under the same ID.
+CREATE FUNCTION record_post_deletion() RETURNS TRIGGER
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM posts WHERE id = OLD.id) THEN
+        INSERT INTO post_deletions (tenant_id, post_id) VALUES (OLD.tenant_id, OLD.id)
+        ON CONFLICT (tenant_id, post_id) DO UPDATE SET deleted_at = NOW();
+    END IF;
+
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER posts_record_deletion AFTER DELETE ON posts
+    FOR EACH ROW EXECUTE FUNCTION record_post_deletion();
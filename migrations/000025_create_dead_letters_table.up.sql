@@ -0,0 +1,23 @@
+-- dead_letters keeps the aggregated articles that could not be stored, with
+-- the error of their last attempt, until they are retried or discarded. An
+-- article that fails again updates its entry.
+CREATE TABLE dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    url VARCHAR(1000) NOT NULL,
+    source VARCHAR(100),
+    country VARCHAR(2),
+    payload JSONB NOT NULL,
+    error_type VARCHAR(50) NOT NULL,
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_failed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT dead_letters_tenant_url_key UNIQUE (tenant_id, url)
+);
+
+CREATE INDEX idx_dead_letters_tenant_failed ON dead_letters(tenant_id, last_failed_at DESC);
+
+ALTER TABLE dead_letters ENABLE ROW LEVEL SECURITY;
+ALTER TABLE dead_letters FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON dead_letters USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS posts_unpin_deleted ON posts;
+DROP FUNCTION IF EXISTS unpin_deleted_post();
+DROP TABLE IF EXISTS pinned_posts;
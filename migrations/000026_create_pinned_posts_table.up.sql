@@ -0,0 +1,35 @@
+-- pinned_posts holds the posts editors pinned to the top of the post list and
+-- the ranked feed. Positions start at 1; a pin with an expiry stops showing
+-- once it passes and is dropped the next time a post is pinned.
+CREATE TABLE pinned_posts (
+    tenant_id VARCHAR(50) NOT NULL DEFAULT current_tenant() REFERENCES tenants(id),
+    post_id INTEGER NOT NULL,
+    position INTEGER NOT NULL CHECK (position > 0),
+    pinned_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP,
+    PRIMARY KEY (tenant_id, post_id)
+);
+
+CREATE INDEX idx_pinned_posts_tenant_position ON pinned_posts(tenant_id, position);
+
+ALTER TABLE pinned_posts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE pinned_posts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON pinned_posts USING (tenant_id = current_tenant()) WITH CHECK (tenant_id = current_tenant());
+
+-- unpin_deleted_post drops the pin of a deleted post. Like sync_post_urls, it
+-- leaves alone a post an update moved to another partition, which is deleted
+-- and inserted again under the same ID.
+CREATE FUNCTION unpin_deleted_post() RETURNS TRIGGER
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM posts WHERE id = OLD.id) THEN
+        DELETE FROM pinned_posts WHERE tenant_id = OLD.tenant_id AND post_id = OLD.id;
+    END IF;
+
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER posts_unpin_deleted AFTER DELETE ON posts
+    FOR EACH ROW EXECUTE FUNCTION unpin_deleted_post();
@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS count_estimate(TEXT);
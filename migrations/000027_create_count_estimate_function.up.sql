@@ -0,0 +1,15 @@
+-- count_estimate returns the planner's estimate of the rows query returns,
+-- without running it. The estimate is derived from pg_class.reltuples and the
+-- column statistics, so it is only as fresh as the last ANALYZE. query runs
+-- with the caller's rights, row-level security included.
+CREATE FUNCTION count_estimate(query TEXT) RETURNS BIGINT
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    plan JSONB;
+BEGIN
+    EXECUTE 'EXPLAIN (FORMAT JSON) ' || query INTO plan;
+
+    RETURN (plan->0->'Plan'->>'Plan Rows')::BIGINT;
+END
+$$;
@@ -0,0 +1,63 @@
+package botdetect
+
+import (
+	"context"
+	"strings"
+)
+
+// defaultTokens appear in the user agents of crawlers, link previewers,
+// monitors and scripted HTTP clients, but not in those of browsers
+var defaultTokens = []string{
+	"bot", "crawl", "spider", "slurp", "scrape", "fetch", "preview",
+	"facebookexternalhit", "embedly", "headless", "phantomjs", "lighthouse",
+	"monitor", "uptime", "pingdom", "curl", "wget", "httpie", "python-",
+	"go-http-client", "java/", "okhttp", "libwww", "axios", "node-fetch",
+}
+
+// Matcher recognizes the user agents of bots
+type Matcher struct {
+	tokens []string
+}
+
+// NewMatcher returns a matcher for the known bot user agents and those
+// containing any of extra, compared case-insensitively
+func NewMatcher(extra []string) *Matcher {
+	tokens := append([]string(nil), defaultTokens...)
+	for _, token := range extra {
+		if token = strings.ToLower(strings.TrimSpace(token)); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return &Matcher{tokens: tokens}
+}
+
+// Match reports whether userAgent belongs to a bot. Browsers always send a
+// user agent, so an empty one is taken for a script.
+func (m *Matcher) Match(userAgent string) bool {
+	userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+	if userAgent == "" {
+		return true
+	}
+
+	for _, token := range m.tokens {
+		if strings.Contains(userAgent, token) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type contextKey struct{}
+
+// WithBot marks ctx as serving a bot
+func WithBot(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, true)
+}
+
+// FromContext reports whether ctx was marked as serving a bot
+func FromContext(ctx context.Context) bool {
+	bot, _ := ctx.Value(contextKey{}).(bool)
+	return bot
+}
@@ -0,0 +1,40 @@
+package cachetrace
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Trace tallies the cache reads made while serving a request
+type Trace struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type contextKey struct{}
+
+// WithTrace returns a context whose cache reads are tallied in the returned trace
+func WithTrace(ctx context.Context) (context.Context, *Trace) {
+	trace := &Trace{}
+	return context.WithValue(ctx, contextKey{}, trace), trace
+}
+
+// Record tallies a cache read in the trace of ctx, if it has one
+func Record(ctx context.Context, hit bool) {
+	trace, ok := ctx.Value(contextKey{}).(*Trace)
+	if !ok {
+		return
+	}
+
+	if hit {
+		trace.hits.Add(1)
+	} else {
+		trace.misses.Add(1)
+	}
+}
+
+// Hit reports whether the request was served from the cache: it read the
+// cache at least once and never missed
+func (t *Trace) Hit() bool {
+	return t.hits.Load() > 0 && t.misses.Load() == 0
+}
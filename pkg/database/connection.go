@@ -2,54 +2,88 @@ package database
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/amirzre/news-feed-system/pkg/tenant"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
 
 type Database struct {
-	PG    *pgxpool.Pool
-	Redis *redis.Client
+	PG       *pgxpool.Pool
+	Replicas *ReplicaSet
+	// Redis is a single node, sentinel failover or cluster client depending
+	// on the configured mode
+	Redis redis.UniversalClient
+	// Queries times the queries run through every pool
+	Queries *QueryTracer
+}
+
+// PoolStats is a snapshot of one PostgreSQL connection pool
+type PoolStats struct {
+	Name                 string
+	Acquired             int32
+	Idle                 int32
+	Total                int32
+	Max                  int32
+	AcquireCount         int64
+	EmptyAcquireCount    int64
+	CanceledAcquireCount int64
+	// AcquireDuration is the total time spent acquiring connections, and
+	// EmptyAcquireWait the part of it spent waiting on an exhausted pool
+	AcquireDuration  time.Duration
+	EmptyAcquireWait time.Duration
 }
 
 // NewDatabase creates new database connections
-func NewDatabase(cfg *config.Config) (*Database, error) {
-	pg, err := newPostgreSQLConnection(cfg)
+func NewDatabase(cfg *config.Config, log *logger.Logger) (*Database, error) {
+	tracer := NewQueryTracer(cfg.DatabasePool.SlowQueryThreshold, log)
+
+	pg, err := newPostgreSQLConnection(cfg, tracer)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to connect to PostgreSQL: %w", err)
 	}
 
+	replicas, err := newReplicaConnections(cfg, tracer)
+	if err != nil {
+		pg.Close()
+		return nil, fmt.Errorf("Failed to connect to PostgreSQL replicas: %w", err)
+	}
+
 	rdb, err := newRedisConnection(cfg)
 	if err != nil {
 		pg.Close()
+		for _, replica := range replicas {
+			replica.Close()
+		}
 		return nil, fmt.Errorf("Failed to connect to Redis: %w", err)
 	}
 
 	return &Database{
-		PG:    pg,
-		Redis: rdb,
+		PG:       pg,
+		Replicas: newReplicaSet(pg, replicas, cfg.DatabasePool.ReplicaHealthCheckPeriod),
+		Redis:    rdb,
+		Queries:  tracer,
 	}, nil
 }
 
 // newPostgreSQLConnection creates a new PostgreSQL connection Pool
-func newPostgreSQLConnection(cfg *config.Config) (*pgxpool.Pool, error) {
+func newPostgreSQLConnection(cfg *config.Config, tracer pgx.QueryTracer) (*pgxpool.Pool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	poolConfig, err := pgxpool.ParseConfig(cfg.DatabaseURL())
+	poolConfig, err := newPoolConfig(cfg, cfg.DatabaseURL(), tracer)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to parse database URL: %w", err)
+		return nil, err
 	}
 
-	poolConfig.MaxConns = int32(cfg.DatabasePool.MaxConns)
-	poolConfig.MinConns = int32(cfg.DatabasePool.MinConns)
-	poolConfig.MaxConnLifetime = cfg.DatabasePool.MaxConnLifetime
-	poolConfig.MaxConnIdleTime = cfg.DatabasePool.MaxConnIdleTime
-	poolConfig.HealthCheckPeriod = cfg.DatabasePool.HealthCheckPeriod
-
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create connection pool: %w", err)
@@ -63,22 +97,135 @@ func newPostgreSQLConnection(cfg *config.Config) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
-// newRedisConnection creates a new Redis connection
-func newRedisConnection(cfg *config.Config) (*redis.Client, error) {
+// newReplicaConnections creates a connection pool per configured read replica.
+// Replicas are not pinged here so an unreachable replica does not block startup;
+// the replica set health checks decide whether it receives reads.
+func newReplicaConnections(cfg *config.Config, tracer pgx.QueryTracer) ([]*pgxpool.Pool, error) {
+	pools := make([]*pgxpool.Pool, 0, len(cfg.DatabasePool.ReplicaURLs))
+
+	for i, dsn := range cfg.DatabasePool.ReplicaURLs {
+		poolConfig, err := newPoolConfig(cfg, dsn, tracer)
+		if err == nil {
+			var pool *pgxpool.Pool
+			pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
+			if err == nil {
+				pools = append(pools, pool)
+				continue
+			}
+		}
+
+		for _, p := range pools {
+			p.Close()
+		}
+		return nil, fmt.Errorf("replica %d: %w", i, err)
+	}
+
+	return pools, nil
+}
+
+// newPoolConfig parses dsn and applies the shared pool settings and tracer
+func newPoolConfig(cfg *config.Config, dsn string, tracer pgx.QueryTracer) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse database URL: %w", err)
+	}
+
+	poolConfig.MaxConns = int32(cfg.DatabasePool.MaxConns)
+	poolConfig.MinConns = int32(cfg.DatabasePool.MinConns)
+	poolConfig.MaxConnLifetime = cfg.DatabasePool.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = cfg.DatabasePool.MaxConnIdleTime
+	poolConfig.HealthCheckPeriod = cfg.DatabasePool.HealthCheckPeriod
+	poolConfig.ConnConfig.Tracer = tracer
+
+	poolConfig.BeforeAcquire = PrepareStatements
+	if cfg.Tenant.Enabled {
+		poolConfig.BeforeAcquire = func(ctx context.Context, conn *pgx.Conn) bool {
+			return PrepareStatements(ctx, conn) && scopeToTenant(ctx, conn)
+		}
+	}
+
+	return poolConfig, nil
+}
+
+// tenantDataKey records, per connection, the tenant its session is scoped to
+const tenantDataKey = "tenant"
+
+// scopeToTenant points the app.tenant_id setting read by the row-level
+// security policies at the tenant of ctx. The setting is only sent when the
+// connection was last used by another tenant.
+func scopeToTenant(ctx context.Context, conn *pgx.Conn) bool {
+	id := tenant.FromContext(ctx)
+
+	data := conn.PgConn().CustomData()
+	if data[tenantDataKey] == id {
+		return true
+	}
+
+	if _, err := conn.Exec(ctx, "SELECT set_config('app.tenant_id', $1, false)", id); err != nil {
+		return false
+	}
+	data[tenantDataKey] = id
+
+	return true
+}
+
+// newRedisConnection creates a new Redis client for the configured mode
+func newRedisConnection(cfg *config.Config) (redis.UniversalClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	rdb := redis.NewClient(&redis.Options{
-		Addr:         cfg.RedisAddr(),
-		Password:     cfg.Redis.Password,
-		DB:           cfg.Redis.DB,
-		PoolSize:     10,
-		MinIdleConns: 3,
-		DialTimeout:  5 * time.Second,
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-		PoolTimeout:  4 * time.Second,
-	})
+	tlsConfig, err := newRedisTLSConfig(cfg.Redis)
+	if err != nil {
+		return nil, err
+	}
+
+	var rdb redis.UniversalClient
+	switch cfg.Redis.Mode {
+	case "sentinel":
+		rdb = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.Redis.MasterName,
+			SentinelAddrs:    cfg.Redis.Addrs,
+			SentinelUsername: cfg.Redis.SentinelUsername,
+			SentinelPassword: cfg.Redis.SentinelPassword,
+			Username:         cfg.Redis.Username,
+			Password:         cfg.Redis.Password,
+			DB:               cfg.Redis.DB,
+			TLSConfig:        tlsConfig,
+			PoolSize:         10,
+			MinIdleConns:     3,
+			DialTimeout:      5 * time.Second,
+			ReadTimeout:      3 * time.Second,
+			WriteTimeout:     3 * time.Second,
+			PoolTimeout:      4 * time.Second,
+		})
+	case "cluster":
+		rdb = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.Redis.Addrs,
+			Username:     cfg.Redis.Username,
+			Password:     cfg.Redis.Password,
+			TLSConfig:    tlsConfig,
+			PoolSize:     10,
+			MinIdleConns: 3,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolTimeout:  4 * time.Second,
+		})
+	default:
+		rdb = redis.NewClient(&redis.Options{
+			Addr:         cfg.RedisAddr(),
+			Username:     cfg.Redis.Username,
+			Password:     cfg.Redis.Password,
+			DB:           cfg.Redis.DB,
+			TLSConfig:    tlsConfig,
+			PoolSize:     10,
+			MinIdleConns: 3,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolTimeout:  4 * time.Second,
+		})
+	}
 
 	if err := rdb.Ping(ctx).Err(); err != nil {
 		rdb.Close()
@@ -88,8 +235,41 @@ func newRedisConnection(cfg *config.Config) (*redis.Client, error) {
 	return rdb, nil
 }
 
+// newRedisTLSConfig returns the TLS settings of the Redis connections, or
+// nil when TLS is disabled
+func newRedisTLSConfig(cfg config.RedisConfig) (*tls.Config, error) {
+	if !cfg.TLSEnabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.TLSServerName,
+		InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+	}
+
+	if cfg.TLSCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read Redis TLS CA file: %w", err)
+		}
+
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("Redis TLS CA file %s holds no PEM certificates", cfg.TLSCAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	return tlsConfig, nil
+}
+
 // Close closes all database connections
 func (db *Database) Close() {
+	if db.Replicas != nil {
+		db.Replicas.Close()
+	}
+
 	if db.PG != nil {
 		db.PG.Close()
 	}
@@ -99,6 +279,37 @@ func (db *Database) Close() {
 	}
 }
 
+// PoolStats returns a snapshot of the primary pool followed by each replica
+// pool, named primary, replica_0, replica_1 and so on
+func (db *Database) PoolStats() []PoolStats {
+	stats := []PoolStats{poolStats("primary", db.PG)}
+	if db.Replicas != nil {
+		for i, r := range db.Replicas.replicas {
+			stats = append(stats, poolStats(fmt.Sprintf("replica_%d", i), r.pool))
+		}
+	}
+
+	return stats
+}
+
+// poolStats takes a snapshot of pool
+func poolStats(name string, pool *pgxpool.Pool) PoolStats {
+	stat := pool.Stat()
+
+	return PoolStats{
+		Name:                 name,
+		Acquired:             stat.AcquiredConns(),
+		Idle:                 stat.IdleConns(),
+		Total:                stat.TotalConns(),
+		Max:                  stat.MaxConns(),
+		AcquireCount:         stat.AcquireCount(),
+		EmptyAcquireCount:    stat.EmptyAcquireCount(),
+		CanceledAcquireCount: stat.CanceledAcquireCount(),
+		AcquireDuration:      stat.AcquireDuration(),
+		EmptyAcquireWait:     stat.EmptyAcquireWaitTime(),
+	}
+}
+
 // Health checks the health of all database connections
 func (db *Database) Health(ctx context.Context) error {
 	if err := db.PG.Ping(ctx); err != nil {
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replica is a read-only connection pool with its last known health
+type replica struct {
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+}
+
+// ReplicaSet routes read queries across healthy read replicas and falls back
+// to the primary when none are available
+type ReplicaSet struct {
+	primary  *pgxpool.Pool
+	replicas []*replica
+	next     atomic.Uint64
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// newReplicaSet wraps the given replica pools and starts periodic health checks
+func newReplicaSet(primary *pgxpool.Pool, pools []*pgxpool.Pool, checkPeriod time.Duration) *ReplicaSet {
+	rs := &ReplicaSet{primary: primary}
+
+	for _, pool := range pools {
+		rs.replicas = append(rs.replicas, &replica{pool: pool})
+	}
+
+	if len(rs.replicas) == 0 {
+		return rs
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rs.cancel = cancel
+
+	rs.checkHealth(ctx)
+	if checkPeriod <= 0 {
+		return rs
+	}
+
+	rs.wg.Add(1)
+	go func() {
+		defer rs.wg.Done()
+
+		ticker := time.NewTicker(checkPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				rs.checkHealth(ctx)
+			}
+		}
+	}()
+
+	return rs
+}
+
+// Reader returns a healthy replica in round-robin order, or the primary if
+// no replica is configured or all of them are failing health checks
+func (rs *ReplicaSet) Reader() *pgxpool.Pool {
+	n := len(rs.replicas)
+	if n == 0 {
+		return rs.primary
+	}
+
+	start := rs.next.Add(1)
+	for i := 0; i < n; i++ {
+		r := rs.replicas[(start+uint64(i))%uint64(n)]
+		if r.healthy.Load() {
+			return r.pool
+		}
+	}
+
+	return rs.primary
+}
+
+// Primary returns the read-write pool
+func (rs *ReplicaSet) Primary() *pgxpool.Pool {
+	return rs.primary
+}
+
+// HealthyCount returns the number of replicas currently accepting reads
+func (rs *ReplicaSet) HealthyCount() int {
+	count := 0
+	for _, r := range rs.replicas {
+		if r.healthy.Load() {
+			count++
+		}
+	}
+	return count
+}
+
+// Len returns the number of configured replicas
+func (rs *ReplicaSet) Len() int {
+	return len(rs.replicas)
+}
+
+// checkHealth pings every replica and updates its health flag
+func (rs *ReplicaSet) checkHealth(ctx context.Context) {
+	for _, r := range rs.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		r.healthy.Store(r.pool.Ping(pingCtx) == nil)
+		cancel()
+	}
+}
+
+// Close stops health checks and closes all replica pools
+func (rs *ReplicaSet) Close() {
+	if rs.cancel != nil {
+		rs.cancel()
+		rs.wg.Wait()
+	}
+
+	for _, r := range rs.replicas {
+		r.pool.Close()
+	}
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// preparedDataKey records, per connection, how many of the registered
+// statements it has prepared
+const preparedDataKey = "prepared"
+
+var (
+	statementsMu sync.RWMutex
+	// statements maps the name of each registered statement to its SQL
+	statements = make(map[string]string)
+)
+
+// PrepareQueries registers statements to be prepared on every pooled
+// connection under their names, so callers run them by name instead of
+// sending the SQL. They are reported in query statistics under their names
+// too.
+func PrepareQueries(named map[string]string) {
+	statementsMu.Lock()
+	for name, sql := range named {
+		statements[name] = sql
+	}
+	statementsMu.Unlock()
+
+	NameQueries(named)
+}
+
+// statementSQL returns the SQL of the registered statement name
+func statementSQL(name string) (string, bool) {
+	statementsMu.RLock()
+	defer statementsMu.RUnlock()
+
+	sql, ok := statements[name]
+	return sql, ok
+}
+
+// PrepareStatements prepares the registered statements on conn the first
+// time it is acquired. It runs on acquire rather than on connect because
+// pools may be opened before the migrations run, as the integration tests
+// do; statements that fail to prepare are retried on the next acquire and
+// left to the startup validation to report. The connection is always usable.
+func PrepareStatements(ctx context.Context, conn *pgx.Conn) bool {
+	statementsMu.RLock()
+	defer statementsMu.RUnlock()
+
+	data := conn.PgConn().CustomData()
+	if prepared, _ := data[preparedDataKey].(int); prepared == len(statements) {
+		return true
+	}
+
+	for name, sql := range statements {
+		if _, err := conn.Prepare(ctx, name, sql); err != nil {
+			return true
+		}
+	}
+	data[preparedDataKey] = len(statements)
+
+	return true
+}
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/amirzre/news-feed-system/pkg/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxLoggedSQLLength caps the SQL text included in slow query logs
+const maxLoggedSQLLength = 2000
+
+// queryLabels maps SQL text to the name it is reported under
+var queryLabels sync.Map
+
+// queryTable finds the first table a statement reads or writes
+var queryTable = regexp.MustCompile(`(?i)\b(?:from|into|update)\s+([a-z_][a-z0-9_]*)`)
+
+// NameQueries registers the label each SQL statement is reported under in
+// query statistics and slow query logs. Unnamed statements are labelled by
+// their verb and first table, such as "select posts".
+func NameQueries(named map[string]string) {
+	for name, sql := range named {
+		queryLabels.Store(sql, name)
+	}
+}
+
+// QueryLabel returns the label sql is reported under
+func QueryLabel(sql string) string {
+	if label, ok := queryLabels.Load(sql); ok {
+		return label.(string)
+	}
+
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "unknown"
+	}
+
+	label := strings.ToLower(fields[0])
+	if match := queryTable.FindStringSubmatch(sql); match != nil {
+		label += " " + strings.ToLower(match[1])
+	}
+	queryLabels.Store(sql, label)
+
+	return label
+}
+
+// QueryStats sums the executions of the statements sharing a label
+type QueryStats struct {
+	Label    string
+	Calls    int64
+	Errors   int64
+	Slow     int64
+	Duration time.Duration
+	Max      time.Duration
+}
+
+type queryStartKey struct{}
+
+// queryStart is carried in the context from the start to the end of a query
+type queryStart struct {
+	sql string
+	at  time.Time
+}
+
+// QueryTracer times every query run through the pools, keeps per-label
+// totals and logs the queries slower than the slow query threshold
+type QueryTracer struct {
+	logger    *logger.Logger
+	threshold atomic.Int64
+	mu        sync.Mutex
+	stats     map[string]*QueryStats
+}
+
+// NewQueryTracer creates a query tracer logging queries that take at least
+// threshold; zero disables slow query logging
+func NewQueryTracer(threshold time.Duration, logger *logger.Logger) *QueryTracer {
+	t := &QueryTracer{
+		logger: logger,
+		stats:  make(map[string]*QueryStats),
+	}
+	t.threshold.Store(int64(threshold))
+
+	return t
+}
+
+// SetSlowThreshold changes the duration from which queries are logged
+func (t *QueryTracer) SetSlowThreshold(threshold time.Duration) {
+	t.threshold.Store(int64(threshold))
+}
+
+// TraceQueryStart implements pgx.QueryTracer. Prepared statements run by
+// name are traced with their SQL.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	sql := data.SQL
+	if prepared, ok := statementSQL(sql); ok {
+		sql = prepared
+	}
+
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: sql, at: time.Now()})
+}
+
+// TraceQueryEnd implements pgx.QueryTracer
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	duration := time.Since(start.at)
+	label := QueryLabel(start.sql)
+	threshold := time.Duration(t.threshold.Load())
+	slow := threshold > 0 && duration >= threshold
+
+	t.mu.Lock()
+	stats, ok := t.stats[label]
+	if !ok {
+		stats = &QueryStats{Label: label}
+		t.stats[label] = stats
+	}
+	stats.Calls++
+	stats.Duration += duration
+	stats.Max = max(stats.Max, duration)
+	if data.Err != nil {
+		stats.Errors++
+	}
+	if slow {
+		stats.Slow++
+	}
+	t.mu.Unlock()
+
+	if slow {
+		t.logger.Warn("Slow database query",
+			"label", label,
+			"durationMs", duration.Milliseconds(),
+			"sql", loggedSQL(start.sql),
+		)
+	}
+}
+
+// Stats returns the totals of every label, the most time consuming first
+func (t *QueryTracer) Stats() []QueryStats {
+	t.mu.Lock()
+	stats := make([]QueryStats, 0, len(t.stats))
+	for _, s := range t.stats {
+		stats = append(stats, *s)
+	}
+	t.mu.Unlock()
+
+	slices.SortFunc(stats, func(a, b QueryStats) int {
+		if c := cmp.Compare(b.Duration, a.Duration); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Label, b.Label)
+	})
+
+	return stats
+}
+
+// loggedSQL collapses the whitespace of sql and truncates it for logging.
+// Arguments are never logged, as they may hold user data.
+func loggedSQL(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if len(sql) > maxLoggedSQLLength {
+		sql = sql[:maxLoggedSQLLength] + "..."
+	}
+
+	return sql
+}
@@ -0,0 +1,157 @@
+package geo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxPlaceWords is the length in words of the longest place name
+const maxPlaceWords = 3
+
+// places maps country names, their demonyms, capitals and major cities to
+// lowercase ISO 3166-1 alpha-2 codes. Names are matched case-sensitively, so
+// "Turkey" is a country but "turkey" is not; names that are mostly used for
+// something else, such as Jordan or Georgia, are left out.
+var places = map[string]string{
+	// North America
+	"US": "us", "USA": "us", "United States": "us", "America": "us", "American": "us", "Americans": "us",
+	"Washington": "us", "New York": "us", "Los Angeles": "us", "Chicago": "us", "San Francisco": "us",
+	"White House": "us", "Pentagon": "us", "Silicon Valley": "us", "Wall Street": "us",
+	"Canada": "ca", "Canadian": "ca", "Canadians": "ca", "Ottawa": "ca", "Toronto": "ca", "Montreal": "ca", "Vancouver": "ca",
+	"Mexico": "mx", "Mexican": "mx", "Mexicans": "mx", "Mexico City": "mx",
+	"Cuba": "cu", "Cuban": "cu", "Havana": "cu",
+
+	// South America
+	"Brazil": "br", "Brazilian": "br", "Brasilia": "br", "Sao Paulo": "br", "São Paulo": "br", "Rio de Janeiro": "br",
+	"Argentina": "ar", "Argentine": "ar", "Argentinian": "ar", "Buenos Aires": "ar",
+	"Chile": "cl", "Chilean": "cl", "Santiago": "cl",
+	"Colombia": "co", "Colombian": "co", "Bogota": "co", "Bogotá": "co",
+	"Peru": "pe", "Peruvian": "pe", "Lima": "pe",
+	"Venezuela": "ve", "Venezuelan": "ve", "Caracas": "ve",
+
+	// Europe
+	"UK": "gb", "Britain": "gb", "British": "gb", "United Kingdom": "gb", "England": "gb", "English": "gb",
+	"Scotland": "gb", "Scottish": "gb", "Wales": "gb", "Welsh": "gb", "London": "gb", "Downing Street": "gb",
+	"Ireland": "ie", "Irish": "ie", "Dublin": "ie",
+	"France": "fr", "French": "fr", "Paris": "fr", "Élysée": "fr",
+	"Germany": "de", "German": "de", "Germans": "de", "Berlin": "de", "Munich": "de", "Frankfurt": "de",
+	"Italy": "it", "Italian": "it", "Italians": "it", "Rome": "it", "Milan": "it",
+	"Spain": "es", "Spanish": "es", "Madrid": "es", "Barcelona": "es",
+	"Portugal": "pt", "Portuguese": "pt", "Lisbon": "pt",
+	"Netherlands": "nl", "Dutch": "nl", "Amsterdam": "nl", "The Hague": "nl",
+	"Belgium": "be", "Belgian": "be", "Brussels": "be",
+	"Switzerland": "ch", "Swiss": "ch", "Geneva": "ch", "Zurich": "ch", "Bern": "ch",
+	"Austria": "at", "Austrian": "at", "Vienna": "at",
+	"Sweden": "se", "Swedish": "se", "Stockholm": "se",
+	"Norway": "no", "Norwegian": "no", "Oslo": "no",
+	"Denmark": "dk", "Danish": "dk", "Copenhagen": "dk",
+	"Finland": "fi", "Finnish": "fi", "Helsinki": "fi",
+	"Poland": "pl", "Polish": "pl", "Warsaw": "pl",
+	"Czech Republic": "cz", "Czechia": "cz", "Czech": "cz", "Prague": "cz",
+	"Hungary": "hu", "Hungarian": "hu", "Budapest": "hu",
+	"Romania": "ro", "Romanian": "ro", "Bucharest": "ro",
+	"Greece": "gr", "Greek": "gr", "Athens": "gr",
+	"Turkey": "tr", "Türkiye": "tr", "Turkish": "tr", "Ankara": "tr", "Istanbul": "tr",
+	"Ukraine": "ua", "Ukrainian": "ua", "Ukrainians": "ua", "Kyiv": "ua", "Kiev": "ua",
+	"Russia": "ru", "Russian": "ru", "Russians": "ru", "Moscow": "ru", "Kremlin": "ru",
+	"Belarus": "by", "Belarusian": "by", "Minsk": "by",
+	"Serbia": "rs", "Serbian": "rs", "Belgrade": "rs",
+
+	// Middle East
+	"Israel": "il", "Israeli": "il", "Israelis": "il", "Jerusalem": "il", "Tel Aviv": "il",
+	"Gaza": "ps", "Palestinian": "ps", "Palestinians": "ps", "West Bank": "ps",
+	"Iran": "ir", "Iranian": "ir", "Iranians": "ir", "Tehran": "ir",
+	"Iraq": "iq", "Iraqi": "iq", "Baghdad": "iq",
+	"Syria": "sy", "Syrian": "sy", "Damascus": "sy",
+	"Lebanon": "lb", "Lebanese": "lb", "Beirut": "lb",
+	"Saudi Arabia": "sa", "Saudi": "sa", "Riyadh": "sa",
+	"UAE": "ae", "United Arab Emirates": "ae", "Emirati": "ae", "Dubai": "ae", "Abu Dhabi": "ae",
+	"Qatar": "qa", "Qatari": "qa", "Doha": "qa",
+	"Yemen": "ye", "Yemeni": "ye",
+	"Egypt": "eg", "Egyptian": "eg", "Cairo": "eg",
+
+	// Asia and Oceania
+	"China": "cn", "Chinese": "cn", "Beijing": "cn", "Shanghai": "cn", "Shenzhen": "cn",
+	"Hong Kong": "hk", "Taiwan": "tw", "Taiwanese": "tw", "Taipei": "tw",
+	"Japan": "jp", "Japanese": "jp", "Tokyo": "jp", "Osaka": "jp",
+	"South Korea": "kr", "South Korean": "kr", "Seoul": "kr",
+	"North Korea": "kp", "North Korean": "kp", "Pyongyang": "kp",
+	"India": "in", "Indian": "in", "Indians": "in", "New Delhi": "in", "Delhi": "in", "Mumbai": "in", "Bengaluru": "in",
+	"Pakistan": "pk", "Pakistani": "pk", "Islamabad": "pk", "Karachi": "pk",
+	"Afghanistan": "af", "Afghan": "af", "Kabul": "af",
+	"Bangladesh": "bd", "Bangladeshi": "bd", "Dhaka": "bd",
+	"Indonesia": "id", "Indonesian": "id", "Jakarta": "id",
+	"Malaysia": "my", "Malaysian": "my", "Kuala Lumpur": "my",
+	"Singapore": "sg", "Singaporean": "sg",
+	"Thailand": "th", "Thai": "th", "Bangkok": "th",
+	"Vietnam": "vn", "Vietnamese": "vn", "Hanoi": "vn",
+	"Philippines": "ph", "Philippine": "ph", "Filipino": "ph", "Manila": "ph",
+	"Australia": "au", "Australian": "au", "Australians": "au", "Canberra": "au", "Sydney": "au", "Melbourne": "au",
+	"New South Wales": "au", "Queensland": "au",
+	"New Zealand": "nz", "Wellington": "nz", "Auckland": "nz",
+
+	// Africa
+	"Nigeria": "ng", "Nigerian": "ng", "Lagos": "ng", "Abuja": "ng",
+	"South Africa": "za", "South African": "za", "Johannesburg": "za", "Cape Town": "za", "Pretoria": "za",
+	"Kenya": "ke", "Kenyan": "ke", "Nairobi": "ke",
+	"Ethiopia": "et", "Ethiopian": "et", "Addis Ababa": "et",
+	"Morocco": "ma", "Moroccan": "ma", "Rabat": "ma",
+	"Sudan": "sd", "Sudanese": "sd", "Khartoum": "sd",
+	"Ghana": "gh", "Ghanaian": "gh", "Accra": "gh",
+}
+
+// abbreviations spells dotted country abbreviations the way places lists them
+var abbreviations = strings.NewReplacer("U.S.A.", "USA", "U.S.", "US", "U.K.", "UK", "U.A.E.", "UAE")
+
+// DetectCountry returns the lowercase ISO 3166-1 alpha-2 code of the country
+// text is mostly about, judged by the places it names, or "" when it names
+// none. The most often named country wins, ties going to the one named
+// first. Longer names take precedence, so "New South Wales" is read as
+// Australia rather than Wales.
+func DetectCountry(text string) string {
+	words := tokenize(abbreviations.Replace(text))
+
+	counts := make(map[string]int)
+	var order []string
+	for i := 0; i < len(words); {
+		matched := 1
+		for n := min(maxPlaceWords, len(words)-i); n > 0; n-- {
+			code, ok := places[strings.Join(words[i:i+n], " ")]
+			if !ok {
+				continue
+			}
+
+			if counts[code] == 0 {
+				order = append(order, code)
+			}
+			counts[code]++
+			matched = n
+			break
+		}
+		i += matched
+	}
+
+	best := ""
+	for _, code := range order {
+		if counts[code] > counts[best] {
+			best = code
+		}
+	}
+
+	return best
+}
+
+// tokenize splits text into words of letters and digits, dropping
+// possessive endings
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	})
+
+	for i, word := range words {
+		word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "’s")
+		words[i] = strings.Trim(word, "'’")
+	}
+
+	return words
+}
@@ -0,0 +1,196 @@
+package htmlsafe
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedTags are the formatting elements kept in sanitized text, without
+// attributes except for the href of links
+var allowedTags = map[atom.Atom]bool{
+	atom.P:          true,
+	atom.Br:         true,
+	atom.B:          true,
+	atom.Strong:     true,
+	atom.I:          true,
+	atom.Em:         true,
+	atom.U:          true,
+	atom.S:          true,
+	atom.Small:      true,
+	atom.Sub:        true,
+	atom.Sup:        true,
+	atom.Mark:       true,
+	atom.Q:          true,
+	atom.Cite:       true,
+	atom.Code:       true,
+	atom.Pre:        true,
+	atom.Blockquote: true,
+	atom.Ul:         true,
+	atom.Ol:         true,
+	atom.Li:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.A:          true,
+}
+
+// voidTags have no end tag
+var voidTags = map[atom.Atom]bool{
+	atom.Br: true,
+}
+
+// droppedTags are removed together with everything inside them
+var droppedTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Iframe:   true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Applet:   true,
+	atom.Template: true,
+	atom.Svg:      true,
+	atom.Math:     true,
+	atom.Textarea: true,
+	atom.Select:   true,
+	atom.Title:    true,
+	atom.Head:     true,
+}
+
+// linkSchemes are the schemes a link may point at
+var linkSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// linkRel is set on every kept link
+const linkRel = "nofollow noopener noreferrer"
+
+// textEscaper escapes text content; quotes need no escaping outside attributes
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Sanitize returns s with only basic formatting markup left, so that it is
+// safe to render as HTML. Scripts, styles, frames and embedded objects are
+// removed with their content; other unknown elements are removed but their
+// text is kept. Every attribute is dropped except the href of links to
+// http(s) and mailto addresses, and links get rel="nofollow noopener
+// noreferrer". Elements left open are closed. Text without any markup is
+// returned unchanged.
+func Sanitize(s string) string {
+	if !strings.Contains(s, "<") {
+		return s
+	}
+
+	var out strings.Builder
+	var open []atom.Atom
+	dropped := 0
+
+	tokenizer := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			// io.EOF, or a read error that cannot happen on a string
+			break
+		}
+
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedTags[token.DataAtom] {
+				if tokenType == html.StartTagToken {
+					dropped++
+				}
+				continue
+			}
+			if dropped > 0 || !allowedTags[token.DataAtom] {
+				continue
+			}
+
+			writeStartTag(&out, token)
+			if !voidTags[token.DataAtom] {
+				if tokenType == html.SelfClosingTagToken {
+					out.WriteString("</" + token.Data + ">")
+				} else {
+					open = append(open, token.DataAtom)
+				}
+			}
+
+		case html.EndTagToken:
+			if droppedTags[token.DataAtom] {
+				dropped = max(dropped-1, 0)
+				continue
+			}
+			if dropped > 0 || !allowedTags[token.DataAtom] || voidTags[token.DataAtom] {
+				continue
+			}
+
+			// Close the element and any left open inside it; an end tag
+			// without a start tag is dropped
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != token.DataAtom {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					out.WriteString("</" + open[j].String() + ">")
+				}
+				open = open[:i]
+				break
+			}
+
+		case html.TextToken:
+			if dropped == 0 {
+				out.WriteString(textEscaper.Replace(token.Data))
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i].String() + ">")
+	}
+
+	return out.String()
+}
+
+// SanitizePtr sanitizes *s, passing nil through
+func SanitizePtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	sanitized := Sanitize(*s)
+	return &sanitized
+}
+
+// writeStartTag writes the start tag of an allowed element with its
+// permitted attributes
+func writeStartTag(out *strings.Builder, token html.Token) {
+	out.WriteString("<" + token.Data)
+
+	if token.DataAtom == atom.A {
+		for _, attr := range token.Attr {
+			if attr.Namespace == "" && strings.EqualFold(attr.Key, "href") && safeLink(attr.Val) {
+				out.WriteString(` href="` + html.EscapeString(strings.TrimSpace(attr.Val)) + `"`)
+				out.WriteString(` rel="` + linkRel + `"`)
+				break
+			}
+		}
+	}
+
+	out.WriteString(">")
+}
+
+// safeLink reports whether href is an absolute link with a permitted scheme
+func safeLink(href string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return false
+	}
+
+	return linkSchemes[strings.ToLower(parsed.Scheme)] && (parsed.Host != "" || parsed.Opaque != "")
+}
@@ -0,0 +1,64 @@
+package htmlsafe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text is returned unchanged", "Rates rise 5% & markets > expectations", "Rates rise 5% & markets > expectations"},
+		{"empty string", "", ""},
+		{"formatting is kept", "<p>Hello <b>world</b><br></p>", "<p>Hello <b>world</b><br></p>"},
+		{"text is escaped", "<p>1 < 2 & 3</p>", "<p>1 &lt; 2 &amp; 3</p>"},
+		{"unknown tags keep their text", "<div><span>kept</span></div>", "kept"},
+
+		{"script is removed with its content", "<p>Hi<script>alert(1)</script></p>", "<p>Hi</p>"},
+		{"upper case script", "<SCRIPT>alert(1)</SCRIPT>ok", "ok"},
+		{"unclosed script", "<p>a<script>alert(1)", "<p>a</p>"},
+		{"style is removed with its content", "<style>body{color:red}</style><p>x</p>", "<p>x</p>"},
+		{"nested dropped tags", "<object><embed>x</embed>y</object>z", "z"},
+		{"comment is removed", "a<!-- <script>alert(1)</script> -->b", "ab"},
+
+		{"event handler attribute", `<b onclick="alert(1)">bold</b>`, "<b>bold</b>"},
+		{"event handler on dropped tag", `<img src=x onerror=alert(1)>text`, "text"},
+		{"event handler on link", `<a href="https://example.com" onmouseover="steal()">link</a>`, `<a href="https://example.com" rel="nofollow noopener noreferrer">link</a>`},
+		{"upper case event handler", `<p ONLOAD="alert(1)">x</p>`, "<p>x</p>"},
+
+		{"mailto link", `<a href="mailto:desk@example.com">mail</a>`, `<a href="mailto:desk@example.com" rel="nofollow noopener noreferrer">mail</a>`},
+		{"relative link", `<a href="/posts/1">x</a>`, "<a>x</a>"},
+		{"javascript link", `<a href="javascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"mixed case javascript link", `<a href="JaVaScRiPt:alert(1)">x</a>`, "<a>x</a>"},
+		{"javascript link with leading space", `<a href="  javascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"entity encoded javascript link", `<a href="&#106;avascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"hex entity encoded javascript link", `<a href="&#x6A;&#x61;vascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"javascript link split by an encoded tab", `<a href="java&#x09;script:alert(1)">x</a>`, "<a>x</a>"},
+		{"data link", `<a href="data:text/html;base64,PHNjcmlwdD4=">x</a>`, "<a>x</a>"},
+		{"mixed case data link", `<a href="DaTa:text/html,<script>alert(1)</script>">x</a>`, "<a>x</a>"},
+		{"entity encoded data link", `<a href="&#100;ata:text/html,x">x</a>`, "<a>x</a>"},
+		{"quotes in a link are escaped", `<a href='https://example.com/?q="x"'>x</a>`, `<a href="https://example.com/?q=&#34;x&#34;" rel="nofollow noopener noreferrer">x</a>`},
+
+		{"unclosed tags are closed", "<p>open <b>bold", "<p>open <b>bold</b></p>"},
+		{"misnested tags", "<b><i>x</b>y</i>", "<b><i>x</i></b>y"},
+		{"end tag without start tag", "x</b></p>", "x"},
+		{"self closing tag", "<b/>x", "<b></b>x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Sanitize(tt.in))
+		})
+	}
+}
+
+func TestSanitizePtr(t *testing.T) {
+	assert.Nil(t, SanitizePtr(nil))
+
+	s := "<p onclick=\"x()\">text</p>"
+	assert.Equal(t, "<p>text</p>", *SanitizePtr(&s))
+}
@@ -0,0 +1,178 @@
+package i18n
+
+// german holds the German translations of API and validation messages
+var german = map[string]string{
+	// Generic and HTTP errors
+	"Request validation failed":      "Validierung der Anfrage fehlgeschlagen",
+	"Invalid request body":           "Ungültiger Anfrageinhalt",
+	"Bad Request":                    "Ungültige Anfrage",
+	"Unauthorized":                   "Nicht autorisiert",
+	"Forbidden":                      "Verboten",
+	"Not Found":                      "Nicht gefunden",
+	"Method Not Allowed":             "Methode nicht erlaubt",
+	"Request Entity Too Large":       "Anfrage zu groß",
+	"Too Many Requests":              "Zu viele Anfragen",
+	"Internal Server Error":          "Interner Serverfehler",
+	"Service Unavailable":            "Dienst nicht verfügbar",
+	"Service is read-only":           "Dienst ist schreibgeschützt",
+	"Invalid tenant ID":              "Ungültige Mandanten-ID",
+	"Tenant not found":               "Mandant nicht gefunden",
+	"Failed to resolve tenant":       "Mandant konnte nicht ermittelt werden",
+	"Failed to list tenants":         "Mandanten konnten nicht aufgelistet werden",
+	"Failed to save tenant":          "Mandant konnte nicht gespeichert werden",
+	"Tenant saved successfully":      "Mandant erfolgreich gespeichert",
+	"Configuration is invalid":       "Die Konfiguration ist ungültig",
+	"Configuration reloaded":         "Konfiguration neu geladen",
+	"Read-only mode enabled":         "Schreibschutzmodus aktiviert",
+	"Read-only mode disabled":        "Schreibschutzmodus deaktiviert",
+	"Failed to reload configuration": "Konfiguration konnte nicht neu geladen werden",
+	"Invalid If-Match header":        "Ungültiger If-Match-Header",
+	"X-User-ID header is required":   "Der X-User-ID-Header ist erforderlich",
+	"Invalid country code":           "Ungültiger Ländercode",
+	"Invalid date range":             "Ungültiger Datumsbereich",
+	"Invalid days parameter":         "Ungültiger Parameter days",
+	"Invalid limit parameter":        "Ungültiger Parameter limit",
+	"Invalid safe_mode parameter":    "Ungültiger Parameter safe_mode",
+	"Invalid sort parameter":         "Ungültiger Parameter sort",
+	"Invalid highlight parameter":    "Ungültiger Parameter highlight",
+	"Invalid min_members parameter":  "Ungültiger Parameter min_members",
+	"Invalid collapse parameter":     "Ungültiger Parameter collapse",
+	"Category is required":           "Die Kategorie ist erforderlich",
+	"Source is required":             "Die Quelle ist erforderlich",
+
+	// Posts
+	"Invalid post ID":                        "Ungültige Beitrags-ID",
+	"Post not found":                         "Beitrag nicht gefunden",
+	"Post created successfully":              "Beitrag erfolgreich erstellt",
+	"Post updated successfully":              "Beitrag erfolgreich aktualisiert",
+	"Post deleted successfully":              "Beitrag erfolgreich gelöscht",
+	"Post with this URL already exists":      "Ein Beitrag mit dieser URL existiert bereits",
+	"Post version is required":               "Die Beitragsversion ist erforderlich",
+	"Post was modified by another request":   "Der Beitrag wurde von einer anderen Anfrage geändert",
+	"Failed to create post":                  "Beitrag konnte nicht erstellt werden",
+	"Failed to retrieve post":                "Beitrag konnte nicht abgerufen werden",
+	"Failed to retrieve posts":               "Beiträge konnten nicht abgerufen werden",
+	"Failed to retrieve posts by category":   "Beiträge der Kategorie konnten nicht abgerufen werden",
+	"Failed to retrieve posts by source":     "Beiträge der Quelle konnten nicht abgerufen werden",
+	"Failed to retrieve topics":              "Themen konnten nicht abgerufen werden",
+	"Failed to update post":                  "Beitrag konnte nicht aktualisiert werden",
+	"Failed to delete post":                  "Beitrag konnte nicht gelöscht werden",
+	"Failed to search posts":                 "Beitragssuche fehlgeschlagen",
+	"Search query parameter 'q' is required": "Der Suchparameter 'q' ist erforderlich",
+
+	// Comments and reactions
+	"Invalid comment ID":             "Ungültige Kommentar-ID",
+	"Invalid comment":                "Ungültiger Kommentar",
+	"Invalid parent comment":         "Ungültiger übergeordneter Kommentar",
+	"Comment not found":              "Kommentar nicht gefunden",
+	"Comment created successfully":   "Kommentar erfolgreich erstellt",
+	"Failed to create comment":       "Kommentar konnte nicht erstellt werden",
+	"Failed to list comments":        "Kommentare konnten nicht aufgelistet werden",
+	"Failed to delete comment":       "Kommentar konnte nicht gelöscht werden",
+	"Invalid reaction type":          "Ungültiger Reaktionstyp",
+	"Reaction not found":             "Reaktion nicht gefunden",
+	"Reaction recorded successfully": "Reaktion erfolgreich gespeichert",
+	"Failed to record reaction":      "Reaktion konnte nicht gespeichert werden",
+	"Failed to remove reaction":      "Reaktion konnte nicht entfernt werden",
+
+	// Feeds, analytics and experiments
+	"Invalid ranking weights":                "Ungültige Ranking-Gewichte",
+	"Ranking weights updated successfully":   "Ranking-Gewichte erfolgreich aktualisiert",
+	"Failed to update ranking weights":       "Ranking-Gewichte konnten nicht aktualisiert werden",
+	"Failed to retrieve ranked feed":         "Gerankter Feed konnte nicht abgerufen werden",
+	"Invalid feed category":                  "Ungültige Feed-Kategorie",
+	"Invalid feed format":                    "Ungültiges Feed-Format",
+	"Invalid sitemap page":                   "Ungültige Sitemap-Seite",
+	"Sitemap page not found":                 "Sitemap-Seite nicht gefunden",
+	"Failed to generate feed":                "Feed konnte nicht erzeugt werden",
+	"Failed to generate sitemap":             "Sitemap konnte nicht erzeugt werden",
+	"Click recorded successfully":            "Klick erfolgreich gespeichert",
+	"Failed to record click":                 "Klick konnte nicht gespeichert werden",
+	"Failed to retrieve click-through rates": "Klickraten konnten nicht abgerufen werden",
+	"Failed to retrieve search analytics":    "Suchstatistiken konnten nicht abgerufen werden",
+	"Invalid experiment":                     "Ungültiges Experiment",
+	"Experiment not found":                   "Experiment nicht gefunden",
+	"Experiment variant not found":           "Experimentvariante nicht gefunden",
+	"Experiment saved successfully":          "Experiment erfolgreich gespeichert",
+	"Experiment deleted successfully":        "Experiment erfolgreich gelöscht",
+	"Event recorded successfully":            "Ereignis erfolgreich gespeichert",
+	"Failed to list experiments":             "Experimente konnten nicht aufgelistet werden",
+	"Failed to save experiment":              "Experiment konnte nicht gespeichert werden",
+	"Failed to delete experiment":            "Experiment konnte nicht gelöscht werden",
+	"Failed to retrieve experiment results":  "Experimentergebnisse konnten nicht abgerufen werden",
+	"Failed to record experiment event":      "Experimentereignis konnte nicht gespeichert werden",
+	"Failed to list quarantined articles":    "Artikel in Quarantäne konnten nicht aufgelistet werden",
+	"Source is blocked":                      "Die Quelle ist gesperrt",
+	"Invalid source rule":                    "Ungültige Quellenregel",
+	"Invalid source rule ID":                 "Ungültige Quellenregel-ID",
+	"Source rule not found":                  "Quellenregel nicht gefunden",
+	"Source rule saved successfully":         "Quellenregel erfolgreich gespeichert",
+	"Source rule deleted successfully":       "Quellenregel erfolgreich gelöscht",
+	"Failed to list source rules":            "Quellenregeln konnten nicht aufgelistet werden",
+	"Failed to save source rule":             "Quellenregel konnte nicht gespeichert werden",
+	"Failed to delete source rule":           "Quellenregel konnte nicht gelöscht werden",
+
+	// Aggregation, enrichment and scheduling
+	"Invalid aggregation parameters":                   "Ungültige Aggregationsparameter",
+	"No valid categories provided":                     "Keine gültigen Kategorien angegeben",
+	"No valid sources provided":                        "Keine gültigen Quellen angegeben",
+	"Aggregation completed successfully":               "Aggregation erfolgreich abgeschlossen",
+	"Aggregation failed":                               "Aggregation fehlgeschlagen",
+	"Top headlines aggregation completed successfully": "Aggregation der Schlagzeilen erfolgreich abgeschlossen",
+	"Top headlines aggregation failed":                 "Aggregation der Schlagzeilen fehlgeschlagen",
+	"Category aggregation completed successfully":      "Aggregation nach Kategorien erfolgreich abgeschlossen",
+	"Category aggregation failed":                      "Aggregation nach Kategorien fehlgeschlagen",
+	"Source aggregation completed successfully":        "Aggregation nach Quellen erfolgreich abgeschlossen",
+	"Source aggregation failed":                        "Aggregation nach Quellen fehlgeschlagen",
+	"Aggregation timed out; partial results returned":  "Zeitüberschreitung bei der Aggregation; Teilergebnisse werden zurückgegeben",
+	"A reprocess run is already in progress":           "Eine Neuverarbeitung läuft bereits",
+	"Reprocess run not found":                          "Neuverarbeitung nicht gefunden",
+	"Reprocess run started":                            "Neuverarbeitung gestartet",
+	"Failed to start reprocess run":                    "Neuverarbeitung konnte nicht gestartet werden",
+	"Job name is required":                             "Der Jobname ist erforderlich",
+	"Job not found":                                    "Job nicht gefunden",
+	"Job is already running":                           "Der Job läuft bereits",
+	"Job trigger acknowledged":                         "Jobausführung angenommen",
+	"Job enabled":                                      "Job aktiviert",
+	"Job disabled":                                     "Job deaktiviert",
+	"Jobs retrieved successfully":                      "Jobs erfolgreich abgerufen",
+	"Job history retrieved successfully":               "Jobverlauf erfolgreich abgerufen",
+	"Failed to retrieve job history":                   "Jobverlauf konnte nicht abgerufen werden",
+	"Failed to update job":                             "Job konnte nicht aktualisiert werden",
+	"Scheduler paused":                                 "Scheduler pausiert",
+	"Scheduler resumed":                                "Scheduler fortgesetzt",
+	"Scheduler status retrieved successfully":          "Scheduler-Status erfolgreich abgerufen",
+
+	// Validation messages; the first argument is always the field name
+	"%s is required":                                               "%s ist erforderlich",
+	"%s must be a valid email address":                             "%s muss eine gültige E-Mail-Adresse sein",
+	"%s must be at least %s characters long":                       "%s muss mindestens %s Zeichen lang sein",
+	"%s must be at least %s":                                       "%s muss mindestens %s sein",
+	"%s must not exceed %s characters":                             "%s darf höchstens %s Zeichen lang sein",
+	"%s must not exceed %s":                                        "%s darf höchstens %s sein",
+	"%s must be exactly %s characters long":                        "%s muss genau %s Zeichen lang sein",
+	"%s must be greater than or equal to %s":                       "%s muss größer oder gleich %s sein",
+	"%s must be less than or equal to %s":                          "%s muss kleiner oder gleich %s sein",
+	"%s must be greater than %s":                                   "%s muss größer als %s sein",
+	"%s must be less than %s":                                      "%s muss kleiner als %s sein",
+	"%s must contain only alphabetic characters":                   "%s darf nur Buchstaben enthalten",
+	"%s must contain only alphanumeric characters":                 "%s darf nur alphanumerische Zeichen enthalten",
+	"%s must be a valid number":                                    "%s muss eine gültige Zahl sein",
+	"%s must be a valid URL":                                       "%s muss eine gültige URL sein",
+	"%s must be a valid URI":                                       "%s muss eine gültige URI sein",
+	"%s must be one of [%s]":                                       "%s muss einer der Werte [%s] sein",
+	"%s must contain unique values":                                "%s darf keine doppelten Werte enthalten",
+	"%s contains invalid nested values":                            "%s enthält ungültige verschachtelte Werte",
+	"%s must be a valid UUID":                                      "%s muss eine gültige UUID sein",
+	"%s must be a valid UUID v4":                                   "%s muss eine gültige UUID v4 sein",
+	"%s must be valid JSON":                                        "%s muss gültiges JSON sein",
+	"%s must be a valid datetime in format %s":                     "%s muss ein gültiger Zeitpunkt im Format %s sein",
+	"%s must equal %s":                                             "%s muss %s entsprechen",
+	"%s must not equal %s":                                         "%s darf nicht %s entsprechen",
+	"%s must contain at least one of the following characters: %s": "%s muss mindestens eines der folgenden Zeichen enthalten: %s",
+	"%s cannot contain any of the following characters: %s":        "%s darf keines der folgenden Zeichen enthalten: %s",
+	"%s must start with '%s'":                                      "%s muss mit '%s' beginnen",
+	"%s must end with '%s'":                                        "%s muss mit '%s' enden",
+	"%s failed validation for tag '%s' with parameter '%s' (current value: '%s')": "%s hat die Prüfung '%s' mit dem Parameter '%s' nicht bestanden (aktueller Wert: '%s')",
+	"%s failed validation for tag '%s' (current value: '%s')":                     "%s hat die Prüfung '%s' nicht bestanden (aktueller Wert: '%s')",
+}
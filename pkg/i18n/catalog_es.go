@@ -0,0 +1,178 @@
+package i18n
+
+// spanish holds the Spanish translations of API and validation messages
+var spanish = map[string]string{
+	// Generic and HTTP errors
+	"Request validation failed":      "La validación de la solicitud falló",
+	"Invalid request body":           "Cuerpo de la solicitud no válido",
+	"Bad Request":                    "Solicitud incorrecta",
+	"Unauthorized":                   "No autorizado",
+	"Forbidden":                      "Prohibido",
+	"Not Found":                      "No encontrado",
+	"Method Not Allowed":             "Método no permitido",
+	"Request Entity Too Large":       "La entidad de la solicitud es demasiado grande",
+	"Too Many Requests":              "Demasiadas solicitudes",
+	"Internal Server Error":          "Error interno del servidor",
+	"Service Unavailable":            "Servicio no disponible",
+	"Service is read-only":           "El servicio es de solo lectura",
+	"Invalid tenant ID":              "ID de inquilino no válido",
+	"Tenant not found":               "Inquilino no encontrado",
+	"Failed to resolve tenant":       "No se pudo resolver el inquilino",
+	"Failed to list tenants":         "No se pudieron listar los inquilinos",
+	"Failed to save tenant":          "No se pudo guardar el inquilino",
+	"Tenant saved successfully":      "Inquilino guardado correctamente",
+	"Configuration is invalid":       "La configuración no es válida",
+	"Configuration reloaded":         "Configuración recargada",
+	"Read-only mode enabled":         "Modo de solo lectura activado",
+	"Read-only mode disabled":        "Modo de solo lectura desactivado",
+	"Failed to reload configuration": "No se pudo recargar la configuración",
+	"Invalid If-Match header":        "Encabezado If-Match no válido",
+	"X-User-ID header is required":   "El encabezado X-User-ID es obligatorio",
+	"Invalid country code":           "Código de país no válido",
+	"Invalid date range":             "Rango de fechas no válido",
+	"Invalid days parameter":         "Parámetro days no válido",
+	"Invalid limit parameter":        "Parámetro limit no válido",
+	"Invalid safe_mode parameter":    "Parámetro safe_mode no válido",
+	"Invalid sort parameter":         "Parámetro sort no válido",
+	"Invalid highlight parameter":    "Parámetro highlight no válido",
+	"Invalid min_members parameter":  "Parámetro min_members no válido",
+	"Invalid collapse parameter":     "Parámetro collapse no válido",
+	"Category is required":           "La categoría es obligatoria",
+	"Source is required":             "La fuente es obligatoria",
+
+	// Posts
+	"Invalid post ID":                        "ID de publicación no válido",
+	"Post not found":                         "Publicación no encontrada",
+	"Post created successfully":              "Publicación creada correctamente",
+	"Post updated successfully":              "Publicación actualizada correctamente",
+	"Post deleted successfully":              "Publicación eliminada correctamente",
+	"Post with this URL already exists":      "Ya existe una publicación con esta URL",
+	"Post version is required":               "La versión de la publicación es obligatoria",
+	"Post was modified by another request":   "La publicación fue modificada por otra solicitud",
+	"Failed to create post":                  "No se pudo crear la publicación",
+	"Failed to retrieve post":                "No se pudo obtener la publicación",
+	"Failed to retrieve posts":               "No se pudieron obtener las publicaciones",
+	"Failed to retrieve posts by category":   "No se pudieron obtener las publicaciones por categoría",
+	"Failed to retrieve posts by source":     "No se pudieron obtener las publicaciones por fuente",
+	"Failed to retrieve topics":              "No se pudieron obtener los temas",
+	"Failed to update post":                  "No se pudo actualizar la publicación",
+	"Failed to delete post":                  "No se pudo eliminar la publicación",
+	"Failed to search posts":                 "No se pudieron buscar publicaciones",
+	"Search query parameter 'q' is required": "El parámetro de búsqueda 'q' es obligatorio",
+
+	// Comments and reactions
+	"Invalid comment ID":             "ID de comentario no válido",
+	"Invalid comment":                "Comentario no válido",
+	"Invalid parent comment":         "Comentario padre no válido",
+	"Comment not found":              "Comentario no encontrado",
+	"Comment created successfully":   "Comentario creado correctamente",
+	"Failed to create comment":       "No se pudo crear el comentario",
+	"Failed to list comments":        "No se pudieron listar los comentarios",
+	"Failed to delete comment":       "No se pudo eliminar el comentario",
+	"Invalid reaction type":          "Tipo de reacción no válido",
+	"Reaction not found":             "Reacción no encontrada",
+	"Reaction recorded successfully": "Reacción registrada correctamente",
+	"Failed to record reaction":      "No se pudo registrar la reacción",
+	"Failed to remove reaction":      "No se pudo eliminar la reacción",
+
+	// Feeds, analytics and experiments
+	"Invalid ranking weights":                "Pesos de clasificación no válidos",
+	"Ranking weights updated successfully":   "Pesos de clasificación actualizados correctamente",
+	"Failed to update ranking weights":       "No se pudieron actualizar los pesos de clasificación",
+	"Failed to retrieve ranked feed":         "No se pudo obtener el feed clasificado",
+	"Invalid feed category":                  "Categoría de feed no válida",
+	"Invalid feed format":                    "Formato de feed no válido",
+	"Invalid sitemap page":                   "Página de sitemap no válida",
+	"Sitemap page not found":                 "Página de sitemap no encontrada",
+	"Failed to generate feed":                "No se pudo generar el feed",
+	"Failed to generate sitemap":             "No se pudo generar el sitemap",
+	"Click recorded successfully":            "Clic registrado correctamente",
+	"Failed to record click":                 "No se pudo registrar el clic",
+	"Failed to retrieve click-through rates": "No se pudieron obtener las tasas de clics",
+	"Failed to retrieve search analytics":    "No se pudieron obtener las estadísticas de búsqueda",
+	"Invalid experiment":                     "Experimento no válido",
+	"Experiment not found":                   "Experimento no encontrado",
+	"Experiment variant not found":           "Variante del experimento no encontrada",
+	"Experiment saved successfully":          "Experimento guardado correctamente",
+	"Experiment deleted successfully":        "Experimento eliminado correctamente",
+	"Event recorded successfully":            "Evento registrado correctamente",
+	"Failed to list experiments":             "No se pudieron listar los experimentos",
+	"Failed to save experiment":              "No se pudo guardar el experimento",
+	"Failed to delete experiment":            "No se pudo eliminar el experimento",
+	"Failed to retrieve experiment results":  "No se pudieron obtener los resultados del experimento",
+	"Failed to record experiment event":      "No se pudo registrar el evento del experimento",
+	"Failed to list quarantined articles":    "No se pudieron listar los artículos en cuarentena",
+	"Source is blocked":                      "La fuente está bloqueada",
+	"Invalid source rule":                    "Regla de fuente no válida",
+	"Invalid source rule ID":                 "ID de regla de fuente no válido",
+	"Source rule not found":                  "Regla de fuente no encontrada",
+	"Source rule saved successfully":         "Regla de fuente guardada correctamente",
+	"Source rule deleted successfully":       "Regla de fuente eliminada correctamente",
+	"Failed to list source rules":            "No se pudieron listar las reglas de fuente",
+	"Failed to save source rule":             "No se pudo guardar la regla de fuente",
+	"Failed to delete source rule":           "No se pudo eliminar la regla de fuente",
+
+	// Aggregation, enrichment and scheduling
+	"Invalid aggregation parameters":                   "Parámetros de agregación no válidos",
+	"No valid categories provided":                     "No se proporcionaron categorías válidas",
+	"No valid sources provided":                        "No se proporcionaron fuentes válidas",
+	"Aggregation completed successfully":               "Agregación completada correctamente",
+	"Aggregation failed":                               "La agregación falló",
+	"Top headlines aggregation completed successfully": "Agregación de titulares completada correctamente",
+	"Top headlines aggregation failed":                 "La agregación de titulares falló",
+	"Category aggregation completed successfully":      "Agregación por categoría completada correctamente",
+	"Category aggregation failed":                      "La agregación por categoría falló",
+	"Source aggregation completed successfully":        "Agregación por fuente completada correctamente",
+	"Source aggregation failed":                        "La agregación por fuente falló",
+	"Aggregation timed out; partial results returned":  "Se agotó el tiempo de la agregación; se devuelven resultados parciales",
+	"A reprocess run is already in progress":           "Ya hay un reprocesamiento en curso",
+	"Reprocess run not found":                          "Reprocesamiento no encontrado",
+	"Reprocess run started":                            "Reprocesamiento iniciado",
+	"Failed to start reprocess run":                    "No se pudo iniciar el reprocesamiento",
+	"Job name is required":                             "El nombre de la tarea es obligatorio",
+	"Job not found":                                    "Tarea no encontrada",
+	"Job is already running":                           "La tarea ya se está ejecutando",
+	"Job trigger acknowledged":                         "Ejecución de la tarea aceptada",
+	"Job enabled":                                      "Tarea habilitada",
+	"Job disabled":                                     "Tarea deshabilitada",
+	"Jobs retrieved successfully":                      "Tareas obtenidas correctamente",
+	"Job history retrieved successfully":               "Historial de la tarea obtenido correctamente",
+	"Failed to retrieve job history":                   "No se pudo obtener el historial de la tarea",
+	"Failed to update job":                             "No se pudo actualizar la tarea",
+	"Scheduler paused":                                 "Planificador en pausa",
+	"Scheduler resumed":                                "Planificador reanudado",
+	"Scheduler status retrieved successfully":          "Estado del planificador obtenido correctamente",
+
+	// Validation messages; the first argument is always the field name
+	"%s is required":                                               "%s es obligatorio",
+	"%s must be a valid email address":                             "%s debe ser una dirección de correo electrónico válida",
+	"%s must be at least %s characters long":                       "%s debe tener al menos %s caracteres",
+	"%s must be at least %s":                                       "%s debe ser como mínimo %s",
+	"%s must not exceed %s characters":                             "%s no debe superar los %s caracteres",
+	"%s must not exceed %s":                                        "%s no debe superar %s",
+	"%s must be exactly %s characters long":                        "%s debe tener exactamente %s caracteres",
+	"%s must be greater than or equal to %s":                       "%s debe ser mayor o igual que %s",
+	"%s must be less than or equal to %s":                          "%s debe ser menor o igual que %s",
+	"%s must be greater than %s":                                   "%s debe ser mayor que %s",
+	"%s must be less than %s":                                      "%s debe ser menor que %s",
+	"%s must contain only alphabetic characters":                   "%s solo debe contener letras",
+	"%s must contain only alphanumeric characters":                 "%s solo debe contener caracteres alfanuméricos",
+	"%s must be a valid number":                                    "%s debe ser un número válido",
+	"%s must be a valid URL":                                       "%s debe ser una URL válida",
+	"%s must be a valid URI":                                       "%s debe ser una URI válida",
+	"%s must be one of [%s]":                                       "%s debe ser uno de [%s]",
+	"%s must contain unique values":                                "%s debe contener valores únicos",
+	"%s contains invalid nested values":                            "%s contiene valores anidados no válidos",
+	"%s must be a valid UUID":                                      "%s debe ser un UUID válido",
+	"%s must be a valid UUID v4":                                   "%s debe ser un UUID v4 válido",
+	"%s must be valid JSON":                                        "%s debe ser un JSON válido",
+	"%s must be a valid datetime in format %s":                     "%s debe ser una fecha y hora válida con el formato %s",
+	"%s must equal %s":                                             "%s debe ser igual a %s",
+	"%s must not equal %s":                                         "%s no debe ser igual a %s",
+	"%s must contain at least one of the following characters: %s": "%s debe contener al menos uno de los siguientes caracteres: %s",
+	"%s cannot contain any of the following characters: %s":        "%s no puede contener ninguno de los siguientes caracteres: %s",
+	"%s must start with '%s'":                                      "%s debe empezar por '%s'",
+	"%s must end with '%s'":                                        "%s debe terminar en '%s'",
+	"%s failed validation for tag '%s' with parameter '%s' (current value: '%s')": "%s no superó la validación '%s' con el parámetro '%s' (valor actual: '%s')",
+	"%s failed validation for tag '%s' (current value: '%s')":                     "%s no superó la validación '%s' (valor actual: '%s')",
+}
@@ -0,0 +1,96 @@
+// Package i18n translates API messages into the language a client asks for
+// with the Accept-Language header.
+//
+// Messages are written in English throughout the code base and double as the
+// catalog keys; a message missing from a catalog is served in English.
+package i18n
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Supported languages, as ISO 639-1 codes
+const (
+	English = "en"
+	Spanish = "es"
+	German  = "de"
+)
+
+// Default is the language used when a client accepts none of the supported ones
+const Default = English
+
+// catalogs maps each language other than English to its translations
+var catalogs = map[string]map[string]string{
+	Spanish: spanish,
+	German:  german,
+}
+
+// Supported returns the languages messages are available in
+func Supported() []string {
+	return []string{English, Spanish, German}
+}
+
+// Negotiate picks the supported language the Accept-Language header value
+// prefers most. Region subtags are ignored, so "es-MX" selects Spanish.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang   string
+		weight float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight <= 0 {
+			continue
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if primary == "*" {
+			primary = Default
+		}
+		if slices.Contains(Supported(), primary) {
+			candidates = append(candidates, candidate{lang: primary, weight: weight})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return Default
+	}
+
+	// Stable so that equally weighted languages keep the client's order
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.weight > b.weight:
+			return -1
+		case a.weight < b.weight:
+			return 1
+		}
+		return 0
+	})
+
+	return candidates[0].lang
+}
+
+// Translate returns message in lang, or message itself when lang is English
+// or the catalog has no translation for it
+func Translate(lang, message string) string {
+	if translated, ok := catalogs[lang][message]; ok {
+		return translated
+	}
+	return message
+}
@@ -1,49 +1,95 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/amirzre/news-feed-system/internal/config"
 )
 
+// Logger writes structured logs to the configured sinks
 type Logger struct {
 	*slog.Logger
+	level    *slog.LevelVar
+	closers  []io.Closer
+	reporter *sentryHandler
 }
 
-// New creates a new logger instance
+// New creates a new logger instance writing to the sinks in cfg.App.LogSinks.
+// A sink that cannot be opened is skipped and reported through the others;
+// stdout is used when none can be opened. Error records are also reported to
+// Sentry when cfg.ErrorReporting.DSN is set.
 func New(cfg *config.Config) *Logger {
-	var handler slog.Handler
-
-	level := parseLogLevel(cfg.App.LogLevel)
-
-	if cfg.IsDevelopment() {
-		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: level,
-			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-				if a.Key == slog.TimeKey {
-					return slog.Attr{
-						Key:   a.Key,
-						Value: slog.StringValue(a.Value.Time().Format(time.TimeOnly)),
-					}
-				}
-				return a
-			},
-		})
+	level := new(slog.LevelVar)
+	level.Set(parseLogLevel(cfg.App.LogLevel))
+
+	l := &Logger{level: level}
+
+	var handlers []slog.Handler
+	var failures []error
+	for _, sink := range cfg.App.LogSinks {
+		handler, closer, err := newSink(sink, cfg, level)
+		if err != nil {
+			failures = append(failures, err)
+			continue
+		}
+		handlers = append(handlers, handler)
+		if closer != nil {
+			l.closers = append(l.closers, closer)
+		}
+	}
+
+	if len(handlers) == 0 {
+		handler, closer, _ := newSink(SinkStdout, cfg, level)
+		handlers = append(handlers, handler)
+		if closer != nil {
+			l.closers = append(l.closers, closer)
+		}
+	}
+
+	// Sentry treats a zero sample rate as sending everything
+	if cfg.ErrorReporting.DSN != "" && cfg.ErrorReporting.SampleRate > 0 {
+		reporter, flusher, err := newSentryHandler(cfg.ErrorReporting, cfg.App.Environment)
+		if err != nil {
+			failures = append(failures, err)
+		} else {
+			handlers = append(handlers, reporter)
+			l.closers = append(l.closers, flusher)
+			l.reporter = reporter
+		}
+	}
+
+	if len(handlers) == 1 {
+		l.Logger = slog.New(handlers[0])
 	} else {
-		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: level,
-		})
+		l.Logger = slog.New(fanoutHandler(handlers))
 	}
 
-	logger := slog.New(handler)
+	for _, err := range failures {
+		l.Error("Failed to open log sink", "error", err.Error())
+	}
+
+	return l
+}
 
-	return &Logger{
-		Logger: logger,
+// Close flushes buffered entries and closes the log file and syslog
+// connection. Nothing is logged to those sinks afterwards.
+func (l *Logger) Close() error {
+	var errs []error
+	for _, closer := range l.closers {
+		errs = append(errs, closer.Close())
 	}
+
+	return errors.Join(errs...)
+}
+
+// SetLevel changes the minimum level logged from now on
+func (l *Logger) SetLevel(level string) {
+	l.level.Set(parseLogLevel(level))
 }
 
 // parseLogLevel converts string log level to slog level
@@ -62,15 +108,47 @@ func parseLogLevel(level string) slog.Level {
 	}
 }
 
-// HTTP request logging helpers
-func (l *Logger) LogHTTPRequest(method, path string, statusCode int, duration int64) {
-	l.Info(
-		"HTTP request",
-		"method", method,
-		"path", path,
-		"status", statusCode,
-		"durationMS", duration,
-	)
+// HTTPRequest describes a served request for LogHTTPRequest. The bodies are
+// only set when they should be logged.
+type HTTPRequest struct {
+	Method       string
+	Path         string
+	Status       int
+	Duration     time.Duration
+	RequestID    string
+	UserAgent    string
+	ResponseSize int64
+	RequestBody  string
+	ResponseBody string
+}
+
+// HTTP request logging helpers. Server errors are logged at the error level
+// and client errors at the warn level.
+func (l *Logger) LogHTTPRequest(req HTTPRequest) {
+	fields := []any{
+		"method", req.Method,
+		"path", req.Path,
+		"status", req.Status,
+		"durationMS", req.Duration.Milliseconds(),
+		"requestID", req.RequestID,
+		"userAgent", req.UserAgent,
+		"responseSize", req.ResponseSize,
+	}
+	if req.RequestBody != "" {
+		fields = append(fields, "requestBody", req.RequestBody)
+	}
+	if req.ResponseBody != "" {
+		fields = append(fields, "responseBody", req.ResponseBody)
+	}
+
+	switch {
+	case req.Status >= 500:
+		l.Error("HTTP request", fields...)
+	case req.Status >= 400:
+		l.Warn("HTTP request", fields...)
+	default:
+		l.Info("HTTP request", fields...)
+	}
 }
 
 // Database operation logging helpers
@@ -0,0 +1,189 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"github.com/getsentry/sentry-go"
+)
+
+// loggerModule is the import path of this package, whose frames are dropped
+// from reported stack traces along with those of log/slog
+const loggerModule = "github.com/amirzre/news-feed-system/pkg/logger"
+
+// sentryHandler reports error records to Sentry with the stack trace of the
+// logging call. Records logged with a context from RequestContext carry the
+// request they were logged for.
+type sentryHandler struct {
+	hub *sentry.Hub
+	// extra holds the attributes added by WithAttrs, keyed by their path
+	extra  map[string]any
+	prefix string
+}
+
+// sentryFlusher flushes pending events when the logger is closed
+type sentryFlusher struct {
+	client  *sentry.Client
+	timeout time.Duration
+}
+
+func (f sentryFlusher) Close() error {
+	if !f.client.Flush(f.timeout) {
+		return fmt.Errorf("sentry: events still pending after %s", f.timeout)
+	}
+
+	return nil
+}
+
+// newSentryHandler creates the Sentry client described by cfg, tagging events
+// with environment unless cfg names its own
+func newSentryHandler(cfg config.ErrorReportingConfig, environment string) (*sentryHandler, sentryFlusher, error) {
+	if cfg.Environment != "" {
+		environment = cfg.Environment
+	}
+
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         cfg.DSN,
+		Environment: environment,
+		SampleRate:  cfg.SampleRate,
+	})
+	if err != nil {
+		return nil, sentryFlusher{}, fmt.Errorf("error reporting: %w", err)
+	}
+
+	hub := sentry.NewHub(client, sentry.NewScope())
+	return &sentryHandler{hub: hub}, sentryFlusher{client: client, timeout: cfg.FlushTimeout}, nil
+}
+
+func (h *sentryHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.LevelError
+}
+
+func (h *sentryHandler) Handle(ctx context.Context, r slog.Record) error {
+	hub := h.hub
+	if requestHub := sentry.GetHubFromContext(ctx); requestHub != nil {
+		hub = requestHub
+	}
+
+	extra := maps.Clone(h.extra)
+	if extra == nil {
+		extra = make(map[string]any)
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		addExtra(extra, h.prefix, attr)
+		return true
+	})
+
+	// Logged errors are strings, so the message names the failure and the
+	// error or panic attribute, when present, describes it
+	value, _ := extra["error"].(string)
+	if value == "" {
+		value, _ = extra["panic"].(string)
+	}
+	if value == "" {
+		value = r.Message
+	}
+	// The event carries the stack trace itself
+	delete(extra, "stack")
+
+	hub.CaptureEvent(&sentry.Event{
+		Level:   sentry.LevelError,
+		Message: r.Message,
+		Extra:   extra,
+		Exception: []sentry.Exception{{
+			Type:       r.Message,
+			Value:      value,
+			Stacktrace: callerStacktrace(),
+		}},
+	})
+
+	return nil
+}
+
+func (h *sentryHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.extra = maps.Clone(h.extra)
+	if clone.extra == nil {
+		clone.extra = make(map[string]any, len(attrs))
+	}
+	for _, attr := range attrs {
+		addExtra(clone.extra, h.prefix, attr)
+	}
+	return &clone
+}
+
+func (h *sentryHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	if h.prefix == "" {
+		clone.prefix = name
+	} else {
+		clone.prefix = h.prefix + "." + name
+	}
+	return &clone
+}
+
+// addExtra flattens attr into extra, naming grouped attributes by their path
+func addExtra(extra map[string]any, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	key := attr.Key
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, member := range attr.Value.Group() {
+			addExtra(extra, key, member)
+		}
+		return
+	}
+
+	extra[key] = attr.Value.Any()
+}
+
+// callerStacktrace returns the current stack without the frames of logging
+func callerStacktrace() *sentry.Stacktrace {
+	stacktrace := sentry.NewStacktrace()
+	if stacktrace == nil {
+		return nil
+	}
+
+	frames := stacktrace.Frames[:0]
+	for _, frame := range stacktrace.Frames {
+		if frame.Module == "log/slog" || frame.Module == loggerModule {
+			continue
+		}
+		frames = append(frames, frame)
+	}
+	stacktrace.Frames = frames
+
+	return stacktrace
+}
+
+// RequestContext returns ctx carrying req, so errors logged with the
+// returned context are reported with the request they occurred in. ctx is
+// returned unchanged when error reporting is disabled.
+func (l *Logger) RequestContext(ctx context.Context, req *http.Request) context.Context {
+	if l.reporter == nil {
+		return ctx
+	}
+
+	hub := l.reporter.hub.Clone()
+	hub.Scope().SetRequest(req)
+	return sentry.SetHubOnContext(ctx, hub)
+}
+
+// LogPanic logs a value recovered from a panic with the stack of the
+// panicking goroutine, which also reports it when error reporting is
+// enabled. It must be called from the deferred function that recovered.
+func (l *Logger) LogPanic(ctx context.Context, recovered any) {
+	l.ErrorContext(ctx, "Recovered from panic",
+		"panic", fmt.Sprint(recovered),
+		"stack", string(debug.Stack()),
+	)
+}
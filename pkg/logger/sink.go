@@ -0,0 +1,199 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Log sink names accepted in LOG_SINKS
+const (
+	SinkStdout = "stdout"
+	SinkFile   = "file"
+	SinkSyslog = "syslog"
+)
+
+// newSink opens the named sink. Sinks without a level of their own follow
+// level, so they pick up log level changes on reload. The returned closer, if
+// any, flushes and releases the sink.
+func newSink(name string, cfg *config.Config, level *slog.LevelVar) (slog.Handler, io.Closer, error) {
+	switch name {
+	case SinkStdout:
+		w, closer := asyncIfEnabled(os.Stdout, cfg.App)
+		opts := &slog.HandlerOptions{Level: sinkLevel(cfg.App.LogStdoutLevel, level)}
+
+		if cfg.IsDevelopment() {
+			opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{
+						Key:   a.Key,
+						Value: slog.StringValue(a.Value.Time().Format(time.TimeOnly)),
+					}
+				}
+				return a
+			}
+			return slog.NewTextHandler(w, opts), closer, nil
+		}
+
+		return slog.NewJSONHandler(w, opts), closer, nil
+
+	case SinkFile:
+		file := &lumberjack.Logger{
+			Filename:   cfg.App.LogFilePath,
+			MaxSize:    cfg.App.LogFileMaxSizeMB,
+			MaxBackups: cfg.App.LogFileMaxBackups,
+			MaxAge:     cfg.App.LogFileMaxAgeDays,
+			Compress:   cfg.App.LogFileCompress,
+			LocalTime:  true,
+		}
+		w, closer := asyncIfEnabled(file, cfg.App)
+		if closer == nil {
+			closer = file
+		}
+
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{Level: sinkLevel(cfg.App.LogFileLevel, level)}), closer, nil
+
+	case SinkSyslog:
+		handler, closer, err := newSyslogHandler(cfg.App, sinkLevel(cfg.App.LogSyslogLevel, level))
+		if err != nil {
+			return nil, nil, fmt.Errorf("syslog sink: %w", err)
+		}
+		return handler, closer, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown log sink %q", name)
+	}
+}
+
+// sinkLevel returns the level a sink logs at: its own when configured,
+// otherwise the shared one
+func sinkLevel(configured string, shared *slog.LevelVar) slog.Leveler {
+	if configured == "" {
+		return shared
+	}
+
+	return parseLogLevel(configured)
+}
+
+// asyncIfEnabled wraps w in an asyncWriter when asynchronous logging is
+// enabled, returning the writer to log to and the closer that flushes it
+func asyncIfEnabled(w io.Writer, cfg config.AppConfig) (io.Writer, io.Closer) {
+	if !cfg.LogAsync {
+		return w, nil
+	}
+
+	async := newAsyncWriter(w, cfg.LogAsyncBufferSize)
+	return async, async
+}
+
+// asyncWriter writes entries to w from a background goroutine. Writes block
+// once size entries are waiting, so a slow sink slows logging down rather
+// than losing entries.
+type asyncWriter struct {
+	w       io.Writer
+	mu      sync.RWMutex
+	closed  bool
+	entries chan []byte
+	done    chan struct{}
+}
+
+func newAsyncWriter(w io.Writer, size int) *asyncWriter {
+	a := &asyncWriter{
+		w:       w,
+		entries: make(chan []byte, size),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(a.done)
+		for entry := range a.entries {
+			_, _ = a.w.Write(entry)
+		}
+	}()
+
+	return a
+}
+
+// Write queues a copy of p, since slog reuses its buffers. After Close,
+// entries are written directly.
+func (a *asyncWriter) Write(p []byte) (int, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		return a.w.Write(p)
+	}
+
+	a.entries <- bytes.Clone(p)
+	return len(p), nil
+}
+
+// Close writes the queued entries and closes w when it is a closer other
+// than stdout
+func (a *asyncWriter) Close() error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.entries)
+	}
+	a.mu.Unlock()
+
+	<-a.done
+
+	if closer, ok := a.w.(io.Closer); ok && a.w != os.Stdout {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// fanoutHandler passes each record to every handler enabled for its level
+type fanoutHandler []slog.Handler
+
+func (h fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h {
+		if handler.Enabled(ctx, r.Level) {
+			errs = append(errs, handler.Handle(ctx, r.Clone()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(fanoutHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+
+	return handlers
+}
+
+func (h fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make(fanoutHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithGroup(name)
+	}
+
+	return handlers
+}
@@ -0,0 +1,86 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"log/syslog"
+	"strings"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+)
+
+// syslogHandler sends each record to syslog at the severity matching its
+// level. Records are rendered in the text format without a timestamp, which
+// syslog adds itself.
+type syslogHandler struct {
+	writer *syslog.Writer
+	level  slog.Leveler
+	// wrap replays the WithAttrs and WithGroup calls made on the handler
+	wrap []func(slog.Handler) slog.Handler
+}
+
+// newSyslogHandler connects to the syslog daemon named in cfg, or the local
+// one when no network is set
+func newSyslogHandler(cfg config.AppConfig, level slog.Leveler) (slog.Handler, io.Closer, error) {
+	writer, err := syslog.Dial(cfg.LogSyslogNetwork, cfg.LogSyslogAddress, syslog.LOG_INFO|syslog.LOG_DAEMON, cfg.LogSyslogTag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &syslogHandler{writer: writer, level: level}, writer, nil
+}
+
+func (h *syslogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *syslogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+	var handler slog.Handler = slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	for _, wrap := range h.wrap {
+		handler = wrap(handler)
+	}
+
+	if err := handler.Handle(ctx, r); err != nil {
+		return err
+	}
+	msg := strings.TrimSuffix(buf.String(), "\n")
+
+	switch {
+	case r.Level >= slog.LevelError:
+		return h.writer.Err(msg)
+	case r.Level >= slog.LevelWarn:
+		return h.writer.Warning(msg)
+	case r.Level >= slog.LevelInfo:
+		return h.writer.Info(msg)
+	default:
+		return h.writer.Debug(msg)
+	}
+}
+
+func (h *syslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h *syslogHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+// with returns a copy of h that also applies wrap
+func (h *syslogHandler) with(wrap func(slog.Handler) slog.Handler) *syslogHandler {
+	clone := *h
+	clone.wrap = append(h.wrap[:len(h.wrap):len(h.wrap)], wrap)
+	return &clone
+}
@@ -0,0 +1,16 @@
+//go:build windows || plan9
+
+package logger
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/amirzre/news-feed-system/internal/config"
+)
+
+// newSyslogHandler fails, as syslog is not available on this platform
+func newSyslogHandler(config.AppConfig, slog.Leveler) (slog.Handler, io.Closer, error) {
+	return nil, nil, errors.New("syslog is not supported on this platform")
+}
@@ -0,0 +1,192 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheGetSet(t *testing.T) {
+	cache := New[string, int](2, time.Minute)
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+
+	cache.Set("a", 1)
+	cache.Set("a", 2)
+
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestCacheGetOrSet(t *testing.T) {
+	cache := New[string, int](2, time.Minute)
+
+	value, loaded := cache.GetOrSet("a", 1)
+	assert.False(t, loaded)
+	assert.Equal(t, 1, value)
+
+	value, loaded = cache.GetOrSet("a", 2)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, value, "an existing value is kept")
+}
+
+func TestCacheGetOrSetReplacesExpired(t *testing.T) {
+	cache := New[string, int](2, 20*time.Millisecond)
+	cache.Set("a", 1)
+
+	time.Sleep(30 * time.Millisecond)
+
+	value, loaded := cache.GetOrSet("a", 2)
+	assert.False(t, loaded)
+	assert.Equal(t, 2, value)
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	tests := []struct {
+		name    string
+		use     func(cache *Cache[string, int])
+		evicted string
+	}{
+		{"oldest set", func(cache *Cache[string, int]) {}, "a"},
+		{"oldest read", func(cache *Cache[string, int]) { cache.Get("a") }, "b"},
+		{"oldest updated", func(cache *Cache[string, int]) { cache.Set("a", 10) }, "b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := New[string, int](2, time.Minute)
+			cache.Set("a", 1)
+			cache.Set("b", 2)
+			tt.use(cache)
+
+			cache.Set("c", 3)
+
+			assert.Equal(t, 2, cache.Len())
+			_, ok := cache.Get(tt.evicted)
+			assert.False(t, ok, "%s should be evicted", tt.evicted)
+			_, ok = cache.Get("c")
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestCacheCapacityAtLeastOne(t *testing.T) {
+	cache := New[string, int](0, time.Minute)
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	assert.Equal(t, 1, cache.Len())
+	value, ok := cache.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+}
+
+func TestCacheExpiresEntries(t *testing.T) {
+	cache := New[string, int](2, 20*time.Millisecond)
+	cache.Set("a", 1)
+
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len(), "an expired entry is removed when read")
+}
+
+func TestCacheSetRenewsTTL(t *testing.T) {
+	cache := New[string, int](2, 40*time.Millisecond)
+	cache.Set("a", 1)
+
+	time.Sleep(25 * time.Millisecond)
+	cache.Set("a", 2)
+	time.Sleep(25 * time.Millisecond)
+
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+}
+
+func TestCacheReadDoesNotRenewTTL(t *testing.T) {
+	cache := New[string, int](2, 40*time.Millisecond)
+	cache.Set("a", 1)
+
+	time.Sleep(25 * time.Millisecond)
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+	time.Sleep(25 * time.Millisecond)
+
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+}
+
+func TestCacheDeleteAndPurge(t *testing.T) {
+	cache := New[string, int](3, time.Minute)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 3)
+
+	cache.Delete("a", "b", "missing")
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+
+	cache.Purge()
+
+	_, ok = cache.Get("c")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+
+	cache.Set("d", 4)
+	_, ok = cache.Get("d")
+	assert.True(t, ok)
+}
+
+func TestCacheConcurrentUse(t *testing.T) {
+	cache := New[int, int](16, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				cache.Set(i*1000+j, j)
+				cache.Get(j)
+				if j%100 == 0 {
+					cache.Delete(j)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, cache.Len(), 16)
+}
+
+func TestCacheGetOrSetConcurrentUse(t *testing.T) {
+	cache := New[string, *int](4, time.Minute)
+
+	values := make([]*int, 8)
+	var wg sync.WaitGroup
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], _ = cache.GetOrSet("a", new(int))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, value := range values {
+		assert.Same(t, values[0], value, "every caller gets the value stored first")
+	}
+}
@@ -0,0 +1,60 @@
+package newsapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnauthorized is returned when NewsAPI rejects the API key
+var ErrUnauthorized = errors.New("invalid API key")
+
+// ErrServer is returned when NewsAPI fails with a 5xx status
+var ErrServer = errors.New("NewsAPI server error")
+
+// ErrRateLimited is returned when NewsAPI throttles a request, or when the
+// allowance reported by its rate limit headers is used up. RetryAfter is how
+// long to wait before the next request, or zero when NewsAPI did not say.
+type ErrRateLimited struct {
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limit exceeded, retry after %s", e.RetryAfter)
+	}
+
+	return "rate limit exceeded"
+}
+
+// ErrBadRequest is returned when NewsAPI rejects a request's parameters.
+// Code is NewsAPI's machine-readable error code, such as
+// CodeMaximumResultsReached.
+type ErrBadRequest struct {
+	Code    string
+	Message string
+}
+
+func (e *ErrBadRequest) Error() string {
+	if e.Code == "" {
+		return "bad request"
+	}
+
+	return fmt.Sprintf("API error: %s - %s", e.Code, e.Message)
+}
+
+// ErrStatus is returned for any other unexpected HTTP status
+type ErrStatus struct {
+	StatusCode int
+}
+
+func (e *ErrStatus) Error() string {
+	return fmt.Sprintf("unexpected HTTP status: %d", e.StatusCode)
+}
+
+// IsMaximumResultsReached reports whether err is NewsAPI refusing a page
+// beyond the number of results the plan allows a query to return
+func IsMaximumResultsReached(err error) bool {
+	var badRequest *ErrBadRequest
+	return errors.As(err, &badRequest) && badRequest.Code == CodeMaximumResultsReached
+}
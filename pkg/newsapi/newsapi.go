@@ -0,0 +1,173 @@
+// Package newsapi is a client for the NewsAPI v2 REST API. Failures are
+// reported as typed errors and the rate limit headers of every response are
+// parsed, so callers can react to throttling without inspecting error text.
+package newsapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CodeMaximumResultsReached is the error code NewsAPI reports for a page
+// beyond the number of results the plan allows a query to return
+const CodeMaximumResultsReached = "maximumResultsReached"
+
+const userAgent = "news-feed-system/1.0"
+
+// RateLimit is the request allowance reported by a response's X-RateLimit
+// headers. Known is false when the response carried none.
+type RateLimit struct {
+	Known     bool
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// Exhausted reports whether the allowance is known to be used up
+func (r RateLimit) Exhausted() bool {
+	return r.Known && r.Remaining <= 0
+}
+
+// RetryAfter returns how long until the allowance resets, or zero when unknown
+func (r RateLimit) RetryAfter() time.Duration {
+	if r.Reset.IsZero() {
+		return 0
+	}
+
+	return max(time.Until(r.Reset), 0)
+}
+
+// Client sends requests to NewsAPI
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// New creates a client for the API at baseURL whose requests time out after
+// timeout
+func New(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: timeout},
+		baseURL:    baseURL,
+	}
+}
+
+// Get requests path with params, which carry the API key, and decodes a
+// successful response into v. The rate limit is returned for failed responses
+// too.
+func (c *Client) Get(ctx context.Context, path string, params url.Values, v any) (RateLimit, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?%s", c.baseURL, path, params.Encode()), nil)
+	if err != nil {
+		return RateLimit{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return RateLimit{}, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	limit := parseRateLimit(resp.Header)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return limit, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return limit, statusError(resp, body, limit)
+	}
+
+	var status struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return limit, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+	if status.Status != "ok" {
+		return limit, fmt.Errorf("API returned error status: %s", status.Status)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return limit, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+
+	return limit, nil
+}
+
+// statusError converts a failed response into its typed error
+func statusError(resp *http.Response, body []byte, limit RateLimit) error {
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case resp.StatusCode == http.StatusTooManyRequests:
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if retryAfter == 0 {
+			retryAfter = limit.RetryAfter()
+		}
+		return &ErrRateLimited{RetryAfter: retryAfter}
+	case resp.StatusCode == http.StatusBadRequest:
+		var errorResp struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(body, &errorResp); err != nil {
+			return &ErrBadRequest{}
+		}
+		return &ErrBadRequest{Code: errorResp.Code, Message: errorResp.Message}
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return ErrServer
+	default:
+		return &ErrStatus{StatusCode: resp.StatusCode}
+	}
+}
+
+// parseRateLimit reads the X-RateLimit headers. Reset may be given as a Unix
+// timestamp or as seconds from now.
+func parseRateLimit(header http.Header) RateLimit {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}
+	}
+
+	limit := RateLimit{Known: true, Remaining: remaining}
+	limit.Limit, _ = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		// Values this small cannot be a recent Unix timestamp
+		if reset < 1e9 {
+			limit.Reset = time.Now().Add(time.Duration(reset) * time.Second)
+		} else {
+			limit.Reset = time.Unix(reset, 0)
+		}
+	}
+
+	return limit
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date, returning zero when it is absent or invalid
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+
+	return 0
+}
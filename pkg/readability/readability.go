@@ -0,0 +1,324 @@
+package readability
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ErrNoContent is returned when no readable block could be found in the document
+var ErrNoContent = errors.New("no readable content found")
+
+// minParagraphLength is the shortest paragraph that counts towards a candidate score
+const minParagraphLength = 25
+
+var (
+	positivePattern = regexp.MustCompile(`(?i)article|body|content|entry|main|page|post|story|text`)
+	negativePattern = regexp.MustCompile(`(?i)ad-|advert|banner|comment|footer|header|menu|meta|nav|promo|related|share|sidebar|social|sponsor|widget`)
+	whitespace      = regexp.MustCompile(`\s+`)
+)
+
+// skippedTags never contain article text and are dropped with their children
+var skippedTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Iframe:   true,
+	atom.Form:     true,
+	atom.Nav:      true,
+	atom.Aside:    true,
+	atom.Header:   true,
+	atom.Footer:   true,
+	atom.Svg:      true,
+	atom.Button:   true,
+}
+
+// textTags are the block elements whose text makes up the extracted article
+var textTags = map[atom.Atom]bool{
+	atom.P:          true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.Li:         true,
+	atom.Blockquote: true,
+	atom.Pre:        true,
+}
+
+// Article is what an HTML page says about the article it holds. Fields the
+// page does not give are left empty.
+type Article struct {
+	Title       string
+	Description string
+	// ImageURL is the lead image as written in the page, possibly relative
+	ImageURL string
+	Author   string
+	SiteName string
+	// Language is the lowercase primary language subtag, such as "en"
+	Language    string
+	PublishedAt time.Time
+	Tags        []string
+	// Text is the main article body as returned by Extract
+	Text string
+}
+
+// Extract parses an HTML document and returns the plain text of its main
+// article body. Candidates are scored the way Readability does: each
+// paragraph adds points for its length and commas to its parent and half
+// as much to its grandparent, weighted by class and id hints. Paragraphs
+// are separated by blank lines and no markup is returned.
+func Extract(r io.Reader) (string, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return "", err
+	}
+
+	return extractText(doc)
+}
+
+// Parse reads an HTML document's metadata and main text. Open Graph and
+// article properties take precedence over the plain <title> and meta
+// description; keywords add to the article tags. A page without readable
+// content is not an error, its Text is left empty.
+func Parse(r io.Reader) (*Article, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	article := &Article{}
+	meta := make(map[string]string)
+	var title string
+	var tags []string
+
+	var visit func(*html.Node)
+	visit = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.Html:
+				article.Language = attr(n, "lang")
+			case atom.Title:
+				if title == "" {
+					title = textOf(n)
+				}
+			case atom.Meta:
+				key := strings.ToLower(attr(n, "property"))
+				if key == "" {
+					key = strings.ToLower(attr(n, "name"))
+				}
+				if key == "" {
+					key = strings.ToLower(attr(n, "http-equiv"))
+				}
+				content := strings.TrimSpace(whitespace.ReplaceAllString(attr(n, "content"), " "))
+				if key == "" || content == "" {
+					break
+				}
+
+				switch key {
+				case "article:tag":
+					tags = append(tags, content)
+				case "keywords", "news_keywords":
+					tags = append(tags, strings.Split(content, ",")...)
+				default:
+					if _, ok := meta[key]; !ok {
+						meta[key] = content
+					}
+				}
+			}
+		}
+
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			visit(child)
+		}
+	}
+	visit(doc)
+
+	article.Title = first(meta["og:title"], meta["twitter:title"], title)
+	article.Description = first(meta["og:description"], meta["twitter:description"], meta["description"])
+	article.ImageURL = first(meta["og:image"], meta["og:image:url"], meta["twitter:image"])
+	article.Author = first(meta["author"], meta["article:author"])
+	article.SiteName = meta["og:site_name"]
+	article.Language = language(first(article.Language, meta["content-language"], meta["og:locale"]))
+	article.Tags = uniqueTags(tags)
+
+	if published := first(meta["article:published_time"], meta["og:published_time"]); published != "" {
+		if t, err := time.Parse(time.RFC3339, published); err == nil {
+			article.PublishedAt = t
+		}
+	}
+
+	if text, err := extractText(doc); err == nil {
+		article.Text = text
+	}
+
+	return article, nil
+}
+
+// extractText returns the main article body of a parsed document as
+// described on Extract
+func extractText(doc *html.Node) (string, error) {
+	scores := make(map[*html.Node]float64)
+	walk(doc, func(n *html.Node) {
+		if n.DataAtom != atom.P {
+			return
+		}
+
+		text := textOf(n)
+		if len(text) < minParagraphLength {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")) + min(float64(len(text))/100, 3)
+		if parent := n.Parent; parent != nil {
+			scores[parent] += score
+			if grandparent := parent.Parent; grandparent != nil {
+				scores[grandparent] += score / 2
+			}
+		}
+	})
+
+	var best *html.Node
+	var bestScore float64
+	for node, score := range scores {
+		score *= 1 + classWeight(node)
+		if best == nil || score > bestScore {
+			best, bestScore = node, score
+		}
+	}
+
+	if best == nil {
+		return "", ErrNoContent
+	}
+
+	var paragraphs []string
+	walk(best, func(n *html.Node) {
+		if !textTags[n.DataAtom] {
+			return
+		}
+		if text := textOf(n); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	})
+
+	if len(paragraphs) == 0 {
+		return "", ErrNoContent
+	}
+
+	return strings.Join(paragraphs, "\n\n"), nil
+}
+
+// attr returns the value of an element's attribute, or "" when it has none
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Namespace == "" && strings.EqualFold(a.Key, key) {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+
+	return ""
+}
+
+// first returns the first non-empty value
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// language reduces a language tag or locale such as "en-US" or "en_GB" to
+// its lowercase primary subtag
+func language(tag string) string {
+	tag, _, _ = strings.Cut(tag, ",")
+	tag, _, _ = strings.Cut(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+
+	return strings.ToLower(tag)
+}
+
+// uniqueTags trims tags and drops blanks and repeats ignoring case, keeping
+// the first spelling of each
+func uniqueTags(tags []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, tag)
+	}
+
+	return unique
+}
+
+// walk visits every node in document order, skipping non-content subtrees.
+// Text blocks are not descended into so nested blocks are not repeated.
+func walk(n *html.Node, visit func(*html.Node)) {
+	if n.Type == html.ElementNode {
+		if skippedTags[n.DataAtom] {
+			return
+		}
+		visit(n)
+		if textTags[n.DataAtom] {
+			return
+		}
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		walk(child, visit)
+	}
+}
+
+// textOf returns the visible text below n with whitespace collapsed
+func textOf(n *html.Node) string {
+	var b strings.Builder
+
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(n.Data)
+			b.WriteByte(' ')
+		case html.ElementNode:
+			if skippedTags[n.DataAtom] {
+				return
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(n)
+
+	return strings.TrimSpace(whitespace.ReplaceAllString(b.String(), " "))
+}
+
+// classWeight nudges a candidate up or down based on its class and id
+func classWeight(n *html.Node) float64 {
+	var weight float64
+	for _, attr := range n.Attr {
+		if attr.Key != "class" && attr.Key != "id" {
+			continue
+		}
+		if positivePattern.MatchString(attr.Val) {
+			weight += 0.25
+		}
+		if negativePattern.MatchString(attr.Val) {
+			weight -= 0.5
+		}
+	}
+
+	if n.DataAtom == atom.Article || n.DataAtom == atom.Main {
+		weight += 0.25
+	}
+
+	return weight
+}
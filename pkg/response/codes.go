@@ -0,0 +1,60 @@
+package response
+
+// ErrorCode is a stable, machine-readable identifier for an API error.
+// Clients should branch on the code; messages may change between releases.
+type ErrorCode string
+
+// Generic error codes
+const (
+	CodeBadRequest         ErrorCode = "BAD_REQUEST"
+	CodeValidationFailed   ErrorCode = "VALIDATION_FAILED"
+	CodeInvalidRequestBody ErrorCode = "INVALID_REQUEST_BODY"
+	CodeInvalidParameter   ErrorCode = "INVALID_PARAMETER"
+	CodeMissingParameter   ErrorCode = "MISSING_PARAMETER"
+	CodeForbidden          ErrorCode = "FORBIDDEN"
+	CodeNotFound           ErrorCode = "NOT_FOUND"
+	CodeMethodNotAllowed   ErrorCode = "METHOD_NOT_ALLOWED"
+	CodePayloadTooLarge    ErrorCode = "PAYLOAD_TOO_LARGE"
+	CodeRateLimited        ErrorCode = "RATE_LIMITED"
+	CodeInternal           ErrorCode = "INTERNAL_ERROR"
+	CodeTimeout            ErrorCode = "TIMEOUT"
+	CodeReadOnly           ErrorCode = "READ_ONLY"
+)
+
+// Domain error codes
+const (
+	CodeInvalidPostID         ErrorCode = "INVALID_POST_ID"
+	CodePostNotFound          ErrorCode = "POST_NOT_FOUND"
+	CodePostExists            ErrorCode = "POST_ALREADY_EXISTS"
+	CodePostVersionRequired   ErrorCode = "POST_VERSION_REQUIRED"
+	CodePostVersionConflict   ErrorCode = "POST_VERSION_CONFLICT"
+	CodeJobNotFound           ErrorCode = "JOB_NOT_FOUND"
+	CodeJobRunning            ErrorCode = "JOB_ALREADY_RUNNING"
+	CodeOnceJobNotFound       ErrorCode = "ONCE_JOB_NOT_FOUND"
+	CodeDeadJobNotFound       ErrorCode = "DEAD_JOB_NOT_FOUND"
+	CodeExperimentNotFound    ErrorCode = "EXPERIMENT_NOT_FOUND"
+	CodeVariantNotFound       ErrorCode = "EXPERIMENT_VARIANT_NOT_FOUND"
+	CodeInvalidExperiment     ErrorCode = "INVALID_EXPERIMENT"
+	CodeInvalidRankingWeights ErrorCode = "INVALID_RANKING_WEIGHTS"
+	CodeInvalidAggregation    ErrorCode = "INVALID_AGGREGATION_PARAMETERS"
+	CodeReprocessRunNotFound  ErrorCode = "REPROCESS_RUN_NOT_FOUND"
+	CodeReprocessRunning      ErrorCode = "REPROCESS_ALREADY_RUNNING"
+	CodeInvalidConfig         ErrorCode = "CONFIG_INVALID"
+	CodeInvalidCommentID      ErrorCode = "INVALID_COMMENT_ID"
+	CodeCommentNotFound       ErrorCode = "COMMENT_NOT_FOUND"
+	CodeInvalidParentComment  ErrorCode = "INVALID_PARENT_COMMENT"
+	CodeReactionNotFound      ErrorCode = "REACTION_NOT_FOUND"
+	CodeTenantNotFound        ErrorCode = "TENANT_NOT_FOUND"
+	CodeSourceBlocked         ErrorCode = "SOURCE_BLOCKED"
+	CodeSourceRuleNotFound    ErrorCode = "SOURCE_RULE_NOT_FOUND"
+	CodeInvalidSourceRule     ErrorCode = "INVALID_SOURCE_RULE"
+	CodeChangesMarkerExpired  ErrorCode = "CHANGES_MARKER_EXPIRED"
+	CodeDeadLetterNotFound    ErrorCode = "DEAD_LETTER_NOT_FOUND"
+	CodeDeadLetterUnstorable  ErrorCode = "DEAD_LETTER_UNSTORABLE"
+	CodeNewsProviderFailed    ErrorCode = "NEWS_PROVIDER_FAILED"
+	CodeArticleFetchFailed    ErrorCode = "ARTICLE_FETCH_FAILED"
+	CodeArticleUnpreviewable  ErrorCode = "ARTICLE_NOT_PREVIEWABLE"
+	CodeInvalidPin            ErrorCode = "INVALID_PIN"
+	CodePostNotPinned         ErrorCode = "POST_NOT_PINNED"
+	CodePostNotPublished      ErrorCode = "POST_NOT_PUBLISHED"
+)
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"github.com/amirzre/news-feed-system/pkg/i18n"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+)
+
+// requestLanguage negotiates the response language from Accept-Language
+func requestLanguage(c echo.Context) string {
+	return i18n.Negotiate(c.Request().Header.Get(headerAcceptLanguage))
+}
+
+// localize translates message into the request's language and marks the
+// response as varying by Accept-Language
+func localize(c echo.Context, message string) string {
+	if message == "" {
+		return ""
+	}
+
+	lang := requestLanguage(c)
+	header := c.Response().Header()
+	header.Set(headerContentLanguage, lang)
+	header.Add(echo.HeaderVary, headerAcceptLanguage)
+
+	return i18n.Translate(lang, message)
+}
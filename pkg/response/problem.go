@@ -0,0 +1,75 @@
+package response
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEApplicationProblemJSON is the RFC 7807 problem details media type
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ProblemTypeBase prefixes the error code to build the problem "type" URI
+var ProblemTypeBase = "urn:news-feed-system:error:"
+
+// ProblemDetails is an RFC 7807 error body, sent instead of APIResponse when
+// the client asks for application/problem+json
+type ProblemDetails struct {
+	Type     string    `json:"type" example:"urn:news-feed-system:error:POST_NOT_FOUND"`
+	Title    string    `json:"title" example:"Post not found"`
+	Status   int       `json:"status" example:"404"`
+	Detail   string    `json:"detail,omitempty" example:"no post with id 42"`
+	Instance string    `json:"instance,omitempty" example:"/api/v1/posts/42"`
+	Code     ErrorCode `json:"code" example:"POST_NOT_FOUND"`
+}
+
+// newProblem maps an error response onto problem details
+func newProblem(c echo.Context, statusCode int, info *ErrorInfo) *ProblemDetails {
+	problemType := "about:blank"
+	if info.Code != "" {
+		problemType = ProblemTypeBase + string(info.Code)
+	}
+
+	return &ProblemDetails{
+		Type:     problemType,
+		Title:    info.Message,
+		Status:   statusCode,
+		Detail:   info.Details,
+		Instance: c.Request().URL.Path,
+		Code:     info.Code,
+	}
+}
+
+// prefersProblem reports whether the Accept header asks for problem details
+func prefersProblem(c echo.Context) bool {
+	accept := c.Request().Header.Get(echo.HeaderAccept)
+	if accept == "" {
+		return false
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != MIMEApplicationProblemJSON {
+			continue
+		}
+
+		if q, ok := params["q"]; ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// writeProblem renders problem details with the problem+json content type
+func writeProblem(c echo.Context, problem *ProblemDetails) error {
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	c.Response().WriteHeader(problem.Status)
+	return c.Echo().JSONSerializer.Serialize(c, problem, "")
+}
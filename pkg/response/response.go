@@ -1,8 +1,11 @@
 package response
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/amirzre/news-feed-system/pkg/validator"
 	"github.com/labstack/echo/v4"
 )
 
@@ -16,8 +19,9 @@ type APIResponse struct {
 
 // ErrorInfo contains detailed error information
 type ErrorInfo struct {
-	Message string `json:"message" example:"Validation failed"`
-	Details string `json:"details,omitempty" example:"title is required"`
+	Code    ErrorCode `json:"code" example:"VALIDATION_FAILED"`
+	Message string    `json:"message" example:"Validation failed"`
+	Details string    `json:"details,omitempty" example:"title is required"`
 }
 
 // PaginatedResponse wraps paginated data
@@ -25,6 +29,31 @@ type PaginatedResponse struct {
 	Items      any               `json:"items"`
 	Pagination *PaginationInfo   `json:"pagination"`
 	Filters    map[string]string `json:"filters,omitempty"`
+	Meta       map[string]any    `json:"meta,omitempty"`
+	Query      *QueryInfo        `json:"query,omitempty"`
+}
+
+// QueryInfo echoes how a list request was understood and served, so that
+// clients can tell why a list looks the way it does or was slow
+type QueryInfo struct {
+	Filters  map[string]string `json:"filters,omitempty"`
+	Sort     string            `json:"sort" example:"latest"`
+	TookMs   int64             `json:"took_ms" example:"12"`
+	CacheHit bool              `json:"cache_hit" example:"true"`
+}
+
+// queryInfoKey is the echo context key of the QueryInfo of a list response
+const queryInfoKey = "response.query_info"
+
+// SetQueryInfo attaches info to the paginated response c is about to send
+func SetQueryInfo(c echo.Context, info *QueryInfo) {
+	c.Set(queryInfoKey, info)
+}
+
+// queryInfo returns the QueryInfo attached to c, if any
+func queryInfo(c echo.Context) *QueryInfo {
+	info, _ := c.Get(queryInfoKey).(*QueryInfo)
+	return info
 }
 
 // PaginationInfo contains pagination metadata
@@ -35,13 +64,16 @@ type PaginationInfo struct {
 	TotalPages int  `json:"total_pages" example:"13"`
 	HasNext    bool `json:"has_next" example:"true"`
 	HasPrev    bool `json:"has_prev" example:"false"`
+	// TotalMode is set when total is not an exact count: "none" when it is a
+	// lower bound, "estimate" when it is the query planner's estimate
+	TotalMode string `json:"total_mode,omitempty" example:"estimate"`
 }
 
 // Success returns a successful response
 func Success(c echo.Context, statusCode int, data any, message ...string) error {
 	msg := ""
 	if len(message) > 0 {
-		msg = message[0]
+		msg = localize(c, message[0])
 	}
 
 	response := APIResponse{
@@ -57,13 +89,38 @@ func Success(c echo.Context, statusCode int, data any, message ...string) error
 func SuccessWithPagination(c echo.Context, items any, pagination *PaginationInfo, filters map[string]string, message ...string) error {
 	msg := ""
 	if len(message) > 0 {
-		msg = message[0]
+		msg = localize(c, message[0])
 	}
 
 	data := PaginatedResponse{
 		Items:      items,
 		Pagination: pagination,
 		Filters:    filters,
+		Query:      queryInfo(c),
+	}
+
+	response := APIResponse{
+		Success: true,
+		Data:    data,
+		Message: msg,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// SuccessWithPaginationAndMeta returns a successful paginated response carrying extra metadata
+func SuccessWithPaginationAndMeta(c echo.Context, items any, pagination *PaginationInfo, filters map[string]string, meta map[string]any, message ...string) error {
+	msg := ""
+	if len(message) > 0 {
+		msg = localize(c, message[0])
+	}
+
+	data := PaginatedResponse{
+		Items:      items,
+		Pagination: pagination,
+		Filters:    filters,
+		Meta:       meta,
+		Query:      queryInfo(c),
 	}
 
 	response := APIResponse{
@@ -76,17 +133,22 @@ func SuccessWithPagination(c echo.Context, items any, pagination *PaginationInfo
 }
 
 // Error returns an error response
-func Error(c echo.Context, statusCode int, message string, details ...string) error {
+func Error(c echo.Context, statusCode int, code ErrorCode, message string, details ...string) error {
 	detail := ""
 	if len(details) > 0 {
 		detail = details[0]
 	}
 
 	errorInfo := &ErrorInfo{
-		Message: message,
+		Code:    code,
+		Message: localize(c, message),
 		Details: detail,
 	}
 
+	if prefersProblem(c) {
+		return writeProblem(c, newProblem(c, statusCode, errorInfo))
+	}
+
 	response := APIResponse{
 		Success: false,
 		Error:   errorInfo,
@@ -97,36 +159,103 @@ func Error(c echo.Context, statusCode int, message string, details ...string) er
 
 // InternalServerError returns a 500 error response
 func InternalServerError(c echo.Context, message string, details ...string) error {
-	return Error(c, http.StatusInternalServerError, message, details...)
+	return Error(c, http.StatusInternalServerError, CodeInternal, message, details...)
+}
+
+// GatewayTimeout returns a 504 error response
+func GatewayTimeout(c echo.Context, message string, details ...string) error {
+	return Error(c, http.StatusGatewayTimeout, CodeTimeout, message, details...)
 }
 
 // BadRequest returns a 400 error response
-func BadRequest(c echo.Context, message string, details ...string) error {
-	return Error(c, http.StatusBadRequest, message, details...)
+func BadRequest(c echo.Context, code ErrorCode, message string, details ...string) error {
+	return Error(c, http.StatusBadRequest, code, message, details...)
 }
 
 // NotFound returns a 404 error response
-func NotFound(c echo.Context, message string, details ...string) error {
-	return Error(c, http.StatusNotFound, message, details...)
+func NotFound(c echo.Context, code ErrorCode, message string, details ...string) error {
+	return Error(c, http.StatusNotFound, code, message, details...)
 }
 
 // Conflict returns a 409 error response
-func Conflict(c echo.Context, message string, details ...string) error {
-	return Error(c, http.StatusConflict, message, details...)
+func Conflict(c echo.Context, code ErrorCode, message string, details ...string) error {
+	return Error(c, http.StatusConflict, code, message, details...)
+}
+
+// PreconditionFailed returns a 412 error response
+func PreconditionFailed(c echo.Context, code ErrorCode, message string, details ...string) error {
+	return Error(c, http.StatusPreconditionFailed, code, message, details...)
+}
+
+// PreconditionRequired returns a 428 error response
+func PreconditionRequired(c echo.Context, code ErrorCode, message string, details ...string) error {
+	return Error(c, http.StatusPreconditionRequired, code, message, details...)
 }
 
+// ValidationError returns a 400 error response for a failed struct validation,
+// with field messages in the request's language
 func ValidationError(c echo.Context, err error) error {
-	errorInfo := &ErrorInfo{
-		Message: "Request validation failed",
-		Details: err.Error(),
+	details := err.Error()
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		details = validationErrors.Localize(requestLanguage(c)).Error()
 	}
 
-	response := APIResponse{
-		Success: false,
-		Error:   errorInfo,
+	return Error(c, http.StatusBadRequest, CodeValidationFailed, "Request validation failed", details)
+}
+
+// HTTPErrorHandler renders errors returned by Echo itself (unknown routes,
+// disallowed methods, middleware rejections) in the standard error format
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	message := http.StatusText(status)
+
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		status = he.Code
+		message = fmt.Sprint(he.Message)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = Error(c, status, codeForStatus(status), message)
+	}
+
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+// codeForStatus picks the generic error code for an HTTP status
+func codeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
 	}
 
-	return c.JSON(http.StatusBadRequest, response)
+	return CodeBadRequest
 }
 
 // CreatePaginationInfo creates pagination metadata
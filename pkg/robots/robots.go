@@ -0,0 +1,169 @@
+package robots
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rules holds the robots.txt directives that apply to one user agent
+type Rules struct {
+	allow      []string
+	disallow   []string
+	CrawlDelay time.Duration
+}
+
+// AllowAll is the rule set used when a site has no robots.txt
+var AllowAll = &Rules{}
+
+// DisallowAll is the rule set used when robots.txt cannot be retrieved
+var DisallowAll = &Rules{disallow: []string{"/"}}
+
+type group struct {
+	agents []string
+	rules  Rules
+}
+
+// Parse reads a robots.txt document and returns the rules for userAgent.
+// The most specific matching User-agent group wins and "*" is the fallback.
+func Parse(r io.Reader, userAgent string) (*Rules, error) {
+	var groups []*group
+	var current *group
+	inAgents := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				current = &group{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			inAgents = true
+		case "allow", "disallow", "crawl-delay":
+			inAgents = false
+			if current == nil {
+				continue
+			}
+			switch key {
+			case "allow":
+				if value != "" {
+					current.rules.allow = append(current.rules.allow, value)
+				}
+			case "disallow":
+				if value != "" {
+					current.rules.disallow = append(current.rules.disallow, value)
+				}
+			case "crawl-delay":
+				if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+					current.rules.CrawlDelay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return match(groups, userAgent), nil
+}
+
+// match picks the group whose agent token is the longest prefix of userAgent
+func match(groups []*group, userAgent string) *Rules {
+	product := strings.ToLower(userAgent)
+	if i := strings.IndexAny(product, "/ "); i >= 0 {
+		product = product[:i]
+	}
+
+	var best *group
+	bestLen := -1
+	for _, g := range groups {
+		for _, agent := range g.agents {
+			length := -1
+			switch {
+			case agent == "*":
+				length = 0
+			case agent != "" && strings.HasPrefix(product, agent):
+				length = len(agent)
+			}
+			if length > bestLen {
+				best, bestLen = g, length
+			}
+		}
+	}
+
+	if best == nil {
+		return AllowAll
+	}
+
+	return &best.rules
+}
+
+// Allowed reports whether path may be crawled. The longest matching rule
+// decides and Allow wins a tie, as in RFC 9309.
+func (r *Rules) Allowed(path string) bool {
+	if path == "" {
+		path = "/"
+	}
+
+	allowLen := longestMatch(r.allow, path)
+	disallowLen := longestMatch(r.disallow, path)
+
+	return disallowLen < 0 || allowLen >= disallowLen
+}
+
+// longestMatch returns the length of the longest pattern matching path, or -1
+func longestMatch(patterns []string, path string) int {
+	longest := -1
+	for _, pattern := range patterns {
+		if len(pattern) > longest && matches(pattern, path) {
+			longest = len(pattern)
+		}
+	}
+	return longest
+}
+
+// matches applies a robots.txt path pattern supporting "*" and a trailing "$"
+func matches(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+
+	if len(parts) == 1 {
+		return !anchored || rest == ""
+	}
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+
+	last := parts[len(parts)-1]
+	if anchored {
+		return strings.HasSuffix(rest, last)
+	}
+	return strings.Contains(rest, last)
+}
@@ -0,0 +1,44 @@
+package tenant
+
+import (
+	"context"
+	"regexp"
+)
+
+// Default is the tenant used when a request or job names none
+const Default = "default"
+
+// idPattern matches tenant IDs: lowercase slugs usable as a subdomain
+var idPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,48}[a-z0-9])?$`)
+
+type contextKey struct{}
+
+// WithTenant returns a copy of ctx scoped to the given tenant
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant ctx is scoped to, or Default
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+		return id
+	}
+
+	return Default
+}
+
+// ValidID reports whether id is a well-formed tenant ID
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+// Key prefixes a cache key with the tenant ctx is scoped to. Keys of the
+// default tenant are left unchanged so single-tenant deployments keep theirs.
+func Key(ctx context.Context, key string) string {
+	id := FromContext(ctx)
+	if id == Default {
+		return key
+	}
+
+	return "tenant:" + id + ":" + key
+}
@@ -0,0 +1,90 @@
+package textnorm
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// sourceSeparators join a headline to the name of the outlet that
+// published it, as in "Markets rally - Reuters"
+var sourceSeparators = []string{" - ", " – ", " — ", " | ", " : "}
+
+// Normalize cleans up text from a news provider: HTML entities are decoded,
+// the text is put in Unicode normalization form C, control and zero-width
+// characters are removed and runs of whitespace, line breaks included,
+// collapse into a single space. The result is trimmed.
+func Normalize(s string) string {
+	s = norm.NFC.String(html.UnescapeString(s))
+
+	var b strings.Builder
+	b.Grow(len(s))
+
+	space := false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case unicode.IsControl(r), invisible(r):
+			continue
+		}
+
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// NormalizePtr normalizes *s, passing nil through
+func NormalizePtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	normalized := Normalize(*s)
+	return &normalized
+}
+
+// Title normalizes a headline and removes a trailing " - Source" naming
+// source, the outlet that published it. The suffix must match source
+// ignoring case and is kept when nothing else would remain.
+func Title(title, source string) string {
+	title = Normalize(title)
+	source = Normalize(source)
+	if source == "" {
+		return title
+	}
+
+	for _, separator := range sourceSeparators {
+		cut := len(title) - len(separator) - len(source)
+		if cut <= 0 || title[cut:cut+len(separator)] != separator {
+			continue
+		}
+
+		if strings.EqualFold(title[cut+len(separator):], source) {
+			return strings.TrimSpace(title[:cut])
+		}
+	}
+
+	return title
+}
+
+// invisible reports whether r renders as nothing and only gets in the way of
+// matching: zero-width spaces, word joiners, byte order marks and soft
+// hyphens. Zero-width joiners and non-joiners are kept, as emoji sequences
+// and scripts such as Persian need them.
+func invisible(r rune) bool {
+	switch r {
+	case '\u200b', '\u2060', '\ufeff', '\u00ad':
+		return true
+	}
+
+	return false
+}
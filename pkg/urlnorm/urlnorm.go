@@ -0,0 +1,93 @@
+package urlnorm
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that only identify the campaign or
+// click that led to a page, never the page itself
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"gbraid":  true,
+	"wbraid":  true,
+	"msclkid": true,
+	"yclid":   true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"_ga":     true,
+	"_gl":     true,
+	"_hsenc":  true,
+	"_hsmi":   true,
+	"mkt_tok": true,
+}
+
+// defaultPorts are dropped from hosts as they name the scheme's own port
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Normalize returns the canonical form of an article URL, so that links to
+// the same page compare equal: the scheme and host are lowercased, default
+// ports, fragments and tracking parameters (utm_* and click identifiers such
+// as fbclid) are dropped, the remaining parameters are sorted and a trailing
+// slash is removed from the path. Anything but an absolute http or https URL
+// is returned trimmed but otherwise unchanged.
+func Normalize(raw string) string {
+	raw = strings.TrimSpace(raw)
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.Opaque != "" {
+		return raw
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if _, ok := defaultPorts[u.Scheme]; !ok {
+		return raw
+	}
+
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	switch {
+	case port != "" && port != defaultPorts[u.Scheme]:
+		u.Host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		u.Host = "[" + host + "]"
+	default:
+		u.Host = host
+	}
+
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for name := range query {
+			if IsTrackingParam(name) {
+				query.Del(name)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	u.ForceQuery = false
+
+	if u.Path == "/" || u.Path == "" {
+		u.Path = ""
+		u.RawPath = ""
+	} else if strings.HasSuffix(u.Path, "/") {
+		u.Path = strings.TrimRight(u.Path, "/")
+		u.RawPath = strings.TrimRight(u.RawPath, "/")
+	}
+
+	return u.String()
+}
+
+// IsTrackingParam reports whether a query parameter only tracks the visit
+func IsTrackingParam(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasPrefix(name, "utm_") || trackingParams[name]
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/amirzre/news-feed-system/pkg/i18n"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -19,6 +20,9 @@ type ValidationError struct {
 	Tag     string `json:"tag"`
 	Value   string `json:"value"`
 	Message string `json:"message"`
+
+	param string
+	kind  reflect.Kind
 }
 
 // ValidationErrors represents multiple validation errors
@@ -35,6 +39,16 @@ func (ve ValidationErrors) Error() string {
 	return strings.Join(messages, "; ")
 }
 
+// Localize returns a copy of the errors with their messages in lang
+func (ve ValidationErrors) Localize(lang string) ValidationErrors {
+	localized := make([]ValidationError, len(ve.Errors))
+	for i, err := range ve.Errors {
+		err.Message = errorMessage(lang, err)
+		localized[i] = err
+	}
+	return ValidationErrors{Errors: localized}
+}
+
 // NewValidator creates a new custom validator instance
 func NewValidator() *CustomValidator {
 	v := validator.New()
@@ -63,91 +77,98 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 
 	if errs, ok := err.(validator.ValidationErrors); ok {
 		for _, err := range errs {
-			validationErrors = append(validationErrors, ValidationError{
-				Field:   err.Field(),
-				Tag:     err.Tag(),
-				Value:   fmt.Sprintf("%v", err.Value()),
-				Message: getErrorMessage(err),
-			})
+			validationError := ValidationError{
+				Field: err.Field(),
+				Tag:   err.Tag(),
+				Value: fmt.Sprintf("%v", err.Value()),
+				param: err.Param(),
+				kind:  err.Kind(),
+			}
+			validationError.Message = errorMessage(i18n.Default, validationError)
+			validationErrors = append(validationErrors, validationError)
 		}
 	}
 
 	return ValidationErrors{Errors: validationErrors}
 }
 
-// getErrorMessage returns a human-readable error message based on the validation tag
-func getErrorMessage(err validator.FieldError) string {
-	field := err.Field()
-	tag := err.Tag()
-	param := err.Param()
-	value := fmt.Sprintf("%v", err.Value())
+// errorMessage returns a human-readable error message in lang based on the validation tag
+func errorMessage(lang string, err ValidationError) string {
+	field := err.Field
+	tag := err.Tag
+	param := err.param
+	value := err.Value
+
+	sprintf := func(format string, args ...any) string {
+		return fmt.Sprintf(i18n.Translate(lang, format), args...)
+	}
 
 	switch tag {
 	case "required":
-		return fmt.Sprintf("%s is required", field)
+		return sprintf("%s is required", field)
 	case "email":
-		return fmt.Sprintf("%s must be a valid email address", field)
+		return sprintf("%s must be a valid email address", field)
 	case "min":
-		if err.Kind() == reflect.String {
-			return fmt.Sprintf("%s must be at least %s characters long", field, param)
+		if err.kind == reflect.String {
+			return sprintf("%s must be at least %s characters long", field, param)
 		}
-		return fmt.Sprintf("%s must be at least %s", field, param)
+		return sprintf("%s must be at least %s", field, param)
 	case "max":
-		if err.Kind() == reflect.String {
-			return fmt.Sprintf("%s must not exceed %s characters", field, param)
+		if err.kind == reflect.String {
+			return sprintf("%s must not exceed %s characters", field, param)
 		}
-		return fmt.Sprintf("%s must not exceed %s", field, param)
+		return sprintf("%s must not exceed %s", field, param)
 	case "len":
-		return fmt.Sprintf("%s must be exactly %s characters long", field, param)
+		return sprintf("%s must be exactly %s characters long", field, param)
 	case "gte":
-		return fmt.Sprintf("%s must be greater than or equal to %s", field, param)
+		return sprintf("%s must be greater than or equal to %s", field, param)
 	case "lte":
-		return fmt.Sprintf("%s must be less than or equal to %s", field, param)
+		return sprintf("%s must be less than or equal to %s", field, param)
 	case "gt":
-		return fmt.Sprintf("%s must be greater than %s", field, param)
+		return sprintf("%s must be greater than %s", field, param)
 	case "lt":
-		return fmt.Sprintf("%s must be less than %s", field, param)
+		return sprintf("%s must be less than %s", field, param)
 	case "alpha":
-		return fmt.Sprintf("%s must contain only alphabetic characters", field)
+		return sprintf("%s must contain only alphabetic characters", field)
 	case "alphanum":
-		return fmt.Sprintf("%s must contain only alphanumeric characters", field)
+		return sprintf("%s must contain only alphanumeric characters", field)
 	case "numeric":
-		return fmt.Sprintf("%s must be a valid number", field)
-	case "url":
-		return fmt.Sprintf("%s must be a valid URL", field)
+		return sprintf("%s must be a valid number", field)
+	case "url", "http_url":
+		return sprintf("%s must be a valid URL", field)
 	case "uri":
-		return fmt.Sprintf("%s must be a valid URI", field)
+		return sprintf("%s must be a valid URI", field)
 	case "oneof":
-		return fmt.Sprintf("%s must be one of [%s]", field, param)
+		return sprintf("%s must be one of [%s]", field, param)
 	case "unique":
-		return fmt.Sprintf("%s must contain unique values", field)
+		return sprintf("%s must contain unique values", field)
 	case "dive":
-		return fmt.Sprintf("%s contains invalid nested values", field)
+		return sprintf("%s contains invalid nested values", field)
 	case "uuid":
-		return fmt.Sprintf("%s must be a valid UUID", field)
+		return sprintf("%s must be a valid UUID", field)
 	case "uuid4":
-		return fmt.Sprintf("%s must be a valid UUID v4", field)
+		return sprintf("%s must be a valid UUID v4", field)
 	case "json":
-		return fmt.Sprintf("%s must be valid JSON", field)
+		return sprintf("%s must be valid JSON", field)
 	case "datetime":
-		return fmt.Sprintf("%s must be a valid datetime in format %s", field, param)
+		return sprintf("%s must be a valid datetime in format %s", field, param)
 	case "eqfield":
-		return fmt.Sprintf("%s must equal %s", field, param)
+		return sprintf("%s must equal %s", field, param)
 	case "nefield":
-		return fmt.Sprintf("%s must not equal %s", field, param)
+		return sprintf("%s must not equal %s", field, param)
 	case "containsany":
-		return fmt.Sprintf("%s must contain at least one of the following characters: %s", field, param)
+		return sprintf("%s must contain at least one of the following characters: %s", field, param)
 	case "excludesall":
-		return fmt.Sprintf("%s cannot contain any of the following characters: %s", field, param)
+		return sprintf("%s cannot contain any of the following characters: %s", field, param)
 	case "startswith":
-		return fmt.Sprintf("%s must start with '%s'", field, param)
+		return sprintf("%s must start with '%s'", field, param)
 	case "endswith":
-		return fmt.Sprintf("%s must end with '%s'", field, param)
+		return sprintf("%s must end with '%s'", field, param)
 	default:
 		// Fallback for unknown tags
 		if param != "" {
-			return fmt.Sprintf("%s failed validation for tag '%s' with parameter '%s' (current value: '%s')", field, tag, param, value)
+			return sprintf("%s failed validation for tag '%s' with parameter '%s' (current value: '%s')", field, tag, param, value)
 		}
-		return fmt.Sprintf("%s failed validation for tag '%s' (current value: '%s')", field, tag, value)
+		return sprintf("%s failed validation for tag '%s' (current value: '%s')", field, tag, value)
 	}
 }
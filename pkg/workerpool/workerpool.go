@@ -0,0 +1,82 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when submitting to a pool that has been closed
+var ErrClosed = errors.New("worker pool is closed")
+
+// Pool runs tasks on a fixed number of goroutines fed by a bounded queue.
+// Submit blocks while the queue is full, so producers slow down to the pace
+// of the workers instead of piling up work.
+type Pool struct {
+	mu     sync.RWMutex
+	tasks  chan func()
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// New starts a pool of size workers with room for queueSize waiting tasks
+func New(size, queueSize int) *Pool {
+	if size <= 0 {
+		size = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	p := &Pool{tasks: make(chan func(), queueSize)}
+
+	p.wg.Add(size)
+	for range size {
+		go p.work()
+	}
+
+	return p
+}
+
+// Submit queues a task, waiting for room while the queue is full. It returns
+// ctx's error if ctx is done before the task is queued, in which case the
+// task never runs.
+func (p *Pool) Submit(ctx context.Context, task func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrClosed
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting tasks and waits for the queued ones to finish
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for task := range p.tasks {
+		task()
+	}
+}